@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend.
+	DefaultDomain = "dev.azure.com"
+	// DefaultIdentityURL is the URL the identity APIs of the DefaultDomain are served at.
+	DefaultIdentityURL = "https://vssps.dev.azure.com"
+	// TokenVariable is the common name for the environment variable
+	// containing an Azure DevOps personal access token.
+	TokenVariable = "AZURE_DEVOPS_TOKEN" // #nosec G101
+)
+
+// NewClient creates a new gitprovider.Client instance for Azure DevOps API endpoints.
+//
+// Using WithPersonalAccessToken you can specify a personal access token (PAT) for authentication.
+// Microsoft Entra ID access tokens can be used with WithOAuth2Token instead. Passing no such
+// ClientOption will allow public read access only.
+//
+// Azure DevOps Server instances can be used if you specify the domain using WithDomain, e.g.
+// "my-server.com/tfs". Project collections are then used as top-level organizations.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options,
+// e.g. WithCustomCAPostChainTransportHook for instances using a private certificate authority.
+// You can also use conditional requests (and an in-memory cache) using WithConditionalRequests.
+//
+// The chain of transports looks like this:
+// Azure DevOps API <-> "Post Chain" <-> Authentication <-> Cache <-> "Pre Chain" <-> *http.Client.
+func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	if err != nil {
+		return nil, err
+	}
+
+	domain := DefaultDomain
+	baseURL := gitprovider.GetDomainURL(DefaultDomain)
+	identityBaseURL := DefaultIdentityURL
+	if opts.Domain != nil && *opts.Domain != DefaultDomain {
+		domain = *opts.Domain
+		// Azure DevOps Server serves the identity APIs on the same host
+		baseURL = gitprovider.GetDomainURL(domain)
+		identityBaseURL = baseURL
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(httpClient, baseURL, identityBaseURL, domain, destructiveActions), nil
+}
@@ -0,0 +1,907 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	apiVersion = "7.0"
+	// gitRepositoriesSecurityNamespace is the ID of the "Git Repositories" security namespace.
+	gitRepositoriesSecurityNamespace = "2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87"
+	// listPageSize is the amount of objects requested per page when listing.
+	listPageSize = 100
+)
+
+// azureClient is a wrapper around the Azure DevOps REST API, which implements higher-level
+// methods, operating on the API structs of this package. Pagination is implemented for all List* methods,
+// all returned objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
+// This interface is also fakeable, in order to unit-test the client.
+type azureClient interface {
+	// Client returns the underlying *http.Client
+	Client() *http.Client
+
+	// GetConnectionData is a wrapper for "GET /{organization}/_apis/connectionData".
+	// This function handles HTTP error wrapping.
+	GetConnectionData(ctx context.Context, org string) (*ConnectionData, error)
+
+	// GetProject is a wrapper for "GET /{organization}/_apis/projects/{projectId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProject(ctx context.Context, org, project string) (*Project, error)
+	// ListProjects is a wrapper for "GET /{organization}/_apis/projects".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProjects(ctx context.Context, org string) ([]*Project, error)
+
+	// GetTeam is a wrapper for "GET /{organization}/_apis/projects/{projectId}/teams/{teamId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetTeam(ctx context.Context, org, project, team string) (*Team, error)
+	// ListTeams is a wrapper for "GET /{organization}/_apis/projects/{projectId}/teams".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListTeams(ctx context.Context, org, project string) ([]*Team, error)
+	// ListTeamMembers is a wrapper for "GET /{organization}/_apis/projects/{projectId}/teams/{teamId}/members".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListTeamMembers(ctx context.Context, org, project, team string) ([]*TeamMember, error)
+
+	// GetRepo is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, org, project, repo string) (*Repository, error)
+	// ListRepos is a wrapper for "GET /{organization}/{project}/_apis/git/repositories".
+	// If project is empty, the repositories of all projects are listed.
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListRepos(ctx context.Context, org, project string) ([]*Repository, error)
+	// CreateRepo is a wrapper for "POST /{organization}/{project}/_apis/git/repositories".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, org, project string, req *Repository) (*Repository, error)
+	// UpdateRepo is a wrapper for "PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, org, project, repoID string, req *Repository) (*Repository, error)
+	// DeleteRepo is a wrapper for "DELETE /{organization}/{project}/_apis/git/repositories/{repositoryId}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, org, project, repoID string) error
+
+	// GetGroupIdentity is a wrapper for "GET /{organization}/_apis/identities?searchFilter=General",
+	// returning the security group called "[{project}]\{group}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetGroupIdentity(ctx context.Context, org, project, group string) (*Identity, error)
+	// GetUserIdentity is a wrapper for "GET /{organization}/_apis/identities?searchFilter=General",
+	// returning the user with the given account name, e.g. "alice@example.com".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUserIdentity(ctx context.Context, org, login string) (*Identity, error)
+	// ListIdentities is a wrapper for "GET /{organization}/_apis/identities?descriptors={descriptors}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListIdentities(ctx context.Context, org string, descriptors []string) ([]*Identity, error)
+
+	// GetAccessControlList is a wrapper for "GET /{organization}/_apis/accesscontrollists/{securityNamespaceId}",
+	// returning the access control list of the Git Repositories security namespace for the given token.
+	// If descriptors are given, only the entries of those identities are returned.
+	// This function handles HTTP error wrapping.
+	GetAccessControlList(ctx context.Context, org, token string, descriptors ...string) (*AccessControlList, error)
+	// SetAccessControlEntry is a wrapper for "POST /{organization}/_apis/accesscontrolentries/{securityNamespaceId}",
+	// replacing the entry of the identity for the given token in the Git Repositories security namespace.
+	// This function handles HTTP error wrapping.
+	SetAccessControlEntry(ctx context.Context, org, token string, req *AccessControlEntry) (*AccessControlEntry, error)
+	// RemoveAccessControlEntry is a wrapper for "DELETE /{organization}/_apis/accesscontrolentries/{securityNamespaceId}",
+	// removing the entry of the identity for the given token in the Git Repositories security namespace.
+	// This function handles HTTP error wrapping.
+	RemoveAccessControlEntry(ctx context.Context, org, token, descriptor string) error
+
+	// ListCommitsPage is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/commits".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListCommitsPage(ctx context.Context, org, project, repo, branch string, perPage, page int) ([]*Commit, error)
+	// CreatePush is a wrapper for "POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pushes".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreatePush(ctx context.Context, org, project, repo string, req *Push) (*Push, error)
+
+	// ListBranchRefs is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/".
+	// All branches are returned in a single response, as no page size is given.
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListBranchRefs(ctx context.Context, org, project, repo string) ([]*Ref, error)
+	// GetBranchRef is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/{branch}".
+	// ErrNotFound is returned if the branch doesn't exist.
+	// This function handles HTTP error wrapping.
+	GetBranchRef(ctx context.Context, org, project, repo, branch string) (*Ref, error)
+	// UpdateRefs is a wrapper for "POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs".
+	// This function handles HTTP error wrapping.
+	UpdateRefs(ctx context.Context, org, project, repo string, req []*RefUpdate) ([]*RefUpdateResult, error)
+
+	// ListPullRequests is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListPullRequests(ctx context.Context, org, project, repo string, criteria PullRequestSearchCriteria) ([]*PullRequest, error)
+	// GetPullRequest is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetPullRequest(ctx context.Context, org, project, repo string, id int) (*PullRequest, error)
+	// CreatePullRequest is a wrapper for "POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreatePullRequest(ctx context.Context, org, project, repo string, req *PullRequest) (*PullRequest, error)
+	// UpdatePullRequest is a wrapper for "PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdatePullRequest(ctx context.Context, org, project, repo string, id int, req *PullRequest) (*PullRequest, error)
+
+	// ListItems is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?scopePath={path}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListItems(ctx context.Context, org, project, repo, branch, scopePath string, recursive bool) ([]*Item, error)
+	// GetItemContent is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?path={path}".
+	// This function handles HTTP error wrapping.
+	GetItemContent(ctx context.Context, org, project, repo, branch, filePath string) ([]byte, error)
+
+	// GetTree is a wrapper for "GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/trees/{sha1}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetTree(ctx context.Context, org, project, repo, sha string, recursive bool) (*Tree, error)
+}
+
+// azureClientImpl is a wrapper around the *http.Client built from the transport chain, which implements
+// higher-level methods, operating on the API structs of this package. See the azureClient interface for
+// method documentation. Authentication is done by the transport chain, e.g. using WithPersonalAccessToken.
+type azureClientImpl struct {
+	c *http.Client
+	// baseURL is the URL organizations are served at, e.g. "https://dev.azure.com".
+	baseURL string
+	// identityBaseURL is the URL the identity APIs of organizations are served at.
+	// For Azure DevOps Services, this is "https://vssps.dev.azure.com", for Azure DevOps Server, it's baseURL.
+	identityBaseURL    string
+	destructiveActions bool
+}
+
+// azureClientImpl implements azureClient.
+var _ azureClient = &azureClientImpl{}
+
+func (c *azureClientImpl) Client() *http.Client {
+	return c.c
+}
+
+func (c *azureClientImpl) GetConnectionData(ctx context.Context, org string) (*ConnectionData, error) {
+	apiObj := &ConnectionData{}
+	// GET /{organization}/_apis/connectionData
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, org, "_apis", "connectionData"), nil, apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureClientImpl) GetProject(ctx context.Context, org, project string) (*Project, error) {
+	apiObj := &Project{}
+	// GET /{organization}/_apis/projects/{projectId}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, org, "_apis", "projects", project), nil, apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureClientImpl) ListProjects(ctx context.Context, org string) ([]*Project, error) {
+	apiObjs := []*Project{}
+	// GET /{organization}/_apis/projects
+	err := c.allPages(ctx, nil, []string{org, "_apis", "projects"}, func(values json.RawMessage) error {
+		pageObjs := []*Project{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateProjectAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) GetTeam(ctx context.Context, org, project, team string) (*Team, error) {
+	apiObj := &Team{}
+	// GET /{organization}/_apis/projects/{projectId}/teams/{teamId}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, org, "_apis", "projects", project, "teams", team), nil, apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateTeamAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureClientImpl) ListTeams(ctx context.Context, org, project string) ([]*Team, error) {
+	apiObjs := []*Team{}
+	// GET /{organization}/_apis/projects/{projectId}/teams
+	err := c.allPages(ctx, nil, []string{org, "_apis", "projects", project, "teams"}, func(values json.RawMessage) error {
+		pageObjs := []*Team{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateTeamAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) ListTeamMembers(ctx context.Context, org, project, team string) ([]*TeamMember, error) {
+	apiObjs := []*TeamMember{}
+	// GET /{organization}/_apis/projects/{projectId}/teams/{teamId}/members
+	err := c.allPages(ctx, nil, []string{org, "_apis", "projects", project, "teams", team, "members"}, func(values json.RawMessage) error {
+		pageObjs := []*TeamMember{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateTeamMemberAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) GetRepo(ctx context.Context, org, project, repo string) (*Repository, error) {
+	apiObj := &Repository{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, org, project, "_apis", "git", "repositories", repo), nil, apiObj); err != nil {
+		return nil, err
+	}
+	return validateRepositoryAPIResp(apiObj)
+}
+
+func validateRepositoryAPIResp(apiObj *Repository) (*Repository, error) {
+	// Validate the API object
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureClientImpl) ListRepos(ctx context.Context, org, project string) ([]*Repository, error) {
+	res := &listResponse{}
+	// GET /{organization}/{project}/_apis/git/repositories
+	// This endpoint isn't paginated, all repositories are returned at once.
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, org, project, "_apis", "git", "repositories"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Repository{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) CreateRepo(ctx context.Context, org, project string, req *Repository) (*Repository, error) {
+	apiObj := &Repository{}
+	// POST /{organization}/{project}/_apis/git/repositories
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, org, project, "_apis", "git", "repositories"), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validateRepositoryAPIResp(apiObj)
+}
+
+func (c *azureClientImpl) UpdateRepo(ctx context.Context, org, project, repoID string, req *Repository) (*Repository, error) {
+	apiObj := &Repository{}
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	if _, err := c.doJSON(ctx, http.MethodPatch, c.url(nil, org, project, "_apis", "git", "repositories", repoID), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validateRepositoryAPIResp(apiObj)
+}
+
+func (c *azureClientImpl) DeleteRepo(ctx context.Context, org, project, repoID string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	_, err := c.do(ctx, http.MethodDelete, c.url(nil, org, project, "_apis", "git", "repositories", repoID), nil, nil)
+	return err
+}
+
+func (c *azureClientImpl) GetGroupIdentity(ctx context.Context, org, project, group string) (*Identity, error) {
+	query := url.Values{}
+	query.Set("searchFilter", "General")
+	query.Set("filterValue", fmt.Sprintf(`[%s]\%s`, project, group))
+	query.Set("queryMembership", "None")
+
+	res := &listResponse{}
+	// GET /{organization}/_apis/identities?searchFilter=General&filterValue=[{project}]\{group}
+	if _, err := c.do(ctx, http.MethodGet, c.identityURL(query, org, "_apis", "identities"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Identity{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		// Only security groups can be given access to repositories through this package
+		if !apiObj.IsContainer {
+			continue
+		}
+		// Validate the API object
+		if err := validateIdentityAPI(apiObj); err != nil {
+			return nil, err
+		}
+		return apiObj, nil
+	}
+	return nil, fmt.Errorf("security group %q not found in project %q: %w", group, project, gitprovider.ErrNotFound)
+}
+
+func (c *azureClientImpl) GetUserIdentity(ctx context.Context, org, login string) (*Identity, error) {
+	query := url.Values{}
+	query.Set("searchFilter", "General")
+	query.Set("filterValue", login)
+	query.Set("queryMembership", "None")
+
+	res := &listResponse{}
+	// GET /{organization}/_apis/identities?searchFilter=General&filterValue={login}
+	if _, err := c.do(ctx, http.MethodGet, c.identityURL(query, org, "_apis", "identities"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Identity{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		// Skip security groups matching the search
+		if apiObj.IsContainer {
+			continue
+		}
+		// Validate the API object
+		if err := validateIdentityAPI(apiObj); err != nil {
+			return nil, err
+		}
+		return apiObj, nil
+	}
+	return nil, fmt.Errorf("user %q not found: %w", login, gitprovider.ErrNotFound)
+}
+
+func (c *azureClientImpl) ListIdentities(ctx context.Context, org string, descriptors []string) ([]*Identity, error) {
+	apiObjs := []*Identity{}
+	if len(descriptors) == 0 {
+		return apiObjs, nil
+	}
+	query := url.Values{}
+	query.Set("descriptors", strings.Join(descriptors, ","))
+	query.Set("queryMembership", "None")
+
+	res := &listResponse{}
+	// GET /{organization}/_apis/identities?descriptors={descriptors}
+	if _, err := c.do(ctx, http.MethodGet, c.identityURL(query, org, "_apis", "identities"), nil, res); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects. Identities which couldn't be resolved are returned as null.
+	identities := make([]*Identity, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if apiObj == nil {
+			continue
+		}
+		if err := validateIdentityAPI(apiObj); err != nil {
+			return nil, err
+		}
+		identities = append(identities, apiObj)
+	}
+	return identities, nil
+}
+
+func (c *azureClientImpl) GetAccessControlList(ctx context.Context, org, token string, descriptors ...string) (*AccessControlList, error) {
+	query := url.Values{}
+	query.Set("token", token)
+	if len(descriptors) != 0 {
+		query.Set("descriptors", strings.Join(descriptors, ","))
+	}
+
+	res := &listResponse{}
+	// GET /{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, "_apis", "accesscontrollists", gitRepositoriesSecurityNamespace), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*AccessControlList{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.Token == token {
+			return apiObj, nil
+		}
+	}
+	// No explicit permissions have been set for the token
+	return &AccessControlList{Token: token, InheritPermissions: true}, nil
+}
+
+func (c *azureClientImpl) SetAccessControlEntry(ctx context.Context, org, token string, req *AccessControlEntry) (*AccessControlEntry, error) {
+	body := &accessControlEntriesRequest{
+		Token:                token,
+		Merge:                false,
+		AccessControlEntries: []*AccessControlEntry{req},
+	}
+
+	res := &listResponse{}
+	// POST /{organization}/_apis/accesscontrolentries/{securityNamespaceId}
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, org, "_apis", "accesscontrolentries", gitRepositoriesSecurityNamespace), body, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*AccessControlEntry{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+	if len(apiObjs) != 1 {
+		return nil, fmt.Errorf("expected one access control entry, got %d: %w", len(apiObjs), gitprovider.ErrInvalidServerData)
+	}
+	return apiObjs[0], nil
+}
+
+func (c *azureClientImpl) RemoveAccessControlEntry(ctx context.Context, org, token, descriptor string) error {
+	query := url.Values{}
+	query.Set("token", token)
+	query.Set("descriptors", descriptor)
+	// DELETE /{organization}/_apis/accesscontrolentries/{securityNamespaceId}?token={token}&descriptors={descriptor}
+	_, err := c.do(ctx, http.MethodDelete, c.url(query, org, "_apis", "accesscontrolentries", gitRepositoriesSecurityNamespace), nil, nil)
+	return err
+}
+
+func (c *azureClientImpl) ListCommitsPage(ctx context.Context, org, project, repo, branch string, perPage, page int) ([]*Commit, error) {
+	query := url.Values{}
+	query.Set("searchCriteria.itemVersion.version", branch)
+	query.Set("searchCriteria.itemVersion.versionType", "branch")
+	query.Set("searchCriteria.$top", strconv.Itoa(perPage))
+	query.Set("searchCriteria.$skip", strconv.Itoa((page-1)*perPage))
+
+	res := &listResponse{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/commits
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, project, "_apis", "git", "repositories", repo, "commits"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Commit{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateCommitAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) CreatePush(ctx context.Context, org, project, repo string, req *Push) (*Push, error) {
+	apiObj := &Push{}
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pushes
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, org, project, "_apis", "git", "repositories", repo, "pushes"), req, apiObj); err != nil {
+		return nil, err
+	}
+
+	// Validate the API object
+	if len(apiObj.Commits) == 0 {
+		return nil, fmt.Errorf("no commits returned for push: %w", gitprovider.ErrInvalidServerData)
+	}
+	for _, commit := range apiObj.Commits {
+		if err := validateCommitAPI(commit); err != nil {
+			return nil, err
+		}
+	}
+	return apiObj, nil
+}
+
+func (c *azureClientImpl) ListBranchRefs(ctx context.Context, org, project, repo string) ([]*Ref, error) {
+	query := url.Values{}
+	query.Set("filter", strings.TrimPrefix(branchRefPrefix, "refs/"))
+
+	res := &listResponse{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, project, "_apis", "git", "repositories", repo, "refs"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Ref{}
+	if len(res.Value) != 0 {
+		if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateRefAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) GetBranchRef(ctx context.Context, org, project, repo, branch string) (*Ref, error) {
+	query := url.Values{}
+	// The filter is a prefix, hence the exact name needs to be looked for in the result
+	query.Set("filter", strings.TrimPrefix(branchRef(branch), "refs/"))
+
+	res := &listResponse{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/{branch}
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, project, "_apis", "git", "repositories", repo, "refs"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Ref{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.Name == branchRef(branch) {
+			return apiObj, nil
+		}
+	}
+	return nil, fmt.Errorf("branch %q not found: %w", branch, gitprovider.ErrNotFound)
+}
+
+func (c *azureClientImpl) UpdateRefs(ctx context.Context, org, project, repo string, req []*RefUpdate) ([]*RefUpdateResult, error) {
+	res := &listResponse{}
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, org, project, "_apis", "git", "repositories", repo, "refs"), req, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*RefUpdateResult{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) ListPullRequests(ctx context.Context, org, project, repo string, criteria PullRequestSearchCriteria) ([]*PullRequest, error) {
+	query := url.Values{}
+	if criteria.Status != "" {
+		query.Set("searchCriteria.status", criteria.Status)
+	}
+	if criteria.SourceRefName != "" {
+		query.Set("searchCriteria.sourceRefName", criteria.SourceRefName)
+	}
+	if criteria.TargetRefName != "" {
+		query.Set("searchCriteria.targetRefName", criteria.TargetRefName)
+	}
+
+	apiObjs := []*PullRequest{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	err := c.allPages(ctx, query, []string{org, project, "_apis", "git", "repositories", repo, "pullrequests"}, func(values json.RawMessage) error {
+		pageObjs := []*PullRequest{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validatePullRequestAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) GetPullRequest(ctx context.Context, org, project, repo string, id int) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, org, project, "_apis", "git", "repositories", repo, "pullrequests", strconv.Itoa(id)), nil, apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func validatePullRequestAPIResp(apiObj *PullRequest) (*PullRequest, error) {
+	// Validate the API object
+	if err := validatePullRequestAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *azureClientImpl) CreatePullRequest(ctx context.Context, org, project, repo string, req *PullRequest) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, org, project, "_apis", "git", "repositories", repo, "pullrequests"), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func (c *azureClientImpl) UpdatePullRequest(ctx context.Context, org, project, repo string, id int, req *PullRequest) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	if _, err := c.doJSON(ctx, http.MethodPatch, c.url(nil, org, project, "_apis", "git", "repositories", repo, "pullrequests", strconv.Itoa(id)), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func (c *azureClientImpl) ListItems(ctx context.Context, org, project, repo, branch, scopePath string, recursive bool) ([]*Item, error) {
+	query := versionQuery(branch)
+	query.Set("scopePath", itemPath(scopePath))
+	query.Set("recursionLevel", "OneLevel")
+	if recursive {
+		query.Set("recursionLevel", "Full")
+	}
+
+	res := &listResponse{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?scopePath={path}
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, project, "_apis", "git", "repositories", repo, "items"), nil, res); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Item{}
+	if err := json.Unmarshal(res.Value, &apiObjs); err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateItemAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *azureClientImpl) GetItemContent(ctx context.Context, org, project, repo, branch, filePath string) ([]byte, error) {
+	query := versionQuery(branch)
+	query.Set("path", itemPath(filePath))
+	query.Set("$format", "octetStream")
+
+	var content []byte
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?path={path}
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, project, "_apis", "git", "repositories", repo, "items"), nil, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (c *azureClientImpl) GetTree(ctx context.Context, org, project, repo, sha string, recursive bool) (*Tree, error) {
+	query := url.Values{}
+	query.Set("recursive", strconv.FormatBool(recursive))
+
+	apiObj := &Tree{}
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/trees/{sha1}
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, org, project, "_apis", "git", "repositories", repo, "trees", sha), nil, apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateTreeAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+// versionQuery returns the query parameters for reading items from the given branch.
+func versionQuery(branch string) url.Values {
+	query := url.Values{}
+	query.Set("versionDescriptor.version", branch)
+	query.Set("versionDescriptor.versionType", "branch")
+	return query
+}
+
+// url builds an absolute API URL out of the given path segments, see buildURL.
+func (c *azureClientImpl) url(query url.Values, segments ...string) string {
+	return buildURL(c.baseURL, query, segments...)
+}
+
+// identityURL builds an absolute URL for the identity APIs out of the given path segments, see buildURL.
+func (c *azureClientImpl) identityURL(query url.Values, segments ...string) string {
+	return buildURL(c.identityBaseURL, query, segments...)
+}
+
+// buildURL builds an absolute URL out of the given path segments, which are escaped individually.
+// Empty segments are skipped. The api-version query parameter is always added.
+func buildURL(baseURL string, query url.Values, segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	q := url.Values{}
+	for key, values := range query {
+		q[key] = values
+	}
+	q.Set("api-version", apiVersion)
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.Join(escaped, "/") + "?" + q.Encode()
+}
+
+// doJSON sends req JSON-encoded as the request body, see do.
+func (c *azureClientImpl) doJSON(ctx context.Context, method, urlStr string, req interface{}, out interface{}) (*http.Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, method, urlStr, bytes.NewReader(body), out)
+}
+
+// do sends a request. A successful response body is decoded into out, or copied as-is if out
+// is a *[]byte. Unsuccessful responses are converted into errors using handleHTTPError.
+func (c *azureClientImpl) do(ctx context.Context, method, urlStr string, body io.Reader, out interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// A 203 status code is returned together with a sign-in page, see handleHTTPError
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices ||
+		res.StatusCode == http.StatusNonAuthoritativeInfo {
+		return res, handleHTTPError(res, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return res, nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = data
+		return res, nil
+	}
+	return res, json.Unmarshal(data, out)
+}
+
+// allPages requests all pages of the list at the given path segments using the $top and $skip
+// query parameters, and calls fn with the values of each page.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *azureClientImpl) allPages(ctx context.Context, query url.Values, segments []string, fn func(values json.RawMessage) error) error {
+	q := url.Values{}
+	for key, values := range query {
+		q[key] = values
+	}
+	q.Set("$top", strconv.Itoa(listPageSize))
+	for skip := 0; ; {
+		q.Set("$skip", strconv.Itoa(skip))
+		p := &listResponse{}
+		if _, err := c.do(ctx, http.MethodGet, c.url(q, segments...), nil, p); err != nil {
+			return err
+		}
+		if p.Count != 0 {
+			if err := fn(p.Value); err != nil {
+				return err
+			}
+		}
+		if p.Count < listPageSize {
+			return nil
+		}
+		skip += p.Count
+	}
+}
+
+// validateRefAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRefAPI(apiObj *Ref) error {
+	return validateAPIObject("AzureDevOps.Ref", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+		if apiObj.ObjectID == "" {
+			validator.Required("ObjectID")
+		}
+	})
+}
+
+// validateProjectAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateProjectAPI(apiObj *Project) error {
+	return validateAPIObject("AzureDevOps.Project", func(validator validation.Validator) {
+		if apiObj.ID == "" {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// validateTeamMemberAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateTeamMemberAPI(apiObj *TeamMember) error {
+	return validateAPIObject("AzureDevOps.TeamMember", func(validator validation.Validator) {
+		if apiObj.Identity == nil || apiObj.Identity.UniqueName == "" {
+			validator.Required("Identity.UniqueName")
+		}
+	})
+}
+
+// validateIdentityAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateIdentityAPI(apiObj *Identity) error {
+	return validateAPIObject("AzureDevOps.Identity", func(validator validation.Validator) {
+		if apiObj.Descriptor == "" {
+			validator.Required("Descriptor")
+		}
+		if apiObj.ProviderDisplayName == "" {
+			validator.Required("ProviderDisplayName")
+		}
+	})
+}
+
+// validateItemAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateItemAPI(apiObj *Item) error {
+	return validateAPIObject("AzureDevOps.Item", func(validator validation.Validator) {
+		if apiObj.Path == "" {
+			validator.Required("Path")
+		}
+	})
+}
+
+// validateTreeAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateTreeAPI(apiObj *Tree) error {
+	return validateAPIObject("AzureDevOps.Tree", func(validator validation.Validator) {
+		if apiObj.ObjectID == "" {
+			validator.Required("ObjectID")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for Azure DevOps.
+const ProviderID = gitprovider.ProviderID("azuredevops")
+
+func newClient(c *http.Client, baseURL, identityBaseURL, domain string, destructiveActions bool) *Client {
+	azClient := &azureClientImpl{c, baseURL, identityBaseURL, destructiveActions}
+	ctx := &clientContext{azClient, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	c                  azureClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+	users     *UsersClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "dev.azure.com" or
+// "my-server.com/tfs". This allows a higher-level user to know what Client to use for
+// what endpoints.
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "azuredevops".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the *http.Client used under the hood for accessing Azure DevOps.
+// Requests sent using it are authenticated, as the transport chain is part of it.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations and projects.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in a project.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+//
+// Azure DevOps repositories always belong to a project, hence this client isn't supported.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// Users returns the UsersClient looking up user accounts.
+func (c *Client) Users() gitprovider.UsersClient {
+	return c.users
+}
+
+// HasTokenPermission returns true if the given token has the given permissions.
+//
+// Azure DevOps doesn't expose the scopes of the token in use, hence ErrTokenPermissionUnknown is
+// returned for all permissions.
+func (c *Client) HasTokenPermission(_ context.Context, permission gitprovider.TokenPermission) (bool, error) {
+	if err := gitprovider.ValidateTokenPermission(permission); err != nil {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+	return false, gitprovider.ErrTokenPermissionUnknown
+}
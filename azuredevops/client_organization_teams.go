@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// TeamsClient implements the gitprovider.TeamsClient interface.
+var _ gitprovider.TeamsClient = &TeamsClient{}
+
+// TeamsClient handles the teams of a project.
+// Teams are only available for projects, not for the organization itself.
+type TeamsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get a team within the specific project. name can be the name or the ID of the team.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamsClient) Get(ctx context.Context, name string) (gitprovider.Team, error) {
+	project, err := c.project()
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/projects/{projectId}/teams/{teamId}
+	apiObj, err := c.c.GetTeam(ctx, c.ref.Organization, project, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.getTeam(ctx, apiObj)
+}
+
+// List all teams within the specific project.
+//
+// List returns all available teams, using multiple paginated requests if needed.
+func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
+	project, err := c.project()
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/projects/{projectId}/teams
+	apiObjs, err := c.c.ListTeams(ctx, c.ref.Organization, project)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]gitprovider.Team, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		team, err := c.getTeam(ctx, apiObj)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+// Create returns gitprovider.ErrNoProviderSupport, as creating teams isn't supported.
+func (c *TeamsClient) Create(_ context.Context, _ gitprovider.TeamInfo) (gitprovider.Team, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport, as creating teams isn't supported.
+func (c *TeamsClient) Reconcile(_ context.Context, _ gitprovider.TeamInfo) (gitprovider.Team, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
+
+func (c *TeamsClient) getTeam(ctx context.Context, apiObj *Team) (gitprovider.Team, error) {
+	project, err := c.project()
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/projects/{projectId}/teams/{teamId}/members
+	members, err := c.c.ListTeamMembers(ctx, c.ref.Organization, project, apiObj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, 0, len(members))
+	for _, member := range members {
+		// The identity is already validated to be set in ListTeamMembers
+		logins = append(logins, member.Identity.UniqueName)
+	}
+
+	return &team{
+		t: *apiObj,
+		info: gitprovider.TeamInfo{
+			Name:    apiObj.Name,
+			Members: logins,
+		},
+		ref: c.ref,
+	}, nil
+}
+
+// project returns the project of the TeamsClient, teams of organizations aren't supported.
+func (c *TeamsClient) project() (string, error) {
+	project := projectName(c.ref)
+	if project == "" {
+		return "", fmt.Errorf("teams are only supported for projects: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return project, nil
+}
+
+var _ gitprovider.Team = &team{}
+
+type team struct {
+	t    Team
+	info gitprovider.TeamInfo
+	ref  gitprovider.OrganizationRef
+}
+
+func (t *team) Get() gitprovider.TeamInfo {
+	return t.info
+}
+
+func (t *team) APIObject() interface{} {
+	return &t.t
+}
+
+func (t *team) Organization() gitprovider.OrganizationRef {
+	return t.ref
+}
+
+// Set returns gitprovider.ErrNoProviderSupport, as managing team members isn't supported.
+func (t *team) Set(_ gitprovider.TeamInfo) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Update returns gitprovider.ErrNoProviderSupport.
+func (t *team) Update(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport.
+func (t *team) Reconcile(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// Delete returns gitprovider.ErrNoProviderSupport.
+func (t *team) Delete(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// AddMember returns gitprovider.ErrNoProviderSupport.
+func (t *team) AddMember(_ context.Context, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// RemoveMember returns gitprovider.ErrNoProviderSupport.
+func (t *team) RemoveMember(_ context.Context, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// validateTeamAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateTeamAPI(apiObj *Team) error {
+	return validateAPIObject("AzureDevOps.Team", func(validator validation.Validator) {
+		if apiObj.ID == "" {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationsClient implements the gitprovider.OrganizationsClient interface.
+var _ gitprovider.OrganizationsClient = &OrganizationsClient{}
+
+// OrganizationsClient operates on the organizations and projects the user has access to.
+type OrganizationsClient struct {
+	*clientContext
+}
+
+// Get a specific organization, or a project if ref has a sub-organization.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.OrganizationRef) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	if project := projectName(ref); project != "" {
+		// GET /{organization}/_apis/projects/{projectId}
+		apiObj, err := c.c.GetProject(ctx, ref.Organization, project)
+		if err != nil {
+			return nil, err
+		}
+		return newProjectOrganization(c.clientContext, apiObj, ref), nil
+	}
+
+	// GET /{organization}/_apis/connectionData
+	apiObj, err := c.c.GetConnectionData(ctx, ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+	return newOrganization(c.clientContext, apiObj, ref), nil
+}
+
+// List all top-level organizations the specific user has access to.
+//
+// This is not supported in Azure DevOps, as organizations can only be listed through the
+// user profile APIs, which aren't served on the domain of the organizations.
+func (c *OrganizationsClient) List(_ context.Context) ([]gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Children returns the immediate child-organizations for the specific OrganizationRef o.
+// The children of an organization are its projects, projects have no children.
+//
+// Children returns all available projects, using multiple paginated requests if needed.
+func (c *OrganizationsClient) Children(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// Projects can't be nested
+	if projectName(ref) != "" {
+		return []gitprovider.Organization{}, nil
+	}
+
+	// GET /{organization}/_apis/projects
+	apiObjs, err := c.c.ListProjects(ctx, ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]gitprovider.Organization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj.Name is already validated to be set in ListProjects
+		projects = append(projects, newProjectOrganization(c.clientContext, apiObj, gitprovider.OrganizationRef{
+			Domain:           c.domain,
+			Organization:     ref.Organization,
+			SubOrganizations: []string{apiObj.Name},
+		}))
+	}
+
+	return projects, nil
+}
+
+// Create returns gitprovider.ErrNoProviderSupport, as creating organizations and projects isn't supported.
+func (c *OrganizationsClient) Create(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.OrganizationInfo) (gitprovider.Organization, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport, as creating organizations and projects isn't supported.
+func (c *OrganizationsClient) Reconcile(_ context.Context, _ gitprovider.OrganizationRef, _ gitprovider.OrganizationInfo) (gitprovider.Organization, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrgRepositoriesClient implements the gitprovider.OrgRepositoriesClient interface.
+var _ gitprovider.OrgRepositoriesClient = &OrgRepositoriesClient{}
+
+// OrgRepositoriesClient operates on repositories the user has access to.
+type OrgRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrgRepositoriesClient) Get(ctx context.Context, ref gitprovider.OrgRepositoryRef) (gitprovider.OrgRepository, error) {
+	// Make sure the OrgRepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+	org, project, repo := repositoryPath(ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err := c.c.GetRepo(ctx, org, project, repo)
+	if err != nil {
+		return nil, err
+	}
+	return newOrgRepository(c.clientContext, apiObj, ref), nil
+}
+
+// List all repositories in the given project, or in all projects of the given organization.
+//
+// List returns all available repositories.
+func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.OrganizationRef) ([]gitprovider.OrgRepository, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/{project}/_apis/git/repositories
+	apiObjs, err := c.c.ListRepos(ctx, ref.Organization, projectName(ref))
+	if err != nil {
+		return nil, err
+	}
+
+	// Traverse the list, and return a list of OrgRepository objects
+	repos := make([]gitprovider.OrgRepository, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListRepos
+		repoRef := gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  apiObj.Name,
+		}
+		// Refer to the repository through its project when listing a whole organization
+		if projectName(ref) == "" && apiObj.Project != nil {
+			repoRef.OrganizationRef.SubOrganizations = []string{apiObj.Project.Name}
+		}
+		repos = append(repos, newOrgRepository(c.clientContext, apiObj, repoRef))
+	}
+	return repos, nil
+}
+
+// Create creates a repository in the given project, with the data and options.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (gitprovider.OrgRepository, error) {
+	// Make sure the RepositoryRef is valid
+	if err := validateOrgRepositoryRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := createRepository(ctx, c.c, ref, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newOrgRepository(c.clientContext, apiObj, ref), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	// Run generic reconciliation
+	actionTaken, err := reconcileRepository(ctx, actual, req)
+	return actual, actionTaken, err
+}
+
+// createRepository creates the repository in the project of ref. If AutoInit is set, an initial
+// commit containing a README.md file is pushed to the default branch.
+// Azure DevOps has no license templates, hence the LicenseTemplate option is ignored.
+func createRepository(ctx context.Context, c azureClient, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*Repository, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// Assemble the options struct based on the given options
+	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	org, projectName, repoName := repositoryPath(ref)
+	// GET /{organization}/_apis/projects/{projectId}
+	project, err := c.GetProject(ctx, org, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRepositoryInfo(req, project); err != nil {
+		return nil, err
+	}
+
+	// POST /{organization}/{project}/_apis/git/repositories
+	apiObj, err := c.CreateRepo(ctx, org, projectName, repositoryToAPI(ref, project))
+	if err != nil {
+		return nil, err
+	}
+
+	if o.AutoInit == nil || !*o.AutoInit {
+		return apiObj, nil
+	}
+
+	readmeContent := fmt.Sprintf("# %s\n", repoName)
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pushes
+	if _, err := c.CreatePush(ctx, org, projectName, apiObj.ID, &Push{
+		RefUpdates: []*RefUpdate{
+			{
+				Name:        branchRef(*req.DefaultBranch),
+				OldObjectID: emptyObjectID,
+			},
+		},
+		Commits: []*Commit{
+			{
+				Comment: "Initial commit",
+				Changes: []*Change{
+					{
+						ChangeType: changeTypeAdd,
+						Item:       &Item{Path: "/README.md"},
+						NewContent: &ItemContent{Content: readmeContent, ContentType: contentTypeRawText},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create initial commit: %w", err)
+	}
+
+	// Fetch the repository again, as the default branch is only set after the first push
+	return c.GetRepo(ctx, org, projectName, apiObj.ID)
+}
+
+func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return false, err
+	}
+	// Apply the desired state by running Update
+	return true, actual.Update(ctx)
+}
+
+func toCreateOpts(opts ...gitprovider.RepositoryReconcileOption) []gitprovider.RepositoryCreateOption {
+	// Convert RepositoryReconcileOption => RepositoryCreateOption
+	createOpts := make([]gitprovider.RepositoryCreateOption, 0, len(opts))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+	return createOpts
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserRepositoriesClient implements the gitprovider.UserRepositoriesClient interface.
+var _ gitprovider.UserRepositoriesClient = &UserRepositoriesClient{}
+
+// UserRepositoriesClient operates on repositories the user has access to.
+//
+// Azure DevOps repositories always belong to a project, hence this client isn't supported.
+// Use the OrgRepositoriesClient with the project as the sub-organization instead.
+type UserRepositoriesClient struct {
+	*clientContext
+}
+
+// Get returns the repository at the given path.
+//
+// This is not supported in Azure DevOps.
+func (c *UserRepositoriesClient) Get(_ context.Context, _ gitprovider.UserRepositoryRef) (gitprovider.UserRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// List all repositories for the given user.
+//
+// This is not supported in Azure DevOps.
+func (c *UserRepositoriesClient) List(_ context.Context, _ gitprovider.UserRef) ([]gitprovider.UserRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create creates a repository for the given user, with the data and options.
+//
+// This is not supported in Azure DevOps.
+func (c *UserRepositoriesClient) Create(_ context.Context,
+	_ gitprovider.UserRepositoryRef,
+	_ gitprovider.RepositoryInfo,
+	_ ...gitprovider.RepositoryCreateOption,
+) (gitprovider.UserRepository, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// This is not supported in Azure DevOps.
+func (c *UserRepositoriesClient) Reconcile(_ context.Context,
+	_ gitprovider.UserRepositoryRef,
+	_ gitprovider.RepositoryInfo,
+	_ ...gitprovider.RepositoryReconcileOption,
+) (gitprovider.UserRepository, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// refUpdateStatusStaleOldObjectID is returned if the reference doesn't point to the given old object ID.
+// For new branches, the old object ID is emptyObjectID, hence this means the branch already exists.
+const refUpdateStatusStaleOldObjectID = "staleOldObjectId"
+
+// BranchClient implements the gitprovider.BranchClient interface.
+var _ gitprovider.BranchClient = &BranchClient{}
+
+// BranchClient operates on the branches for a specific repository.
+type BranchClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// List lists all branches in the repository.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.Branch, error) {
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/
+	apiObjs, err := c.c.ListBranchRefs(ctx, org, project, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]gitprovider.Branch, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		branches = append(branches, newBranch(c, apiObj))
+	}
+	return branches, nil
+}
+
+// Get returns the branch with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Get(ctx context.Context, branch string) (gitprovider.Branch, error) {
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/{branch}
+	apiObj, err := c.c.GetBranchRef(ctx, org, project, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	return newBranch(c, apiObj), nil
+}
+
+// Create creates a branch with the given specifications.
+//
+// ErrAlreadyExists will be returned if the branch already exists.
+func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
+	org, project, repo := repositoryPath(c.ref)
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs
+	results, err := c.c.UpdateRefs(ctx, org, project, repo, []*RefUpdate{
+		{
+			Name:        branchRef(branch),
+			OldObjectID: emptyObjectID,
+			NewObjectID: sha,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Success {
+			continue
+		}
+		if result.UpdateStatus == refUpdateStatusStaleOldObjectID {
+			return fmt.Errorf("branch %q already exists: %w", branch, gitprovider.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create branch %q: %s %s", branch, result.UpdateStatus, result.CustomMessage)
+	}
+	return nil
+}
+
+// Delete deletes the branch with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// The current object ID of the branch is needed to delete it
+	ref, err := c.c.GetBranchRef(ctx, org, project, repo, branch)
+	if err != nil {
+		return err
+	}
+
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs
+	results, err := c.c.UpdateRefs(ctx, org, project, repo, []*RefUpdate{
+		{
+			Name:        ref.Name,
+			OldObjectID: ref.ObjectID,
+			NewObjectID: emptyObjectID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("failed to delete branch %q: %s %s", branch, result.UpdateStatus, result.CustomMessage)
+		}
+	}
+	return nil
+}
+
+// SetDefault makes the branch with the given name the default branch of the repository.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) SetDefault(ctx context.Context, branch string) error {
+	org, project, repo := repositoryPath(c.ref)
+	// Make sure the branch exists, as the default branch of a repository can't be unknown
+	if _, err := c.c.GetBranchRef(ctx, org, project, repo, branch); err != nil {
+		return err
+	}
+
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	_, err := c.c.UpdateRepo(ctx, org, project, repo, &Repository{
+		DefaultBranch: branchRef(branch),
+	})
+	return err
+}
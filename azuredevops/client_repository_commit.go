@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// CommitClient implements the gitprovider.CommitClient interface.
+var _ gitprovider.CommitClient = &CommitClient{}
+
+// CommitClient operates on the commits for a specific repository.
+type CommitClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// ListPage lists repository commits of the given page and page size.
+func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	commits, err := c.listPage(ctx, branch, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.Commit
+	result := make([]gitprovider.Commit, 0, len(commits))
+	for _, commit := range commits {
+		result = append(result, commit)
+	}
+	return result, nil
+}
+
+func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/commits
+	apiObjs, err := c.c.ListCommitsPage(ctx, org, project, repo, branch, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our CommitType type
+	commits := make([]*commitType, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListCommitsPage
+		commits = append(commits, newCommit(c, apiObj))
+	}
+
+	return commits, nil
+}
+
+// Create creates a commit with the given specifications, using a single push.
+// Files with a nil Content are deleted. If the branch doesn't exist yet, it is created.
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter=heads/{branch}
+	oldObjectID := emptyObjectID
+	ref, err := c.c.GetBranchRef(ctx, org, project, repo, branch)
+	switch {
+	case err == nil:
+		oldObjectID = ref.ObjectID
+	case !errors.Is(err, gitprovider.ErrNotFound):
+		return nil, err
+	}
+
+	changes := make([]*Change, 0, len(files))
+	for _, file := range files {
+		fileChanges, err := c.changes(ctx, branch, oldObjectID, file)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fileChanges...)
+	}
+
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pushes
+	push, err := c.c.CreatePush(ctx, org, project, repo, &Push{
+		RefUpdates: []*RefUpdate{
+			{
+				Name:        branchRef(branch),
+				OldObjectID: oldObjectID,
+			},
+		},
+		Commits: []*Commit{
+			{
+				Comment: message,
+				Changes: changes,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The pushed commits are validated to be set in CreatePush
+	return newCommit(c, push.Commits[len(push.Commits)-1]), nil
+}
+
+// changes returns the changes needed to commit file to the given branch.
+func (c *CommitClient) changes(ctx context.Context, branch, oldObjectID string, file gitprovider.CommitFile) ([]*Change, error) {
+	if err := file.Validate(); err != nil {
+		return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+	}
+	if file.GetMode() != gitprovider.CommitFileModeRegular {
+		return nil, fmt.Errorf("cannot commit %q with mode %s: %w", *file.Path, file.GetMode(), gitprovider.ErrNoProviderSupport)
+	}
+
+	action := file.GetAction()
+	if action == gitprovider.CommitFileActionMove && file.Content == nil {
+		return []*Change{{
+			ChangeType:       changeTypeRename,
+			Item:             &Item{Path: itemPath(*file.Path)},
+			SourceServerItem: itemPath(*file.PreviousPath),
+		}}, nil
+	}
+
+	var changes []*Change
+	if action == gitprovider.CommitFileActionMove {
+		// A file moved with new content is deleted, and added at its new path
+		changes = append(changes, &Change{
+			ChangeType: changeTypeDelete,
+			Item:       &Item{Path: itemPath(*file.PreviousPath)},
+		})
+	}
+	changeType, err := c.changeType(ctx, branch, oldObjectID, file)
+	if err != nil {
+		return nil, err
+	}
+	change := &Change{
+		ChangeType: changeType,
+		Item:       &Item{Path: itemPath(*file.Path)},
+	}
+	if file.Content != nil {
+		content, err := file.DecodedContent()
+		if err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+		change.NewContent = &ItemContent{Content: string(content), ContentType: contentTypeRawText}
+		// Binary content can't be sent as JSON text
+		if (file.Encoding != nil && *file.Encoding == gitprovider.ContentEncodingBase64) || !utf8.Valid(content) {
+			change.NewContent = &ItemContent{Content: base64.StdEncoding.EncodeToString(content), ContentType: contentTypeBase64Encoded}
+		}
+	}
+	return append(changes, change), nil
+}
+
+// changeType returns the type of change needed to commit file to the given branch.
+// Azure DevOps requires to tell apart new and existing files.
+func (c *CommitClient) changeType(ctx context.Context, branch, oldObjectID string, file gitprovider.CommitFile) (string, error) {
+	switch file.GetAction() {
+	case gitprovider.CommitFileActionDelete:
+		return changeTypeDelete, nil
+	case gitprovider.CommitFileActionAdd, gitprovider.CommitFileActionMove:
+		return changeTypeAdd, nil
+	case gitprovider.CommitFileActionUpdate:
+		return changeTypeEdit, nil
+	}
+	// All files are new on a new branch
+	if oldObjectID == emptyObjectID {
+		return changeTypeAdd, nil
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?scopePath={path}
+	_, err := c.c.ListItems(ctx, org, project, repo, branch, *file.Path, false)
+	switch {
+	case err == nil:
+		return changeTypeEdit, nil
+	case errors.Is(err, gitprovider.ErrNotFound):
+		return changeTypeAdd, nil
+	}
+	return "", err
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const gitObjectTypeBlob = "blob"
+
+// FileClient implements the gitprovider.FileClient interface.
+var _ gitprovider.FileClient = &FileClient{}
+
+// FileClient operates on the branch for a specific repository.
+type FileClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// Get fetches and returns the contents of a file or multiple files in a directory from a given branch and path with possible options of FilesGetOption
+// If a file path is given, the contents of the file are returned
+// If a directory path is given, the contents of the files in the path's root are returned
+func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	fileOpts := gitprovider.FilesGetOptions{}
+	for _, opt := range optFns {
+		opt.ApplyFilesGetOptions(&fileOpts)
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?scopePath={path}
+	items, err := c.c.ListItems(ctx, org, project, repo, branch, path, fileOpts.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*gitprovider.CommitFile, 0, len(items))
+	for _, item := range items {
+		// Folders are listed too, including the one at path itself
+		if item.IsFolder || item.GitObjectType != gitObjectTypeBlob {
+			continue
+		}
+		// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/items?path={path}
+		content, err := c.c.GetItemContent(ctx, org, project, repo, branch, item.Path)
+		if err != nil {
+			return nil, err
+		}
+		// Item paths are absolute, return them relative to the repository root like the other providers
+		filePath := strings.TrimPrefix(item.Path, "/")
+		contentStr := string(content)
+		files = append(files, &gitprovider.CommitFile{
+			Path:    &filePath,
+			Content: &contentStr,
+			SHA:     gitprovider.StringVar(item.ObjectID),
+		})
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found on this path[%s]", path)
+	}
+
+	return files, nil
+}
+
+// Put creates or updates the file at path on the given branch.
+// This is not supported in Azure DevOps yet.
+func (c *FileClient) Put(_ context.Context, _, _, _, _, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Delete deletes the file at path on the given branch.
+// This is not supported in Azure DevOps yet.
+func (c *FileClient) Delete(_ context.Context, _, _, _, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+//nolint:gochecknoglobals
+var mergeStrategies = map[gitprovider.MergeMethod]string{
+	gitprovider.MergeMethodMerge:  "noFastForward",
+	gitprovider.MergeMethodSquash: "squash",
+	gitprovider.MergeMethodRebase: "rebase",
+}
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests for a specific repository.
+type PullRequestClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// List lists all pull requests in the repository, in all states unless filtered
+// with PullRequestListOptions.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := PullRequestSearchCriteria{Status: pullRequestStatusAll}
+	if o.State != nil {
+		criteria.Status = pullRequestStatuses[*o.State]
+	}
+	if o.SourceBranch != nil {
+		criteria.SourceRefName = branchRef(*o.SourceBranch)
+	}
+	if o.TargetBranch != nil {
+		criteria.TargetRefName = branchRef(*o.TargetBranch)
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	apiObjs, err := c.c.ListPullRequests(ctx, org, project, repo, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]gitprovider.PullRequest, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		requests = append(requests, newPullRequest(c.clientContext, apiObj))
+	}
+
+	return requests, nil
+}
+
+// Create creates a pull request with the given specifications. Labels and drafts are supported,
+// reviewers are identified by ID in Azure DevOps, hence they return
+// gitprovider.ErrNoProviderSupport like assignees.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(o.Assignees) > 0:
+		return nil, fmt.Errorf("azure devops pull requests don't support assignees: %w", gitprovider.ErrNoProviderSupport)
+	case len(o.Reviewers) > 0:
+		return nil, fmt.Errorf("azure devops pull requests can't request reviewers by name: %w", gitprovider.ErrNoProviderSupport)
+	case o.MaintainerCanModify != nil:
+		return nil, fmt.Errorf("azure devops pull requests don't support maintainer edits: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	req := &PullRequest{
+		Title:         title,
+		Description:   description,
+		SourceRefName: branchRef(branch),
+		TargetRefName: branchRef(baseBranch),
+		IsDraft:       o.Draft != nil && *o.Draft,
+	}
+	for _, label := range o.Labels {
+		req.Labels = append(req.Labels, &WebAPITagDefinition{Name: label})
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// POST /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests
+	apiObj, err := c.c.CreatePullRequest(ctx, org, project, repo, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPullRequest(c.clientContext, apiObj), nil
+}
+
+// Get retrieves an existing pull request by number
+func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	org, project, repo := repositoryPath(c.ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err := c.c.GetPullRequest(ctx, org, project, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPullRequest(c.clientContext, apiObj), nil
+}
+
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Update(ctx context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	update := &PullRequest{}
+	if req.Title != nil {
+		update.Title = *req.Title
+	}
+	if req.Description != nil {
+		update.Description = *req.Description
+	}
+	if req.TargetBranch != nil {
+		update.TargetRefName = branchRef(*req.TargetBranch)
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	apiObj, err := c.c.UpdatePullRequest(ctx, org, project, repo, number, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPullRequest(c.clientContext, apiObj), nil
+}
+
+// Close abandons the pull request with the given number.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Close(ctx context.Context, number int) error {
+	org, project, repo := repositoryPath(c.ref)
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	_, err := c.c.UpdatePullRequest(ctx, org, project, repo, number, &PullRequest{
+		Status: pullRequestStatusAbandoned,
+	})
+	return err
+}
+
+// Merge merges a pull request with the given specifications, by completing it.
+// Azure DevOps completes pull requests asynchronously, the merge may still be in progress when this returns.
+// With auto-merge, auto-complete is set by the authenticated user instead, which completes the pull request
+// once its policies pass.
+// Deleting the source branch after merging requires destructive API calls to be enabled, otherwise
+// ErrDestructiveCallDisallowed is returned without merging the pull request.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	strategy, ok := mergeStrategies[mergeMethod]
+	if !ok {
+		return fmt.Errorf("merge method %q is not supported: %w", mergeMethod, gitprovider.ErrNoProviderSupport)
+	}
+	// Don't allow deleting the source branch if the user didn't explicitly allow dangerous API calls.
+	if o.DeleteSourceBranch != nil && *o.DeleteSourceBranch && !c.destructiveActions {
+		return fmt.Errorf("cannot delete source branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+
+	org, project, repo := repositoryPath(c.ref)
+	// Completing a pull request requires the last merged source commit, to avoid merging unreviewed changes
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	pr, err := c.c.GetPullRequest(ctx, org, project, repo, number)
+	if err != nil {
+		return err
+	}
+
+	req := &PullRequest{
+		Status:                pullRequestStatusCompleted,
+		LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		CompletionOptions: &CompletionOptions{
+			MergeStrategy:      strategy,
+			MergeCommitMessage: message,
+			DeleteSourceBranch: o.DeleteSourceBranch != nil && *o.DeleteSourceBranch,
+		},
+	}
+	if o.AutoMerge != nil && *o.AutoMerge {
+		// GET /{organization}/_apis/connectionData
+		connectionData, err := c.c.GetConnectionData(ctx, org)
+		if err != nil {
+			return err
+		}
+		if connectionData.AuthenticatedUser == nil {
+			return fmt.Errorf("no authenticated user to set auto-complete: %w", gitprovider.ErrInvalidServerData)
+		}
+		req.Status = ""
+		req.LastMergeSourceCommit = nil
+		req.AutoCompleteSetBy = &IdentityRef{ID: connectionData.AuthenticatedUser.ID}
+	}
+
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+	_, err = c.c.UpdatePullRequest(ctx, org, project, repo, number, req)
+	return err
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestReviewClient implements the gitprovider.PullRequestReviewClient interface.
+var _ gitprovider.PullRequestReviewClient = &PullRequestReviewClient{}
+
+// PullRequestReviewClient gives read access to the reviews of a specific pull request.
+// Pull request reviews aren't supported for Azure DevOps yet, hence all methods return
+// gitprovider.ErrNoProviderSupport.
+type PullRequestReviewClient struct {
+	*clientContext
+}
+
+// List returns gitprovider.ErrNoProviderSupport.
+func (c *PullRequestReviewClient) List(_ context.Context) ([]gitprovider.PullRequestReview, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
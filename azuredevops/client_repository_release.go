@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+// Azure DevOps has no concept of releases, hence all methods return
+// gitprovider.ErrNoProviderSupport.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// List returns gitprovider.ErrNoProviderSupport.
+func (c *ReleaseClient) List(_ context.Context) ([]gitprovider.Release, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Get returns gitprovider.ErrNoProviderSupport.
+func (c *ReleaseClient) Get(_ context.Context, _ string) (gitprovider.Release, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create returns gitprovider.ErrNoProviderSupport.
+func (c *ReleaseClient) Create(_ context.Context, _ gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Update returns gitprovider.ErrNoProviderSupport.
+func (c *ReleaseClient) Update(_ context.Context, _ string, _ gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Delete returns gitprovider.ErrNoProviderSupport.
+func (c *ReleaseClient) Delete(_ context.Context, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// UploadAsset returns gitprovider.ErrNoProviderSupport.
+func (c *ReleaseClient) UploadAsset(_ context.Context, _, _ string, _ []byte) (*gitprovider.ReleaseAsset, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
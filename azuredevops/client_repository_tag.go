@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+// Tags aren't supported for Azure DevOps yet, hence all methods return
+// gitprovider.ErrNoProviderSupport.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// List returns gitprovider.ErrNoProviderSupport.
+func (c *TagClient) List(_ context.Context) ([]gitprovider.Tag, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Get returns gitprovider.ErrNoProviderSupport.
+func (c *TagClient) Get(_ context.Context, _ string) (gitprovider.Tag, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Create returns gitprovider.ErrNoProviderSupport.
+func (c *TagClient) Create(_ context.Context, _ gitprovider.TagInfo) (gitprovider.Tag, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Delete returns gitprovider.ErrNoProviderSupport.
+func (c *TagClient) Delete(_ context.Context, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
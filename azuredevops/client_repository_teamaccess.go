@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamAccessClient implements the gitprovider.TeamAccessClient interface.
+var _ gitprovider.TeamAccessClient = &TeamAccessClient{}
+
+// TeamAccessClient operates on the explicit permissions of security groups for a specific repository.
+// Teams are the security groups of the project, e.g. "Contributors", or "<project> Team" for the default team.
+// The permissions are stored in the Git Repositories security namespace.
+type TeamAccessClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// Get a security group's permission for the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamAccessClient) Get(ctx context.Context, name string) (gitprovider.TeamAccess, error) {
+	org, project, _ := repositoryPath(c.ref)
+	token, err := securityToken(ctx, c.clientContext, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/identities?searchFilter=General&filterValue=[{project}]\{group}
+	identity, err := c.c.GetGroupIdentity(ctx, org, project, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}&descriptors={descriptor}
+	acl, err := c.c.GetAccessControlList(ctx, org, token, identity.Descriptor)
+	if err != nil {
+		return nil, err
+	}
+	ace := findAccessControlEntry(acl, identity.Descriptor)
+	if ace == nil || ace.Allow == 0 {
+		return nil, fmt.Errorf("security group %q has no permissions for the repository: %w", name, gitprovider.ErrNotFound)
+	}
+	return newTeamAccess(c, ace, name)
+}
+
+// List lists the explicit permissions of security groups for this repository.
+//
+// List returns all available team access lists.
+func (c *TeamAccessClient) List(ctx context.Context) ([]gitprovider.TeamAccess, error) {
+	org, _, _ := repositoryPath(c.ref)
+	token, err := securityToken(ctx, c.clientContext, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}
+	acl, err := c.c.GetAccessControlList(ctx, org, token)
+	if err != nil {
+		return nil, err
+	}
+	descriptors := make([]string, 0, len(acl.AcesDictionary))
+	for _, ace := range acl.AcesDictionary {
+		if ace.Allow != 0 {
+			descriptors = append(descriptors, ace.Descriptor)
+		}
+	}
+
+	// GET /{organization}/_apis/identities?descriptors={descriptors}
+	identities, err := c.c.ListIdentities(ctx, org, descriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	teamAccess := make([]gitprovider.TeamAccess, 0, len(identities))
+	for _, identity := range identities {
+		// Skip permissions of individual users
+		if !identity.IsContainer {
+			continue
+		}
+		ace := findAccessControlEntry(acl, identity.Descriptor)
+		if ace == nil {
+			continue
+		}
+		ta, err := newTeamAccess(c, ace, groupName(identity.ProviderDisplayName))
+		if err != nil {
+			return nil, err
+		}
+		teamAccess = append(teamAccess, ta)
+	}
+
+	return teamAccess, nil
+}
+
+// Create gives a given security group access to the repository.
+// Any other explicit permissions of the group for the repository are replaced.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamAccessClient) Create(ctx context.Context, req gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	permission, err := getAzurePermission(*req.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	org, project, _ := repositoryPath(c.ref)
+	token, err := securityToken(ctx, c.clientContext, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/identities?searchFilter=General&filterValue=[{project}]\{group}
+	identity, err := c.c.GetGroupIdentity(ctx, org, project, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// POST /{organization}/_apis/accesscontrolentries/{securityNamespaceId}
+	ace, err := c.c.SetAccessControlEntry(ctx, org, token, &AccessControlEntry{
+		Descriptor: identity.Descriptor,
+		Allow:      permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTeamAccess(c, ace, req.Name)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.TeamAccessInfo,
+) (gitprovider.TeamAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// securityToken returns the token of the repository in the Git Repositories security namespace.
+// The token consists of the IDs of the project and the repository, which are looked up here.
+func securityToken(ctx context.Context, c *clientContext, ref gitprovider.OrgRepositoryRef) (string, error) {
+	org, project, name := repositoryPath(ref)
+	// GET /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	repo, err := c.c.GetRepo(ctx, org, project, name)
+	if err != nil {
+		return "", err
+	}
+	if repo.Project == nil || repo.Project.ID == "" {
+		return "", fmt.Errorf("project of repository %q unknown: %w", name, gitprovider.ErrInvalidServerData)
+	}
+	return fmt.Sprintf("repoV2/%s/%s", repo.Project.ID, repo.ID), nil
+}
+
+// findAccessControlEntry returns the entry of the given identity in acl, or nil if there's none.
+// Descriptors are compared case-insensitively, like the server does.
+func findAccessControlEntry(acl *AccessControlList, descriptor string) *AccessControlEntry {
+	for key, ace := range acl.AcesDictionary {
+		if strings.EqualFold(key, descriptor) {
+			return ace
+		}
+	}
+	return nil
+}
+
+// groupName returns the name of a security group, without the scope prefix, e.g.
+// "Contributors" for "[my-project]\Contributors".
+func groupName(displayName string) string {
+	if i := strings.LastIndex(displayName, `\`); i >= 0 {
+		return displayName[i+1:]
+	}
+	return displayName
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserAccessClient implements the gitprovider.UserAccessClient interface.
+var _ gitprovider.UserAccessClient = &UserAccessClient{}
+
+// UserAccessClient operates on the explicit permissions of individual users for a specific repository.
+// Users are referred to by their account name, e.g. "alice@example.com".
+// The permissions are stored in the Git Repositories security namespace.
+type UserAccessClient struct {
+	*clientContext
+	ref gitprovider.OrgRepositoryRef
+}
+
+// Get a user's permission for the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *UserAccessClient) Get(ctx context.Context, login string) (gitprovider.UserAccess, error) {
+	token, err := securityToken(ctx, c.clientContext, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/identities?searchFilter=General&filterValue={login}
+	identity, err := c.c.GetUserIdentity(ctx, c.ref.Organization, login)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}&descriptors={descriptor}
+	acl, err := c.c.GetAccessControlList(ctx, c.ref.Organization, token, identity.Descriptor)
+	if err != nil {
+		return nil, err
+	}
+	ace := findAccessControlEntry(acl, identity.Descriptor)
+	if ace == nil || ace.Allow == 0 {
+		return nil, fmt.Errorf("user %q has no permissions for the repository: %w", login, gitprovider.ErrNotFound)
+	}
+	return newUserAccess(c, ace, login)
+}
+
+// List lists the explicit permissions of individual users for this repository.
+//
+// List returns all available user access lists.
+func (c *UserAccessClient) List(ctx context.Context) ([]gitprovider.UserAccess, error) {
+	token, err := securityToken(ctx, c.clientContext, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}
+	acl, err := c.c.GetAccessControlList(ctx, c.ref.Organization, token)
+	if err != nil {
+		return nil, err
+	}
+	descriptors := make([]string, 0, len(acl.AcesDictionary))
+	for _, ace := range acl.AcesDictionary {
+		if ace.Allow != 0 {
+			descriptors = append(descriptors, ace.Descriptor)
+		}
+	}
+
+	// GET /{organization}/_apis/identities?descriptors={descriptors}
+	identities, err := c.c.ListIdentities(ctx, c.ref.Organization, descriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccess := make([]gitprovider.UserAccess, 0, len(identities))
+	for _, identity := range identities {
+		// Skip permissions of security groups, and users without an account name
+		login := accountName(identity)
+		if identity.IsContainer || login == "" {
+			continue
+		}
+		ace := findAccessControlEntry(acl, identity.Descriptor)
+		if ace == nil {
+			continue
+		}
+		ua, err := newUserAccess(c, ace, login)
+		if err != nil {
+			return nil, err
+		}
+		userAccess = append(userAccess, ua)
+	}
+
+	return userAccess, nil
+}
+
+// Create gives a given user access to the repository.
+// Any other explicit permissions of the user for the repository are replaced.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *UserAccessClient) Create(ctx context.Context, req gitprovider.UserAccessInfo) (gitprovider.UserAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	permission, err := getAzurePermission(*req.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := securityToken(ctx, c.clientContext, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /{organization}/_apis/identities?searchFilter=General&filterValue={login}
+	identity, err := c.c.GetUserIdentity(ctx, c.ref.Organization, req.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	// POST /{organization}/_apis/accesscontrolentries/{securityNamespaceId}
+	ace, err := c.c.SetAccessControlEntry(ctx, c.ref.Organization, token, &AccessControlEntry{
+		Descriptor: identity.Descriptor,
+		Allow:      permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newUserAccess(c, ace, req.Login)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *UserAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.UserAccessInfo,
+) (gitprovider.UserAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// accountName returns the account name of a user identity, e.g. "alice@example.com",
+// or an empty string if it's unknown.
+func accountName(identity *Identity) string {
+	if p, ok := identity.Properties["Account"]; ok {
+		if name, ok := p.Value.(string); ok {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,892 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	repoPath    = "/org/project/_apis/git/repositories/repo"
+	projectID   = "3c6ff9e5-c0a3-4a09-b3a6-2e3e1f1e0f5b"
+	repoID      = "5febef5a-833d-4e14-b9c0-14cb638f91e6"
+	descriptor  = "Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1"
+	commitSHA   = "be67f8871a4d2c75f13a51c1d3c30ac0d74d4ef4"
+	treeSHA     = "7fa1a3523ffef51c525ea476bffff7d648b8cb3d"
+	securityURL = "/org/_apis/accesscontrollists/" + gitRepositoriesSecurityNamespace
+)
+
+func setup(t *testing.T, optFns ...gitprovider.ClientOption) (*http.ServeMux, gitprovider.Client) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	optFns = append([]gitprovider.ClientOption{
+		gitprovider.WithDomain(server.URL),
+		gitprovider.WithPersonalAccessToken("token"),
+	}, optFns...)
+	c, err := NewClient(optFns...)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return mux, c
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+func writeList(t *testing.T, w http.ResponseWriter, values interface{}) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("failed to encode values: %v", err)
+	}
+	var list []json.RawMessage
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("values are no list: %v", err)
+	}
+	writeJSON(t, w, http.StatusOK, &listResponse{Count: len(list), Value: data})
+}
+
+func decodeJSON(t *testing.T, r *http.Request, obj interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+}
+
+func repoRef(c gitprovider.Client) gitprovider.OrgRepositoryRef {
+	return gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{
+			Domain:           c.SupportedDomain(),
+			Organization:     "org",
+			SubOrganizations: []string{"project"},
+		},
+		RepositoryName: "repo",
+	}
+}
+
+func testProject() *Project {
+	return &Project{ID: projectID, Name: "project", Visibility: projectVisibilityPrivate}
+}
+
+func testRepository() *Repository {
+	return &Repository{
+		ID:            repoID,
+		Name:          "repo",
+		Project:       testProject(),
+		DefaultBranch: "refs/heads/main",
+		WebURL:        "https://dev.azure.com/org/project/_git/repo",
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/org/_apis/connectionData", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "" || password != "token" {
+			t.Errorf("BasicAuth() = %q, %q, %v, want personal access token", user, password, ok)
+		}
+		if got := r.URL.Query().Get("api-version"); got != apiVersion {
+			t.Errorf("api-version = %q, want %q", got, apiVersion)
+		}
+		writeJSON(t, w, http.StatusOK, &ConnectionData{InstanceID: "instance"})
+	})
+
+	if _, err := c.Organizations().Get(context.Background(), gitprovider.OrganizationRef{
+		Domain:       c.SupportedDomain(),
+		Organization: "org",
+	}); err != nil {
+		t.Fatalf("Organizations().Get returned error: %v", err)
+	}
+}
+
+func TestHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    *ErrorResponse
+		wantErr error
+	}{
+		{
+			name:    "not found",
+			status:  http.StatusNotFound,
+			body:    &ErrorResponse{Message: "project not found"},
+			wantErr: gitprovider.ErrNotFound,
+		},
+		{
+			name:    "conflict",
+			status:  http.StatusConflict,
+			body:    &ErrorResponse{Message: "conflict"},
+			wantErr: gitprovider.ErrAlreadyExists,
+		},
+		{
+			name:    "already exists exception",
+			status:  http.StatusBadRequest,
+			body:    &ErrorResponse{Message: "exists", TypeKey: "ProjectAlreadyExistsException"},
+			wantErr: gitprovider.ErrAlreadyExists,
+		},
+		{
+			name:    "sign-in page",
+			status:  http.StatusNonAuthoritativeInfo,
+			wantErr: &gitprovider.InvalidCredentialsError{},
+		},
+		{
+			name:    "unauthorized",
+			status:  http.StatusUnauthorized,
+			wantErr: &gitprovider.InvalidCredentialsError{},
+		},
+		{
+			name:    "rate limited",
+			status:  http.StatusTooManyRequests,
+			wantErr: &gitprovider.RateLimitError{},
+		},
+		{
+			name:    "internal server error",
+			status:  http.StatusInternalServerError,
+			body:    &ErrorResponse{Message: "boom"},
+			wantErr: &gitprovider.HTTPError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc("/org/_apis/projects/project", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, tt.status, tt.body)
+			})
+
+			_, err := c.Organizations().Get(context.Background(), gitprovider.OrganizationRef{
+				Domain:           c.SupportedDomain(),
+				Organization:     "org",
+				SubOrganizations: []string{"project"},
+			})
+			switch want := tt.wantErr.(type) {
+			case *gitprovider.InvalidCredentialsError:
+				if !errors.As(err, &want) {
+					t.Errorf("error = %v, want %T", err, want)
+				}
+			case *gitprovider.RateLimitError:
+				if !errors.As(err, &want) {
+					t.Errorf("error = %v, want %T", err, want)
+				}
+			case *gitprovider.HTTPError:
+				if !errors.As(err, &want) {
+					t.Errorf("error = %v, want %T", err, want)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Errorf("error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/org/_apis/projects/project", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &Project{ID: projectID, Name: "project", Description: "desc"})
+	})
+	mux.HandleFunc("/org/_apis/projects", func(w http.ResponseWriter, r *http.Request) {
+		writeList(t, w, []*Project{testProject(), {ID: "other", Name: "other"}})
+	})
+	ctx := context.Background()
+	orgRef := gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "org"}
+
+	project, err := c.Organizations().Get(ctx, gitprovider.OrganizationRef{
+		Domain:           c.SupportedDomain(),
+		Organization:     "org",
+		SubOrganizations: []string{"project"},
+	})
+	if err != nil {
+		t.Fatalf("Organizations().Get returned error: %v", err)
+	}
+	if got := project.Get(); got.Name == nil || *got.Name != "project" || got.Description == nil || *got.Description != "desc" {
+		t.Errorf("Organizations().Get() = %+v, want name and description of the project", got)
+	}
+
+	children, err := c.Organizations().Children(ctx, orgRef)
+	if err != nil {
+		t.Fatalf("Organizations().Children returned error: %v", err)
+	}
+	var names []string
+	for _, child := range children {
+		names = append(names, child.Organization().String())
+	}
+	want := []string{orgRef.String() + "/project", orgRef.String() + "/other"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("Organizations().Children() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.Organizations().List(ctx); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Organizations().List() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+func TestRepositoryReconcile(t *testing.T) {
+	mux, c := setup(t)
+	created := false
+	pushed := false
+	mux.HandleFunc("/org/_apis/projects/project", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testProject())
+	})
+	mux.HandleFunc("/org/project/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		req := &Repository{}
+		decodeJSON(t, r, req)
+		if req.Name != "repo" || req.Project == nil || req.Project.ID != projectID {
+			t.Errorf("CreateRepo request = %+v, want repo in project %s", req, projectID)
+		}
+		created = true
+		repo := testRepository()
+		repo.DefaultBranch = ""
+		writeJSON(t, w, http.StatusCreated, repo)
+	})
+	getRepo := func(w http.ResponseWriter, r *http.Request) {
+		if !created {
+			writeJSON(t, w, http.StatusNotFound, &ErrorResponse{Message: "repository not found"})
+			return
+		}
+		repo := testRepository()
+		if !pushed {
+			repo.DefaultBranch = ""
+		}
+		writeJSON(t, w, http.StatusOK, repo)
+	}
+	mux.HandleFunc(repoPath, getRepo)
+	mux.HandleFunc("/org/project/_apis/git/repositories/"+repoID, getRepo)
+	mux.HandleFunc("/org/project/_apis/git/repositories/"+repoID+"/pushes", func(w http.ResponseWriter, r *http.Request) {
+		req := &Push{}
+		decodeJSON(t, r, req)
+		if len(req.RefUpdates) != 1 || req.RefUpdates[0].Name != "refs/heads/main" || req.RefUpdates[0].OldObjectID != emptyObjectID {
+			t.Errorf("CreatePush ref updates = %+v, want new branch main", req.RefUpdates)
+		}
+		if len(req.Commits) != 1 || len(req.Commits[0].Changes) != 1 || req.Commits[0].Changes[0].Item.Path != "/README.md" {
+			t.Errorf("CreatePush commits = %+v, want README.md", req.Commits)
+		}
+		pushed = true
+		writeJSON(t, w, http.StatusCreated, &Push{PushID: 1, Commits: []*Commit{{CommitID: commitSHA}}})
+	})
+	ctx := context.Background()
+
+	repo, actionTaken, err := c.OrgRepositories().Reconcile(ctx, repoRef(c), gitprovider.RepositoryInfo{
+		DefaultBranch: gitprovider.StringVar("main"),
+	}, &gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("OrgRepositories().Reconcile returned error: %v", err)
+	}
+	if !actionTaken || !pushed {
+		t.Errorf("Reconcile() actionTaken = %v, pushed = %v, want repository to be created and initialized", actionTaken, pushed)
+	}
+	info := repo.Get()
+	if info.DefaultBranch == nil || *info.DefaultBranch != "main" {
+		t.Errorf("DefaultBranch = %v, want main", info.DefaultBranch)
+	}
+	if info.Visibility == nil || *info.Visibility != gitprovider.RepositoryVisibilityPrivate {
+		t.Errorf("Visibility = %v, want private", info.Visibility)
+	}
+
+	_, actionTaken, err = c.OrgRepositories().Reconcile(ctx, repoRef(c), gitprovider.RepositoryInfo{
+		DefaultBranch: gitprovider.StringVar("main"),
+	})
+	if err != nil {
+		t.Fatalf("OrgRepositories().Reconcile returned error: %v", err)
+	}
+	if actionTaken {
+		t.Error("Reconcile() actionTaken = true, want false for an up-to-date repository")
+	}
+}
+
+func TestRepositoryInfoUnsupported(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/org/_apis/projects/project", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testProject())
+	})
+	ctx := context.Background()
+
+	for name, info := range map[string]gitprovider.RepositoryInfo{
+		"description":       {Description: gitprovider.StringVar("desc")},
+		"public repository": {Visibility: gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic)},
+	} {
+		if _, err := c.OrgRepositories().Create(ctx, repoRef(c), info); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+			t.Errorf("%s: Create() error = %v, want %v", name, err, gitprovider.ErrNoProviderSupport)
+		}
+	}
+
+	if _, err := c.OrgRepositories().Get(ctx, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "org"},
+		RepositoryName:  "repo",
+	}); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Get() without project error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	tests := []struct {
+		name               string
+		destructiveActions bool
+		wantDeleted        bool
+		wantErr            error
+	}{
+		{
+			name:    "destructive actions disabled",
+			wantErr: gitprovider.ErrDestructiveCallDisallowed,
+		},
+		{
+			name:               "destructive actions enabled",
+			destructiveActions: true,
+			wantDeleted:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t, gitprovider.WithDestructiveAPICalls(tt.destructiveActions))
+			deleted := false
+			mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, http.StatusOK, testRepository())
+			})
+			mux.HandleFunc("/org/project/_apis/git/repositories/"+repoID, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Fatalf("unexpected method %s", r.Method)
+				}
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+			ctx := context.Background()
+
+			repo, err := c.OrgRepositories().Get(ctx, repoRef(c))
+			if err != nil {
+				t.Fatalf("OrgRepositories().Get returned error: %v", err)
+			}
+			err = repo.Delete(ctx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Delete() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Delete() returned error: %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestCommitCreate(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testRepository())
+	})
+	mux.HandleFunc(repoPath+"/refs", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter"); got != "heads/main" {
+			t.Errorf("filter = %q, want %q", got, "heads/main")
+		}
+		writeList(t, w, []*Ref{{Name: "refs/heads/main-old", ObjectID: treeSHA}, {Name: "refs/heads/main", ObjectID: commitSHA}})
+	})
+	mux.HandleFunc(repoPath+"/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scopePath") == "/existing.txt" {
+			writeList(t, w, []*Item{{ObjectID: treeSHA, GitObjectType: gitObjectTypeBlob, Path: "/existing.txt"}})
+			return
+		}
+		writeJSON(t, w, http.StatusNotFound, &ErrorResponse{Message: "item not found"})
+	})
+	mux.HandleFunc(repoPath+"/pushes", func(w http.ResponseWriter, r *http.Request) {
+		req := &Push{}
+		decodeJSON(t, r, req)
+		if len(req.RefUpdates) != 1 || req.RefUpdates[0].OldObjectID != commitSHA {
+			t.Errorf("CreatePush ref updates = %+v, want update from %s", req.RefUpdates, commitSHA)
+		}
+		var changes []string
+		for _, change := range req.Commits[0].Changes {
+			changes = append(changes, change.ChangeType+" "+change.Item.Path)
+		}
+		want := []string{"add /new.txt", "edit /existing.txt", "delete /removed.txt"}
+		if diff := cmp.Diff(want, changes); diff != "" {
+			t.Errorf("CreatePush changes mismatch (-want +got):\n%s", diff)
+		}
+		writeJSON(t, w, http.StatusCreated, &Push{PushID: 2, Commits: []*Commit{{
+			CommitID: "new-sha",
+			TreeID:   treeSHA,
+			Comment:  req.Commits[0].Comment,
+		}}})
+	})
+
+	commit, err := c.OrgRepositories().Get(context.Background(), repoRef(c))
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	got, err := commit.Commits().Create(context.Background(), "main", "update files", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("new.txt"), Content: gitprovider.StringVar("new")},
+		{Path: gitprovider.StringVar("existing.txt"), Content: gitprovider.StringVar("changed")},
+		{Path: gitprovider.StringVar("removed.txt")},
+	})
+	if err != nil {
+		t.Fatalf("Commits().Create returned error: %v", err)
+	}
+	if info := got.Get(); info.Sha != "new-sha" || info.Message != "update files" {
+		t.Errorf("Commits().Create() = %+v, want new commit", info)
+	}
+}
+
+func TestBranchCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *RefUpdateResult
+		wantErr error
+	}{
+		{
+			name:   "created",
+			result: &RefUpdateResult{Name: "refs/heads/feature", Success: true, UpdateStatus: "succeeded"},
+		},
+		{
+			name:    "already exists",
+			result:  &RefUpdateResult{Name: "refs/heads/feature", UpdateStatus: refUpdateStatusStaleOldObjectID},
+			wantErr: gitprovider.ErrAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, http.StatusOK, testRepository())
+			})
+			mux.HandleFunc(repoPath+"/refs", func(w http.ResponseWriter, r *http.Request) {
+				req := []*RefUpdate{}
+				decodeJSON(t, r, &req)
+				want := []*RefUpdate{{Name: "refs/heads/feature", OldObjectID: emptyObjectID, NewObjectID: commitSHA}}
+				if diff := cmp.Diff(want, req); diff != "" {
+					t.Errorf("UpdateRefs request mismatch (-want +got):\n%s", diff)
+				}
+				writeList(t, w, []*RefUpdateResult{tt.result})
+			})
+
+			repo, err := c.OrgRepositories().Get(context.Background(), repoRef(c))
+			if err != nil {
+				t.Fatalf("OrgRepositories().Get returned error: %v", err)
+			}
+			err = repo.Branches().Create(context.Background(), "feature", commitSHA)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Branches().Create() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Branches().Create() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestBranches(t *testing.T) {
+	mux, c := setup(t, gitprovider.WithDestructiveAPICalls(true))
+	refs := []*Ref{
+		{Name: "refs/heads/main", ObjectID: commitSHA, IsLocked: true},
+		{Name: "refs/heads/feature", ObjectID: treeSHA},
+	}
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			req := &Repository{}
+			decodeJSON(t, r, req)
+			if diff := cmp.Diff(&Repository{DefaultBranch: "refs/heads/feature"}, req); diff != "" {
+				t.Errorf("UpdateRepo request mismatch (-want +got):\n%s", diff)
+			}
+		}
+		writeJSON(t, w, http.StatusOK, testRepository())
+	})
+	mux.HandleFunc(repoPath+"/refs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			req := []*RefUpdate{}
+			decodeJSON(t, r, &req)
+			want := []*RefUpdate{{Name: "refs/heads/feature", OldObjectID: treeSHA, NewObjectID: emptyObjectID}}
+			if diff := cmp.Diff(want, req); diff != "" {
+				t.Errorf("UpdateRefs request mismatch (-want +got):\n%s", diff)
+			}
+			writeList(t, w, []*RefUpdateResult{{Name: "refs/heads/feature", Success: true}})
+			return
+		}
+		filtered := []*Ref{}
+		for _, ref := range refs {
+			if strings.HasPrefix(ref.Name, "refs/"+r.URL.Query().Get("filter")) {
+				filtered = append(filtered, ref)
+			}
+		}
+		writeList(t, w, filtered)
+	})
+
+	ctx := context.Background()
+	repo, err := c.OrgRepositories().Get(ctx, repoRef(c))
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+
+	branches, err := repo.Branches().List(ctx)
+	if err != nil {
+		t.Fatalf("Branches().List returned error: %v", err)
+	}
+	if len(branches) != 2 {
+		t.Errorf("Branches().List returned %d branches, want 2", len(branches))
+	}
+	branch, err := repo.Branches().Get(ctx, "main")
+	if err != nil {
+		t.Fatalf("Branches().Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(gitprovider.BranchInfo{Name: "main", Sha: commitSHA, Protected: true}, branch.Get()); diff != "" {
+		t.Errorf("Branches().Get mismatch (-want +got):\n%s", diff)
+	}
+	if err := repo.Branches().SetDefault(ctx, "feature"); err != nil {
+		t.Errorf("Branches().SetDefault returned error: %v", err)
+	}
+	if err := repo.Branches().SetDefault(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches().SetDefault() error = %v, want ErrNotFound", err)
+	}
+	if err := repo.Branches().Delete(ctx, "feature"); err != nil {
+		t.Errorf("Branches().Delete returned error: %v", err)
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testRepository())
+	})
+	mux.HandleFunc(repoPath+"/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			q := r.URL.Query()
+			if q.Get("searchCriteria.status") != pullRequestStatusAbandoned || q.Get("searchCriteria.targetRefName") != "refs/heads/main" {
+				t.Errorf("ListPullRequests query = %q, want abandoned pull requests targeting main", r.URL.RawQuery)
+			}
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"count": 1,
+				"value": []*PullRequest{{PullRequestID: 6, Status: pullRequestStatusAbandoned, TargetRefName: "refs/heads/main"}},
+			})
+			return
+		}
+		req := &PullRequest{}
+		decodeJSON(t, r, req)
+		if req.SourceRefName != "refs/heads/feature" || req.TargetRefName != "refs/heads/main" {
+			t.Errorf("CreatePullRequest refs = %q -> %q, want feature -> main", req.SourceRefName, req.TargetRefName)
+		}
+		req.PullRequestID = 7
+		req.Status = pullRequestStatusActive
+		req.Repository = testRepository()
+		writeJSON(t, w, http.StatusCreated, req)
+	})
+	mux.HandleFunc(repoPath+"/pullrequests/7", func(w http.ResponseWriter, r *http.Request) {
+		pr := &PullRequest{
+			PullRequestID:         7,
+			Status:                pullRequestStatusActive,
+			LastMergeSourceCommit: &Commit{CommitID: commitSHA},
+			Repository:            testRepository(),
+		}
+		if r.Method == http.MethodPatch {
+			req := &PullRequest{}
+			decodeJSON(t, r, req)
+			if req.Status == pullRequestStatusAbandoned {
+				pr.Status = pullRequestStatusAbandoned
+				writeJSON(t, w, http.StatusOK, pr)
+				return
+			}
+			if req.Status != pullRequestStatusCompleted || req.LastMergeSourceCommit == nil ||
+				req.LastMergeSourceCommit.CommitID != commitSHA || req.CompletionOptions == nil ||
+				req.CompletionOptions.MergeStrategy != "squash" || req.CompletionOptions.MergeCommitMessage != "squashed" {
+				t.Errorf("UpdatePullRequest request = %+v, want squash completion", req)
+			}
+			pr.Status = pullRequestStatusCompleted
+		}
+		writeJSON(t, w, http.StatusOK, pr)
+	})
+	ctx := context.Background()
+
+	repo, err := c.OrgRepositories().Get(ctx, repoRef(c))
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	pr, err := repo.PullRequests().Create(ctx, "title", "feature", "main", "description")
+	if err != nil {
+		t.Fatalf("PullRequests().Create returned error: %v", err)
+	}
+	info := pr.Get()
+	if info.Number != 7 || info.Merged || info.WebURL != testRepository().WebURL+"/pullrequest/7" {
+		t.Errorf("PullRequests().Create() = %+v, want open pull request 7", info)
+	}
+
+	if err := repo.PullRequests().Merge(ctx, 7, gitprovider.MergeMethodSquash, "squashed"); err != nil {
+		t.Fatalf("PullRequests().Merge returned error: %v", err)
+	}
+	if err := repo.PullRequests().Merge(ctx, 7, gitprovider.MergeMethodFastForwardOnly, ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("PullRequests().Merge() with unsupported method error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+
+	prs, err := repo.PullRequests().List(ctx, &gitprovider.PullRequestListOptions{
+		State:        gitprovider.PullRequestStateVar(gitprovider.PullRequestStateClosed),
+		TargetBranch: gitprovider.StringVar("main"),
+	})
+	if err != nil {
+		t.Fatalf("PullRequests().List returned error: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().State != gitprovider.PullRequestStateClosed || prs[0].Get().TargetBranch != "main" {
+		t.Errorf("PullRequests().List() = %v, want closed pull request 6", prs)
+	}
+	if err := repo.PullRequests().Close(ctx, 7); err != nil {
+		t.Fatalf("PullRequests().Close returned error: %v", err)
+	}
+}
+
+func TestFilesAndTrees(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testRepository())
+	})
+	mux.HandleFunc(repoPath+"/items", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("$format") == "octetStream" {
+			_, _ = w.Write([]byte("content of " + q.Get("path")))
+			return
+		}
+		writeList(t, w, []*Item{
+			{Path: "/dir", IsFolder: true, GitObjectType: "tree"},
+			{Path: "/dir/a.txt", GitObjectType: gitObjectTypeBlob},
+			{Path: "/dir/b.txt", GitObjectType: gitObjectTypeBlob},
+		})
+	})
+	mux.HandleFunc(repoPath+"/trees/"+treeSHA, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("recursive"); got != "true" {
+			t.Errorf("recursive = %q, want %q", got, "true")
+		}
+		writeJSON(t, w, http.StatusOK, &Tree{
+			ObjectID: treeSHA,
+			TreeEntries: []*TreeEntry{
+				{ObjectID: "1", RelativePath: "dir", Mode: "040000", GitObjectType: "tree"},
+				{ObjectID: "2", RelativePath: "dir/a.txt", Mode: "100644", GitObjectType: gitObjectTypeBlob, Size: 3},
+				{ObjectID: "3", RelativePath: "other.txt", Mode: "100644", GitObjectType: gitObjectTypeBlob, Size: 4},
+			},
+		})
+	})
+	ctx := context.Background()
+
+	repo, err := c.OrgRepositories().Get(ctx, repoRef(c))
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	files, err := repo.Files().Get(ctx, "dir", "main")
+	if err != nil {
+		t.Fatalf("Files().Get returned error: %v", err)
+	}
+	var got []string
+	for _, file := range files {
+		got = append(got, *file.Path+": "+*file.Content)
+	}
+	want := []string{"dir/a.txt: content of /dir/a.txt", "dir/b.txt: content of /dir/b.txt"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Files().Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	entries, err := repo.Trees().List(ctx, treeSHA, "dir", true)
+	if err != nil {
+		t.Fatalf("Trees().List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "dir/a.txt" || entries[0].Size != 3 {
+		t.Errorf("Trees().List() = %+v, want dir/a.txt", entries)
+	}
+}
+
+func TestTeamAccess(t *testing.T) {
+	mux, c := setup(t)
+	allow := 0
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testRepository())
+	})
+	mux.HandleFunc("/org/_apis/identities", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("filterValue"), `[project]\Contributors`; got != want {
+			t.Errorf("filterValue = %q, want %q", got, want)
+		}
+		writeList(t, w, []*Identity{{
+			ID:                  "group",
+			Descriptor:          descriptor,
+			ProviderDisplayName: `[project]\Contributors`,
+			IsContainer:         true,
+		}})
+	})
+	token := "repoV2/" + projectID + "/" + repoID
+	mux.HandleFunc(securityURL, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("token"); got != token {
+			t.Errorf("token = %q, want %q", got, token)
+		}
+		acl := &AccessControlList{Token: token, AcesDictionary: map[string]*AccessControlEntry{}}
+		if allow != 0 {
+			acl.AcesDictionary[descriptor] = &AccessControlEntry{Descriptor: descriptor, Allow: allow}
+		}
+		writeList(t, w, []*AccessControlList{acl})
+	})
+	mux.HandleFunc("/org/_apis/accesscontrolentries/"+gitRepositoriesSecurityNamespace, func(w http.ResponseWriter, r *http.Request) {
+		req := &accessControlEntriesRequest{}
+		decodeJSON(t, r, req)
+		if req.Token != token || len(req.AccessControlEntries) != 1 {
+			t.Fatalf("SetAccessControlEntry request = %+v, want one entry for %s", req, token)
+		}
+		allow = req.AccessControlEntries[0].Allow
+		writeList(t, w, req.AccessControlEntries)
+	})
+	ctx := context.Background()
+
+	repo, err := c.OrgRepositories().Get(ctx, repoRef(c))
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if _, err := repo.TeamAccess().Get(ctx, "Contributors"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Fatalf("TeamAccess().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	req := gitprovider.TeamAccessInfo{
+		Name:       "Contributors",
+		Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionMaintain),
+	}
+	_, actionTaken, err := repo.TeamAccess().Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("TeamAccess().Reconcile returned error: %v", err)
+	}
+	if !actionTaken || allow != maintainPermissions {
+		t.Errorf("Reconcile() actionTaken = %v, allow = %d, want %d", actionTaken, allow, maintainPermissions)
+	}
+
+	ta, err := repo.TeamAccess().Get(ctx, "Contributors")
+	if err != nil {
+		t.Fatalf("TeamAccess().Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(req, ta.Get()); diff != "" {
+		t.Errorf("TeamAccess().Get() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUserAccess(t *testing.T) {
+	mux, c := setup(t)
+	allow := 0
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, testRepository())
+	})
+	mux.HandleFunc("/org/_apis/identities", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filterValue"); got != "" && got != "alice@example.com" {
+			t.Errorf("filterValue = %q, want %q", got, "alice@example.com")
+		}
+		writeList(t, w, []*Identity{{
+			ID:                  "alice",
+			Descriptor:          descriptor,
+			ProviderDisplayName: "Alice",
+			Properties: map[string]IdentityProperty{
+				"Account": {Type: "System.String", Value: "alice@example.com"},
+			},
+		}})
+	})
+	token := "repoV2/" + projectID + "/" + repoID
+	mux.HandleFunc(securityURL, func(w http.ResponseWriter, r *http.Request) {
+		acl := &AccessControlList{Token: token, AcesDictionary: map[string]*AccessControlEntry{}}
+		if allow != 0 {
+			acl.AcesDictionary[descriptor] = &AccessControlEntry{Descriptor: descriptor, Allow: allow}
+		}
+		writeList(t, w, []*AccessControlList{acl})
+	})
+	mux.HandleFunc("/org/_apis/accesscontrolentries/"+gitRepositoriesSecurityNamespace, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			allow = 0
+			writeJSON(t, w, http.StatusOK, true)
+			return
+		}
+		req := &accessControlEntriesRequest{}
+		decodeJSON(t, r, req)
+		allow = req.AccessControlEntries[0].Allow
+		writeList(t, w, req.AccessControlEntries)
+	})
+	ctx := context.Background()
+
+	repo, err := c.OrgRepositories().Get(ctx, repoRef(c))
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if _, err := repo.UserAccess().Get(ctx, "alice@example.com"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Fatalf("UserAccess().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	req := gitprovider.UserAccessInfo{
+		Login:      "alice@example.com",
+		Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPush),
+	}
+	_, actionTaken, err := repo.UserAccess().Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("UserAccess().Reconcile returned error: %v", err)
+	}
+	if !actionTaken || allow != pushPermissions {
+		t.Errorf("Reconcile() actionTaken = %v, allow = %d, want %d", actionTaken, allow, pushPermissions)
+	}
+
+	list, err := repo.UserAccess().List(ctx)
+	if err != nil {
+		t.Fatalf("UserAccess().List returned error: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("UserAccess().List() = %d entries, want 1", len(list))
+	}
+	if diff := cmp.Diff(req, list[0].Get()); diff != "" {
+		t.Errorf("UserAccess().List() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := list[0].Delete(ctx); err != nil {
+		t.Fatalf("UserAccess().Delete returned error: %v", err)
+	}
+	if allow != 0 {
+		t.Errorf("Delete() allow = %d, want 0", allow)
+	}
+}
+
+func TestGetGitProviderPermission(t *testing.T) {
+	for _, level := range []gitprovider.RepositoryPermission{
+		gitprovider.RepositoryPermissionPull,
+		gitprovider.RepositoryPermissionTriage,
+		gitprovider.RepositoryPermissionPush,
+		gitprovider.RepositoryPermissionMaintain,
+		gitprovider.RepositoryPermissionAdmin,
+	} {
+		allow, err := getAzurePermission(level)
+		if err != nil {
+			t.Fatalf("getAzurePermission(%s) returned error: %v", level, err)
+		}
+		got, err := getGitProviderPermission(allow)
+		if err != nil {
+			t.Fatalf("getGitProviderPermission(%d) returned error: %v", allow, err)
+		}
+		if *got != level {
+			t.Errorf("getGitProviderPermission(%d) = %s, want %s", allow, *got, level)
+		}
+	}
+	if _, err := getGitProviderPermission(permissionGenericContribute); !errors.Is(err, gitprovider.ErrInvalidPermissionLevel) {
+		t.Errorf("getGitProviderPermission() error = %v, want %v", err, gitprovider.ErrInvalidPermissionLevel)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+// azureStandIn emulates the project and repository endpoints of Azure DevOps, with a single
+// private project "project" in the organization "org".
+type azureStandIn struct {
+	t      *testing.T
+	mu     sync.Mutex
+	repos  []*Repository
+	lastID int
+}
+
+func TestConformance(t *testing.T) {
+	s := &azureStandIn{t: t}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewClient(append([]gitprovider.ClientOption{
+				gitprovider.WithDomain(server.URL),
+				gitprovider.WithPersonalAccessToken("token"),
+			}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: gitprovider.OrganizationRef{
+			Domain:           server.URL,
+			Organization:     "org",
+			SubOrganizations: []string{"project"},
+		},
+		Skip: map[string]string{
+			"CreateDefaulting":     "new repositories are empty, and have no default branch until the first push",
+			"ReconcileIdempotency": "repositories have no description, and inherit their visibility from the project",
+		},
+	})
+}
+
+func (s *azureStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := &Project{ID: projectID, Name: "project", Visibility: projectVisibilityPrivate}
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/org/_apis/projects/project" && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, project)
+	case r.URL.Path == "/org/project/_apis/git/repositories" && r.Method == http.MethodGet:
+		writeList(s.t, w, s.repos)
+	case r.URL.Path == "/org/project/_apis/git/repositories" && r.Method == http.MethodPost:
+		req := &Repository{}
+		decodeJSON(s.t, r, req)
+		if s.repo(req.Name) != nil {
+			writeJSON(s.t, w, http.StatusConflict, &ErrorResponse{
+				Message: fmt.Sprintf("TF400948: A Git repository with the name %s already exists.", req.Name),
+				TypeKey: "GitRepositoryNameAlreadyExistsException",
+			})
+			return
+		}
+		s.lastID++
+		repo := &Repository{
+			ID:      fmt.Sprintf("00000000-0000-0000-0000-%012d", s.lastID),
+			Name:    req.Name,
+			Project: project,
+		}
+		s.repos = append(s.repos, repo)
+		writeJSON(s.t, w, http.StatusCreated, repo)
+	case len(path) == 6 && strings.HasPrefix(r.URL.Path, "/org/project/_apis/git/repositories/"):
+		repo := s.repo(path[5])
+		if repo == nil {
+			writeJSON(s.t, w, http.StatusNotFound, &ErrorResponse{Message: "TF401019: The Git repository doesn't exist."})
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(s.t, w, http.StatusOK, repo)
+		case http.MethodPatch:
+			req := &Repository{}
+			decodeJSON(s.t, r, req)
+			if req.DefaultBranch != "" {
+				repo.DefaultBranch = req.DefaultBranch
+			}
+			writeJSON(s.t, w, http.StatusOK, repo)
+		case http.MethodDelete:
+			for i := range s.repos {
+				if s.repos[i] == repo {
+					s.repos = append(s.repos[:i], s.repos[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	default:
+		writeJSON(s.t, w, http.StatusNotFound, &ErrorResponse{Message: "Not Found"})
+	}
+}
+
+// repo returns the repository with the given ID or name, or nil.
+func (s *azureStandIn) repo(idOrName string) *Repository {
+	for _, repo := range s.repos {
+		if repo.ID == idOrName || repo.Name == idOrName {
+			return repo
+		}
+	}
+	return nil
+}
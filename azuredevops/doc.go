@@ -0,0 +1,27 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package azuredevops implements the gitprovider.Client interface for Azure DevOps Services
+// and Azure DevOps Server, using the Azure Repos REST API.
+//
+// An Azure DevOps organization (or project collection on Azure DevOps Server) is mapped to
+// a top-level organization, and its projects are mapped to sub-organizations. Repositories
+// always belong to a project, hence they are referred to using an OrgRepositoryRef with
+// exactly one sub-organization, e.g. "dev.azure.com/my-org/my-project/my-repo".
+//
+// Security groups of a project (including the groups backing its teams) are mapped to the
+// TeamAccessClient of a repository, using the permissions of the Git Repositories security namespace.
+package azuredevops
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranch(c *BranchClient, ref *Ref) *branchType {
+	return &branchType{
+		r: *ref,
+		c: c,
+	}
+}
+
+var _ gitprovider.Branch = &branchType{}
+
+type branchType struct {
+	r Ref
+	c *BranchClient
+}
+
+func (b *branchType) Get() gitprovider.BranchInfo {
+	return branchFromAPI(&b.r)
+}
+
+func (b *branchType) APIObject() interface{} {
+	return &b.r
+}
+
+// branchFromAPI converts the reference of a branch. Branch policies aren't part of the
+// reference, hence only locked branches are reported as protected.
+func branchFromAPI(apiObj *Ref) gitprovider.BranchInfo {
+	return gitprovider.BranchInfo{
+		Name:      branchName(apiObj.Name),
+		Sha:       apiObj.ObjectID,
+		Protected: apiObj.IsLocked,
+	}
+}
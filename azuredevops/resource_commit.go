@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// emptyObjectID is used as the old object ID of references that don't exist yet,
+	// and as the new object ID of references to delete.
+	emptyObjectID = "0000000000000000000000000000000000000000"
+
+	changeTypeAdd    = "add"
+	changeTypeEdit   = "edit"
+	changeTypeDelete = "delete"
+	changeTypeRename = "rename"
+
+	contentTypeRawText       = "rawtext"
+	contentTypeBase64Encoded = "base64encoded"
+)
+
+func newCommit(c *CommitClient, commit *Commit) *commitType {
+	return &commitType{
+		k: *commit,
+		c: c,
+	}
+}
+
+var _ gitprovider.Commit = &commitType{}
+
+type commitType struct {
+	k Commit
+	c *CommitClient
+}
+
+func (c *commitType) Get() gitprovider.CommitInfo {
+	return commitFromAPI(&c.k)
+}
+
+func (c *commitType) APIObject() interface{} {
+	return &c.k
+}
+
+func commitFromAPI(apiObj *Commit) gitprovider.CommitInfo {
+	info := gitprovider.CommitInfo{
+		Sha:     apiObj.CommitID,
+		TreeSha: apiObj.TreeID,
+		Message: apiObj.Comment,
+		URL:     apiObj.RemoteURL,
+	}
+	if apiObj.Author != nil {
+		info.Author = apiObj.Author.Name
+		info.CreatedAt = apiObj.Author.Date
+	}
+	return info
+}
+
+// validateCommitAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateCommitAPI(apiObj *Commit) error {
+	return validateAPIObject("AzureDevOps.Commit", func(validator validation.Validator) {
+		if apiObj.CommitID == "" {
+			validator.Required("CommitID")
+		}
+	})
+}
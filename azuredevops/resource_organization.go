@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newOrganization(ctx *clientContext, apiObj *ConnectionData, ref gitprovider.OrganizationRef) *organization {
+	return &organization{
+		clientContext: ctx,
+		cd:            apiObj,
+		ref:           ref,
+		teams: &TeamsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+func newProjectOrganization(ctx *clientContext, apiObj *Project, ref gitprovider.OrganizationRef) *organization {
+	return &organization{
+		clientContext: ctx,
+		p:             apiObj,
+		ref:           ref,
+		teams: &TeamsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+var _ gitprovider.Organization = &organization{}
+
+// organization is either an Azure DevOps organization (cd is set),
+// or a project of an organization (p is set).
+type organization struct {
+	*clientContext
+
+	cd  *ConnectionData
+	p   *Project
+	ref gitprovider.OrganizationRef
+
+	teams *TeamsClient
+}
+
+func (o *organization) Get() gitprovider.OrganizationInfo {
+	if o.p != nil {
+		return projectFromAPI(o.p)
+	}
+	return gitprovider.OrganizationInfo{
+		Name: gitprovider.StringVar(o.ref.Organization),
+	}
+}
+
+// APIObject returns the underlying value that was returned from the server.
+// This is a *Project for projects, and a *ConnectionData for organizations.
+func (o *organization) APIObject() interface{} {
+	if o.p != nil {
+		return o.p
+	}
+	return o.cd
+}
+
+func (o *organization) Organization() gitprovider.OrganizationRef {
+	return o.ref
+}
+
+func (o *organization) Teams() gitprovider.TeamsClient {
+	return o.teams
+}
+
+func projectFromAPI(apiObj *Project) gitprovider.OrganizationInfo {
+	return gitprovider.OrganizationInfo{
+		Name:        gitprovider.StringVar(apiObj.Name),
+		Description: gitprovider.StringVar(apiObj.Description),
+	}
+}
+
+// Set returns gitprovider.ErrNoProviderSupport, as updating organizations and projects isn't supported.
+func (o *organization) Set(_ gitprovider.OrganizationInfo) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Update returns gitprovider.ErrNoProviderSupport.
+func (o *organization) Update(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport.
+func (o *organization) Reconcile(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// Delete returns gitprovider.ErrNoProviderSupport.
+func (o *organization) Delete(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	pullRequestStatusActive    = "active"
+	pullRequestStatusAbandoned = "abandoned"
+	pullRequestStatusCompleted = "completed"
+	pullRequestStatusAll       = "all"
+
+	// pullRequestMergeStatusSucceeded and pullRequestMergeStatusConflicts are the outcomes of the
+	// merge Azure DevOps attempts whenever a pull request changes.
+	pullRequestMergeStatusSucceeded = "succeeded"
+	pullRequestMergeStatusConflicts = "conflicts"
+)
+
+// pullRequestStatuses maps pull request states to the statuses of Azure DevOps pull requests.
+//
+//nolint:gochecknoglobals
+var pullRequestStatuses = map[gitprovider.PullRequestState]string{
+	gitprovider.PullRequestStateOpen:   pullRequestStatusActive,
+	gitprovider.PullRequestStateClosed: pullRequestStatusAbandoned,
+	gitprovider.PullRequestStateMerged: pullRequestStatusCompleted,
+}
+
+func newPullRequest(ctx *clientContext, apiObj *PullRequest) *pullrequest {
+	return &pullrequest{
+		clientContext: ctx,
+		pr:            *apiObj,
+	}
+}
+
+var _ gitprovider.PullRequest = &pullrequest{}
+
+type pullrequest struct {
+	*clientContext
+
+	pr PullRequest
+}
+
+func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
+	return pullrequestFromAPI(&pr.pr)
+}
+
+func (pr *pullrequest) APIObject() interface{} {
+	return &pr.pr
+}
+
+// Comments gives access to the general comments of this pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{clientContext: pr.clientContext}
+}
+
+// Reviews gives access to the reviews of this pull request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return &PullRequestReviewClient{clientContext: pr.clientContext}
+}
+
+func pullrequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
+	info := gitprovider.PullRequestInfo{
+		Merged:       apiObj.Status == pullRequestStatusCompleted,
+		Number:       apiObj.PullRequestID,
+		Title:        apiObj.Title,
+		Description:  apiObj.Description,
+		State:        gitprovider.PullRequestStateOpen,
+		Draft:        apiObj.IsDraft,
+		SourceBranch: branchName(apiObj.SourceRefName),
+		TargetBranch: branchName(apiObj.TargetRefName),
+	}
+	switch apiObj.Status {
+	case pullRequestStatusCompleted:
+		info.State = gitprovider.PullRequestStateMerged
+	case pullRequestStatusAbandoned:
+		info.State = gitprovider.PullRequestStateClosed
+	}
+	switch apiObj.MergeStatus {
+	case pullRequestMergeStatusSucceeded:
+		info.Mergeable = gitprovider.BoolVar(true)
+	case pullRequestMergeStatusConflicts:
+		info.Mergeable = gitprovider.BoolVar(false)
+	}
+	if apiObj.LastMergeSourceCommit != nil {
+		info.HeadSHA = apiObj.LastMergeSourceCommit.CommitID
+	}
+	if apiObj.LastMergeTargetCommit != nil {
+		info.BaseSHA = apiObj.LastMergeTargetCommit.CommitID
+	}
+	if apiObj.CreatedBy != nil {
+		info.Author = apiObj.CreatedBy.UniqueName
+	}
+	// Azure DevOps doesn't track when a pull request was last updated
+	if apiObj.CreationDate != nil {
+		info.CreatedAt = *apiObj.CreationDate
+	}
+	// The API only returns the REST URL of the pull request, the web URL is derived from the repository
+	if apiObj.Repository != nil && apiObj.Repository.WebURL != "" {
+		info.WebURL = fmt.Sprintf("%s/pullrequest/%d", apiObj.Repository.WebURL, apiObj.PullRequestID)
+	}
+	return info
+}
+
+// validatePullRequestAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validatePullRequestAPI(apiObj *PullRequest) error {
+	return validateAPIObject("AzureDevOps.PullRequest", func(validator validation.Validator) {
+		if apiObj.PullRequestID == 0 {
+			validator.Required("PullRequestID")
+		}
+	})
+}
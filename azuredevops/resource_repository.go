@@ -0,0 +1,361 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	projectVisibilityPrivate = "private"
+	projectVisibilityPublic  = "public"
+)
+
+func newOrgRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.OrgRepositoryRef) *orgRepository {
+	return &orgRepository{
+		clientContext: ctx,
+		r:             *apiObj,
+		ref:           ref,
+		deployKeys: &DeployKeyClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		userAccess: &UserAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		commits: &CommitClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branches: &BranchClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		pullRequests: &PullRequestClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		statuses: &CommitStatusClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		files: &FileClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		trees: &TreeClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		teamAccess: &TeamAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+var _ gitprovider.OrgRepository = &orgRepository{}
+
+type orgRepository struct {
+	*clientContext
+
+	r   Repository
+	ref gitprovider.OrgRepositoryRef
+
+	deployKeys        *DeployKeyClient
+	userAccess        *UserAccessClient
+	commits           *CommitClient
+	branches          *BranchClient
+	branchProtections *BranchProtectionClient
+	webhooks          *WebhookClient
+	pullRequests      *PullRequestClient
+	tags              *TagClient
+	releases          *ReleaseClient
+	statuses          *CommitStatusClient
+	files             *FileClient
+	trees             *TreeClient
+	teamAccess        *TeamAccessClient
+}
+
+func (r *orgRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.r)
+}
+
+// Set sets the desired state of this object.
+// User have to call Update() to apply the changes to the server.
+// The changes will then be reflected in the internal API object.
+func (r *orgRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := validateRepositoryInfo(info, r.r.Project); err != nil {
+		return err
+	}
+	repositoryInfoToAPIObj(&info, &r.r)
+	return nil
+}
+
+func (r *orgRepository) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *orgRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+// DeployKeys gives access to manipulate deploy keys to access this specific repository.
+// Deploy keys aren't supported in Azure DevOps.
+func (r *orgRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+// UserAccess gives access to manipulate the explicit permissions of individual users for this specific repository.
+func (r *orgRepository) UserAccess() gitprovider.UserAccessClient {
+	return r.userAccess
+}
+
+func (r *orgRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *orgRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *orgRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *orgRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *orgRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *orgRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
+func (r *orgRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *orgRepository) Statuses() gitprovider.CommitStatusClient {
+	return r.statuses
+}
+
+func (r *orgRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *orgRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
+	return r.teamAccess
+}
+
+// Update will apply the desired state in this object to the server.
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (r *orgRepository) Update(ctx context.Context) error {
+	org, project, _ := repositoryPath(r.ref)
+	// PATCH /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	apiObj, err := r.c.UpdateRepo(ctx, org, project, r.repositoryID(), newRepositorySpec(&r.r).Repository)
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (r *orgRepository) Reconcile(ctx context.Context) (bool, error) {
+	org, project, name := repositoryPath(r.ref)
+	apiObj, err := r.c.GetRepo(ctx, org, project, name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			repo, err := createRepository(ctx, r.c, r.ref, r.Get())
+			if err != nil {
+				return true, err
+			}
+			r.r = *repo
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	// Use wrappers here to extract the "spec" part of the object for comparison
+	desiredSpec := newRepositorySpec(&r.r)
+	actualSpec := newRepositorySpec(apiObj)
+
+	// If desired state already is the actual state, do nothing
+	if desiredSpec.Equals(actualSpec) {
+		return false, nil
+	}
+	// Otherwise, make the desired state the actual state
+	r.r.ID = apiObj.ID
+	return true, r.Update(ctx)
+}
+
+// Delete deletes the current resource irreversibly.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *orgRepository) Delete(ctx context.Context) error {
+	org, project, _ := repositoryPath(r.ref)
+	// DELETE /{organization}/{project}/_apis/git/repositories/{repositoryId}
+	return r.c.DeleteRepo(ctx, org, project, r.repositoryID())
+}
+
+// repositoryID returns the ID of the repository, or its name if the ID isn't known.
+func (r *orgRepository) repositoryID() string {
+	if r.r.ID != "" {
+		return r.r.ID
+	}
+	return r.ref.RepositoryName
+}
+
+// validateRepositoryInfo makes sure the RepositoryInfo is valid, and that it can be represented in Azure DevOps.
+// Repositories don't have a description, and inherit their visibility from project, if known.
+func validateRepositoryInfo(info gitprovider.RepositoryInfo, project *Project) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Description != nil && *info.Description != "" {
+		return fmt.Errorf("azure devops repositories don't have a description: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.Visibility == nil {
+		return nil
+	}
+	if *info.Visibility == gitprovider.RepositoryVisibilityInternal {
+		return fmt.Errorf("azure devops doesn't support internal repositories: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if visibility := projectVisibility(project); visibility != nil && *visibility != *info.Visibility {
+		return fmt.Errorf("repositories inherit the %s visibility of project %q: %w", *visibility, project.Name, gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRepositoryAPI(apiObj *Repository) error {
+	return validateAPIObject("AzureDevOps.Repository", func(validator validation.Validator) {
+		if apiObj.ID == "" {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// projectVisibility returns the visibility of the repositories in project, if known.
+func projectVisibility(project *Project) *gitprovider.RepositoryVisibility {
+	if project == nil {
+		return nil
+	}
+	switch project.Visibility {
+	case projectVisibilityPrivate:
+		return gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate)
+	case projectVisibilityPublic:
+		return gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic)
+	}
+	return nil
+}
+
+func repositoryFromAPI(apiObj *Repository) gitprovider.RepositoryInfo {
+	repo := gitprovider.RepositoryInfo{
+		Visibility: projectVisibility(apiObj.Project),
+	}
+	// Empty repositories don't have a default branch
+	if apiObj.DefaultBranch != "" {
+		repo.DefaultBranch = gitprovider.StringVar(branchName(apiObj.DefaultBranch))
+	}
+	return repo
+}
+
+// repositoryToAPI returns the request to create the repository of ref in project.
+// New repositories are empty, hence their default branch can only be set by pushing to it.
+func repositoryToAPI(ref gitprovider.OrgRepositoryRef, project *Project) *Repository {
+	return &Repository{
+		Name:    ref.RepositoryName,
+		Project: &Project{ID: project.ID},
+	}
+}
+
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository) {
+	if repo.DefaultBranch != nil {
+		apiObj.DefaultBranch = branchRef(*repo.DefaultBranch)
+	}
+}
+
+// This function copies over the fields that are part of create/update requests of a repository
+// i.e. the desired spec of the repository. This allows us to separate "spec" from "status" fields.
+// See also: https://learn.microsoft.com/en-us/rest/api/azure/devops/git/repositories/update?view=azure-devops-rest-7.0
+func newRepositorySpec(repo *Repository) *repositorySpec {
+	return &repositorySpec{
+		&Repository{
+			Name:          repo.Name,
+			DefaultBranch: repo.DefaultBranch,
+		},
+	}
+}
+
+type repositorySpec struct {
+	*Repository
+}
+
+func (s *repositorySpec) Equals(other *repositorySpec) bool {
+	return reflect.DeepEqual(s, other)
+}
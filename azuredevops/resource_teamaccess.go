@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// The permission bits of the Git Repositories security namespace.
+// See: https://learn.microsoft.com/en-us/azure/devops/organizations/security/namespace-reference#git-repositories
+const (
+	permissionAdminister              = 1
+	permissionGenericRead             = 2
+	permissionGenericContribute       = 4
+	permissionForcePush               = 8
+	permissionCreateBranch            = 16
+	permissionCreateTag               = 32
+	permissionManageNote              = 64
+	permissionPolicyExempt            = 128
+	permissionDeleteRepository        = 512
+	permissionRenameRepository        = 1024
+	permissionEditPolicies            = 2048
+	permissionRemoveOthersLocks       = 4096
+	permissionManagePermissions       = 8192
+	permissionPullRequestContribute   = 16384
+	permissionPullRequestBypassPolicy = 32768
+)
+
+// The sets of permission bits the repository permission levels are mapped to.
+// Each level includes all bits of the levels below it.
+const (
+	pullPermissions     = permissionGenericRead
+	triagePermissions   = pullPermissions | permissionPullRequestContribute
+	pushPermissions     = triagePermissions | permissionGenericContribute | permissionCreateBranch | permissionCreateTag | permissionManageNote
+	maintainPermissions = pushPermissions | permissionForcePush | permissionEditPolicies | permissionRemoveOthersLocks
+	adminPermissions    = maintainPermissions | permissionAdminister | permissionManagePermissions | permissionRenameRepository |
+		permissionDeleteRepository | permissionPolicyExempt | permissionPullRequestBypassPolicy
+)
+
+// permissionMapping is ordered from the highest to the lowest permission level.
+//
+//nolint:gochecknoglobals
+var permissionMapping = []struct {
+	permissions int
+	level       gitprovider.RepositoryPermission
+}{
+	{adminPermissions, gitprovider.RepositoryPermissionAdmin},
+	{maintainPermissions, gitprovider.RepositoryPermissionMaintain},
+	{pushPermissions, gitprovider.RepositoryPermissionPush},
+	{triagePermissions, gitprovider.RepositoryPermissionTriage},
+	{pullPermissions, gitprovider.RepositoryPermissionPull},
+}
+
+func newTeamAccess(c *TeamAccessClient, apiObj *AccessControlEntry, name string) (*teamAccess, error) {
+	permission, err := getGitProviderPermission(apiObj.Allow)
+	if err != nil {
+		return nil, err
+	}
+	return &teamAccess{
+		ta: gitprovider.TeamAccessInfo{
+			Name:       name,
+			Permission: permission,
+		},
+		ace: *apiObj,
+		c:   c,
+	}, nil
+}
+
+var _ gitprovider.TeamAccess = &teamAccess{}
+
+type teamAccess struct {
+	ta  gitprovider.TeamAccessInfo
+	ace AccessControlEntry
+	c   *TeamAccessClient
+}
+
+func (ta *teamAccess) Get() gitprovider.TeamAccessInfo {
+	return ta.ta
+}
+
+func (ta *teamAccess) Set(info gitprovider.TeamAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ta.ta = info
+	return nil
+}
+
+func (ta *teamAccess) APIObject() interface{} {
+	return &ta.ace
+}
+
+func (ta *teamAccess) Repository() gitprovider.RepositoryRef {
+	return ta.c.ref
+}
+
+// Delete removes the explicit permissions of the security group from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ta *teamAccess) Delete(ctx context.Context) error {
+	token, err := securityToken(ctx, ta.c.clientContext, ta.c.ref)
+	if err != nil {
+		return err
+	}
+	// DELETE /{organization}/_apis/accesscontrolentries/{securityNamespaceId}?token={token}&descriptors={descriptor}
+	return ta.c.c.RemoveAccessControlEntry(ctx, ta.c.ref.Organization, token, ta.ace.Descriptor)
+}
+
+func (ta *teamAccess) Update(ctx context.Context) error {
+	// Update the actual state to be the desired state
+	// by issuing a Create, which replaces the access control entry.
+	resp, err := ta.c.Create(ctx, ta.Get())
+	if err != nil {
+		return err
+	}
+	ta.ace = *resp.APIObject().(*AccessControlEntry)
+	return ta.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ta *teamAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ta.Get()
+	actual, err := ta.c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, ta.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ta.Update(ctx)
+}
+
+// getGitProviderPermission returns the highest permission level whose permission bits are all allowed.
+func getGitProviderPermission(allow int) (*gitprovider.RepositoryPermission, error) {
+	for _, m := range permissionMapping {
+		if allow&m.permissions == m.permissions {
+			level := m.level
+			return &level, nil
+		}
+	}
+	return nil, gitprovider.ErrInvalidPermissionLevel
+}
+
+func getAzurePermission(permission gitprovider.RepositoryPermission) (int, error) {
+	for _, m := range permissionMapping {
+		if m.level == permission {
+			return m.permissions, nil
+		}
+	}
+	return 0, gitprovider.ErrInvalidPermissionLevel
+}
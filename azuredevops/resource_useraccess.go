@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUserAccess(c *UserAccessClient, apiObj *AccessControlEntry, login string) (*userAccess, error) {
+	permission, err := getGitProviderPermission(apiObj.Allow)
+	if err != nil {
+		return nil, err
+	}
+	return &userAccess{
+		ua: gitprovider.UserAccessInfo{
+			Login:      login,
+			Permission: permission,
+		},
+		ace: *apiObj,
+		c:   c,
+	}, nil
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	ua  gitprovider.UserAccessInfo
+	ace AccessControlEntry
+	c   *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return ua.ua
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ua.ua = info
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return &ua.ace
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited always returns false, as Azure DevOps grants access to organization members directly.
+func (ua *userAccess) Invited() bool {
+	return false
+}
+
+// Delete removes the explicit permissions of the user from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(ctx context.Context) error {
+	token, err := securityToken(ctx, ua.c.clientContext, ua.c.ref)
+	if err != nil {
+		return err
+	}
+	// DELETE /{organization}/_apis/accesscontrolentries/{securityNamespaceId}?token={token}&descriptors={descriptor}
+	return ua.c.c.RemoveAccessControlEntry(ctx, ua.c.ref.Organization, token, ua.ace.Descriptor)
+}
+
+func (ua *userAccess) Update(ctx context.Context) error {
+	// Update the actual state to be the desired state
+	// by issuing a Create, which replaces the access control entry.
+	resp, err := ua.c.Create(ctx, ua.Get())
+	if err != nil {
+		return err
+	}
+	ua.ace = *resp.APIObject().(*AccessControlEntry)
+	return ua.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ua.Get()
+	actual, err := ua.c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, ua.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ua.Update(ctx)
+}
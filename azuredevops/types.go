@@ -0,0 +1,267 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The types in this file model the JSON objects of the Azure DevOps REST API (version 7.0).
+// They are returned by the APIObject() methods of the resources in this package.
+// See: https://learn.microsoft.com/en-us/rest/api/azure/devops/?view=azure-devops-rest-7.0
+
+// ConnectionData describes the organization a connection is made to.
+type ConnectionData struct {
+	InstanceID        string    `json:"instanceId,omitempty"`
+	AuthenticatedUser *Identity `json:"authenticatedUser,omitempty"`
+}
+
+// Project is a team project, containing repositories and teams.
+type Project struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	State       string `json:"state,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+}
+
+// Repository is a Git repository in a project.
+type Repository struct {
+	ID            string   `json:"id,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	URL           string   `json:"url,omitempty"`
+	Project       *Project `json:"project,omitempty"`
+	DefaultBranch string   `json:"defaultBranch,omitempty"`
+	Size          int64    `json:"size,omitempty"`
+	RemoteURL     string   `json:"remoteUrl,omitempty"`
+	SSHURL        string   `json:"sshUrl,omitempty"`
+	WebURL        string   `json:"webUrl,omitempty"`
+	IsDisabled    bool     `json:"isDisabled,omitempty"`
+}
+
+// Identity is a user or group known to the organization.
+type Identity struct {
+	ID                  string `json:"id,omitempty"`
+	Descriptor          string `json:"descriptor,omitempty"`
+	SubjectDescriptor   string `json:"subjectDescriptor,omitempty"`
+	ProviderDisplayName string `json:"providerDisplayName,omitempty"`
+	IsActive            bool   `json:"isActive,omitempty"`
+	IsContainer         bool   `json:"isContainer,omitempty"`
+	// Properties holds e.g. the "Account" name of users.
+	Properties map[string]IdentityProperty `json:"properties,omitempty"`
+}
+
+// IdentityProperty is a typed property of an identity.
+type IdentityProperty struct {
+	Type  string      `json:"$type,omitempty"`
+	Value interface{} `json:"$value,omitempty"`
+}
+
+// IdentityRef is a reference to a user or group, embedded in other objects.
+type IdentityRef struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+	Descriptor  string `json:"descriptor,omitempty"`
+}
+
+// Team is a team of a project. Every team is backed by a security group.
+type Team struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	ProjectID   string `json:"projectId,omitempty"`
+	ProjectName string `json:"projectName,omitempty"`
+}
+
+// TeamMember is a member of a team.
+type TeamMember struct {
+	Identity    *IdentityRef `json:"identity,omitempty"`
+	IsTeamAdmin bool         `json:"isTeamAdmin,omitempty"`
+}
+
+// AccessControlList holds the access control entries of a securable resource, identified by a token.
+type AccessControlList struct {
+	Token              string                         `json:"token,omitempty"`
+	InheritPermissions bool                           `json:"inheritPermissions,omitempty"`
+	AcesDictionary     map[string]*AccessControlEntry `json:"acesDictionary,omitempty"`
+}
+
+// AccessControlEntry holds the allowed and denied permission bits of an identity.
+type AccessControlEntry struct {
+	Descriptor string `json:"descriptor,omitempty"`
+	Allow      int    `json:"allow"`
+	Deny       int    `json:"deny"`
+}
+
+// accessControlEntriesRequest is the request body for setting access control entries.
+type accessControlEntriesRequest struct {
+	Token                string                `json:"token"`
+	Merge                bool                  `json:"merge"`
+	AccessControlEntries []*AccessControlEntry `json:"accessControlEntries"`
+}
+
+// GitUserDate is the author or committer of a commit.
+type GitUserDate struct {
+	Name  string    `json:"name,omitempty"`
+	Email string    `json:"email,omitempty"`
+	Date  time.Time `json:"date,omitempty"`
+}
+
+// Commit is a Git commit.
+type Commit struct {
+	CommitID  string       `json:"commitId,omitempty"`
+	TreeID    string       `json:"treeId,omitempty"`
+	Comment   string       `json:"comment,omitempty"`
+	Author    *GitUserDate `json:"author,omitempty"`
+	Committer *GitUserDate `json:"committer,omitempty"`
+	URL       string       `json:"url,omitempty"`
+	RemoteURL string       `json:"remoteUrl,omitempty"`
+	Changes   []*Change    `json:"changes,omitempty"`
+}
+
+// Ref is a Git reference, e.g. a branch.
+type Ref struct {
+	Name     string `json:"name,omitempty"`
+	ObjectID string `json:"objectId,omitempty"`
+	IsLocked bool   `json:"isLocked,omitempty"`
+}
+
+// RefUpdate moves a Git reference from OldObjectID to NewObjectID.
+type RefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId,omitempty"`
+}
+
+// RefUpdateResult is the result of a RefUpdate.
+type RefUpdateResult struct {
+	Name          string `json:"name,omitempty"`
+	OldObjectID   string `json:"oldObjectId,omitempty"`
+	NewObjectID   string `json:"newObjectId,omitempty"`
+	Success       bool   `json:"success,omitempty"`
+	UpdateStatus  string `json:"updateStatus,omitempty"`
+	CustomMessage string `json:"customMessage,omitempty"`
+}
+
+// Push creates one or more commits and updates the given references.
+type Push struct {
+	PushID     int          `json:"pushId,omitempty"`
+	RefUpdates []*RefUpdate `json:"refUpdates,omitempty"`
+	Commits    []*Commit    `json:"commits,omitempty"`
+}
+
+// Change is a change to a single file in a pushed commit.
+type Change struct {
+	ChangeType       string       `json:"changeType"`
+	Item             *Item        `json:"item"`
+	NewContent       *ItemContent `json:"newContent,omitempty"`
+	SourceServerItem string       `json:"sourceServerItem,omitempty"`
+}
+
+// ItemContent is the new content of a file in a Change.
+type ItemContent struct {
+	Content     string `json:"content"`
+	ContentType string `json:"contentType"`
+}
+
+// Item is a file or folder in a repository.
+type Item struct {
+	ObjectID      string `json:"objectId,omitempty"`
+	GitObjectType string `json:"gitObjectType,omitempty"`
+	CommitID      string `json:"commitId,omitempty"`
+	Path          string `json:"path,omitempty"`
+	IsFolder      bool   `json:"isFolder,omitempty"`
+	URL           string `json:"url,omitempty"`
+}
+
+// Tree is a Git tree object.
+type Tree struct {
+	ObjectID    string       `json:"objectId,omitempty"`
+	TreeEntries []*TreeEntry `json:"treeEntries,omitempty"`
+	Size        int64        `json:"size,omitempty"`
+	URL         string       `json:"url,omitempty"`
+}
+
+// TreeEntry is an entry of a Tree.
+type TreeEntry struct {
+	ObjectID      string `json:"objectId,omitempty"`
+	RelativePath  string `json:"relativePath,omitempty"`
+	Mode          string `json:"mode,omitempty"`
+	GitObjectType string `json:"gitObjectType,omitempty"`
+	Size          int64  `json:"size,omitempty"`
+	URL           string `json:"url,omitempty"`
+}
+
+// PullRequest is a pull request of a repository.
+type PullRequest struct {
+	PullRequestID         int                    `json:"pullRequestId,omitempty"`
+	Status                string                 `json:"status,omitempty"`
+	Title                 string                 `json:"title,omitempty"`
+	Description           string                 `json:"description,omitempty"`
+	SourceRefName         string                 `json:"sourceRefName,omitempty"`
+	TargetRefName         string                 `json:"targetRefName,omitempty"`
+	MergeStatus           string                 `json:"mergeStatus,omitempty"`
+	IsDraft               bool                   `json:"isDraft,omitempty"`
+	CreatedBy             *IdentityRef           `json:"createdBy,omitempty"`
+	CreationDate          *time.Time             `json:"creationDate,omitempty"`
+	URL                   string                 `json:"url,omitempty"`
+	Repository            *Repository            `json:"repository,omitempty"`
+	LastMergeSourceCommit *Commit                `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *Commit                `json:"lastMergeTargetCommit,omitempty"`
+	CompletionOptions     *CompletionOptions     `json:"completionOptions,omitempty"`
+	AutoCompleteSetBy     *IdentityRef           `json:"autoCompleteSetBy,omitempty"`
+	Labels                []*WebAPITagDefinition `json:"labels,omitempty"`
+}
+
+// WebAPITagDefinition is a label of a pull request.
+type WebAPITagDefinition struct {
+	Name string `json:"name"`
+}
+
+// PullRequestSearchCriteria narrows down the pull requests returned by ListPullRequests.
+// Empty fields aren't filtered on, except Status which the server defaults to active.
+type PullRequestSearchCriteria struct {
+	Status        string
+	SourceRefName string
+	TargetRefName string
+}
+
+// CompletionOptions describe how a pull request is merged when it's completed.
+type CompletionOptions struct {
+	MergeStrategy      string `json:"mergeStrategy,omitempty"`
+	MergeCommitMessage string `json:"mergeCommitMessage,omitempty"`
+	DeleteSourceBranch bool   `json:"deleteSourceBranch,omitempty"`
+}
+
+// ErrorResponse is the body of an unsuccessful response.
+type ErrorResponse struct {
+	Message   string `json:"message"`
+	TypeName  string `json:"typeName"`
+	TypeKey   string `json:"typeKey"`
+	ErrorCode int    `json:"errorCode"`
+	EventID   int    `json:"eventId"`
+}
+
+// listResponse is the envelope of all list responses.
+type listResponse struct {
+	Count int             `json:"count"`
+	Value json.RawMessage `json:"value"`
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	alreadyExistsTypeKeySuffix = "AlreadyExistsException"
+	apiDocURL                  = "https://learn.microsoft.com/en-us/rest/api/azure/devops/"
+	branchRefPrefix            = "refs/heads/"
+)
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for Azure DevOps' usage.
+// Repositories always belong to a project, hence exactly one sub-organization is required.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	if len(ref.SubOrganizations) != 1 {
+		return fmt.Errorf("repositories must be referred to using their project as the only sub-organization: %w", gitprovider.ErrInvalidArgument)
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for Azure DevOps' usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization:
+		return nil
+	case gitprovider.IdentityTypeSuborganization:
+		// Projects are the only level of sub-organizations
+		if strings.Count(ref.GetIdentity(), "/") > 1 {
+			return fmt.Errorf("azure devops only supports projects as sub-organizations: %w", gitprovider.ErrNoProviderSupport)
+		}
+		return nil
+	case gitprovider.IdentityTypeUser:
+		return fmt.Errorf("azure devops doesn't support user-owned repositories: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// projectName returns the project referred to by ref, or an empty string for an organization.
+func projectName(ref gitprovider.OrganizationRef) string {
+	if len(ref.SubOrganizations) == 0 {
+		return ""
+	}
+	return ref.SubOrganizations[0]
+}
+
+// handleHTTPError converts an unsuccessful response into typed errors.
+// However, it _always_ keeps the original error too, and just wraps it in a MultiError
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(res *http.Response, body []byte) error {
+	// Azure DevOps returns a message and the type of the server-side exception
+	errRes := ErrorResponse{}
+	if err := json.Unmarshal(body, &errRes); err != nil {
+		errRes.Message = strings.TrimSpace(string(body))
+	}
+	apiErr := fmt.Errorf("%s %s: %s", res.Request.Method, res.Request.URL, res.Status)
+	if errRes.Message != "" {
+		apiErr = fmt.Errorf("%w: %s", apiErr, errRes.Message)
+	}
+
+	httpErr := gitprovider.HTTPError{
+		Response:         res,
+		ErrorMessage:     apiErr.Error(),
+		Message:          errRes.Message,
+		DocumentationURL: apiDocURL,
+	}
+	switch res.StatusCode {
+	// Azure DevOps Services answers requests with invalid credentials with a sign-in page,
+	// and a 203 Non-Authoritative Information status code.
+	case http.StatusNonAuthoritativeInfo, http.StatusUnauthorized, http.StatusForbidden:
+		// Check for invalid credentials, and return a typed error in that case
+		return validation.NewMultiError(apiErr, &gitprovider.InvalidCredentialsError{HTTPError: httpErr})
+	case http.StatusNotFound:
+		return validation.NewMultiError(apiErr, gitprovider.ErrNotFound)
+	case http.StatusConflict:
+		return validation.NewMultiError(apiErr, gitprovider.ErrAlreadyExists)
+	case http.StatusTooManyRequests:
+		return validation.NewMultiError(apiErr, &gitprovider.RateLimitError{HTTPError: httpErr})
+	}
+	// Check for already exists errors
+	if strings.HasSuffix(errRes.TypeKey, alreadyExistsTypeKeySuffix) {
+		return validation.NewMultiError(apiErr, gitprovider.ErrAlreadyExists)
+	}
+	// Otherwise, return a generic *HTTPError
+	return validation.NewMultiError(apiErr, &httpErr)
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// branchRef returns the full name of the reference of branch.
+func branchRef(branch string) string {
+	return branchRefPrefix + branch
+}
+
+// branchName returns the branch name of the given full reference name.
+func branchName(ref string) string {
+	return strings.TrimPrefix(ref, branchRefPrefix)
+}
+
+// itemPath returns the absolute path of a file in the repository, as Azure DevOps expects it.
+func itemPath(path string) string {
+	return "/" + strings.TrimPrefix(path, "/")
+}
+
+// repositoryPath returns the organization, project and name of the repository referred to by ref.
+func repositoryPath(ref gitprovider.OrgRepositoryRef) (string, string, string) {
+	return ref.Organization, projectName(ref.OrganizationRef), ref.RepositoryName
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+
+	gobitbucket "github.com/ktrysmt/go-bitbucket"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend.
+	DefaultDomain = "bitbucket.org"
+	// DefaultAPIURL is the API endpoint used for the DefaultDomain.
+	DefaultAPIURL = gobitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	// TokenVariable is the common name for the environment variable
+	// containing a Bitbucket authentication token.
+	TokenVariable = "BITBUCKET_TOKEN" // #nosec G101
+)
+
+// NewClient creates a new gitprovider.Client instance for Bitbucket Cloud API endpoints.
+//
+// If username is set, token is used as an app password for HTTP basic authentication.
+// If username is empty, token is used as a bearer token, e.g. a repository, project or
+// workspace access token. Passing neither will allow public read access only.
+//
+// If another domain than DefaultDomain is given using WithDomain, the API is expected
+// to be served at "https://<domain>/2.0".
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
+// You can also use conditional requests (and an in-memory cache) using WithConditionalRequests.
+func NewClient(username, token string, optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	if err != nil {
+		return nil, err
+	}
+
+	var bb *gobitbucket.Client
+	if username != "" {
+		bb = gobitbucket.NewBasicAuth(username, token)
+	} else {
+		bb = gobitbucket.NewOAuthbearerToken(token)
+	}
+	bb.HttpClient = httpClient
+
+	domain := DefaultDomain
+	apiURL := DefaultAPIURL
+	if opts.Domain != nil && *opts.Domain != DefaultDomain {
+		domain = *opts.Domain
+		apiURL = fmt.Sprintf("%s/2.0", gitprovider.GetDomainURL(domain))
+	}
+	baseURL, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	bb.SetApiBaseURL(*baseURL)
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(bb, username, token, domain, destructiveActions), nil
+}
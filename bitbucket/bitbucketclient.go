@@ -0,0 +1,909 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	gobitbucket "github.com/ktrysmt/go-bitbucket"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// bitbucketClient is a wrapper around the Bitbucket Cloud 2.0 REST API, which implements higher-level
+// methods, operating on the API structs of this package. Pagination is implemented for all List* methods,
+// all returned objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
+// This interface is also fakeable, in order to unit-test the client.
+type bitbucketClient interface {
+	// Client returns the underlying *gobitbucket.Client
+	Client() *gobitbucket.Client
+
+	// GetCurrentUser is a wrapper for "GET /user".
+	// This function handles HTTP error wrapping, and returns the response headers.
+	GetCurrentUser(ctx context.Context) (*User, http.Header, error)
+	// GetUser is a wrapper for "GET /users/{selected_user}", where the user is referenced by account ID or UUID.
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUser(ctx context.Context, user string) (*User, error)
+
+	// GetWorkspace is a wrapper for "GET /workspaces/{workspace}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetWorkspace(ctx context.Context, workspace string) (*Workspace, error)
+	// ListWorkspaces is a wrapper for "GET /workspaces".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListWorkspaces(ctx context.Context) ([]*Workspace, error)
+
+	// GetRepo is a wrapper for "GET /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, workspace, repo string) (*Repository, error)
+	// ListRepos is a wrapper for "GET /repositories/{workspace}".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepos(ctx context.Context, workspace string) ([]*Repository, error)
+	// CreateRepo is a wrapper for "POST /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error)
+	// UpdateRepo is a wrapper for "PUT /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error)
+	// DeleteRepo is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, workspace, repo string) error
+
+	// ListKeys is a wrapper for "GET /repositories/{workspace}/{repo_slug}/deploy-keys".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListKeys(ctx context.Context, workspace, repo string) ([]*DeployKey, error)
+	// CreateKey is a wrapper for "POST /repositories/{workspace}/{repo_slug}/deploy-keys".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateKey(ctx context.Context, workspace, repo string, req *DeployKey) (*DeployKey, error)
+	// DeleteKey is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}/deploy-keys/{key_id}".
+	// This function handles HTTP error wrapping.
+	DeleteKey(ctx context.Context, workspace, repo string, id int) error
+
+	// GetGroupPermission is a wrapper for "GET /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetGroupPermission(ctx context.Context, workspace, repo, group string) (*GroupPermission, error)
+	// ListGroupPermissions is a wrapper for "GET /repositories/{workspace}/{repo_slug}/permissions-config/groups".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupPermissions(ctx context.Context, workspace, repo string) ([]*GroupPermission, error)
+	// UpdateGroupPermission is a wrapper for "PUT /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateGroupPermission(ctx context.Context, workspace, repo, group, permission string) (*GroupPermission, error)
+	// DeleteGroupPermission is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}".
+	// This function handles HTTP error wrapping.
+	DeleteGroupPermission(ctx context.Context, workspace, repo, group string) error
+
+	// GetUserPermission is a wrapper for "GET /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUserPermission(ctx context.Context, workspace, repo, user string) (*UserPermission, error)
+	// ListUserPermissions is a wrapper for "GET /repositories/{workspace}/{repo_slug}/permissions-config/users".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListUserPermissions(ctx context.Context, workspace, repo string) ([]*UserPermission, error)
+	// UpdateUserPermission is a wrapper for "PUT /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateUserPermission(ctx context.Context, workspace, repo, user, permission string) (*UserPermission, error)
+	// DeleteUserPermission is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}".
+	// This function handles HTTP error wrapping.
+	DeleteUserPermission(ctx context.Context, workspace, repo, user string) error
+
+	// GetCommit is a wrapper for "GET /repositories/{workspace}/{repo_slug}/commit/{commit}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetCommit(ctx context.Context, workspace, repo, sha string) (*Commit, error)
+	// ListCommitsPage is a wrapper for "GET /repositories/{workspace}/{repo_slug}/commits/{revision}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListCommitsPage(ctx context.Context, workspace, repo, branch string, perPage int, page int) ([]*Commit, error)
+	// CreateCommit is a wrapper for "POST /repositories/{workspace}/{repo_slug}/src".
+	// Files with a nil Content are deleted.
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateCommit(ctx context.Context, workspace, repo, branch, message string, files []gitprovider.CommitFile) (*Commit, error)
+
+	// ListBranches is a wrapper for "GET /repositories/{workspace}/{repo_slug}/refs/branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, workspace, repo string) ([]*Branch, error)
+	// GetBranch is a wrapper for "GET /repositories/{workspace}/{repo_slug}/refs/branches/{name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, workspace, repo, branch string) (*Branch, error)
+	// CreateBranch is a wrapper for "POST /repositories/{workspace}/{repo_slug}/refs/branches".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateBranch(ctx context.Context, workspace, repo, branch, sha string) (*Branch, error)
+	// DeleteBranch is a wrapper for "DELETE /repositories/{workspace}/{repo_slug}/refs/branches/{name}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteBranch(ctx context.Context, workspace, repo, branch string) error
+
+	// GetPullRequest is a wrapper for "GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetPullRequest(ctx context.Context, workspace, repo string, id int) (*PullRequest, error)
+	// ListPullRequests is a wrapper for "GET /repositories/{workspace}/{repo_slug}/pullrequests".
+	// Only pull requests in the given states are listed, Bitbucket defaults to OPEN if there are none.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListPullRequests(ctx context.Context, workspace, repo string, states ...string) ([]*PullRequest, error)
+	// CreatePullRequest is a wrapper for "POST /repositories/{workspace}/{repo_slug}/pullrequests".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreatePullRequest(ctx context.Context, workspace, repo string, req *PullRequest) (*PullRequest, error)
+	// MergePullRequest is a wrapper for "POST /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/merge".
+	// This function handles HTTP error wrapping, and validates the server result.
+	MergePullRequest(ctx context.Context, workspace, repo string, id int, strategy, message string, closeSourceBranch bool) (*PullRequest, error)
+	// UpdatePullRequest is a wrapper for "PUT /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdatePullRequest(ctx context.Context, workspace, repo string, id int, req *PullRequest) (*PullRequest, error)
+	// DeclinePullRequest is a wrapper for "POST /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/decline".
+	// This function handles HTTP error wrapping, and validates the server result.
+	DeclinePullRequest(ctx context.Context, workspace, repo string, id int) (*PullRequest, error)
+
+	// GetSourceMeta is a wrapper for "GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}?format=meta".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetSourceMeta(ctx context.Context, workspace, repo, ref, filePath string) (*SourceEntry, error)
+	// ListSource is a wrapper for "GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}/".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListSource(ctx context.Context, workspace, repo, ref, dirPath string) ([]*SourceEntry, error)
+	// GetFileContent is a wrapper for "GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}".
+	// This function handles HTTP error wrapping.
+	GetFileContent(ctx context.Context, workspace, repo, ref, filePath string) ([]byte, error)
+}
+
+// bitbucketClientImpl is a wrapper around *gobitbucket.Client, which implements higher-level methods,
+// operating on the API structs of this package. See the bitbucketClient interface for method documentation.
+//
+// The requests are sent using the HTTP client, base URL and credentials of the *gobitbucket.Client, which
+// is also exposed through Client.Raw(). Its own methods aren't used, as they don't accept a context, and
+// return loosely typed data.
+type bitbucketClientImpl struct {
+	c                  *gobitbucket.Client
+	username           string
+	token              string
+	destructiveActions bool
+}
+
+// bitbucketClientImpl implements bitbucketClient.
+var _ bitbucketClient = &bitbucketClientImpl{}
+
+func (c *bitbucketClientImpl) Client() *gobitbucket.Client {
+	return c.c
+}
+
+func (c *bitbucketClientImpl) GetCurrentUser(ctx context.Context) (*User, http.Header, error) {
+	apiObj := &User{}
+	// GET /user
+	res, err := c.do(ctx, http.MethodGet, c.url(nil, "user"), nil, "", apiObj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return apiObj, res.Header, nil
+}
+
+func (c *bitbucketClientImpl) GetUser(ctx context.Context, user string) (*User, error) {
+	apiObj := &User{}
+	// GET /users/{selected_user}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "users", user), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) GetWorkspace(ctx context.Context, workspace string) (*Workspace, error) {
+	apiObj := &Workspace{}
+	// GET /workspaces/{workspace}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "workspaces", workspace), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateWorkspaceAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListWorkspaces(ctx context.Context) ([]*Workspace, error) {
+	apiObjs := []*Workspace{}
+	// GET /workspaces
+	err := c.allPages(ctx, c.url(nil, "workspaces"), func(values json.RawMessage) error {
+		pageObjs := []*Workspace{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateWorkspaceAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) GetRepo(ctx context.Context, workspace, repo string) (*Repository, error) {
+	apiObj := &Repository{}
+	// GET /repositories/{workspace}/{repo_slug}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	return validateRepositoryAPIResp(apiObj)
+}
+
+func validateRepositoryAPIResp(apiObj *Repository) (*Repository, error) {
+	// Validate the API object
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListRepos(ctx context.Context, workspace string) ([]*Repository, error) {
+	apiObjs := []*Repository{}
+	// GET /repositories/{workspace}
+	err := c.allPages(ctx, c.url(nil, "repositories", workspace), func(values json.RawMessage) error {
+		pageObjs := []*Repository{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) CreateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error) {
+	apiObj := &Repository{}
+	// POST /repositories/{workspace}/{repo_slug}
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validateRepositoryAPIResp(apiObj)
+}
+
+func (c *bitbucketClientImpl) UpdateRepo(ctx context.Context, workspace, repo string, req *Repository) (*Repository, error) {
+	apiObj := &Repository{}
+	// PUT /repositories/{workspace}/{repo_slug}
+	if _, err := c.doJSON(ctx, http.MethodPut, c.url(nil, "repositories", workspace, repo), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validateRepositoryAPIResp(apiObj)
+}
+
+func (c *bitbucketClientImpl) DeleteRepo(ctx context.Context, workspace, repo string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repositories/{workspace}/{repo_slug}
+	_, err := c.do(ctx, http.MethodDelete, c.url(nil, "repositories", workspace, repo), nil, "", nil)
+	return err
+}
+
+func (c *bitbucketClientImpl) ListKeys(ctx context.Context, workspace, repo string) ([]*DeployKey, error) {
+	apiObjs := []*DeployKey{}
+	// GET /repositories/{workspace}/{repo_slug}/deploy-keys
+	err := c.allPages(ctx, c.url(nil, "repositories", workspace, repo, "deploy-keys"), func(values json.RawMessage) error {
+		pageObjs := []*DeployKey{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateDeployKeyAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) CreateKey(ctx context.Context, workspace, repo string, req *DeployKey) (*DeployKey, error) {
+	apiObj := &DeployKey{}
+	// POST /repositories/{workspace}/{repo_slug}/deploy-keys
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo, "deploy-keys"), req, apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateDeployKeyAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) DeleteKey(ctx context.Context, workspace, repo string, id int) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/deploy-keys/{key_id}
+	_, err := c.do(ctx, http.MethodDelete, c.url(nil, "repositories", workspace, repo, "deploy-keys", strconv.Itoa(id)), nil, "", nil)
+	return err
+}
+
+func (c *bitbucketClientImpl) GetGroupPermission(ctx context.Context, workspace, repo, group string) (*GroupPermission, error) {
+	apiObj := &GroupPermission{}
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo, "permissions-config", "groups", group), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateGroupPermissionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListGroupPermissions(ctx context.Context, workspace, repo string) ([]*GroupPermission, error) {
+	apiObjs := []*GroupPermission{}
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/groups
+	err := c.allPages(ctx, c.url(nil, "repositories", workspace, repo, "permissions-config", "groups"), func(values json.RawMessage) error {
+		pageObjs := []*GroupPermission{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateGroupPermissionAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) UpdateGroupPermission(ctx context.Context, workspace, repo, group, permission string) (*GroupPermission, error) {
+	apiObj := &GroupPermission{}
+	// PUT /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	req := &GroupPermission{Permission: permission}
+	if _, err := c.doJSON(ctx, http.MethodPut, c.url(nil, "repositories", workspace, repo, "permissions-config", "groups", group), req, apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateGroupPermissionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) DeleteGroupPermission(ctx context.Context, workspace, repo, group string) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	_, err := c.do(ctx, http.MethodDelete, c.url(nil, "repositories", workspace, repo, "permissions-config", "groups", group), nil, "", nil)
+	return err
+}
+
+func (c *bitbucketClientImpl) GetUserPermission(ctx context.Context, workspace, repo, user string) (*UserPermission, error) {
+	apiObj := &UserPermission{}
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo, "permissions-config", "users", user), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateUserPermissionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListUserPermissions(ctx context.Context, workspace, repo string) ([]*UserPermission, error) {
+	apiObjs := []*UserPermission{}
+	// GET /repositories/{workspace}/{repo_slug}/permissions-config/users
+	err := c.allPages(ctx, c.url(nil, "repositories", workspace, repo, "permissions-config", "users"), func(values json.RawMessage) error {
+		pageObjs := []*UserPermission{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateUserPermissionAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) UpdateUserPermission(ctx context.Context, workspace, repo, user, permission string) (*UserPermission, error) {
+	apiObj := &UserPermission{}
+	// PUT /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}
+	req := &UserPermission{Permission: permission}
+	if _, err := c.doJSON(ctx, http.MethodPut, c.url(nil, "repositories", workspace, repo, "permissions-config", "users", user), req, apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateUserPermissionAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) DeleteUserPermission(ctx context.Context, workspace, repo, user string) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}
+	_, err := c.do(ctx, http.MethodDelete, c.url(nil, "repositories", workspace, repo, "permissions-config", "users", user), nil, "", nil)
+	return err
+}
+
+func (c *bitbucketClientImpl) GetCommit(ctx context.Context, workspace, repo, sha string) (*Commit, error) {
+	apiObj := &Commit{}
+	// GET /repositories/{workspace}/{repo_slug}/commit/{commit}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo, "commit", sha), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateCommitAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListCommitsPage(ctx context.Context, workspace, repo, branch string, perPage int, page int) ([]*Commit, error) {
+	query := url.Values{}
+	if perPage > 0 {
+		query.Set("pagelen", strconv.Itoa(perPage))
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	p := &struct {
+		Values []*Commit `json:"values"`
+	}{}
+	// GET /repositories/{workspace}/{repo_slug}/commits/{revision}
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, "repositories", workspace, repo, "commits", branch), nil, "", p); err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range p.Values {
+		if err := validateCommitAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return p.Values, nil
+}
+
+func (c *bitbucketClientImpl) CreateCommit(ctx context.Context, workspace, repo, branch, message string, files []gitprovider.CommitFile) (*Commit, error) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	if err := mw.WriteField("message", message); err != nil {
+		return nil, err
+	}
+	if err := mw.WriteField("branch", branch); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.Path == nil {
+			return nil, fmt.Errorf("file path must be set: %w", gitprovider.ErrInvalidArgument)
+		}
+		// Files without content are removed using the "files" field
+		if file.Content == nil {
+			if err := mw.WriteField("files", *file.Path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// Otherwise, the field name is the path of the file to add or update
+		fw, err := mw.CreateFormFile(*file.Path, path.Base(*file.Path))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(fw, *file.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	// POST /repositories/{workspace}/{repo_slug}/src
+	res, err := c.do(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo, "src"), body, mw.FormDataContentType(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Location header points to the new commit, fall back to the head of the branch
+	if location := res.Header.Get("Location"); location != "" {
+		return c.GetCommit(ctx, workspace, repo, path.Base(location))
+	}
+	commits, err := c.ListCommitsPage(ctx, workspace, repo, branch, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found on branch %q: %w", branch, gitprovider.ErrUnexpectedEvent)
+	}
+	return commits[0], nil
+}
+
+func (c *bitbucketClientImpl) ListBranches(ctx context.Context, workspace, repo string) ([]*Branch, error) {
+	apiObjs := []*Branch{}
+	// GET /repositories/{workspace}/{repo_slug}/refs/branches
+	err := c.allPages(ctx, c.url(nil, "repositories", workspace, repo, "refs", "branches"), func(values json.RawMessage) error {
+		pageObjs := []*Branch{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) GetBranch(ctx context.Context, workspace, repo, branch string) (*Branch, error) {
+	apiObj := &Branch{}
+	// GET /repositories/{workspace}/{repo_slug}/refs/branches/{name}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo, "refs", "branches", branch), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) CreateBranch(ctx context.Context, workspace, repo, branch, sha string) (*Branch, error) {
+	apiObj := &Branch{}
+	req := &Branch{
+		Name:   branch,
+		Target: &Commit{Hash: sha},
+	}
+	// POST /repositories/{workspace}/{repo_slug}/refs/branches
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo, "refs", "branches"), req, apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) DeleteBranch(ctx context.Context, workspace, repo, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repositories/{workspace}/{repo_slug}/refs/branches/{name}
+	_, err := c.do(ctx, http.MethodDelete, c.url(nil, "repositories", workspace, repo, "refs", "branches", branch), nil, "", nil)
+	return err
+}
+
+func (c *bitbucketClientImpl) GetPullRequest(ctx context.Context, workspace, repo string, id int) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo, "pullrequests", strconv.Itoa(id)), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func validatePullRequestAPIResp(apiObj *PullRequest) (*PullRequest, error) {
+	// Validate the API object
+	if err := validatePullRequestAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListPullRequests(ctx context.Context, workspace, repo string, states ...string) ([]*PullRequest, error) {
+	apiObjs := []*PullRequest{}
+	query := url.Values{"state": states}
+	// GET /repositories/{workspace}/{repo_slug}/pullrequests
+	err := c.allPages(ctx, c.url(query, "repositories", workspace, repo, "pullrequests"), func(values json.RawMessage) error {
+		pageObjs := []*PullRequest{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validatePullRequestAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) CreatePullRequest(ctx context.Context, workspace, repo string, req *PullRequest) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// POST /repositories/{workspace}/{repo_slug}/pullrequests
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo, "pullrequests"), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func (c *bitbucketClientImpl) MergePullRequest(ctx context.Context, workspace, repo string, id int, strategy, message string, closeSourceBranch bool) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	req := &struct {
+		MergeStrategy     string `json:"merge_strategy,omitempty"`
+		Message           string `json:"message,omitempty"`
+		CloseSourceBranch bool   `json:"close_source_branch,omitempty"`
+	}{strategy, message, closeSourceBranch}
+	// POST /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/merge
+	if _, err := c.doJSON(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo, "pullrequests", strconv.Itoa(id), "merge"), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func (c *bitbucketClientImpl) UpdatePullRequest(ctx context.Context, workspace, repo string, id int, req *PullRequest) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// PUT /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}
+	if _, err := c.doJSON(ctx, http.MethodPut, c.url(nil, "repositories", workspace, repo, "pullrequests", strconv.Itoa(id)), req, apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func (c *bitbucketClientImpl) DeclinePullRequest(ctx context.Context, workspace, repo string, id int) (*PullRequest, error) {
+	apiObj := &PullRequest{}
+	// POST /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/decline
+	if _, err := c.do(ctx, http.MethodPost, c.url(nil, "repositories", workspace, repo, "pullrequests", strconv.Itoa(id), "decline"), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	return validatePullRequestAPIResp(apiObj)
+}
+
+func (c *bitbucketClientImpl) GetSourceMeta(ctx context.Context, workspace, repo, ref, filePath string) (*SourceEntry, error) {
+	apiObj := &SourceEntry{}
+	query := url.Values{"format": []string{"meta"}}
+	// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}?format=meta
+	if _, err := c.do(ctx, http.MethodGet, c.url(query, "repositories", workspace, repo, "src", ref, filePath), nil, "", apiObj); err != nil {
+		return nil, err
+	}
+	// Validate the API object
+	if err := validateSourceEntryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *bitbucketClientImpl) ListSource(ctx context.Context, workspace, repo, ref, dirPath string) ([]*SourceEntry, error) {
+	apiObjs := []*SourceEntry{}
+	// Directory listings need a trailing slash
+	u := c.url(nil, "repositories", workspace, repo, "src", ref, dirPath) + "/"
+	// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}/
+	err := c.allPages(ctx, u, func(values json.RawMessage) error {
+		pageObjs := []*SourceEntry{}
+		if err := json.Unmarshal(values, &pageObjs); err != nil {
+			return err
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateSourceEntryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *bitbucketClientImpl) GetFileContent(ctx context.Context, workspace, repo, ref, filePath string) ([]byte, error) {
+	var content []byte
+	// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}
+	if _, err := c.do(ctx, http.MethodGet, c.url(nil, "repositories", workspace, repo, "src", ref, filePath), nil, "", &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// url builds an absolute API URL out of the given path segments, which are escaped
+// individually. Segments containing slashes (e.g. file paths) keep their slashes.
+func (c *bitbucketClientImpl) url(query url.Values, segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		segment = strings.Trim(segment, "/")
+		if segment == "" {
+			continue
+		}
+		parts := strings.Split(segment, "/")
+		for i := range parts {
+			parts[i] = url.PathEscape(parts[i])
+		}
+		escaped = append(escaped, strings.Join(parts, "/"))
+	}
+
+	u := strings.TrimSuffix(c.c.GetApiBaseURL(), "/") + "/" + strings.Join(escaped, "/")
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// doJSON sends req JSON-encoded as the request body, see do.
+func (c *bitbucketClientImpl) doJSON(ctx context.Context, method, urlStr string, req interface{}, out interface{}) (*http.Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, method, urlStr, bytes.NewReader(body), "application/json", out)
+}
+
+// do sends an authenticated request. A successful response body is decoded into out, or copied
+// as-is if out is a *[]byte. Unsuccessful responses are converted into errors using handleHTTPError.
+func (c *bitbucketClientImpl) do(ctx context.Context, method, urlStr string, body io.Reader, contentType string, out interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	c.authenticate(req)
+
+	res, err := c.c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res, handleHTTPError(res, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return res, nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = data
+		return res, nil
+	}
+	return res, json.Unmarshal(data, out)
+}
+
+// allPages requests urlStr and all pages after it, and calls fn with the values of each page.
+// There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
+func (c *bitbucketClientImpl) allPages(ctx context.Context, urlStr string, fn func(values json.RawMessage) error) error {
+	for urlStr != "" {
+		p := &page{}
+		if _, err := c.do(ctx, http.MethodGet, urlStr, nil, "", p); err != nil {
+			return err
+		}
+		if len(p.Values) != 0 {
+			if err := fn(p.Values); err != nil {
+				return err
+			}
+		}
+		urlStr = p.Next
+	}
+	return nil
+}
+
+// authenticate adds the credentials given at client creation time to req.
+func (c *bitbucketClientImpl) authenticate(req *http.Request) {
+	switch {
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.token)
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+// validateWorkspaceAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateWorkspaceAPI(apiObj *Workspace) error {
+	return validateAPIObject("Bitbucket.Workspace", func(validator validation.Validator) {
+		if apiObj.Slug == "" {
+			validator.Required("Slug")
+		}
+	})
+}
+
+// validateUserAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateUserAPI(apiObj *User) error {
+	return validateAPIObject("Bitbucket.User", func(validator validation.Validator) {
+		if apiObj.AccountID == "" && apiObj.UUID == "" {
+			validator.Required("AccountID")
+		}
+	})
+}
+
+// validateCommitAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateCommitAPI(apiObj *Commit) error {
+	return validateAPIObject("Bitbucket.Commit", func(validator validation.Validator) {
+		if apiObj.Hash == "" {
+			validator.Required("Hash")
+		}
+	})
+}
+
+// validateBranchAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateBranchAPI(apiObj *Branch) error {
+	return validateAPIObject("Bitbucket.Branch", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+		if apiObj.Target == nil || apiObj.Target.Hash == "" {
+			validator.Required("Target.Hash")
+		}
+	})
+}
+
+// validateSourceEntryAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateSourceEntryAPI(apiObj *SourceEntry) error {
+	return validateAPIObject("Bitbucket.SourceEntry", func(validator validation.Validator) {
+		if apiObj.Path == "" {
+			validator.Required("Path")
+		}
+		if apiObj.Type != sourceTypeFile && apiObj.Type != sourceTypeDirectory {
+			validator.Invalid(apiObj.Type, "Type")
+		}
+	})
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"strings"
+
+	gobitbucket "github.com/ktrysmt/go-bitbucket"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// ProviderID is the provider ID for Bitbucket Cloud.
+const ProviderID = gitprovider.ProviderID("bitbucket")
+
+func newClient(c *gobitbucket.Client, username, token, domain string, destructiveActions bool) *Client {
+	bbClient := &bitbucketClientImpl{c, username, token, destructiveActions}
+	ctx := &clientContext{bbClient, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	c                  bitbucketClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+	users     *UsersClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "bitbucket.org".
+// This allows a higher-level user to know what Client to use for what endpoints.
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "bitbucket".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the Go Bitbucket client (github.com/ktrysmt/go-bitbucket *Client)
+// used under the hood for accessing Bitbucket.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// Users returns the UsersClient looking up user accounts.
+func (c *Client) Users() gitprovider.UsersClient {
+	return c.users
+}
+
+// permissionScopes maps the permissions to the OAuth scopes granting them.
+//
+//nolint:gochecknoglobals
+var permissionScopes = map[gitprovider.TokenPermission][]string{
+	gitprovider.TokenPermissionRWRepository:      {"repository:write"},
+	gitprovider.TokenPermissionAdminRepoHook:     {"webhook"},
+	gitprovider.TokenPermissionDeleteRepository:  {"repository:delete"},
+	gitprovider.TokenPermissionAdminOrganization: {"team:write"},
+}
+
+// HasTokenPermission returns true if the given token has the given permissions.
+//
+// Only OAuth access tokens report their scopes, ErrTokenPermissionUnknown is returned for
+// other kinds of credentials. Bitbucket Cloud has no package registry, hence
+// TokenPermissionWritePackages isn't supported.
+func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.TokenPermission) (bool, error) {
+	requestedScopes, ok := permissionScopes[permission]
+	if !ok {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+
+	// The X-OAuth-Scopes header is returned for any API calls, using the current user here to keep things simple.
+	_, header, err := c.c.GetCurrentUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	scopes := header.Get("X-OAuth-Scopes")
+	if scopes == "" {
+		return false, validation.NewMultiError(gitprovider.ErrMissingHeader, gitprovider.ErrTokenPermissionUnknown)
+	}
+
+	for _, s := range strings.Split(scopes, ",") {
+		scope := strings.TrimSpace(s)
+		for _, requestedScope := range requestedScopes {
+			if scope == requestedScope {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// CommitClient implements the gitprovider.CommitClient interface.
+var _ gitprovider.CommitClient = &CommitClient{}
+
+// CommitClient operates on the commits for a specific repository.
+type CommitClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// ListPage lists repository commits of the given page and page size.
+func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	// GET /repositories/{workspace}/{repo_slug}/commits/{revision}
+	apiObjs, err := c.c.ListCommitsPage(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Commit type
+	commits := make([]gitprovider.Commit, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		commits = append(commits, newCommit(c, apiObj))
+	}
+	return commits, nil
+}
+
+// Create creates a commit with the given specifications.
+// Files with a nil Content are deleted from the branch.
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+
+	srcFiles := make([]gitprovider.CommitFile, 0, len(files))
+	for _, file := range files {
+		fileSrcFiles, err := c.srcFiles(ctx, branch, file)
+		if err != nil {
+			return nil, err
+		}
+		srcFiles = append(srcFiles, fileSrcFiles...)
+	}
+
+	// POST /repositories/{workspace}/{repo_slug}/src
+	apiObj, err := c.c.CreateCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, message, srcFiles)
+	if err != nil {
+		return nil, err
+	}
+	return newCommit(c, apiObj), nil
+}
+
+// srcFiles returns the files to send to the src endpoint to commit file, with decoded content.
+// The src endpoint only adds, updates and deletes files, so moves are sent as a deletion and an addition.
+func (c *CommitClient) srcFiles(ctx context.Context, branch string, file gitprovider.CommitFile) ([]gitprovider.CommitFile, error) {
+	if err := file.Validate(); err != nil {
+		return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+	}
+	if file.GetMode() != gitprovider.CommitFileModeRegular {
+		return nil, fmt.Errorf("cannot commit %q with mode %s: %w", *file.Path, file.GetMode(), gitprovider.ErrNoProviderSupport)
+	}
+	if file.GetAction() == gitprovider.CommitFileActionDelete {
+		return []gitprovider.CommitFile{{Path: file.Path}}, nil
+	}
+
+	content, err := file.DecodedContent()
+	if err != nil {
+		return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+	}
+	if file.GetAction() != gitprovider.CommitFileActionMove {
+		return []gitprovider.CommitFile{{Path: file.Path, Content: gitprovider.StringVar(string(content))}}, nil
+	}
+	// Moving a file without new content keeps its content
+	if file.Content == nil {
+		// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}
+		content, err = c.c.GetFileContent(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, *file.PreviousPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return []gitprovider.CommitFile{
+		{Path: file.PreviousPath},
+		{Path: file.Path, Content: gitprovider.StringVar(string(content))},
+	}, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// FileClient implements the gitprovider.FileClient interface.
+var _ gitprovider.FileClient = &FileClient{}
+
+// FileClient operates on the files for a specific repository.
+type FileClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get fetches and returns the contents of a file or multiple files in a directory from a given branch and path with possible options of FilesGetOption
+// If a file path is given, the contents of the file are returned
+// If a directory path is given, the contents of the files in the path's root are returned
+// If the Recursive option is set, the contents of the files in all sub-directories are returned too
+func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	fileOpts := gitprovider.FilesGetOptions{}
+	for _, opt := range optFns {
+		opt.ApplyFilesGetOptions(&fileOpts)
+	}
+
+	// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}?format=meta
+	meta, err := c.c.GetSourceMeta(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*SourceEntry
+	if meta.Type == sourceTypeFile {
+		entries = []*SourceEntry{meta}
+	} else {
+		entries, err = listSource(ctx, c.c, c.ref, branch, path, fileOpts.Recursive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := make([]*gitprovider.CommitFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type != sourceTypeFile {
+			continue
+		}
+		// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}
+		content, err := c.c.GetFileContent(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, entry.Path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &gitprovider.CommitFile{
+			Path:    gitprovider.StringVar(entry.Path),
+			Content: gitprovider.StringVar(string(content)),
+		})
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found on this path[%s]", path)
+	}
+
+	return files, nil
+}
+
+// Put creates or updates the file at path on the given branch.
+// This is not supported in Bitbucket Cloud yet.
+func (c *FileClient) Put(_ context.Context, _, _, _, _, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Delete deletes the file at path on the given branch.
+// This is not supported in Bitbucket Cloud yet.
+func (c *FileClient) Delete(_ context.Context, _, _, _, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+//nolint:gochecknoglobals
+var mergeStrategies = map[gitprovider.MergeMethod]string{
+	gitprovider.MergeMethodMerge:           "merge_commit",
+	gitprovider.MergeMethodSquash:          "squash",
+	gitprovider.MergeMethodRebase:          "rebase_fast_forward",
+	gitprovider.MergeMethodFastForwardOnly: "fast_forward",
+}
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests for a specific repository.
+type PullRequestClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all pull requests in the repository, in all states unless filtered
+// with PullRequestListOptions.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	states := []string{pullRequestStateOpen, pullRequestStateDeclined, pullRequestStateMerged, pullRequestStateSuperseded}
+	if o.State != nil {
+		states = pullRequestStates[*o.State]
+	}
+
+	// GET /repositories/{workspace}/{repo_slug}/pullrequests
+	apiObjs, err := c.c.ListPullRequests(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), states...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Branches are matched client side
+	requests := make([]gitprovider.PullRequest, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		pr := newPullRequest(c.clientContext, apiObj)
+		if o.Matches(pr.Get()) {
+			requests = append(requests, pr)
+		}
+	}
+	return requests, nil
+}
+
+// Create creates a pull request with the given specifications. Bitbucket Cloud identifies
+// reviewers by UUID, and has no labels, assignees or drafts, hence all options return
+// gitprovider.ErrNoProviderSupport.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.Labels) > 0 || len(o.Assignees) > 0 || len(o.Reviewers) > 0 || o.Draft != nil || o.MaintainerCanModify != nil {
+		return nil, fmt.Errorf("bitbucket pull requests don't support create options: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	req := &PullRequest{
+		Title:       title,
+		Description: description,
+		Source: &PullRequestEndpoint{
+			Branch: &BranchName{Name: branch},
+		},
+		Destination: &PullRequestEndpoint{
+			Branch: &BranchName{Name: baseBranch},
+		},
+	}
+
+	// POST /repositories/{workspace}/{repo_slug}/pullrequests
+	apiObj, err := c.c.CreatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(c.clientContext, apiObj), nil
+}
+
+// Get retrieves an existing pull request by number
+func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	// GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}
+	apiObj, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(c.clientContext, apiObj), nil
+}
+
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Update(ctx context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	// GET /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}
+	apiObj, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+
+	// The title is required, so start from the current pull request
+	update := &PullRequest{
+		Title:       apiObj.Title,
+		Description: apiObj.Description,
+	}
+	if req.Title != nil {
+		update.Title = *req.Title
+	}
+	if req.Description != nil {
+		update.Description = *req.Description
+	}
+	if req.TargetBranch != nil {
+		update.Destination = &PullRequestEndpoint{
+			Branch: &BranchName{Name: *req.TargetBranch},
+		}
+	}
+
+	// PUT /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}
+	apiObj, err = c.c.UpdatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, update)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(c.clientContext, apiObj), nil
+}
+
+// Close declines the pull request with the given number.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Close(ctx context.Context, number int) error {
+	// POST /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/decline
+	_, err := c.c.DeclinePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	return err
+}
+
+// Merge merges a pull request with the given specifications. Bitbucket Cloud has no auto-merge,
+// hence it returns gitprovider.ErrNoProviderSupport.
+// Deleting the source branch after merging requires destructive API calls to be enabled, otherwise
+// ErrDestructiveCallDisallowed is returned without merging the pull request.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	strategy, ok := mergeStrategies[mergeMethod]
+	if !ok {
+		return fmt.Errorf("merge method %q is not supported: %w", mergeMethod, gitprovider.ErrNoProviderSupport)
+	}
+	if o.AutoMerge != nil && *o.AutoMerge {
+		return fmt.Errorf("bitbucket pull requests don't support auto-merge: %w", gitprovider.ErrNoProviderSupport)
+	}
+	// Don't allow deleting the source branch if the user didn't explicitly allow dangerous API calls.
+	if o.DeleteSourceBranch != nil && *o.DeleteSourceBranch && !c.destructiveActions {
+		return fmt.Errorf("cannot delete source branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+
+	// POST /repositories/{workspace}/{repo_slug}/pullrequests/{pull_request_id}/merge
+	_, err = c.c.MergePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, strategy, message,
+		o.DeleteSourceBranch != nil && *o.DeleteSourceBranch)
+	return err
+}
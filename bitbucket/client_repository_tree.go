@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	sourceTypeFile      = "commit_file"
+	sourceTypeDirectory = "commit_directory"
+
+	treeEntryTypeBlob = "blob"
+	treeEntryTypeTree = "tree"
+)
+
+// TreeClient implements the gitprovider.TreeClient interface.
+var _ gitprovider.TreeClient = &TreeClient{}
+
+// TreeClient operates on the trees in a specific repository.
+//
+// Bitbucket doesn't expose git tree objects, instead the "src" endpoints are used to
+// list the files and directories of a commit. Hence sha refers to a commit (or a branch),
+// and the entries don't carry the SHA of their objects.
+type TreeClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the files and directories of the given commit.
+func (c *TreeClient) Get(ctx context.Context, sha string, recursive bool) (*gitprovider.TreeInfo, error) {
+	// GET /repositories/{workspace}/{repo_slug}/src/{commit}/
+	entries, err := listSource(ctx, c.c, c.ref, sha, "", recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	treeEntries := make([]*gitprovider.TreeEntry, 0, len(entries))
+	for _, entry := range entries {
+		treeEntries = append(treeEntries, treeEntryFromAPI(entry))
+	}
+
+	return &gitprovider.TreeInfo{
+		SHA:       sha,
+		Tree:      treeEntries,
+		Truncated: false,
+	}, nil
+}
+
+// List files (blob) of the given commit, in the given path.
+func (c *TreeClient) List(ctx context.Context, sha string, path string, recursive bool) ([]*gitprovider.TreeEntry, error) {
+	// GET /repositories/{workspace}/{repo_slug}/src/{commit}/{path}/
+	entries, err := listSource(ctx, c.c, c.ref, sha, path, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	treeEntries := make([]*gitprovider.TreeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == sourceTypeFile {
+			treeEntries = append(treeEntries, treeEntryFromAPI(entry))
+		}
+	}
+	return treeEntries, nil
+}
+
+// listSource lists the entries of dirPath at the given commit. If recursive is set, the entries
+// of all sub-directories are listed too, after the directory containing them.
+func listSource(ctx context.Context, c bitbucketClient, ref gitprovider.RepositoryRef, commit, dirPath string, recursive bool) ([]*SourceEntry, error) {
+	entries, err := c.ListSource(ctx, ref.GetIdentity(), ref.GetRepository(), commit, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if !recursive {
+		return entries, nil
+	}
+
+	all := make([]*SourceEntry, 0, len(entries))
+	for _, entry := range entries {
+		all = append(all, entry)
+		if entry.Type != sourceTypeDirectory {
+			continue
+		}
+		children, err := listSource(ctx, c, ref, commit, entry.Path, recursive)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, children...)
+	}
+	return all, nil
+}
+
+func treeEntryFromAPI(apiObj *SourceEntry) *gitprovider.TreeEntry {
+	entry := &gitprovider.TreeEntry{
+		Path: apiObj.Path,
+		Mode: treeEntryMode(apiObj),
+		Type: treeEntryTypeBlob,
+		Size: apiObj.Size,
+	}
+	if apiObj.Type == sourceTypeDirectory {
+		entry.Type = treeEntryTypeTree
+	}
+	if apiObj.Links != nil {
+		entry.URL = linkHref(apiObj.Links.Self)
+	}
+	return entry
+}
+
+// treeEntryMode derives the git file mode out of the type and the attributes of the entry.
+func treeEntryMode(apiObj *SourceEntry) string {
+	if apiObj.Type == sourceTypeDirectory {
+		return "040000"
+	}
+	for _, attr := range apiObj.Attributes {
+		switch strings.ToLower(attr) {
+		case "link":
+			return "120000"
+		case "executable":
+			return "100755"
+		case "subrepository":
+			return "160000"
+		}
+	}
+	return "100644"
+}
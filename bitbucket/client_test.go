@@ -0,0 +1,847 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const apiPrefix = "/2.0"
+
+func setup(t *testing.T, optFns ...gitprovider.ClientOption) (*http.ServeMux, gitprovider.Client, string) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	optFns = append([]gitprovider.ClientOption{gitprovider.WithDomain(server.URL)}, optFns...)
+	c, err := NewClient("", "token", optFns...)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return mux, c, server.URL
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+func writeError(t *testing.T, w http.ResponseWriter, status int, message string) {
+	res := errorResponse{Type: "error"}
+	res.Error.Message = message
+	writeJSON(t, w, status, res)
+}
+
+func TestAuthentication(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/user", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization header = %q, want %q", got, "Bearer token")
+		}
+		w.Header().Set("X-OAuth-Scopes", "account, repository:write")
+		writeJSON(t, w, http.StatusOK, &User{Nickname: "user"})
+	})
+
+	ok, err := c.HasTokenPermission(context.Background(), gitprovider.TokenPermissionRWRepository)
+	if err != nil {
+		t.Fatalf("HasTokenPermission returned error: %v", err)
+	}
+	if !ok {
+		t.Errorf("HasTokenPermission = false, want true")
+	}
+
+	ok, err = c.HasTokenPermission(context.Background(), gitprovider.TokenPermissionDeleteRepository)
+	if err != nil || ok {
+		t.Errorf("HasTokenPermission(TokenPermissionDeleteRepository) = %v, %v, want false", ok, err)
+	}
+	if _, err := c.HasTokenPermission(context.Background(), gitprovider.TokenPermissionWritePackages); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("HasTokenPermission(TokenPermissionWritePackages) error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	mux, c, domain := setup(t)
+	mux.HandleFunc(apiPrefix+"/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"values": []*Workspace{{Slug: "ws2", Name: "Workspace 2"}},
+			})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"values": []*Workspace{{Slug: "ws1", Name: "Workspace 1"}},
+			"next":   fmt.Sprintf("http://%s%s/workspaces?page=2", r.Host, apiPrefix),
+		})
+	})
+	mux.HandleFunc(apiPrefix+"/workspaces/ws1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &Workspace{Slug: "ws1", Name: "Workspace 1"})
+	})
+	mux.HandleFunc(apiPrefix+"/workspaces/missing", func(w http.ResponseWriter, r *http.Request) {
+		writeError(t, w, http.StatusNotFound, "No workspace with identifier 'missing'.")
+	})
+
+	ctx := context.Background()
+	orgs, err := c.Organizations().List(ctx)
+	if err != nil {
+		t.Fatalf("Organizations.List returned error: %v", err)
+	}
+	got := []string{}
+	for _, org := range orgs {
+		got = append(got, org.Organization().Organization)
+	}
+	if diff := cmp.Diff([]string{"ws1", "ws2"}, got); diff != "" {
+		t.Errorf("Organizations.List returned diff (want -> got):\n%s", diff)
+	}
+
+	org, err := c.Organizations().Get(ctx, gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"})
+	if err != nil {
+		t.Fatalf("Organizations.Get returned error: %v", err)
+	}
+	if name := *org.Get().Name; name != "Workspace 1" {
+		t.Errorf("Organizations.Get returned name %q, want %q", name, "Workspace 1")
+	}
+
+	_, err = c.Organizations().Get(ctx, gitprovider.OrganizationRef{Domain: domain, Organization: "missing"})
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Organizations.Get returned error %v, want ErrNotFound", err)
+	}
+
+	_, err = c.Organizations().Get(ctx, gitprovider.OrganizationRef{Domain: "github.com", Organization: "ws1"})
+	if !errors.Is(err, gitprovider.ErrDomainUnsupported) {
+		t.Errorf("Organizations.Get returned error %v, want ErrDomainUnsupported", err)
+	}
+}
+
+func TestOrgRepositoriesReconcile(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	var actual *Repository
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if actual == nil {
+				writeError(t, w, http.StatusNotFound, "Repository ws1/repo1 not found")
+				return
+			}
+			writeJSON(t, w, http.StatusOK, actual)
+		case http.MethodPost, http.MethodPut:
+			if r.Method == http.MethodPost && actual != nil {
+				writeError(t, w, http.StatusBadRequest, "Repository with this Slug and Owner already exists.")
+				return
+			}
+			req := &Repository{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			req.Slug = "repo1"
+			actual = req
+			writeJSON(t, w, http.StatusOK, actual)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	}
+	info := gitprovider.RepositoryInfo{
+		Description: gitprovider.StringVar("test repository"),
+	}
+
+	repo, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, info)
+	if err != nil {
+		t.Fatalf("OrgRepositories.Reconcile returned error: %v", err)
+	}
+	if !actionTaken {
+		t.Errorf("expected the repository to be created")
+	}
+	want := gitprovider.RepositoryInfo{
+		Description:   gitprovider.StringVar("test repository"),
+		DefaultBranch: gitprovider.StringVar("main"),
+		Visibility:    gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}
+	if diff := cmp.Diff(want, repo.Get()); diff != "" {
+		t.Errorf("OrgRepositories.Reconcile returned diff (want -> got):\n%s", diff)
+	}
+
+	_, err = c.OrgRepositories().Create(ctx, ref, info)
+	if !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("OrgRepositories.Create returned error %v, want ErrAlreadyExists", err)
+	}
+
+	_, actionTaken, err = c.OrgRepositories().Reconcile(ctx, ref, info)
+	if err != nil {
+		t.Fatalf("OrgRepositories.Reconcile returned error: %v", err)
+	}
+	if actionTaken {
+		t.Errorf("expected no action to be taken")
+	}
+
+	info.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic)
+	_, actionTaken, err = c.OrgRepositories().Reconcile(ctx, ref, info)
+	if err != nil {
+		t.Fatalf("OrgRepositories.Reconcile returned error: %v", err)
+	}
+	if !actionTaken || *actual.IsPrivate {
+		t.Errorf("expected the repository to be updated to public")
+	}
+
+	info.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityInternal)
+	_, _, err = c.OrgRepositories().Reconcile(ctx, ref, info)
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("OrgRepositories.Reconcile returned error %v, want ErrNoProviderSupport", err)
+	}
+
+	err = repo.Delete(ctx)
+	if !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete returned error %v, want ErrDestructiveCallDisallowed", err)
+	}
+}
+
+func TestDeployKeys(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	keys := []*DeployKey{{ID: 1, Label: "existing", Key: "ssh-rsa AAAA", Comment: "user@host"}}
+	mux.HandleFunc(apiPrefix+"/repositories/user/repo/deploy-keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"values": keys})
+		case http.MethodPost:
+			req := &DeployKey{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			req.ID = len(keys) + 1
+			keys = append(keys, req)
+			writeJSON(t, w, http.StatusOK, req)
+		}
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/user/repo/deploy-keys/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		keys = keys[1:]
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	dkClient := newUserRepository(c.(*Client).clientContext, &Repository{Slug: "repo"}, gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: domain, UserLogin: "user"},
+		RepositoryName: "repo",
+	}).DeployKeys()
+
+	dk, err := dkClient.Get(ctx, "existing")
+	if err != nil {
+		t.Fatalf("DeployKeys.Get returned error: %v", err)
+	}
+	want := gitprovider.DeployKeyInfo{Name: "existing", Key: []byte("ssh-rsa AAAA user@host"), ReadOnly: gitprovider.BoolVar(true)}
+	if diff := cmp.Diff(want, dk.Get()); diff != "" {
+		t.Errorf("DeployKeys.Get returned diff (want -> got):\n%s", diff)
+	}
+
+	_, actionTaken, err := dkClient.Reconcile(ctx, want)
+	if err != nil {
+		t.Fatalf("DeployKeys.Reconcile returned error: %v", err)
+	}
+	if actionTaken {
+		t.Errorf("expected no action to be taken")
+	}
+
+	_, err = dkClient.Create(ctx, gitprovider.DeployKeyInfo{Name: "rw", Key: []byte("ssh-rsa BBBB"), ReadOnly: gitprovider.BoolVar(false)})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("DeployKeys.Create returned error %v, want ErrNoProviderSupport", err)
+	}
+
+	_, actionTaken, err = dkClient.Reconcile(ctx, gitprovider.DeployKeyInfo{Name: "existing", Key: []byte("ssh-rsa CCCC")})
+	if err != nil {
+		t.Fatalf("DeployKeys.Reconcile returned error: %v", err)
+	}
+	if !actionTaken {
+		t.Errorf("expected the deploy key to be recreated")
+	}
+	if len(keys) != 1 || keys[0].Key != "ssh-rsa CCCC" {
+		t.Errorf("unexpected deploy keys after reconciliation: %v", keys)
+	}
+}
+
+func TestTeamAccess(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	perms := map[string]string{"developers": "write"}
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/permissions-config/groups", func(w http.ResponseWriter, r *http.Request) {
+		values := []*GroupPermission{}
+		for slug, perm := range perms {
+			values = append(values, &GroupPermission{Permission: perm, Group: &Group{Slug: slug}})
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"values": values})
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/permissions-config/groups/", func(w http.ResponseWriter, r *http.Request) {
+		slug := r.URL.Path[len(apiPrefix+"/repositories/ws1/repo1/permissions-config/groups/"):]
+		switch r.Method {
+		case http.MethodGet:
+			perm, ok := perms[slug]
+			if !ok {
+				writeError(t, w, http.StatusNotFound, "Group not found")
+				return
+			}
+			writeJSON(t, w, http.StatusOK, &GroupPermission{Permission: perm, Group: &Group{Slug: slug}})
+		case http.MethodPut:
+			req := &GroupPermission{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			perms[slug] = req.Permission
+			writeJSON(t, w, http.StatusOK, &GroupPermission{Permission: req.Permission, Group: &Group{Slug: slug}})
+		case http.MethodDelete:
+			delete(perms, slug)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	taClient := newOrgRepository(c.(*Client).clientContext, &Repository{Slug: "repo1"}, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	}).TeamAccess()
+
+	list, err := taClient.List(ctx)
+	if err != nil {
+		t.Fatalf("TeamAccess.List returned error: %v", err)
+	}
+	if len(list) != 1 || *list[0].Get().Permission != gitprovider.RepositoryPermissionPush {
+		t.Errorf("unexpected team access list: %v", list)
+	}
+
+	_, actionTaken, err := taClient.Reconcile(ctx, gitprovider.TeamAccessInfo{
+		Name:       "admins",
+		Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionAdmin),
+	})
+	if err != nil {
+		t.Fatalf("TeamAccess.Reconcile returned error: %v", err)
+	}
+	if !actionTaken || perms["admins"] != "admin" {
+		t.Errorf("expected the admins group to be given admin permission, got %v", perms)
+	}
+
+	_, err = taClient.Create(ctx, gitprovider.TeamAccessInfo{
+		Name:       "triagers",
+		Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionTriage),
+	})
+	if !errors.Is(err, gitprovider.ErrInvalidPermissionLevel) {
+		t.Errorf("TeamAccess.Create returned error %v, want ErrInvalidPermissionLevel", err)
+	}
+
+	ta, err := taClient.Get(ctx, "developers")
+	if err != nil {
+		t.Fatalf("TeamAccess.Get returned error: %v", err)
+	}
+	if err := ta.Delete(ctx); err != nil {
+		t.Fatalf("TeamAccess.Delete returned error: %v", err)
+	}
+	if _, ok := perms["developers"]; ok {
+		t.Errorf("expected the developers group permission to be deleted")
+	}
+}
+
+func TestUserAccess(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	perms := map[string]string{"557058:alice": "read"}
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/permissions-config/users", func(w http.ResponseWriter, r *http.Request) {
+		values := []*UserPermission{}
+		for id, perm := range perms {
+			values = append(values, &UserPermission{Permission: perm, User: &User{AccountID: id}})
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"values": values})
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/permissions-config/users/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[len(apiPrefix+"/repositories/ws1/repo1/permissions-config/users/"):]
+		switch r.Method {
+		case http.MethodGet:
+			perm, ok := perms[id]
+			if !ok {
+				writeError(t, w, http.StatusNotFound, "User not found")
+				return
+			}
+			writeJSON(t, w, http.StatusOK, &UserPermission{Permission: perm, User: &User{AccountID: id}})
+		case http.MethodPut:
+			req := &UserPermission{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			perms[id] = req.Permission
+			writeJSON(t, w, http.StatusOK, &UserPermission{Permission: req.Permission, User: &User{AccountID: id}})
+		case http.MethodDelete:
+			delete(perms, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	uaClient := newUserRepository(c.(*Client).clientContext, &Repository{Slug: "repo1"}, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	}).UserAccess()
+
+	list, err := uaClient.List(ctx)
+	if err != nil {
+		t.Fatalf("UserAccess.List returned error: %v", err)
+	}
+	if len(list) != 1 || list[0].Get().Login != "557058:alice" || *list[0].Get().Permission != gitprovider.RepositoryPermissionPull {
+		t.Errorf("unexpected user access list: %v", list)
+	}
+
+	ua, actionTaken, err := uaClient.Reconcile(ctx, gitprovider.UserAccessInfo{
+		Login:      "557058:alice",
+		Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPush),
+	})
+	if err != nil {
+		t.Fatalf("UserAccess.Reconcile returned error: %v", err)
+	}
+	if !actionTaken || perms["557058:alice"] != "write" || ua.Invited() {
+		t.Errorf("expected alice to be given write permission, got %v", perms)
+	}
+
+	_, err = uaClient.Create(ctx, gitprovider.UserAccessInfo{
+		Login:      "557058:bob",
+		Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionMaintain),
+	})
+	if !errors.Is(err, gitprovider.ErrInvalidPermissionLevel) {
+		t.Errorf("UserAccess.Create returned error %v, want ErrInvalidPermissionLevel", err)
+	}
+
+	if err := ua.Delete(ctx); err != nil {
+		t.Fatalf("UserAccess.Delete returned error: %v", err)
+	}
+	if _, err := uaClient.Get(ctx, "557058:alice"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("UserAccess.Get returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	mux.HandleFunc(apiPrefix+"/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &User{AccountID: "557058:alice", DisplayName: "Alice", Links: &Links{
+			HTML:   &Link{Href: "https://bitbucket.org/%7Balice%7D/"},
+			Avatar: &Link{Href: "https://avatars/alice"},
+		}})
+	})
+	mux.HandleFunc(apiPrefix+"/users/", func(w http.ResponseWriter, r *http.Request) {
+		if id := r.URL.Path[len(apiPrefix+"/users/"):]; id != "557058:bob" {
+			writeError(t, w, http.StatusNotFound, "User not found")
+			return
+		}
+		writeJSON(t, w, http.StatusOK, &User{AccountID: "557058:bob", DisplayName: "Bob"})
+	})
+
+	ctx := context.Background()
+	u, err := c.Users().GetAuthenticated(ctx)
+	if err != nil {
+		t.Fatalf("Users.GetAuthenticated returned error: %v", err)
+	}
+	want := gitprovider.UserInfo{
+		Login:     "557058:alice",
+		Name:      "Alice",
+		AvatarURL: "https://avatars/alice",
+		WebURL:    "https://bitbucket.org/%7Balice%7D/",
+	}
+	if diff := cmp.Diff(want, u.Get()); diff != "" {
+		t.Errorf("unexpected user (-want +got):\n%s", diff)
+	}
+
+	ref := gitprovider.UserRef{Domain: domain, UserLogin: "557058:bob"}
+	u, err = c.Users().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(ref, u.User()); diff != "" {
+		t.Errorf("unexpected user ref (-want +got):\n%s", diff)
+	}
+
+	ref.UserLogin = "557058:carol"
+	if _, err := c.Users().Get(ctx, ref); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Users.Get returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestCommits(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/src", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.FormValue("branch"); got != "main" {
+			t.Errorf("branch = %q, want %q", got, "main")
+		}
+		if got := r.FormValue("message"); got != "update files" {
+			t.Errorf("message = %q, want %q", got, "update files")
+		}
+		if got := r.MultipartForm.Value["files"]; !cmp.Equal(got, []string{"old.txt"}) {
+			t.Errorf("deleted files = %v, want [old.txt]", got)
+		}
+		f, _, err := r.FormFile("dir/new.txt")
+		if err != nil {
+			t.Fatalf("missing file: %v", err)
+		}
+		content, _ := io.ReadAll(f)
+		if string(content) != "hello" {
+			t.Errorf("content = %q, want %q", content, "hello")
+		}
+		w.Header().Set("Location", fmt.Sprintf("http://%s%s/repositories/ws1/repo1/commit/abc123", r.Host, apiPrefix))
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/commit/abc123", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &Commit{
+			Hash:    "abc123",
+			Message: "update files",
+			Author:  &CommitAuthor{Raw: "User <user@example.com>"},
+		})
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("pagelen"); got != "2" {
+			t.Errorf("pagelen = %q, want %q", got, "2")
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"values": []*Commit{{Hash: "abc123"}, {Hash: "def456"}},
+		})
+	})
+
+	ctx := context.Background()
+	commitClient := newOrgRepository(c.(*Client).clientContext, &Repository{Slug: "repo1"}, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	}).Commits()
+
+	commit, err := commitClient.Create(ctx, "main", "update files", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("dir/new.txt"), Content: gitprovider.StringVar("hello")},
+		{Path: gitprovider.StringVar("old.txt")},
+	})
+	if err != nil {
+		t.Fatalf("Commits.Create returned error: %v", err)
+	}
+	if got := commit.Get(); got.Sha != "abc123" || got.Author != "User <user@example.com>" {
+		t.Errorf("unexpected commit: %+v", got)
+	}
+
+	commits, err := commitClient.ListPage(ctx, "main", 2, 1)
+	if err != nil {
+		t.Fatalf("Commits.ListPage returned error: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Errorf("Commits.ListPage returned %d commits, want 2", len(commits))
+	}
+}
+
+func TestBranches(t *testing.T) {
+	mux, c, domain := setup(t, gitprovider.WithDestructiveAPICalls(true))
+
+	branches := map[string]*Branch{
+		"main":    {Name: "main", Target: &Commit{Hash: "abc123"}},
+		"feature": {Name: "feature", Target: &Commit{Hash: "def456"}},
+	}
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/refs/branches", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"values": []*Branch{branches["feature"]}})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"values": []*Branch{branches["main"]},
+			"next":   fmt.Sprintf("http://%s%s?page=2", r.Host, r.URL.Path),
+		})
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/refs/branches/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, apiPrefix+"/repositories/ws1/repo1/refs/branches/")
+		branch, ok := branches[name]
+		if !ok {
+			writeError(t, w, http.StatusNotFound, "Branch not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, http.StatusOK, branch)
+		case http.MethodDelete:
+			delete(branches, name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		req := &Repository{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.MainBranch == nil || req.MainBranch.Name != "feature" || req.Description != nil {
+			t.Errorf("unexpected update request: %+v", req)
+		}
+		writeJSON(t, w, http.StatusOK, &Repository{Slug: "repo1", Name: "repo1", FullName: "ws1/repo1", MainBranch: req.MainBranch})
+	})
+
+	ctx := context.Background()
+	branchClient := newOrgRepository(c.(*Client).clientContext, &Repository{Slug: "repo1"}, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	}).Branches()
+
+	list, err := branchClient.List(ctx)
+	if err != nil {
+		t.Fatalf("Branches.List returned error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("Branches.List returned %d branches, want 2", len(list))
+	}
+
+	branch, err := branchClient.Get(ctx, "feature")
+	if err != nil {
+		t.Fatalf("Branches.Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(gitprovider.BranchInfo{Name: "feature", Sha: "def456"}, branch.Get()); diff != "" {
+		t.Errorf("Branches.Get returned diff (want -> got):\n%s", diff)
+	}
+	if _, err := branchClient.Get(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches.Get returned error %v, want ErrNotFound", err)
+	}
+
+	if err := branchClient.SetDefault(ctx, "feature"); err != nil {
+		t.Fatalf("Branches.SetDefault returned error: %v", err)
+	}
+	if err := branchClient.SetDefault(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches.SetDefault returned error %v, want ErrNotFound", err)
+	}
+
+	if err := branchClient.Delete(ctx, "main"); err != nil {
+		t.Fatalf("Branches.Delete returned error: %v", err)
+	}
+	if _, ok := branches["main"]; ok {
+		t.Error("Branches.Delete didn't delete the branch")
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.URL.Query()["state"]; len(got) != 4 {
+				t.Errorf("state = %v, want all states", got)
+			}
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"values": []*PullRequest{
+					{ID: 1, State: pullRequestStateOpen, Destination: &PullRequestEndpoint{Branch: &BranchName{Name: "main"}}},
+					{ID: 3, State: pullRequestStateDeclined, Destination: &PullRequestEndpoint{Branch: &BranchName{Name: "dev"}}},
+				},
+			})
+		case http.MethodPost:
+			req := &PullRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.Source.Branch.Name != "feature" || req.Destination.Branch.Name != "main" {
+				t.Errorf("unexpected branches in request: %+v", req)
+			}
+			req.ID = 2
+			req.State = "OPEN"
+			req.Links = &Links{HTML: &Link{Href: "https://bitbucket.org/ws1/repo1/pull-requests/2"}}
+			writeJSON(t, w, http.StatusCreated, req)
+		}
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/pullrequests/2", func(w http.ResponseWriter, r *http.Request) {
+		pr := &PullRequest{ID: 2, Title: "title", Description: "description", State: pullRequestStateOpen}
+		if r.Method == http.MethodPut {
+			req := &PullRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.Title != "new title" || req.Description != "description" {
+				t.Errorf("unexpected update request: %+v", req)
+			}
+			pr.Title = req.Title
+		}
+		writeJSON(t, w, http.StatusOK, pr)
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/pullrequests/2/decline", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		writeJSON(t, w, http.StatusOK, &PullRequest{ID: 2, State: pullRequestStateDeclined})
+	})
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/pullrequests/2/merge", func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req["merge_strategy"] != "squash" {
+			t.Errorf("merge_strategy = %q, want %q", req["merge_strategy"], "squash")
+		}
+		writeJSON(t, w, http.StatusOK, &PullRequest{ID: 2, State: pullRequestStateMerged})
+	})
+
+	ctx := context.Background()
+	prClient := newOrgRepository(c.(*Client).clientContext, &Repository{Slug: "repo1"}, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	}).PullRequests()
+
+	pr, err := prClient.Create(ctx, "title", "feature", "main", "description")
+	if err != nil {
+		t.Fatalf("PullRequests.Create returned error: %v", err)
+	}
+	want := gitprovider.PullRequestInfo{
+		Number:       2,
+		WebURL:       "https://bitbucket.org/ws1/repo1/pull-requests/2",
+		Title:        "title",
+		Description:  "description",
+		State:        gitprovider.PullRequestStateOpen,
+		SourceBranch: "feature",
+		TargetBranch: "main",
+	}
+	if diff := cmp.Diff(want, pr.Get()); diff != "" {
+		t.Errorf("PullRequests.Create returned diff (want -> got):\n%s", diff)
+	}
+
+	if err := prClient.Merge(ctx, 2, gitprovider.MergeMethodSquash, "merge"); err != nil {
+		t.Fatalf("PullRequests.Merge returned error: %v", err)
+	}
+
+	prs, err := prClient.List(ctx, &gitprovider.PullRequestListOptions{TargetBranch: gitprovider.StringVar("main")})
+	if err != nil {
+		t.Fatalf("PullRequests.List returned error: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().Number != 1 {
+		t.Errorf("unexpected pull requests: %v", prs)
+	}
+
+	pr, err = prClient.Update(ctx, 2, gitprovider.PullRequestUpdateInfo{Title: gitprovider.StringVar("new title")})
+	if err != nil {
+		t.Fatalf("PullRequests.Update returned error: %v", err)
+	}
+	if got := pr.Get().Title; got != "new title" {
+		t.Errorf("Title = %q, want %q", got, "new title")
+	}
+
+	if err := prClient.Close(ctx, 2); err != nil {
+		t.Fatalf("PullRequests.Close returned error: %v", err)
+	}
+}
+
+func TestFilesAndTrees(t *testing.T) {
+	mux, c, domain := setup(t)
+
+	mux.HandleFunc(apiPrefix+"/repositories/ws1/repo1/src/main/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path[len(apiPrefix+"/repositories/ws1/repo1/src/main/"):] {
+		case "":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"values": []*SourceEntry{
+					{Path: "README.md", Type: sourceTypeFile, Size: 6},
+					{Path: "dir", Type: sourceTypeDirectory},
+				},
+			})
+		case "dir":
+			writeJSON(t, w, http.StatusOK, &SourceEntry{Path: "dir", Type: sourceTypeDirectory})
+		case "dir/":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{
+				"values": []*SourceEntry{
+					{Path: "dir/run.sh", Type: sourceTypeFile, Size: 4, Attributes: []string{"executable"}},
+				},
+			})
+		case "README.md":
+			if r.URL.Query().Get("format") == "meta" {
+				writeJSON(t, w, http.StatusOK, &SourceEntry{Path: "README.md", Type: sourceTypeFile})
+				return
+			}
+			_, _ = w.Write([]byte("# repo"))
+		case "dir/run.sh":
+			_, _ = w.Write([]byte("exit"))
+		default:
+			writeError(t, w, http.StatusNotFound, "No such file or directory")
+		}
+	})
+
+	ctx := context.Background()
+	repo := newOrgRepository(c.(*Client).clientContext, &Repository{Slug: "repo1"}, gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: "ws1"},
+		RepositoryName:  "repo1",
+	})
+
+	files, err := repo.Files().Get(ctx, "README.md", "main")
+	if err != nil {
+		t.Fatalf("Files.Get returned error: %v", err)
+	}
+	if len(files) != 1 || *files[0].Content != "# repo" {
+		t.Errorf("unexpected files: %v", files)
+	}
+
+	files, err = repo.Files().Get(ctx, "dir", "main")
+	if err != nil {
+		t.Fatalf("Files.Get returned error: %v", err)
+	}
+	if len(files) != 1 || *files[0].Path != "dir/run.sh" || *files[0].Content != "exit" {
+		t.Errorf("unexpected files: %v", files)
+	}
+
+	_, err = repo.Files().Get(ctx, "missing", "main")
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Files.Get returned error %v, want ErrNotFound", err)
+	}
+
+	tree, err := repo.Trees().Get(ctx, "main", true)
+	if err != nil {
+		t.Fatalf("Trees.Get returned error: %v", err)
+	}
+	want := []*gitprovider.TreeEntry{
+		{Path: "README.md", Mode: "100644", Type: "blob", Size: 6},
+		{Path: "dir", Mode: "040000", Type: "tree"},
+		{Path: "dir/run.sh", Mode: "100755", Type: "blob", Size: 4},
+	}
+	if diff := cmp.Diff(want, tree.Tree); diff != "" {
+		t.Errorf("Trees.Get returned diff (want -> got):\n%s", diff)
+	}
+
+	blobs, err := repo.Trees().List(ctx, "main", "", false)
+	if err != nil {
+		t.Fatalf("Trees.List returned error: %v", err)
+	}
+	if len(blobs) != 1 || blobs[0].Path != "README.md" {
+		t.Errorf("unexpected blobs: %v", blobs)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+// conformancePageSize is the page size of the stand-in, small enough for List to need multiple pages.
+const conformancePageSize = 2
+
+// bitbucketStandIn emulates the repository and deploy key endpoints of Bitbucket Cloud with a
+// single workspace "org".
+type bitbucketStandIn struct {
+	t      *testing.T
+	mu     sync.Mutex
+	repos  []*Repository
+	keys   map[string][]*DeployKey
+	lastID int
+}
+
+func TestConformance(t *testing.T) {
+	s := &bitbucketStandIn{t: t, keys: map[string][]*DeployKey{}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewClient("", "token", append([]gitprovider.ClientOption{
+				gitprovider.WithDomain(server.URL),
+			}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: gitprovider.OrganizationRef{Domain: server.URL, Organization: "org"},
+	})
+}
+
+func (s *bitbucketStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, apiPrefix+"/repositories/org"), "/")[1:]
+	switch {
+	case !strings.HasPrefix(r.URL.Path, apiPrefix+"/repositories/org"):
+		writeError(s.t, w, http.StatusNotFound, "Resource not found")
+	case len(path) == 0 && r.Method == http.MethodGet:
+		s.writePage(w, r, len(s.repos), func(start, end int) interface{} {
+			return s.repos[start:end]
+		})
+	case len(path) == 1 && r.Method == http.MethodPost:
+		req := &Repository{}
+		s.decode(r, req)
+		if s.repo(path[0]) != nil {
+			writeError(s.t, w, http.StatusBadRequest, "Repository with this Slug and Owner already exists.")
+			return
+		}
+		repo := &Repository{
+			Name:        req.Name,
+			Slug:        path[0],
+			FullName:    "org/" + path[0],
+			Description: req.Description,
+			IsPrivate:   req.IsPrivate,
+			SCM:         "git",
+			MainBranch:  req.MainBranch,
+		}
+		if repo.MainBranch == nil {
+			repo.MainBranch = &BranchName{Name: "main"}
+		}
+		s.repos = append(s.repos, repo)
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) >= 1:
+		s.serveRepo(w, r, path[0], path[1:])
+	default:
+		writeError(s.t, w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *bitbucketStandIn) serveRepo(w http.ResponseWriter, r *http.Request, slug string, path []string) {
+	repo := s.repo(slug)
+	if repo == nil {
+		writeError(s.t, w, http.StatusNotFound, "Repository org/"+slug+" not found")
+		return
+	}
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodPut:
+		req := &Repository{}
+		s.decode(r, req)
+		if req.Description != nil {
+			repo.Description = req.Description
+		}
+		if req.IsPrivate != nil {
+			repo.IsPrivate = req.IsPrivate
+		}
+		if req.MainBranch != nil {
+			repo.MainBranch = req.MainBranch
+		}
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodDelete:
+		for i := range s.repos {
+			if s.repos[i] == repo {
+				s.repos = append(s.repos[:i], s.repos[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && path[0] == "deploy-keys" && r.Method == http.MethodGet:
+		keys := s.keys[slug]
+		s.writePage(w, r, len(keys), func(start, end int) interface{} {
+			return keys[start:end]
+		})
+	case len(path) == 1 && path[0] == "deploy-keys" && r.Method == http.MethodPost:
+		req := &DeployKey{}
+		s.decode(r, req)
+		// Like Bitbucket, return the key without its comment, which is returned separately
+		fields := strings.Fields(req.Key)
+		key := &DeployKey{Label: req.Label, Key: strings.Join(fields[:2], " "), Comment: strings.Join(fields[2:], " ")}
+		for _, existing := range s.keys[slug] {
+			if existing.Key == key.Key {
+				writeError(s.t, w, http.StatusBadRequest, "Someone has already added that access key to this repository.")
+				return
+			}
+		}
+		s.lastID++
+		key.ID = s.lastID
+		s.keys[slug] = append(s.keys[slug], key)
+		writeJSON(s.t, w, http.StatusOK, key)
+	case len(path) == 2 && path[0] == "deploy-keys" && r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(path[1])
+		keys := s.keys[slug]
+		for i := range keys {
+			if keys[i].ID == id {
+				s.keys[slug] = append(keys[:i], keys[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(s.t, w, http.StatusNotFound, "Deploy key not found")
+	default:
+		writeError(s.t, w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *bitbucketStandIn) repo(slug string) *Repository {
+	for _, repo := range s.repos {
+		if repo.Slug == slug {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (s *bitbucketStandIn) decode(r *http.Request, obj interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		s.t.Errorf("failed to decode request: %v", err)
+	}
+}
+
+// writePage writes the page (starting at 1) given by the page query parameter of total items,
+// and links the next page if there are more pages.
+func (s *bitbucketStandIn) writePage(w http.ResponseWriter, r *http.Request, total int, items func(start, end int) interface{}) {
+	n, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if n < 1 {
+		n = 1
+	}
+	start := (n - 1) * conformancePageSize
+	if start > total {
+		start = total
+	}
+	end := start + conformancePageSize
+	if end > total {
+		end = total
+	}
+	values, err := json.Marshal(items(start, end))
+	if err != nil {
+		s.t.Fatalf("failed to encode values: %v", err)
+	}
+	p := &page{Values: values}
+	if end < total {
+		p.Next = fmt.Sprintf("http://%s%s?page=%d", r.Host, r.URL.Path, n+1)
+	}
+	writeJSON(s.t, w, http.StatusOK, p)
+}
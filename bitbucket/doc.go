@@ -14,9 +14,9 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package bitbucket implements the gitprovider.Client interface for Bitbucket Cloud (bitbucket.org).
+//
+// Bitbucket workspaces are mapped to top-level organizations, and a user's personal workspace
+// is used for user repositories. Bitbucket projects only group repositories inside a workspace
+// and are not part of the repository path, hence they aren't exposed as sub-organizations.
 package bitbucket
-
-import (
-	// TODO: Dummy import until we have the implementation ready.
-	_ "github.com/ktrysmt/go-bitbucket"
-)
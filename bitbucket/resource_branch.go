@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranch(c *BranchClient, branch *Branch) *branchType {
+	return &branchType{
+		b: *branch,
+		c: c,
+	}
+}
+
+var _ gitprovider.Branch = &branchType{}
+
+type branchType struct {
+	b Branch
+	c *BranchClient
+}
+
+func (b *branchType) Get() gitprovider.BranchInfo {
+	return branchFromAPI(&b.b)
+}
+
+func (b *branchType) APIObject() interface{} {
+	return &b.b
+}
+
+// branchFromAPI converts the branch. Bitbucket manages branch restrictions separately from
+// branches, hence Protected is always false.
+func branchFromAPI(apiObj *Branch) gitprovider.BranchInfo {
+	return gitprovider.BranchInfo{
+		Name: apiObj.Name,
+		Sha:  apiObj.Target.Hash,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newOrganization(ctx *clientContext, apiObj *Workspace, ref gitprovider.OrganizationRef) *organization {
+	return &organization{
+		clientContext: ctx,
+		w:             *apiObj,
+		ref:           ref,
+		teams: &TeamsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+var _ gitprovider.Organization = &organization{}
+
+type organization struct {
+	*clientContext
+
+	w   Workspace
+	ref gitprovider.OrganizationRef
+
+	teams *TeamsClient
+}
+
+func (o *organization) Get() gitprovider.OrganizationInfo {
+	return organizationFromAPI(&o.w)
+}
+
+func (o *organization) APIObject() interface{} {
+	return &o.w
+}
+
+func (o *organization) Organization() gitprovider.OrganizationRef {
+	return o.ref
+}
+
+func (o *organization) Teams() gitprovider.TeamsClient {
+	return o.teams
+}
+
+func organizationFromAPI(apiObj *Workspace) gitprovider.OrganizationInfo {
+	return gitprovider.OrganizationInfo{
+		Name: gitprovider.StringVar(apiObj.Name),
+	}
+}
+
+// Set returns gitprovider.ErrNoProviderSupport, as updating workspaces isn't supported.
+func (o *organization) Set(_ gitprovider.OrganizationInfo) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Update returns gitprovider.ErrNoProviderSupport.
+func (o *organization) Update(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport.
+func (o *organization) Reconcile(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// Delete returns gitprovider.ErrNoProviderSupport.
+func (o *organization) Delete(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
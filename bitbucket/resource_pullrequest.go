@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	pullRequestStateOpen       = "OPEN"
+	pullRequestStateMerged     = "MERGED"
+	pullRequestStateDeclined   = "DECLINED"
+	pullRequestStateSuperseded = "SUPERSEDED"
+)
+
+// pullRequestStates maps pull request states to the states of Bitbucket pull requests.
+// Superseded pull requests are closed without having been merged.
+//
+//nolint:gochecknoglobals
+var pullRequestStates = map[gitprovider.PullRequestState][]string{
+	gitprovider.PullRequestStateOpen:   {pullRequestStateOpen},
+	gitprovider.PullRequestStateClosed: {pullRequestStateDeclined, pullRequestStateSuperseded},
+	gitprovider.PullRequestStateMerged: {pullRequestStateMerged},
+}
+
+func newPullRequest(ctx *clientContext, apiObj *PullRequest) *pullrequest {
+	return &pullrequest{
+		clientContext: ctx,
+		pr:            *apiObj,
+	}
+}
+
+var _ gitprovider.PullRequest = &pullrequest{}
+
+type pullrequest struct {
+	*clientContext
+
+	pr PullRequest
+}
+
+func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
+	return pullrequestFromAPI(&pr.pr)
+}
+
+func (pr *pullrequest) APIObject() interface{} {
+	return &pr.pr
+}
+
+// Comments gives access to the general comments of this pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{clientContext: pr.clientContext}
+}
+
+// Reviews gives access to the reviews of this pull request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return &PullRequestReviewClient{clientContext: pr.clientContext}
+}
+
+func pullrequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
+	info := gitprovider.PullRequestInfo{
+		Merged:      apiObj.State == pullRequestStateMerged,
+		Number:      apiObj.ID,
+		Title:       apiObj.Title,
+		Description: apiObj.Description,
+		State:       gitprovider.PullRequestStateOpen,
+	}
+	switch apiObj.State {
+	case pullRequestStateMerged:
+		info.State = gitprovider.PullRequestStateMerged
+	case pullRequestStateDeclined, pullRequestStateSuperseded:
+		info.State = gitprovider.PullRequestStateClosed
+	}
+	if apiObj.Source != nil {
+		info.SourceBranch, info.HeadSHA = pullRequestEndpointRefs(apiObj.Source)
+	}
+	if apiObj.Destination != nil {
+		info.TargetBranch, info.BaseSHA = pullRequestEndpointRefs(apiObj.Destination)
+	}
+	if apiObj.Author != nil {
+		info.Author = apiObj.Author.Nickname
+	}
+	if apiObj.CreatedOn != nil {
+		info.CreatedAt = *apiObj.CreatedOn
+	}
+	if apiObj.UpdatedOn != nil {
+		info.UpdatedAt = *apiObj.UpdatedOn
+	}
+	if apiObj.Links != nil {
+		info.WebURL = linkHref(apiObj.Links.HTML)
+	}
+	return info
+}
+
+// pullRequestEndpointRefs returns the branch name and commit hash of a pull request endpoint.
+func pullRequestEndpointRefs(endpoint *PullRequestEndpoint) (branch, sha string) {
+	if endpoint.Branch != nil {
+		branch = endpoint.Branch.Name
+	}
+	if endpoint.Commit != nil {
+		sha = endpoint.Commit.Hash
+	}
+	return branch, sha
+}
+
+// validatePullRequestAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validatePullRequestAPI(apiObj *PullRequest) error {
+	return validateAPIObject("Bitbucket.PullRequest", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+	})
+}
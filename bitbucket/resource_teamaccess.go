@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	bitbucketPermissionRead  = "read"
+	bitbucketPermissionWrite = "write"
+	bitbucketPermissionAdmin = "admin"
+)
+
+//nolint:gochecknoglobals
+var permissionMapping = map[string]gitprovider.RepositoryPermission{
+	bitbucketPermissionRead:  gitprovider.RepositoryPermissionPull,
+	bitbucketPermissionWrite: gitprovider.RepositoryPermissionPush,
+	bitbucketPermissionAdmin: gitprovider.RepositoryPermissionAdmin,
+}
+
+func newTeamAccess(c *TeamAccessClient, apiObj *GroupPermission, name string) (*teamAccess, error) {
+	permission, err := getGitProviderPermission(apiObj.Permission)
+	if err != nil {
+		return nil, err
+	}
+	return &teamAccess{
+		ta: gitprovider.TeamAccessInfo{
+			Name:       name,
+			Permission: permission,
+		},
+		p: *apiObj,
+		c: c,
+	}, nil
+}
+
+var _ gitprovider.TeamAccess = &teamAccess{}
+
+type teamAccess struct {
+	ta gitprovider.TeamAccessInfo
+	p  GroupPermission
+	c  *TeamAccessClient
+}
+
+func (ta *teamAccess) Get() gitprovider.TeamAccessInfo {
+	return ta.ta
+}
+
+func (ta *teamAccess) Set(info gitprovider.TeamAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ta.ta = info
+	return nil
+}
+
+func (ta *teamAccess) APIObject() interface{} {
+	return &ta.p
+}
+
+func (ta *teamAccess) Repository() gitprovider.RepositoryRef {
+	return ta.c.ref
+}
+
+// Delete removes the explicit permission of the group from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ta *teamAccess) Delete(ctx context.Context) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/permissions-config/groups/{group_slug}
+	return ta.c.c.DeleteGroupPermission(ctx, ta.c.ref.GetIdentity(), ta.c.ref.GetRepository(), ta.ta.Name)
+}
+
+func (ta *teamAccess) Update(ctx context.Context) error {
+	// Update the actual state to be the desired state
+	// by issuing a Create, which uses a PUT underneath.
+	resp, err := ta.c.Create(ctx, ta.Get())
+	if err != nil {
+		return err
+	}
+	ta.p = *resp.APIObject().(*GroupPermission)
+	return ta.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ta *teamAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ta.Get()
+	actual, err := ta.c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, ta.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ta.Update(ctx)
+}
+
+// validateGroupPermissionAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateGroupPermissionAPI(apiObj *GroupPermission) error {
+	return validateAPIObject("Bitbucket.GroupPermission", func(validator validation.Validator) {
+		if apiObj.Group == nil || apiObj.Group.Slug == "" {
+			validator.Required("Group.Slug")
+		}
+		if _, ok := permissionMapping[apiObj.Permission]; !ok {
+			validator.Invalid(apiObj.Permission, "Permission")
+		}
+	})
+}
+
+func getGitProviderPermission(permission string) (*gitprovider.RepositoryPermission, error) {
+	if p, ok := permissionMapping[permission]; ok {
+		return &p, nil
+	}
+	return nil, gitprovider.ErrInvalidPermissionLevel
+}
+
+func getBitbucketPermission(permission gitprovider.RepositoryPermission) (string, error) {
+	for key, value := range permissionMapping {
+		if value == permission {
+			return key, nil
+		}
+	}
+	return "", gitprovider.ErrInvalidPermissionLevel
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUser(ctx *clientContext, apiObj *User) *user {
+	return &user{
+		clientContext: ctx,
+		u:             *apiObj,
+	}
+}
+
+var _ gitprovider.User = &user{}
+
+type user struct {
+	*clientContext
+
+	u User
+}
+
+func (u *user) Get() gitprovider.UserInfo {
+	return userFromAPI(&u.u)
+}
+
+func (u *user) APIObject() interface{} {
+	return &u.u
+}
+
+func (u *user) User() gitprovider.UserRef {
+	return gitprovider.UserRef{
+		Domain:    u.domain,
+		UserLogin: userLogin(&u.u),
+	}
+}
+
+// userFromAPI converts the Bitbucket user to UserInfo. Bitbucket Cloud doesn't
+// expose the email address of other users, hence it is left empty.
+func userFromAPI(apiObj *User) gitprovider.UserInfo {
+	info := gitprovider.UserInfo{
+		Login: userLogin(apiObj),
+		Name:  apiObj.DisplayName,
+	}
+	if apiObj.Links != nil {
+		if apiObj.Links.Avatar != nil {
+			info.AvatarURL = apiObj.Links.Avatar.Href
+		}
+		if apiObj.Links.HTML != nil {
+			info.WebURL = apiObj.Links.HTML.Href
+		}
+	}
+	return info
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUserAccess(c *UserAccessClient, apiObj *UserPermission, login string) (*userAccess, error) {
+	permission, err := getGitProviderPermission(apiObj.Permission)
+	if err != nil {
+		return nil, err
+	}
+	return &userAccess{
+		ua: gitprovider.UserAccessInfo{
+			Login:      login,
+			Permission: permission,
+		},
+		p: *apiObj,
+		c: c,
+	}, nil
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	ua gitprovider.UserAccessInfo
+	p  UserPermission
+	c  *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return ua.ua
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ua.ua = info
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return &ua.p
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited always returns false, as Bitbucket grants access to workspace members directly.
+func (ua *userAccess) Invited() bool {
+	return false
+}
+
+// Delete removes the explicit permission of the user from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(ctx context.Context) error {
+	// DELETE /repositories/{workspace}/{repo_slug}/permissions-config/users/{selected_user_id}
+	return ua.c.c.DeleteUserPermission(ctx, ua.c.ref.GetIdentity(), ua.c.ref.GetRepository(), ua.ua.Login)
+}
+
+func (ua *userAccess) Update(ctx context.Context) error {
+	// Update the actual state to be the desired state
+	// by issuing a Create, which uses a PUT underneath.
+	resp, err := ua.c.Create(ctx, ua.Get())
+	if err != nil {
+		return err
+	}
+	ua.p = *resp.APIObject().(*UserPermission)
+	return ua.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ua.Get()
+	actual, err := ua.c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, ua.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ua.Update(ctx)
+}
+
+// validateUserPermissionAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateUserPermissionAPI(apiObj *UserPermission) error {
+	return validateAPIObject("Bitbucket.UserPermission", func(validator validation.Validator) {
+		if apiObj.User == nil || userLogin(apiObj.User) == "" {
+			validator.Required("User.AccountID")
+		}
+		if _, ok := permissionMapping[apiObj.Permission]; !ok {
+			validator.Invalid(apiObj.Permission, "Permission")
+		}
+	})
+}
+
+// userLogin returns the identifier used to refer to the user in the permissions API,
+// which is the Atlassian account ID, or the UUID for users without one.
+func userLogin(user *User) string {
+	if user.AccountID != "" {
+		return user.AccountID
+	}
+	return user.UUID
+}
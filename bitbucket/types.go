@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The types in this file model the JSON objects of the Bitbucket Cloud 2.0 REST API.
+// They are returned by the APIObject() methods of the resources in this package.
+// See: https://developer.atlassian.com/cloud/bitbucket/rest/intro/
+
+// Link is a hypermedia link to a related resource.
+type Link struct {
+	Href string `json:"href"`
+	Name string `json:"name,omitempty"`
+}
+
+// Links is the set of links attached to most API objects.
+type Links struct {
+	Self   *Link  `json:"self,omitempty"`
+	HTML   *Link  `json:"html,omitempty"`
+	Avatar *Link  `json:"avatar,omitempty"`
+	Clone  []Link `json:"clone,omitempty"`
+}
+
+// User is a Bitbucket account.
+type User struct {
+	UUID        string `json:"uuid,omitempty"`
+	AccountID   string `json:"account_id,omitempty"`
+	Nickname    string `json:"nickname,omitempty"`
+	Username    string `json:"username,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// Workspace is the top-level container of repositories and projects.
+type Workspace struct {
+	UUID      string `json:"uuid,omitempty"`
+	Slug      string `json:"slug,omitempty"`
+	Name      string `json:"name,omitempty"`
+	IsPrivate bool   `json:"is_private,omitempty"`
+	Links     *Links `json:"links,omitempty"`
+}
+
+// Project groups repositories inside a workspace.
+type Project struct {
+	UUID string `json:"uuid,omitempty"`
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// BranchName references a branch by name.
+type BranchName struct {
+	Name string `json:"name"`
+}
+
+// Repository is a Bitbucket repository.
+type Repository struct {
+	UUID        string      `json:"uuid,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Slug        string      `json:"slug,omitempty"`
+	FullName    string      `json:"full_name,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	IsPrivate   *bool       `json:"is_private,omitempty"`
+	SCM         string      `json:"scm,omitempty"`
+	MainBranch  *BranchName `json:"mainbranch,omitempty"`
+	Project     *Project    `json:"project,omitempty"`
+	Links       *Links      `json:"links,omitempty"`
+}
+
+// DeployKey is an access key of a repository. Deploy keys are always read-only in Bitbucket.
+type DeployKey struct {
+	ID      int    `json:"id,omitempty"`
+	Key     string `json:"key"`
+	Label   string `json:"label"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Group is a user group of a workspace.
+type Group struct {
+	Slug string `json:"slug,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// GroupPermission is the explicit permission of a group on a repository.
+type GroupPermission struct {
+	Permission string `json:"permission"`
+	Group      *Group `json:"group,omitempty"`
+}
+
+// UserPermission is the explicit permission of a user on a repository.
+type UserPermission struct {
+	Permission string `json:"permission"`
+	User       *User  `json:"user,omitempty"`
+}
+
+// CommitAuthor is the author of a commit, which might not be linked to a Bitbucket account.
+type CommitAuthor struct {
+	Raw  string `json:"raw"`
+	User *User  `json:"user,omitempty"`
+}
+
+// Commit is a commit of a repository.
+type Commit struct {
+	Hash    string        `json:"hash"`
+	Message string        `json:"message,omitempty"`
+	Date    time.Time     `json:"date,omitempty"`
+	Author  *CommitAuthor `json:"author,omitempty"`
+	Parents []Commit      `json:"parents,omitempty"`
+	Links   *Links        `json:"links,omitempty"`
+}
+
+// Branch is a branch of a repository.
+type Branch struct {
+	Name   string  `json:"name"`
+	Target *Commit `json:"target,omitempty"`
+	Links  *Links  `json:"links,omitempty"`
+}
+
+// PullRequestEndpoint is the source or destination of a pull request.
+type PullRequestEndpoint struct {
+	Branch     *BranchName `json:"branch,omitempty"`
+	Commit     *Commit     `json:"commit,omitempty"`
+	Repository *Repository `json:"repository,omitempty"`
+}
+
+// PullRequest is a pull request of a repository.
+type PullRequest struct {
+	ID                int                  `json:"id,omitempty"`
+	Title             string               `json:"title"`
+	Description       string               `json:"description,omitempty"`
+	State             string               `json:"state,omitempty"`
+	Source            *PullRequestEndpoint `json:"source,omitempty"`
+	Destination       *PullRequestEndpoint `json:"destination,omitempty"`
+	MergeCommit       *Commit              `json:"merge_commit,omitempty"`
+	CloseSourceBranch bool                 `json:"close_source_branch,omitempty"`
+	Author            *User                `json:"author,omitempty"`
+	CreatedOn         *time.Time           `json:"created_on,omitempty"`
+	UpdatedOn         *time.Time           `json:"updated_on,omitempty"`
+	Links             *Links               `json:"links,omitempty"`
+}
+
+// SourceEntry is a file or a directory returned by the "src" endpoints.
+type SourceEntry struct {
+	Path       string   `json:"path"`
+	Type       string   `json:"type"`
+	Size       int      `json:"size,omitempty"`
+	MimeType   string   `json:"mimetype,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+	Commit     *Commit  `json:"commit,omitempty"`
+	Links      *Links   `json:"links,omitempty"`
+}
+
+// page is a single page of a paginated list.
+type page struct {
+	Next   string          `json:"next,omitempty"`
+	Values json.RawMessage `json:"values"`
+}
+
+// errorResponse is the body of an unsuccessful request.
+type errorResponse struct {
+	Type  string `json:"type"`
+	Error struct {
+		Message string              `json:"message"`
+		Detail  string              `json:"detail,omitempty"`
+		Fields  map[string][]string `json:"fields,omitempty"`
+	} `json:"error"`
+}
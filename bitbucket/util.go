@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	gobitbucket "github.com/ktrysmt/go-bitbucket"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	alreadyExistsMagicString = "already exists"
+	// keyInUseMagicString is returned when adding a deploy key which is already added to the repository.
+	keyInUseMagicString = "already added that access key"
+	apiDocURL           = "https://developer.atlassian.com/cloud/bitbucket/rest/intro/"
+)
+
+// validateUserRepositoryRef makes sure the UserRepositoryRef is valid for Bitbucket's usage.
+func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("UserRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrgRepositoryRef makes sure the OrgRepositoryRef is valid for Bitbucket's usage.
+func validateOrgRepositoryRef(ref gitprovider.OrgRepositoryRef, expectedDomain string) error {
+	// Make sure the RepositoryRef fields are valid
+	if err := validation.ValidateTargets("OrgRepositoryRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateOrganizationRef makes sure the OrganizationRef is valid for Bitbucket's usage.
+func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
+	// Make sure the OrganizationRef fields are valid
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateUserRef makes sure the UserRef is valid for Bitbucket's usage.
+func validateUserRef(ref gitprovider.UserRef, expectedDomain string) error {
+	// Make sure the UserRef fields are valid
+	if err := validation.ValidateTargets("UserRef", ref); err != nil {
+		return err
+	}
+	// Make sure the type is valid, and domain is expected
+	return validateIdentityFields(ref, expectedDomain)
+}
+
+// validateIdentityFields makes sure the type of the IdentityRef is supported, and the domain is as expected.
+func validateIdentityFields(ref gitprovider.IdentityRef, expectedDomain string) error {
+	// Make sure the expected domain is used
+	if ref.GetDomain() != expectedDomain {
+		return fmt.Errorf("domain %q not supported by this client: %w", ref.GetDomain(), gitprovider.ErrDomainUnsupported)
+	}
+	// Make sure the right type of identityref is used
+	switch ref.GetType() {
+	case gitprovider.IdentityTypeOrganization, gitprovider.IdentityTypeUser:
+		return nil
+	case gitprovider.IdentityTypeSuborganization:
+		return fmt.Errorf("bitbucket doesn't support sub-organizations: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return fmt.Errorf("invalid identity type: %v: %w", ref.GetType(), gitprovider.ErrInvalidArgument)
+}
+
+// handleHTTPError converts an unsuccessful response into typed errors.
+// However, it _always_ keeps the original error too, and just wraps it in a MultiError
+// The consumer must use errors.Is and errors.As to check for equality and get data out of it.
+func handleHTTPError(res *http.Response, body []byte) error {
+	apiErr := &gobitbucket.UnexpectedResponseStatusError{Status: res.Status, Body: body}
+
+	// Bitbucket wraps the human-readable message in an error object
+	message := ""
+	errRes := errorResponse{}
+	if err := json.Unmarshal(body, &errRes); err == nil {
+		message = errRes.Error.Message
+	}
+
+	httpErr := gitprovider.HTTPError{
+		Response:         res,
+		ErrorMessage:     apiErr.ErrorWithBody().Error(),
+		Message:          message,
+		DocumentationURL: apiDocURL,
+	}
+	switch res.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Check for invalid credentials, and return a typed error in that case
+		return validation.NewMultiError(apiErr, &gitprovider.InvalidCredentialsError{HTTPError: httpErr})
+	case http.StatusNotFound:
+		return validation.NewMultiError(apiErr, gitprovider.ErrNotFound)
+	case http.StatusTooManyRequests:
+		return validation.NewMultiError(apiErr, &gitprovider.RateLimitError{HTTPError: httpErr})
+	}
+	// Check for already exists errors
+	if res.StatusCode == http.StatusBadRequest &&
+		(strings.Contains(message, alreadyExistsMagicString) || strings.Contains(message, keyInUseMagicString)) {
+		return validation.NewMultiError(apiErr, gitprovider.ErrAlreadyExists)
+	}
+	// Otherwise, return a generic *HTTPError
+	return validation.NewMultiError(apiErr, &httpErr)
+}
+
+// validateAPIObject creates a Validatior with the specified name, gives it to fn, and
+// depending on if any error was registered with it; either returns nil, or a MultiError
+// with both the validation error and ErrInvalidServerData, to mark that the server data
+// was invalid.
+func validateAPIObject(name string, fn func(validation.Validator)) error {
+	v := validation.New(name)
+	fn(v)
+	// If there was a validation error, also mark it specifically as invalid server data
+	if err := v.Error(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidServerData)
+	}
+	return nil
+}
+
+// linkHref returns the href of the given link, or an empty string if it is not set.
+func linkHref(l *Link) string {
+	if l == nil {
+		return ""
+	}
+	return l.Href
+}
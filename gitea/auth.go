@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// DefaultDomain specifies the default domain used as the backend.
+	DefaultDomain = "gitea.com"
+	// TokenVariable is the common name for the environment variable
+	// containing a Gitea authentication token.
+	TokenVariable = "GITEA_TOKEN" // #nosec G101
+)
+
+// NewClient creates a new gitprovider.Client instance for Gitea API endpoints.
+//
+// Using WithOAuth2Token you can specify authentication credentials, Gitea accepts
+// both personal access tokens and OAuth2 tokens this way. Passing no such ClientOption
+// will allow public read access only.
+//
+// Self-hosted Gitea and Forgejo instances can be used if you specify the domain using WithDomain.
+// The server is expected to run Gitea 1.15 or newer, its version isn't queried when the client is created.
+//
+// You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options,
+// e.g. WithCustomCAPostChainTransportHook for instances using a private certificate authority.
+// You can also use conditional requests (and an in-memory cache) using WithConditionalRequests.
+//
+// The chain of transports looks like this:
+// Gitea API <-> "Post Chain" <-> Authentication <-> Cache <-> "Pre Chain" <-> *gitea.Client.
+func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a *http.Client using the transport chain
+	httpClient, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	if err != nil {
+		return nil, err
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = *opts.Domain
+	}
+
+	gt, err := gitea.NewClient(gitprovider.GetDomainURL(domain),
+		gitea.SetHTTPClient(httpClient),
+		// Don't query the server version at creation time, assume a recent server instead.
+		gitea.SetGiteaVersion(""),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return newClient(gt, domain, destructiveActions), nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []gitprovider.ClientOption
+		wantDomain string
+	}{
+		{
+			name:       "default domain",
+			wantDomain: DefaultDomain,
+		},
+		{
+			name:       "custom domain without protocol",
+			opts:       []gitprovider.ClientOption{gitprovider.WithDomain("codeberg.org")},
+			wantDomain: "codeberg.org",
+		},
+		{
+			name:       "custom domain with http protocol",
+			opts:       []gitprovider.ClientOption{gitprovider.WithDomain("http://127.0.0.1:3000")},
+			wantDomain: "http://127.0.0.1:3000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.opts...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			if got := c.SupportedDomain(); got != tt.wantDomain {
+				t.Errorf("SupportedDomain() = %q, want %q", got, tt.wantDomain)
+			}
+			if got := c.ProviderID(); got != ProviderID {
+				t.Errorf("ProviderID() = %q, want %q", got, ProviderID)
+			}
+		})
+	}
+}
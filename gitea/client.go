@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProviderID is the provider ID for Gitea.
+const ProviderID = gitprovider.ProviderID("gitea")
+
+func newClient(c *gitea.Client, domain string, destructiveActions bool) *Client {
+	gtClient := &giteaClientImpl{c, destructiveActions}
+	ctx := &clientContext{gtClient, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	c                  giteaClient
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+	users     *UsersClient
+}
+
+// SupportedDomain returns the domain endpoint for this client, e.g. "gitea.com" or
+// "my-custom-git-server.com:6443". This allows a higher-level user to know what Client to use for
+// what endpoints.
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID "gitea".
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return ProviderID
+}
+
+// Raw returns the Go Gitea client (code.gitea.io/sdk/gitea *Client)
+// used under the hood for accessing Gitea.
+func (c *Client) Raw() interface{} {
+	return c.c.Client()
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// Users returns the UsersClient looking up user accounts.
+func (c *Client) Users() gitprovider.UsersClient {
+	return c.users
+}
+
+// HasTokenPermission returns true if the given token has the given permissions.
+//
+// Gitea doesn't expose the scopes of the token in use, hence ErrTokenPermissionUnknown is
+// returned for all permissions.
+func (c *Client) HasTokenPermission(_ context.Context, permission gitprovider.TokenPermission) (bool, error) {
+	if err := gitprovider.ValidateTokenPermission(permission); err != nil {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+	return false, gitprovider.ErrTokenPermissionUnknown
+}
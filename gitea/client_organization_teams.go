@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// TeamsClient implements the gitprovider.TeamsClient interface.
+var _ gitprovider.TeamsClient = &TeamsClient{}
+
+// TeamsClient handles teams organization-wide.
+type TeamsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get a team within the specific organization.
+//
+// teamName may include slashes, to point to e.g. subgroups in GitLab.
+// teamName must not be an empty string.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Team, error) {
+	// GET /orgs/{org}/teams
+	apiObj, err := c.c.GetOrgTeam(ctx, c.ref.Organization, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return c.getTeam(ctx, apiObj)
+}
+
+// List all teams (recursively, in terms of subgroups) within the specific organization.
+//
+// List returns all available organizations, using multiple paginated requests if needed.
+func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
+	// GET /orgs/{org}/teams
+	apiObjs, err := c.c.ListOrgTeams(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]gitprovider.Team, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// Get detailed information about individual teams (including members).
+		team, err := c.getTeam(ctx, apiObj)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+// Create returns gitprovider.ErrNoProviderSupport, as creating teams isn't supported.
+func (c *TeamsClient) Create(_ context.Context, _ gitprovider.TeamInfo) (gitprovider.Team, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport, as creating teams isn't supported.
+func (c *TeamsClient) Reconcile(_ context.Context, _ gitprovider.TeamInfo) (gitprovider.Team, bool, error) {
+	return nil, false, gitprovider.ErrNoProviderSupport
+}
+
+func (c *TeamsClient) getTeam(ctx context.Context, apiObj *gitea.Team) (*team, error) {
+	// GET /teams/{id}/members
+	apiObjs, err := c.c.ListOrgTeamMembers(ctx, apiObj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect a list of the members' names. UserName is validated to be set in ListOrgTeamMembers.
+	logins := make([]string, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		logins = append(logins, apiObj.UserName)
+	}
+
+	return &team{
+		users: apiObjs,
+		info: gitprovider.TeamInfo{
+			Name:    apiObj.Name,
+			Members: logins,
+		},
+		ref: c.ref,
+	}, nil
+}
+
+var _ gitprovider.Team = &team{}
+
+type team struct {
+	users []*gitea.User
+	info  gitprovider.TeamInfo
+	ref   gitprovider.OrganizationRef
+}
+
+func (t *team) Get() gitprovider.TeamInfo {
+	return t.info
+}
+
+func (t *team) APIObject() interface{} {
+	return t.users
+}
+
+func (t *team) Organization() gitprovider.OrganizationRef {
+	return t.ref
+}
+
+// Set returns gitprovider.ErrNoProviderSupport, as managing team members isn't supported.
+func (t *team) Set(_ gitprovider.TeamInfo) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Update returns gitprovider.ErrNoProviderSupport.
+func (t *team) Update(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Reconcile returns gitprovider.ErrNoProviderSupport.
+func (t *team) Reconcile(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// Delete returns gitprovider.ErrNoProviderSupport.
+func (t *team) Delete(_ context.Context) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// AddMember returns gitprovider.ErrNoProviderSupport.
+func (t *team) AddMember(_ context.Context, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// RemoveMember returns gitprovider.ErrNoProviderSupport.
+func (t *team) RemoveMember(_ context.Context, _ string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// validateTeamAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateTeamAPI(apiObj *gitea.Team) error {
+	return validateAPIObject("Gitea.Team", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
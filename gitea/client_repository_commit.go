@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// CommitClient implements the gitprovider.CommitClient interface.
+var _ gitprovider.CommitClient = &CommitClient{}
+
+// CommitClient operates on the commits for a specific repository.
+type CommitClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// ListPage lists all repository commits of the given page and page size.
+// ListPage returns all available repository commits
+// using multiple paginated requests if needed.
+func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	dks, err := c.listPage(ctx, branch, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.Commit
+	commits := make([]gitprovider.Commit, 0, len(dks))
+	for _, dk := range dks {
+		commits = append(commits, dk)
+	}
+	return commits, nil
+}
+
+func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
+	// GET /repos/{owner}/{repo}/commits
+	apiObjs, err := c.c.ListCommitsPage(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our CommitType type
+	keys := make([]*commitType, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		keys = append(keys, newCommit(c, apiObj))
+	}
+
+	return keys, nil
+}
+
+// Create creates a commit with the given specifications.
+//
+// The Gitea API changes a single file per request, hence a commit is created for every
+// file in files, all using the given message. The last of these commits is returned.
+// Files with a nil Content are deleted.
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	fileOpts := gitea.FileOptions{
+		Message:    message,
+		BranchName: branch,
+	}
+	for _, file := range files {
+		if err := file.Validate(); err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+		path := *file.Path
+		if file.GetMode() != gitprovider.CommitFileModeRegular {
+			return nil, fmt.Errorf("cannot commit %q with mode %s: %w", path, file.GetMode(), gitprovider.ErrNoProviderSupport)
+		}
+		content, err := file.DecodedContent()
+		if err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+
+		// A moved file is looked up at its previous path
+		action := file.GetAction()
+		existingPath := path
+		if action == gitprovider.CommitFileActionMove {
+			existingPath = *file.PreviousPath
+		}
+		// GET /repos/{owner}/{repo}/contents/{filepath}
+		existing, err := c.c.GetContents(ctx, owner, repo, branch, existingPath)
+		if err != nil && !errors.Is(err, gitprovider.ErrNotFound) {
+			return nil, err
+		}
+
+		switch {
+		case action == gitprovider.CommitFileActionDelete && existing == nil:
+			if file.Action != nil {
+				return nil, fmt.Errorf("cannot delete %q: %w", path, gitprovider.ErrNotFound)
+			}
+			// Nothing to delete
+			continue
+		case action == gitprovider.CommitFileActionDelete:
+			// DELETE /repos/{owner}/{repo}/contents/{filepath}
+			err = c.c.DeleteFile(ctx, owner, repo, path, gitea.DeleteFileOptions{
+				FileOptions: fileOpts,
+				SHA:         existing.SHA,
+			})
+		case existing == nil && (action == gitprovider.CommitFileActionUpdate || action == gitprovider.CommitFileActionMove):
+			return nil, fmt.Errorf("cannot %s %q: %w", action, existingPath, gitprovider.ErrNotFound)
+		case existing == nil:
+			// POST /repos/{owner}/{repo}/contents/{filepath}
+			_, err = c.c.CreateFile(ctx, owner, repo, path, gitea.CreateFileOptions{
+				FileOptions: fileOpts,
+				Content:     base64.StdEncoding.EncodeToString(content),
+			})
+		case action == gitprovider.CommitFileActionAdd:
+			return nil, fmt.Errorf("cannot add %q: %w", path, gitprovider.ErrAlreadyExists)
+		default:
+			opts := gitea.UpdateFileOptions{
+				FileOptions: fileOpts,
+				SHA:         existing.SHA,
+				Content:     base64.StdEncoding.EncodeToString(content),
+			}
+			if action == gitprovider.CommitFileActionMove {
+				opts.FromPath = existingPath
+				// Moving a file without new content keeps its content
+				if file.Content == nil && existing.Content != nil {
+					opts.Content = *existing.Content
+				}
+			}
+			// PUT /repos/{owner}/{repo}/contents/{filepath}
+			_, err = c.c.UpdateFile(ctx, owner, repo, path, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return the latest commit of the branch
+	commits, err := c.listPage(ctx, branch, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found on branch %q: %w", branch, gitprovider.ErrUnexpectedEvent)
+	}
+	return commits[0], nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	contentTypeFile      = "file"
+	contentTypeDirectory = "dir"
+)
+
+// FileClient implements the gitprovider.FileClient interface.
+var _ gitprovider.FileClient = &FileClient{}
+
+// FileClient operates on the branch for a specific repository.
+type FileClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get fetches and returns the contents of a file or multiple files in a directory from a given branch and path with possible options of FilesGetOption
+// If a file path is given, the contents of the file are returned
+// If a directory path is given, the contents of the files in the path's root are returned
+func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	fileOpts := gitprovider.FilesGetOptions{}
+	for _, opt := range optFns {
+		opt.ApplyFilesGetOptions(&fileOpts)
+	}
+
+	files, err := c.getFiles(ctx, path, branch, fileOpts.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found on this path[%s]", path)
+	}
+
+	return files, nil
+}
+
+func (c *FileClient) getFiles(ctx context.Context, path, branch string, recursive bool) ([]*gitprovider.CommitFile, error) {
+	// GET /repos/{owner}/{repo}/contents/{filepath}
+	directoryContent, err := c.c.ListContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*gitprovider.CommitFile, 0)
+	for _, file := range directoryContent {
+		switch file.Type {
+		case contentTypeFile:
+			// GET /repos/{owner}/{repo}/raw/{filepath}?ref={ref}
+			content, err := c.c.GetFile(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, file.Path)
+			if err != nil {
+				return nil, err
+			}
+			filePath := file.Path
+			contentStr := string(content)
+			files = append(files, &gitprovider.CommitFile{
+				Path:    &filePath,
+				Content: &contentStr,
+				SHA:     gitprovider.StringVar(file.SHA),
+			})
+		case contentTypeDirectory:
+			if !recursive {
+				continue
+			}
+			subFiles, err := c.getFiles(ctx, file.Path, branch, recursive)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, subFiles...)
+		}
+	}
+
+	return files, nil
+}
+
+// Put creates or updates the file at path on the given branch, committing content with message.
+// ErrConflict is returned if the blob SHA of the file isn't expectedSHA, which is empty for new files.
+func (c *FileClient) Put(ctx context.Context, path, branch, content, message, expectedSHA string) error {
+	if _, err := c.checkSHA(ctx, path, branch, expectedSHA); err != nil {
+		return err
+	}
+
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	fileOpts := gitea.FileOptions{
+		Message:    message,
+		BranchName: branch,
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+	if expectedSHA == "" {
+		// POST /repos/{owner}/{repo}/contents/{filepath}
+		_, err := c.c.CreateFile(ctx, owner, repo, path, gitea.CreateFileOptions{
+			FileOptions: fileOpts,
+			Content:     encoded,
+		})
+		if errors.Is(err, gitprovider.ErrAlreadyExists) {
+			return fmt.Errorf("file %q: %w", path, gitprovider.ErrConflict)
+		}
+		return err
+	}
+	// Gitea rejects the update if the file doesn't have the given SHA anymore
+	// PUT /repos/{owner}/{repo}/contents/{filepath}
+	_, err := c.c.UpdateFile(ctx, owner, repo, path, gitea.UpdateFileOptions{
+		FileOptions: fileOpts,
+		SHA:         expectedSHA,
+		Content:     encoded,
+	})
+	return err
+}
+
+// Delete deletes the file at path on the given branch, committing with message.
+// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its blob SHA isn't expectedSHA.
+func (c *FileClient) Delete(ctx context.Context, path, branch, message, expectedSHA string) error {
+	if expectedSHA == "" {
+		return fmt.Errorf("expected SHA is required to delete file %q: %w", path, gitprovider.ErrInvalidArgument)
+	}
+	exists, err := c.checkSHA(ctx, path, branch, expectedSHA)
+	if errors.Is(err, gitprovider.ErrConflict) && !exists {
+		return fmt.Errorf("file %q: %w", path, gitprovider.ErrNotFound)
+	} else if err != nil {
+		return err
+	}
+
+	// DELETE /repos/{owner}/{repo}/contents/{filepath}
+	return c.c.DeleteFile(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, gitea.DeleteFileOptions{
+		FileOptions: gitea.FileOptions{
+			Message:    message,
+			BranchName: branch,
+		},
+		SHA: expectedSHA,
+	})
+}
+
+// checkSHA returns whether the file at path exists, and ErrConflict if its SHA isn't expectedSHA.
+func (c *FileClient) checkSHA(ctx context.Context, path, branch, expectedSHA string) (bool, error) {
+	// GET /repos/{owner}/{repo}/contents/{filepath}
+	existing, err := c.c.GetContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, path)
+	actualSHA := ""
+	if err == nil {
+		actualSHA = existing.SHA
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return false, err
+	}
+	if actualSHA != expectedSHA {
+		return actualSHA != "", fmt.Errorf("file %q has SHA %q, expected %q: %w", path, actualSHA, expectedSHA, gitprovider.ErrConflict)
+	}
+	return actualSHA != "", nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+//nolint:gochecknoglobals
+var mergeStyles = map[gitprovider.MergeMethod]gitea.MergeStyle{
+	gitprovider.MergeMethodMerge:  gitea.MergeStyleMerge,
+	gitprovider.MergeMethodSquash: gitea.MergeStyleSquash,
+	gitprovider.MergeMethodRebase: gitea.MergeStyleRebase,
+}
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests for a specific repository.
+type PullRequestClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all pull requests in the repository, in all states unless filtered
+// with PullRequestListOptions.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitea doesn't tell merged pull requests apart from closed ones, nor filter on branches,
+	// that is done client side
+	state := gitea.StateAll
+	if o.State != nil && *o.State == gitprovider.PullRequestStateOpen {
+		state = gitea.StateOpen
+	} else if o.State != nil {
+		state = gitea.StateClosed
+	}
+
+	// GET /repos/{owner}/{repo}/pulls
+	prs, err := c.c.ListPullRequests(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), state)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]gitprovider.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		request := newPullRequest(c.clientContext, pr)
+		if o.Matches(request.Get()) {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests, nil
+}
+
+// Create creates a pull request with the given specifications. Only assignees are supported,
+// other options return gitprovider.ErrNoProviderSupport.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(o.Labels) > 0:
+		return nil, fmt.Errorf("gitea pull requests can't be labeled by name: %w", gitprovider.ErrNoProviderSupport)
+	case len(o.Reviewers) > 0:
+		return nil, fmt.Errorf("gitea pull requests can't request reviewers yet: %w", gitprovider.ErrNoProviderSupport)
+	case o.Draft != nil && *o.Draft:
+		return nil, fmt.Errorf("gitea pull requests don't support drafts: %w", gitprovider.ErrNoProviderSupport)
+	case o.MaintainerCanModify != nil:
+		return nil, fmt.Errorf("gitea pull requests don't support maintainer edits: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	prOpts := gitea.CreatePullRequestOption{
+		Title:     title,
+		Head:      branch,
+		Base:      baseBranch,
+		Body:      description,
+		Assignees: o.Assignees,
+	}
+
+	// POST /repos/{owner}/{repo}/pulls
+	pr, err := c.c.CreatePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), prOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPullRequest(c.clientContext, pr), nil
+}
+
+// Get retrieves an existing pull request by number
+func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.PullRequest, error) {
+	// GET /repos/{owner}/{repo}/pulls/{index}
+	pr, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), int64(number))
+	if err != nil {
+		return nil, err
+	}
+
+	return newPullRequest(c.clientContext, pr), nil
+}
+
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Update(ctx context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	// GET /repos/{owner}/{repo}/pulls/{index}
+	pr, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), int64(number))
+	if err != nil {
+		return nil, err
+	}
+
+	// The body is always sent, so start from the current pull request
+	editOpts := gitea.EditPullRequestOption{
+		Title: pr.Title,
+		Body:  pr.Body,
+	}
+	if req.Title != nil {
+		editOpts.Title = *req.Title
+	}
+	if req.Description != nil {
+		editOpts.Body = *req.Description
+	}
+	if req.TargetBranch != nil {
+		editOpts.Base = *req.TargetBranch
+	}
+
+	// PATCH /repos/{owner}/{repo}/pulls/{index}
+	pr, err = c.c.EditPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), editOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPullRequest(c.clientContext, pr), nil
+}
+
+// Close closes the pull request with the given number without merging it.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Close(ctx context.Context, number int) error {
+	// GET /repos/{owner}/{repo}/pulls/{index}
+	pr, err := c.c.GetPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), int64(number))
+	if err != nil {
+		return err
+	}
+
+	state := gitea.StateClosed
+	// PATCH /repos/{owner}/{repo}/pulls/{index}
+	_, err = c.c.EditPullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), gitea.EditPullRequestOption{
+		Title: pr.Title,
+		Body:  pr.Body,
+		State: &state,
+	})
+	return err
+}
+
+// Merge merges a pull request with the given specifications. The Gitea SDK can't request
+// auto-merge or deleting the source branch, hence these options return
+// gitprovider.ErrNoProviderSupport.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	style, ok := mergeStyles[mergeMethod]
+	if !ok {
+		return fmt.Errorf("merge method %q: %w", mergeMethod, gitprovider.ErrNoProviderSupport)
+	}
+	switch {
+	case o.AutoMerge != nil && *o.AutoMerge:
+		return fmt.Errorf("gitea pull requests don't support auto-merge: %w", gitprovider.ErrNoProviderSupport)
+	case o.DeleteSourceBranch != nil && *o.DeleteSourceBranch:
+		return fmt.Errorf("gitea pull requests can't delete the source branch on merge: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	// POST /repos/{owner}/{repo}/pulls/{index}/merge
+	return c.c.MergePullRequest(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), gitea.MergePullRequestOption{
+		Style:   style,
+		Message: message,
+	})
+}
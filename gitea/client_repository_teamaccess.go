@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamAccessClient implements the gitprovider.TeamAccessClient interface.
+var _ gitprovider.TeamAccessClient = &TeamAccessClient{}
+
+// TeamAccessClient operates on the teams list for a specific repository.
+//
+// In Gitea, the permission level is a property of the team and applies to all repositories
+// the team has access to, hence it can't be set for a single repository.
+type TeamAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get a team's permission level of this given repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamAccessClient) Get(ctx context.Context, name string) (gitprovider.TeamAccess, error) {
+	// GET /repos/{owner}/{repo}/teams/{team}
+	apiObj, err := c.c.GetRepoTeam(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name)
+	if err != nil {
+		return nil, err
+	}
+	return newTeamAccess(c, apiObj)
+}
+
+// List lists the team access control list for this repository.
+//
+// List returns all available team access lists, using multiple paginated requests if needed.
+func (c *TeamAccessClient) List(ctx context.Context) ([]gitprovider.TeamAccess, error) {
+	// GET /repos/{owner}/{repo}/teams
+	apiObjs, err := c.c.ListRepoTeams(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	teamAccess := make([]gitprovider.TeamAccess, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		ta, err := newTeamAccess(c, apiObj)
+		if err != nil {
+			return nil, err
+		}
+		teamAccess = append(teamAccess, ta)
+	}
+
+	return teamAccess, nil
+}
+
+// Create adds a given team to the repo's team access control list.
+//
+// The requested permission must match the permission of the team, as the team's
+// permission is used for all its repositories in Gitea. Otherwise, an error
+// wrapping ErrNoProviderSupport is returned.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamAccessClient) Create(ctx context.Context, req gitprovider.TeamAccessInfo) (gitprovider.TeamAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// GET /orgs/{org}/teams
+	apiObj, err := c.c.GetOrgTeam(ctx, c.ref.GetIdentity(), req.Name)
+	if err != nil {
+		return nil, err
+	}
+	ta, err := newTeamAccess(c, apiObj)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTeamPermission(req, ta.Get()); err != nil {
+		return nil, err
+	}
+
+	// PUT /repos/{owner}/{repo}/teams/{team}
+	if err := c.c.AddTeam(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Name); err != nil {
+		return nil, err
+	}
+	return ta, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, an error wrapping ErrNoProviderSupport is returned,
+// as the team's permission can't be changed for a single repository.
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.TeamAccessInfo,
+) (gitprovider.TeamAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// validateTeamPermission makes sure the desired permission of a team is its actual permission,
+// as Gitea doesn't support setting the permission of a team per repository.
+func validateTeamPermission(desired, actual gitprovider.TeamAccessInfo) error {
+	if desired.Permission != nil && actual.Permission != nil && *desired.Permission != *actual.Permission {
+		return fmt.Errorf("team %q has permission %q for all its repositories, can't set it to %q: %w",
+			desired.Name, *actual.Permission, *desired.Permission, gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserAccessClient implements the gitprovider.UserAccessClient interface.
+var _ gitprovider.UserAccessClient = &UserAccessClient{}
+
+// UserAccessClient operates on the collaborators of a specific repository.
+//
+// The Gitea API client doesn't expose the permission of a collaborator, hence the Permission
+// of the returned UserAccess objects is nil, unless it has just been set by this client.
+type UserAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get a collaborator of the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// UserAccess.APIObject will be nil, because the collaborator check doesn't return any Gitea struct.
+func (c *UserAccessClient) Get(ctx context.Context, login string) (gitprovider.UserAccess, error) {
+	// GET /repos/{owner}/{repo}/collaborators/{collaborator}
+	if err := c.c.IsCollaborator(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), login); err != nil {
+		return nil, err
+	}
+	return newUserAccess(c, gitprovider.UserAccessInfo{Login: login}, nil), nil
+}
+
+// List lists the collaborators of this repository.
+//
+// List returns all available user access lists, using multiple paginated requests if needed.
+func (c *UserAccessClient) List(ctx context.Context) ([]gitprovider.UserAccess, error) {
+	// GET /repos/{owner}/{repo}/collaborators
+	apiObjs, err := c.c.ListCollaborators(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	userAccess := make([]gitprovider.UserAccess, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// UserName is validated to be set in ListCollaborators
+		userAccess = append(userAccess, newUserAccess(c, gitprovider.UserAccessInfo{Login: apiObj.UserName}, apiObj))
+	}
+
+	return userAccess, nil
+}
+
+// Create adds a given user as collaborator of the repository.
+// Only the pull, push and admin permissions can be represented in Gitea.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *UserAccessClient) Create(ctx context.Context, req gitprovider.UserAccessInfo) (gitprovider.UserAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	permission, err := getGiteaPermission(*req.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	// PUT /repos/{owner}/{repo}/collaborators/{collaborator}
+	if err := c.c.AddCollaborator(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Login, permission); err != nil {
+		return nil, err
+	}
+	return newUserAccess(c, req, nil), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req already exists, this is a no-op (actionTaken == false), as the permission of the collaborator
+// can't be compared. Use UserAccess.Update() to enforce the permission.
+func (c *UserAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.UserAccessInfo,
+) (gitprovider.UserAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// The user is a collaborator already, populate the desired state to the actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, false, nil
+}
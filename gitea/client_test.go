@@ -0,0 +1,675 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const apiPrefix = "/api/v1"
+
+func setup(t *testing.T, optFns ...gitprovider.ClientOption) (*http.ServeMux, gitprovider.Client, string) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	optFns = append([]gitprovider.ClientOption{
+		gitprovider.WithDomain(server.URL),
+		gitprovider.WithOAuth2Token("token"),
+	}, optFns...)
+	c, err := NewClient(optFns...)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return mux, c, server.URL
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+func writeError(t *testing.T, w http.ResponseWriter, status int, message string) {
+	writeJSON(t, w, status, map[string]string{"message": message})
+}
+
+func TestAuthentication(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/orgs/org", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization header = %q, want %q", got, "Bearer token")
+		}
+		writeJSON(t, w, http.StatusOK, &gitea.Organization{ID: 1, UserName: "org"})
+	})
+
+	if _, err := c.Organizations().Get(context.Background(), gitprovider.OrganizationRef{
+		Domain:       c.SupportedDomain(),
+		Organization: "org",
+	}); err != nil {
+		t.Fatalf("Organizations().Get returned error: %v", err)
+	}
+}
+
+func TestOrganizations_List(t *testing.T) {
+	mux, c, serverURL := setup(t)
+	mux.HandleFunc(apiPrefix+"/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s/user/orgs?page=2>; rel="next"`, serverURL, apiPrefix))
+			writeJSON(t, w, http.StatusOK, []*gitea.Organization{{ID: 1, UserName: "org1", FullName: "Org One"}})
+		case "2":
+			writeJSON(t, w, http.StatusOK, []*gitea.Organization{{ID: 2, UserName: "org2"}})
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+
+	orgs, err := c.Organizations().List(context.Background())
+	if err != nil {
+		t.Fatalf("Organizations().List returned error: %v", err)
+	}
+	got := []string{}
+	for _, org := range orgs {
+		got = append(got, org.Organization().Organization)
+	}
+	if diff := cmp.Diff([]string{"org1", "org2"}, got); diff != "" {
+		t.Errorf("unexpected organizations (-want +got):\n%s", diff)
+	}
+	if name := orgs[0].Get().Name; name == nil || *name != "Org One" {
+		t.Errorf("unexpected organization name %v", name)
+	}
+
+	_, err = c.Organizations().Children(context.Background(), orgs[0].Organization())
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Children() error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		message string
+		want    error
+	}{
+		{name: "not found", status: http.StatusNotFound, message: "not found", want: gitprovider.ErrNotFound},
+		{name: "conflict", status: http.StatusConflict, message: "repository already exists", want: gitprovider.ErrAlreadyExists},
+		{name: "unprocessable", status: http.StatusUnprocessableEntity, message: "name has been used", want: gitprovider.ErrAlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c, _ := setup(t)
+			mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+				writeError(t, w, tt.status, tt.message)
+			})
+			_, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		mux, c, _ := setup(t)
+		mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+			writeError(t, w, http.StatusUnauthorized, "token is required")
+		})
+		_, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+		var credErr *gitprovider.InvalidCredentialsError
+		if !errors.As(err, &credErr) {
+			t.Errorf("error = %v, want InvalidCredentialsError", err)
+		}
+	})
+}
+
+func TestOrgRepositories_Reconcile(t *testing.T) {
+	mux, c, _ := setup(t)
+	created := false
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		if !created {
+			writeError(t, w, http.StatusNotFound, "not found")
+			return
+		}
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo", Description: "desc", DefaultBranch: "main", Private: true})
+	})
+	mux.HandleFunc(apiPrefix+"/org/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		var req gitea.CreateRepoOption
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Name != "repo" || !req.Private || req.License != "Apache-2.0" || !req.AutoInit {
+			t.Errorf("unexpected create request %+v", req)
+		}
+		created = true
+		writeJSON(t, w, http.StatusCreated, &gitea.Repository{ID: 1, Name: "repo", Description: "desc", DefaultBranch: "main", Private: true})
+	})
+
+	ref := newOrgRepoRef(c, "org", "repo")
+	info := gitprovider.RepositoryInfo{
+		Description: gitprovider.StringVar("desc"),
+	}
+	repo, actionTaken, err := c.OrgRepositories().Reconcile(context.Background(), ref, info,
+		&gitprovider.RepositoryCreateOptions{
+			AutoInit:        gitprovider.BoolVar(true),
+			LicenseTemplate: gitprovider.LicenseTemplateVar(gitprovider.LicenseTemplateApache2),
+		})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if !actionTaken {
+		t.Errorf("Reconcile didn't create the repository")
+	}
+	if got := *repo.Get().Visibility; got != gitprovider.RepositoryVisibilityPrivate {
+		t.Errorf("Visibility = %q, want %q", got, gitprovider.RepositoryVisibilityPrivate)
+	}
+
+	// A second reconcile with the same spec must be a no-op
+	_, actionTaken, err = c.OrgRepositories().Reconcile(context.Background(), ref, info)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if actionTaken {
+		t.Errorf("Reconcile took action for an up-to-date repository")
+	}
+}
+
+func TestRepository_DeleteDisallowed(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			t.Errorf("DELETE request must not be sent")
+		}
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if err := repo.Delete(context.Background()); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete() error = %v, want ErrDestructiveCallDisallowed", err)
+	}
+}
+
+func TestDeployKeys(t *testing.T) {
+	mux, c, _ := setup(t)
+	keys := []*gitea.DeployKey{}
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, http.StatusOK, keys)
+		case http.MethodPost:
+			var req gitea.CreateKeyOption
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			key := &gitea.DeployKey{ID: 1, Title: req.Title, Key: req.Key, ReadOnly: req.ReadOnly}
+			keys = append(keys, key)
+			writeJSON(t, w, http.StatusCreated, key)
+		}
+	})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if _, err := repo.DeployKeys().Get(context.Background(), "key"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	_, err = repo.DeployKeys().Create(context.Background(), gitprovider.DeployKeyInfo{
+		Name: "key",
+		Key:  []byte("ssh-ed25519 AAAA"),
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	key, err := repo.DeployKeys().Get(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got := key.Get(); got.ReadOnly == nil || !*got.ReadOnly {
+		t.Errorf("ReadOnly = %v, want true", got.ReadOnly)
+	}
+}
+
+func TestTeamAccess_PermissionMismatch(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	mux.HandleFunc(apiPrefix+"/orgs/org/teams", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, []*gitea.Team{{ID: 1, Name: "readers", Permission: gitea.AccessModeRead}})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/teams/readers", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s request for team", r.Method)
+	})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	push := gitprovider.RepositoryPermissionPush
+	_, err = repo.TeamAccess().Create(context.Background(), gitprovider.TeamAccessInfo{
+		Name:       "readers",
+		Permission: &push,
+	})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestUserAccess(t *testing.T) {
+	mux, c, _ := setup(t)
+	collaborators := map[string]gitea.AccessMode{}
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		users := []*gitea.User{}
+		for login := range collaborators {
+			users = append(users, &gitea.User{UserName: login})
+		}
+		writeJSON(t, w, http.StatusOK, users)
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/collaborators/", func(w http.ResponseWriter, r *http.Request) {
+		login := strings.TrimPrefix(r.URL.Path, apiPrefix+"/repos/org/repo/collaborators/")
+		switch r.Method {
+		case http.MethodGet:
+			if _, ok := collaborators[login]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPut:
+			var req gitea.AddCollaboratorOption
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			collaborators[login] = *req.Permission
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(collaborators, login)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if _, err := repo.UserAccess().Get(context.Background(), "alice"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	push := gitprovider.RepositoryPermissionPush
+	ua, actionTaken, err := repo.UserAccess().Reconcile(context.Background(), gitprovider.UserAccessInfo{
+		Login:      "alice",
+		Permission: &push,
+	})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() actionTaken = false, want true")
+	}
+	if got := collaborators["alice"]; got != gitea.AccessModeWrite {
+		t.Errorf("permission = %q, want %q", got, gitea.AccessModeWrite)
+	}
+
+	list, err := repo.UserAccess().List(context.Background())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(list) != 1 || list[0].Get().Login != "alice" {
+		t.Errorf("List() = %v, want alice", list)
+	}
+
+	maintain := gitprovider.RepositoryPermissionMaintain
+	if err := ua.Set(gitprovider.UserAccessInfo{Login: "alice", Permission: &maintain}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if err := ua.Update(context.Background()); !errors.Is(err, gitprovider.ErrInvalidPermissionLevel) {
+		t.Errorf("Update() error = %v, want ErrInvalidPermissionLevel", err)
+	}
+
+	if err := ua.Delete(context.Background()); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if len(collaborators) != 0 {
+		t.Errorf("collaborators = %v, want none", collaborators)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	mux, c, serverURL := setup(t)
+	mux.HandleFunc(apiPrefix+"/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.User{ID: 1, UserName: "alice", FullName: "Alice", Email: "alice@example.com"})
+	})
+	mux.HandleFunc(apiPrefix+"/users/", func(w http.ResponseWriter, r *http.Request) {
+		if login := strings.TrimPrefix(r.URL.Path, apiPrefix+"/users/"); login != "bob" {
+			writeError(t, w, http.StatusNotFound, "user does not exist")
+			return
+		}
+		writeJSON(t, w, http.StatusOK, &gitea.User{ID: 2, UserName: "bob", AvatarURL: "https://avatars/bob"})
+	})
+
+	u, err := c.Users().GetAuthenticated(context.Background())
+	if err != nil {
+		t.Fatalf("GetAuthenticated returned error: %v", err)
+	}
+	want := gitprovider.UserInfo{Login: "alice", Name: "Alice", Email: "alice@example.com", WebURL: serverURL + "/alice"}
+	if diff := cmp.Diff(want, u.Get()); diff != "" {
+		t.Errorf("unexpected user (-want +got):\n%s", diff)
+	}
+
+	ref := gitprovider.UserRef{Domain: c.SupportedDomain(), UserLogin: "bob"}
+	u, err = c.Users().Get(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(ref, u.User()); diff != "" {
+		t.Errorf("unexpected user ref (-want +got):\n%s", diff)
+	}
+	if got := u.Get().AvatarURL; got != "https://avatars/bob" {
+		t.Errorf("AvatarURL = %q, want %q", got, "https://avatars/bob")
+	}
+
+	ref.UserLogin = "carol"
+	if _, err := c.Users().Get(context.Background(), ref); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBranches(t *testing.T) {
+	mux, c, _ := setup(t, gitprovider.WithDestructiveAPICalls(true))
+	branches := map[string]*gitea.Branch{
+		"main":    {Name: "main", Commit: &gitea.PayloadCommit{ID: "abc123"}, Protected: true},
+		"feature": {Name: "feature", Commit: &gitea.PayloadCommit{ID: "def456"}},
+	}
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var req gitea.EditRepoOption
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.DefaultBranch == nil || *req.DefaultBranch != "feature" || req.Description != nil {
+				t.Errorf("unexpected edit request %+v", req)
+			}
+		}
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, []*gitea.Branch{branches["main"], branches["feature"]})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/branches/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, apiPrefix+"/repos/org/repo/branches/")
+		branch, ok := branches[name]
+		if !ok {
+			writeError(t, w, http.StatusNotFound, "branch not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, http.StatusOK, branch)
+		case http.MethodDelete:
+			delete(branches, name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	repo, err := c.OrgRepositories().Get(ctx, newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	list, err := repo.Branches().List(ctx)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("List returned %d branches, want 2", len(list))
+	}
+	branch, err := repo.Branches().Get(ctx, "main")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(gitprovider.BranchInfo{Name: "main", Sha: "abc123", Protected: true}, branch.Get()); diff != "" {
+		t.Errorf("Get returned diff (want -> got):\n%s", diff)
+	}
+	if err := repo.Branches().SetDefault(ctx, "feature"); err != nil {
+		t.Errorf("SetDefault returned error: %v", err)
+	}
+	if err := repo.Branches().SetDefault(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("SetDefault() error = %v, want ErrNotFound", err)
+	}
+	if err := repo.Branches().Delete(ctx, "feature"); err != nil {
+		t.Errorf("Delete returned error: %v", err)
+	}
+	if err := repo.Branches().Delete(ctx, "feature"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if got := r.URL.Query().Get("state"); got != string(gitea.StateClosed) {
+				t.Errorf("state = %q, want %q", got, gitea.StateClosed)
+			}
+			writeJSON(t, w, http.StatusOK, []*gitea.PullRequest{
+				{Index: 1, State: gitea.StateClosed, HasMerged: true},
+				{Index: 2, State: gitea.StateClosed},
+			})
+			return
+		}
+		var req gitea.CreatePullRequestOption
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Head != "feature" || req.Base != "main" {
+			t.Errorf("unexpected create request %+v", req)
+		}
+		writeJSON(t, w, http.StatusCreated, &gitea.PullRequest{Index: 3, Title: req.Title, HTMLURL: "https://gitea.com/org/repo/pulls/3"})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/pulls/3", func(w http.ResponseWriter, r *http.Request) {
+		pr := &gitea.PullRequest{Index: 3, Title: "title", Body: "description", State: gitea.StateOpen}
+		if r.Method == http.MethodPatch {
+			var req gitea.EditPullRequestOption
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.Title != "title" || req.Body != "description" || req.State == nil || *req.State != gitea.StateClosed {
+				t.Errorf("unexpected edit request %+v", req)
+			}
+			pr.State = *req.State
+		}
+		writeJSON(t, w, http.StatusOK, pr)
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/pulls/3/merge", func(w http.ResponseWriter, r *http.Request) {
+		var req gitea.MergePullRequestOption
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Style != gitea.MergeStyleSquash {
+			t.Errorf("merge style = %q, want %q", req.Style, gitea.MergeStyleSquash)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	pr, err := repo.PullRequests().Create(context.Background(), "title", "feature", "main", "description")
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if got := pr.Get().Number; got != 3 {
+		t.Errorf("Number = %d, want 3", got)
+	}
+	prs, err := repo.PullRequests().List(context.Background(), &gitprovider.PullRequestListOptions{State: gitprovider.PullRequestStateVar(gitprovider.PullRequestStateClosed)})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Get().Number != 2 {
+		t.Errorf("List returned %d pull requests, want only number 2", len(prs))
+	}
+	if err := repo.PullRequests().Close(context.Background(), 3); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+	if err := repo.PullRequests().Merge(context.Background(), 3, gitprovider.MergeMethodSquash, "msg"); err != nil {
+		t.Errorf("Merge returned error: %v", err)
+	}
+	if err := repo.PullRequests().Merge(context.Background(), 3, gitprovider.MergeMethodFastForwardOnly, "msg"); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Merge() error = %v, want ErrNoProviderSupport", err)
+	}
+}
+
+func TestFilesAndTrees(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/contents/dir", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, []*gitea.ContentsResponse{
+			{Name: "a.txt", Path: "dir/a.txt", Type: "file"},
+			{Name: "sub", Path: "dir/sub", Type: "dir"},
+		})
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/raw/dir/a.txt", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref"); got != "main" {
+			t.Errorf("ref = %q, want %q", got, "main")
+		}
+		fmt.Fprint(w, "hello")
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/git/trees/abc", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("recursive"); got != "1" {
+			t.Errorf("recursive = %q, want %q", got, "1")
+		}
+		writeJSON(t, w, http.StatusOK, &gitea.GitTreeResponse{
+			SHA: "abc",
+			Entries: []gitea.GitEntry{
+				{Path: "dir", Type: "tree", SHA: "def"},
+				{Path: "dir/a.txt", Type: "blob", Size: 5, SHA: "123"},
+			},
+		})
+	})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	files, err := repo.Files().Get(context.Background(), "dir", "main")
+	if err != nil {
+		t.Fatalf("Files().Get returned error: %v", err)
+	}
+	if len(files) != 1 || *files[0].Path != "dir/a.txt" || *files[0].Content != "hello" {
+		t.Errorf("unexpected files %v", files)
+	}
+
+	entries, err := repo.Trees().List(context.Background(), "abc", "dir", true)
+	if err != nil {
+		t.Fatalf("Trees().List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "dir/a.txt" || entries[0].Size != 5 {
+		t.Errorf("unexpected tree entries %v", entries)
+	}
+}
+
+func TestFilePutAndDelete(t *testing.T) {
+	mux, c, _ := setup(t)
+	mux.HandleFunc(apiPrefix+"/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, &gitea.Repository{ID: 1, Name: "repo"})
+	})
+	updated := false
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/contents/a.txt", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, http.StatusOK, &gitea.ContentsResponse{Name: "a.txt", Path: "a.txt", Type: "file", SHA: "abc"})
+		case http.MethodPut:
+			req := gitea.UpdateFileOptions{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			if req.SHA != "abc" || req.BranchName != "main" {
+				t.Errorf("unexpected update request %+v", req)
+			}
+			updated = true
+			writeJSON(t, w, http.StatusOK, &gitea.FileResponse{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	mux.HandleFunc(apiPrefix+"/repos/org/repo/contents/missing.txt", func(w http.ResponseWriter, r *http.Request) {
+		writeError(t, w, http.StatusNotFound, "not found")
+	})
+
+	ctx := context.Background()
+	repo, err := c.OrgRepositories().Get(ctx, newOrgRepoRef(c, "org", "repo"))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if err := repo.Files().Put(ctx, "a.txt", "main", "a", "Add a.txt", ""); !errors.Is(err, gitprovider.ErrConflict) {
+		t.Errorf("Files().Put() of existing file error = %v, want %v", err, gitprovider.ErrConflict)
+	}
+	if err := repo.Files().Put(ctx, "a.txt", "main", "a", "Change a.txt", "stale"); !errors.Is(err, gitprovider.ErrConflict) {
+		t.Errorf("Files().Put() with stale SHA error = %v, want %v", err, gitprovider.ErrConflict)
+	}
+	if err := repo.Files().Put(ctx, "a.txt", "main", "a", "Change a.txt", "abc"); err != nil || !updated {
+		t.Errorf("Files().Put() = %v, updated = %v, want the file to be updated", err, updated)
+	}
+	if err := repo.Files().Delete(ctx, "missing.txt", "main", "Delete missing.txt", "abc"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Files().Delete() of missing file error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func newOrgRepoRef(c gitprovider.Client, org, repo string) gitprovider.OrgRepositoryRef {
+	return gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{
+			Domain:       c.SupportedDomain(),
+			Organization: org,
+		},
+		RepositoryName: repo,
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+// conformancePageSize is the page size of the stand-in, small enough for List to need multiple pages.
+const conformancePageSize = 2
+
+// giteaStandIn emulates the repository and deploy key endpoints of a Gitea server with a
+// single organization "org".
+type giteaStandIn struct {
+	t      *testing.T
+	mu     sync.Mutex
+	repos  []*gitea.Repository
+	keys   map[string][]*gitea.DeployKey
+	lastID int64
+}
+
+func TestConformance(t *testing.T) {
+	s := &giteaStandIn{t: t, keys: map[string][]*gitea.DeployKey{}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewClient(append([]gitprovider.ClientOption{
+				gitprovider.WithDomain(server.URL),
+				gitprovider.WithOAuth2Token("token"),
+			}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: gitprovider.OrganizationRef{Domain: server.URL, Organization: "org"},
+	})
+}
+
+func (s *giteaStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, apiPrefix+"/"), "/")
+	switch {
+	case len(path) == 3 && path[0] == "orgs" && path[1] == "org" && path[2] == "repos" && r.Method == http.MethodGet:
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		writePage(s.t, w, r, page, len(s.repos), func(start, end int) interface{} {
+			return s.repos[start:end]
+		})
+	case len(path) == 3 && path[0] == "org" && path[1] == "org" && path[2] == "repos" && r.Method == http.MethodPost:
+		opt := gitea.CreateRepoOption{}
+		s.decode(r, &opt)
+		if s.repo(opt.Name) != nil {
+			writeError(s.t, w, http.StatusConflict, "The repository with the same name already exists.")
+			return
+		}
+		repo := &gitea.Repository{
+			Name:          opt.Name,
+			FullName:      "org/" + opt.Name,
+			Description:   opt.Description,
+			Private:       opt.Private,
+			DefaultBranch: opt.DefaultBranch,
+		}
+		s.repos = append(s.repos, repo)
+		writeJSON(s.t, w, http.StatusCreated, repo)
+	case len(path) >= 3 && path[0] == "repos" && path[1] == "org":
+		s.serveRepo(w, r, path[2], path[3:])
+	default:
+		writeError(s.t, w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *giteaStandIn) serveRepo(w http.ResponseWriter, r *http.Request, name string, path []string) {
+	repo := s.repo(name)
+	if repo == nil {
+		writeError(s.t, w, http.StatusNotFound, "The target couldn't be found.")
+		return
+	}
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodPatch:
+		opt := gitea.EditRepoOption{}
+		s.decode(r, &opt)
+		if opt.Description != nil {
+			repo.Description = *opt.Description
+		}
+		if opt.Private != nil {
+			repo.Private = *opt.Private
+		}
+		if opt.DefaultBranch != nil {
+			repo.DefaultBranch = *opt.DefaultBranch
+		}
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodDelete:
+		for i := range s.repos {
+			if s.repos[i] == repo {
+				s.repos = append(s.repos[:i], s.repos[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && path[0] == "keys" && r.Method == http.MethodGet:
+		keys := s.keys[name]
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		writePage(s.t, w, r, page, len(keys), func(start, end int) interface{} {
+			return keys[start:end]
+		})
+	case len(path) == 1 && path[0] == "keys" && r.Method == http.MethodPost:
+		opt := gitea.CreateKeyOption{}
+		s.decode(r, &opt)
+		for _, key := range s.keys[name] {
+			if key.Title == opt.Title || key.Key == opt.Key {
+				writeError(s.t, w, http.StatusUnprocessableEntity, "Key content has been used as non-deploy key")
+				return
+			}
+		}
+		s.lastID++
+		key := &gitea.DeployKey{ID: s.lastID, Title: opt.Title, Key: opt.Key, ReadOnly: opt.ReadOnly}
+		s.keys[name] = append(s.keys[name], key)
+		writeJSON(s.t, w, http.StatusCreated, key)
+	case len(path) == 2 && path[0] == "keys" && r.Method == http.MethodDelete:
+		id, _ := strconv.ParseInt(path[1], 10, 64)
+		keys := s.keys[name]
+		for i := range keys {
+			if keys[i].ID == id {
+				s.keys[name] = append(keys[:i], keys[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(s.t, w, http.StatusNotFound, "The target couldn't be found.")
+	default:
+		writeError(s.t, w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *giteaStandIn) repo(name string) *gitea.Repository {
+	for _, repo := range s.repos {
+		if repo.Name == name {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (s *giteaStandIn) decode(r *http.Request, obj interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		s.t.Errorf("failed to decode request: %v", err)
+	}
+}
+
+// writePage writes the given page (starting at 1) of total items, and sets the Link header
+// if there are more pages.
+func writePage(t *testing.T, w http.ResponseWriter, r *http.Request, page, total int, items func(start, end int) interface{}) {
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * conformancePageSize
+	if start > total {
+		start = total
+	}
+	end := start + conformancePageSize
+	if end >= total {
+		end = total
+	} else {
+		next := *r.URL
+		query := next.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+	writeJSON(t, w, http.StatusOK, items(start, end))
+}
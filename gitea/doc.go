@@ -0,0 +1,22 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitea implements the gitprovider.Client interface for Gitea and Forgejo instances,
+// using the code.gitea.io/sdk/gitea client under the hood.
+//
+// Gitea organizations are mapped to top-level organizations. Gitea doesn't have nested
+// organizations, hence sub-organizations aren't supported.
+package gitea
@@ -0,0 +1,763 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// giteaClient is a wrapper around *gitea.Client, which implements higher-level methods,
+// operating on the Gitea SDK structs. Pagination is implemented for all List* methods, all returned
+// objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
+// This interface is also fakeable, in order to unit-test the client.
+type giteaClient interface {
+	// Client returns the underlying *gitea.Client
+	Client() *gitea.Client
+
+	// GetOrg is a wrapper for "GET /orgs/{org}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetOrg(ctx context.Context, orgName string) (*gitea.Organization, error)
+	// ListOrgs is a wrapper for "GET /user/orgs".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgs(ctx context.Context) ([]*gitea.Organization, error)
+
+	// ListOrgTeamMembers is a wrapper for "GET /teams/{id}/members".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgTeamMembers(ctx context.Context, teamID int64) ([]*gitea.User, error)
+	// ListOrgTeams is a wrapper for "GET /orgs/{org}/teams".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgTeams(ctx context.Context, orgName string) ([]*gitea.Team, error)
+	// GetOrgTeam finds the team called teamName using ListOrgTeams, as Gitea addresses teams by ID.
+	// ErrNotFound is returned if there's no team with the given name.
+	GetOrgTeam(ctx context.Context, orgName, teamName string) (*gitea.Team, error)
+
+	// GetMyUser is a wrapper for "GET /user".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetMyUser(ctx context.Context) (*gitea.User, error)
+	// GetUser is a wrapper for "GET /users/{username}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUser(ctx context.Context, username string) (*gitea.User, error)
+
+	// GetRepo is a wrapper for "GET /repos/{owner}/{repo}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepo(ctx context.Context, owner, repo string) (*gitea.Repository, error)
+	// ListOrgRepos is a wrapper for "GET /orgs/{org}/repos".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgRepos(ctx context.Context, org string) ([]*gitea.Repository, error)
+	// ListUserRepos is a wrapper for "GET /users/{username}/repos".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListUserRepos(ctx context.Context, username string) ([]*gitea.Repository, error)
+	// CreateRepo is a wrapper for "POST /user/repos" (if orgName == "")
+	// or "POST /org/{org}/repos" (if orgName != "").
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepo(ctx context.Context, orgName string, req gitea.CreateRepoOption) (*gitea.Repository, error)
+	// UpdateRepo is a wrapper for "PATCH /repos/{owner}/{repo}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepo(ctx context.Context, owner, repo string, req gitea.EditRepoOption) (*gitea.Repository, error)
+	// DeleteRepo is a wrapper for "DELETE /repos/{owner}/{repo}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteRepo(ctx context.Context, owner, repo string) error
+
+	// ListKeys is a wrapper for "GET /repos/{owner}/{repo}/keys".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListKeys(ctx context.Context, owner, repo string) ([]*gitea.DeployKey, error)
+	// CreateKey is a wrapper for "POST /repos/{owner}/{repo}/keys".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateKey(ctx context.Context, owner, repo string, req gitea.CreateKeyOption) (*gitea.DeployKey, error)
+	// DeleteKey is a wrapper for "DELETE /repos/{owner}/{repo}/keys/{id}".
+	// This function handles HTTP error wrapping.
+	DeleteKey(ctx context.Context, owner, repo string, id int64) error
+
+	// GetRepoTeam is a wrapper for "GET /repos/{owner}/{repo}/teams/{team}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepoTeam(ctx context.Context, owner, repo, teamName string) (*gitea.Team, error)
+	// ListRepoTeams is a wrapper for "GET /repos/{owner}/{repo}/teams".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListRepoTeams(ctx context.Context, owner, repo string) ([]*gitea.Team, error)
+	// AddTeam is a wrapper for "PUT /repos/{owner}/{repo}/teams/{team}".
+	// This function handles HTTP error wrapping.
+	AddTeam(ctx context.Context, owner, repo, teamName string) error
+	// RemoveTeam is a wrapper for "DELETE /repos/{owner}/{repo}/teams/{team}".
+	// This function handles HTTP error wrapping.
+	RemoveTeam(ctx context.Context, owner, repo, teamName string) error
+
+	// ListCollaborators is a wrapper for "GET /repos/{owner}/{repo}/collaborators".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListCollaborators(ctx context.Context, owner, repo string) ([]*gitea.User, error)
+	// IsCollaborator is a wrapper for "GET /repos/{owner}/{repo}/collaborators/{collaborator}".
+	// ErrNotFound is returned if the user isn't a collaborator of the repository.
+	// This function handles HTTP error wrapping.
+	IsCollaborator(ctx context.Context, owner, repo, user string) error
+	// AddCollaborator is a wrapper for "PUT /repos/{owner}/{repo}/collaborators/{collaborator}".
+	// This function handles HTTP error wrapping.
+	AddCollaborator(ctx context.Context, owner, repo, user string, permission gitea.AccessMode) error
+	// RemoveCollaborator is a wrapper for "DELETE /repos/{owner}/{repo}/collaborators/{collaborator}".
+	// This function handles HTTP error wrapping.
+	RemoveCollaborator(ctx context.Context, owner, repo, user string) error
+
+	// ListCommitsPage is a wrapper for "GET /repos/{owner}/{repo}/commits".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ListCommitsPage(ctx context.Context, owner, repo, branch string, perPage, page int) ([]*gitea.Commit, error)
+
+	// ListBranches is a wrapper for "GET /repos/{owner}/{repo}/branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, owner, repo string) ([]*gitea.Branch, error)
+	// GetBranch is a wrapper for "GET /repos/{owner}/{repo}/branches/{branch}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, owner, repo, branch string) (*gitea.Branch, error)
+	// CreateBranch is a wrapper for "POST /repos/{owner}/{repo}/branches".
+	// This function handles HTTP error wrapping.
+	CreateBranch(ctx context.Context, owner, repo string, req gitea.CreateBranchOption) (*gitea.Branch, error)
+	// DeleteBranch is a wrapper for "DELETE /repos/{owner}/{repo}/branches/{branch}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteBranch(ctx context.Context, owner, repo, branch string) error
+
+	// ListPullRequests is a wrapper for "GET /repos/{owner}/{repo}/pulls".
+	// This function handles pagination, HTTP error wrapping.
+	ListPullRequests(ctx context.Context, owner, repo string, state gitea.StateType) ([]*gitea.PullRequest, error)
+	// GetPullRequest is a wrapper for "GET /repos/{owner}/{repo}/pulls/{index}".
+	// This function handles HTTP error wrapping.
+	GetPullRequest(ctx context.Context, owner, repo string, index int64) (*gitea.PullRequest, error)
+	// CreatePullRequest is a wrapper for "POST /repos/{owner}/{repo}/pulls".
+	// This function handles HTTP error wrapping.
+	CreatePullRequest(ctx context.Context, owner, repo string, req gitea.CreatePullRequestOption) (*gitea.PullRequest, error)
+	// MergePullRequest is a wrapper for "POST /repos/{owner}/{repo}/pulls/{index}/merge".
+	// This function handles HTTP error wrapping.
+	MergePullRequest(ctx context.Context, owner, repo string, index int64, req gitea.MergePullRequestOption) error
+	// EditPullRequest is a wrapper for "PATCH /repos/{owner}/{repo}/pulls/{index}".
+	// This function handles HTTP error wrapping.
+	EditPullRequest(ctx context.Context, owner, repo string, index int64, req gitea.EditPullRequestOption) (*gitea.PullRequest, error)
+
+	// GetContents is a wrapper for "GET /repos/{owner}/{repo}/contents/{filepath}" for a file.
+	// This function handles HTTP error wrapping.
+	GetContents(ctx context.Context, owner, repo, ref, filepath string) (*gitea.ContentsResponse, error)
+	// ListContents is a wrapper for "GET /repos/{owner}/{repo}/contents/{filepath}" for a directory.
+	// This function handles HTTP error wrapping.
+	ListContents(ctx context.Context, owner, repo, ref, filepath string) ([]*gitea.ContentsResponse, error)
+	// GetFile is a wrapper for "GET /repos/{owner}/{repo}/raw/{filepath}".
+	// This function handles HTTP error wrapping.
+	GetFile(ctx context.Context, owner, repo, ref, filepath string) ([]byte, error)
+	// CreateFile is a wrapper for "POST /repos/{owner}/{repo}/contents/{filepath}".
+	// This function handles HTTP error wrapping.
+	CreateFile(ctx context.Context, owner, repo, filepath string, req gitea.CreateFileOptions) (*gitea.FileResponse, error)
+	// UpdateFile is a wrapper for "PUT /repos/{owner}/{repo}/contents/{filepath}".
+	// This function handles HTTP error wrapping.
+	UpdateFile(ctx context.Context, owner, repo, filepath string, req gitea.UpdateFileOptions) (*gitea.FileResponse, error)
+	// DeleteFile is a wrapper for "DELETE /repos/{owner}/{repo}/contents/{filepath}".
+	// This function handles HTTP error wrapping.
+	DeleteFile(ctx context.Context, owner, repo, filepath string, req gitea.DeleteFileOptions) error
+
+	// GetTree is a wrapper for "GET /repos/{owner}/{repo}/git/trees/{sha}".
+	// This function handles HTTP error wrapping.
+	GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*gitea.GitTreeResponse, error)
+}
+
+// giteaClientImpl is a wrapper around *gitea.Client, which implements higher-level methods,
+// operating on the Gitea SDK structs. See the giteaClient interface for method documentation.
+// Pagination is implemented for all List* methods, all returned
+// objects are validated, and HTTP errors are handled/wrapped using handleHTTPError.
+//
+// The Gitea SDK keeps the request context on the client instead of taking it per call,
+// hence every method sets the given context on the client before issuing requests.
+type giteaClientImpl struct {
+	c                  *gitea.Client
+	destructiveActions bool
+}
+
+// giteaClientImpl implements giteaClient.
+var _ giteaClient = &giteaClientImpl{}
+
+func (c *giteaClientImpl) Client() *gitea.Client {
+	return c.c
+}
+
+func (c *giteaClientImpl) GetOrg(ctx context.Context, orgName string) (*gitea.Organization, error) {
+	c.c.SetContext(ctx)
+	// GET /orgs/{org}
+	apiObj, res, err := c.c.GetOrg(orgName)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	// Validate the API object
+	if err := validateOrganizationAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListOrgs(ctx context.Context) ([]*gitea.Organization, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.Organization{}
+	opts := gitea.ListOrgsOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /user/orgs
+		pageObjs, res, listErr := c.c.ListMyOrgs(opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateOrganizationAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) ListOrgTeamMembers(ctx context.Context, teamID int64) ([]*gitea.User, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.User{}
+	opts := gitea.ListTeamMembersOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /teams/{id}/members
+		pageObjs, res, listErr := c.c.ListTeamMembers(teamID, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the UserName field is set.
+	for _, apiObj := range apiObjs {
+		if apiObj.UserName == "" {
+			return nil, fmt.Errorf("didn't expect login to be empty for user: %+v: %w", apiObj, gitprovider.ErrInvalidServerData)
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) ListOrgTeams(ctx context.Context, orgName string) ([]*gitea.Team, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.Team{}
+	opts := gitea.ListTeamsOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /orgs/{org}/teams
+		pageObjs, res, listErr := c.c.ListOrgTeams(orgName, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateTeamAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) GetOrgTeam(ctx context.Context, orgName, teamName string) (*gitea.Team, error) {
+	// GET /orgs/{org}/teams
+	apiObjs, err := c.ListOrgTeams(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.Name == teamName {
+			return apiObj, nil
+		}
+	}
+	return nil, fmt.Errorf("team %q not found in organization %q: %w", teamName, orgName, gitprovider.ErrNotFound)
+}
+
+func (c *giteaClientImpl) GetMyUser(ctx context.Context) (*gitea.User, error) {
+	c.c.SetContext(ctx)
+	// GET /user
+	apiObj, res, err := c.c.GetMyUserInfo()
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) GetUser(ctx context.Context, username string) (*gitea.User, error) {
+	c.c.SetContext(ctx)
+	// GET /users/{username}
+	apiObj, res, err := c.c.GetUserInfo(username)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) GetRepo(ctx context.Context, owner, repo string) (*gitea.Repository, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}
+	apiObj, res, err := c.c.GetRepo(owner, repo)
+	return validateRepositoryAPIResp(apiObj, res, err)
+}
+
+func validateRepositoryAPIResp(apiObj *gitea.Repository, res *gitea.Response, err error) (*gitea.Repository, error) {
+	// If the response contained an error, return
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	// Make sure apiObj is valid
+	if err := validateRepositoryAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListOrgRepos(ctx context.Context, org string) ([]*gitea.Repository, error) {
+	c.c.SetContext(ctx)
+	var apiObjs []*gitea.Repository
+	opts := gitea.ListOrgReposOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /orgs/{org}/repos
+		pageObjs, res, listErr := c.c.ListOrgRepos(org, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateRepositoryObjects(apiObjs)
+}
+
+func validateRepositoryObjects(apiObjs []*gitea.Repository) ([]*gitea.Repository, error) {
+	for _, apiObj := range apiObjs {
+		// Make sure apiObj is valid
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) ListUserRepos(ctx context.Context, username string) ([]*gitea.Repository, error) {
+	c.c.SetContext(ctx)
+	var apiObjs []*gitea.Repository
+	opts := gitea.ListReposOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /users/{username}/repos
+		pageObjs, res, listErr := c.c.ListUserRepos(username, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateRepositoryObjects(apiObjs)
+}
+
+func (c *giteaClientImpl) CreateRepo(ctx context.Context, orgName string, req gitea.CreateRepoOption) (*gitea.Repository, error) {
+	c.c.SetContext(ctx)
+	if orgName == "" {
+		// POST /user/repos
+		apiObj, res, err := c.c.CreateRepo(req)
+		return validateRepositoryAPIResp(apiObj, res, err)
+	}
+	// POST /org/{org}/repos
+	apiObj, res, err := c.c.CreateOrgRepo(orgName, req)
+	return validateRepositoryAPIResp(apiObj, res, err)
+}
+
+func (c *giteaClientImpl) UpdateRepo(ctx context.Context, owner, repo string, req gitea.EditRepoOption) (*gitea.Repository, error) {
+	c.c.SetContext(ctx)
+	// PATCH /repos/{owner}/{repo}
+	apiObj, res, err := c.c.EditRepo(owner, repo, req)
+	return validateRepositoryAPIResp(apiObj, res, err)
+}
+
+func (c *giteaClientImpl) DeleteRepo(ctx context.Context, owner, repo string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	c.c.SetContext(ctx)
+	// DELETE /repos/{owner}/{repo}
+	res, err := c.c.DeleteRepo(owner, repo)
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) ListKeys(ctx context.Context, owner, repo string) ([]*gitea.DeployKey, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.DeployKey{}
+	opts := gitea.ListDeployKeysOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/keys
+		pageObjs, res, listErr := c.c.ListDeployKeys(owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateDeployKeyAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) CreateKey(ctx context.Context, owner, repo string, req gitea.CreateKeyOption) (*gitea.DeployKey, error) {
+	c.c.SetContext(ctx)
+	// POST /repos/{owner}/{repo}/keys
+	apiObj, res, err := c.c.CreateDeployKey(owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateDeployKeyAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) DeleteKey(ctx context.Context, owner, repo string, id int64) error {
+	c.c.SetContext(ctx)
+	// DELETE /repos/{owner}/{repo}/keys/{id}
+	res, err := c.c.DeleteDeployKey(owner, repo, id)
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) GetRepoTeam(ctx context.Context, owner, repo, teamName string) (*gitea.Team, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/teams/{team}
+	apiObj, res, err := c.c.CheckRepoTeam(owner, repo, teamName)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	// The SDK returns a nil team if the team isn't assigned to the repository
+	if apiObj == nil {
+		return nil, fmt.Errorf("team %q has no access to repository %s/%s: %w", teamName, owner, repo, gitprovider.ErrNotFound)
+	}
+	if err := validateTeamAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListRepoTeams(ctx context.Context, owner, repo string) ([]*gitea.Team, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/teams
+	apiObjs, res, err := c.c.GetRepoTeams(owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	for _, apiObj := range apiObjs {
+		if err := validateTeamAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) AddTeam(ctx context.Context, owner, repo, teamName string) error {
+	c.c.SetContext(ctx)
+	// PUT /repos/{owner}/{repo}/teams/{team}
+	res, err := c.c.AddRepoTeam(owner, repo, teamName)
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) RemoveTeam(ctx context.Context, owner, repo, teamName string) error {
+	c.c.SetContext(ctx)
+	// DELETE /repos/{owner}/{repo}/teams/{team}
+	res, err := c.c.RemoveRepoTeam(owner, repo, teamName)
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) ListCollaborators(ctx context.Context, owner, repo string) ([]*gitea.User, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.User{}
+	opts := gitea.ListCollaboratorsOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/collaborators
+		pageObjs, res, listErr := c.c.ListCollaborators(owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateUserAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) IsCollaborator(ctx context.Context, owner, repo, user string) error {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/collaborators/{collaborator}
+	ok, res, err := c.c.IsCollaborator(owner, repo, user)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+	if !ok {
+		return fmt.Errorf("user %q isn't a collaborator of repository %s/%s: %w", user, owner, repo, gitprovider.ErrNotFound)
+	}
+	return nil
+}
+
+func (c *giteaClientImpl) AddCollaborator(ctx context.Context, owner, repo, user string, permission gitea.AccessMode) error {
+	c.c.SetContext(ctx)
+	// PUT /repos/{owner}/{repo}/collaborators/{collaborator}
+	res, err := c.c.AddCollaborator(owner, repo, user, gitea.AddCollaboratorOption{Permission: &permission})
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) RemoveCollaborator(ctx context.Context, owner, repo, user string) error {
+	c.c.SetContext(ctx)
+	// DELETE /repos/{owner}/{repo}/collaborators/{collaborator}
+	res, err := c.c.DeleteCollaborator(owner, repo, user)
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) ListCommitsPage(ctx context.Context, owner, repo, branch string, perPage, page int) ([]*gitea.Commit, error) {
+	c.c.SetContext(ctx)
+	opts := gitea.ListCommitOptions{
+		ListOptions: gitea.ListOptions{
+			Page:     page,
+			PageSize: perPage,
+		},
+		SHA: branch,
+	}
+	// GET /repos/{owner}/{repo}/commits
+	apiObjs, res, err := c.c.ListRepoCommits(owner, repo, opts)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	for _, apiObj := range apiObjs {
+		if err := validateCommitAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) ListBranches(ctx context.Context, owner, repo string) ([]*gitea.Branch, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.Branch{}
+	opts := gitea.ListRepoBranchesOptions{}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/branches
+		pageObjs, res, listErr := c.c.ListRepoBranches(owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) GetBranch(ctx context.Context, owner, repo, branch string) (*gitea.Branch, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/branches/{branch}
+	apiObj, res, err := c.c.GetRepoBranch(owner, repo, branch)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) CreateBranch(ctx context.Context, owner, repo string, req gitea.CreateBranchOption) (*gitea.Branch, error) {
+	c.c.SetContext(ctx)
+	// POST /repos/{owner}/{repo}/branches
+	apiObj, res, err := c.c.CreateBranch(owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	c.c.SetContext(ctx)
+	// DELETE /repos/{owner}/{repo}/branches/{branch}
+	deleted, res, err := c.c.DeleteRepoBranch(owner, repo, branch)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+	// The SDK only reports whether the branch was deleted, without an error for other status codes
+	if !deleted {
+		if res.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+		}
+		return fmt.Errorf("failed to delete branch %q: %s", branch, res.Status)
+	}
+	return nil
+}
+
+func (c *giteaClientImpl) ListPullRequests(ctx context.Context, owner, repo string, state gitea.StateType) ([]*gitea.PullRequest, error) {
+	c.c.SetContext(ctx)
+	apiObjs := []*gitea.PullRequest{}
+	opts := gitea.ListPullRequestsOptions{State: state}
+	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls
+		pageObjs, res, listErr := c.c.ListRepoPullRequests(owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) GetPullRequest(ctx context.Context, owner, repo string, index int64) (*gitea.PullRequest, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/pulls/{index}
+	apiObj, res, err := c.c.GetPullRequest(owner, repo, index)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) CreatePullRequest(ctx context.Context, owner, repo string, req gitea.CreatePullRequestOption) (*gitea.PullRequest, error) {
+	c.c.SetContext(ctx)
+	// POST /repos/{owner}/{repo}/pulls
+	apiObj, res, err := c.c.CreatePullRequest(owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) MergePullRequest(ctx context.Context, owner, repo string, index int64, req gitea.MergePullRequestOption) error {
+	c.c.SetContext(ctx)
+	// POST /repos/{owner}/{repo}/pulls/{index}/merge
+	merged, res, err := c.c.MergePullRequest(owner, repo, index, req)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+	// The SDK doesn't return an error for unsuccessful status codes here, only merged == false
+	if !merged {
+		err := fmt.Errorf("failed to merge pull request %d", index)
+		if res != nil {
+			err = fmt.Errorf("failed to merge pull request %d: %s", index, http.StatusText(res.StatusCode))
+		}
+		return handleHTTPError(res, err)
+	}
+	return nil
+}
+
+func (c *giteaClientImpl) EditPullRequest(ctx context.Context, owner, repo string, index int64, req gitea.EditPullRequestOption) (*gitea.PullRequest, error) {
+	c.c.SetContext(ctx)
+	// PATCH /repos/{owner}/{repo}/pulls/{index}
+	apiObj, res, err := c.c.EditPullRequest(owner, repo, index, req)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) GetContents(ctx context.Context, owner, repo, ref, filepath string) (*gitea.ContentsResponse, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/contents/{filepath}
+	apiObj, res, err := c.c.GetContents(owner, repo, ref, filepath)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) ListContents(ctx context.Context, owner, repo, ref, filepath string) ([]*gitea.ContentsResponse, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/contents/{filepath}
+	apiObjs, res, err := c.c.ListContents(owner, repo, ref, filepath)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObjs, nil
+}
+
+func (c *giteaClientImpl) GetFile(ctx context.Context, owner, repo, ref, filepath string) ([]byte, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/raw/{filepath}?ref={ref}
+	content, res, err := c.c.GetFile(owner, repo, ref, filepath)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return content, nil
+}
+
+func (c *giteaClientImpl) CreateFile(ctx context.Context, owner, repo, filepath string, req gitea.CreateFileOptions) (*gitea.FileResponse, error) {
+	c.c.SetContext(ctx)
+	// POST /repos/{owner}/{repo}/contents/{filepath}
+	apiObj, res, err := c.c.CreateFile(owner, repo, filepath, req)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) UpdateFile(ctx context.Context, owner, repo, filepath string, req gitea.UpdateFileOptions) (*gitea.FileResponse, error) {
+	c.c.SetContext(ctx)
+	// PUT /repos/{owner}/{repo}/contents/{filepath}
+	apiObj, res, err := c.c.UpdateFile(owner, repo, filepath, req)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+func (c *giteaClientImpl) DeleteFile(ctx context.Context, owner, repo, filepath string, req gitea.DeleteFileOptions) error {
+	c.c.SetContext(ctx)
+	// DELETE /repos/{owner}/{repo}/contents/{filepath}
+	res, err := c.c.DeleteFile(owner, repo, filepath, req)
+	return handleHTTPError(res, err)
+}
+
+func (c *giteaClientImpl) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*gitea.GitTreeResponse, error) {
+	c.c.SetContext(ctx)
+	// GET /repos/{owner}/{repo}/git/trees/{sha}
+	apiObj, res, err := c.c.GetTrees(owner, repo, sha, recursive)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
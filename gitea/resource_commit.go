@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newCommit(c *CommitClient, commit *gitea.Commit) *commitType {
+	return &commitType{
+		k: *commit,
+		c: c,
+	}
+}
+
+var _ gitprovider.Commit = &commitType{}
+
+type commitType struct {
+	k gitea.Commit
+	c *CommitClient
+}
+
+func (c *commitType) Get() gitprovider.CommitInfo {
+	return commitFromAPI(&c.k)
+}
+
+func (c *commitType) APIObject() interface{} {
+	return &c.k
+}
+
+func commitFromAPI(apiObj *gitea.Commit) gitprovider.CommitInfo {
+	info := gitprovider.CommitInfo{
+		Sha:       apiObj.SHA,
+		Message:   apiObj.RepoCommit.Message,
+		CreatedAt: apiObj.Created,
+		URL:       apiObj.HTMLURL,
+	}
+	if apiObj.RepoCommit.Tree != nil {
+		info.TreeSha = apiObj.RepoCommit.Tree.SHA
+	}
+	if apiObj.RepoCommit.Author != nil {
+		info.Author = apiObj.RepoCommit.Author.Name
+	}
+	return info
+}
+
+// validateCommitAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateCommitAPI(apiObj *gitea.Commit) error {
+	return validateAPIObject("Gitea.Commit", func(validator validation.Validator) {
+		if apiObj.CommitMeta == nil || apiObj.SHA == "" {
+			validator.Required("SHA")
+		}
+		if apiObj.RepoCommit == nil {
+			validator.Required("RepoCommit")
+		}
+	})
+}
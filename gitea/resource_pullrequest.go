@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newPullRequest(ctx *clientContext, apiObj *gitea.PullRequest) *pullrequest {
+	return &pullrequest{
+		clientContext: ctx,
+		pr:            *apiObj,
+	}
+}
+
+var _ gitprovider.PullRequest = &pullrequest{}
+
+type pullrequest struct {
+	*clientContext
+
+	pr gitea.PullRequest
+}
+
+func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
+	return pullrequestFromAPI(&pr.pr)
+}
+
+func (pr *pullrequest) APIObject() interface{} {
+	return &pr.pr
+}
+
+// Comments gives access to the general comments of this pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{clientContext: pr.clientContext}
+}
+
+// Reviews gives access to the reviews of this pull request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return &PullRequestReviewClient{clientContext: pr.clientContext}
+}
+
+func pullrequestFromAPI(apiObj *gitea.PullRequest) gitprovider.PullRequestInfo {
+	info := gitprovider.PullRequestInfo{
+		Merged:      apiObj.HasMerged,
+		Number:      int(apiObj.Index),
+		WebURL:      apiObj.HTMLURL,
+		Title:       apiObj.Title,
+		Description: apiObj.Body,
+		State:       gitprovider.PullRequestStateOpen,
+	}
+	switch {
+	case apiObj.HasMerged:
+		info.State = gitprovider.PullRequestStateMerged
+	case apiObj.State == gitea.StateClosed:
+		info.State = gitprovider.PullRequestStateClosed
+	default:
+		// Gitea only computes mergeability for open pull requests
+		info.Mergeable = gitprovider.BoolVar(apiObj.Mergeable)
+	}
+	if apiObj.Head != nil {
+		info.SourceBranch = apiObj.Head.Ref
+		info.HeadSHA = apiObj.Head.Sha
+	}
+	if apiObj.Base != nil {
+		info.TargetBranch = apiObj.Base.Ref
+		info.BaseSHA = apiObj.Base.Sha
+	}
+	if apiObj.Poster != nil {
+		info.Author = apiObj.Poster.UserName
+	}
+	if apiObj.Created != nil {
+		info.CreatedAt = *apiObj.Created
+	}
+	if apiObj.Updated != nil {
+		info.UpdatedAt = *apiObj.Updated
+	}
+	return info
+}
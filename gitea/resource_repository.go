@@ -0,0 +1,388 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// licenseTemplates maps the gitprovider license templates to the names of the
+// licenses shipped with Gitea.
+//
+//nolint:gochecknoglobals
+var licenseTemplates = map[gitprovider.LicenseTemplate]string{
+	gitprovider.LicenseTemplateApache2: "Apache-2.0",
+	gitprovider.LicenseTemplateMIT:     "MIT",
+	gitprovider.LicenseTemplateGPL3:    "GPL-3.0",
+}
+
+func newUserRepository(ctx *clientContext, apiObj *gitea.Repository, ref gitprovider.RepositoryRef) *userRepository {
+	return &userRepository{
+		clientContext: ctx,
+		r:             *apiObj,
+		ref:           ref,
+		deployKeys: &DeployKeyClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		userAccess: &UserAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		commits: &CommitClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branches: &BranchClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		pullRequests: &PullRequestClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		statuses: &CommitStatusClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		files: &FileClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		trees: &TreeClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+var _ gitprovider.UserRepository = &userRepository{}
+
+type userRepository struct {
+	*clientContext
+
+	r   gitea.Repository
+	ref gitprovider.RepositoryRef
+
+	deployKeys        *DeployKeyClient
+	userAccess        *UserAccessClient
+	commits           *CommitClient
+	branches          *BranchClient
+	branchProtections *BranchProtectionClient
+	webhooks          *WebhookClient
+	pullRequests      *PullRequestClient
+	tags              *TagClient
+	releases          *ReleaseClient
+	statuses          *CommitStatusClient
+	files             *FileClient
+	trees             *TreeClient
+}
+
+func (r *userRepository) Get() gitprovider.RepositoryInfo {
+	return repositoryFromAPI(&r.r)
+}
+
+// Set sets the desired state of this object.
+// User have to call Update() to apply the changes to the server.
+// The changes will then be reflected in the internal API object.
+func (r *userRepository) Set(info gitprovider.RepositoryInfo) error {
+	if err := validateRepositoryInfo(info); err != nil {
+		return err
+	}
+	repositoryInfoToAPIObj(&info, &r.r)
+	return nil
+}
+
+func (r *userRepository) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *userRepository) Repository() gitprovider.RepositoryRef {
+	return r.ref
+}
+
+func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
+	return r.deployKeys
+}
+
+func (r *userRepository) UserAccess() gitprovider.UserAccessClient {
+	return r.userAccess
+}
+
+func (r *userRepository) Commits() gitprovider.CommitClient {
+	return r.commits
+}
+
+func (r *userRepository) Branches() gitprovider.BranchClient {
+	return r.branches
+}
+
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
+func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
+	return r.pullRequests
+}
+
+func (r *userRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Statuses() gitprovider.CommitStatusClient {
+	return r.statuses
+}
+
+func (r *userRepository) Files() gitprovider.FileClient {
+	return r.files
+}
+
+func (r *userRepository) Trees() gitprovider.TreeClient {
+	return r.trees
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (r *userRepository) Update(ctx context.Context) error {
+	// PATCH /repos/{owner}/{repo}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), repositoryToEditOption(&r.r))
+	if err != nil {
+		return err
+	}
+	r.r = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
+	apiObj, err := r.c.GetRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			orgName := ""
+			if orgRef, ok := r.ref.(gitprovider.OrgRepositoryRef); ok {
+				orgName = orgRef.Organization
+			}
+			repo, err := r.c.CreateRepo(ctx, orgName, repositoryToCreateOption(&r.r))
+			if err != nil {
+				return true, err
+			}
+			r.r = *repo
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	// Use wrappers here to extract the "spec" part of the object for comparison
+	desiredSpec := newRepositorySpec(&r.r)
+	actualSpec := newRepositorySpec(apiObj)
+
+	// If desired state already is the actual state, do nothing
+	if desiredSpec.Equals(actualSpec) {
+		return false, nil
+	}
+	// Otherwise, make the desired state the actual state
+	return true, r.Update(ctx)
+}
+
+// Delete deletes the current resource irreversibly.
+//
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (r *userRepository) Delete(ctx context.Context) error {
+	return r.c.DeleteRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+}
+
+func newOrgRepository(ctx *clientContext, apiObj *gitea.Repository, ref gitprovider.RepositoryRef) *orgRepository {
+	return &orgRepository{
+		userRepository: *newUserRepository(ctx, apiObj, ref),
+		teamAccess: &TeamAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+	}
+}
+
+var _ gitprovider.OrgRepository = &orgRepository{}
+
+type orgRepository struct {
+	userRepository
+
+	teamAccess *TeamAccessClient
+}
+
+func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
+	return r.teamAccess
+}
+
+// validateRepositoryInfo makes sure the RepositoryInfo is valid, and that it can be represented in Gitea.
+func validateRepositoryInfo(info gitprovider.RepositoryInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	// Gitea repositories are either private or public
+	if info.Visibility != nil && *info.Visibility == gitprovider.RepositoryVisibilityInternal {
+		return fmt.Errorf("gitea doesn't support internal repositories: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+// validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateRepositoryAPI(apiObj *gitea.Repository) error {
+	return validateAPIObject("Gitea.Repository", func(validator validation.Validator) {
+		// Make sure name is set
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+func repositoryFromAPI(apiObj *gitea.Repository) gitprovider.RepositoryInfo {
+	repo := gitprovider.RepositoryInfo{
+		Description:   &apiObj.Description,
+		DefaultBranch: &apiObj.DefaultBranch,
+	}
+	visibility := gitprovider.RepositoryVisibilityPublic
+	if apiObj.Private {
+		visibility = gitprovider.RepositoryVisibilityPrivate
+	}
+	repo.Visibility = gitprovider.RepositoryVisibilityVar(visibility)
+	return repo
+}
+
+func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) gitea.CreateRepoOption {
+	apiObj := gitea.Repository{
+		Name: ref.GetRepository(),
+	}
+	repositoryInfoToAPIObj(repo, &apiObj)
+	return repositoryToCreateOption(&apiObj)
+}
+
+func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *gitea.Repository) {
+	if repo.Description != nil {
+		apiObj.Description = *repo.Description
+	}
+	if repo.DefaultBranch != nil {
+		apiObj.DefaultBranch = *repo.DefaultBranch
+	}
+	if repo.Visibility != nil {
+		apiObj.Private = *repo.Visibility != gitprovider.RepositoryVisibilityPublic
+	}
+}
+
+func repositoryToCreateOption(apiObj *gitea.Repository) gitea.CreateRepoOption {
+	return gitea.CreateRepoOption{
+		Name:          apiObj.Name,
+		Description:   apiObj.Description,
+		Private:       apiObj.Private,
+		DefaultBranch: apiObj.DefaultBranch,
+	}
+}
+
+func repositoryToEditOption(apiObj *gitea.Repository) gitea.EditRepoOption {
+	opt := gitea.EditRepoOption{
+		Description: &apiObj.Description,
+		Private:     &apiObj.Private,
+	}
+	// The default branch can't be unset
+	if apiObj.DefaultBranch != "" {
+		opt.DefaultBranch = &apiObj.DefaultBranch
+	}
+	return opt
+}
+
+func applyRepoCreateOptions(apiObj *gitea.CreateRepoOption, opts gitprovider.RepositoryCreateOptions) {
+	if opts.AutoInit != nil {
+		apiObj.AutoInit = *opts.AutoInit
+	}
+	if apiObj.AutoInit {
+		// Gitea needs a README template to initialize the repository with
+		apiObj.Readme = "Default"
+	}
+	if opts.LicenseTemplate != nil {
+		apiObj.License = licenseTemplates[*opts.LicenseTemplate]
+	}
+}
+
+// This function copies over the fields that are part of create/update requests of a repository
+// i.e. the desired spec of the repository. This allows us to separate "spec" from "status" fields.
+// See also: https://try.gitea.io/api/swagger#/repository/repoEdit
+func newRepositorySpec(repo *gitea.Repository) *repositorySpec {
+	return &repositorySpec{
+		&gitea.Repository{
+			Name:          repo.Name,
+			Description:   repo.Description,
+			Private:       repo.Private,
+			DefaultBranch: repo.DefaultBranch,
+		},
+	}
+}
+
+type repositorySpec struct {
+	*gitea.Repository
+}
+
+func (s *repositorySpec) Equals(other *repositorySpec) bool {
+	return reflect.DeepEqual(s, other)
+}
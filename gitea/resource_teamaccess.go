@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+//nolint:gochecknoglobals
+var permissionMapping = map[gitea.AccessMode]gitprovider.RepositoryPermission{
+	gitea.AccessModeRead:  gitprovider.RepositoryPermissionPull,
+	gitea.AccessModeWrite: gitprovider.RepositoryPermissionPush,
+	gitea.AccessModeAdmin: gitprovider.RepositoryPermissionAdmin,
+	gitea.AccessModeOwner: gitprovider.RepositoryPermissionAdmin,
+}
+
+func newTeamAccess(c *TeamAccessClient, apiObj *gitea.Team) (*teamAccess, error) {
+	permission, err := getGitProviderPermission(apiObj.Permission)
+	if err != nil {
+		return nil, err
+	}
+	return &teamAccess{
+		ta: gitprovider.TeamAccessInfo{
+			Name:       apiObj.Name,
+			Permission: permission,
+		},
+		t: *apiObj,
+		c: c,
+	}, nil
+}
+
+var _ gitprovider.TeamAccess = &teamAccess{}
+
+type teamAccess struct {
+	ta gitprovider.TeamAccessInfo
+	t  gitea.Team
+	c  *TeamAccessClient
+}
+
+func (ta *teamAccess) Get() gitprovider.TeamAccessInfo {
+	return ta.ta
+}
+
+func (ta *teamAccess) Set(info gitprovider.TeamAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ta.ta = info
+	return nil
+}
+
+func (ta *teamAccess) APIObject() interface{} {
+	return &ta.t
+}
+
+func (ta *teamAccess) Repository() gitprovider.RepositoryRef {
+	return ta.c.ref
+}
+
+// Delete removes the given team from the repo's team access control list.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ta *teamAccess) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/teams/{team}
+	return ta.c.c.RemoveTeam(ctx, ta.c.ref.GetIdentity(), ta.c.ref.GetRepository(), ta.ta.Name)
+}
+
+// Update makes sure the team has access to the repository.
+//
+// As the permission of a team applies to all its repositories in Gitea, an error wrapping
+// ErrNoProviderSupport is returned if the desired permission isn't the team's permission.
+func (ta *teamAccess) Update(ctx context.Context) error {
+	// GET /repos/{owner}/{repo}/teams/{team}
+	actual, err := ta.c.Get(ctx, ta.ta.Name)
+	if err != nil {
+		return err
+	}
+	if err := validateTeamPermission(ta.ta, actual.Get()); err != nil {
+		return err
+	}
+	ta.t = *actual.APIObject().(*gitea.Team)
+	return ta.Set(actual.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, an error wrapping ErrNoProviderSupport is returned,
+// as the team's permission can't be changed for a single repository.
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ta *teamAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ta.Get()
+	actual, err := ta.c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := ta.c.Create(ctx, req)
+			if err != nil {
+				return true, err
+			}
+			return true, ta.Set(resp.Get())
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ta.Update(ctx)
+}
+
+func getGitProviderPermission(permission gitea.AccessMode) (*gitprovider.RepositoryPermission, error) {
+	if p, ok := permissionMapping[permission]; ok {
+		return &p, nil
+	}
+	return nil, gitprovider.ErrInvalidPermissionLevel
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUser(ctx *clientContext, apiObj *gitea.User) *user {
+	return &user{
+		clientContext: ctx,
+		u:             *apiObj,
+	}
+}
+
+var _ gitprovider.User = &user{}
+
+type user struct {
+	*clientContext
+
+	u gitea.User
+}
+
+func (u *user) Get() gitprovider.UserInfo {
+	return userFromAPI(&u.u, u.domain)
+}
+
+func (u *user) APIObject() interface{} {
+	return &u.u
+}
+
+func (u *user) User() gitprovider.UserRef {
+	return gitprovider.UserRef{
+		Domain:    u.domain,
+		UserLogin: u.u.UserName,
+	}
+}
+
+// validateUserAPI validates the apiObj received from the server, to make sure that it is
+// valid for our use.
+func validateUserAPI(apiObj *gitea.User) error {
+	return validateAPIObject("Gitea.User", func(validator validation.Validator) {
+		if apiObj.UserName == "" {
+			validator.Required("UserName")
+		}
+	})
+}
+
+// userFromAPI converts the Gitea user to UserInfo. The Gitea API doesn't return the
+// profile URL of a user, hence it is derived from the domain.
+func userFromAPI(apiObj *gitea.User, domain string) gitprovider.UserInfo {
+	return gitprovider.UserInfo{
+		Login:     apiObj.UserName,
+		Name:      apiObj.FullName,
+		Email:     apiObj.Email,
+		AvatarURL: apiObj.AvatarURL,
+		WebURL:    gitprovider.GetDomainURL(domain) + "/" + apiObj.UserName,
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUserAccess(c *UserAccessClient, ua gitprovider.UserAccessInfo, apiObj *gitea.User) *userAccess {
+	return &userAccess{
+		ua: ua,
+		u:  apiObj,
+		c:  c,
+	}
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	ua gitprovider.UserAccessInfo
+	u  *gitea.User
+	c  *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return ua.ua
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ua.ua = info
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return ua.u
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited always returns false, as Gitea adds collaborators directly.
+func (ua *userAccess) Invited() bool {
+	return false
+}
+
+// Delete removes the given user from the repo's collaborators.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/collaborators/{collaborator}
+	return ua.c.c.RemoveCollaborator(ctx, ua.c.ref.GetIdentity(), ua.c.ref.GetRepository(), ua.ua.Login)
+}
+
+// Update sets the permission of the collaborator to the desired permission.
+func (ua *userAccess) Update(ctx context.Context) error {
+	// Update the actual state to be the desired state
+	// by issuing a Create, which uses a PUT underneath.
+	resp, err := ua.c.Create(ctx, ua.Get())
+	if err != nil {
+		return err
+	}
+	return ua.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req already exists, this is a no-op (actionTaken == false), as the permission of the collaborator
+// can't be compared. Use Update() to enforce the permission.
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	_, err := ua.c.Get(ctx, ua.ua.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, ua.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+	return false, nil
+}
+
+func getGiteaPermission(permission gitprovider.RepositoryPermission) (gitea.AccessMode, error) {
+	switch permission {
+	case gitprovider.RepositoryPermissionPull:
+		return gitea.AccessModeRead, nil
+	case gitprovider.RepositoryPermissionPush:
+		return gitea.AccessModeWrite, nil
+	case gitprovider.RepositoryPermissionAdmin:
+		return gitea.AccessModeAdmin, nil
+	}
+	return "", gitprovider.ErrInvalidPermissionLevel
+}
@@ -36,6 +36,7 @@ const (
 //
 // Using WithOAuth2Token you can specify authentication
 // credentials, passing no such ClientOption will allow public read access only.
+// To authenticate as a GitHub App installation, use WithAppAuth or WithAppAuthForOrganization instead.
 //
 // Password-based authentication is not supported because it is deprecated by GitHub, see
 // https://developer.github.com/changes/2020-02-14-deprecating-password-auth/
@@ -0,0 +1,280 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// appTokenRefreshMargin is how long before their expiry installation tokens are refreshed.
+	appTokenRefreshMargin = 5 * time.Minute
+	// appJWTLifetime is the lifetime of the JWTs authenticating as the app, GitHub allows at most 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew is how far JWTs are backdated, to allow for clock drift between the client and GitHub.
+	appJWTClockSkew = time.Minute
+)
+
+// WithAppAuth initializes a Client which authenticates as the installation installationID of the
+// GitHub App appID. privateKeyPEM is a PEM-encoded private key of the app, as generated by GitHub.
+//
+// Installation access tokens are requested using JWTs signed with the private key, cached, and
+// refreshed shortly before they expire. They are requested from the API the client talks to, i.e.
+// the GitHub Enterprise server when using WithDomain.
+// WithAppAuth is mutually exclusive with WithOAuth2Token.
+func WithAppAuth(appID, installationID int64, privateKeyPEM []byte) gitprovider.ClientOption {
+	// Don't allow an empty value
+	if installationID <= 0 {
+		return optionError(fmt.Errorf("installationID must be positive: %w", gitprovider.ErrInvalidClientOptions))
+	}
+
+	return appAuthOption(appID, installationID, "", privateKeyPEM)
+}
+
+// WithAppAuthForOrganization is like WithAppAuth, but looks up the installation of the GitHub App
+// appID in the given organization when the first installation access token is requested.
+func WithAppAuthForOrganization(appID int64, organization string, privateKeyPEM []byte) gitprovider.ClientOption {
+	// Don't allow an empty value
+	if organization == "" {
+		return optionError(fmt.Errorf("organization cannot be empty: %w", gitprovider.ErrInvalidClientOptions))
+	}
+
+	return appAuthOption(appID, 0, organization, privateKeyPEM)
+}
+
+func appAuthOption(appID, installationID int64, organization string, privateKeyPEM []byte) gitprovider.ClientOption {
+	if appID <= 0 {
+		return optionError(fmt.Errorf("appID must be positive: %w", gitprovider.ErrInvalidClientOptions))
+	}
+	key, err := parseAppPrivateKey(privateKeyPEM)
+	if err != nil {
+		return optionError(fmt.Errorf("invalid privateKeyPEM: %v: %w", err, gitprovider.ErrInvalidClientOptions))
+	}
+
+	return gitprovider.WithAuthTransport(func(in http.RoundTripper) http.RoundTripper {
+		// Like oauth2.Transport, fall back to the default transport if there's no underlying one
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &appTransport{
+			base:           in,
+			appID:          appID,
+			installationID: installationID,
+			organization:   organization,
+			key:            key,
+		}
+	})
+}
+
+// parseAppPrivateKey parses a PKCS #1 (as generated by GitHub) or PKCS #8 encoded RSA private key.
+func parseAppPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return key, nil
+}
+
+// appTransport authenticates requests with installation access tokens of a GitHub App.
+type appTransport struct {
+	base         http.RoundTripper
+	appID        int64
+	organization string
+	key          *rsa.PrivateKey
+
+	// mu guards the installation and its cached token.
+	mu             sync.Mutex
+	installationID int64
+	token          string
+	permissions    *github.InstallationPermissions
+	expiresAt      time.Time
+}
+
+// appPermissionsKey is the context key of the appTokenPermissions a request asks appTransport
+// to fill in, see Client.HasTokenPermission.
+type appPermissionsKey struct{}
+
+// appTokenPermissions receives the permissions of the installation access token authenticating a request.
+type appTokenPermissions struct {
+	permissions *github.InstallationPermissions
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, permissions, err := t.installationToken(req)
+	if err != nil {
+		return nil, err
+	}
+	if recv, ok := req.Context().Value(appPermissionsKey{}).(*appTokenPermissions); ok {
+		recv.permissions = permissions
+	}
+
+	// As per the http.RoundTripper contract, don't modify the given request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(req)
+}
+
+// installationToken returns the cached installation access token and its permissions, or requests
+// a new one from the API serving req if there's none or it's about to expire.
+func (t *appTransport) installationToken(req *http.Request) (string, *github.InstallationPermissions, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Add(appTokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, t.permissions, nil
+	}
+
+	apps, err := t.appClient(req.URL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if t.installationID == 0 {
+		// GET /orgs/{org}/installation
+		installation, _, err := apps.Apps.FindOrganizationInstallation(req.Context(), t.organization)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to find the installation of GitHub App %d in organization %q: %w",
+				t.appID, t.organization, handleHTTPError(err))
+		}
+		t.installationID = installation.GetID()
+	}
+
+	// POST /app/installations/{installation_id}/access_tokens
+	token, _, err := apps.Apps.CreateInstallationToken(req.Context(), t.installationID, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create an access token for installation %d of GitHub App %d: %w",
+			t.installationID, t.appID, handleHTTPError(err))
+	}
+	t.token = token.GetToken()
+	t.permissions = token.GetPermissions()
+	t.expiresAt = token.GetExpiresAt()
+	return t.token, t.permissions, nil
+}
+
+// appClient returns a client authenticating as the GitHub App itself, for the API serving u.
+func (t *appTransport) appClient(u *url.URL) (*github.Client, error) {
+	jwt, err := t.jwt(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the JWT of GitHub App %d: %w", t.appID, err)
+	}
+	baseURL, err := url.Parse(apiBaseURL(u))
+	if err != nil {
+		return nil, err
+	}
+
+	c := github.NewClient(&http.Client{Transport: &bearerTransport{base: t.base, token: jwt}})
+	c.BaseURL = baseURL
+	return c, nil
+}
+
+// jwt returns a JWT authenticating as the GitHub App, signed with RS256 as GitHub requires.
+func (t *appTransport) jwt(now time.Time) (string, error) {
+	header, err := json.Marshal(struct {
+		Algorithm string `json:"alg"`
+		Type      string `json:"typ"`
+	}{"RS256", "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}{
+		IssuedAt:  now.Add(-appJWTClockSkew).Unix(),
+		ExpiresAt: now.Add(appJWTLifetime).Unix(),
+		Issuer:    strconv.FormatInt(t.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// apiBaseURL returns the root of the REST API serving u, as chosen by NewClient, i.e.
+// https://api.github.com/ for github.com, and https://{domain}/api/v3/ for GitHub Enterprise.
+func apiBaseURL(u *url.URL) string {
+	if u.Host == "api.github.com" || u.Host == "uploads.github.com" {
+		return "https://api.github.com/"
+	}
+	return fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Host)
+}
+
+// bearerTransport adds a bearer token to all requests.
+type bearerTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// As per the http.RoundTripper contract, don't modify the given request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+// errorOption implements gitprovider.ClientOption, and just wraps an error which is immediately
+// returned, in order to make NewClient fail if there are invalid options given to the With... functions.
+type errorOption struct {
+	err error
+}
+
+// ApplyToClientOptions implements gitprovider.ClientOption, but just returns the internal error.
+func (e *errorOption) ApplyToClientOptions(*gitprovider.ClientOptions) error { return e.err }
+
+// optionError is a constructor for errorOption.
+func optionError(err error) gitprovider.ClientOption {
+	return &errorOption{err}
+}
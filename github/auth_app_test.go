@@ -0,0 +1,319 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestWithAppAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+
+	tests := []struct {
+		name           string
+		opt            gitprovider.ClientOption
+		tokenLifetime  time.Duration
+		wantExchanges  int
+		wantErr        error
+		wantOrgLookups int
+	}{
+		{
+			name:          "token is cached",
+			opt:           WithAppAuth(1234, 42, pkcs1),
+			tokenLifetime: time.Hour,
+			wantExchanges: 1,
+		},
+		{
+			name:          "PKCS #8 key",
+			opt:           WithAppAuth(1234, 42, pkcs8),
+			tokenLifetime: time.Hour,
+			wantExchanges: 1,
+		},
+		{
+			name:          "token is refreshed before expiry",
+			opt:           WithAppAuth(1234, 42, pkcs1),
+			tokenLifetime: time.Minute,
+			wantExchanges: 2,
+		},
+		{
+			name:           "installation looked up by organization",
+			opt:            WithAppAuthForOrganization(1234, "acme", pkcs1),
+			tokenLifetime:  time.Hour,
+			wantExchanges:  1,
+			wantOrgLookups: 1,
+		},
+		{
+			name:           "unknown organization",
+			opt:            WithAppAuthForOrganization(1234, "unknown", pkcs1),
+			wantErr:        gitprovider.ErrNotFound,
+			wantOrgLookups: 1,
+		},
+		{
+			name:    "unknown installation",
+			opt:     WithAppAuth(1234, 7, pkcs1),
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchanges, orgLookups := 0, 0
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v3/orgs/acme/installation", "/api/v3/orgs/unknown/installation":
+					orgLookups++
+					verifyAppJWT(t, r, &key.PublicKey, "1234")
+					if r.URL.Path != "/api/v3/orgs/acme/installation" {
+						writeError(t, w, http.StatusNotFound, "")
+						return
+					}
+					writeJSON(t, w, http.StatusOK, map[string]interface{}{"id": 42})
+				case "/api/v3/app/installations/42/access_tokens", "/api/v3/app/installations/7/access_tokens":
+					exchanges++
+					verifyAppJWT(t, r, &key.PublicKey, "1234")
+					if r.URL.Path != "/api/v3/app/installations/42/access_tokens" {
+						writeError(t, w, http.StatusNotFound, "")
+						return
+					}
+					writeJSON(t, w, http.StatusCreated, map[string]interface{}{
+						"token":      fmt.Sprintf("installation-token-%d", exchanges),
+						"expires_at": time.Now().Add(tt.tokenLifetime).UTC().Format(time.RFC3339),
+					})
+				case "/api/v3/user":
+					if got, want := r.Header.Get("Authorization"), fmt.Sprintf("token installation-token-%d", exchanges); got != want {
+						t.Errorf("Authorization = %q, want %q", got, want)
+					}
+					writeJSON(t, w, http.StatusOK, map[string]interface{}{"login": "my-app[bot]"})
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					writeError(t, w, http.StatusNotFound, "")
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(
+				gitprovider.WithDomain(strings.TrimPrefix(server.URL, "https://")),
+				tt.opt,
+				gitprovider.WithPostChainTransportHook(func(http.RoundTripper) http.RoundTripper {
+					return server.Client().Transport
+				}),
+			)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+
+			for i := 0; i < 2; i++ {
+				_, err := c.Users().GetAuthenticated(context.Background())
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetAuthenticated() error = %v, want %v", err, tt.wantErr)
+				}
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if exchanges != tt.wantExchanges {
+				t.Errorf("got %d token exchanges, want %d", exchanges, tt.wantExchanges)
+			}
+			if orgLookups != tt.wantOrgLookups {
+				t.Errorf("got %d installation lookups, want %d", orgLookups, tt.wantOrgLookups)
+			}
+		})
+	}
+}
+
+func TestWithAppAuth_invalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  gitprovider.ClientOption
+	}{
+		{name: "invalid app ID", opt: WithAppAuth(0, 42, nil)},
+		{name: "invalid installation ID", opt: WithAppAuth(1234, 0, nil)},
+		{name: "empty organization", opt: WithAppAuthForOrganization(1234, "", nil)},
+		{name: "no PEM data", opt: WithAppAuth(1234, 42, []byte("not a key"))},
+		{name: "invalid key", opt: WithAppAuth(1234, 42, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("garbage")}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient(tt.opt); !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+				t.Errorf("NewClient() error = %v, want %v", err, gitprovider.ErrInvalidClientOptions)
+			}
+		})
+	}
+}
+
+func TestWithAppAuth_HasTokenPermission(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/42/access_tokens":
+			writeJSON(t, w, http.StatusCreated, map[string]interface{}{
+				"token":       "installation-token",
+				"expires_at":  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+				"permissions": map[string]string{"contents": "write", "packages": "read"},
+			})
+		case "/api/v3/meta":
+			// Installation tokens don't report OAuth scopes, but the response may be cached
+			w.Header().Set("Cache-Control", "max-age=60")
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			writeError(t, w, http.StatusNotFound, "")
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(
+		gitprovider.WithDomain(strings.TrimPrefix(server.URL, "https://")),
+		WithAppAuth(1234, 42, pemKey),
+		gitprovider.WithConditionalRequests(true),
+		gitprovider.WithPostChainTransportHook(func(http.RoundTripper) http.RoundTripper {
+			return server.Client().Transport
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	// Cache the response of the request HasTokenPermission makes
+	if _, _, err := c.Raw().(*github.Client).APIMeta(context.Background()); err != nil {
+		t.Fatalf("APIMeta returned error: %v", err)
+	}
+	for permission, want := range map[gitprovider.TokenPermission]bool{
+		gitprovider.TokenPermissionRWRepository:      true,
+		gitprovider.TokenPermissionWritePackages:     false,
+		gitprovider.TokenPermissionAdminOrganization: false,
+	} {
+		got, err := c.HasTokenPermission(context.Background(), permission)
+		if err != nil {
+			t.Fatalf("HasTokenPermission(%v) returned error: %v", permission, err)
+		}
+		if got != want {
+			t.Errorf("HasTokenPermission(%v) = %v, want %v", permission, got, want)
+		}
+	}
+}
+
+func TestWithAppAuth_exclusiveWithOAuth2Token(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	_, err = NewClient(WithAppAuth(1234, 42, pemKey), gitprovider.WithOAuth2Token("token"))
+	if !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+		t.Errorf("NewClient() error = %v, want %v", err, gitprovider.ErrInvalidClientOptions)
+	}
+}
+
+func Test_apiBaseURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://api.github.com/user", want: "https://api.github.com/"},
+		{url: "https://uploads.github.com/repos/o/r/releases/1/assets", want: "https://api.github.com/"},
+		{url: "https://my-github.dev.com/api/v3/user", want: "https://my-github.dev.com/api/v3/"},
+		{url: "https://my-github.dev.com/api/uploads/repos/o/r/releases/1/assets", want: "https://my-github.dev.com/api/v3/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := apiBaseURL(req.URL); got != tt.want {
+				t.Errorf("apiBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// verifyAppJWT checks that r is authenticated with a valid, RS256-signed JWT issued by appID.
+func verifyAppJWT(t *testing.T, r *http.Request, key *rsa.PublicKey, appID string) {
+	t.Helper()
+
+	jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Errorf("malformed JWT %q", jwt)
+		return
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Errorf("failed to decode JWT signature: %v", err)
+		return
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("invalid JWT signature: %v", err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Errorf("failed to decode JWT claims: %v", err)
+		return
+	}
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Errorf("failed to unmarshal JWT claims: %v", err)
+		return
+	}
+	if claims.Issuer != appID {
+		t.Errorf("JWT issuer = %q, want %q", claims.Issuer, appID)
+	}
+	if now := time.Now().Unix(); claims.IssuedAt > now || claims.ExpiresAt <= now || claims.ExpiresAt-claims.IssuedAt > 600 {
+		t.Errorf("invalid JWT validity: iat=%d exp=%d now=%d", claims.IssuedAt, claims.ExpiresAt, now)
+	}
+}
@@ -18,11 +18,13 @@ package github
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"github.com/google/go-github/v47/github"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 )
 
 // ProviderID is the provider ID for GitHub.
@@ -42,6 +44,9 @@ func newClient(c *github.Client, domain string, destructiveActions bool) *Client
 		userRepos: &UserRepositoriesClient{
 			clientContext: ctx,
 		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
 	}
 }
 
@@ -61,6 +66,7 @@ type Client struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+	users     *UsersClient
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "github.com", "enterprise.github.com" or
@@ -98,33 +104,78 @@ func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
 	return c.userRepos
 }
 
+// Users returns the UsersClient looking up user accounts.
+func (c *Client) Users() gitprovider.UsersClient {
+	return c.users
+}
+
+// permissionScopes maps the permissions to the classic OAuth scopes granting them,
+// the scopes of a permission are ordered from the narrowest to the widest.
+//
+//nolint:gochecknoglobals
+var permissionScopes = map[gitprovider.TokenPermission][]string{
+	gitprovider.TokenPermissionRWRepository:      {"repo"},
+	gitprovider.TokenPermissionAdminRepoHook:     {"admin:repo_hook", "repo"},
+	gitprovider.TokenPermissionDeleteRepository:  {"delete_repo"},
+	gitprovider.TokenPermissionAdminOrganization: {"admin:org"},
+	gitprovider.TokenPermissionWritePackages:     {"write:packages"},
+}
+
+// permissionAppPermissions maps the permissions to the GitHub App permission granting them with write access.
+//
 //nolint:gochecknoglobals
-var permissionScopes = map[gitprovider.TokenPermission]string{
-	gitprovider.TokenPermissionRWRepository: "repo",
+var permissionAppPermissions = map[gitprovider.TokenPermission]func(*github.InstallationPermissions) string{
+	gitprovider.TokenPermissionRWRepository:      (*github.InstallationPermissions).GetContents,
+	gitprovider.TokenPermissionAdminRepoHook:     (*github.InstallationPermissions).GetRepositoryHooks,
+	gitprovider.TokenPermissionDeleteRepository:  (*github.InstallationPermissions).GetAdministration,
+	gitprovider.TokenPermissionAdminOrganization: (*github.InstallationPermissions).GetOrganizationAdministration,
+	gitprovider.TokenPermissionWritePackages:     (*github.InstallationPermissions).GetPackages,
 }
 
 // HasTokenPermission returns true if the given token has the given permissions.
+//
+// Classic personal access tokens and OAuth app tokens report their scopes. Installation access
+// tokens requested by WithAppAuth or WithAppAuthForOrganization come with the permissions of the
+// installation, which need write access. The permissions of fine-grained personal access tokens,
+// and of installation tokens passed in through WithOAuth2Token, are granted per repository or
+// organization, hence ErrTokenPermissionUnknown is returned for them.
 func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.TokenPermission) (bool, error) {
-	requestedScope, ok := permissionScopes[permission]
+	requestedScopes, ok := permissionScopes[permission]
 	if !ok {
 		return false, gitprovider.ErrNoProviderSupport
 	}
 
+	// The app transport, if any, reports the permissions of the installation token it authenticates with
+	appPermissions := &appTokenPermissions{}
+	ctx = context.WithValue(ctx, appPermissionsKey{}, appPermissions)
+
 	// The X-OAuth-Scopes header is returned for any API calls, using Meta here to keep things simple.
-	_, res, err := c.c.Client().APIMeta(ctx)
+	req, err := c.c.Client().NewRequest(http.MethodGet, "meta", nil)
+	if err != nil {
+		return false, err
+	}
+	// Make sure the request isn't served from the cache of conditional requests, but reaches the app transport
+	req.Header.Set("Cache-Control", "no-cache")
+	res, err := c.c.Client().Do(ctx, req, nil)
 	if err != nil {
 		return false, err
 	}
 
-	scopes := res.Header.Get("X-OAuth-Scopes")
-	if scopes == "" {
-		return false, gitprovider.ErrMissingHeader
+	if appPermissions.permissions != nil {
+		return permissionAppPermissions[permission](appPermissions.permissions) == "write", nil
+	}
+
+	// The header is only sent for classic tokens, and is empty for classic tokens without scopes.
+	if _, ok := res.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; !ok {
+		return false, validation.NewMultiError(gitprovider.ErrMissingHeader, gitprovider.ErrTokenPermissionUnknown)
 	}
 
-	for _, s := range strings.Split(scopes, ",") {
+	for _, s := range strings.Split(res.Header.Get("X-OAuth-Scopes"), ",") {
 		scope := strings.TrimSpace(s)
-		if scope == requestedScope {
-			return true, nil
+		for _, requestedScope := range requestedScopes {
+			if scope == requestedScope {
+				return true, nil
+			}
 		}
 	}
 
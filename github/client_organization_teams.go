@@ -18,6 +18,10 @@ package github
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/google/go-github/v47/github"
 
@@ -59,7 +63,7 @@ func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Tea
 			Name:    teamName,
 			Members: logins,
 		},
-		ref: c.ref,
+		c: c,
 	}, nil
 }
 
@@ -89,22 +93,212 @@ func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
 	return teams, nil
 }
 
+// Create creates a team within the specific organization, with the given members.
+// GitHub derives the slug of the team from req.Name, the returned team is named by its slug.
+// Users who aren't members of the organization are invited, and only become team members
+// once they accept the invitation.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamsClient) Create(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// POST /orgs/{org}/teams
+	apiObj, err := c.c.CreateTeam(ctx, c.ref.Organization, github.NewTeam{Name: req.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	// The creator of the team becomes a maintainer, hence reconcile the members right away.
+	// Slug is validated to be non-nil in CreateTeam.
+	t := &team{
+		info: gitprovider.TeamInfo{
+			Name:    *apiObj.Slug,
+			Members: req.Members,
+		},
+		c: c,
+	}
+	return t, t.Update(ctx)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// The team is looked up by the slug GitHub derives from req.Name, like in Create.
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamsClient) Reconcile(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	desired := req
+	desired.Name = teamSlug(req.Name)
+	actual, err := c.Get(ctx, desired.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if desired.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(desired); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// teamSlugSeparators matches the characters GitHub replaces with dashes in team slugs.
+//
+//nolint:gochecknoglobals
+var teamSlugSeparators = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// teamSlug returns the slug GitHub derives from the name of a team, e.g. "my-team" for "My Team".
+func teamSlug(name string) string {
+	return strings.Trim(teamSlugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
 var _ gitprovider.Team = &team{}
 
 type team struct {
 	users []*github.User
 	info  gitprovider.TeamInfo
-	ref   gitprovider.OrganizationRef
+	c     *TeamsClient
 }
 
 func (t *team) Get() gitprovider.TeamInfo {
 	return t.info
 }
 
+// Set sets the desired members of this team. The name of the team can't be changed.
+// User have to call Update() to apply the changes to the server.
+func (t *team) Set(info gitprovider.TeamInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Name != t.info.Name {
+		return fmt.Errorf("cannot rename team %q to %q: %w", t.info.Name, info.Name, gitprovider.ErrInvalidArgument)
+	}
+	t.info = info
+	return nil
+}
+
 func (t *team) APIObject() interface{} {
 	return t.users
 }
 
 func (t *team) Organization() gitprovider.OrganizationRef {
-	return t.ref
+	return t.c.ref
+}
+
+// Update adds and removes members, such that the members of the team match the desired state
+// in this object.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (t *team) Update(ctx context.Context) error {
+	// GET /orgs/{org}/teams/{team_slug}/members
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		return err
+	}
+	added, removed := t.info.MemberChanges(actual.Get())
+	for _, login := range added {
+		// PUT /orgs/{org}/teams/{team_slug}/memberships/{username}
+		if err := t.c.c.AddTeamMember(ctx, t.c.ref.Organization, t.info.Name, login); err != nil {
+			return err
+		}
+	}
+	for _, login := range removed {
+		// DELETE /orgs/{org}/teams/{team_slug}/memberships/{username}
+		if err := t.c.c.RemoveTeamMember(ctx, t.c.ref.Organization, t.info.Name, login); err != nil {
+			return err
+		}
+	}
+	return t.refresh(ctx)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (t *team) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := t.c.Create(ctx, t.info)
+			if err != nil {
+				return true, err
+			}
+			*t = *resp.(*team)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if t.info.Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, t.Update(ctx)
+}
+
+// Delete deletes the team from the organization.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (t *team) Delete(ctx context.Context) error {
+	// DELETE /orgs/{org}/teams/{team_slug}
+	return t.c.c.DeleteTeam(ctx, t.c.ref.Organization, t.info.Name)
+}
+
+// AddMember adds the user with the given login to the team. Users who aren't members of the
+// organization are invited, and only become team members once they accept the invitation.
+// Adding an existing member is a no-op.
+func (t *team) AddMember(ctx context.Context, login string) error {
+	// PUT /orgs/{org}/teams/{team_slug}/memberships/{username}
+	if err := t.c.c.AddTeamMember(ctx, t.c.ref.Organization, t.info.Name, login); err != nil {
+		return err
+	}
+	return t.refresh(ctx)
+}
+
+// RemoveMember removes the user with the given login from the team.
+//
+// ErrNotFound is returned if the user isn't a member of the team.
+func (t *team) RemoveMember(ctx context.Context, login string) error {
+	// DELETE /orgs/{org}/teams/{team_slug}/memberships/{username}
+	if err := t.c.c.RemoveTeamMember(ctx, t.c.ref.Organization, t.info.Name, login); err != nil {
+		return err
+	}
+	return t.refresh(ctx)
+}
+
+// refresh overrides the internal API object with the current members of the team.
+func (t *team) refresh(ctx context.Context) error {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		return err
+	}
+	*t = *actual.(*team)
+	return nil
 }
@@ -18,6 +18,10 @@ package github
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v47/github"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -80,3 +84,86 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organizat
 func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.OrganizationRef) ([]gitprovider.Organization, error) {
 	return nil, gitprovider.ErrNoProviderSupport
 }
+
+// Create creates an organization with the given data. The authenticated user becomes its admin.
+//
+// This is only supported in GitHub Enterprise, using the site admin API.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrganizationsClient) Create(ctx context.Context, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := createOrganization(ctx, c.clientContext, ref, req)
+	if err != nil {
+		return nil, err
+	}
+	return newOrganization(c.clientContext, apiObj, ref), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrganizationsClient) Reconcile(ctx context.Context, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (gitprovider.Organization, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(req); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+func createOrganization(ctx context.Context, c *clientContext, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (*github.Organization, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	// Organizations can only be created by site admins of GitHub Enterprise
+	if c.domain == DefaultDomain {
+		return nil, fmt.Errorf("github.com doesn't support creating organizations: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	// GET /user
+	admin, err := c.c.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// POST /admin/organizations
+	apiObj, err := c.c.CreateOrg(ctx, &github.Organization{
+		Login: gitprovider.StringVar(ref.Organization),
+		Name:  req.Name,
+	}, *admin.Login)
+	if err != nil {
+		return nil, err
+	}
+	// The description can't be set at creation time
+	if req.Description == nil {
+		return apiObj, nil
+	}
+	// PATCH /orgs/{org}
+	return c.c.UpdateOrg(ctx, ref.Organization, &github.Organization{
+		Description: req.Description,
+	})
+}
@@ -32,6 +32,35 @@ type BranchClient struct {
 	ref gitprovider.RepositoryRef
 }
 
+// List lists all branches in the repository.
+//
+// List returns all available branches, using multiple paginated requests if needed.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.Branch, error) {
+	// GET /repos/{owner}/{repo}/branches
+	apiObjs, err := c.c.ListBranches(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]gitprovider.Branch, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		branches = append(branches, newBranch(c, apiObj))
+	}
+	return branches, nil
+}
+
+// Get returns the branch with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Get(ctx context.Context, branch string) (gitprovider.Branch, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}
+	apiObj, err := c.c.GetBranch(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+	if err != nil {
+		return nil, err
+	}
+	return newBranch(c, apiObj), nil
+}
+
 // Create creates a branch with the given specifications.
 func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 
@@ -50,3 +79,31 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 
 	return nil
 }
+
+// Delete deletes the branch with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	// Make sure the branch exists, GitHub responds with a validation error otherwise
+	if _, err := c.Get(ctx, branch); err != nil {
+		return err
+	}
+	// DELETE /repos/{owner}/{repo}/git/refs/heads/{branch}
+	return c.c.DeleteBranch(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+}
+
+// SetDefault makes the branch with the given name the default branch of the repository.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) SetDefault(ctx context.Context, branch string) error {
+	// Make sure the branch exists, GitHub responds with a validation error otherwise
+	if _, err := c.Get(ctx, branch); err != nil {
+		return err
+	}
+	// PATCH /repos/{owner}/{repo}
+	_, err := c.c.UpdateRepo(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Repository{
+		DefaultBranch: &branch,
+	})
+	return err
+}
@@ -18,14 +18,16 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/google/go-github/v47/github"
 )
 
-var githubNewFileMode = "100644"
-var githubBlobTypeFile = "blob"
+const githubBlobTypeFile = "blob"
 
 // CommitClient implements the gitprovider.CommitClient interface.
 var _ gitprovider.CommitClient = &CommitClient{}
@@ -75,16 +77,6 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 		return nil, fmt.Errorf("no files added")
 	}
 
-	treeEntries := make([]*github.TreeEntry, 0)
-	for _, file := range files {
-		treeEntries = append(treeEntries, &github.TreeEntry{
-			Path:    file.Path,
-			Mode:    &githubNewFileMode,
-			Type:    &githubBlobTypeFile,
-			Content: file.Content,
-		})
-	}
-
 	commits, err := c.ListPage(ctx, branch, 1, 0)
 	if err != nil {
 		return nil, err
@@ -92,6 +84,68 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 
 	latestCommitTreeSHA := commits[0].Get().TreeSha
 
+	treeEntries := make([]*github.TreeEntry, 0, len(files))
+	var baseTree map[string]*github.TreeEntry
+	for _, file := range files {
+		if err := file.Validate(); err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+
+		action := file.GetAction()
+		if action == gitprovider.CommitFileActionDelete {
+			treeEntries = append(treeEntries, deletedTreeEntry(*file.Path))
+			continue
+		}
+
+		mode := string(file.GetMode())
+		entry := &github.TreeEntry{
+			Path: file.Path,
+			Mode: &mode,
+			Type: github.String(githubBlobTypeFile),
+		}
+		if action == gitprovider.CommitFileActionMove {
+			treeEntries = append(treeEntries, deletedTreeEntry(*file.PreviousPath))
+			// Moving a file without new content keeps the blob (and mode) of the previous path
+			if file.Content == nil {
+				if baseTree == nil {
+					if baseTree, err = c.getTree(ctx, latestCommitTreeSHA); err != nil {
+						return nil, err
+					}
+				}
+				previous, ok := baseTree[*file.PreviousPath]
+				if !ok {
+					return nil, fmt.Errorf("cannot move %q: %w", *file.PreviousPath, gitprovider.ErrNotFound)
+				}
+				entry.SHA = previous.SHA
+				if file.Mode == nil {
+					entry.Mode = previous.Mode
+				}
+				treeEntries = append(treeEntries, entry)
+				continue
+			}
+		}
+
+		content, err := file.DecodedContent()
+		if err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+		// Tree entries only carry UTF-8 content, anything else has to be uploaded as a blob first
+		if (file.Encoding != nil && *file.Encoding == gitprovider.ContentEncodingBase64) || !utf8.Valid(content) {
+			// POST /repos/{owner}/{repo}/git/blobs
+			blob, _, err := c.c.Client().Git.CreateBlob(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Blob{
+				Content:  github.String(base64.StdEncoding.EncodeToString(content)),
+				Encoding: github.String("base64"),
+			})
+			if err != nil {
+				return nil, handleHTTPError(err)
+			}
+			entry.SHA = blob.SHA
+		} else {
+			entry.Content = github.String(string(content))
+		}
+		treeEntries = append(treeEntries, entry)
+	}
+
 	tree, _, err := c.c.Client().Git.CreateTree(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), latestCommitTreeSHA, treeEntries)
 	if err != nil {
 		return nil, err
@@ -125,3 +179,30 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 
 	return newCommit(c, nCommit), nil
 }
+
+// getTree returns the entries of the given tree and all its subtrees, keyed by path.
+func (c *CommitClient) getTree(ctx context.Context, treeSHA string) (map[string]*github.TreeEntry, error) {
+	// GET /repos/{owner}/{repo}/git/trees/{tree_sha}
+	tree, _, err := c.c.Client().Git.GetTree(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), treeSHA, true)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("tree %s is too large to be listed: %w", treeSHA, gitprovider.ErrNoProviderSupport)
+	}
+	entries := make(map[string]*github.TreeEntry, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		entries[entry.GetPath()] = entry
+	}
+	return entries, nil
+}
+
+// deletedTreeEntry returns a tree entry deleting the file at path. The GitHub API deletes
+// files whose entry has neither a SHA nor content.
+func deletedTreeEntry(path string) *github.TreeEntry {
+	return &github.TreeEntry{
+		Path: github.String(path),
+		Mode: github.String(string(gitprovider.CommitFileModeRegular)),
+		Type: github.String(githubBlobTypeFile),
+	}
+}
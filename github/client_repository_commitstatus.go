@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CommitStatusClient implements the gitprovider.CommitStatusClient interface.
+var _ gitprovider.CommitStatusClient = &CommitStatusClient{}
+
+// CommitStatusClient operates on the commit statuses of a specific repository.
+type CommitStatusClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List returns the latest status of each context for the commit with the given sha.
+//
+// List returns all available statuses, using multiple paginated requests if needed.
+func (c *CommitStatusClient) List(ctx context.Context, sha string) ([]gitprovider.CommitStatus, error) {
+	// GET /repos/{owner}/{repo}/commits/{ref}/status
+	apiObjs, err := c.c.ListCommitStatuses(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]gitprovider.CommitStatus, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		statuses = append(statuses, newCommitStatus(c, apiObj))
+	}
+	return statuses, nil
+}
+
+// Create reports a status with the given specifications for the commit with the given sha,
+// replacing the status of the same context, if any.
+func (c *CommitStatusClient) Create(ctx context.Context, sha string, req gitprovider.CommitStatusInfo) (gitprovider.CommitStatus, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/statuses/{sha}
+	apiObj, err := c.c.CreateCommitStatus(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha, commitStatusToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newCommitStatus(c, apiObj), nil
+}
@@ -18,10 +18,14 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/google/go-github/v47/github"
 )
 
@@ -75,9 +79,54 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 		files = append(files, &gitprovider.CommitFile{
 			Path:    filePath,
 			Content: &contentStr,
+			SHA:     file.SHA,
 		})
 
 	}
 
 	return files, nil
 }
+
+// Put creates or updates the file at path on the given branch, committing content with message.
+// ErrConflict is returned if the blob SHA of the file isn't expectedSHA, which is empty for new files.
+func (c *FileClient) Put(ctx context.Context, path, branch, content, message, expectedSHA string) error {
+	opts := &github.RepositoryContentFileOptions{
+		Message: &message,
+		Content: []byte(content),
+		Branch:  &branch,
+	}
+	if expectedSHA != "" {
+		opts.SHA = &expectedSHA
+	}
+	// PUT /repos/{owner}/{repo}/contents/{path}
+	return fileConflictError(c.c.PutFile(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, opts))
+}
+
+// Delete deletes the file at path on the given branch, committing with message.
+// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its blob SHA isn't expectedSHA.
+func (c *FileClient) Delete(ctx context.Context, path, branch, message, expectedSHA string) error {
+	if expectedSHA == "" {
+		return fmt.Errorf("expected SHA is required to delete file %q: %w", path, gitprovider.ErrInvalidArgument)
+	}
+	opts := &github.RepositoryContentFileOptions{
+		Message: &message,
+		Branch:  &branch,
+		SHA:     &expectedSHA,
+	}
+	// DELETE /repos/{owner}/{repo}/contents/{path}
+	return fileConflictError(c.c.DeleteFile(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, opts))
+}
+
+// fileConflictError adds ErrConflict to err, if GitHub rejected writing a file because the given
+// SHA is stale (409 Conflict), or because no SHA was given for an existing file.
+func fileConflictError(err error) error {
+	httpErr := &gitprovider.HTTPError{}
+	if !errors.As(err, &httpErr) || httpErr.Response == nil {
+		return err
+	}
+	if httpErr.Response.StatusCode == http.StatusConflict ||
+		(httpErr.Response.StatusCode == http.StatusUnprocessableEntity && strings.Contains(httpErr.Message, shaNotSuppliedMagicString)) {
+		return validation.NewMultiError(err, gitprovider.ErrConflict)
+	}
+	return err
+}
@@ -18,6 +18,8 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v47/github"
@@ -32,38 +34,103 @@ type PullRequestClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// List lists all pull requests in the repository
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
-	prs, _, err := c.c.Client().PullRequests.List(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), nil)
+// List lists all pull requests in the repository, in all states unless filtered
+// with PullRequestListOptions.
+//
+// List returns all available pull requests, using multiple paginated requests if needed.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	filter, err := gitprovider.MakePullRequestListOptions(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	requests := make([]gitprovider.PullRequest, len(prs))
+	// GitHub only knows open and closed pull requests, merged ones are closed ones
+	listOpts := &github.PullRequestListOptions{State: "all"}
+	if filter.State != nil {
+		listOpts.State = "closed"
+		if *filter.State == gitprovider.PullRequestStateOpen {
+			listOpts.State = "open"
+		}
+	}
+	if filter.SourceBranch != nil {
+		listOpts.Head = fmt.Sprintf("%s:%s", c.ref.GetIdentity(), *filter.SourceBranch)
+	}
+	if filter.TargetBranch != nil {
+		listOpts.Base = *filter.TargetBranch
+	}
 
-	for idx, pr := range prs {
-		requests[idx] = newPullRequest(c.clientContext, pr)
+	prs := []*github.PullRequest{}
+	err = allPages(&listOpts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls
+		pageObjs, resp, listErr := c.c.Client().PullRequests.List(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), listOpts)
+		prs = append(prs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	requests := make([]gitprovider.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if filter.Matches(pullrequestFromAPI(pr)) {
+			requests = append(requests, newPullRequest(c.clientContext, c.ref, pr))
+		}
 	}
 
 	return requests, nil
 }
 
-// Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Create creates a pull request with the given specifications. Labels and assignees are added,
+// and reviews requested, after the pull request has been created. If that fails, the created
+// pull request is returned along with the error.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
 
 	prOpts := &github.NewPullRequest{
-		Title: &title,
-		Head:  &branch,
-		Base:  &baseBranch,
-		Body:  &description,
+		Title:               &title,
+		Head:                &branch,
+		Base:                &baseBranch,
+		Body:                &description,
+		Draft:               o.Draft,
+		MaintainerCanModify: o.MaintainerCanModify,
 	}
 
 	pr, _, err := c.c.Client().PullRequests.Create(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), prOpts)
 	if err != nil {
 		return nil, err
 	}
+	created := newPullRequest(c.clientContext, c.ref, pr)
+	if len(o.Labels) == 0 && len(o.Assignees) == 0 && len(o.Reviewers) == 0 {
+		return created, nil
+	}
+
+	number := pr.GetNumber()
+	if len(o.Labels) > 0 {
+		// POST /repos/{owner}/{repo}/issues/{issue_number}/labels
+		if _, _, err := c.c.Client().Issues.AddLabelsToIssue(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, o.Labels); err != nil {
+			return created, fmt.Errorf("pull request #%d was created, but adding labels failed: %w", number, handleHTTPError(err))
+		}
+	}
+	if len(o.Assignees) > 0 {
+		// POST /repos/{owner}/{repo}/issues/{issue_number}/assignees
+		if _, _, err := c.c.Client().Issues.AddAssignees(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, o.Assignees); err != nil {
+			return created, fmt.Errorf("pull request #%d was created, but adding assignees failed: %w", number, handleHTTPError(err))
+		}
+	}
+	if len(o.Reviewers) > 0 {
+		// POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers
+		if _, _, err := c.c.Client().PullRequests.RequestReviewers(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, github.ReviewersRequest{
+			Reviewers: o.Reviewers,
+		}); err != nil {
+			return created, fmt.Errorf("pull request #%d was created, but requesting reviews failed: %w", number, handleHTTPError(err))
+		}
+	}
 
-	return newPullRequest(c.clientContext, pr), nil
+	// Get the pull request again, so that it reports its labels, assignees and reviewers
+	return c.Get(ctx, number)
 }
 
 // Get retrieves an existing pull request by number
@@ -74,22 +141,94 @@ func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.Pu
 		return nil, err
 	}
 
-	return newPullRequest(c.clientContext, pr), nil
+	return newPullRequest(c.clientContext, c.ref, pr), nil
 }
 
-// Merge merges a pull request with the given specifications.
-func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string) error {
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Update(ctx context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	prOpts := &github.PullRequest{
+		Title: req.Title,
+		Body:  req.Description,
+	}
+	if req.TargetBranch != nil {
+		prOpts.Base = &github.PullRequestBranch{Ref: req.TargetBranch}
+	}
 
-	prOpts := &github.PullRequestOptions{
-		CommitTitle: "",
-		SHA:         "",
-		MergeMethod: string(mergeMethod),
+	// PATCH /repos/{owner}/{repo}/pulls/{pull_number}
+	pr, _, err := c.c.Client().PullRequests.Edit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, prOpts)
+	if err != nil {
+		return nil, handleHTTPError(err)
 	}
 
-	_, _, err := c.c.Client().PullRequests.Merge(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, message, prOpts)
+	return newPullRequest(c.clientContext, c.ref, pr), nil
+}
+
+// Close closes the pull request with the given number without merging it.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Close(ctx context.Context, number int) error {
+	// PATCH /repos/{owner}/{repo}/pulls/{pull_number}
+	_, _, err := c.c.Client().PullRequests.Edit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, &github.PullRequest{
+		State: github.String("closed"),
+	})
+	return handleHTTPError(err)
+}
+
+// Merge merges a pull request with the given specifications. GitHub has no fast-forward-only
+// merges, hence gitprovider.MergeMethodFastForwardOnly returns gitprovider.ErrNoProviderSupport.
+//
+// With auto-merge, the source branch is deleted according to the "delete_branch_on_merge"
+// setting of the repository, hence it can't be combined with DeleteSourceBranch. Deleting the
+// source branch after merging requires destructive API calls to be enabled, otherwise
+// ErrDestructiveCallDisallowed is returned without merging the pull request.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
 	if err != nil {
 		return err
 	}
+	if mergeMethod == gitprovider.MergeMethodFastForwardOnly {
+		return fmt.Errorf("github doesn't support fast-forward-only merges: %w", gitprovider.ErrNoProviderSupport)
+	}
+	autoMerge := o.AutoMerge != nil && *o.AutoMerge
+	deleteSourceBranch := o.DeleteSourceBranch != nil && *o.DeleteSourceBranch
+	if autoMerge && deleteSourceBranch {
+		return fmt.Errorf("github deletes the source branch of auto-merged pull requests based on the repository settings: %w", gitprovider.ErrNoProviderSupport)
+	}
+	// Don't allow deleting the source branch if the user didn't explicitly allow dangerous API calls.
+	if deleteSourceBranch && !c.destructiveActions {
+		return fmt.Errorf("cannot delete source branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
 
+	var pr *github.PullRequest
+	if autoMerge || deleteSourceBranch {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}
+		if pr, _, err = c.c.Client().PullRequests.Get(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number); err != nil {
+			return handleHTTPError(err)
+		}
+	}
+	if autoMerge {
+		err = c.c.EnablePullRequestAutoMerge(ctx, pr.GetNodeID(), string(mergeMethod), message)
+		// GitHub refuses to enable auto-merge if the pull request can be merged right away
+		if err == nil || !strings.Contains(err.Error(), cleanStatusMagicString) {
+			return err
+		}
+	}
+
+	prOpts := &github.PullRequestOptions{
+		MergeMethod: string(mergeMethod),
+	}
+	// PUT /repos/{owner}/{repo}/pulls/{pull_number}/merge
+	_, _, err = c.c.Client().PullRequests.Merge(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, message, prOpts)
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	if deleteSourceBranch {
+		// The source branch may belong to a fork
+		head := pr.GetHead()
+		return c.c.DeleteBranch(ctx, head.GetRepo().GetOwner().GetLogin(), head.GetRepo().GetName(), head.GetRef())
+	}
 	return nil
 }
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all releases in the repository.
+//
+// List returns all available releases, using multiple paginated requests if needed.
+func (c *ReleaseClient) List(ctx context.Context) ([]gitprovider.Release, error) {
+	// GET /repos/{owner}/{repo}/releases
+	apiObjs, err := c.c.ListReleases(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]gitprovider.Release, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		releases = append(releases, newRelease(c, apiObj))
+	}
+	return releases, nil
+}
+
+// Get returns the release for the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Get(ctx context.Context, tagName string) (gitprovider.Release, error) {
+	return c.get(ctx, tagName)
+}
+
+func (c *ReleaseClient) get(ctx context.Context, tagName string) (*release, error) {
+	// GET /repos/{owner}/{repo}/releases/tags/{tag}
+	apiObj, err := c.c.GetReleaseByTag(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), tagName)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// Create creates a release with the given specifications, creating its tag from req.Target
+// if it doesn't exist yet.
+//
+// ErrAlreadyExists will be returned if a release for the tag already exists.
+func (c *ReleaseClient) Create(ctx context.Context, req gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	// GitHub responds with a validation error for existing releases
+	if _, err := c.get(ctx, req.TagName); err == nil {
+		return nil, fmt.Errorf("release %q: %w", req.TagName, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	if req.Name == nil {
+		req.Name = &req.TagName
+	}
+	// POST /repos/{owner}/{repo}/releases
+	apiObj, err := c.c.CreateRelease(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), releaseToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// Update changes the release for the tag with the given name to req. Unset fields of req
+// are left unchanged, and the tag can't be changed.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Update(ctx context.Context, tagName string, req gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	actual, err := c.get(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+	req.TagName = ""
+	// PATCH /repos/{owner}/{repo}/releases/{release_id}
+	apiObj, err := c.c.EditRelease(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), *actual.r.ID, releaseToAPI(&req))
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// Delete deletes the release for the tag with the given name. The tag itself is kept.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Delete(ctx context.Context, tagName string) error {
+	actual, err := c.get(ctx, tagName)
+	if err != nil {
+		return err
+	}
+	// DELETE /repos/{owner}/{repo}/releases/{release_id}
+	return c.c.DeleteRelease(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), *actual.r.ID)
+}
+
+// UploadAsset attaches a file with the given name and content to the release for the
+// tag with the given name.
+//
+// ErrNotFound is returned if the release does not exist.
+func (c *ReleaseClient) UploadAsset(ctx context.Context, tagName, name string, content []byte) (*gitprovider.ReleaseAsset, error) {
+	actual, err := c.get(ctx, tagName)
+	if err != nil {
+		return nil, err
+	}
+	// POST /repos/{owner}/{repo}/releases/{release_id}/assets
+	apiObj, err := c.c.UploadReleaseAsset(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), *actual.r.ID, name, content)
+	if err != nil {
+		return nil, err
+	}
+	asset := releaseAssetFromAPI(apiObj)
+	return &asset, nil
+}
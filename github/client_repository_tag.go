@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all tags in the repository.
+//
+// List returns all available tags, using multiple paginated requests if needed.
+// The tag object of every annotated tag is fetched to resolve its commit and message.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.Tag, error) {
+	// GET /repos/{owner}/{repo}/git/matching-refs/tags
+	apiObjs, err := c.c.ListTagRefs(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gitprovider.Tag, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		tag, err := c.fromRef(ctx, apiObj)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Get(ctx context.Context, name string) (gitprovider.Tag, error) {
+	// GET /repos/{owner}/{repo}/git/ref/tags/{tag}
+	apiObj, err := c.c.GetTagRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromRef(ctx, apiObj)
+}
+
+// fromRef returns the tag of a tag reference, fetching the tag object if it's annotated.
+func (c *TagClient) fromRef(ctx context.Context, ref *github.Reference) (*tagType, error) {
+	name := strings.TrimPrefix(*ref.Ref, "refs/tags/")
+	if *ref.Object.Type != gitObjectTypeTag {
+		return newTag(c, lightweightTag(name, ref)), nil
+	}
+	// GET /repos/{owner}/{repo}/git/tags/{tag_sha}
+	apiObj, err := c.c.GetTagObject(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), *ref.Object.SHA)
+	if err != nil {
+		return nil, err
+	}
+	return newTag(c, apiObj), nil
+}
+
+// Create creates a tag with the given specifications. A tag with a Message is an
+// annotated tag, otherwise a lightweight tag.
+//
+// ErrAlreadyExists will be returned if the tag already exists.
+func (c *TagClient) Create(ctx context.Context, req gitprovider.TagInfo) (gitprovider.Tag, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	// GitHub responds with a validation error for existing references
+	if _, err := c.Get(ctx, req.Name); err == nil {
+		return nil, fmt.Errorf("tag %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	sha := req.Sha
+	var apiObj *github.Tag
+	if req.Message != nil {
+		// POST /repos/{owner}/{repo}/git/tags
+		var err error
+		apiObj, err = c.c.CreateTagObject(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Tag{
+			Tag:     &req.Name,
+			Message: req.Message,
+			Object: &github.GitObject{
+				Type: github.String("commit"),
+				SHA:  &req.Sha,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		sha = *apiObj.SHA
+	}
+	// POST /repos/{owner}/{repo}/git/refs
+	ref, err := c.c.CreateTagRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Name, sha)
+	if err != nil {
+		return nil, err
+	}
+	if apiObj == nil {
+		apiObj = lightweightTag(req.Name, ref)
+	}
+	return newTag(c, apiObj), nil
+}
+
+// Delete deletes the tag with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Delete(ctx context.Context, name string) error {
+	// DELETE /repos/{owner}/{repo}/git/refs/tags/{tag}
+	return c.c.DeleteTag(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name)
+}
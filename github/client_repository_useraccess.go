@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserAccessClient implements the gitprovider.UserAccessClient interface.
+var _ gitprovider.UserAccessClient = &UserAccessClient{}
+
+// UserAccessClient operates on the collaborators of a specific repository.
+type UserAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get a collaborator of the repository, or a user with a pending invitation to the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *UserAccessClient) Get(ctx context.Context, login string) (gitprovider.UserAccess, error) {
+	userAccess, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ua := range userAccess {
+		// GitHub logins are case-insensitive
+		if strings.EqualFold(ua.Get().Login, login) {
+			return ua, nil
+		}
+	}
+	return nil, fmt.Errorf("user %q has no access to repository %s: %w", login, c.ref.String(), gitprovider.ErrNotFound)
+}
+
+// List lists the direct collaborators of this repository, followed by the users with a
+// pending invitation to it.
+//
+// List returns all available user access lists, using multiple paginated requests if needed.
+func (c *UserAccessClient) List(ctx context.Context) ([]gitprovider.UserAccess, error) {
+	// GET /repos/{owner}/{repo}/collaborators
+	collaborators, err := c.c.ListCollaborators(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+	// GET /repos/{owner}/{repo}/invitations
+	invitations, err := c.c.ListRepoInvitations(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	userAccess := make([]gitprovider.UserAccess, 0, len(collaborators)+len(invitations))
+	for _, apiObj := range collaborators {
+		// Login and Permissions are validated to be set in ListCollaborators
+		userAccess = append(userAccess, newUserAccess(c, gitprovider.UserAccessInfo{
+			Login:      *apiObj.Login,
+			Permission: getPermissionFromMap(apiObj.Permissions),
+		}, apiObj, nil))
+	}
+	for _, apiObj := range invitations {
+		// ID, Invitee.Login and Permissions are validated to be set in ListRepoInvitations
+		userAccess = append(userAccess, newUserAccess(c, gitprovider.UserAccessInfo{
+			Login:      *apiObj.Invitee.Login,
+			Permission: getPermissionFromInvitation(*apiObj.Permissions),
+		}, apiObj, apiObj.ID))
+	}
+
+	return userAccess, nil
+}
+
+// Create adds a given user as collaborator of the repository. GitHub adds members of the owning
+// organization right away, and sends an invitation to other users, which is reflected by
+// UserAccess.Invited().
+//
+// ErrAlreadyExists will be returned if the user is a collaborator or invited already.
+func (c *UserAccessClient) Create(ctx context.Context, req gitprovider.UserAccessInfo) (gitprovider.UserAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// GitHub updates the permission of existing collaborators and invitations instead of failing
+	_, err := c.Get(ctx, req.Login)
+	if err == nil {
+		return nil, fmt.Errorf("user %q has access to repository %s: %w", req.Login, c.ref.String(), gitprovider.ErrAlreadyExists)
+	}
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	// PUT /repos/{owner}/{repo}/collaborators/{username}
+	invitation, err := c.c.AddCollaborator(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), req.Login, *req.Permission)
+	if err != nil {
+		return nil, err
+	}
+	if invitation != nil {
+		return newUserAccess(c, req, invitation, invitation.ID), nil
+	}
+	return newUserAccess(c, req, nil, nil), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *UserAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.UserAccessInfo,
+) (gitprovider.UserAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// The login returned by GitHub might differ in case from the requested one
+	req.Login = actual.Get().Login
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestClient_HasTokenPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		scopes     *string
+		permission gitprovider.TokenPermission
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "repo scope",
+			scopes:     github.String("repo, read:org"),
+			permission: gitprovider.TokenPermissionRWRepository,
+			want:       true,
+		},
+		{
+			name:       "repo hooks granted by the repo scope",
+			scopes:     github.String("repo"),
+			permission: gitprovider.TokenPermissionAdminRepoHook,
+			want:       true,
+		},
+		{
+			name:       "repo hooks granted by the admin:repo_hook scope",
+			scopes:     github.String("admin:repo_hook"),
+			permission: gitprovider.TokenPermissionAdminRepoHook,
+			want:       true,
+		},
+		{
+			name:       "missing scope",
+			scopes:     github.String("repo, read:packages"),
+			permission: gitprovider.TokenPermissionWritePackages,
+			want:       false,
+		},
+		{
+			name:       "classic token without scopes",
+			scopes:     github.String(""),
+			permission: gitprovider.TokenPermissionDeleteRepository,
+			want:       false,
+		},
+		{
+			name:       "fine-grained token",
+			permission: gitprovider.TokenPermissionAdminOrganization,
+			wantErr:    gitprovider.ErrTokenPermissionUnknown,
+		},
+		{
+			name:       "unknown permission",
+			scopes:     github.String("repo"),
+			permission: 0,
+			wantErr:    gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.scopes != nil {
+					w.Header().Set("X-OAuth-Scopes", *tt.scopes)
+				}
+				writeJSON(t, w, http.StatusOK, map[string]interface{}{})
+			})
+
+			got, err := c.HasTokenPermission(context.Background(), tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HasTokenPermission() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HasTokenPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestClient returns a client talking to a TLS server serving handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(
+		gitprovider.WithDomain(strings.TrimPrefix(server.URL, "https://")),
+		gitprovider.WithOAuth2Token("token"),
+		gitprovider.WithPostChainTransportHook(func(http.RoundTripper) http.RoundTripper {
+			return server.Client().Transport
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return c.(*Client)
+}
+
+func TestPullRequestClient_Create_followUpFailure(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/org/repo/pulls":
+			writeJSON(t, w, http.StatusCreated, &github.PullRequest{Number: github.Int(1), Title: github.String("title")})
+		case "/api/v3/repos/org/repo/issues/1/labels":
+			writeError(t, w, http.StatusUnprocessableEntity, "label is invalid")
+		default:
+			writeError(t, w, http.StatusNotFound, "")
+		}
+	})
+	prs := &PullRequestClient{
+		clientContext: c.clientContext,
+		ref:           gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Domain: "github.example.com", Organization: "org"}, RepositoryName: "repo"},
+	}
+
+	pr, err := prs.Create(context.Background(), "title", "feature", "main", "", &gitprovider.PullRequestCreateOptions{Labels: []string{"bug"}})
+	if err == nil {
+		t.Fatal("Create() returned no error, want the error of adding labels")
+	}
+	if pr == nil {
+		t.Fatal("Create() returned no pull request, want the created pull request")
+	}
+	if got := pr.Get().Number; got != 1 {
+		t.Errorf("Create() returned pull request %d, want 1", got)
+	}
+}
+
+func TestTeamsClient_Reconcile_slug(t *testing.T) {
+	var created []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/orgs/org/teams/my-team/members":
+			writeJSON(t, w, http.StatusOK, []*github.User{{Login: github.String("alice")}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/orgs/org/teams":
+			created = append(created, r.URL.Path)
+			writeJSON(t, w, http.StatusCreated, &github.Team{Name: github.String("My Team"), Slug: github.String("my-team")})
+		default:
+			writeError(t, w, http.StatusNotFound, "")
+		}
+	})
+	teams := &TeamsClient{
+		clientContext: c.clientContext,
+		ref:           gitprovider.OrganizationRef{Domain: "github.example.com", Organization: "org"},
+	}
+
+	team, actionTaken, err := teams.Reconcile(context.Background(), gitprovider.TeamInfo{Name: "My Team", Members: []string{"alice"}})
+	if err != nil {
+		t.Fatalf("Reconcile() returned error: %v", err)
+	}
+	if actionTaken || len(created) != 0 {
+		t.Errorf("Reconcile() took action for an existing team, created %v", created)
+	}
+	if got := team.Get().Name; got != "my-team" {
+		t.Errorf("Reconcile() returned team %q, want %q", got, "my-team")
+	}
+}
+
+func Test_teamSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "my-team", want: "my-team"},
+		{name: "My Team", want: "my-team"},
+		{name: "Team.Name  (ops)", want: "team-name-ops"},
+		{name: "team_name", want: "team_name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := teamSlug(tt.name); got != tt.want {
+				t.Errorf("teamSlug() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserAccessClient_Create_existing(t *testing.T) {
+	var added []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/org/repo/collaborators":
+			writeJSON(t, w, http.StatusOK, []*github.User{{Login: github.String("Alice"), Permissions: map[string]bool{"pull": true}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/org/repo/invitations":
+			writeJSON(t, w, http.StatusOK, []*github.RepositoryInvitation{})
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/v3/repos/org/repo/collaborators/"):
+			added = append(added, strings.TrimPrefix(r.URL.Path, "/api/v3/repos/org/repo/collaborators/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(t, w, http.StatusNotFound, "")
+		}
+	})
+	userAccess := &UserAccessClient{
+		clientContext: c.clientContext,
+		ref:           gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Domain: "github.example.com", Organization: "org"}, RepositoryName: "repo"},
+	}
+
+	ctx := context.Background()
+	if _, err := userAccess.Create(ctx, gitprovider.UserAccessInfo{Login: "alice"}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	if _, err := userAccess.Create(ctx, gitprovider.UserAccessInfo{Login: "bob"}); err != nil {
+		t.Errorf("Create() returned error: %v", err)
+	}
+	if diff := strings.Join(added, ","); diff != "bob" {
+		t.Errorf("Create() added collaborators %q, want %q", diff, "bob")
+	}
+}
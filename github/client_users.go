@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UsersClient implements the gitprovider.UsersClient interface.
+var _ gitprovider.UsersClient = &UsersClient{}
+
+// UsersClient looks up user accounts.
+type UsersClient struct {
+	*clientContext
+}
+
+// GetAuthenticated returns the user the client is authenticated as.
+func (c *UsersClient) GetAuthenticated(ctx context.Context) (gitprovider.User, error) {
+	// GET /user
+	apiObj, err := c.c.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newUser(c.clientContext, apiObj), nil
+}
+
+// Get returns the user for the given reference.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *UsersClient) Get(ctx context.Context, ref gitprovider.UserRef) (gitprovider.User, error) {
+	// Make sure the UserRef is valid
+	if err := validateUserRef(ref, c.domain); err != nil {
+		return nil, err
+	}
+
+	// GET /users/{username}
+	apiObj, err := c.c.GetUser(ctx, ref.UserLogin)
+	if err != nil {
+		return nil, err
+	}
+	return newUser(c.clientContext, apiObj), nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+// conformancePageSize is the page size of the stand-in, small enough for List to need multiple pages.
+const conformancePageSize = 2
+
+// githubStandIn emulates the repository and deploy key endpoints of a GitHub Enterprise server
+// with a single organization "org".
+type githubStandIn struct {
+	t      *testing.T
+	mu     sync.Mutex
+	repos  []*github.Repository
+	keys   map[string][]*github.Key
+	lastID int64
+}
+
+func TestConformance(t *testing.T) {
+	s := &githubStandIn{t: t, keys: map[string][]*github.Key{}}
+	// Enterprise clients always use HTTPS, hence use the client of the TLS server as transport
+	server := httptest.NewTLSServer(s)
+	t.Cleanup(server.Close)
+	domain := strings.TrimPrefix(server.URL, "https://")
+
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewClient(append([]gitprovider.ClientOption{
+				gitprovider.WithDomain(domain),
+				gitprovider.WithOAuth2Token("token"),
+				gitprovider.WithPostChainTransportHook(func(http.RoundTripper) http.RoundTripper {
+					return server.Client().Transport
+				}),
+			}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: gitprovider.OrganizationRef{Domain: domain, Organization: "org"},
+	})
+}
+
+func (s *githubStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v3/"), "/")
+	switch {
+	case len(path) == 3 && path[0] == "orgs" && path[1] == "org" && path[2] == "repos" && r.Method == http.MethodGet:
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		writePage(s.t, w, r, page, len(s.repos), func(start, end int) interface{} {
+			return s.repos[start:end]
+		})
+	case len(path) == 3 && path[0] == "orgs" && path[1] == "org" && path[2] == "repos" && r.Method == http.MethodPost:
+		req := &github.Repository{}
+		s.decode(r, req)
+		if s.repo(req.GetName()) != nil {
+			writeError(s.t, w, http.StatusUnprocessableEntity, alreadyExistsMagicString)
+			return
+		}
+		repo := &github.Repository{
+			Name:          req.Name,
+			FullName:      github.String("org/" + req.GetName()),
+			Description:   req.Description,
+			Visibility:    req.Visibility,
+			DefaultBranch: req.DefaultBranch,
+		}
+		if repo.DefaultBranch == nil {
+			repo.DefaultBranch = github.String("main")
+		}
+		s.repos = append(s.repos, repo)
+		writeJSON(s.t, w, http.StatusCreated, repo)
+	case len(path) >= 3 && path[0] == "repos" && path[1] == "org":
+		s.serveRepo(w, r, path[2], path[3:])
+	default:
+		writeError(s.t, w, http.StatusNotFound, "")
+	}
+}
+
+func (s *githubStandIn) serveRepo(w http.ResponseWriter, r *http.Request, name string, path []string) {
+	repo := s.repo(name)
+	if repo == nil {
+		writeError(s.t, w, http.StatusNotFound, "")
+		return
+	}
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodPatch:
+		req := &github.Repository{}
+		s.decode(r, req)
+		if req.Description != nil {
+			repo.Description = req.Description
+		}
+		if req.Visibility != nil {
+			repo.Visibility = req.Visibility
+		}
+		if req.DefaultBranch != nil {
+			repo.DefaultBranch = req.DefaultBranch
+		}
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodDelete:
+		for i := range s.repos {
+			if s.repos[i] == repo {
+				s.repos = append(s.repos[:i], s.repos[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && path[0] == "keys" && r.Method == http.MethodGet:
+		keys := s.keys[name]
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		writePage(s.t, w, r, page, len(keys), func(start, end int) interface{} {
+			return keys[start:end]
+		})
+	case len(path) == 1 && path[0] == "keys" && r.Method == http.MethodPost:
+		req := &github.Key{}
+		s.decode(r, req)
+		for _, key := range s.keys[name] {
+			if key.GetKey() == req.GetKey() {
+				writeError(s.t, w, http.StatusUnprocessableEntity, keyInUseMagicString)
+				return
+			}
+		}
+		s.lastID++
+		key := &github.Key{ID: github.Int64(s.lastID), Title: req.Title, Key: req.Key, ReadOnly: req.ReadOnly}
+		s.keys[name] = append(s.keys[name], key)
+		writeJSON(s.t, w, http.StatusCreated, key)
+	case len(path) == 2 && path[0] == "keys" && r.Method == http.MethodDelete:
+		id, _ := strconv.ParseInt(path[1], 10, 64)
+		keys := s.keys[name]
+		for i := range keys {
+			if keys[i].GetID() == id {
+				s.keys[name] = append(keys[:i], keys[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(s.t, w, http.StatusNotFound, "")
+	default:
+		writeError(s.t, w, http.StatusNotFound, "")
+	}
+}
+
+func (s *githubStandIn) repo(name string) *github.Repository {
+	for _, repo := range s.repos {
+		if repo.GetName() == name {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (s *githubStandIn) decode(r *http.Request, obj interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		s.t.Errorf("failed to decode request: %v", err)
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+// writeError writes an error response, with a single validation error carrying message if
+// it's non-empty.
+func writeError(t *testing.T, w http.ResponseWriter, status int, message string) {
+	// github.ErrorResponse can't be used, as its encoded Response field would be decoded as nil
+	resp := map[string]interface{}{"message": http.StatusText(status)}
+	if message != "" {
+		resp["message"] = "Validation Failed"
+		resp["errors"] = []github.Error{{Code: "custom", Message: message}}
+	}
+	writeJSON(t, w, status, resp)
+}
+
+// writePage writes the given page (starting at 1) of total items, and sets the Link header
+// if there are more pages.
+func writePage(t *testing.T, w http.ResponseWriter, r *http.Request, page, total int, items func(start, end int) interface{}) {
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * conformancePageSize
+	if start > total {
+		start = total
+	}
+	end := start + conformancePageSize
+	if end >= total {
+		end = total
+	} else {
+		next := *r.URL
+		query := next.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+	writeJSON(t, w, http.StatusOK, items(start, end))
+}
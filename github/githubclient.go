@@ -17,8 +17,15 @@ limitations under the License.
 package github
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v47/github"
@@ -38,6 +45,22 @@ type githubClient interface {
 	// ListOrgs is a wrapper for "GET /user/orgs".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListOrgs(ctx context.Context) ([]*github.Organization, error)
+	// CreateOrg is a wrapper for "POST /admin/organizations", which is only available in GitHub Enterprise.
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateOrg(ctx context.Context, req *github.Organization, admin string) (*github.Organization, error)
+	// UpdateOrg is a wrapper for "PATCH /orgs/{org}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateOrg(ctx context.Context, orgName string, req *github.Organization) (*github.Organization, error)
+	// DeleteOrg is a wrapper for "DELETE /orgs/{org}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteOrg(ctx context.Context, orgName string) error
+	// GetAuthenticatedUser is a wrapper for "GET /user".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetAuthenticatedUser(ctx context.Context) (*github.User, error)
+	// GetUser is a wrapper for "GET /users/{username}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetUser(ctx context.Context, login string) (*github.User, error)
 
 	// ListOrgTeamMembers is a wrapper for "GET /orgs/{org}/teams/{team_slug}/members".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
@@ -45,6 +68,19 @@ type githubClient interface {
 	// ListOrgTeams is a wrapper for "GET /orgs/{org}/teams".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListOrgTeams(ctx context.Context, orgName string) ([]*github.Team, error)
+	// CreateTeam is a wrapper for "POST /orgs/{org}/teams".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateTeam(ctx context.Context, orgName string, req github.NewTeam) (*github.Team, error)
+	// DeleteTeam is a wrapper for "DELETE /orgs/{org}/teams/{team_slug}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteTeam(ctx context.Context, orgName, teamName string) error
+	// AddTeamMember is a wrapper for "PUT /orgs/{org}/teams/{team_slug}/memberships/{username}".
+	// This function handles HTTP error wrapping.
+	AddTeamMember(ctx context.Context, orgName, teamName, login string) error
+	// RemoveTeamMember is a wrapper for "DELETE /orgs/{org}/teams/{team_slug}/memberships/{username}".
+	// This function handles HTTP error wrapping.
+	RemoveTeamMember(ctx context.Context, orgName, teamName, login string) error
 
 	// GetRepo is a wrapper for "GET /repos/{owner}/{repo}".
 	// This function handles HTTP error wrapping, and validates the server result.
@@ -80,6 +116,117 @@ type githubClient interface {
 	// This function handles HTTP error wrapping.
 	DeleteKey(ctx context.Context, owner, repo string, id int64) error
 
+	// ListBranches is a wrapper for "GET /repos/{owner}/{repo}/branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error)
+	// GetBranch is a wrapper for "GET /repos/{owner}/{repo}/branches/{branch}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, owner, repo, branch string) (*github.Branch, error)
+	// DeleteBranch is a wrapper for "DELETE /repos/{owner}/{repo}/git/refs/heads/{branch}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteBranch(ctx context.Context, owner, repo, branch string) error
+
+	// ListProtectedBranches is a wrapper for "GET /repos/{owner}/{repo}/branches?protected=true".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProtectedBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error)
+	// GetBranchProtection is a wrapper for "GET /repos/{owner}/{repo}/branches/{branch}/protection".
+	// This function handles HTTP error wrapping, and returns ErrNotFound if the branch isn't protected.
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error)
+	// UpdateBranchProtection is a wrapper for "PUT /repos/{owner}/{repo}/branches/{branch}/protection".
+	// This function handles HTTP error wrapping.
+	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, req *github.ProtectionRequest) (*github.Protection, error)
+	// RemoveBranchProtection is a wrapper for "DELETE /repos/{owner}/{repo}/branches/{branch}/protection".
+	// This function handles HTTP error wrapping.
+	RemoveBranchProtection(ctx context.Context, owner, repo, branch string) error
+
+	// ListHooks is a wrapper for "GET /repos/{owner}/{repo}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error)
+	// CreateHook is a wrapper for "POST /repos/{owner}/{repo}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateHook(ctx context.Context, owner, repo string, req *github.Hook) (*github.Hook, error)
+	// EditHook is a wrapper for "PATCH /repos/{owner}/{repo}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditHook(ctx context.Context, owner, repo string, id int64, req *github.Hook) (*github.Hook, error)
+	// DeleteHook is a wrapper for "DELETE /repos/{owner}/{repo}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteHook(ctx context.Context, owner, repo string, id int64) error
+
+	// PutFile is a wrapper for "PUT /repos/{owner}/{repo}/contents/{path}".
+	// This function handles HTTP error wrapping.
+	PutFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) error
+	// DeleteFile is a wrapper for "DELETE /repos/{owner}/{repo}/contents/{path}".
+	// This function handles HTTP error wrapping.
+	DeleteFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) error
+
+	// ListTagRefs is a wrapper for "GET /repos/{owner}/{repo}/git/matching-refs/tags".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListTagRefs(ctx context.Context, owner, repo string) ([]*github.Reference, error)
+	// GetTagRef is a wrapper for "GET /repos/{owner}/{repo}/git/ref/tags/{tag}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetTagRef(ctx context.Context, owner, repo, tag string) (*github.Reference, error)
+	// CreateTagRef is a wrapper for "POST /repos/{owner}/{repo}/git/refs".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateTagRef(ctx context.Context, owner, repo, tag, sha string) (*github.Reference, error)
+	// DeleteTag is a wrapper for "DELETE /repos/{owner}/{repo}/git/refs/tags/{tag}".
+	// This function handles HTTP error wrapping, and deletes the tag only if destructive actions are allowed.
+	DeleteTag(ctx context.Context, owner, repo, tag string) error
+	// GetTagObject is a wrapper for "GET /repos/{owner}/{repo}/git/tags/{tag_sha}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetTagObject(ctx context.Context, owner, repo, sha string) (*github.Tag, error)
+	// CreateTagObject is a wrapper for "POST /repos/{owner}/{repo}/git/tags".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateTagObject(ctx context.Context, owner, repo string, req *github.Tag) (*github.Tag, error)
+
+	// ListReleases is a wrapper for "GET /repos/{owner}/{repo}/releases".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error)
+	// GetReleaseByTag is a wrapper for "GET /repos/{owner}/{repo}/releases/tags/{tag}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error)
+	// CreateRelease is a wrapper for "POST /repos/{owner}/{repo}/releases".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRelease(ctx context.Context, owner, repo string, req *github.RepositoryRelease) (*github.RepositoryRelease, error)
+	// EditRelease is a wrapper for "PATCH /repos/{owner}/{repo}/releases/{release_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditRelease(ctx context.Context, owner, repo string, id int64, req *github.RepositoryRelease) (*github.RepositoryRelease, error)
+	// DeleteRelease is a wrapper for "DELETE /repos/{owner}/{repo}/releases/{release_id}".
+	// This function handles HTTP error wrapping.
+	DeleteRelease(ctx context.Context, owner, repo string, id int64) error
+	// UploadReleaseAsset is a wrapper for "POST /repos/{owner}/{repo}/releases/{release_id}/assets".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, name string, content []byte) (*github.ReleaseAsset, error)
+
+	// ListCommitStatuses is a wrapper for "GET /repos/{owner}/{repo}/commits/{ref}/status".
+	// It returns the latest status of each context, as the combined status does.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListCommitStatuses(ctx context.Context, owner, repo, ref string) ([]*github.RepoStatus, error)
+	// CreateCommitStatus is a wrapper for "POST /repos/{owner}/{repo}/statuses/{sha}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateCommitStatus(ctx context.Context, owner, repo, sha string, req *github.RepoStatus) (*github.RepoStatus, error)
+
+	// ListIssueComments is a wrapper for "GET /repos/{owner}/{repo}/issues/{issue_number}/comments".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error)
+	// CreateIssueComment is a wrapper for "POST /repos/{owner}/{repo}/issues/{issue_number}/comments".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error)
+	// UpdateIssueComment is a wrapper for "PATCH /repos/{owner}/{repo}/issues/comments/{comment_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateIssueComment(ctx context.Context, owner, repo string, id int64, body string) (*github.IssueComment, error)
+	// DeleteIssueComment is a wrapper for "DELETE /repos/{owner}/{repo}/issues/comments/{comment_id}".
+	// This function handles HTTP error wrapping.
+	DeleteIssueComment(ctx context.Context, owner, repo string, id int64) error
+
+	// ListPullRequestReviews is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error)
+	// EnablePullRequestAutoMerge is a wrapper for the "enablePullRequestAutoMerge" GraphQL mutation,
+	// nodeID is the GraphQL ID of the pull request.
+	// This function handles HTTP error wrapping, and returns GraphQL errors as errors.
+	EnablePullRequestAutoMerge(ctx context.Context, nodeID, mergeMethod, commitBody string) error
+
 	// GetTeamPermissions is a wrapper for "GET /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetTeamPermissions(ctx context.Context, orgName, repo, teamName string) (map[string]bool, error)
@@ -92,6 +239,27 @@ type githubClient interface {
 	// RemoveTeam is a wrapper for "DELETE /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}".
 	// This function handles HTTP error wrapping.
 	RemoveTeam(ctx context.Context, orgName, repo, teamName string) error
+
+	// ListCollaborators is a wrapper for "GET /repos/{owner}/{repo}/collaborators",
+	// listing only the direct collaborators of the repository.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListCollaborators(ctx context.Context, owner, repo string) ([]*github.User, error)
+	// AddCollaborator is a wrapper for "PUT /repos/{owner}/{repo}/collaborators/{username}".
+	// The returned invitation is nil if the user was added or updated directly.
+	// This function handles HTTP error wrapping.
+	AddCollaborator(ctx context.Context, owner, repo, login string, permission gitprovider.RepositoryPermission) (*github.CollaboratorInvitation, error)
+	// RemoveCollaborator is a wrapper for "DELETE /repos/{owner}/{repo}/collaborators/{username}".
+	// This function handles HTTP error wrapping.
+	RemoveCollaborator(ctx context.Context, owner, repo, login string) error
+	// ListRepoInvitations is a wrapper for "GET /repos/{owner}/{repo}/invitations".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepoInvitations(ctx context.Context, owner, repo string) ([]*github.RepositoryInvitation, error)
+	// UpdateRepoInvitation is a wrapper for "PATCH /repos/{owner}/{repo}/invitations/{invitation_id}".
+	// This function handles HTTP error wrapping.
+	UpdateRepoInvitation(ctx context.Context, owner, repo string, id int64, permission gitprovider.RepositoryPermission) error
+	// DeleteRepoInvitation is a wrapper for "DELETE /repos/{owner}/{repo}/invitations/{invitation_id}".
+	// This function handles HTTP error wrapping.
+	DeleteRepoInvitation(ctx context.Context, owner, repo string, id int64) error
 }
 
 // githubClientImpl is a wrapper around *github.Client, which implements higher-level methods,
@@ -145,6 +313,69 @@ func (c *githubClientImpl) ListOrgs(ctx context.Context) ([]*github.Organization
 	return apiObjs, nil
 }
 
+func (c *githubClientImpl) CreateOrg(ctx context.Context, req *github.Organization, admin string) (*github.Organization, error) {
+	// POST /admin/organizations
+	apiObj, _, err := c.c.Admin.CreateOrg(ctx, req, admin)
+	return validateOrganizationAPIResp(apiObj, err)
+}
+
+func (c *githubClientImpl) UpdateOrg(ctx context.Context, orgName string, req *github.Organization) (*github.Organization, error) {
+	// PATCH /orgs/{org}
+	apiObj, _, err := c.c.Organizations.Edit(ctx, orgName, req)
+	return validateOrganizationAPIResp(apiObj, err)
+}
+
+func validateOrganizationAPIResp(apiObj *github.Organization, err error) (*github.Organization, error) {
+	// If the response contained an error, return
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure apiObj is valid
+	if err := validateOrganizationAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteOrg(ctx context.Context, orgName string) error {
+	// Don't allow deleting organizations if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete organization: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /orgs/{org}, go-github doesn't wrap this endpoint (yet)
+	req, err := c.c.NewRequest(http.MethodDelete, fmt.Sprintf("orgs/%s", url.PathEscape(orgName)), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.c.Do(ctx, req, nil)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetAuthenticatedUser(ctx context.Context) (*github.User, error) {
+	// GET /user
+	apiObj, _, err := c.c.Users.Get(ctx, "")
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the Login field is set.
+	if apiObj.Login == nil {
+		return nil, fmt.Errorf("didn't expect login to be nil for user: %+v: %w", apiObj, gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) GetUser(ctx context.Context, login string) (*github.User, error) {
+	// GET /users/{username}
+	apiObj, _, err := c.c.Users.Get(ctx, login)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
 func (c *githubClientImpl) ListOrgTeamMembers(ctx context.Context, orgName, teamName string) ([]*github.User, error) {
 	apiObjs := []*github.User{}
 	opts := &github.TeamListTeamMembersOptions{}
@@ -191,6 +422,43 @@ func (c *githubClientImpl) ListOrgTeams(ctx context.Context, orgName string) ([]
 	return apiObjs, nil
 }
 
+func (c *githubClientImpl) CreateTeam(ctx context.Context, orgName string, req github.NewTeam) (*github.Team, error) {
+	// POST /orgs/{org}/teams
+	apiObj, _, err := c.c.Teams.CreateTeam(ctx, orgName, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure the Slug field is set.
+	if apiObj.Slug == nil {
+		return nil, fmt.Errorf("didn't expect slug to be nil for team: %+v: %w", apiObj, gitprovider.ErrInvalidServerData)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteTeam(ctx context.Context, orgName, teamName string) error {
+	// Don't allow deleting teams if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete team: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /orgs/{org}/teams/{team_slug}
+	_, err := c.c.Teams.DeleteTeamBySlug(ctx, orgName, teamName)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) AddTeamMember(ctx context.Context, orgName, teamName, login string) error {
+	// PUT /orgs/{org}/teams/{team_slug}/memberships/{username}
+	_, _, err := c.c.Teams.AddTeamMembershipBySlug(ctx, orgName, teamName, login, &github.TeamAddTeamMembershipOptions{
+		Role: "member",
+	})
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) RemoveTeamMember(ctx context.Context, orgName, teamName, login string) error {
+	// DELETE /orgs/{org}/teams/{team_slug}/memberships/{username}
+	_, err := c.c.Teams.RemoveTeamMembershipBySlug(ctx, orgName, teamName, login)
+	return handleHTTPError(err)
+}
+
 func (c *githubClientImpl) GetRepo(ctx context.Context, owner, repo string) (*github.Repository, error) {
 	// GET /repos/{owner}/{repo}
 	apiObj, _, err := c.c.Repositories.Get(ctx, owner, repo)
@@ -345,6 +613,443 @@ func (c *githubClientImpl) DeleteKey(ctx context.Context, owner, repo string, id
 	return handleHTTPError(err)
 }
 
+func (c *githubClientImpl) ListBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error) {
+	apiObjs := []*github.Branch{}
+	opts := &github.BranchListOptions{}
+	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/branches
+		pageObjs, resp, listErr := c.c.Repositories.ListBranches(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetBranch(ctx context.Context, owner, repo, branch string) (*github.Branch, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}
+	apiObj, _, err := c.c.Repositories.GetBranch(ctx, owner, repo, branch, false)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repos/{owner}/{repo}/git/refs/heads/{branch}
+	_, err := c.c.Git.DeleteRef(ctx, owner, repo, "heads/"+branch)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListProtectedBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error) {
+	apiObjs := []*github.Branch{}
+	opts := &github.BranchListOptions{Protected: gitprovider.BoolVar(true)}
+	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/branches?protected=true
+		pageObjs, resp, listErr := c.c.Repositories.ListBranches(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, _, err := c.c.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+	// go-github replaces the 404 response of unprotected branches with its own error
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return nil, fmt.Errorf("protection of branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, req *github.ProtectionRequest) (*github.Protection, error) {
+	// PUT /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, _, err := c.c.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, req)
+	return apiObj, handleHTTPError(err)
+}
+
+func (c *githubClientImpl) RemoveBranchProtection(ctx context.Context, owner, repo, branch string) error {
+	// DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+	_, err := c.c.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+	apiObjs := []*github.Hook{}
+	opts := &github.ListOptions{}
+	err := allPages(opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/hooks
+		pageObjs, resp, listErr := c.c.Repositories.ListHooks(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateWebhookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateHook(ctx context.Context, owner, repo string, req *github.Hook) (*github.Hook, error) {
+	// POST /repos/{owner}/{repo}/hooks
+	apiObj, _, err := c.c.Repositories.CreateHook(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditHook(ctx context.Context, owner, repo string, id int64, req *github.Hook) (*github.Hook, error) {
+	// PATCH /repos/{owner}/{repo}/hooks/{hook_id}
+	apiObj, _, err := c.c.Repositories.EditHook(ctx, owner, repo, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteHook(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/hooks/{hook_id}
+	_, err := c.c.Repositories.DeleteHook(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) PutFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) error {
+	// PUT /repos/{owner}/{repo}/contents/{path}
+	_, _, err := c.c.Repositories.UpdateFile(ctx, owner, repo, path, opts)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) DeleteFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) error {
+	// DELETE /repos/{owner}/{repo}/contents/{path}
+	_, _, err := c.c.Repositories.DeleteFile(ctx, owner, repo, path, opts)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListTagRefs(ctx context.Context, owner, repo string) ([]*github.Reference, error) {
+	apiObjs := []*github.Reference{}
+	opts := &github.ReferenceListOptions{Ref: "tags"}
+	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/git/matching-refs/tags
+		pageObjs, resp, listErr := c.c.Git.ListMatchingRefs(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateReferenceAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetTagRef(ctx context.Context, owner, repo, tag string) (*github.Reference, error) {
+	// GET /repos/{owner}/{repo}/git/ref/tags/{tag}
+	apiObj, _, err := c.c.Git.GetRef(ctx, owner, repo, "tags/"+tag)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReferenceAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateTagRef(ctx context.Context, owner, repo, tag, sha string) (*github.Reference, error) {
+	// POST /repos/{owner}/{repo}/git/refs
+	apiObj, _, err := c.c.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + tag),
+		Object: &github.GitObject{SHA: &sha},
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReferenceAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteTag(ctx context.Context, owner, repo, tag string) error {
+	// Don't allow deleting tags if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete tag: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repos/{owner}/{repo}/git/refs/tags/{tag}
+	_, err := c.c.Git.DeleteRef(ctx, owner, repo, "tags/"+tag)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetTagObject(ctx context.Context, owner, repo, sha string) (*github.Tag, error) {
+	// GET /repos/{owner}/{repo}/git/tags/{tag_sha}
+	apiObj, _, err := c.c.Git.GetTag(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateTagObject(ctx context.Context, owner, repo string, req *github.Tag) (*github.Tag, error) {
+	// POST /repos/{owner}/{repo}/git/tags
+	apiObj, _, err := c.c.Git.CreateTag(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error) {
+	apiObjs := []*github.RepositoryRelease{}
+	opts := &github.ListOptions{}
+	err := allPages(opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/releases
+		pageObjs, resp, listErr := c.c.Repositories.ListReleases(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateReleaseAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error) {
+	// GET /repos/{owner}/{repo}/releases/tags/{tag}
+	apiObj, _, err := c.c.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateRelease(ctx context.Context, owner, repo string, req *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	// POST /repos/{owner}/{repo}/releases
+	apiObj, _, err := c.c.Repositories.CreateRelease(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditRelease(ctx context.Context, owner, repo string, id int64, req *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	// PATCH /repos/{owner}/{repo}/releases/{release_id}
+	apiObj, _, err := c.c.Repositories.EditRelease(ctx, owner, repo, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/releases/{release_id}
+	_, err := c.c.Repositories.DeleteRelease(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, name string, content []byte) (*github.ReleaseAsset, error) {
+	// go-github only uploads assets from an *os.File, so build the upload request here
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?name=%s", owner, repo, id, url.QueryEscape(name))
+	mediaType := mime.TypeByExtension(path.Ext(name))
+	if mediaType == "" {
+		mediaType = defaultMediaType
+	}
+	req, err := c.c.NewUploadRequest(u, bytes.NewReader(content), int64(len(content)), mediaType)
+	if err != nil {
+		return nil, err
+	}
+	// POST /repos/{owner}/{repo}/releases/{release_id}/assets
+	apiObj := &github.ReleaseAsset{}
+	if _, err := c.c.Do(ctx, req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAssetAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListCommitStatuses(ctx context.Context, owner, repo, ref string) ([]*github.RepoStatus, error) {
+	apiObjs := []*github.RepoStatus{}
+	opts := &github.ListOptions{}
+	err := allPages(opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/commits/{ref}/status
+		combined, resp, listErr := c.c.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+		if combined != nil {
+			apiObjs = append(apiObjs, combined.Statuses...)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateCommitStatusAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateCommitStatus(ctx context.Context, owner, repo, sha string, req *github.RepoStatus) (*github.RepoStatus, error) {
+	// POST /repos/{owner}/{repo}/statuses/{sha}
+	apiObj, _, err := c.c.Repositories.CreateStatus(ctx, owner, repo, sha, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateCommitStatusAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+	apiObjs := []*github.IssueComment{}
+	opts := &github.IssueListCommentsOptions{}
+	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/issues/{issue_number}/comments
+		pageObjs, resp, listErr := c.c.Issues.ListComments(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateIssueCommentAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error) {
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/comments
+	apiObj, _, err := c.c.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &body})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateIssueCommentAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateIssueComment(ctx context.Context, owner, repo string, id int64, body string) (*github.IssueComment, error) {
+	// PATCH /repos/{owner}/{repo}/issues/comments/{comment_id}
+	apiObj, _, err := c.c.Issues.EditComment(ctx, owner, repo, id, &github.IssueComment{Body: &body})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateIssueCommentAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteIssueComment(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/issues/comments/{comment_id}
+	_, err := c.c.Issues.DeleteComment(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) EnablePullRequestAutoMerge(ctx context.Context, nodeID, mergeMethod, commitBody string) error {
+	input := map[string]interface{}{
+		"pullRequestId": nodeID,
+		"mergeMethod":   strings.ToUpper(mergeMethod),
+	}
+	if commitBody != "" {
+		input["commitBody"] = commitBody
+	}
+	return graphQL(ctx, c.c, enablePullRequestAutoMergeMutation, map[string]interface{}{"input": input})
+}
+
+func (c *githubClientImpl) ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	apiObjs := []*github.PullRequestReview{}
+	opts := &github.ListOptions{}
+	err := allPages(opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+		pageObjs, resp, listErr := c.c.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validatePullRequestReviewAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
 func (c *githubClientImpl) GetTeamPermissions(ctx context.Context, orgName, repo, teamName string) (map[string]bool, error) {
 	// GET /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}
 	apiObj, _, err := c.c.Teams.IsTeamRepoBySlug(ctx, orgName, teamName, orgName, repo)
@@ -394,3 +1099,78 @@ func (c *githubClientImpl) RemoveTeam(ctx context.Context, orgName, repo, teamNa
 	_, err := c.c.Teams.RemoveTeamRepoBySlug(ctx, orgName, teamName, orgName, repo)
 	return handleHTTPError(err)
 }
+
+func (c *githubClientImpl) ListCollaborators(ctx context.Context, owner, repo string) ([]*github.User, error) {
+	apiObjs := []*github.User{}
+	opts := &github.ListCollaboratorsOptions{Affiliation: "direct"}
+	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/collaborators
+		pageObjs, resp, listErr := c.c.Repositories.ListCollaborators(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateCollaboratorAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) AddCollaborator(ctx context.Context, owner, repo, login string, permission gitprovider.RepositoryPermission) (*github.CollaboratorInvitation, error) {
+	// PUT /repos/{owner}/{repo}/collaborators/{username}
+	apiObj, _, err := c.c.Repositories.AddCollaborator(ctx, owner, repo, login, &github.RepositoryAddCollaboratorOptions{
+		Permission: string(permission),
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// No invitation is returned when the user already is a collaborator
+	if apiObj == nil || apiObj.ID == nil {
+		return nil, nil
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) RemoveCollaborator(ctx context.Context, owner, repo, login string) error {
+	// DELETE /repos/{owner}/{repo}/collaborators/{username}
+	_, err := c.c.Repositories.RemoveCollaborator(ctx, owner, repo, login)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListRepoInvitations(ctx context.Context, owner, repo string) ([]*github.RepositoryInvitation, error) {
+	apiObjs := []*github.RepositoryInvitation{}
+	opts := &github.ListOptions{}
+	err := allPages(opts, func() (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/invitations
+		pageObjs, resp, listErr := c.c.Repositories.ListInvitations(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateRepositoryInvitationAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) UpdateRepoInvitation(ctx context.Context, owner, repo string, id int64, permission gitprovider.RepositoryPermission) error {
+	// PATCH /repos/{owner}/{repo}/invitations/{invitation_id}
+	_, _, err := c.c.Repositories.UpdateInvitation(ctx, owner, repo, id, invitationPermission(permission))
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) DeleteRepoInvitation(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/invitations/{invitation_id}
+	_, err := c.c.Repositories.DeleteInvitation(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
@@ -459,6 +459,10 @@ var _ = Describe("GitHub Provider", func() {
 		err := userRepo.Branches().Create(ctx, branchName, latestCommit.Get().Sha)
 		Expect(err).ToNot(HaveOccurred())
 
+		branch, err := userRepo.Branches().Get(ctx, branchName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(branch.Get().Sha).To(Equal(latestCommit.Get().Sha))
+
 		err = userRepo.Branches().Create(ctx, branchName, "wrong-sha")
 		Expect(err).To(HaveOccurred())
 
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranchProtection(c *BranchProtectionClient, branch string, apiObj *github.Protection) *branchProtection {
+	return &branchProtection{
+		branch: branch,
+		p:      *apiObj,
+		c:      c,
+	}
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	// branch is the name of the protected branch, which isn't part of the API object
+	branch string
+	p      github.Protection
+	c      *BranchProtectionClient
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	return branchProtectionFromAPI(bp.branch, &bp.p)
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	bp.branch = info.Branch
+	branchProtectionInfoToAPIObj(&info, &bp.p)
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return &bp.p
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(ctx context.Context) error {
+	// PUT /repos/{owner}/{repo}/branches/{branch}/protection
+	apiObj, err := bp.c.c.UpdateBranchProtection(ctx, bp.c.ref.GetIdentity(), bp.c.ref.GetRepository(), bp.branch, protectionToRequest(&bp.p))
+	if err != nil {
+		return err
+	}
+	bp.p = *apiObj
+	return nil
+}
+
+// Delete removes the protection of the branch.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(ctx context.Context) error {
+	// DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+	return bp.c.c.RemoveBranchProtection(ctx, bp.c.ref.GetIdentity(), bp.c.ref.GetRepository(), bp.branch)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (bp *branchProtection) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := bp.c.c.GetBranchProtection(ctx, bp.c.ref.GetIdentity(), bp.c.ref.GetRepository(), bp.branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, bp.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if branchProtectionFromAPI(bp.branch, &bp.p).Equals(branchProtectionFromAPI(bp.branch, actual)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, bp.Update(ctx)
+}
+
+func branchProtectionFromAPI(branch string, apiObj *github.Protection) gitprovider.BranchProtectionInfo {
+	info := gitprovider.BranchProtectionInfo{
+		Branch:            branch,
+		RequiredApprovals: gitprovider.IntVar(0),
+		AllowForcePush:    gitprovider.BoolVar(false),
+		EnforceAdmins:     gitprovider.BoolVar(false),
+	}
+	// All settings are optional parts of the protection, which are omitted when disabled
+	if apiObj.RequiredPullRequestReviews != nil {
+		info.RequiredApprovals = gitprovider.IntVar(apiObj.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+	}
+	if apiObj.RequiredStatusChecks != nil && len(apiObj.RequiredStatusChecks.Contexts) != 0 {
+		// GitHub doesn't guarantee the order of the contexts, sort them like BranchProtectionInfo.Default
+		info.RequiredStatusChecks = append([]string{}, apiObj.RequiredStatusChecks.Contexts...)
+		sort.Strings(info.RequiredStatusChecks)
+	}
+	if apiObj.AllowForcePushes != nil {
+		info.AllowForcePush = gitprovider.BoolVar(apiObj.AllowForcePushes.Enabled)
+	}
+	if apiObj.EnforceAdmins != nil {
+		info.EnforceAdmins = gitprovider.BoolVar(apiObj.EnforceAdmins.Enabled)
+	}
+	return info
+}
+
+func branchProtectionToAPI(info *gitprovider.BranchProtectionInfo) *github.ProtectionRequest {
+	p := &github.Protection{}
+	branchProtectionInfoToAPIObj(info, p)
+	return protectionToRequest(p)
+}
+
+func branchProtectionInfoToAPIObj(info *gitprovider.BranchProtectionInfo, apiObj *github.Protection) {
+	// RequiredStatusChecks is always applied, as an empty list means no required status checks
+	if len(info.RequiredStatusChecks) == 0 {
+		apiObj.RequiredStatusChecks = nil
+	} else {
+		if apiObj.RequiredStatusChecks == nil {
+			apiObj.RequiredStatusChecks = &github.RequiredStatusChecks{}
+		}
+		apiObj.RequiredStatusChecks.Contexts = info.RequiredStatusChecks
+		apiObj.RequiredStatusChecks.Checks = nil
+	}
+	// optional fields
+	if info.RequiredApprovals != nil {
+		if *info.RequiredApprovals == 0 {
+			apiObj.RequiredPullRequestReviews = nil
+		} else {
+			if apiObj.RequiredPullRequestReviews == nil {
+				apiObj.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcement{}
+			}
+			apiObj.RequiredPullRequestReviews.RequiredApprovingReviewCount = *info.RequiredApprovals
+		}
+	}
+	if info.AllowForcePush != nil {
+		apiObj.AllowForcePushes = &github.AllowForcePushes{Enabled: *info.AllowForcePush}
+	}
+	if info.EnforceAdmins != nil {
+		apiObj.EnforceAdmins = &github.AdminEnforcement{Enabled: *info.EnforceAdmins}
+	}
+}
+
+// protectionToRequest converts the protection returned by the server to the request replacing it,
+// such that settings this library doesn't manage, e.g. push restrictions, are kept as they are.
+func protectionToRequest(apiObj *github.Protection) *github.ProtectionRequest {
+	req := &github.ProtectionRequest{}
+	if apiObj.RequiredStatusChecks != nil {
+		req.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict:   apiObj.RequiredStatusChecks.Strict,
+			Contexts: apiObj.RequiredStatusChecks.Contexts,
+		}
+	}
+	if r := apiObj.RequiredPullRequestReviews; r != nil {
+		req.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          r.DismissStaleReviews,
+			RequireCodeOwnerReviews:      r.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: r.RequiredApprovingReviewCount,
+		}
+	}
+	if apiObj.EnforceAdmins != nil {
+		req.EnforceAdmins = apiObj.EnforceAdmins.Enabled
+	}
+	if r := apiObj.Restrictions; r != nil {
+		req.Restrictions = &github.BranchRestrictionsRequest{Users: []string{}, Teams: []string{}}
+		for _, user := range r.Users {
+			req.Restrictions.Users = append(req.Restrictions.Users, user.GetLogin())
+		}
+		for _, team := range r.Teams {
+			req.Restrictions.Teams = append(req.Restrictions.Teams, team.GetSlug())
+		}
+		for _, app := range r.Apps {
+			req.Restrictions.Apps = append(req.Restrictions.Apps, app.GetSlug())
+		}
+	}
+	if apiObj.RequireLinearHistory != nil {
+		req.RequireLinearHistory = gitprovider.BoolVar(apiObj.RequireLinearHistory.Enabled)
+	}
+	if apiObj.AllowForcePushes != nil {
+		req.AllowForcePushes = gitprovider.BoolVar(apiObj.AllowForcePushes.Enabled)
+	}
+	if apiObj.AllowDeletions != nil {
+		req.AllowDeletions = gitprovider.BoolVar(apiObj.AllowDeletions.Enabled)
+	}
+	if apiObj.RequiredConversationResolution != nil {
+		req.RequiredConversationResolution = gitprovider.BoolVar(apiObj.RequiredConversationResolution.Enabled)
+	}
+	return req
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_branchProtectionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		info gitprovider.BranchProtectionInfo
+	}{
+		{
+			name: "defaults",
+			info: gitprovider.BranchProtectionInfo{Branch: "main"},
+		},
+		{
+			name: "all settings",
+			info: gitprovider.BranchProtectionInfo{
+				Branch:               "main",
+				RequiredApprovals:    gitprovider.IntVar(2),
+				RequiredStatusChecks: []string{"ci/build", "ci/test"},
+				AllowForcePush:       gitprovider.BoolVar(true),
+				EnforceAdmins:        gitprovider.BoolVar(true),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.Default()
+			apiObj := &github.Protection{}
+			branchProtectionInfoToAPIObj(&tt.info, apiObj)
+			if got := branchProtectionFromAPI(tt.info.Branch, apiObj); !reflect.DeepEqual(got, tt.info) {
+				t.Errorf("branchProtectionFromAPI() = %+v, want %+v", got, tt.info)
+			}
+		})
+	}
+}
+
+func Test_branchProtectionFromAPI_statusCheckOrder(t *testing.T) {
+	desired := gitprovider.BranchProtectionInfo{
+		Branch:               "main",
+		RequiredStatusChecks: []string{"ci/build", "ci/test"},
+	}
+	desired.Default()
+	// The server may list the contexts in any order
+	apiObj := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"ci/test", "ci/build"}},
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: true},
+	}
+	if got := branchProtectionFromAPI("main", apiObj); !desired.Equals(got) {
+		t.Errorf("branchProtectionFromAPI() = %+v, want %+v", got, desired)
+	}
+	if contexts := apiObj.RequiredStatusChecks.Contexts; contexts[0] != "ci/test" {
+		t.Errorf("branchProtectionFromAPI() modified the API object: %v", contexts)
+	}
+}
+
+func Test_protectionToRequest(t *testing.T) {
+	apiObj := &github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			DismissStaleReviews:          true,
+			RequiredApprovingReviewCount: 1,
+		},
+		EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+		Restrictions: &github.BranchRestrictions{
+			Users: []*github.User{{Login: gitprovider.StringVar("alice")}},
+			Teams: []*github.Team{{Slug: gitprovider.StringVar("maintainers")}},
+		},
+		AllowDeletions: &github.AllowDeletions{Enabled: false},
+	}
+	want := &github.ProtectionRequest{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          true,
+			RequiredApprovingReviewCount: 1,
+		},
+		EnforceAdmins: true,
+		Restrictions: &github.BranchRestrictionsRequest{
+			Users: []string{"alice"},
+			Teams: []string{"maintainers"},
+		},
+		AllowDeletions: gitprovider.BoolVar(false),
+	}
+	if got := protectionToRequest(apiObj); !reflect.DeepEqual(got, want) {
+		t.Errorf("protectionToRequest() = %+v, want %+v", got, want)
+	}
+}
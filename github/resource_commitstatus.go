@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newCommitStatus(c *CommitStatusClient, apiObj *github.RepoStatus) *commitStatus {
+	return &commitStatus{
+		s: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.CommitStatus = &commitStatus{}
+
+type commitStatus struct {
+	s github.RepoStatus
+	c *CommitStatusClient
+}
+
+func (s *commitStatus) Get() gitprovider.CommitStatusInfo {
+	return commitStatusFromAPI(&s.s)
+}
+
+func (s *commitStatus) APIObject() interface{} {
+	return &s.s
+}
+
+func validateCommitStatusAPI(apiObj *github.RepoStatus) error {
+	return validateAPIObject("GitHub.RepoStatus", func(validator validation.Validator) {
+		// Make sure state and context are populated as per
+		// https://docs.github.com/en/rest/commits/statuses#create-a-commit-status
+		if apiObj.State == nil {
+			validator.Required("State")
+		}
+		if apiObj.Context == nil {
+			validator.Required("Context")
+		}
+	})
+}
+
+// commitStatusFromAPI converts a GitHub commit status to a CommitStatusInfo. The GitHub states
+// pending, success, failure and error map to the gitprovider states of the same name.
+func commitStatusFromAPI(apiObj *github.RepoStatus) gitprovider.CommitStatusInfo {
+	return gitprovider.CommitStatusInfo{
+		State:       gitprovider.CommitStatusState(*apiObj.State),
+		Context:     *apiObj.Context,
+		Description: apiObj.Description,
+		TargetURL:   apiObj.TargetURL,
+	}
+}
+
+func commitStatusToAPI(info *gitprovider.CommitStatusInfo) *github.RepoStatus {
+	state := string(info.State)
+	return &github.RepoStatus{
+		State:       &state,
+		Context:     &info.Context,
+		Description: info.Description,
+		TargetURL:   info.TargetURL,
+	}
+}
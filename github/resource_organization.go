@@ -17,6 +17,9 @@ limitations under the License.
 package github
 
 import (
+	"context"
+	"errors"
+
 	"github.com/google/go-github/v47/github"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -50,6 +53,17 @@ func (o *organization) Get() gitprovider.OrganizationInfo {
 	return organizationFromAPI(&o.o)
 }
 
+// Set sets the desired state of this object.
+// User have to call Update() to apply the changes to the server.
+// The changes will then be reflected in the internal API object.
+func (o *organization) Set(info gitprovider.OrganizationInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	organizationInfoToAPIObj(&info, &o.o)
+	return nil
+}
+
 func (o *organization) APIObject() interface{} {
 	return &o.o
 }
@@ -62,6 +76,71 @@ func (o *organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (o *organization) Update(ctx context.Context) error {
+	// PATCH /orgs/{org}
+	apiObj, err := o.c.UpdateOrg(ctx, o.ref.Organization, &github.Organization{
+		Name:        o.o.Name,
+		Description: o.o.Description,
+	})
+	if err != nil {
+		return err
+	}
+	o.o = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (o *organization) Reconcile(ctx context.Context) (bool, error) {
+	// GET /orgs/{org}
+	actual, err := o.c.GetOrg(ctx, o.ref.Organization)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			apiObj, err := createOrganization(ctx, o.clientContext, o.ref, o.Get())
+			if err != nil {
+				return true, err
+			}
+			o.o = *apiObj
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if o.Get().Equals(organizationFromAPI(actual)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, o.Update(ctx)
+}
+
+// Delete deletes the organization, including all of its repositories.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (o *organization) Delete(ctx context.Context) error {
+	// DELETE /orgs/{org}
+	return o.c.DeleteOrg(ctx, o.ref.Organization)
+}
+
 func organizationFromAPI(apiObj *github.Organization) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        apiObj.Name,
@@ -69,6 +148,16 @@ func organizationFromAPI(apiObj *github.Organization) gitprovider.OrganizationIn
 	}
 }
 
+func organizationInfoToAPIObj(info *gitprovider.OrganizationInfo, apiObj *github.Organization) {
+	// optional fields
+	if info.Name != nil {
+		apiObj.Name = info.Name
+	}
+	if info.Description != nil {
+		apiObj.Description = info.Description
+	}
+}
+
 // validateOrganizationAPI validates the apiObj received from the server, to make sure that it is
 // valid for our use.
 func validateOrganizationAPI(apiObj *github.Organization) error {
@@ -21,10 +21,20 @@ import (
 	"github.com/google/go-github/v47/github"
 )
 
-func newPullRequest(ctx *clientContext, apiObj *github.PullRequest) *pullrequest {
+func newPullRequest(ctx *clientContext, ref gitprovider.RepositoryRef, apiObj *github.PullRequest) *pullrequest {
 	return &pullrequest{
 		clientContext: ctx,
 		pr:            *apiObj,
+		comments: &PullRequestCommentClient{
+			clientContext: ctx,
+			ref:           ref,
+			number:        apiObj.GetNumber(),
+		},
+		reviews: &PullRequestReviewClient{
+			clientContext: ctx,
+			ref:           ref,
+			number:        apiObj.GetNumber(),
+		},
 	}
 }
 
@@ -34,6 +44,9 @@ type pullrequest struct {
 	*clientContext
 
 	pr github.PullRequest
+
+	comments *PullRequestCommentClient
+	reviews  *PullRequestReviewClient
 }
 
 func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
@@ -44,10 +57,41 @@ func (pr *pullrequest) APIObject() interface{} {
 	return &pr.pr
 }
 
+// Comments gives access to the general comments of this pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return pr.comments
+}
+
+// Reviews gives access to the reviews of this pull request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return pr.reviews
+}
+
+// pullrequestFromAPI converts a GitHub pull request to a PullRequestInfo. Listed pull requests
+// don't report whether they are merged or mergeable, hence Merged is derived from MergedAt.
 func pullrequestFromAPI(apiObj *github.PullRequest) gitprovider.PullRequestInfo {
-	return gitprovider.PullRequestInfo{
-		Merged: apiObj.GetMerged(),
-		Number: apiObj.GetNumber(),
-		WebURL: apiObj.GetHTMLURL(),
+	info := gitprovider.PullRequestInfo{
+		Merged:       apiObj.GetMerged() || apiObj.MergedAt != nil,
+		Number:       apiObj.GetNumber(),
+		WebURL:       apiObj.GetHTMLURL(),
+		Title:        apiObj.GetTitle(),
+		Description:  apiObj.GetBody(),
+		State:        gitprovider.PullRequestStateOpen,
+		Draft:        apiObj.GetDraft(),
+		SourceBranch: apiObj.GetHead().GetRef(),
+		TargetBranch: apiObj.GetBase().GetRef(),
+		HeadSHA:      apiObj.GetHead().GetSHA(),
+		BaseSHA:      apiObj.GetBase().GetSHA(),
+		Author:       apiObj.GetUser().GetLogin(),
+		CreatedAt:    apiObj.GetCreatedAt(),
+		UpdatedAt:    apiObj.GetUpdatedAt(),
+		Mergeable:    apiObj.Mergeable,
+	}
+	if apiObj.GetState() == "closed" {
+		info.State = gitprovider.PullRequestStateClosed
+		if info.Merged {
+			info.State = gitprovider.PullRequestStateMerged
+		}
 	}
+	return info
 }
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newPullRequestComment(c *PullRequestCommentClient, apiObj *github.IssueComment) *pullRequestComment {
+	return &pullRequestComment{
+		cm: *apiObj,
+		c:  c,
+	}
+}
+
+var _ gitprovider.PullRequestComment = &pullRequestComment{}
+
+type pullRequestComment struct {
+	cm github.IssueComment
+	c  *PullRequestCommentClient
+}
+
+func (cm *pullRequestComment) Get() gitprovider.PullRequestCommentInfo {
+	return pullRequestCommentFromAPI(&cm.cm)
+}
+
+func (cm *pullRequestComment) APIObject() interface{} {
+	return &cm.cm
+}
+
+func validateIssueCommentAPI(apiObj *github.IssueComment) error {
+	return validateAPIObject("GitHub.IssueComment", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+	})
+}
+
+func pullRequestCommentFromAPI(apiObj *github.IssueComment) gitprovider.PullRequestCommentInfo {
+	return gitprovider.PullRequestCommentInfo{
+		ID:        apiObj.GetID(),
+		Body:      apiObj.GetBody(),
+		Author:    apiObj.GetUser().GetLogin(),
+		CreatedAt: apiObj.GetCreatedAt(),
+		UpdatedAt: apiObj.GetUpdatedAt(),
+	}
+}
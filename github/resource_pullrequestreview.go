@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const reviewStateCommented = "COMMENTED"
+
+// pullRequestReviewStates maps the GitHub review states to their gitprovider counterparts.
+//
+//nolint:gochecknoglobals
+var pullRequestReviewStates = map[string]gitprovider.PullRequestReviewState{
+	"APPROVED":           gitprovider.PullRequestReviewStateApproved,
+	"CHANGES_REQUESTED":  gitprovider.PullRequestReviewStateChangesRequested,
+	reviewStateCommented: gitprovider.PullRequestReviewStateCommented,
+	"DISMISSED":          gitprovider.PullRequestReviewStateDismissed,
+	"PENDING":            gitprovider.PullRequestReviewStatePending,
+}
+
+func newPullRequestReview(c *PullRequestReviewClient, apiObj *github.PullRequestReview) *pullRequestReview {
+	return &pullRequestReview{
+		r: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+type pullRequestReview struct {
+	r github.PullRequestReview
+	c *PullRequestReviewClient
+}
+
+func (r *pullRequestReview) Get() gitprovider.PullRequestReviewInfo {
+	return pullRequestReviewFromAPI(&r.r)
+}
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return &r.r
+}
+
+func validatePullRequestReviewAPI(apiObj *github.PullRequestReview) error {
+	return validateAPIObject("GitHub.PullRequestReview", func(validator validation.Validator) {
+		if apiObj.State == nil {
+			validator.Required("State")
+		}
+	})
+}
+
+func pullRequestReviewFromAPI(apiObj *github.PullRequestReview) gitprovider.PullRequestReviewInfo {
+	return gitprovider.PullRequestReviewInfo{
+		Reviewer:    apiObj.GetUser().GetLogin(),
+		State:       pullRequestReviewStates[apiObj.GetState()],
+		SubmittedAt: apiObj.GetSubmittedAt(),
+	}
+}
+
+// latestReviews reduces the chronological list of reviews to the current review of each
+// reviewer. Like in the GitHub UI, a later comment-only review doesn't replace an earlier
+// approval or change request.
+func latestReviews(apiObjs []*github.PullRequestReview) []*github.PullRequestReview {
+	latest := make([]*github.PullRequestReview, 0, len(apiObjs))
+	index := map[string]int{}
+	for _, apiObj := range apiObjs {
+		login := apiObj.GetUser().GetLogin()
+		i, ok := index[login]
+		if !ok {
+			index[login] = len(latest)
+			latest = append(latest, apiObj)
+			continue
+		}
+		if apiObj.GetState() == reviewStateCommented && latest[i].GetState() != reviewStateCommented {
+			continue
+		}
+		latest[i] = apiObj
+	}
+	return latest
+}
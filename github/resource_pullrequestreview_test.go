@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_latestReviews(t *testing.T) {
+	review := func(login, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{
+			User:  &github.User{Login: github.String(login)},
+			State: github.String(state),
+		}
+	}
+	tests := []struct {
+		name    string
+		reviews []*github.PullRequestReview
+		want    []gitprovider.PullRequestReviewState
+	}{
+		{
+			name:    "later approval replaces change request",
+			reviews: []*github.PullRequestReview{review("alice", "CHANGES_REQUESTED"), review("alice", "APPROVED")},
+			want:    []gitprovider.PullRequestReviewState{gitprovider.PullRequestReviewStateApproved},
+		},
+		{
+			name:    "later comment keeps approval",
+			reviews: []*github.PullRequestReview{review("alice", "APPROVED"), review("alice", "COMMENTED")},
+			want:    []gitprovider.PullRequestReviewState{gitprovider.PullRequestReviewStateApproved},
+		},
+		{
+			name:    "dismissal replaces approval",
+			reviews: []*github.PullRequestReview{review("alice", "APPROVED"), review("alice", "DISMISSED")},
+			want:    []gitprovider.PullRequestReviewState{gitprovider.PullRequestReviewStateDismissed},
+		},
+		{
+			name:    "one review per reviewer in order",
+			reviews: []*github.PullRequestReview{review("alice", "COMMENTED"), review("bob", "APPROVED"), review("alice", "COMMENTED")},
+			want: []gitprovider.PullRequestReviewState{
+				gitprovider.PullRequestReviewStateCommented,
+				gitprovider.PullRequestReviewStateApproved,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []gitprovider.PullRequestReviewState{}
+			for _, apiObj := range latestReviews(tt.reviews) {
+				got = append(got, pullRequestReviewFromAPI(apiObj).State)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("latestReviews() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newRelease(c *ReleaseClient, apiObj *github.RepositoryRelease) *release {
+	return &release{
+		r: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Release = &release{}
+
+type release struct {
+	r github.RepositoryRelease
+	c *ReleaseClient
+}
+
+func (r *release) Get() gitprovider.ReleaseInfo {
+	return releaseFromAPI(&r.r)
+}
+
+func (r *release) APIObject() interface{} {
+	return &r.r
+}
+
+func validateReleaseAPI(apiObj *github.RepositoryRelease) error {
+	return validateAPIObject("GitHub.RepositoryRelease", func(validator validation.Validator) {
+		// Make sure ID and tag name are populated as per
+		// https://docs.github.com/en/rest/releases/releases#get-a-release
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.TagName == nil {
+			validator.Required("TagName")
+		}
+		for _, asset := range apiObj.Assets {
+			if asset.Name == nil {
+				validator.Required("Assets.Name")
+			}
+		}
+	})
+}
+
+func validateReleaseAssetAPI(apiObj *github.ReleaseAsset) error {
+	return validateAPIObject("GitHub.ReleaseAsset", func(validator validation.Validator) {
+		// Make sure the name is populated as per
+		// https://docs.github.com/en/rest/releases/assets#get-a-release-asset
+		if apiObj.Name == nil {
+			validator.Required("Name")
+		}
+	})
+}
+
+func releaseFromAPI(apiObj *github.RepositoryRelease) gitprovider.ReleaseInfo {
+	info := gitprovider.ReleaseInfo{
+		TagName:    *apiObj.TagName,
+		Target:     apiObj.TargetCommitish,
+		Name:       apiObj.Name,
+		Notes:      apiObj.Body,
+		Draft:      apiObj.Draft,
+		Prerelease: apiObj.Prerelease,
+	}
+	for _, asset := range apiObj.Assets {
+		info.Assets = append(info.Assets, releaseAssetFromAPI(asset))
+	}
+	return info
+}
+
+func releaseAssetFromAPI(apiObj *github.ReleaseAsset) gitprovider.ReleaseAsset {
+	return gitprovider.ReleaseAsset{
+		Name: *apiObj.Name,
+		URL:  apiObj.GetBrowserDownloadURL(),
+		Size: apiObj.GetSize(),
+	}
+}
+
+// releaseToAPI returns the request body for creating or editing a release. Unset fields are
+// omitted, and hence left unchanged when editing.
+func releaseToAPI(info *gitprovider.ReleaseInfo) *github.RepositoryRelease {
+	apiObj := &github.RepositoryRelease{
+		TargetCommitish: info.Target,
+		Name:            info.Name,
+		Body:            info.Notes,
+		Draft:           info.Draft,
+		Prerelease:      info.Prerelease,
+	}
+	if info.TagName != "" {
+		apiObj.TagName = &info.TagName
+	}
+	return apiObj
+}
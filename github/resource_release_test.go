@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_releaseRoundTrip(t *testing.T) {
+	info := gitprovider.ReleaseInfo{
+		TagName:    "v1.0.0",
+		Target:     gitprovider.StringVar("main"),
+		Name:       gitprovider.StringVar("v1.0.0"),
+		Notes:      gitprovider.StringVar("Fixes"),
+		Draft:      gitprovider.BoolVar(false),
+		Prerelease: gitprovider.BoolVar(true),
+	}
+	if got := releaseFromAPI(releaseToAPI(&info)); !reflect.DeepEqual(got, info) {
+		t.Errorf("releaseFromAPI() = %+v, want %+v", got, info)
+	}
+
+	// Unset fields are omitted when editing a release
+	want := &github.RepositoryRelease{Body: gitprovider.StringVar("Fixes")}
+	if got := releaseToAPI(&gitprovider.ReleaseInfo{Notes: gitprovider.StringVar("Fixes")}); !reflect.DeepEqual(got, want) {
+		t.Errorf("releaseToAPI() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_tagFromAPI(t *testing.T) {
+	ref := &github.Reference{
+		Ref:    github.String("refs/tags/v1.0.0"),
+		Object: &github.GitObject{Type: github.String("commit"), SHA: github.String("abc")},
+	}
+	want := gitprovider.TagInfo{Name: "v1.0.0", Sha: "abc"}
+	if got := tagFromAPI(lightweightTag("v1.0.0", ref)); !reflect.DeepEqual(got, want) {
+		t.Errorf("tagFromAPI() = %+v, want %+v", got, want)
+	}
+
+	// The message of annotated tags is reported without the trailing newline
+	annotated := &github.Tag{
+		Tag:     github.String("v1.0.0"),
+		SHA:     github.String("def"),
+		Message: github.String("Release v1.0.0\n"),
+		Object:  &github.GitObject{Type: github.String("commit"), SHA: github.String("abc")},
+	}
+	want.Message = gitprovider.StringVar("Release v1.0.0")
+	if got := tagFromAPI(annotated); !reflect.DeepEqual(got, want) {
+		t.Errorf("tagFromAPI() = %+v, want %+v", got, want)
+	}
+}
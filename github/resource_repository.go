@@ -62,6 +62,10 @@ func newUserRepository(ctx *clientContext, apiObj *github.Repository, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		userAccess: &UserAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -70,10 +74,30 @@ func newUserRepository(ctx *clientContext, apiObj *github.Repository, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		statuses: &CommitStatusClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		files: &FileClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -94,12 +118,18 @@ type userRepository struct {
 	topUpdate *github.Repository
 	ref       gitprovider.RepositoryRef
 
-	deployKeys   *DeployKeyClient
-	commits      *CommitClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	files        *FileClient
-	trees        *TreeClient
+	deployKeys        *DeployKeyClient
+	userAccess        *UserAccessClient
+	commits           *CommitClient
+	branches          *BranchClient
+	branchProtections *BranchProtectionClient
+	webhooks          *WebhookClient
+	pullRequests      *PullRequestClient
+	tags              *TagClient
+	releases          *ReleaseClient
+	statuses          *CommitStatusClient
+	files             *FileClient
+	trees             *TreeClient
 }
 
 func (r *userRepository) Get() gitprovider.RepositoryInfo {
@@ -129,6 +159,10 @@ func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
 	return r.deployKeys
 }
 
+func (r *userRepository) UserAccess() gitprovider.UserAccessClient {
+	return r.userAccess
+}
+
 func (r *userRepository) Commits() gitprovider.CommitClient {
 	return r.commits
 }
@@ -137,10 +171,30 @@ func (r *userRepository) Branches() gitprovider.BranchClient {
 	return r.branches
 }
 
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
 func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
 	return r.pullRequests
 }
 
+func (r *userRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Statuses() gitprovider.CommitStatusClient {
+	return r.statuses
+}
+
 func (r *userRepository) Files() gitprovider.FileClient {
 	return r.files
 }
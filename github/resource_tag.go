@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// gitObjectTypeTag is the type of the Git object an annotated tag reference points to.
+const gitObjectTypeTag = "tag"
+
+func newTag(c *TagClient, apiObj *github.Tag) *tagType {
+	return &tagType{
+		t: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Tag = &tagType{}
+
+// tagType wraps the Git tag object of an annotated tag. Lightweight tags have no tag object,
+// their github.Tag holds the commit the tag points to and no message.
+type tagType struct {
+	t github.Tag
+	c *TagClient
+}
+
+func (t *tagType) Get() gitprovider.TagInfo {
+	return tagFromAPI(&t.t)
+}
+
+func (t *tagType) APIObject() interface{} {
+	return &t.t
+}
+
+// lightweightTag returns the github.Tag of a reference to a commit.
+func lightweightTag(name string, ref *github.Reference) *github.Tag {
+	return &github.Tag{
+		Tag:    &name,
+		SHA:    ref.Object.SHA,
+		Object: ref.Object,
+	}
+}
+
+func validateReferenceAPI(apiObj *github.Reference) error {
+	return validateAPIObject("GitHub.Reference", func(validator validation.Validator) {
+		// Make sure the ref and the object it points to are populated as per
+		// https://docs.github.com/en/rest/git/refs#get-a-reference
+		if apiObj.Ref == nil {
+			validator.Required("Ref")
+		}
+		if apiObj.Object == nil || apiObj.Object.SHA == nil || apiObj.Object.Type == nil {
+			validator.Required("Object")
+		}
+	})
+}
+
+func validateTagAPI(apiObj *github.Tag) error {
+	return validateAPIObject("GitHub.Tag", func(validator validation.Validator) {
+		// Make sure name and tagged object are populated as per
+		// https://docs.github.com/en/rest/git/tags#get-a-tag
+		if apiObj.SHA == nil {
+			validator.Required("SHA")
+		}
+		if apiObj.Tag == nil {
+			validator.Required("Tag")
+		}
+		if apiObj.Object == nil || apiObj.Object.SHA == nil {
+			validator.Required("Object.SHA")
+		}
+	})
+}
+
+func tagFromAPI(apiObj *github.Tag) gitprovider.TagInfo {
+	info := gitprovider.TagInfo{
+		Name: *apiObj.Tag,
+		Sha:  *apiObj.Object.SHA,
+	}
+	if apiObj.Message != nil {
+		// Git terminates tag messages with a newline
+		info.Message = gitprovider.StringVar(strings.TrimSuffix(*apiObj.Message, "\n"))
+	}
+	return info
+}
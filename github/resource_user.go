@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUser(ctx *clientContext, apiObj *github.User) *user {
+	return &user{
+		clientContext: ctx,
+		u:             *apiObj,
+	}
+}
+
+var _ gitprovider.User = &user{}
+
+type user struct {
+	*clientContext
+
+	u github.User
+}
+
+func (u *user) Get() gitprovider.UserInfo {
+	return userFromAPI(&u.u)
+}
+
+func (u *user) APIObject() interface{} {
+	return &u.u
+}
+
+func (u *user) User() gitprovider.UserRef {
+	return gitprovider.UserRef{
+		Domain:    u.domain,
+		UserLogin: u.u.GetLogin(),
+	}
+}
+
+func validateUserAPI(apiObj *github.User) error {
+	return validateAPIObject("GitHub.User", func(validator validation.Validator) {
+		// Make sure login is populated as per
+		// https://docs.github.com/en/rest/users/users#get-a-user
+		if apiObj.Login == nil {
+			validator.Required("Login")
+		}
+	})
+}
+
+func userFromAPI(apiObj *github.User) gitprovider.UserInfo {
+	return gitprovider.UserInfo{
+		Login:     apiObj.GetLogin(),
+		Name:      apiObj.GetName(),
+		Email:     apiObj.GetEmail(),
+		AvatarURL: apiObj.GetAvatarURL(),
+		WebURL:    apiObj.GetHTMLURL(),
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_user(t *testing.T) {
+	apiObj := &github.User{
+		Login:     github.String("octocat"),
+		Name:      github.String("The Octocat"),
+		AvatarURL: github.String("https://avatars.githubusercontent.com/u/583231"),
+		HTMLURL:   github.String("https://github.com/octocat"),
+	}
+	u := newUser(&clientContext{domain: "github.com"}, apiObj)
+
+	want := gitprovider.UserInfo{
+		Login:     "octocat",
+		Name:      "The Octocat",
+		AvatarURL: "https://avatars.githubusercontent.com/u/583231",
+		WebURL:    "https://github.com/octocat",
+	}
+	if diff := cmp.Diff(want, u.Get()); diff != "" {
+		t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+	}
+	wantRef := gitprovider.UserRef{Domain: "github.com", UserLogin: "octocat"}
+	if got := u.User(); got != wantRef {
+		t.Errorf("User() = %v, want %v", got, wantRef)
+	}
+	if err := validateUserAPI(&github.User{}); err == nil {
+		t.Error("validateUserAPI() for user without login returned no error")
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUserAccess(c *UserAccessClient, ua gitprovider.UserAccessInfo, apiObj interface{}, invitationID *int64) *userAccess {
+	return &userAccess{
+		ua:           ua,
+		apiObj:       apiObj,
+		invitationID: invitationID,
+		c:            c,
+	}
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	ua gitprovider.UserAccessInfo
+	// apiObj is either a *github.User, a *github.CollaboratorInvitation,
+	// a *github.RepositoryInvitation or nil.
+	apiObj interface{}
+	// invitationID is set if the user has a pending invitation to the repository.
+	invitationID *int64
+	c            *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return ua.ua
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ua.ua = info
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return ua.apiObj
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited returns true if the user hasn't accepted the invitation to the repository yet.
+func (ua *userAccess) Invited() bool {
+	return ua.invitationID != nil
+}
+
+// Delete removes the given user from the repo's collaborators, or revokes the pending invitation.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(ctx context.Context) error {
+	if ua.invitationID != nil {
+		// DELETE /repos/{owner}/{repo}/invitations/{invitation_id}
+		return ua.c.c.DeleteRepoInvitation(ctx, ua.c.ref.GetIdentity(), ua.c.ref.GetRepository(), *ua.invitationID)
+	}
+	// DELETE /repos/{owner}/{repo}/collaborators/{username}
+	return ua.c.c.RemoveCollaborator(ctx, ua.c.ref.GetIdentity(), ua.c.ref.GetRepository(), ua.ua.Login)
+}
+
+// Update sets the permission of the collaborator, or of the pending invitation, to the desired permission.
+func (ua *userAccess) Update(ctx context.Context) error {
+	if ua.invitationID != nil {
+		// PATCH /repos/{owner}/{repo}/invitations/{invitation_id}
+		return ua.c.c.UpdateRepoInvitation(ctx, ua.c.ref.GetIdentity(), ua.c.ref.GetRepository(), *ua.invitationID, *ua.ua.Permission)
+	}
+	// Update the actual state to be the desired state
+	// by issuing a Create, which uses a PUT underneath.
+	resp, err := ua.c.Create(ctx, ua.Get())
+	if err != nil {
+		return err
+	}
+	ua.invitationID = resp.(*userAccess).invitationID
+	return ua.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ua.Get()
+	actual, err := ua.c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			ua.invitationID = nil
+			return true, ua.Update(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+	ua.invitationID = actual.(*userAccess).invitationID
+
+	// If the desired matches the actual state, just return the actual state
+	req.Login = actual.Get().Login
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ua.Update(ctx)
+}
+
+func validateCollaboratorAPI(apiObj *github.User) error {
+	return validateAPIObject("GitHub.User", func(validator validation.Validator) {
+		// Make sure login and permissions are populated as per
+		// https://docs.github.com/en/rest/collaborators/collaborators#list-repository-collaborators
+		if apiObj.Login == nil {
+			validator.Required("Login")
+		}
+		if apiObj.Permissions == nil {
+			validator.Required("Permissions")
+		}
+	})
+}
+
+func validateRepositoryInvitationAPI(apiObj *github.RepositoryInvitation) error {
+	return validateAPIObject("GitHub.RepositoryInvitation", func(validator validation.Validator) {
+		// Make sure ID, invitee and permissions are populated as per
+		// https://docs.github.com/en/rest/collaborators/invitations#list-repository-invitations
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Invitee == nil || apiObj.Invitee.Login == nil {
+			validator.Required("Invitee.Login")
+		}
+		if apiObj.Permissions == nil {
+			validator.Required("Permissions")
+		}
+	})
+}
+
+// invitationPermission returns the name GitHub uses for the given permission in invitations.
+func invitationPermission(permission gitprovider.RepositoryPermission) string {
+	switch permission {
+	case gitprovider.RepositoryPermissionPull:
+		return "read"
+	case gitprovider.RepositoryPermissionPush:
+		return "write"
+	}
+	return string(permission)
+}
+
+// getPermissionFromInvitation is the inverse of invitationPermission.
+func getPermissionFromInvitation(permission string) *gitprovider.RepositoryPermission {
+	var p gitprovider.RepositoryPermission
+	switch strings.ToLower(permission) {
+	case "read":
+		p = gitprovider.RepositoryPermissionPull
+	case "write":
+		p = gitprovider.RepositoryPermissionPush
+	default:
+		p = gitprovider.RepositoryPermission(strings.ToLower(permission))
+		if _, ok := permissionPriority[p]; !ok {
+			return nil
+		}
+	}
+	return &p
+}
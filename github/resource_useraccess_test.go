@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_getPermissionFromInvitation(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		want       *gitprovider.RepositoryPermission
+	}{
+		{
+			name:       "read",
+			permission: "read",
+			want:       gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPull),
+		},
+		{
+			name:       "write",
+			permission: "write",
+			want:       gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPush),
+		},
+		{
+			name:       "maintain",
+			permission: "maintain",
+			want:       gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionMaintain),
+		},
+		{
+			name:       "admin",
+			permission: "admin",
+			want:       gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionAdmin),
+		},
+		{
+			name:       "invalid",
+			permission: "invalid",
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPermission := getPermissionFromInvitation(tt.permission)
+			if !reflect.DeepEqual(gotPermission, tt.want) {
+				t.Errorf("getPermissionFromInvitation() = %v, want %v", gotPermission, tt.want)
+			}
+			if tt.want != nil {
+				if got := getPermissionFromInvitation(invitationPermission(*tt.want)); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("getPermissionFromInvitation(invitationPermission()) = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
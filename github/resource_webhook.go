@@ -0,0 +1,275 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// maskedWebhookSecret is returned by GitHub in place of the secret of a webhook.
+	maskedWebhookSecret = "********"
+	// webhookContentTypeForm is the default content type of GitHub webhooks.
+	webhookContentTypeForm = "form"
+)
+
+// githubWebhookEvents maps the events supported by GitHub webhooks to their GitHub names.
+// GitHub includes tag pushes in push events, hence WebhookEventTagPush isn't supported.
+//nolint:gochecknoglobals
+var githubWebhookEvents = map[gitprovider.WebhookEvent]string{
+	gitprovider.WebhookEventPush:        "push",
+	gitprovider.WebhookEventPullRequest: "pull_request",
+	gitprovider.WebhookEventRelease:     "release",
+}
+
+func newWebhook(c *WebhookClient, hook *github.Hook) *webhook {
+	return &webhook{
+		h: *hook,
+		c: c,
+	}
+}
+
+var _ gitprovider.Webhook = &webhook{}
+
+type webhook struct {
+	h github.Hook
+	c *WebhookClient
+}
+
+func (wh *webhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *webhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookInfo(info); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	return nil
+}
+
+func (wh *webhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *webhook) Repository() gitprovider.RepositoryRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *webhook) Update(ctx context.Context) error {
+	// We can use the same webhook ID that we got from the GET calls. Make sure it's non-nil.
+	// This _should never_ happen, but just check for it anyways to avoid panicing.
+	if wh.h.ID == nil {
+		return fmt.Errorf("didn't expect ID to be nil: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	// PATCH /repos/{owner}/{repo}/hooks/{hook_id}
+	apiObj, err := wh.c.c.EditHook(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), *wh.h.ID, webhookUpdateRequest(&wh.h))
+	if err != nil {
+		return err
+	}
+	wh.h = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *webhook) Delete(ctx context.Context) error {
+	// We can use the same webhook ID that we got from the GET calls. Make sure it's non-nil.
+	// This _should never_ happen, but just check for it anyways to avoid panicing.
+	if wh.h.ID == nil {
+		return fmt.Errorf("didn't expect ID to be nil: %w", gitprovider.ErrUnexpectedEvent)
+	}
+
+	// DELETE /repos/{owner}/{repo}/hooks/{hook_id}
+	return wh.c.c.DeleteHook(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), *wh.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (wh *webhook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := wh.c.get(ctx, webhookURL(&wh.h))
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, wh.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if webhookFromAPI(&wh.h).Equals(webhookFromAPI(&actual.h)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update the actual webhook
+	wh.h.ID = actual.h.ID
+	return true, wh.Update(ctx)
+}
+
+func (wh *webhook) createIntoSelf(ctx context.Context) error {
+	// POST /repos/{owner}/{repo}/hooks
+	apiObj, err := wh.c.c.CreateHook(ctx, wh.c.ref.GetIdentity(), wh.c.ref.GetRepository(), &wh.h)
+	if err != nil {
+		return err
+	}
+	wh.h = *apiObj
+	return nil
+}
+
+func validateWebhookAPI(apiObj *github.Hook) error {
+	return validateAPIObject("GitHub.Hook", func(validator validation.Validator) {
+		// Make sure ID and the URL in the config are populated as per
+		// https://docs.github.com/en/rest/webhooks/repos#get-a-repository-webhook
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if webhookURL(apiObj) == "" {
+			validator.Required("Config.url")
+		}
+	})
+}
+
+// validateWebhookInfo returns ErrNoProviderSupport for the webhook events GitHub can't express.
+func validateWebhookInfo(info gitprovider.WebhookInfo) error {
+	for _, event := range info.Events {
+		if _, ok := githubWebhookEvents[event]; !ok {
+			return fmt.Errorf("github doesn't support the %q webhook event: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	return nil
+}
+
+// webhookURL returns the URL the webhook delivers to, or an empty string if unset.
+func webhookURL(apiObj *github.Hook) string {
+	url, _ := apiObj.Config["url"].(string)
+	return url
+}
+
+func webhookFromAPI(apiObj *github.Hook) gitprovider.WebhookInfo {
+	contentType, _ := apiObj.Config["content_type"].(string)
+	if contentType == "" {
+		contentType = webhookContentTypeForm
+	}
+	// insecure_ssl is returned as a string, but can be set as a number too
+	var insecureSSL bool
+	switch v := apiObj.Config["insecure_ssl"].(type) {
+	case string:
+		insecureSSL = v == "1"
+	case float64:
+		insecureSSL = v == 1
+	}
+
+	events := make([]gitprovider.WebhookEvent, 0, len(apiObj.Events))
+	for event, name := range githubWebhookEvents {
+		for _, apiEvent := range apiObj.Events {
+			if apiEvent == name {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return gitprovider.WebhookInfo{
+		URL:                   webhookURL(apiObj),
+		ContentType:           gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentType(contentType)),
+		Events:                gitprovider.SortWebhookEvents(events),
+		InsecureSkipTLSVerify: gitprovider.BoolVar(insecureSSL),
+		Active:                apiObj.Active,
+	}
+}
+
+func webhookToAPI(info *gitprovider.WebhookInfo) *github.Hook {
+	h := &github.Hook{}
+	webhookInfoToAPIObj(info, h)
+	return h
+}
+
+func webhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *github.Hook) {
+	// Copy the config, in order to not modify the map of the caller
+	config := make(map[string]interface{}, len(apiObj.Config)+4)
+	for k, v := range apiObj.Config {
+		config[k] = v
+	}
+	// Required fields, we assume info is validated, and hence these are set
+	config["url"] = info.URL
+	// optional fields
+	if info.Secret != nil {
+		config["secret"] = *info.Secret
+	}
+	if info.ContentType != nil {
+		config["content_type"] = string(*info.ContentType)
+	}
+	if info.InsecureSkipTLSVerify != nil {
+		config["insecure_ssl"] = "0"
+		if *info.InsecureSkipTLSVerify {
+			config["insecure_ssl"] = "1"
+		}
+	}
+	apiObj.Config = config
+	if len(info.Events) != 0 {
+		apiObj.Events = make([]string, 0, len(info.Events))
+		for _, event := range gitprovider.SortWebhookEvents(info.Events) {
+			apiObj.Events = append(apiObj.Events, githubWebhookEvents[event])
+		}
+	}
+	if info.Active != nil {
+		apiObj.Active = info.Active
+	}
+}
+
+// webhookUpdateRequest returns the fields of apiObj which can be updated, without the masked
+// secret returned by GitHub, as sending it back would make it the actual secret.
+func webhookUpdateRequest(apiObj *github.Hook) *github.Hook {
+	config := make(map[string]interface{}, len(apiObj.Config))
+	for k, v := range apiObj.Config {
+		if k == "secret" && v == maskedWebhookSecret {
+			continue
+		}
+		config[k] = v
+	}
+	return &github.Hook{
+		Config: config,
+		Events: apiObj.Events,
+		Active: apiObj.Active,
+	}
+}
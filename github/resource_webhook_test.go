@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_webhookRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		info gitprovider.WebhookInfo
+	}{
+		{
+			name: "defaults",
+			info: gitprovider.WebhookInfo{URL: "https://example.com/hook"},
+		},
+		{
+			name: "all settings",
+			info: gitprovider.WebhookInfo{
+				URL:                   "https://example.com/hook",
+				ContentType:           gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm),
+				Events:                []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush, gitprovider.WebhookEventRelease},
+				InsecureSkipTLSVerify: gitprovider.BoolVar(true),
+				Active:                gitprovider.BoolVar(false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.Default()
+			got := webhookFromAPI(webhookToAPI(&tt.info))
+			if !reflect.DeepEqual(got, tt.info) {
+				t.Errorf("webhookFromAPI() = %+v, want %+v", got, tt.info)
+			}
+		})
+	}
+}
+
+func Test_webhookUpdateRequest(t *testing.T) {
+	apiObj := &github.Hook{
+		ID: github.Int64(1),
+		Config: map[string]interface{}{
+			"url":    "https://example.com/hook",
+			"secret": maskedWebhookSecret,
+		},
+		Events: []string{"push"},
+		Active: gitprovider.BoolVar(true),
+	}
+	want := &github.Hook{
+		Config: map[string]interface{}{"url": "https://example.com/hook"},
+		Events: []string{"push"},
+		Active: gitprovider.BoolVar(true),
+	}
+	if got := webhookUpdateRequest(apiObj); !reflect.DeepEqual(got, want) {
+		t.Errorf("webhookUpdateRequest() = %+v, want %+v", got, want)
+	}
+
+	// A secret set through Set() is sent as-is
+	webhookInfoToAPIObj(&gitprovider.WebhookInfo{URL: "https://example.com/hook", Secret: gitprovider.StringVar("s3cr3t")}, apiObj)
+	if got := webhookUpdateRequest(apiObj).Config["secret"]; got != "s3cr3t" {
+		t.Errorf("webhookUpdateRequest() secret = %v, want %q", got, "s3cr3t")
+	}
+}
+
+func Test_validateWebhookInfo(t *testing.T) {
+	info := gitprovider.WebhookInfo{
+		URL:    "https://example.com/hook",
+		Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventTagPush},
+	}
+	if err := validateWebhookInfo(info); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("validateWebhookInfo() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
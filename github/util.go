@@ -17,9 +17,12 @@ limitations under the License.
 package github
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/go-github/v47/github"
 
@@ -29,7 +32,19 @@ import (
 
 const (
 	alreadyExistsMagicString = "name already exists on this account"
-	rateLimitDocURL          = "https://developer.github.com/v3/#rate-limiting"
+	// keyInUseMagicString is returned when creating a deploy key which is already added.
+	keyInUseMagicString = "key is already in use"
+	rateLimitDocURL     = "https://developer.github.com/v3/#rate-limiting"
+
+	// shaNotSuppliedMagicString is returned when writing a file which exists without giving its SHA.
+	shaNotSuppliedMagicString = "\"sha\" wasn't supplied"
+
+	// defaultMediaType is the content type of release assets with an unknown file extension.
+	defaultMediaType = "application/octet-stream"
+
+	// cleanStatusMagicString is returned when enabling auto-merge for a pull request which can
+	// be merged right away.
+	cleanStatusMagicString = "is in clean status"
 )
 
 // TODO: Guard better against nil pointer dereference panics in this package, also
@@ -134,7 +149,7 @@ func handleHTTPError(err error) error {
 		}
 		// Check for already exists errors
 		for _, validationErr := range ghErrorResponse.Errors {
-			if validationErr.Message == alreadyExistsMagicString {
+			if validationErr.Message == alreadyExistsMagicString || validationErr.Message == keyInUseMagicString {
 				return validation.NewMultiError(err, gitprovider.ErrAlreadyExists)
 			}
 		}
@@ -176,3 +191,48 @@ func validateAPIObject(name string, fn func(validation.Validator)) error {
 	}
 	return nil
 }
+
+// enablePullRequestAutoMergeMutation turns on auto-merge for the pull request with the given
+// input, see https://docs.github.com/en/graphql/reference/mutations#enablepullrequestautomerge.
+const enablePullRequestAutoMergeMutation = `mutation($input: EnablePullRequestAutoMergeInput!) {
+  enablePullRequestAutoMerge(input: $input) {
+    clientMutationId
+  }
+}`
+
+// graphQLResponse is the part of a GraphQL response which is relevant for mutations.
+type graphQLResponse struct {
+	Errors []struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQL sends the given query to the GraphQL API of the server c points to. GraphQL reports
+// most failures with a 200 status, these are returned as errors too.
+func graphQL(ctx context.Context, c *github.Client, query string, variables map[string]interface{}) error {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+	req, err := c.NewRequest(http.MethodPost, graphQLURL(c.BaseURL), body)
+	if err != nil {
+		return err
+	}
+	resp := &graphQLResponse{}
+	if _, err := c.Do(ctx, req, resp); err != nil {
+		return handleHTTPError(err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("graphql request failed: %s", resp.Errors[0].Message)
+	}
+	return nil
+}
+
+// graphQLURL returns the URL of the GraphQL API for the REST API at baseURL. GitHub Enterprise
+// serves the REST API at /api/v3/, and the GraphQL API at /api/graphql.
+func graphQLURL(baseURL *url.URL) string {
+	u := *baseURL
+	u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	return u.String()
+}
@@ -133,3 +133,33 @@ func Test_allPages(t *testing.T) {
 		})
 	}
 }
+
+func Test_graphQLURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "github.com",
+			baseURL: "https://api.github.com/",
+			want:    "https://api.github.com/graphql",
+		},
+		{
+			name:    "enterprise",
+			baseURL: "https://github.example.com/api/v3/",
+			want:    "https://github.example.com/api/graphql",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, err := url.Parse(tt.baseURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := graphQLURL(baseURL); got != tt.want {
+				t.Errorf("graphQLURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,10 +18,12 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -42,6 +44,9 @@ func newClient(c *gitlab.Client, domain string, sshDomain string, destructiveAct
 		userRepos: &UserRepositoriesClient{
 			clientContext: ctx,
 		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
 	}
 }
 
@@ -62,6 +67,7 @@ type Client struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+	users     *UsersClient
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "gitlab.com" or
@@ -111,7 +117,50 @@ func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
 	return c.userRepos
 }
 
+// Users returns the UsersClient looking up user accounts.
+func (c *Client) Users() gitprovider.UsersClient {
+	return c.users
+}
+
+// permissionScopes maps the permissions to the token scopes granting them.
+//
+//nolint:gochecknoglobals
+var permissionScopes = map[gitprovider.TokenPermission][]string{
+	gitprovider.TokenPermissionRWRepository:      {"write_repository", "api"},
+	gitprovider.TokenPermissionAdminRepoHook:     {"api"},
+	gitprovider.TokenPermissionDeleteRepository:  {"api"},
+	gitprovider.TokenPermissionAdminOrganization: {"api"},
+	gitprovider.TokenPermissionWritePackages:     {"write_registry", "api"},
+}
+
 // HasTokenPermission returns true if the given token has the given permissions.
-func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
-	return false, gitprovider.ErrNoProviderSupport
+//
+// The scopes are read from the access token in use, which requires GitLab 15.5 or later.
+// ErrTokenPermissionUnknown is returned for older versions and other kinds of credentials,
+// e.g. OAuth tokens, which the endpoint responds to with 401 or 403.
+func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.TokenPermission) (bool, error) {
+	requestedScopes, ok := permissionScopes[permission]
+	if !ok {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+
+	token, err := c.c.GetCurrentPersonalAccessToken(ctx)
+	if err != nil {
+		// Older versions don't know the endpoint, and other kinds of credentials are rejected
+		var invalidCredentialsErr *gitprovider.InvalidCredentialsError
+		if errors.Is(err, gitprovider.ErrNotFound) || errors.As(err, &invalidCredentialsErr) {
+			return false, validation.NewMultiError(err, gitprovider.ErrTokenPermissionUnknown)
+		}
+		return false, err
+	}
+
+	for _, scope := range token.Scopes {
+		for _, requestedScope := range requestedScopes {
+			if scope == requestedScope {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
 }
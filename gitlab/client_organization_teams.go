@@ -18,6 +18,9 @@ package gitlab
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/xanzy/go-gitlab"
@@ -34,20 +37,20 @@ type TeamsClient struct {
 
 // Get a team within the specific organization.
 //
-// teamName may include slashes, to point to e.g. subgroups in GitLab.
-// teamName must not be an empty string.
+// teamName is the path of a subgroup relative to the organization, and may include slashes
+// to point to nested subgroups. teamName must not be an empty string.
 //
 // ErrNotFound is returned if the resource does not exist.
 func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Team, error) {
-	apiObjs, err := c.c.ListGroupMembers(ctx, c.ref.Organization)
+	// GET /groups/{group}/members
+	apiObjs, err := c.c.ListGroupMembers(ctx, c.teamPath(teamName))
 	if err != nil {
 		return nil, err
 	}
 
-	// Collect a list of the members' names. Login is validated to be non-nil in ListOrgTeamMembers.
+	// Collect a list of the members' names.
 	logins := make([]string, 0, len(apiObjs))
 	for _, apiObj := range apiObjs {
-		// Login is validated to be non-nil in ListOrgTeamMembers
 		logins = append(logins, apiObj.Username)
 	}
 
@@ -57,7 +60,7 @@ func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Tea
 			Name:    teamName,
 			Members: logins,
 		},
-		ref: c.ref,
+		c: c,
 	}, nil
 }
 
@@ -72,7 +75,8 @@ func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
 
 	teams := make([]gitprovider.Team, 0, len(subgroups))
 	for _, subgroup := range subgroups {
-		team, err := c.Get(ctx, subgroup.Name)
+		// Subgroups are addressed by their path, which might differ from their name
+		team, err := c.Get(ctx, subgroup.Path)
 		if err != nil {
 			return nil, err
 		}
@@ -83,22 +87,219 @@ func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
 	return teams, nil
 }
 
+// Create creates a subgroup within the specific organization, with the given members.
+// Members are added with developer access.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamsClient) Create(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// POST /groups
+	ref := c.ref
+	ref.SubOrganizations = append(append([]string{}, c.ref.SubOrganizations...), strings.Split(req.Name, "/")...)
+	if _, err := createGroup(ctx, c.clientContext, ref, gitprovider.OrganizationInfo{}); err != nil {
+		return nil, err
+	}
+
+	// The creator of the group becomes its owner, hence reconcile the members right away.
+	t := &team{
+		info: req,
+		c:    c,
+	}
+	return t, t.Update(ctx)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamsClient) Reconcile(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(req); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// teamPath returns the full path of the subgroup of the given team.
+func (c *TeamsClient) teamPath(teamName string) string {
+	return fmt.Sprintf("%s/%s", c.ref.GetIdentity(), teamName)
+}
+
 var _ gitprovider.Team = &team{}
 
 type team struct {
 	users []*gitlab.GroupMember
 	info  gitprovider.TeamInfo
-	ref   gitprovider.OrganizationRef
+	c     *TeamsClient
 }
 
 func (t *team) Get() gitprovider.TeamInfo {
 	return t.info
 }
 
+// Set sets the desired members of this team. The name of the team can't be changed.
+// User have to call Update() to apply the changes to the server.
+func (t *team) Set(info gitprovider.TeamInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Name != t.info.Name {
+		return fmt.Errorf("cannot rename team %q to %q: %w", t.info.Name, info.Name, gitprovider.ErrInvalidArgument)
+	}
+	t.info = info
+	return nil
+}
+
 func (t *team) APIObject() interface{} {
 	return t.users
 }
 
 func (t *team) Organization() gitprovider.OrganizationRef {
-	return t.ref
+	return t.c.ref
+}
+
+// Update adds and removes direct members of the subgroup, such that they match the desired
+// state in this object.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (t *team) Update(ctx context.Context) error {
+	// GET /groups/{group}/members
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		return err
+	}
+	added, removed := t.info.MemberChanges(actual.Get())
+	for _, login := range added {
+		if err := t.addMember(ctx, login); err != nil {
+			return err
+		}
+	}
+	for _, login := range removed {
+		if err := t.removeMember(ctx, login); err != nil {
+			return err
+		}
+	}
+	return t.refresh(ctx)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (t *team) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := t.c.Create(ctx, t.info)
+			if err != nil {
+				return true, err
+			}
+			*t = *resp.(*team)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if t.info.Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, t.Update(ctx)
+}
+
+// Delete deletes the subgroup of the team, including its projects.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (t *team) Delete(ctx context.Context) error {
+	// DELETE /groups/{group}
+	return t.c.c.DeleteGroup(ctx, t.c.teamPath(t.info.Name))
+}
+
+// AddMember adds the user with the given login to the subgroup, with developer access.
+// Adding an existing member is a no-op.
+func (t *team) AddMember(ctx context.Context, login string) error {
+	for _, member := range t.info.Members {
+		if member == login {
+			return nil
+		}
+	}
+	if err := t.addMember(ctx, login); err != nil {
+		return err
+	}
+	return t.refresh(ctx)
+}
+
+// RemoveMember removes the user with the given login from the subgroup.
+//
+// ErrNotFound is returned if the user isn't a member of the team.
+func (t *team) RemoveMember(ctx context.Context, login string) error {
+	if err := t.removeMember(ctx, login); err != nil {
+		return err
+	}
+	return t.refresh(ctx)
+}
+
+func (t *team) addMember(ctx context.Context, login string) error {
+	// GET /users?username={username}
+	user, err := t.c.c.GetUserByUsername(ctx, login)
+	if err != nil {
+		return err
+	}
+	// POST /groups/{group}/members
+	return t.c.c.AddGroupMember(ctx, t.c.teamPath(t.info.Name), user.ID, gitlab.DeveloperPermissions)
+}
+
+func (t *team) removeMember(ctx context.Context, login string) error {
+	// GET /users?username={username}
+	user, err := t.c.c.GetUserByUsername(ctx, login)
+	if err != nil {
+		return err
+	}
+	// DELETE /groups/{group}/members/{user_id}
+	return t.c.c.RemoveGroupMember(ctx, t.c.teamPath(t.info.Name), user.ID)
+}
+
+// refresh overrides the internal API object with the current members of the team.
+func (t *team) refresh(ctx context.Context) error {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		return err
+	}
+	*t = *actual.(*team)
+	return nil
 }
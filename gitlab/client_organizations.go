@@ -18,6 +18,10 @@ package gitlab
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -87,3 +91,90 @@ func (c *OrganizationsClient) Children(ctx context.Context, ref gitprovider.Orga
 
 	return subgroups, nil
 }
+
+// Create creates a group, or a subgroup if ref has SubOrganizations. The parent group of a
+// subgroup must exist. If req.Name is unset, the path of the group is used as its name.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrganizationsClient) Create(ctx context.Context, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (gitprovider.Organization, error) {
+	apiObj, err := createGroup(ctx, c.clientContext, ref, req)
+	if err != nil {
+		return nil, err
+	}
+	return newOrganization(c.clientContext, apiObj, ref), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrganizationsClient) Reconcile(ctx context.Context, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (gitprovider.Organization, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	// GET /groups/{group}
+	apiObj, err := c.c.GetGroup(ctx, ref.GetIdentity())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+	actual := newOrganization(c.clientContext, apiObj, ref)
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(req); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+func createGroup(ctx context.Context, c *clientContext, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (*gitlab.Group, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	// GitLab reports existing paths as generic validation errors, hence check up front.
+	// GET /groups/{group}
+	if _, err := c.c.GetGroup(ctx, ref.GetIdentity()); err == nil {
+		return nil, fmt.Errorf("group %q: %w", ref.GetIdentity(), gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	path := ref.Organization
+	opts := &gitlab.CreateGroupOptions{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if n := len(ref.SubOrganizations); n > 0 {
+		path = ref.SubOrganizations[n-1]
+		parentRef := gitprovider.OrganizationRef{
+			Domain:           ref.Domain,
+			Organization:     ref.Organization,
+			SubOrganizations: ref.SubOrganizations[:n-1],
+		}
+		// GET /groups/{group}
+		parent, err := c.c.GetGroup(ctx, parentRef.GetIdentity())
+		if err != nil {
+			return nil, err
+		}
+		opts.ParentID = &parent.ID
+	}
+	opts.Path = &path
+	if opts.Name == nil {
+		opts.Name = &path
+	}
+	// POST /groups
+	return c.c.CreateGroup(ctx, opts)
+}
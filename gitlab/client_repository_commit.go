@@ -18,9 +18,13 @@ package gitlab
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -64,24 +68,19 @@ func (c *CommitClient) listPage(branch string, perPage, page int) ([]*commitType
 }
 
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(_ context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
 
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files added")
 	}
 
-	commitActions := make([]*gitlab.CommitActionOptions, 0)
+	commitActions := make([]*gitlab.CommitActionOptions, 0, len(files))
 	for _, file := range files {
-		fileAction := gitlab.FileCreate
-		if file.Content == nil {
-			fileAction = gitlab.FileDelete
+		actions, err := c.commitActions(ctx, branch, file)
+		if err != nil {
+			return nil, err
 		}
-
-		commitActions = append(commitActions, &gitlab.CommitActionOptions{
-			Action:   &fileAction,
-			FilePath: file.Path,
-			Content:  file.Content,
-		})
+		commitActions = append(commitActions, actions...)
 	}
 
 	opts := &gitlab.CreateCommitOptions{
@@ -90,10 +89,75 @@ func (c *CommitClient) Create(_ context.Context, branch string, message string,
 		Actions:       commitActions,
 	}
 
-	commit, _, err := c.c.Client().Commits.CreateCommit(getRepoPath(c.ref), opts)
+	commit, _, err := c.c.Client().Commits.CreateCommit(getRepoPath(c.ref), opts, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 
 	return newCommit(c, commit), nil
 }
+
+// commitActions maps a gitprovider.CommitFile to GitLab commit actions. GitLab only applies the
+// executable flag in chmod actions, so a chmod action follows the file action if Mode is set.
+func (c *CommitClient) commitActions(ctx context.Context, branch string, file gitprovider.CommitFile) ([]*gitlab.CommitActionOptions, error) {
+	if err := file.Validate(); err != nil {
+		return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+	}
+	if file.GetMode() == gitprovider.CommitFileModeSymlink {
+		return nil, fmt.Errorf("cannot commit symlink %q: %w", *file.Path, gitprovider.ErrNoProviderSupport)
+	}
+
+	var fileAction gitlab.FileActionValue
+	switch file.GetAction() {
+	case gitprovider.CommitFileActionAdd:
+		fileAction = gitlab.FileCreate
+	case gitprovider.CommitFileActionUpdate:
+		fileAction = gitlab.FileUpdate
+	case gitprovider.CommitFileActionDelete:
+		fileAction = gitlab.FileDelete
+	case gitprovider.CommitFileActionMove:
+		fileAction = gitlab.FileMove
+	default:
+		// GitLab fails creating a file which exists, so check whether it's an update
+		// HEAD /projects/{project}/repository/files/{file_path}
+		_, err := c.c.GetFileMetaData(ctx, getRepoPath(c.ref), *file.Path, branch)
+		switch {
+		case err == nil:
+			fileAction = gitlab.FileUpdate
+		case errors.Is(err, gitprovider.ErrNotFound):
+			fileAction = gitlab.FileCreate
+		default:
+			return nil, err
+		}
+	}
+
+	commitAction := &gitlab.CommitActionOptions{
+		Action:       &fileAction,
+		FilePath:     file.Path,
+		PreviousPath: file.PreviousPath,
+	}
+	actions := []*gitlab.CommitActionOptions{commitAction}
+	if file.Mode != nil && fileAction != gitlab.FileDelete {
+		actions = append(actions, &gitlab.CommitActionOptions{
+			Action:          gitlab.FileAction(gitlab.FileChmod),
+			FilePath:        file.Path,
+			ExecuteFilemode: gitlab.Bool(*file.Mode == gitprovider.CommitFileModeExecutable),
+		})
+	}
+	if file.Content == nil {
+		return actions, nil
+	}
+
+	content, err := file.DecodedContent()
+	if err != nil {
+		return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+	}
+	// Content is sent as JSON, which can't carry binary data
+	if (file.Encoding != nil && *file.Encoding == gitprovider.ContentEncodingBase64) || !utf8.Valid(content) {
+		commitAction.Content = gitlab.String(base64.StdEncoding.EncodeToString(content))
+		commitAction.Encoding = gitlab.String("base64")
+	} else {
+		commitAction.Content = gitlab.String(string(content))
+	}
+	return actions, nil
+}
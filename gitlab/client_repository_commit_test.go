@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_commitActions(t *testing.T) {
+	executable := gitprovider.CommitFileModeExecutable
+	regular := gitprovider.CommitFileModeRegular
+	add := gitprovider.CommitFileActionAdd
+	move := gitprovider.CommitFileActionMove
+	del := gitprovider.CommitFileActionDelete
+
+	tests := []struct {
+		name string
+		file gitprovider.CommitFile
+		want []*gitlab.CommitActionOptions
+	}{
+		{
+			name: "add without mode",
+			file: gitprovider.CommitFile{Path: gitprovider.StringVar("a.txt"), Content: gitprovider.StringVar("a"), Action: &add},
+			want: []*gitlab.CommitActionOptions{
+				{Action: gitlab.FileAction(gitlab.FileCreate), FilePath: gitlab.String("a.txt"), Content: gitlab.String("a")},
+			},
+		},
+		{
+			name: "add executable",
+			file: gitprovider.CommitFile{Path: gitprovider.StringVar("run.sh"), Content: gitprovider.StringVar("#!/bin/sh"), Action: &add, Mode: &executable},
+			want: []*gitlab.CommitActionOptions{
+				{Action: gitlab.FileAction(gitlab.FileCreate), FilePath: gitlab.String("run.sh"), Content: gitlab.String("#!/bin/sh")},
+				{Action: gitlab.FileAction(gitlab.FileChmod), FilePath: gitlab.String("run.sh"), ExecuteFilemode: gitlab.Bool(true)},
+			},
+		},
+		{
+			name: "move to regular",
+			file: gitprovider.CommitFile{Path: gitprovider.StringVar("b.sh"), PreviousPath: gitprovider.StringVar("a.sh"), Action: &move, Mode: &regular},
+			want: []*gitlab.CommitActionOptions{
+				{Action: gitlab.FileAction(gitlab.FileMove), FilePath: gitlab.String("b.sh"), PreviousPath: gitlab.String("a.sh")},
+				{Action: gitlab.FileAction(gitlab.FileChmod), FilePath: gitlab.String("b.sh"), ExecuteFilemode: gitlab.Bool(false)},
+			},
+		},
+		{
+			name: "delete",
+			file: gitprovider.CommitFile{Path: gitprovider.StringVar("a.txt"), Action: &del},
+			want: []*gitlab.CommitActionOptions{
+				{Action: gitlab.FileAction(gitlab.FileDelete), FilePath: gitlab.String("a.txt")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommitClient{}
+			got, err := c.commitActions(context.Background(), "main", tt.file)
+			if err != nil {
+				t.Fatalf("commitActions() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("commitActions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,10 +19,13 @@ package gitlab
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -80,8 +83,87 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 		files = append(files, &gitprovider.CommitFile{
 			Path:    &filePath,
 			Content: &fileStr,
+			SHA:     &fileDownloaded.BlobID,
 		})
 	}
 
 	return files, nil
 }
+
+// Put creates or updates the file at path on the given branch, committing content with message.
+// ErrConflict is returned if the blob SHA of the file isn't expectedSHA, which is empty for new files.
+func (c *FileClient) Put(ctx context.Context, path, branch, content, message, expectedSHA string) error {
+	file, err := c.fileMetaData(ctx, path, branch, expectedSHA)
+	if err != nil {
+		return err
+	}
+
+	if file == nil {
+		// POST /projects/{project}/repository/files/{file_path}
+		return fileConflictError(c.c.CreateFile(ctx, getRepoPath(c.ref), path, &gitlab.CreateFileOptions{
+			Branch:        &branch,
+			Content:       &content,
+			CommitMessage: &message,
+		}))
+	}
+	// The last commit ID makes GitLab reject the update if the file changed after getting its metadata
+	// PUT /projects/{project}/repository/files/{file_path}
+	return fileConflictError(c.c.UpdateFile(ctx, getRepoPath(c.ref), path, &gitlab.UpdateFileOptions{
+		Branch:        &branch,
+		Content:       &content,
+		CommitMessage: &message,
+		LastCommitID:  &file.LastCommitID,
+	}))
+}
+
+// Delete deletes the file at path on the given branch, committing with message.
+// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its blob SHA isn't expectedSHA.
+func (c *FileClient) Delete(ctx context.Context, path, branch, message, expectedSHA string) error {
+	if expectedSHA == "" {
+		return fmt.Errorf("expected SHA is required to delete file %q: %w", path, gitprovider.ErrInvalidArgument)
+	}
+	file, err := c.fileMetaData(ctx, path, branch, expectedSHA)
+	if errors.Is(err, gitprovider.ErrConflict) && file == nil {
+		return fmt.Errorf("file %q: %w", path, gitprovider.ErrNotFound)
+	} else if err != nil {
+		return err
+	}
+
+	// DELETE /projects/{project}/repository/files/{file_path}
+	return fileConflictError(c.c.DeleteFile(ctx, getRepoPath(c.ref), path, &gitlab.DeleteFileOptions{
+		Branch:        &branch,
+		CommitMessage: &message,
+		LastCommitID:  &file.LastCommitID,
+	}))
+}
+
+// fileMetaData returns the metadata of the file at path, or nil if it doesn't exist.
+// ErrConflict is returned if the blob ID of the file isn't expectedSHA, or the file exists
+// although expectedSHA is empty.
+func (c *FileClient) fileMetaData(ctx context.Context, path, branch, expectedSHA string) (*gitlab.File, error) {
+	// HEAD /projects/{project}/repository/files/{file_path}
+	file, err := c.c.GetFileMetaData(ctx, getRepoPath(c.ref), path, branch)
+	actualSHA := ""
+	if err == nil {
+		actualSHA = file.BlobID
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+	if actualSHA != expectedSHA {
+		return file, fmt.Errorf("file %q has SHA %q, expected %q: %w", path, actualSHA, expectedSHA, gitprovider.ErrConflict)
+	}
+	return file, nil
+}
+
+// fileConflictError adds ErrConflict to err, if GitLab rejected writing a file because it
+// was created or changed concurrently.
+func fileConflictError(err error) error {
+	httpErr := &gitprovider.HTTPError{}
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+	if strings.Contains(httpErr.Message, fileExistsMagicString) || strings.Contains(httpErr.Message, fileChangedMagicString) {
+		return validation.NewMultiError(err, gitprovider.ErrConflict)
+	}
+	return err
+}
@@ -28,6 +28,18 @@ import (
 // mergeStatusChecking indicates that gitlab has not yet asynchronously updated the merge status for a merge request
 const mergeStatusChecking = "checking"
 
+// stateEventClose is the state event which closes a merge request.
+const stateEventClose = "close"
+
+// mergeRequestStates maps pull request states to the states of merge requests.
+//
+//nolint:gochecknoglobals
+var mergeRequestStates = map[gitprovider.PullRequestState]string{
+	gitprovider.PullRequestStateOpen:   "opened",
+	gitprovider.PullRequestStateClosed: "closed",
+	gitprovider.PullRequestStateMerged: mergedState,
+}
+
 // PullRequestClient implements the gitprovider.PullRequestClient interface.
 var _ gitprovider.PullRequestClient = &PullRequestClient{}
 
@@ -37,38 +49,98 @@ type PullRequestClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// List lists all pull requests in the repository
-func (c *PullRequestClient) List(_ context.Context) ([]gitprovider.PullRequest, error) {
-	mrs, _, err := c.c.Client().MergeRequests.ListProjectMergeRequests(getRepoPath(c.ref), nil)
+// List lists all pull requests in the repository, in all states unless filtered
+// with PullRequestListOptions.
+//
+// List returns all available pull requests, using multiple paginated requests if needed.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	filter, err := gitprovider.MakePullRequestListOptions(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	requests := make([]gitprovider.PullRequest, len(mrs))
+	listOpts := &gitlab.ListProjectMergeRequestsOptions{
+		SourceBranch: filter.SourceBranch,
+		TargetBranch: filter.TargetBranch,
+	}
+	if filter.State != nil {
+		listOpts.State = gitlab.String(mergeRequestStates[*filter.State])
+	}
+
+	mrs := []*gitlab.MergeRequest{}
+	err = allMergeRequestPages(listOpts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests
+		pageObjs, resp, listErr := c.c.Client().MergeRequests.ListProjectMergeRequests(getRepoPath(c.ref), listOpts, gitlab.WithContext(ctx))
+		mrs = append(mrs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
 
-	for idx, mr := range mrs {
-		requests[idx] = newPullRequest(c.clientContext, mr)
+	requests := make([]gitprovider.PullRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		requests = append(requests, newPullRequest(c.clientContext, c.ref, mr))
 	}
 
 	return requests, nil
 }
 
-// Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Create creates a pull request with the given specifications. GitLab marks merge requests as
+// drafts by the "Draft:" prefix of their title, and the usernames of assignees and reviewers are
+// looked up to get their user IDs.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
 
+	if o.Draft != nil && *o.Draft {
+		title = draftTitlePrefix + title
+	}
 	prOpts := &gitlab.CreateMergeRequestOptions{
-		Title:        &title,
-		SourceBranch: &branch,
-		TargetBranch: &baseBranch,
-		Description:  &description,
+		Title:              &title,
+		SourceBranch:       &branch,
+		TargetBranch:       &baseBranch,
+		Description:        &description,
+		AllowCollaboration: o.MaintainerCanModify,
+	}
+	if len(o.Labels) > 0 {
+		labels := gitlab.Labels(o.Labels)
+		prOpts.Labels = &labels
+	}
+	if len(o.Assignees) > 0 {
+		if prOpts.AssigneeIDs, err = c.userIDs(ctx, o.Assignees); err != nil {
+			return nil, err
+		}
+	}
+	if len(o.Reviewers) > 0 {
+		if prOpts.ReviewerIDs, err = c.userIDs(ctx, o.Reviewers); err != nil {
+			return nil, err
+		}
 	}
 
-	mr, _, err := c.c.Client().MergeRequests.CreateMergeRequest(getRepoPath(c.ref), prOpts)
+	// POST /projects/{project}/merge_requests
+	mr, _, err := c.c.Client().MergeRequests.CreateMergeRequest(getRepoPath(c.ref), prOpts, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 
-	return newPullRequest(c.clientContext, mr), nil
+	return newPullRequest(c.clientContext, c.ref, mr), nil
+}
+
+// userIDs returns the IDs of the users with the given usernames.
+func (c *PullRequestClient) userIDs(ctx context.Context, usernames []string) (*[]int, error) {
+	ids := make([]int, 0, len(usernames))
+	for _, username := range usernames {
+		// GET /users?username={username}
+		user, err := c.c.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, user.ID)
+	}
+	return &ids, nil
 }
 
 // Get retrieves an existing pull request by number
@@ -79,14 +151,53 @@ func (c *PullRequestClient) Get(_ context.Context, number int) (gitprovider.Pull
 		return nil, err
 	}
 
-	return newPullRequest(c.clientContext, mr), nil
+	return newPullRequest(c.clientContext, c.ref, mr), nil
 }
 
-// Merge merges a pull request with the given specifications.
-func (c *PullRequestClient) Merge(_ context.Context, number int, mergeMethod gitprovider.MergeMethod, message string) error {
-	if err := c.waitForMergeRequestToBeMergeable(number); err != nil {
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Update(ctx context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	mr, _, err := c.c.Client().MergeRequests.UpdateMergeRequest(getRepoPath(c.ref), number, &gitlab.UpdateMergeRequestOptions{
+		Title:        req.Title,
+		Description:  req.Description,
+		TargetBranch: req.TargetBranch,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	return newPullRequest(c.clientContext, c.ref, mr), nil
+}
+
+// Close closes the pull request with the given number without merging it.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Close(ctx context.Context, number int) error {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	_, _, err := c.c.Client().MergeRequests.UpdateMergeRequest(getRepoPath(c.ref), number, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String(stateEventClose),
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+// Merge merges a pull request with the given specifications. GitLab merges with the merge method
+// of the project, hence gitprovider.MergeMethodRebase and gitprovider.MergeMethodFastForwardOnly
+// require the project to use fast-forward merges, the source branch is rebased first for the
+// former. Auto-merge merges the merge request when its pipeline succeeds.
+// Deleting the source branch after merging requires destructive API calls to be enabled, otherwise
+// ErrDestructiveCallDisallowed is returned without merging the pull request.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err != nil {
 		return err
 	}
+	// Don't allow deleting the source branch if the user didn't explicitly allow dangerous API calls.
+	if o.DeleteSourceBranch != nil && *o.DeleteSourceBranch && !c.destructiveActions {
+		return fmt.Errorf("cannot delete source branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
 
 	var squash bool
 
@@ -99,27 +210,74 @@ func (c *PullRequestClient) Merge(_ context.Context, number int, mergeMethod git
 		squash = true
 	case gitprovider.MergeMethodMerge:
 		mergeCommitMessage = &message
+	case gitprovider.MergeMethodRebase, gitprovider.MergeMethodFastForwardOnly:
+		if err := c.requireFastForwardMerges(ctx, mergeMethod); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown merge method: %s", mergeMethod)
 	}
 
+	if mergeMethod == gitprovider.MergeMethodRebase {
+		if err := c.rebase(ctx, number); err != nil {
+			return err
+		}
+	}
+	if err := c.waitForMergeRequestToBeMergeable(number); err != nil {
+		return err
+	}
+
 	amrOpts := &gitlab.AcceptMergeRequestOptions{
 		MergeCommitMessage:        mergeCommitMessage,
 		SquashCommitMessage:       squashCommitMessage,
 		Squash:                    &squash,
-		ShouldRemoveSourceBranch:  nil,
-		MergeWhenPipelineSucceeds: nil,
+		ShouldRemoveSourceBranch:  o.DeleteSourceBranch,
+		MergeWhenPipelineSucceeds: o.AutoMerge,
 		SHA:                       nil,
 	}
 
-	_, _, err := c.c.Client().MergeRequests.AcceptMergeRequest(getRepoPath(c.ref), number, amrOpts)
+	_, _, err = c.c.Client().MergeRequests.AcceptMergeRequest(getRepoPath(c.ref), number, amrOpts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+// requireFastForwardMerges returns gitprovider.ErrNoProviderSupport if the project doesn't use
+// fast-forward merges, which mergeMethod needs.
+func (c *PullRequestClient) requireFastForwardMerges(ctx context.Context, mergeMethod gitprovider.MergeMethod) error {
+	project, _, err := c.c.Client().Projects.GetProject(getRepoPath(c.ref), &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
 	if err != nil {
-		return err
+		return handleHTTPError(err)
+	}
+	if project.MergeMethod != gitlab.FastForwardMerge {
+		return fmt.Errorf("merge method %q needs fast-forward merges, but the project uses %q: %w",
+			mergeMethod, project.MergeMethod, gitprovider.ErrNoProviderSupport)
 	}
-
 	return nil
 }
 
+// rebase rebases the source branch of the merge request onto its target branch, and waits for
+// the rebase to finish.
+func (c *PullRequestClient) rebase(ctx context.Context, number int) error {
+	if _, err := c.c.Client().MergeRequests.RebaseMergeRequest(getRepoPath(c.ref), number, gitlab.WithContext(ctx)); err != nil {
+		return handleHTTPError(err)
+	}
+	// gitlab rebases asynchronously
+	for retries := 0; retries < 10; retries++ {
+		mr, _, err := c.c.Client().MergeRequests.GetMergeRequest(getRepoPath(c.ref), number, &gitlab.GetMergeRequestsOptions{
+			IncludeRebaseInProgress: gitlab.Bool(true),
+		}, gitlab.WithContext(ctx))
+		if err != nil || mr.RebaseInProgress {
+			time.Sleep(time.Second * 2)
+			continue
+		}
+		if mr.MergeError != "" {
+			return fmt.Errorf("rebase of pull request number %d failed: %s", number, mr.MergeError)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("rebase of pull request number %d didn't finish", number)
+}
+
 func (c *PullRequestClient) waitForMergeRequestToBeMergeable(number int) error {
 	// gitlab says to poll for merge status
 	for retries := 0; retries < 10; retries++ {
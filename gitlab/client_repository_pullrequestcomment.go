@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestCommentClient implements the gitprovider.PullRequestCommentClient interface.
+var _ gitprovider.PullRequestCommentClient = &PullRequestCommentClient{}
+
+// PullRequestCommentClient operates on the general comments of a specific merge request.
+// GitLab stores these as notes of the merge request.
+type PullRequestCommentClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+	iid int
+}
+
+// List lists all general comments of the merge request, oldest first. System notes, like
+// "added 1 commit", and comments on the diff are left out.
+//
+// List returns all available comments, using multiple paginated requests if needed.
+func (c *PullRequestCommentClient) List(ctx context.Context) ([]gitprovider.PullRequestComment, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/notes
+	apiObjs, err := c.c.ListMergeRequestNotes(ctx, getRepoPath(c.ref), c.iid)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]gitprovider.PullRequestComment, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if apiObj.System || apiObj.Position != nil {
+			continue
+		}
+		comments = append(comments, newPullRequestComment(c, apiObj))
+	}
+	return comments, nil
+}
+
+// Create adds a general comment with the given body to the merge request.
+func (c *PullRequestCommentClient) Create(ctx context.Context, body string) (gitprovider.PullRequestComment, error) {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/notes
+	apiObj, err := c.c.CreateMergeRequestNote(ctx, getRepoPath(c.ref), c.iid, body)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequestComment(c, apiObj), nil
+}
+
+// Update replaces the body of the comment with the given id.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestCommentClient) Update(ctx context.Context, id int64, body string) (gitprovider.PullRequestComment, error) {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}/notes/{note_id}
+	apiObj, err := c.c.UpdateMergeRequestNote(ctx, getRepoPath(c.ref), c.iid, int(id), body)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequestComment(c, apiObj), nil
+}
+
+// Delete removes the comment with the given id.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestCommentClient) Delete(ctx context.Context, id int64) error {
+	// DELETE /projects/{project}/merge_requests/{merge_request_iid}/notes/{note_id}
+	return c.c.DeleteMergeRequestNote(ctx, getRepoPath(c.ref), c.iid, int(id))
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+//
+// GitLab has no draft releases or pre-releases, ErrNoProviderSupport is returned when
+// setting Draft or Prerelease to true. Uploaded assets are attached as release links.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all releases in the repository.
+//
+// List returns all available releases, using multiple paginated requests if needed.
+func (c *ReleaseClient) List(ctx context.Context) ([]gitprovider.Release, error) {
+	// GET /projects/{project}/releases
+	apiObjs, err := c.c.ListReleases(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]gitprovider.Release, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		releases = append(releases, newRelease(c, apiObj))
+	}
+	return releases, nil
+}
+
+// Get returns the release for the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Get(ctx context.Context, tagName string) (gitprovider.Release, error) {
+	// GET /projects/{project}/releases/{tag_name}
+	apiObj, err := c.c.GetRelease(ctx, getRepoPath(c.ref), tagName)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// Create creates a release with the given specifications, creating its tag from req.Target
+// if it doesn't exist yet.
+//
+// ErrAlreadyExists will be returned if a release for the tag already exists.
+func (c *ReleaseClient) Create(ctx context.Context, req gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	if err := validateReleaseInfo(req); err != nil {
+		return nil, err
+	}
+	target := req.Target
+	if target == nil {
+		// GitLab needs a ref to create a missing tag from
+		// GET /projects/{project}
+		project, err := c.c.GetUserProject(ctx, getRepoPath(c.ref))
+		if err != nil {
+			return nil, err
+		}
+		target = &project.DefaultBranch
+	}
+	name := req.Name
+	if name == nil {
+		name = &req.TagName
+	}
+
+	// POST /projects/{project}/releases
+	apiObj, err := c.c.CreateRelease(ctx, getRepoPath(c.ref), &gitlab.CreateReleaseOptions{
+		TagName:     &req.TagName,
+		Ref:         target,
+		Name:        name,
+		Description: req.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// Update changes the release for the tag with the given name to req. Unset fields of req
+// are left unchanged, and the tag can't be changed.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Update(ctx context.Context, tagName string, req gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	if err := validateReleaseInfo(req); err != nil {
+		return nil, err
+	}
+	// GitLab clears the name and description if they aren't sent
+	// GET /projects/{project}/releases/{tag_name}
+	actual, err := c.c.GetRelease(ctx, getRepoPath(c.ref), tagName)
+	if err != nil {
+		return nil, err
+	}
+	opts := &gitlab.UpdateReleaseOptions{
+		Name:        &actual.Name,
+		Description: &actual.Description,
+	}
+	if req.Name != nil {
+		opts.Name = req.Name
+	}
+	if req.Notes != nil {
+		opts.Description = req.Notes
+	}
+
+	// PUT /projects/{project}/releases/{tag_name}
+	apiObj, err := c.c.UpdateRelease(ctx, getRepoPath(c.ref), tagName, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(c, apiObj), nil
+}
+
+// Delete deletes the release for the tag with the given name. The tag itself is kept.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Delete(ctx context.Context, tagName string) error {
+	// DELETE /projects/{project}/releases/{tag_name}
+	return c.c.DeleteRelease(ctx, getRepoPath(c.ref), tagName)
+}
+
+// UploadAsset uploads a file with the given name and content to the project, and links it
+// to the release for the tag with the given name.
+//
+// ErrNotFound is returned if the release does not exist.
+func (c *ReleaseClient) UploadAsset(ctx context.Context, tagName, name string, content []byte) (*gitprovider.ReleaseAsset, error) {
+	// Make sure the release exists before uploading the file
+	if _, err := c.Get(ctx, tagName); err != nil {
+		return nil, err
+	}
+	// POST /projects/{project}/uploads
+	file, err := c.c.UploadFile(ctx, getRepoPath(c.ref), name, content)
+	if err != nil {
+		return nil, err
+	}
+	// The URL of uploads is relative to the project
+	url := c.ref.String() + file.URL
+	// POST /projects/{project}/releases/{tag_name}/assets/links
+	apiObj, err := c.c.CreateReleaseLink(ctx, getRepoPath(c.ref), tagName, &gitlab.CreateReleaseLinkOptions{
+		Name: &name,
+		URL:  &url,
+	})
+	if err != nil {
+		return nil, err
+	}
+	asset := releaseAssetFromAPI(apiObj, len(content))
+	return &asset, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all tags in the repository.
+//
+// List returns all available tags, using multiple paginated requests if needed.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.Tag, error) {
+	// GET /projects/{project}/repository/tags
+	apiObjs, err := c.c.ListTags(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gitprovider.Tag, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		tags = append(tags, newTag(c, apiObj))
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Get(ctx context.Context, name string) (gitprovider.Tag, error) {
+	// GET /projects/{project}/repository/tags/{tag_name}
+	apiObj, err := c.c.GetTag(ctx, getRepoPath(c.ref), name)
+	if err != nil {
+		return nil, err
+	}
+	return newTag(c, apiObj), nil
+}
+
+// Create creates a tag with the given specifications. A tag with a Message is an
+// annotated tag, otherwise a lightweight tag.
+//
+// ErrAlreadyExists will be returned if the tag already exists.
+func (c *TagClient) Create(ctx context.Context, req gitprovider.TagInfo) (gitprovider.Tag, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	// GitLab responds with a bad request for existing tags
+	if _, err := c.Get(ctx, req.Name); err == nil {
+		return nil, fmt.Errorf("tag %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	// POST /projects/{project}/repository/tags
+	apiObj, err := c.c.CreateTag(ctx, getRepoPath(c.ref), &gitlab.CreateTagOptions{
+		TagName: &req.Name,
+		Ref:     &req.Sha,
+		Message: req.Message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTag(c, apiObj), nil
+}
+
+// Delete deletes the tag with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Delete(ctx context.Context, name string) error {
+	// DELETE /projects/{project}/repository/tags/{tag_name}
+	return c.c.DeleteTag(ctx, getRepoPath(c.ref), name)
+}
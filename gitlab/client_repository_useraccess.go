@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserAccessClient implements the gitprovider.UserAccessClient interface.
+var _ gitprovider.UserAccessClient = &UserAccessClient{}
+
+// UserAccessClient operates on the direct members of a specific repository.
+type UserAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get a direct member of the repository by their username.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *UserAccessClient) Get(ctx context.Context, login string) (gitprovider.UserAccess, error) {
+	user, err := c.c.GetUserByUsername(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	// GET /projects/{project}/members/{user_id}
+	apiObj, err := c.c.GetProjectMember(ctx, getRepoPath(c.ref), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return userAccessFromAPI(c, apiObj)
+}
+
+// List lists the direct members of this repository.
+//
+// List returns all available user access lists, using multiple paginated requests if needed.
+func (c *UserAccessClient) List(ctx context.Context) ([]gitprovider.UserAccess, error) {
+	// GET /projects/{project}/members
+	apiObjs, err := c.c.ListProjectMembers(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	userAccess := make([]gitprovider.UserAccess, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		ua, err := userAccessFromAPI(c, apiObj)
+		if err != nil {
+			return nil, err
+		}
+		userAccess = append(userAccess, ua)
+	}
+
+	return userAccess, nil
+}
+
+// Create adds a given user as direct member of the repository.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *UserAccessClient) Create(ctx context.Context, req gitprovider.UserAccessInfo) (gitprovider.UserAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	gitlabPermission, err := getGitlabPermission(*req.Permission)
+	if err != nil {
+		return nil, err
+	}
+	user, err := c.c.GetUserByUsername(ctx, req.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	// POST /projects/{project}/members
+	if err := c.c.AddProjectMember(ctx, getRepoPath(c.ref), user.ID, gitlab.AccessLevelValue(gitlabPermission)); err != nil {
+		return nil, err
+	}
+	return newUserAccess(c, req, nil), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *UserAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.UserAccessInfo,
+) (gitprovider.UserAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestClient_HasTokenPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		scopes     []string
+		status     int
+		permission gitprovider.TokenPermission
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "api scope",
+			scopes:     []string{"api"},
+			status:     http.StatusOK,
+			permission: gitprovider.TokenPermissionDeleteRepository,
+			want:       true,
+		},
+		{
+			name:       "write_repository scope",
+			scopes:     []string{"read_api", "write_repository"},
+			status:     http.StatusOK,
+			permission: gitprovider.TokenPermissionRWRepository,
+			want:       true,
+		},
+		{
+			name:       "missing scope",
+			scopes:     []string{"read_api", "write_repository"},
+			status:     http.StatusOK,
+			permission: gitprovider.TokenPermissionAdminRepoHook,
+			want:       false,
+		},
+		{
+			name:       "no access token",
+			status:     http.StatusNotFound,
+			permission: gitprovider.TokenPermissionWritePackages,
+			wantErr:    gitprovider.ErrTokenPermissionUnknown,
+		},
+		{
+			name:       "token endpoint unauthorized",
+			status:     http.StatusUnauthorized,
+			permission: gitprovider.TokenPermissionRWRepository,
+			wantErr:    gitprovider.ErrTokenPermissionUnknown,
+		},
+		{
+			name:       "token endpoint forbidden",
+			status:     http.StatusForbidden,
+			permission: gitprovider.TokenPermissionRWRepository,
+			wantErr:    gitprovider.ErrTokenPermissionUnknown,
+		},
+		{
+			name:       "unknown permission",
+			status:     http.StatusOK,
+			permission: 0,
+			wantErr:    gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// NewClient also queries the API root, only the token endpoint is of interest here
+				if r.URL.Path != "/api/v4/personal_access_tokens/self" {
+					writeJSON(t, w, http.StatusOK, map[string]interface{}{})
+					return
+				}
+				if tt.status != http.StatusOK {
+					writeError(t, w, tt.status, http.StatusText(tt.status))
+					return
+				}
+				writeJSON(t, w, tt.status, &gitlab.PersonalAccessToken{ID: 1, Active: true, Scopes: tt.scopes})
+			}))
+			defer server.Close()
+
+			c, err := NewClient("token", "", gitprovider.WithDomain(server.URL))
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+
+			got, err := c.HasTokenPermission(context.Background(), tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HasTokenPermission() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HasTokenPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
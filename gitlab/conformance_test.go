@@ -0,0 +1,245 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+// conformancePageSize is the page size of the stand-in, small enough for List to need multiple pages.
+const conformancePageSize = 2
+
+// gitlabStandIn emulates the group, project and deploy key endpoints of a GitLab server with a
+// single group "org".
+type gitlabStandIn struct {
+	t      *testing.T
+	mu     sync.Mutex
+	group  *gitlab.Group
+	repos  []*gitlab.Project
+	keys   map[int][]*gitlab.ProjectDeployKey
+	lastID int
+}
+
+func TestConformance(t *testing.T) {
+	s := &gitlabStandIn{
+		t:     t,
+		group: &gitlab.Group{ID: 1, Name: "org", Path: "org", FullPath: "org"},
+		keys:  map[int][]*gitlab.ProjectDeployKey{},
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewClient("token", "", append([]gitprovider.ClientOption{
+				gitprovider.WithDomain(server.URL),
+			}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: gitprovider.OrganizationRef{Domain: server.URL, Organization: "org"},
+	})
+}
+
+func (s *gitlabStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Projects are referred to by their URL-encoded path, hence split the escaped path
+	path := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4/"), "/")
+	for i := range path {
+		path[i], _ = url.PathUnescape(path[i])
+	}
+	switch {
+	case len(path) == 2 && path[0] == "groups" && path[1] == s.group.Path && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, s.group)
+	case len(path) == 3 && path[0] == "groups" && path[1] == s.group.Path && path[2] == "projects" && r.Method == http.MethodGet:
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		writePage(s.t, w, page, len(s.repos), func(start, end int) interface{} {
+			return s.repos[start:end]
+		})
+	case len(path) == 1 && path[0] == "projects" && r.Method == http.MethodPost:
+		opts := &gitlab.CreateProjectOptions{}
+		s.decode(r, opts)
+		if opts.NamespaceID == nil || *opts.NamespaceID != s.group.ID {
+			writeError(s.t, w, http.StatusBadRequest, map[string]interface{}{"namespace": []string{"is not valid"}})
+			return
+		}
+		if s.project(s.group.Path+"/"+*opts.Name) != nil {
+			writeError(s.t, w, http.StatusBadRequest, map[string]interface{}{
+				"name": []string{"has already been taken"},
+				"path": []string{"has already been taken"},
+			})
+			return
+		}
+		s.lastID++
+		repo := &gitlab.Project{
+			ID:                s.lastID,
+			Name:              *opts.Name,
+			Path:              *opts.Name,
+			PathWithNamespace: s.group.Path + "/" + *opts.Name,
+			Namespace:         &gitlab.ProjectNamespace{ID: s.group.ID, Name: s.group.Name, Path: s.group.Path, Kind: "group"},
+		}
+		if opts.Description != nil {
+			repo.Description = *opts.Description
+		}
+		if opts.Visibility != nil {
+			repo.Visibility = *opts.Visibility
+		}
+		if opts.DefaultBranch != nil {
+			repo.DefaultBranch = *opts.DefaultBranch
+		}
+		s.repos = append(s.repos, repo)
+		writeJSON(s.t, w, http.StatusCreated, repo)
+	case len(path) >= 2 && path[0] == "projects":
+		s.serveProject(w, r, path[1], path[2:])
+	default:
+		writeError(s.t, w, http.StatusNotFound, "404 Not Found")
+	}
+}
+
+func (s *gitlabStandIn) serveProject(w http.ResponseWriter, r *http.Request, pid string, path []string) {
+	repo := s.project(pid)
+	if repo == nil {
+		writeError(s.t, w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodPut:
+		opts := &gitlab.EditProjectOptions{}
+		s.decode(r, opts)
+		if opts.Description != nil {
+			repo.Description = *opts.Description
+		}
+		if opts.Visibility != nil {
+			repo.Visibility = *opts.Visibility
+		}
+		if opts.DefaultBranch != nil {
+			repo.DefaultBranch = *opts.DefaultBranch
+		}
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodDelete:
+		for i := range s.repos {
+			if s.repos[i] == repo {
+				s.repos = append(s.repos[:i], s.repos[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case len(path) == 1 && path[0] == "deploy_keys" && r.Method == http.MethodGet:
+		keys := s.keys[repo.ID]
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		writePage(s.t, w, page, len(keys), func(start, end int) interface{} {
+			return keys[start:end]
+		})
+	case len(path) == 1 && path[0] == "deploy_keys" && r.Method == http.MethodPost:
+		opts := &gitlab.AddDeployKeyOptions{}
+		s.decode(r, opts)
+		for _, key := range s.keys[repo.ID] {
+			if key.Key == *opts.Key {
+				writeError(s.t, w, http.StatusBadRequest, map[string]interface{}{
+					"deploy_keys_projects.deploy_key_id": []string{"already exists in project"},
+				})
+				return
+			}
+		}
+		s.lastID++
+		key := &gitlab.ProjectDeployKey{ID: s.lastID, Title: *opts.Title, Key: *opts.Key}
+		if opts.CanPush != nil {
+			key.CanPush = *opts.CanPush
+		}
+		s.keys[repo.ID] = append(s.keys[repo.ID], key)
+		writeJSON(s.t, w, http.StatusCreated, key)
+	case len(path) == 2 && path[0] == "deploy_keys" && r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(path[1])
+		keys := s.keys[repo.ID]
+		for i := range keys {
+			if keys[i].ID == id {
+				s.keys[repo.ID] = append(keys[:i], keys[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(s.t, w, http.StatusNotFound, "404 Deploy Key Not Found")
+	default:
+		writeError(s.t, w, http.StatusNotFound, "404 Not Found")
+	}
+}
+
+// project returns the project with the given ID or path, or nil.
+func (s *gitlabStandIn) project(pid string) *gitlab.Project {
+	for _, repo := range s.repos {
+		if strconv.Itoa(repo.ID) == pid || repo.PathWithNamespace == pid {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (s *gitlabStandIn) decode(r *http.Request, obj interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		s.t.Errorf("failed to decode request: %v", err)
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+// writeError writes an error response, message is either a string or the validation errors by field.
+func writeError(t *testing.T, w http.ResponseWriter, status int, message interface{}) {
+	writeJSON(t, w, status, map[string]interface{}{"message": message})
+}
+
+// writePage writes the given page (starting at 1) of total items, and sets the X-Next-Page header
+// if there are more pages.
+func writePage(t *testing.T, w http.ResponseWriter, page, total int, items func(start, end int) interface{}) {
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * conformancePageSize
+	if start > total {
+		start = total
+	}
+	end := start + conformancePageSize
+	if end >= total {
+		end = total
+	} else {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	writeJSON(t, w, http.StatusOK, items(start, end))
+}
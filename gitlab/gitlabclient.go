@@ -17,8 +17,10 @@ limitations under the License.
 package gitlab
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -47,6 +49,22 @@ type gitlabClient interface {
 	// ListGroupMembers is a wrapper for "GET /groups/{group}/members".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListGroupMembers(ctx context.Context, groupName string) ([]*gitlab.GroupMember, error)
+	// CreateGroup is a wrapper for "POST /groups".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateGroup(ctx context.Context, req *gitlab.CreateGroupOptions) (*gitlab.Group, error)
+	// UpdateGroup is a wrapper for "PUT /groups/{group}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateGroup(ctx context.Context, groupName string, req *gitlab.UpdateGroupOptions) (*gitlab.Group, error)
+	// DeleteGroup is a wrapper for "DELETE /groups/{group}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteGroup(ctx context.Context, groupName string) error
+	// AddGroupMember is a wrapper for "POST /groups/{group}/members".
+	// This function handles HTTP error wrapping.
+	AddGroupMember(ctx context.Context, groupName string, userID int, accessLevel gitlab.AccessLevelValue) error
+	// RemoveGroupMember is a wrapper for "DELETE /groups/{group}/members/{user_id}".
+	// This function handles HTTP error wrapping.
+	RemoveGroupMember(ctx context.Context, groupName string, userID int) error
 
 	// Project methods
 
@@ -97,11 +115,171 @@ type gitlabClient interface {
 	// This function handles HTTP error wrapping, and validates the server result.
 	UnshareProject(projectName string, groupID int) error
 
+	// User access related methods
+
+	// ListProjectMembers is a wrapper for "GET /projects/{project}/members".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProjectMembers(ctx context.Context, projectName string) ([]*gitlab.ProjectMember, error)
+	// GetProjectMember is a wrapper for "GET /projects/{project}/members/{user_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProjectMember(ctx context.Context, projectName string, userID int) (*gitlab.ProjectMember, error)
+	// AddProjectMember is a wrapper for "POST /projects/{project}/members".
+	// This function handles HTTP error wrapping.
+	AddProjectMember(ctx context.Context, projectName string, userID int, accessLevel gitlab.AccessLevelValue) error
+	// EditProjectMember is a wrapper for "PUT /projects/{project}/members/{user_id}".
+	// This function handles HTTP error wrapping.
+	EditProjectMember(ctx context.Context, projectName string, userID int, accessLevel gitlab.AccessLevelValue) error
+	// DeleteProjectMember is a wrapper for "DELETE /projects/{project}/members/{user_id}".
+	// This function handles HTTP error wrapping.
+	DeleteProjectMember(ctx context.Context, projectName string, userID int) error
+
+	// Branches
+
+	// ListBranches is a wrapper for "GET /projects/{project}/repository/branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListBranches(ctx context.Context, projectName string) ([]*gitlab.Branch, error)
+	// GetBranch is a wrapper for "GET /projects/{project}/repository/branches/{branch}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetBranch(ctx context.Context, projectName, branch string) (*gitlab.Branch, error)
+	// DeleteBranch is a wrapper for "DELETE /projects/{project}/repository/branches/{branch}".
+	// This function handles HTTP error wrapping.
+	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
+	DeleteBranch(ctx context.Context, projectName, branch string) error
+	// SetDefaultBranch is a wrapper for "PUT /projects/{project}", only changing the default branch.
+	// This function handles HTTP error wrapping.
+	SetDefaultBranch(ctx context.Context, projectName, branch string) error
+
+	// Protected branches
+
+	// ListProtectedBranches is a wrapper for "GET /projects/{project}/protected_branches".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProtectedBranches(ctx context.Context, projectName string) ([]*gitlab.ProtectedBranch, error)
+	// GetProtectedBranch is a wrapper for "GET /projects/{project}/protected_branches/{branch}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProtectedBranch(ctx context.Context, projectName, branch string) (*gitlab.ProtectedBranch, error)
+	// ProtectBranch is a wrapper for "POST /projects/{project}/protected_branches".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ProtectBranch(ctx context.Context, projectName string, req *gitlab.ProtectedBranch) (*gitlab.ProtectedBranch, error)
+	// UnprotectBranch is a wrapper for "DELETE /projects/{project}/protected_branches/{branch}".
+	// This function handles HTTP error wrapping.
+	UnprotectBranch(ctx context.Context, projectName, branch string) error
+
+	// Project hooks
+
+	// ListProjectHooks is a wrapper for "GET /projects/{project}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProjectHooks(ctx context.Context, projectName string) ([]*gitlab.ProjectHook, error)
+	// AddProjectHook is a wrapper for "POST /projects/{project}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	AddProjectHook(ctx context.Context, projectName string, opts *gitlab.AddProjectHookOptions) (*gitlab.ProjectHook, error)
+	// EditProjectHook is a wrapper for "PUT /projects/{project}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditProjectHook(ctx context.Context, projectName string, hookID int, opts *gitlab.EditProjectHookOptions) (*gitlab.ProjectHook, error)
+	// DeleteProjectHook is a wrapper for "DELETE /projects/{project}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteProjectHook(ctx context.Context, projectName string, hookID int) error
+
+	// Repository files
+
+	// GetFileMetaData is a wrapper for "HEAD /projects/{project}/repository/files/{file_path}".
+	// This function handles HTTP error wrapping.
+	GetFileMetaData(ctx context.Context, projectName, filePath, ref string) (*gitlab.File, error)
+	// CreateFile is a wrapper for "POST /projects/{project}/repository/files/{file_path}".
+	// This function handles HTTP error wrapping.
+	CreateFile(ctx context.Context, projectName, filePath string, opts *gitlab.CreateFileOptions) error
+	// UpdateFile is a wrapper for "PUT /projects/{project}/repository/files/{file_path}".
+	// This function handles HTTP error wrapping.
+	UpdateFile(ctx context.Context, projectName, filePath string, opts *gitlab.UpdateFileOptions) error
+	// DeleteFile is a wrapper for "DELETE /projects/{project}/repository/files/{file_path}".
+	// This function handles HTTP error wrapping.
+	DeleteFile(ctx context.Context, projectName, filePath string, opts *gitlab.DeleteFileOptions) error
+
+	// Tags
+
+	// ListTags is a wrapper for "GET /projects/{project}/repository/tags".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListTags(ctx context.Context, projectName string) ([]*gitlab.Tag, error)
+	// GetTag is a wrapper for "GET /projects/{project}/repository/tags/{tag_name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetTag(ctx context.Context, projectName, tag string) (*gitlab.Tag, error)
+	// CreateTag is a wrapper for "POST /projects/{project}/repository/tags".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateTag(ctx context.Context, projectName string, opts *gitlab.CreateTagOptions) (*gitlab.Tag, error)
+	// DeleteTag is a wrapper for "DELETE /projects/{project}/repository/tags/{tag_name}".
+	// This function handles HTTP error wrapping, and deletes the tag only if destructive actions are allowed.
+	DeleteTag(ctx context.Context, projectName, tag string) error
+
+	// Releases
+
+	// ListReleases is a wrapper for "GET /projects/{project}/releases".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListReleases(ctx context.Context, projectName string) ([]*gitlab.Release, error)
+	// GetRelease is a wrapper for "GET /projects/{project}/releases/{tag_name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRelease(ctx context.Context, projectName, tagName string) (*gitlab.Release, error)
+	// CreateRelease is a wrapper for "POST /projects/{project}/releases".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRelease(ctx context.Context, projectName string, opts *gitlab.CreateReleaseOptions) (*gitlab.Release, error)
+	// UpdateRelease is a wrapper for "PUT /projects/{project}/releases/{tag_name}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRelease(ctx context.Context, projectName, tagName string, opts *gitlab.UpdateReleaseOptions) (*gitlab.Release, error)
+	// DeleteRelease is a wrapper for "DELETE /projects/{project}/releases/{tag_name}".
+	// This function handles HTTP error wrapping.
+	DeleteRelease(ctx context.Context, projectName, tagName string) error
+	// UploadFile is a wrapper for "POST /projects/{project}/uploads".
+	// This function handles HTTP error wrapping.
+	UploadFile(ctx context.Context, projectName, fileName string, content []byte) (*gitlab.ProjectFile, error)
+	// CreateReleaseLink is a wrapper for "POST /projects/{project}/releases/{tag_name}/assets/links".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateReleaseLink(ctx context.Context, projectName, tagName string, opts *gitlab.CreateReleaseLinkOptions) (*gitlab.ReleaseLink, error)
+
+	// Commit statuses
+
+	// ListCommitStatuses is a wrapper for "GET /projects/{project}/repository/commits/{sha}/statuses".
+	// It returns the latest status of each name.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListCommitStatuses(ctx context.Context, projectName, sha string) ([]*gitlab.CommitStatus, error)
+	// SetCommitStatus is a wrapper for "POST /projects/{project}/statuses/{sha}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	SetCommitStatus(ctx context.Context, projectName, sha string, opts *gitlab.SetCommitStatusOptions) (*gitlab.CommitStatus, error)
+
+	// Merge request notes and approvals
+
+	// ListMergeRequestNotes is a wrapper for "GET /projects/{project}/merge_requests/{merge_request_iid}/notes".
+	// It returns the notes oldest first.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListMergeRequestNotes(ctx context.Context, projectName string, mrIID int) ([]*gitlab.Note, error)
+	// CreateMergeRequestNote is a wrapper for "POST /projects/{project}/merge_requests/{merge_request_iid}/notes".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateMergeRequestNote(ctx context.Context, projectName string, mrIID int, body string) (*gitlab.Note, error)
+	// UpdateMergeRequestNote is a wrapper for "PUT /projects/{project}/merge_requests/{merge_request_iid}/notes/{note_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateMergeRequestNote(ctx context.Context, projectName string, mrIID, noteID int, body string) (*gitlab.Note, error)
+	// DeleteMergeRequestNote is a wrapper for "DELETE /projects/{project}/merge_requests/{merge_request_iid}/notes/{note_id}".
+	// This function handles HTTP error wrapping.
+	DeleteMergeRequestNote(ctx context.Context, projectName string, mrIID, noteID int) error
+	// GetMergeRequestApprovals is a wrapper for "GET /projects/{project}/merge_requests/{merge_request_iid}/approvals".
+	// This function handles HTTP error wrapping.
+	GetMergeRequestApprovals(ctx context.Context, projectName string, mrIID int) (*gitlab.MergeRequestApprovals, error)
+
 	// Commits
 
 	// ListCommitsPage is a wrapper for "GET /projects/{project}/repository/commits".
 	// This function handles pagination, HTTP error wrapping.
 	ListCommitsPage(projectName, branch string, perPage int, page int) ([]*gitlab.Commit, error)
+
+	// Users
+
+	// GetUserByUsername is a wrapper for "GET /users?username={username}".
+	// This function handles HTTP error wrapping, and returns ErrNotFound if no user has the username.
+	GetUserByUsername(ctx context.Context, username string) (*gitlab.User, error)
+	// GetCurrentUser is a wrapper for "GET /user".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetCurrentUser(ctx context.Context) (*gitlab.User, error)
+	// GetCurrentPersonalAccessToken is a wrapper for "GET /personal_access_tokens/self",
+	// which is available since GitLab 15.5 for personal, project and group access tokens.
+	// This function handles HTTP error wrapping.
+	GetCurrentPersonalAccessToken(ctx context.Context) (*gitlab.PersonalAccessToken, error)
 }
 
 // gitlabClientImpl is a wrapper around *gitlab.Client, which implements higher-level methods,
@@ -123,7 +301,7 @@ func (c *gitlabClientImpl) Client() *gitlab.Client {
 func (c *gitlabClientImpl) GetGroup(ctx context.Context, groupID interface{}) (*gitlab.Group, error) {
 	apiObj, _, err := c.c.Groups.GetGroup(groupID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
 	}
 	// Validate the API object
 	if err := validateGroupAPI(apiObj); err != nil {
@@ -214,11 +392,60 @@ func (c *gitlabClientImpl) ListGroupMembers(ctx context.Context, groupName strin
 		return resp, listErr
 	})
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
 	}
 	return apiObjs, nil
 }
 
+func (c *gitlabClientImpl) CreateGroup(ctx context.Context, req *gitlab.CreateGroupOptions) (*gitlab.Group, error) {
+	// POST /groups
+	apiObj, _, err := c.c.Groups.CreateGroup(req, gitlab.WithContext(ctx))
+	return validateGroupAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) UpdateGroup(ctx context.Context, groupName string, req *gitlab.UpdateGroupOptions) (*gitlab.Group, error) {
+	// PUT /groups/{group}
+	apiObj, _, err := c.c.Groups.UpdateGroup(groupName, req, gitlab.WithContext(ctx))
+	return validateGroupAPIResp(apiObj, err)
+}
+
+func validateGroupAPIResp(apiObj *gitlab.Group, err error) (*gitlab.Group, error) {
+	// If the response contained an error, return
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// Make sure apiObj is valid
+	if err := validateGroupAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteGroup(ctx context.Context, groupName string) error {
+	// Don't allow deleting groups if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete group: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /groups/{group}
+	_, err := c.c.Groups.DeleteGroup(groupName, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) AddGroupMember(ctx context.Context, groupName string, userID int, accessLevel gitlab.AccessLevelValue) error {
+	// POST /groups/{group}/members
+	_, _, err := c.c.GroupMembers.AddGroupMember(groupName, &gitlab.AddGroupMemberOptions{
+		UserID:      &userID,
+		AccessLevel: &accessLevel,
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) RemoveGroupMember(ctx context.Context, groupName string, userID int) error {
+	// DELETE /groups/{group}/members/{user_id}
+	_, err := c.c.GroupMembers.RemoveGroupMember(groupName, userID, nil, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
 func (c *gitlabClientImpl) GetUserProject(ctx context.Context, projectName string) (*gitlab.Project, error) {
 	opts := &gitlab.GetProjectOptions{}
 	apiObj, _, err := c.c.Projects.GetProject(projectName, opts, gitlab.WithContext(ctx))
@@ -390,6 +617,488 @@ func (c *gitlabClientImpl) UnshareProject(projectName string, groupID int) error
 	return handleHTTPError(err)
 }
 
+func (c *gitlabClientImpl) ListProjectMembers(ctx context.Context, projectName string) ([]*gitlab.ProjectMember, error) {
+	apiObjs := []*gitlab.ProjectMember{}
+	opts := &gitlab.ListProjectMembersOptions{}
+	err := allProjectMemberPages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/members
+		pageObjs, resp, listErr := c.c.ProjectMembers.ListProjectMembers(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProjectMemberAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetProjectMember(ctx context.Context, projectName string, userID int) (*gitlab.ProjectMember, error) {
+	// GET /projects/{project}/members/{user_id}
+	apiObj, _, err := c.c.ProjectMembers.GetProjectMember(projectName, userID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProjectMemberAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) AddProjectMember(ctx context.Context, projectName string, userID int, accessLevel gitlab.AccessLevelValue) error {
+	// POST /projects/{project}/members
+	_, _, err := c.c.ProjectMembers.AddProjectMember(projectName, &gitlab.AddProjectMemberOptions{
+		UserID:      userID,
+		AccessLevel: &accessLevel,
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) EditProjectMember(ctx context.Context, projectName string, userID int, accessLevel gitlab.AccessLevelValue) error {
+	// PUT /projects/{project}/members/{user_id}
+	_, _, err := c.c.ProjectMembers.EditProjectMember(projectName, userID, &gitlab.EditProjectMemberOptions{
+		AccessLevel: &accessLevel,
+	}, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) DeleteProjectMember(ctx context.Context, projectName string, userID int) error {
+	// DELETE /projects/{project}/members/{user_id}
+	_, err := c.c.ProjectMembers.DeleteProjectMember(projectName, userID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListBranches(ctx context.Context, projectName string) ([]*gitlab.Branch, error) {
+	apiObjs := []*gitlab.Branch{}
+	opts := &gitlab.ListBranchesOptions{}
+	err := allBranchPages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/branches
+		pageObjs, resp, listErr := c.c.Branches.ListBranches(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetBranch(ctx context.Context, projectName, branch string) (*gitlab.Branch, error) {
+	// GET /projects/{project}/repository/branches/{branch}
+	apiObj, _, err := c.c.Branches.GetBranch(projectName, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteBranch(ctx context.Context, projectName, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /projects/{project}/repository/branches/{branch}
+	_, err := c.c.Branches.DeleteBranch(projectName, branch, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) SetDefaultBranch(ctx context.Context, projectName, branch string) error {
+	opts := &gitlab.EditProjectOptions{
+		DefaultBranch: &branch,
+	}
+	// PUT /projects/{project}
+	_, _, err := c.c.Projects.EditProject(projectName, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListProtectedBranches(ctx context.Context, projectName string) ([]*gitlab.ProtectedBranch, error) {
+	apiObjs := []*gitlab.ProtectedBranch{}
+	opts := &gitlab.ListProtectedBranchesOptions{}
+	err := allProtectedBranchPages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/protected_branches
+		pageObjs, resp, listErr := c.c.ProtectedBranches.ListProtectedBranches(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProtectedBranchAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetProtectedBranch(ctx context.Context, projectName, branch string) (*gitlab.ProtectedBranch, error) {
+	// GET /projects/{project}/protected_branches/{branch}
+	apiObj, _, err := c.c.ProtectedBranches.GetProtectedBranch(projectName, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ProtectBranch(ctx context.Context, projectName string, req *gitlab.ProtectedBranch) (*gitlab.ProtectedBranch, error) {
+	opts := &gitlab.ProtectRepositoryBranchesOptions{
+		Name:                      &req.Name,
+		AllowForcePush:            &req.AllowForcePush,
+		CodeOwnerApprovalRequired: &req.CodeOwnerApprovalRequired,
+		AllowedToPush:             branchPermissionsFromAPI(req.PushAccessLevels),
+		AllowedToMerge:            branchPermissionsFromAPI(req.MergeAccessLevels),
+		AllowedToUnprotect:        branchPermissionsFromAPI(req.UnprotectAccessLevels),
+	}
+	// POST /projects/{project}/protected_branches
+	apiObj, _, err := c.c.ProtectedBranches.ProtectRepositoryBranches(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedBranchAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UnprotectBranch(ctx context.Context, projectName, branch string) error {
+	// DELETE /projects/{project}/protected_branches/{branch}
+	_, err := c.c.ProtectedBranches.UnprotectRepositoryBranches(projectName, branch, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListProjectHooks(ctx context.Context, projectName string) ([]*gitlab.ProjectHook, error) {
+	apiObjs := []*gitlab.ProjectHook{}
+	opts := &gitlab.ListProjectHooksOptions{}
+	err := allProjectHookPages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/hooks
+		pageObjs, resp, listErr := c.c.Projects.ListProjectHooks(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProjectHookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) AddProjectHook(ctx context.Context, projectName string, opts *gitlab.AddProjectHookOptions) (*gitlab.ProjectHook, error) {
+	// POST /projects/{project}/hooks
+	apiObj, _, err := c.c.Projects.AddProjectHook(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProjectHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) EditProjectHook(ctx context.Context, projectName string, hookID int, opts *gitlab.EditProjectHookOptions) (*gitlab.ProjectHook, error) {
+	// PUT /projects/{project}/hooks/{hook_id}
+	apiObj, _, err := c.c.Projects.EditProjectHook(projectName, hookID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProjectHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteProjectHook(ctx context.Context, projectName string, hookID int) error {
+	// DELETE /projects/{project}/hooks/{hook_id}
+	_, err := c.c.Projects.DeleteProjectHook(projectName, hookID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) GetFileMetaData(ctx context.Context, projectName, filePath, ref string) (*gitlab.File, error) {
+	// HEAD /projects/{project}/repository/files/{file_path}
+	apiObj, _, err := c.c.RepositoryFiles.GetFileMetaData(projectName, filePath, &gitlab.GetFileMetaDataOptions{Ref: &ref}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) CreateFile(ctx context.Context, projectName, filePath string, opts *gitlab.CreateFileOptions) error {
+	// POST /projects/{project}/repository/files/{file_path}
+	_, _, err := c.c.RepositoryFiles.CreateFile(projectName, filePath, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) UpdateFile(ctx context.Context, projectName, filePath string, opts *gitlab.UpdateFileOptions) error {
+	// PUT /projects/{project}/repository/files/{file_path}
+	_, _, err := c.c.RepositoryFiles.UpdateFile(projectName, filePath, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) DeleteFile(ctx context.Context, projectName, filePath string, opts *gitlab.DeleteFileOptions) error {
+	// DELETE /projects/{project}/repository/files/{file_path}
+	_, err := c.c.RepositoryFiles.DeleteFile(projectName, filePath, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListTags(ctx context.Context, projectName string) ([]*gitlab.Tag, error) {
+	apiObjs := []*gitlab.Tag{}
+	opts := &gitlab.ListTagsOptions{}
+	err := allTagPages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/tags
+		pageObjs, resp, listErr := c.c.Tags.ListTags(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateTagAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetTag(ctx context.Context, projectName, tag string) (*gitlab.Tag, error) {
+	// GET /projects/{project}/repository/tags/{tag_name}
+	apiObj, _, err := c.c.Tags.GetTag(projectName, tag, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) CreateTag(ctx context.Context, projectName string, opts *gitlab.CreateTagOptions) (*gitlab.Tag, error) {
+	// POST /projects/{project}/repository/tags
+	apiObj, _, err := c.c.Tags.CreateTag(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteTag(ctx context.Context, projectName, tag string) error {
+	// Don't allow deleting tags if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete tag: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /projects/{project}/repository/tags/{tag_name}
+	_, err := c.c.Tags.DeleteTag(projectName, tag, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListReleases(ctx context.Context, projectName string) ([]*gitlab.Release, error) {
+	apiObjs := []*gitlab.Release{}
+	opts := &gitlab.ListReleasesOptions{}
+	err := allReleasePages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/releases
+		pageObjs, resp, listErr := c.c.Releases.ListReleases(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateReleaseAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetRelease(ctx context.Context, projectName, tagName string) (*gitlab.Release, error) {
+	// GET /projects/{project}/releases/{tag_name}
+	apiObj, _, err := c.c.Releases.GetRelease(projectName, tagName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) CreateRelease(ctx context.Context, projectName string, opts *gitlab.CreateReleaseOptions) (*gitlab.Release, error) {
+	// POST /projects/{project}/releases
+	apiObj, _, err := c.c.Releases.CreateRelease(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UpdateRelease(ctx context.Context, projectName, tagName string, opts *gitlab.UpdateReleaseOptions) (*gitlab.Release, error) {
+	// PUT /projects/{project}/releases/{tag_name}
+	apiObj, _, err := c.c.Releases.UpdateRelease(projectName, tagName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteRelease(ctx context.Context, projectName, tagName string) error {
+	// DELETE /projects/{project}/releases/{tag_name}
+	_, _, err := c.c.Releases.DeleteRelease(projectName, tagName, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) UploadFile(ctx context.Context, projectName, fileName string, content []byte) (*gitlab.ProjectFile, error) {
+	// POST /projects/{project}/uploads
+	apiObj, _, err := c.c.Projects.UploadFile(projectName, bytes.NewReader(content), fileName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) CreateReleaseLink(ctx context.Context, projectName, tagName string, opts *gitlab.CreateReleaseLinkOptions) (*gitlab.ReleaseLink, error) {
+	// POST /projects/{project}/releases/{tag_name}/assets/links
+	apiObj, _, err := c.c.ReleaseLinks.CreateReleaseLink(projectName, tagName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseLinkAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListCommitStatuses(ctx context.Context, projectName, sha string) ([]*gitlab.CommitStatus, error) {
+	apiObjs := []*gitlab.CommitStatus{}
+	opts := &gitlab.GetCommitStatusesOptions{}
+	err := allCommitStatusPages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/commits/{sha}/statuses
+		pageObjs, resp, listErr := c.c.Commits.GetCommitStatuses(projectName, sha, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateCommitStatusAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) SetCommitStatus(ctx context.Context, projectName, sha string, opts *gitlab.SetCommitStatusOptions) (*gitlab.CommitStatus, error) {
+	// POST /projects/{project}/statuses/{sha}
+	apiObj, _, err := c.c.Commits.SetCommitStatus(projectName, sha, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateCommitStatusAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListMergeRequestNotes(ctx context.Context, projectName string, mrIID int) ([]*gitlab.Note, error) {
+	apiObjs := []*gitlab.Note{}
+	opts := &gitlab.ListMergeRequestNotesOptions{
+		OrderBy: gitlab.String("created_at"),
+		Sort:    gitlab.String("asc"),
+	}
+	err := allMergeRequestNotePages(opts, func() (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}/notes
+		pageObjs, resp, listErr := c.c.Notes.ListMergeRequestNotes(projectName, mrIID, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateNoteAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateMergeRequestNote(ctx context.Context, projectName string, mrIID int, body string) (*gitlab.Note, error) {
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/notes
+	opts := &gitlab.CreateMergeRequestNoteOptions{Body: &body}
+	apiObj, _, err := c.c.Notes.CreateMergeRequestNote(projectName, mrIID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateNoteAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UpdateMergeRequestNote(ctx context.Context, projectName string, mrIID, noteID int, body string) (*gitlab.Note, error) {
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}/notes/{note_id}
+	opts := &gitlab.UpdateMergeRequestNoteOptions{Body: &body}
+	apiObj, _, err := c.c.Notes.UpdateMergeRequestNote(projectName, mrIID, noteID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateNoteAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteMergeRequestNote(ctx context.Context, projectName string, mrIID, noteID int) error {
+	// DELETE /projects/{project}/merge_requests/{merge_request_iid}/notes/{note_id}
+	_, err := c.c.Notes.DeleteMergeRequestNote(projectName, mrIID, noteID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) GetMergeRequestApprovals(ctx context.Context, projectName string, mrIID int) (*gitlab.MergeRequestApprovals, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/approvals
+	apiObj, _, err := c.c.MergeRequestApprovals.GetConfiguration(projectName, mrIID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
 func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, perPage int, page int) ([]*gitlab.Commit, error) {
 	apiObjs := make([]*gitlab.Commit, 0)
 
@@ -418,3 +1127,40 @@ func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, pe
 	}
 	return apiObjs, nil
 }
+
+func (c *gitlabClientImpl) GetUserByUsername(ctx context.Context, username string) (*gitlab.User, error) {
+	// GET /users?username={username}
+	apiObjs, _, err := c.c.Users.ListUsers(&gitlab.ListUsersOptions{Username: &username}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if len(apiObjs) == 0 {
+		return nil, fmt.Errorf("user %q: %w", username, gitprovider.ErrNotFound)
+	}
+	return apiObjs[0], nil
+}
+
+func (c *gitlabClientImpl) GetCurrentUser(ctx context.Context) (*gitlab.User, error) {
+	// GET /user
+	apiObj, _, err := c.c.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetCurrentPersonalAccessToken(ctx context.Context) (*gitlab.PersonalAccessToken, error) {
+	// GET /personal_access_tokens/self, which isn't wrapped by go-gitlab yet
+	req, err := c.c.NewRequest(http.MethodGet, "personal_access_tokens/self", nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	apiObj := &gitlab.PersonalAccessToken{}
+	if _, err := c.c.Do(req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
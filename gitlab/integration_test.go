@@ -809,6 +809,10 @@ var _ = Describe("GitLab Provider", func() {
 		err = userRepo.Branches().Create(ctx, branchName, latestCommit.Get().Sha)
 		Expect(err).ToNot(HaveOccurred())
 
+		branch, err := userRepo.Branches().Get(ctx, branchName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(branch.Get().Sha).To(Equal(latestCommit.Get().Sha))
+
 		path := "setup/config.txt"
 		content := "yaml content 1"
 		files := []gitprovider.CommitFile{
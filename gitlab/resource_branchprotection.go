@@ -0,0 +1,197 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newBranchProtection(c *BranchProtectionClient, apiObj *gitlab.ProtectedBranch) *branchProtection {
+	return &branchProtection{
+		p: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	p gitlab.ProtectedBranch
+	c *BranchProtectionClient
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	return branchProtectionFromAPI(&bp.p)
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateBranchProtectionInfo(info); err != nil {
+		return err
+	}
+	branchProtectionInfoToAPIObj(&info, &bp.p)
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return &bp.p
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(ctx context.Context) error {
+	// GitLab can't update a protected branch, hence unprotect and protect it again
+	if err := bp.Delete(ctx); err != nil {
+		return err
+	}
+	return bp.createIntoSelf(ctx)
+}
+
+// Delete removes the protection of the branch.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/protected_branches/{branch}
+	return bp.c.c.UnprotectBranch(ctx, getRepoPath(bp.c.ref), bp.p.Name)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (bp *branchProtection) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := bp.c.c.GetProtectedBranch(ctx, getRepoPath(bp.c.ref), bp.p.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, bp.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if branchProtectionFromAPI(&bp.p).Equals(branchProtectionFromAPI(actual)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, bp.Update(ctx)
+}
+
+func (bp *branchProtection) createIntoSelf(ctx context.Context) error {
+	// POST /projects/{project}/protected_branches
+	apiObj, err := bp.c.c.ProtectBranch(ctx, getRepoPath(bp.c.ref), &bp.p)
+	if err != nil {
+		return err
+	}
+	bp.p = *apiObj
+	return nil
+}
+
+func validateProtectedBranchAPI(apiObj *gitlab.ProtectedBranch) error {
+	return validateAPIObject("GitLab.ProtectedBranch", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// validateBranchProtectionInfo makes sure info only uses settings GitLab protected branches support.
+// info is expected to be defaulted.
+func validateBranchProtectionInfo(info gitprovider.BranchProtectionInfo) error {
+	if info.RequiredApprovals != nil && *info.RequiredApprovals != 0 {
+		return fmt.Errorf("gitlab protected branches don't support required approvals: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if len(info.RequiredStatusChecks) != 0 {
+		return fmt.Errorf("gitlab protected branches don't support required status checks: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.EnforceAdmins != nil && !*info.EnforceAdmins {
+		return fmt.Errorf("gitlab protected branches always apply to administrators: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+func branchProtectionFromAPI(apiObj *gitlab.ProtectedBranch) gitprovider.BranchProtectionInfo {
+	return gitprovider.BranchProtectionInfo{
+		Branch:            apiObj.Name,
+		RequiredApprovals: gitprovider.IntVar(0),
+		AllowForcePush:    gitprovider.BoolVar(apiObj.AllowForcePush),
+		EnforceAdmins:     gitprovider.BoolVar(true),
+	}
+}
+
+func branchProtectionToAPI(info *gitprovider.BranchProtectionInfo) *gitlab.ProtectedBranch {
+	p := &gitlab.ProtectedBranch{}
+	branchProtectionInfoToAPIObj(info, p)
+	return p
+}
+
+func branchProtectionInfoToAPIObj(info *gitprovider.BranchProtectionInfo, apiObj *gitlab.ProtectedBranch) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Name = info.Branch
+	// optional fields
+	if info.AllowForcePush != nil {
+		apiObj.AllowForcePush = *info.AllowForcePush
+	}
+}
+
+// branchPermissionsFromAPI converts the access levels of a protected branch to the options
+// granting them when protecting the branch again. nil means the GitLab defaults.
+func branchPermissionsFromAPI(levels []*gitlab.BranchAccessDescription) *[]*gitlab.BranchPermissionOptions {
+	if len(levels) == 0 {
+		return nil
+	}
+	opts := make([]*gitlab.BranchPermissionOptions, 0, len(levels))
+	for _, level := range levels {
+		opt := &gitlab.BranchPermissionOptions{}
+		switch {
+		case level.UserID != 0:
+			opt.UserID = gitlab.Int(level.UserID)
+		case level.GroupID != 0:
+			opt.GroupID = gitlab.Int(level.GroupID)
+		default:
+			opt.AccessLevel = gitlab.AccessLevel(level.AccessLevel)
+		}
+		opts = append(opts, opt)
+	}
+	return &opts
+}
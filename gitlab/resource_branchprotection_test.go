@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_branchPermissionsFromAPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []*gitlab.BranchAccessDescription
+		want   *[]*gitlab.BranchPermissionOptions
+	}{
+		{
+			name: "defaults",
+			want: nil,
+		},
+		{
+			name: "mixed",
+			levels: []*gitlab.BranchAccessDescription{
+				{AccessLevel: gitlab.MaintainerPermissions},
+				{AccessLevel: gitlab.DeveloperPermissions, UserID: 4},
+				{AccessLevel: gitlab.DeveloperPermissions, GroupID: 7},
+			},
+			want: &[]*gitlab.BranchPermissionOptions{
+				{AccessLevel: gitlab.AccessLevel(gitlab.MaintainerPermissions)},
+				{UserID: gitlab.Int(4)},
+				{GroupID: gitlab.Int(7)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := branchPermissionsFromAPI(tt.levels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("branchPermissionsFromAPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateBranchProtectionInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    gitprovider.BranchProtectionInfo
+		wantErr error
+	}{
+		{
+			name: "force push",
+			info: gitprovider.BranchProtectionInfo{Branch: "main", AllowForcePush: gitprovider.BoolVar(true)},
+		},
+		{
+			name:    "required approvals",
+			info:    gitprovider.BranchProtectionInfo{Branch: "main", RequiredApprovals: gitprovider.IntVar(1)},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:    "required status checks",
+			info:    gitprovider.BranchProtectionInfo{Branch: "main", RequiredStatusChecks: []string{"ci"}},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:    "admins bypass",
+			info:    gitprovider.BranchProtectionInfo{Branch: "main", EnforceAdmins: gitprovider.BoolVar(false)},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.Default()
+			if err := validateBranchProtectionInfo(tt.info); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateBranchProtectionInfo() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newCommitStatus(c *CommitStatusClient, apiObj *gitlab.CommitStatus) *commitStatus {
+	return &commitStatus{
+		s: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.CommitStatus = &commitStatus{}
+
+type commitStatus struct {
+	s gitlab.CommitStatus
+	c *CommitStatusClient
+}
+
+func (s *commitStatus) Get() gitprovider.CommitStatusInfo {
+	return commitStatusFromAPI(&s.s)
+}
+
+func (s *commitStatus) APIObject() interface{} {
+	return &s.s
+}
+
+func validateCommitStatusAPI(apiObj *gitlab.CommitStatus) error {
+	return validateAPIObject("GitLab.CommitStatus", func(validator validation.Validator) {
+		// Make sure status and name are populated as per
+		// https://docs.gitlab.com/ee/api/commits.html#list-the-statuses-of-a-commit
+		if apiObj.Status == "" {
+			validator.Required("Status")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// commitStatusFromAPI converts a GitLab commit status to a CommitStatusInfo.
+// GitLab has more states than gitprovider, see commitStatusStateFromAPI.
+func commitStatusFromAPI(apiObj *gitlab.CommitStatus) gitprovider.CommitStatusInfo {
+	info := gitprovider.CommitStatusInfo{
+		State:   commitStatusStateFromAPI(gitlab.BuildStateValue(apiObj.Status)),
+		Context: apiObj.Name,
+	}
+	if apiObj.Description != "" {
+		info.Description = &apiObj.Description
+	}
+	if apiObj.TargetURL != "" {
+		info.TargetURL = &apiObj.TargetURL
+	}
+	return info
+}
+
+// commitStatusStateFromAPI maps failed to failure and canceled to error. Skipped jobs didn't
+// have to run, hence they are a success, and all other states are pending.
+func commitStatusStateFromAPI(state gitlab.BuildStateValue) gitprovider.CommitStatusState {
+	switch state {
+	case gitlab.Success, gitlab.Skipped:
+		return gitprovider.CommitStatusStateSuccess
+	case gitlab.Failed:
+		return gitprovider.CommitStatusStateFailure
+	case gitlab.Canceled:
+		return gitprovider.CommitStatusStateError
+	default:
+		return gitprovider.CommitStatusStatePending
+	}
+}
+
+// commitStatusStateToAPI maps failure to failed and error to canceled.
+func commitStatusStateToAPI(state gitprovider.CommitStatusState) gitlab.BuildStateValue {
+	switch state {
+	case gitprovider.CommitStatusStateSuccess:
+		return gitlab.Success
+	case gitprovider.CommitStatusStateFailure:
+		return gitlab.Failed
+	case gitprovider.CommitStatusStateError:
+		return gitlab.Canceled
+	default:
+		return gitlab.Pending
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_commitStatusState(t *testing.T) {
+	// All gitprovider states survive a round trip through the GitLab states
+	for _, state := range []gitprovider.CommitStatusState{
+		gitprovider.CommitStatusStatePending,
+		gitprovider.CommitStatusStateSuccess,
+		gitprovider.CommitStatusStateFailure,
+		gitprovider.CommitStatusStateError,
+	} {
+		if got := commitStatusStateFromAPI(commitStatusStateToAPI(state)); got != state {
+			t.Errorf("commitStatusStateFromAPI(commitStatusStateToAPI(%q)) = %q", state, got)
+		}
+	}
+
+	tests := []struct {
+		state gitlab.BuildStateValue
+		want  gitprovider.CommitStatusState
+	}{
+		{state: gitlab.Running, want: gitprovider.CommitStatusStatePending},
+		{state: gitlab.Manual, want: gitprovider.CommitStatusStatePending},
+		{state: gitlab.Skipped, want: gitprovider.CommitStatusStateSuccess},
+	}
+	for _, tt := range tests {
+		if got := commitStatusStateFromAPI(tt.state); got != tt.want {
+			t.Errorf("commitStatusStateFromAPI(%q) = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+}
@@ -149,8 +149,9 @@ func validateDeployKeyAPI(apiObj *gitlab.ProjectDeployKey) error {
 
 func deployKeyFromAPI(apiObj *gitlab.ProjectDeployKey) gitprovider.DeployKeyInfo {
 	return gitprovider.DeployKeyInfo{
-		Name: apiObj.Title,
-		Key:  []byte(apiObj.Key),
+		Name:     apiObj.Title,
+		Key:      []byte(apiObj.Key),
+		ReadOnly: gitprovider.BoolVar(!apiObj.CanPush),
 	}
 }
 
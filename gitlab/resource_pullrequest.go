@@ -24,10 +24,33 @@ import (
 // The value of the "State" field of a gitlab merge request after it has been merged"
 const mergedState = "merged"
 
-func newPullRequest(ctx *clientContext, apiObj *gitlab.MergeRequest) *pullrequest {
+const (
+	// closedState is the value of the "State" field of a closed gitlab merge request.
+	closedState = "closed"
+
+	// mergeStatusCanBeMerged and mergeStatusCannotBeMerged are the values of the "MergeStatus"
+	// field once gitlab has checked whether a merge request has conflicts.
+	mergeStatusCanBeMerged    = "can_be_merged"
+	mergeStatusCannotBeMerged = "cannot_be_merged"
+
+	// draftTitlePrefix marks a merge request as a draft.
+	draftTitlePrefix = "Draft: "
+)
+
+func newPullRequest(ctx *clientContext, ref gitprovider.RepositoryRef, apiObj *gitlab.MergeRequest) *pullrequest {
 	return &pullrequest{
 		clientContext: ctx,
 		pr:            *apiObj,
+		comments: &PullRequestCommentClient{
+			clientContext: ctx,
+			ref:           ref,
+			iid:           apiObj.IID,
+		},
+		reviews: &PullRequestReviewClient{
+			clientContext: ctx,
+			ref:           ref,
+			iid:           apiObj.IID,
+		},
 	}
 }
 
@@ -37,6 +60,9 @@ type pullrequest struct {
 	*clientContext
 
 	pr gitlab.MergeRequest
+
+	comments *PullRequestCommentClient
+	reviews  *PullRequestReviewClient
 }
 
 func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
@@ -47,10 +73,52 @@ func (pr *pullrequest) APIObject() interface{} {
 	return &pr.pr
 }
 
+// Comments gives access to the general comments of this merge request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return pr.comments
+}
+
+// Reviews gives access to the approvals of this merge request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return pr.reviews
+}
+
+// pullrequestFromAPI converts a gitlab merge request to a PullRequestInfo. Locked merge requests
+// are reported as open.
 func pullrequestFromAPI(apiObj *gitlab.MergeRequest) gitprovider.PullRequestInfo {
-	return gitprovider.PullRequestInfo{
-		Merged: apiObj.State == mergedState,
-		Number: apiObj.IID,
-		WebURL: apiObj.WebURL,
+	info := gitprovider.PullRequestInfo{
+		Merged:       apiObj.State == mergedState,
+		Number:       apiObj.IID,
+		WebURL:       apiObj.WebURL,
+		Title:        apiObj.Title,
+		Description:  apiObj.Description,
+		State:        gitprovider.PullRequestStateOpen,
+		Draft:        apiObj.Draft || apiObj.WorkInProgress,
+		SourceBranch: apiObj.SourceBranch,
+		TargetBranch: apiObj.TargetBranch,
+		HeadSHA:      apiObj.SHA,
+		BaseSHA:      apiObj.DiffRefs.BaseSha,
+	}
+	switch apiObj.State {
+	case mergedState:
+		info.State = gitprovider.PullRequestStateMerged
+	case closedState:
+		info.State = gitprovider.PullRequestStateClosed
+	}
+	switch apiObj.MergeStatus {
+	case mergeStatusCanBeMerged:
+		info.Mergeable = gitprovider.BoolVar(true)
+	case mergeStatusCannotBeMerged:
+		info.Mergeable = gitprovider.BoolVar(false)
+	}
+	if apiObj.Author != nil {
+		info.Author = apiObj.Author.Username
+	}
+	if apiObj.CreatedAt != nil {
+		info.CreatedAt = *apiObj.CreatedAt
+	}
+	if apiObj.UpdatedAt != nil {
+		info.UpdatedAt = *apiObj.UpdatedAt
 	}
+	return info
 }
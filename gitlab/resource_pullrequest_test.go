@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_pullrequestFromAPI(t *testing.T) {
+	tests := []struct {
+		name          string
+		mr            gitlab.MergeRequest
+		wantState     gitprovider.PullRequestState
+		wantMerged    bool
+		wantMergeable *bool
+	}{
+		{
+			name:          "opened",
+			mr:            gitlab.MergeRequest{State: "opened", MergeStatus: mergeStatusCanBeMerged},
+			wantState:     gitprovider.PullRequestStateOpen,
+			wantMergeable: gitprovider.BoolVar(true),
+		},
+		{
+			name:          "locked",
+			mr:            gitlab.MergeRequest{State: "locked", MergeStatus: mergeStatusCannotBeMerged},
+			wantState:     gitprovider.PullRequestStateOpen,
+			wantMergeable: gitprovider.BoolVar(false),
+		},
+		{
+			name:      "closed",
+			mr:        gitlab.MergeRequest{State: closedState, MergeStatus: mergeStatusChecking},
+			wantState: gitprovider.PullRequestStateClosed,
+		},
+		{
+			name:       "merged",
+			mr:         gitlab.MergeRequest{State: mergedState},
+			wantState:  gitprovider.PullRequestStateMerged,
+			wantMerged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pullrequestFromAPI(&tt.mr)
+			if got.State != tt.wantState || got.Merged != tt.wantMerged {
+				t.Errorf("pullrequestFromAPI() state = %q, merged = %v, want %q, %v", got.State, got.Merged, tt.wantState, tt.wantMerged)
+			}
+			if (got.Mergeable == nil) != (tt.wantMergeable == nil) || (got.Mergeable != nil && *got.Mergeable != *tt.wantMergeable) {
+				t.Errorf("pullrequestFromAPI() mergeable = %v, want %v", got.Mergeable, tt.wantMergeable)
+			}
+		})
+	}
+}
+
+func Test_pullRequestCommentFromAPI(t *testing.T) {
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	note := &gitlab.Note{ID: 42, Body: "LGTM", CreatedAt: &created}
+	note.Author.Username = "alice"
+
+	want := gitprovider.PullRequestCommentInfo{ID: 42, Body: "LGTM", Author: "alice", CreatedAt: created}
+	if got := pullRequestCommentFromAPI(note); got != want {
+		t.Errorf("pullRequestCommentFromAPI() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newPullRequestReview(c *PullRequestReviewClient, apiObj *gitlab.MergeRequestApproverUser) *pullRequestReview {
+	return &pullRequestReview{
+		a: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+type pullRequestReview struct {
+	a gitlab.MergeRequestApproverUser
+	c *PullRequestReviewClient
+}
+
+func (r *pullRequestReview) Get() gitprovider.PullRequestReviewInfo {
+	return pullRequestReviewFromAPI(&r.a)
+}
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return &r.a
+}
+
+// pullRequestReviewFromAPI converts a GitLab approver to a PullRequestReviewInfo. GitLab
+// doesn't report when the merge request was approved, hence SubmittedAt is left unset.
+func pullRequestReviewFromAPI(apiObj *gitlab.MergeRequestApproverUser) gitprovider.PullRequestReviewInfo {
+	return gitprovider.PullRequestReviewInfo{
+		Reviewer: apiObj.User.Username,
+		State:    gitprovider.PullRequestReviewStateApproved,
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newRelease(c *ReleaseClient, apiObj *gitlab.Release) *release {
+	return &release{
+		r: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Release = &release{}
+
+type release struct {
+	r gitlab.Release
+	c *ReleaseClient
+}
+
+func (r *release) Get() gitprovider.ReleaseInfo {
+	return releaseFromAPI(&r.r)
+}
+
+func (r *release) APIObject() interface{} {
+	return &r.r
+}
+
+// validateReleaseInfo returns ErrNoProviderSupport for drafts and pre-releases.
+func validateReleaseInfo(info gitprovider.ReleaseInfo) error {
+	if info.Draft != nil && *info.Draft {
+		return fmt.Errorf("draft releases: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.Prerelease != nil && *info.Prerelease {
+		return fmt.Errorf("pre-releases: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+func validateReleaseAPI(apiObj *gitlab.Release) error {
+	return validateAPIObject("GitLab.Release", func(validator validation.Validator) {
+		if apiObj.TagName == "" {
+			validator.Required("TagName")
+		}
+		for _, link := range apiObj.Assets.Links {
+			if link.Name == "" {
+				validator.Required("Assets.Links.Name")
+			}
+		}
+	})
+}
+
+func validateReleaseLinkAPI(apiObj *gitlab.ReleaseLink) error {
+	return validateAPIObject("GitLab.ReleaseLink", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// releaseFromAPI reports GitLab releases as published, as GitLab has no drafts or pre-releases.
+func releaseFromAPI(apiObj *gitlab.Release) gitprovider.ReleaseInfo {
+	info := gitprovider.ReleaseInfo{
+		TagName:    apiObj.TagName,
+		Name:       gitprovider.StringVar(apiObj.Name),
+		Notes:      gitprovider.StringVar(apiObj.Description),
+		Draft:      gitprovider.BoolVar(false),
+		Prerelease: gitprovider.BoolVar(false),
+	}
+	if apiObj.Commit.ID != "" {
+		info.Target = gitprovider.StringVar(apiObj.Commit.ID)
+	}
+	for _, link := range apiObj.Assets.Links {
+		info.Assets = append(info.Assets, releaseAssetFromAPI(link, 0))
+	}
+	return info
+}
+
+// releaseAssetFromAPI returns the asset of a release link. GitLab doesn't report the size of
+// linked files, so it's only known right after uploading.
+func releaseAssetFromAPI(apiObj *gitlab.ReleaseLink, size int) gitprovider.ReleaseAsset {
+	url := apiObj.DirectAssetURL
+	if url == "" {
+		url = apiObj.URL
+	}
+	return gitprovider.ReleaseAsset{
+		Name: apiObj.Name,
+		URL:  url,
+		Size: size,
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_releaseFromAPI(t *testing.T) {
+	apiObj := &gitlab.Release{
+		TagName:     "v1.0.0",
+		Name:        "v1.0.0",
+		Description: "Fixes",
+		Commit:      gitlab.Commit{ID: "abc"},
+	}
+	apiObj.Assets.Links = []*gitlab.ReleaseLink{
+		{Name: "manifests.yaml", URL: "https://gitlab.com/group/repo/uploads/1/manifests.yaml"},
+		{Name: "sbom.json", URL: "https://example.com/sbom.json", DirectAssetURL: "https://gitlab.com/group/repo/-/releases/v1.0.0/downloads/sbom.json"},
+	}
+	want := gitprovider.ReleaseInfo{
+		TagName:    "v1.0.0",
+		Target:     gitprovider.StringVar("abc"),
+		Name:       gitprovider.StringVar("v1.0.0"),
+		Notes:      gitprovider.StringVar("Fixes"),
+		Draft:      gitprovider.BoolVar(false),
+		Prerelease: gitprovider.BoolVar(false),
+		Assets: []gitprovider.ReleaseAsset{
+			{Name: "manifests.yaml", URL: "https://gitlab.com/group/repo/uploads/1/manifests.yaml"},
+			{Name: "sbom.json", URL: "https://gitlab.com/group/repo/-/releases/v1.0.0/downloads/sbom.json"},
+		},
+	}
+	if got := releaseFromAPI(apiObj); !reflect.DeepEqual(got, want) {
+		t.Errorf("releaseFromAPI() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_validateReleaseInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    gitprovider.ReleaseInfo
+		wantErr error
+	}{
+		{
+			name: "published",
+			info: gitprovider.ReleaseInfo{TagName: "v1.0.0", Draft: gitprovider.BoolVar(false), Prerelease: gitprovider.BoolVar(false)},
+		},
+		{
+			name:    "draft",
+			info:    gitprovider.ReleaseInfo{TagName: "v1.0.0", Draft: gitprovider.BoolVar(true)},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name:    "pre-release",
+			info:    gitprovider.ReleaseInfo{TagName: "v1.0.0", Prerelease: gitprovider.BoolVar(true)},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateReleaseInfo(tt.info); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateReleaseInfo() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -35,6 +35,10 @@ func newUserProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovide
 			clientContext: ctx,
 			ref:           ref,
 		},
+		userAccess: &UserAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -43,10 +47,30 @@ func newUserProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovide
 			clientContext: ctx,
 			ref:           ref,
 		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		statuses: &CommitStatusClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		files: &FileClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -66,12 +90,18 @@ type userProject struct {
 	p   gogitlab.Project
 	ref gitprovider.RepositoryRef
 
-	deployKeys   *DeployKeyClient
-	commits      *CommitClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	files        *FileClient
-	trees        *TreeClient
+	deployKeys        *DeployKeyClient
+	userAccess        *UserAccessClient
+	commits           *CommitClient
+	branches          *BranchClient
+	branchProtections *BranchProtectionClient
+	webhooks          *WebhookClient
+	pullRequests      *PullRequestClient
+	tags              *TagClient
+	releases          *ReleaseClient
+	statuses          *CommitStatusClient
+	files             *FileClient
+	trees             *TreeClient
 }
 
 func (p *userProject) Get() gitprovider.RepositoryInfo {
@@ -98,6 +128,10 @@ func (p *userProject) DeployKeys() gitprovider.DeployKeyClient {
 	return p.deployKeys
 }
 
+func (p *userProject) UserAccess() gitprovider.UserAccessClient {
+	return p.userAccess
+}
+
 func (p *userProject) Commits() gitprovider.CommitClient {
 	return p.commits
 }
@@ -106,10 +140,30 @@ func (p *userProject) Branches() gitprovider.BranchClient {
 	return p.branches
 }
 
+func (p *userProject) BranchProtections() gitprovider.BranchProtectionClient {
+	return p.branchProtections
+}
+
+func (p *userProject) Webhooks() gitprovider.WebhookClient {
+	return p.webhooks
+}
+
 func (p *userProject) PullRequests() gitprovider.PullRequestClient {
 	return p.pullRequests
 }
 
+func (p *userProject) Tags() gitprovider.TagClient {
+	return p.tags
+}
+
+func (p *userProject) Releases() gitprovider.ReleaseClient {
+	return p.releases
+}
+
+func (p *userProject) Statuses() gitprovider.CommitStatusClient {
+	return p.statuses
+}
+
 func (p *userProject) Files() gitprovider.FileClient {
 	return p.files
 }
@@ -206,6 +260,14 @@ func (r *orgRepository) Branches() gitprovider.BranchClient {
 	return r.branches
 }
 
+func (r *orgRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *orgRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
 // Reconcile makes sure the desired state in this object (called "req" here) becomes
 // the actual state in the backing Git provider.
 //
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUser(ctx *clientContext, apiObj *gitlab.User) *user {
+	return &user{
+		clientContext: ctx,
+		u:             *apiObj,
+	}
+}
+
+var _ gitprovider.User = &user{}
+
+type user struct {
+	*clientContext
+
+	u gitlab.User
+}
+
+func (u *user) Get() gitprovider.UserInfo {
+	return userFromAPI(&u.u)
+}
+
+func (u *user) APIObject() interface{} {
+	return &u.u
+}
+
+func (u *user) User() gitprovider.UserRef {
+	return gitprovider.UserRef{
+		Domain:    u.domain,
+		UserLogin: u.u.Username,
+	}
+}
+
+func validateUserAPI(apiObj *gitlab.User) error {
+	return validateAPIObject("GitLab.User", func(validator validation.Validator) {
+		if apiObj.Username == "" {
+			validator.Required("Username")
+		}
+	})
+}
+
+func userFromAPI(apiObj *gitlab.User) gitprovider.UserInfo {
+	// Email is only returned for the authenticated user, or to administrators
+	email := apiObj.Email
+	if email == "" {
+		email = apiObj.PublicEmail
+	}
+	return gitprovider.UserInfo{
+		Login:     apiObj.Username,
+		Name:      apiObj.Name,
+		Email:     email,
+		AvatarURL: apiObj.AvatarURL,
+		WebURL:    apiObj.WebURL,
+	}
+}
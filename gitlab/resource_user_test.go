@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func Test_userFromAPI(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      gitlab.User
+		wantEmail string
+	}{
+		{
+			name:      "private email",
+			user:      gitlab.User{Username: "alice", Email: "alice@example.com", PublicEmail: "public@example.com"},
+			wantEmail: "alice@example.com",
+		},
+		{
+			name:      "public email",
+			user:      gitlab.User{Username: "alice", PublicEmail: "public@example.com"},
+			wantEmail: "public@example.com",
+		},
+		{
+			name: "no email",
+			user: gitlab.User{Username: "alice"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userFromAPI(&tt.user)
+			if got.Login != "alice" {
+				t.Errorf("userFromAPI() Login = %q, want %q", got.Login, "alice")
+			}
+			if got.Email != tt.wantEmail {
+				t.Errorf("userFromAPI() Email = %q, want %q", got.Email, tt.wantEmail)
+			}
+		})
+	}
+}
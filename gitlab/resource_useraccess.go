@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newUserAccess(c *UserAccessClient, ua gitprovider.UserAccessInfo, apiObj *gitlab.ProjectMember) *userAccess {
+	return &userAccess{
+		ua: ua,
+		m:  apiObj,
+		c:  c,
+	}
+}
+
+func userAccessFromAPI(c *UserAccessClient, apiObj *gitlab.ProjectMember) (*userAccess, error) {
+	permission, err := getGitProviderPermission(int(apiObj.AccessLevel))
+	if err != nil {
+		return nil, err
+	}
+	return newUserAccess(c, gitprovider.UserAccessInfo{
+		Login:      apiObj.Username,
+		Permission: permission,
+	}, apiObj), nil
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	ua gitprovider.UserAccessInfo
+	m  *gitlab.ProjectMember
+	c  *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return ua.ua
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ua.ua = info
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return ua.m
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited always returns false, as GitLab adds existing users as members directly.
+func (ua *userAccess) Invited() bool {
+	return false
+}
+
+// Delete removes the given user from the repo's members.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(ctx context.Context) error {
+	userID, err := ua.userID(ctx)
+	if err != nil {
+		return err
+	}
+	// DELETE /projects/{project}/members/{user_id}
+	return ua.c.c.DeleteProjectMember(ctx, getRepoPath(ua.c.ref), userID)
+}
+
+// Update sets the access level of the member to the desired permission.
+func (ua *userAccess) Update(ctx context.Context) error {
+	// Make sure the desired state is valid and fully-populated
+	req := ua.Get()
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return err
+	}
+	gitlabPermission, err := getGitlabPermission(*req.Permission)
+	if err != nil {
+		return err
+	}
+	userID, err := ua.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	// PUT /projects/{project}/members/{user_id}
+	if err := ua.c.c.EditProjectMember(ctx, getRepoPath(ua.c.ref), userID, gitlab.AccessLevelValue(gitlabPermission)); err != nil {
+		return err
+	}
+	return ua.Set(req)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	req := ua.Get()
+	actual, err := ua.c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := ua.c.Create(ctx, req)
+			if err != nil {
+				return true, err
+			}
+			return true, ua.Set(resp.Get())
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, ua.Update(ctx)
+}
+
+// userID returns the GitLab ID of the user, looking it up by username if needed.
+func (ua *userAccess) userID(ctx context.Context) (int, error) {
+	if ua.m != nil && ua.m.Username == ua.ua.Login {
+		return ua.m.ID, nil
+	}
+	user, err := ua.c.c.GetUserByUsername(ctx, ua.ua.Login)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+func validateProjectMemberAPI(apiObj *gitlab.ProjectMember) error {
+	return validateAPIObject("GitLab.ProjectMember", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Username == "" {
+			validator.Required("Username")
+		}
+	})
+}
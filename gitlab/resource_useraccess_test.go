@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_userAccessFromAPI(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  gitlab.ProjectMember
+		want    gitprovider.UserAccessInfo
+		wantErr error
+	}{
+		{
+			name:   "reporter",
+			member: gitlab.ProjectMember{ID: 1, Username: "alice", AccessLevel: gitlab.ReporterPermissions},
+			want: gitprovider.UserAccessInfo{
+				Login:      "alice",
+				Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionTriage),
+			},
+		},
+		{
+			name:   "maintainer",
+			member: gitlab.ProjectMember{ID: 2, Username: "bob", AccessLevel: gitlab.MaintainerPermissions},
+			want: gitprovider.UserAccessInfo{
+				Login:      "bob",
+				Permission: gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionMaintain),
+			},
+		},
+		{
+			name:    "minimal access",
+			member:  gitlab.ProjectMember{ID: 3, Username: "carol", AccessLevel: gitlab.MinimalAccessPermissions},
+			wantErr: gitprovider.ErrInvalidPermissionLevel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ua, err := userAccessFromAPI(&UserAccessClient{}, &tt.member)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("userAccessFromAPI() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := ua.Get(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userAccessFromAPI() = %v, want %v", got, tt.want)
+			}
+			if ua.APIObject() != &tt.member {
+				t.Error("APIObject() doesn't return the project member")
+			}
+		})
+	}
+}
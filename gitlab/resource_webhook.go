@@ -0,0 +1,237 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newWebhook(c *WebhookClient, hook *gitlab.ProjectHook) *webhook {
+	return &webhook{
+		h: *hook,
+		c: c,
+	}
+}
+
+var _ gitprovider.Webhook = &webhook{}
+
+type webhook struct {
+	h gitlab.ProjectHook
+	// token is the secret set through Set(), GitLab never returns it
+	token *string
+	c     *WebhookClient
+}
+
+func (wh *webhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.h)
+}
+
+func (wh *webhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookInfo(info); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.h)
+	if info.Secret != nil {
+		wh.token = info.Secret
+	}
+	return nil
+}
+
+func (wh *webhook) APIObject() interface{} {
+	return &wh.h
+}
+
+func (wh *webhook) Repository() gitprovider.RepositoryRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *webhook) Update(ctx context.Context) error {
+	opts := gitlab.EditProjectHookOptions(*projectHookOptions(&wh.h, wh.token))
+	// PUT /projects/{project}/hooks/{hook_id}
+	apiObj, err := wh.c.c.EditProjectHook(ctx, getRepoPath(wh.c.ref), wh.h.ID, &opts)
+	if err != nil {
+		return err
+	}
+	wh.h = *apiObj
+	return nil
+}
+
+// Delete deletes the hook from the project.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *webhook) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/hooks/{hook_id}
+	return wh.c.c.DeleteProjectHook(ctx, getRepoPath(wh.c.ref), wh.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (wh *webhook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := wh.c.get(ctx, wh.h.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, wh.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if webhookFromAPI(&wh.h).Equals(webhookFromAPI(&actual.h)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update the actual hook
+	wh.h.ID = actual.h.ID
+	return true, wh.Update(ctx)
+}
+
+func (wh *webhook) createIntoSelf(ctx context.Context) error {
+	// POST /projects/{project}/hooks
+	apiObj, err := wh.c.c.AddProjectHook(ctx, getRepoPath(wh.c.ref), projectHookOptions(&wh.h, wh.token))
+	if err != nil {
+		return err
+	}
+	wh.h = *apiObj
+	return nil
+}
+
+func validateProjectHookAPI(apiObj *gitlab.ProjectHook) error {
+	return validateAPIObject("GitLab.ProjectHook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// validateWebhookInfo returns ErrNoProviderSupport for the settings GitLab project hooks can't express.
+func validateWebhookInfo(info gitprovider.WebhookInfo) error {
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("gitlab hooks always deliver json payloads: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.Active != nil && !*info.Active {
+		return fmt.Errorf("gitlab hooks can't be deactivated: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+func webhookFromAPI(apiObj *gitlab.ProjectHook) gitprovider.WebhookInfo {
+	var events []gitprovider.WebhookEvent
+	if apiObj.PushEvents {
+		events = append(events, gitprovider.WebhookEventPush)
+	}
+	if apiObj.TagPushEvents {
+		events = append(events, gitprovider.WebhookEventTagPush)
+	}
+	if apiObj.MergeRequestsEvents {
+		events = append(events, gitprovider.WebhookEventPullRequest)
+	}
+	if apiObj.ReleasesEvents {
+		events = append(events, gitprovider.WebhookEventRelease)
+	}
+	return gitprovider.WebhookInfo{
+		URL:                   apiObj.URL,
+		ContentType:           gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Events:                gitprovider.SortWebhookEvents(events),
+		InsecureSkipTLSVerify: gitprovider.BoolVar(!apiObj.EnableSSLVerification),
+		Active:                gitprovider.BoolVar(true),
+	}
+}
+
+func webhookToAPI(info *gitprovider.WebhookInfo) *gitlab.ProjectHook {
+	h := &gitlab.ProjectHook{}
+	webhookInfoToAPIObj(info, h)
+	return h
+}
+
+func webhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *gitlab.ProjectHook) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.URL = info.URL
+	// optional fields
+	if len(info.Events) != 0 {
+		apiObj.PushEvents = false
+		apiObj.TagPushEvents = false
+		apiObj.MergeRequestsEvents = false
+		apiObj.ReleasesEvents = false
+		for _, event := range info.Events {
+			switch event {
+			case gitprovider.WebhookEventPush:
+				apiObj.PushEvents = true
+			case gitprovider.WebhookEventTagPush:
+				apiObj.TagPushEvents = true
+			case gitprovider.WebhookEventPullRequest:
+				apiObj.MergeRequestsEvents = true
+			case gitprovider.WebhookEventRelease:
+				apiObj.ReleasesEvents = true
+			}
+		}
+	}
+	if info.InsecureSkipTLSVerify != nil {
+		apiObj.EnableSSLVerification = !*info.InsecureSkipTLSVerify
+	}
+}
+
+// projectHookOptions returns the options to create or edit the hook, using token as secret if set.
+// Events that aren't part of WebhookInfo are kept as they are.
+func projectHookOptions(apiObj *gitlab.ProjectHook, token *string) *gitlab.AddProjectHookOptions {
+	return &gitlab.AddProjectHookOptions{
+		URL:                      &apiObj.URL,
+		Token:                    token,
+		PushEvents:               &apiObj.PushEvents,
+		PushEventsBranchFilter:   &apiObj.PushEventsBranchFilter,
+		TagPushEvents:            &apiObj.TagPushEvents,
+		MergeRequestsEvents:      &apiObj.MergeRequestsEvents,
+		ReleasesEvents:           &apiObj.ReleasesEvents,
+		IssuesEvents:             &apiObj.IssuesEvents,
+		ConfidentialIssuesEvents: &apiObj.ConfidentialIssuesEvents,
+		NoteEvents:               &apiObj.NoteEvents,
+		ConfidentialNoteEvents:   &apiObj.ConfidentialNoteEvents,
+		JobEvents:                &apiObj.JobEvents,
+		PipelineEvents:           &apiObj.PipelineEvents,
+		WikiPageEvents:           &apiObj.WikiPageEvents,
+		DeploymentEvents:         &apiObj.DeploymentEvents,
+		EnableSSLVerification:    &apiObj.EnableSSLVerification,
+	}
+}
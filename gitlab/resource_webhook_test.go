@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_webhookRoundTrip(t *testing.T) {
+	info := gitprovider.WebhookInfo{
+		URL:                   "https://example.com/hook",
+		Events:                []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventRelease, gitprovider.WebhookEventTagPush},
+		InsecureSkipTLSVerify: gitprovider.BoolVar(true),
+	}
+	info.Default()
+	if got := webhookFromAPI(webhookToAPI(&info)); !reflect.DeepEqual(got, info) {
+		t.Errorf("webhookFromAPI() = %+v, want %+v", got, info)
+	}
+}
+
+func Test_projectHookOptions(t *testing.T) {
+	apiObj := &gitlab.ProjectHook{ID: 1, URL: "https://example.com/hook", PushEvents: true, NoteEvents: true}
+	webhookInfoToAPIObj(&gitprovider.WebhookInfo{
+		URL:    "https://example.com/hook",
+		Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventTagPush},
+	}, apiObj)
+
+	opts := projectHookOptions(apiObj, gitprovider.StringVar("s3cr3t"))
+	if *opts.PushEvents || !*opts.TagPushEvents {
+		t.Errorf("projectHookOptions() push = %v, tag push = %v, want only tag push events", *opts.PushEvents, *opts.TagPushEvents)
+	}
+	// Events that aren't part of WebhookInfo are kept
+	if !*opts.NoteEvents {
+		t.Error("projectHookOptions() NoteEvents = false, want it to be kept")
+	}
+	if opts.Token == nil || *opts.Token != "s3cr3t" {
+		t.Errorf("projectHookOptions() Token = %v, want %q", opts.Token, "s3cr3t")
+	}
+}
+
+func Test_validateWebhookInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    gitprovider.WebhookInfo
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			info: gitprovider.WebhookInfo{URL: "https://example.com/hook"},
+		},
+		{
+			name: "form payloads",
+			info: gitprovider.WebhookInfo{
+				URL:         "https://example.com/hook",
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm),
+			},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+		{
+			name: "inactive",
+			info: gitprovider.WebhookInfo{
+				URL:    "https://example.com/hook",
+				Active: gitprovider.BoolVar(false),
+			},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.Default()
+			if err := validateWebhookInfo(tt.info); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateWebhookInfo() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,7 +14,14 @@ import (
 const (
 	alreadyExistsMagicString = "name: [has already been taken]"
 	alreadySharedWithGroup   = "already shared with this group"
-	defaultBranchName        = "main"
+	// keyInUseMagicString is returned when adding a deploy key which is already added to the project.
+	keyInUseMagicString = "already exists in project"
+	defaultBranchName   = "main"
+
+	// fileExistsMagicString is returned when creating a file which exists already.
+	fileExistsMagicString = "A file with this name already exists"
+	// fileChangedMagicString is returned when updating or deleting a file with a stale last commit ID.
+	fileChangedMagicString = "has changed since you started editing it"
 )
 
 func getRepoPath(ref gitprovider.RepositoryRef) string {
@@ -103,6 +110,110 @@ func allProjectUserPages(opts *gitlab.ListProjectUserOptions, fn func() (*gitlab
 	}
 }
 
+func allBranchPages(opts *gitlab.ListBranchesOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProtectedBranchPages(opts *gitlab.ListProtectedBranchesOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProjectHookPages(opts *gitlab.ListProjectHooksOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allTagPages(opts *gitlab.ListTagsOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allReleasePages(opts *gitlab.ListReleasesOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMergeRequestPages(opts *gitlab.ListProjectMergeRequestsOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMergeRequestNotePages(opts *gitlab.ListMergeRequestNotesOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allCommitStatusPages(opts *gitlab.GetCommitStatusesOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 func allDeployKeyPages(opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gitlab.Response, error)) error {
 	for {
 		resp, err := fn()
@@ -116,6 +227,19 @@ func allDeployKeyPages(opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gi
 	}
 }
 
+func allProjectMemberPages(opts *gitlab.ListProjectMembersOptions, fn func() (*gitlab.Response, error)) error {
+	for {
+		resp, err := fn()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 // validateUserRepositoryRef makes sure the UserRepositoryRef is valid for GitHub's usage.
 func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
 	// Make sure the RepositoryRef fields are valid
@@ -231,8 +355,10 @@ func handleHTTPError(err error) error {
 		if glErrorResponse.Response.StatusCode == http.StatusNotFound {
 			return validation.NewMultiError(err, gitprovider.ErrNotFound)
 		}
-		// Check for already exists errors
-		if strings.Contains(glErrorResponse.Message, alreadyExistsMagicString) {
+		// Check for already exists errors, e.g. when protecting a protected branch
+		if glErrorResponse.Response.StatusCode == http.StatusConflict ||
+			strings.Contains(glErrorResponse.Message, alreadyExistsMagicString) ||
+			strings.Contains(glErrorResponse.Message, keyInUseMagicString) {
 			return validation.NewMultiError(err, gitprovider.ErrAlreadyExists)
 		}
 		// Otherwise, return a generic *HTTPError
@@ -35,6 +35,10 @@ type Client interface {
 
 	// HasTokenPermission returns a boolean indicating whether the supplied token has the requested
 	// permission. Permissions should be coarse-grained and applicable to *all* providers.
+	//
+	// ErrTokenPermissionUnknown is returned if it can't be determined whether the token has the
+	// permission, which must not be taken as the token lacking it. ErrNoProviderSupport is returned
+	// if the provider has no equivalent of the permission.
 	HasTokenPermission(ctx context.Context, permission TokenPermission) (bool, error)
 
 	// Raw returns the Go client used under the hood to access the Git provider.
@@ -51,6 +55,9 @@ type ResourceClient interface {
 
 	// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
 	UserRepositories() UserRepositoriesClient
+
+	// Users returns the UsersClient looking up user accounts.
+	Users() UsersClient
 }
 
 //
@@ -78,7 +85,31 @@ type OrganizationsClient interface {
 	// Children returns all available organizations, using multiple paginated requests if needed.
 	Children(ctx context.Context, o OrganizationRef) ([]Organization, error)
 
-	// Possibly add Create/Update/Delete methods later
+	// Create creates an organization with the given data. If o has sub-organizations, the
+	// parent organization must already exist.
+	//
+	// This is only supported in GitHub Enterprise, GitLab (groups) and Stash (projects).
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, o OrganizationRef, req OrganizationInfo) (Organization, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, o OrganizationRef, req OrganizationInfo) (resp Organization, actionTaken bool, err error)
+}
+
+// UsersClient looks up user accounts.
+type UsersClient interface {
+	// GetAuthenticated returns the user the client is authenticated as.
+	GetAuthenticated(ctx context.Context) (User, error)
+
+	// Get returns the user for the given reference.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, u UserRef) (User, error)
 }
 
 // OrgRepositoriesClient operates on repositories for organizations.
@@ -151,7 +182,17 @@ type TeamsClient interface {
 	// List returns all available organizations, using multiple paginated requests if needed.
 	List(ctx context.Context) ([]Team, error)
 
-	// Possibly add Create/Update/Delete methods later
+	// Create creates a team within the specific organization, with the given members.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, req TeamInfo) (Team, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req TeamInfo) (resp Team, actionTaken bool, err error)
 }
 
 // TeamAccessClient operates on the teams list for a specific repository.
@@ -180,6 +221,36 @@ type TeamAccessClient interface {
 	Reconcile(ctx context.Context, req TeamAccessInfo) (resp TeamAccess, actionTaken bool, err error)
 }
 
+// UserAccessClient operates on the individual users' access to a specific repository,
+// e.g. outside collaborators on GitHub, project members on GitLab, or user permissions on
+// Bitbucket Server. This client can be accessed through Repository.UserAccess().
+type UserAccessClient interface {
+	// Get a user's permission level of this given repository. Pending invitations are
+	// returned as well, see UserAccess.Invited().
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, login string) (UserAccess, error)
+
+	// List the users who have been granted access to this repository directly, or have been invited to it.
+	// Access granted through teams or organization membership isn't listed.
+	//
+	// List returns all available user access lists, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]UserAccess, error)
+
+	// Create grants the given user access to the repository. On providers using invitations,
+	// the user might have to accept an invitation first.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, req UserAccessInfo) (UserAccess, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req UserAccessInfo) (resp UserAccess, actionTaken bool, err error)
+}
+
 // DeployKeyClient operates on the access credential list for a specific repository.
 // This client can be accessed through Repository.DeployKeys().
 type DeployKeyClient interface {
@@ -220,28 +291,254 @@ type CommitClient interface {
 // BranchClient operates on the branches for a specific repository.
 // This client can be accessed through Repository.Branches().
 type BranchClient interface {
+	// List lists all branches in the repository.
+	//
+	// List returns all available branches, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Branch, error)
+
+	// Get returns the branch with the given name.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, branch string) (Branch, error)
+
 	// Create creates a branch with the given specifications.
 	Create(ctx context.Context, branch, sha string) error
+
+	// Delete deletes the branch with the given name.
+	//
+	// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+	// ErrNotFound is returned if the resource does not exist.
+	Delete(ctx context.Context, branch string) error
+
+	// SetDefault makes the branch with the given name the default branch of the repository.
+	//
+	// ErrNotFound is returned if the branch does not exist.
+	SetDefault(ctx context.Context, branch string) error
+}
+
+// BranchProtectionClient operates on the branch protection rules of a specific repository.
+// This client can be accessed through Repository.BranchProtections().
+type BranchProtectionClient interface {
+	// Get returns the protection of the branch with the given name.
+	//
+	// ErrNotFound is returned if the branch isn't protected.
+	Get(ctx context.Context, branch string) (BranchProtection, error)
+
+	// List lists the protections of all protected branches in the repository.
+	//
+	// List returns all available branch protections, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]BranchProtection, error)
+
+	// Create protects a branch with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if the branch is protected already.
+	Create(ctx context.Context, req BranchProtectionInfo) (BranchProtection, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req BranchProtectionInfo) (resp BranchProtection, actionTaken bool, err error)
+
+	// Delete removes the protection of the branch with the given name.
+	//
+	// ErrNotFound is returned if the branch isn't protected.
+	Delete(ctx context.Context, branch string) error
+}
+
+// WebhookClient operates on the webhooks of a specific repository.
+// This client can be accessed through Repository.Webhooks().
+type WebhookClient interface {
+	// Get returns the webhook delivering to the given URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, url string) (Webhook, error)
+
+	// List lists all repository webhooks.
+	//
+	// List returns all available webhooks, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Webhook, error)
+
+	// Create creates a webhook with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if a webhook delivering to the URL already exists.
+	Create(ctx context.Context, req WebhookInfo) (Webhook, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req WebhookInfo) (resp Webhook, actionTaken bool, err error)
+
+	// Delete removes the webhook delivering to the given URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Delete(ctx context.Context, url string) error
+}
+
+// TagClient operates on the tags of a specific repository.
+// This client can be accessed through Repository.Tags().
+type TagClient interface {
+	// List lists all tags in the repository.
+	//
+	// List returns all available tags, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Tag, error)
+
+	// Get returns the tag with the given name.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, name string) (Tag, error)
+
+	// Create creates a tag with the given specifications. A tag with a Message is an
+	// annotated tag, otherwise a lightweight tag.
+	//
+	// ErrAlreadyExists will be returned if the tag already exists.
+	Create(ctx context.Context, req TagInfo) (Tag, error)
+
+	// Delete deletes the tag with the given name.
+	//
+	// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+	// ErrNotFound is returned if the resource does not exist.
+	Delete(ctx context.Context, name string) error
+}
+
+// ReleaseClient operates on the releases of a specific repository.
+// This client can be accessed through Repository.Releases().
+// ErrNoProviderSupport is returned by all methods if the provider has no concept of releases.
+type ReleaseClient interface {
+	// List lists all releases in the repository.
+	//
+	// List returns all available releases, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Release, error)
+
+	// Get returns the release for the tag with the given name.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, tagName string) (Release, error)
+
+	// Create creates a release with the given specifications, creating its tag from req.Target
+	// if it doesn't exist yet.
+	//
+	// ErrAlreadyExists will be returned if a release for the tag already exists.
+	Create(ctx context.Context, req ReleaseInfo) (Release, error)
+
+	// Update changes the release for the tag with the given name to req. Unset fields of req
+	// are left unchanged, and the tag can't be changed.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Update(ctx context.Context, tagName string, req ReleaseInfo) (Release, error)
+
+	// Delete deletes the release for the tag with the given name. The tag itself is kept.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Delete(ctx context.Context, tagName string) error
+
+	// UploadAsset attaches a file with the given name and content to the release for the
+	// tag with the given name.
+	//
+	// ErrNotFound is returned if the release does not exist.
+	UploadAsset(ctx context.Context, tagName, name string, content []byte) (*ReleaseAsset, error)
+}
+
+// CommitStatusClient operates on the statuses reported for the commits of a specific repository.
+// This client can be accessed through Repository.Statuses().
+type CommitStatusClient interface {
+	// List returns the latest status of each context for the commit with the given sha.
+	// Use CombinedCommitStatusState to get the overall state of the commit.
+	//
+	// List returns all available statuses, using multiple paginated requests if needed.
+	List(ctx context.Context, sha string) ([]CommitStatus, error)
+
+	// Create reports a status with the given specifications for the commit with the given sha,
+	// replacing the status of the same context, if any.
+	Create(ctx context.Context, sha string, req CommitStatusInfo) (CommitStatus, error)
 }
 
 // PullRequestClient operates on the pull requests for a specific repository.
 // This client can be accessed through Repository.PullRequests().
 type PullRequestClient interface {
-	// List lists all pull requests in the repository
-	List(ctx context.Context) ([]PullRequest, error)
-	// Create creates a pull request with the given specifications.
-	Create(ctx context.Context, title, branch, baseBranch, description string) (PullRequest, error)
+	// List lists all pull requests in the repository, in all states unless filtered
+	// with PullRequestListOptions.
+	//
+	// List returns all available pull requests, using multiple paginated requests if needed.
+	List(ctx context.Context, opts ...PullRequestListOption) ([]PullRequest, error)
+	// Create creates a pull request with the given specifications. Labels, assignees, reviewers
+	// and more can be set with PullRequestCreateOptions.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support one of the given options.
+	Create(ctx context.Context, title, branch, baseBranch, description string, opts ...PullRequestCreateOption) (PullRequest, error)
 	// Get retrieves an existing pull request by number
 	Get(ctx context.Context, number int) (PullRequest, error)
-	// Merge merges a pull request with via either the "Squash" or "Merge" method
-	Merge(ctx context.Context, number int, mergeMethod MergeMethod, message string) error
+	// Update changes the pull request with the given number to req. Unset fields of req are left
+	// unchanged.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Update(ctx context.Context, number int, req PullRequestUpdateInfo) (PullRequest, error)
+	// Close closes the pull request with the given number without merging it.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Close(ctx context.Context, number int) error
+	// Merge merges a pull request with the given merge method. Auto-merge and deleting the
+	// source branch can be requested with PullRequestMergeOptions.
+	//
+	// ErrNoProviderSupport is returned if the provider doesn't support the merge method or one
+	// of the given options.
+	Merge(ctx context.Context, number int, mergeMethod MergeMethod, message string, opts ...PullRequestMergeOption) error
 }
 
-// FileClient operates on the branches for a specific repository.
-// This client can be accessed through Repository.Branches().
+// PullRequestCommentClient operates on the general comments of a specific pull request, i.e.
+// comments which aren't attached to a line of the diff.
+// This client can be accessed through PullRequest.Comments().
+type PullRequestCommentClient interface {
+	// List lists all general comments of the pull request, oldest first.
+	//
+	// List returns all available comments, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]PullRequestComment, error)
+
+	// Create adds a comment with the given body, in markdown, to the pull request.
+	Create(ctx context.Context, body string) (PullRequestComment, error)
+
+	// Update changes the body of the comment with the given ID.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Update(ctx context.Context, id int64, body string) (PullRequestComment, error)
+
+	// Delete deletes the comment with the given ID.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Delete(ctx context.Context, id int64) error
+}
+
+// PullRequestReviewClient gives read access to the reviews of a specific pull request.
+// This client can be accessed through PullRequest.Reviews().
+type PullRequestReviewClient interface {
+	// List lists the current review of each reviewer of the pull request, e.g. to check for
+	// approvals before merging. A reviewer's latest approval or request for changes takes
+	// precedence over later comments.
+	//
+	// List returns all available reviews, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]PullRequestReview, error)
+}
+
+// FileClient operates on the files for a specific repository.
+// This client can be accessed through Repository.Files().
 type FileClient interface {
 	// GetFiles fetch files content from specific path and branch
 	Get(ctx context.Context, path, branch string, optFns ...FilesGetOption) ([]*CommitFile, error)
+
+	// Put creates or updates the file at path on the given branch, committing content with message.
+	//
+	// expectedSHA is the blob SHA the file is expected to have (see CommitFile.SHA), or an empty
+	// string if the file is expected not to exist yet. ErrConflict is returned otherwise.
+	Put(ctx context.Context, path, branch, content, message, expectedSHA string) error
+
+	// Delete deletes the file at path on the given branch, committing with message.
+	//
+	// expectedSHA is the blob SHA the file is expected to have (see CommitFile.SHA), and is required.
+	// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its SHA doesn't match.
+	Delete(ctx context.Context, path, branch, message, expectedSHA string) error
 }
 
 // TreeClient operates on the trees for a Git repository which describe the hierarchy between files in the repository
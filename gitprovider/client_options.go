@@ -288,6 +288,61 @@ func oauth2Transport(oauth2Token string) ChainableRoundTripperFunc {
 	}
 }
 
+// WithPersonalAccessToken initializes a Client which authenticates through a personal access token,
+// sent as the password of HTTP basic authentication with an empty username, as e.g. Azure DevOps expects.
+// personalAccessToken must not be an empty string.
+func WithPersonalAccessToken(personalAccessToken string) ClientOption {
+	// Don't allow an empty value
+	if personalAccessToken == "" {
+		return optionError(fmt.Errorf("personalAccessToken cannot be empty: %w", ErrInvalidClientOptions))
+	}
+
+	return &ClientOptions{authTransport: basicAuthTransport("", personalAccessToken)}
+}
+
+func basicAuthTransport(username, password string) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		return &basicAuthRoundTripper{
+			base:     in,
+			username: username,
+			password: password,
+		}
+	}
+}
+
+// basicAuthRoundTripper adds HTTP basic authentication credentials to all requests.
+type basicAuthRoundTripper struct {
+	base     http.RoundTripper
+	username string
+	password string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// As per the http.RoundTripper contract, don't modify the given request
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.username, rt.password)
+	// Like oauth2.Transport, fall back to the default transport if there's no underlying one
+	base := rt.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// WithAuthTransport initializes a Client which authenticates through the given ChainableRoundTripperFunc.
+// This allows provider packages to offer authentication schemes specific to the provider, e.g. GitHub
+// App installation tokens. It's mutually exclusive with the other authentication options.
+// authTransport must not be nil.
+func WithAuthTransport(authTransport ChainableRoundTripperFunc) ClientOption {
+	// Don't allow an empty value
+	if authTransport == nil {
+		return optionError(fmt.Errorf("authTransport cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	return &ClientOptions{authTransport: authTransport}
+}
+
 // WithConditionalRequests instructs the client to use Conditional Requests to Stash.
 // See: https://gitlab.com/gitlab.org/gitlab.foss/-/issues/26926, and
 // https://docs.gitlab.com/ee/development/polling.html for more info.
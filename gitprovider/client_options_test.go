@@ -282,6 +282,36 @@ func Test_makeCientOptions(t *testing.T) {
 			opts:         []ClientOption{WithOAuth2Token("")},
 			expectedErrs: []error{ErrInvalidClientOptions},
 		},
+		{
+			name: "WithPersonalAccessToken",
+			opts: []ClientOption{WithPersonalAccessToken("foo")},
+			want: &ClientOptions{authTransport: basicAuthTransport("", "foo")},
+		},
+		{
+			name:         "WithPersonalAccessToken, empty",
+			opts:         []ClientOption{WithPersonalAccessToken("")},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithPersonalAccessToken and WithOAuth2Token, exclusive",
+			opts:         []ClientOption{WithPersonalAccessToken("foo"), WithOAuth2Token("bar")},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name: "WithAuthTransport",
+			opts: []ClientOption{WithAuthTransport(dummyRoundTripper1)},
+			want: &ClientOptions{authTransport: dummyRoundTripper1},
+		},
+		{
+			name:         "WithAuthTransport, nil",
+			opts:         []ClientOption{WithAuthTransport(nil)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithAuthTransport and WithOAuth2Token, exclusive",
+			opts:         []ClientOption{WithAuthTransport(dummyRoundTripper1), WithOAuth2Token("bar")},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
 		{
 			name: "WithConditionalRequests",
 			opts: []ClientOption{WithConditionalRequests(true)},
@@ -0,0 +1,308 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance checks that a gitprovider.Client implementation follows the contract
+// documented in the gitprovider package, e.g. which errors are returned, how Reconcile behaves
+// and which fields are defaulted.
+//
+// Providers run the suite from their tests through Run, usually against an httptest.Server
+// emulating the subset of their API the suite uses, so that no live server is needed.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// defaultListRepositories is the default for Options.ListRepositories.
+	defaultListRepositories = 5
+
+	// deployKey is the public key used when creating deploy keys.
+	deployKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKxdjhFZ6qvyf9Uo1QDGy3IESeVSHNZnpBrXIdRtF0Ve conformance"
+)
+
+// Options configures the conformance suite for a provider.
+type Options struct {
+	// NewClient creates a client for the provider under test, applying opts on top of the
+	// provider-specific options. It is called multiple times, e.g. with and without
+	// WithDestructiveAPICalls, hence all clients need to share the same backend.
+	// +required
+	NewClient func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client
+
+	// Organization is an existing organization the suite creates repositories in.
+	// The suite only touches repositories with a "conformance-" prefix.
+	// +required
+	Organization gitprovider.OrganizationRef
+
+	// ListRepositories is the number of repositories created to check that List returns all
+	// pages. It should exceed the page size of the backend. Defaults to 5.
+	// +optional
+	ListRepositories int
+
+	// Skip maps the names of checks the provider can't pass, e.g. "CreateDefaulting", to the
+	// limitation of the provider causing it. Skipped checks are reported with this reason.
+	// +optional
+	Skip map[string]string
+}
+
+// Run runs the conformance suite as sub-tests of t.
+func Run(t *testing.T, opts Options) {
+	if opts.NewClient == nil {
+		t.Fatal("conformance: Options.NewClient is required")
+	}
+	if opts.ListRepositories == 0 {
+		opts.ListRepositories = defaultListRepositories
+	}
+	s := &suite{opts: opts}
+
+	t.Run("OrgRepositories", func(t *testing.T) {
+		s.run(t, "CreateAlreadyExists", s.testCreateAlreadyExists)
+		s.run(t, "GetNotFound", s.testGetNotFound)
+		s.run(t, "CreateDefaulting", s.testCreateDefaulting)
+		s.run(t, "ReconcileIdempotency", s.testReconcileIdempotency)
+		s.run(t, "ListCompleteness", s.testListCompleteness)
+		s.run(t, "DeleteDestructiveGating", s.testDeleteDestructiveGating)
+	})
+	s.run(t, "DeployKeys", s.testDeployKeys)
+}
+
+type suite struct {
+	opts Options
+}
+
+// run runs the check as a sub-test, unless it is skipped through Options.Skip.
+func (s *suite) run(t *testing.T, name string, check func(t *testing.T)) {
+	t.Run(name, func(t *testing.T) {
+		if reason, ok := s.opts.Skip[name]; ok {
+			t.Skipf("skipped by the provider: %s", reason)
+		}
+		check(t)
+	})
+}
+
+func (s *suite) repoRef(name string) gitprovider.OrgRepositoryRef {
+	return gitprovider.OrgRepositoryRef{
+		OrganizationRef: s.opts.Organization,
+		RepositoryName:  "conformance-" + name,
+	}
+}
+
+func (s *suite) createRepo(t *testing.T, c gitprovider.Client, name string, req gitprovider.RepositoryInfo) gitprovider.OrgRepository {
+	t.Helper()
+	repo, err := c.OrgRepositories().Create(context.Background(), s.repoRef(name), req)
+	if err != nil {
+		t.Fatalf("OrgRepositories().Create(%q) returned error: %v", name, err)
+	}
+	return repo
+}
+
+// testCreateAlreadyExists checks that creating an existing repository fails with ErrAlreadyExists.
+func (s *suite) testCreateAlreadyExists(t *testing.T) {
+	c := s.opts.NewClient(t)
+	s.createRepo(t, c, "create", gitprovider.RepositoryInfo{})
+
+	_, err := c.OrgRepositories().Create(context.Background(), s.repoRef("create"), gitprovider.RepositoryInfo{})
+	if !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("second Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+}
+
+// testGetNotFound checks that getting a missing repository fails with ErrNotFound.
+func (s *suite) testGetNotFound(t *testing.T) {
+	c := s.opts.NewClient(t)
+
+	_, err := c.OrgRepositories().Get(context.Background(), s.repoRef("missing"))
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+// testCreateDefaulting checks that unset fields of RepositoryInfo are defaulted at creation.
+func (s *suite) testCreateDefaulting(t *testing.T) {
+	c := s.opts.NewClient(t)
+	s.createRepo(t, c, "defaulting", gitprovider.RepositoryInfo{})
+
+	repo, err := c.OrgRepositories().Get(context.Background(), s.repoRef("defaulting"))
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	want := gitprovider.RepositoryInfo{}
+	want.Default()
+	got := repo.Get()
+	if got.Visibility == nil || *got.Visibility != *want.Visibility {
+		t.Errorf("Visibility = %s, want %q", stringOrNil((*string)(got.Visibility)), *want.Visibility)
+	}
+	if got.DefaultBranch == nil || *got.DefaultBranch != *want.DefaultBranch {
+		t.Errorf("DefaultBranch = %s, want %q", stringOrNil(got.DefaultBranch), *want.DefaultBranch)
+	}
+}
+
+// testReconcileIdempotency checks that Reconcile only takes action if the actual state differs.
+func (s *suite) testReconcileIdempotency(t *testing.T) {
+	ctx := context.Background()
+	c := s.opts.NewClient(t)
+	ref := s.repoRef("reconcile")
+
+	steps := []struct {
+		description     string
+		wantActionTaken bool
+	}{
+		{description: "first", wantActionTaken: true},
+		{description: "first", wantActionTaken: false},
+		{description: "second", wantActionTaken: true},
+		{description: "second", wantActionTaken: false},
+	}
+	for i, step := range steps {
+		req := gitprovider.RepositoryInfo{Description: gitprovider.StringVar(step.description)}
+		_, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req)
+		if err != nil {
+			t.Fatalf("Reconcile() #%d returned error: %v", i+1, err)
+		}
+		if actionTaken != step.wantActionTaken {
+			t.Errorf("Reconcile() #%d actionTaken = %v, want %v", i+1, actionTaken, step.wantActionTaken)
+		}
+	}
+
+	repo, err := c.OrgRepositories().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	if got := repo.Get().Description; got == nil || *got != "second" {
+		t.Errorf("Description = %s, want %q", stringOrNil(got), "second")
+	}
+}
+
+// testListCompleteness checks that List returns the repositories of all pages.
+func (s *suite) testListCompleteness(t *testing.T) {
+	c := s.opts.NewClient(t)
+	want := map[string]bool{}
+	for i := 0; i < s.opts.ListRepositories; i++ {
+		name := fmt.Sprintf("list-%d", i)
+		s.createRepo(t, c, name, gitprovider.RepositoryInfo{})
+		want[s.repoRef(name).RepositoryName] = true
+	}
+
+	repos, err := c.OrgRepositories().List(context.Background(), s.opts.Organization)
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	for _, repo := range repos {
+		delete(want, repo.Repository().GetRepository())
+	}
+	for name := range want {
+		t.Errorf("List() is missing repository %q", name)
+	}
+}
+
+// testDeleteDestructiveGating checks that repositories can only be deleted by clients
+// created with WithDestructiveAPICalls(true).
+func (s *suite) testDeleteDestructiveGating(t *testing.T) {
+	ctx := context.Background()
+	c := s.opts.NewClient(t)
+	ref := s.repoRef("delete")
+	repo := s.createRepo(t, c, "delete", gitprovider.RepositoryInfo{})
+
+	if err := repo.Delete(ctx); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete() without destructive API calls error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if _, err := c.OrgRepositories().Get(ctx, ref); err != nil {
+		t.Fatalf("Get() after disallowed Delete() returned error: %v", err)
+	}
+
+	destructive := s.opts.NewClient(t, gitprovider.WithDestructiveAPICalls(true))
+	repo, err := destructive.OrgRepositories().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	if err := repo.Delete(ctx); err != nil {
+		t.Fatalf("Delete() with destructive API calls returned error: %v", err)
+	}
+	if _, err := c.OrgRepositories().Get(ctx, ref); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+// testDeployKeys checks the errors and Reconcile behavior of deploy keys. It is skipped if
+// the provider returns ErrNoProviderSupport.
+func (s *suite) testDeployKeys(t *testing.T) {
+	ctx := context.Background()
+	c := s.opts.NewClient(t)
+	repo := s.createRepo(t, c, "deploy-keys", gitprovider.RepositoryInfo{})
+
+	req := gitprovider.DeployKeyInfo{Name: "conformance", Key: []byte(deployKey)}
+	_, err := repo.DeployKeys().Create(ctx, req)
+	if errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Skipf("deploy keys aren't supported: %v", err)
+	} else if err != nil {
+		t.Fatalf("DeployKeys().Create() returned error: %v", err)
+	}
+	if _, err := repo.DeployKeys().Create(ctx, req); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("second DeployKeys().Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	if _, err := repo.DeployKeys().Get(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("DeployKeys().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	// Create defaults ReadOnly to true, hence this is a no-op
+	_, actionTaken, err := repo.DeployKeys().Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("DeployKeys().Reconcile() returned error: %v", err)
+	}
+	if actionTaken {
+		t.Error("DeployKeys().Reconcile() of an existing deploy key took action")
+	}
+
+	// Some providers only have read-only deploy keys
+	req.ReadOnly = gitprovider.BoolVar(false)
+	_, actionTaken, err = repo.DeployKeys().Reconcile(ctx, req)
+	switch {
+	case errors.Is(err, gitprovider.ErrNoProviderSupport):
+		t.Logf("read-write deploy keys aren't supported: %v", err)
+	case err != nil:
+		t.Fatalf("DeployKeys().Reconcile() returned error: %v", err)
+	default:
+		if !actionTaken {
+			t.Error("DeployKeys().Reconcile() of a changed deploy key took no action")
+		}
+		dk, err := repo.DeployKeys().Get(ctx, req.Name)
+		if err != nil {
+			t.Fatalf("DeployKeys().Get() returned error: %v", err)
+		}
+		if got := dk.Get().ReadOnly; got == nil || *got {
+			t.Errorf("ReadOnly = %v, want false", got)
+		}
+	}
+
+	keys, err := repo.DeployKeys().List(ctx)
+	if err != nil {
+		t.Fatalf("DeployKeys().List() returned error: %v", err)
+	}
+	if len(keys) != 1 {
+		t.Errorf("DeployKeys().List() returned %d deploy keys, want 1", len(keys))
+	}
+}
+
+func stringOrNil(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%q", *s)
+}
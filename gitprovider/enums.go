@@ -16,7 +16,11 @@ limitations under the License.
 
 package gitprovider
 
-import "github.com/fluxcd/go-git-providers/validation"
+import (
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
 
 // TransportType is an enum specifying the transport type used when cloning a repository.
 type TransportType string
@@ -168,8 +172,39 @@ type TokenPermission int
 const (
 	// TokenPermissionRWRepository Read/Write permission for public/private repositories.
 	TokenPermissionRWRepository TokenPermission = iota + 1
+	// TokenPermissionAdminRepoHook permission to manage the webhooks of repositories,
+	// e.g. the admin:repo_hook scope of GitHub.
+	TokenPermissionAdminRepoHook
+	// TokenPermissionDeleteRepository permission to delete repositories,
+	// e.g. the delete_repo scope of GitHub.
+	TokenPermissionDeleteRepository
+	// TokenPermissionAdminOrganization permission to manage organizations, their teams and members,
+	// e.g. the admin:org scope of GitHub.
+	TokenPermissionAdminOrganization
+	// TokenPermissionWritePackages permission to publish packages to the package registry,
+	// e.g. the write:packages scope of GitHub.
+	TokenPermissionWritePackages
 )
 
+// knownTokenPermissionValues is a map of known TokenPermission values, used for validation.
+//nolint:gochecknoglobals
+var knownTokenPermissionValues = map[TokenPermission]struct{}{
+	TokenPermissionRWRepository:      {},
+	TokenPermissionAdminRepoHook:     {},
+	TokenPermissionDeleteRepository:  {},
+	TokenPermissionAdminOrganization: {},
+	TokenPermissionWritePackages:     {},
+}
+
+// ValidateTokenPermission validates a given TokenPermission.
+func ValidateTokenPermission(p TokenPermission) error {
+	_, ok := knownTokenPermissionValues[p]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
 // MergeMethod is an enum specifying the merge method for a pull request.
 type MergeMethod string
 
@@ -179,4 +214,327 @@ const (
 
 	// MergeMethodSquash causes a pull request merge to first squash commits
 	MergeMethodSquash = MergeMethod("squash")
+
+	// MergeMethodRebase causes a pull request merge to replay the commits on top of the target
+	// branch, without a merge commit
+	MergeMethodRebase = MergeMethod("rebase")
+
+	// MergeMethodFastForwardOnly causes a pull request merge to only fast-forward the target
+	// branch, the merge fails if the source branch isn't based on the tip of the target branch
+	MergeMethodFastForwardOnly = MergeMethod("ff-only")
+)
+
+// PullRequestState is an enum specifying the state of a pull request.
+type PullRequestState string
+
+const (
+	// PullRequestStateOpen means the pull request can still be merged, this includes drafts.
+	PullRequestStateOpen = PullRequestState("open")
+	// PullRequestStateClosed means the pull request was closed without being merged.
+	PullRequestStateClosed = PullRequestState("closed")
+	// PullRequestStateMerged means the pull request was merged.
+	PullRequestStateMerged = PullRequestState("merged")
+)
+
+// knownPullRequestStateValues is a map of known PullRequestState values, used for validation.
+//nolint:gochecknoglobals
+var knownPullRequestStateValues = map[PullRequestState]struct{}{
+	PullRequestStateOpen:   {},
+	PullRequestStateClosed: {},
+	PullRequestStateMerged: {},
+}
+
+// ValidatePullRequestState validates a given PullRequestState.
+// Use as errs.Append(ValidatePullRequestState(state), state, "FieldName").
+func ValidatePullRequestState(s PullRequestState) error {
+	_, ok := knownPullRequestStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// PullRequestStateVar returns a pointer to a PullRequestState.
+func PullRequestStateVar(s PullRequestState) *PullRequestState {
+	return &s
+}
+
+// PullRequestReviewState is an enum specifying the state of a review of a pull request.
+type PullRequestReviewState string
+
+const (
+	// PullRequestReviewStateApproved means the reviewer approved the changes.
+	PullRequestReviewStateApproved = PullRequestReviewState("approved")
+	// PullRequestReviewStateChangesRequested means the reviewer asked for changes before the
+	// pull request can be merged.
+	PullRequestReviewStateChangesRequested = PullRequestReviewState("changes_requested")
+	// PullRequestReviewStateCommented means the reviewer left feedback without approving or
+	// requesting changes.
+	PullRequestReviewStateCommented = PullRequestReviewState("commented")
+	// PullRequestReviewStateDismissed means the review was dismissed, and no longer counts.
+	PullRequestReviewStateDismissed = PullRequestReviewState("dismissed")
+	// PullRequestReviewStatePending means the review was requested or started, but not
+	// submitted yet.
+	PullRequestReviewStatePending = PullRequestReviewState("pending")
+)
+
+// knownPullRequestReviewStateValues is a map of known PullRequestReviewState values, used for validation.
+//nolint:gochecknoglobals
+var knownPullRequestReviewStateValues = map[PullRequestReviewState]struct{}{
+	PullRequestReviewStateApproved:         {},
+	PullRequestReviewStateChangesRequested: {},
+	PullRequestReviewStateCommented:        {},
+	PullRequestReviewStateDismissed:        {},
+	PullRequestReviewStatePending:          {},
+}
+
+// ValidatePullRequestReviewState validates a given PullRequestReviewState.
+// Use as errs.Append(ValidatePullRequestReviewState(state), state, "FieldName").
+func ValidatePullRequestReviewState(s PullRequestReviewState) error {
+	_, ok := knownPullRequestReviewStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// PullRequestReviewStateVar returns a pointer to a PullRequestReviewState.
+func PullRequestReviewStateVar(s PullRequestReviewState) *PullRequestReviewState {
+	return &s
+}
+
+// WebhookContentType is an enum specifying the encoding of webhook payloads.
+type WebhookContentType string
+
+const (
+	// WebhookContentTypeJSON delivers payloads as "application/json".
+	WebhookContentTypeJSON = WebhookContentType("json")
+	// WebhookContentTypeForm delivers payloads as "application/x-www-form-urlencoded".
+	WebhookContentTypeForm = WebhookContentType("form")
 )
+
+// knownWebhookContentTypeValues is a map of known WebhookContentType values, used for validation.
+//nolint:gochecknoglobals
+var knownWebhookContentTypeValues = map[WebhookContentType]struct{}{
+	WebhookContentTypeJSON: {},
+	WebhookContentTypeForm: {},
+}
+
+// ValidateWebhookContentType validates a given WebhookContentType.
+// Use as errs.Append(ValidateWebhookContentType(contentType), contentType, "FieldName").
+func ValidateWebhookContentType(t WebhookContentType) error {
+	_, ok := knownWebhookContentTypeValues[t]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WebhookContentTypeVar returns a pointer to a WebhookContentType.
+func WebhookContentTypeVar(t WebhookContentType) *WebhookContentType {
+	return &t
+}
+
+// WebhookEvent is an enum specifying an event that triggers a webhook.
+type WebhookEvent string
+
+const (
+	// WebhookEventPush ("push") is triggered by pushes to branches.
+	// Providers that don't distinguish tag pushes also trigger it for tags.
+	WebhookEventPush = WebhookEvent("push")
+
+	// WebhookEventTagPush ("tag_push") is triggered by pushing tags.
+	// This is only supported by GitLab, other providers include tags in push events.
+	WebhookEventTagPush = WebhookEvent("tag_push")
+
+	// WebhookEventPullRequest ("pull_request") is triggered when pull requests are opened,
+	// updated, merged or closed.
+	WebhookEventPullRequest = WebhookEvent("pull_request")
+
+	// WebhookEventRelease ("release") is triggered when releases are published or changed.
+	WebhookEventRelease = WebhookEvent("release")
+)
+
+// knownWebhookEventValues is a map of known WebhookEvent values, used for validation.
+//nolint:gochecknoglobals
+var knownWebhookEventValues = map[WebhookEvent]struct{}{
+	WebhookEventPush:        {},
+	WebhookEventTagPush:     {},
+	WebhookEventPullRequest: {},
+	WebhookEventRelease:     {},
+}
+
+// ValidateWebhookEvent validates a given WebhookEvent.
+// Use as errs.Append(ValidateWebhookEvent(event), event, "FieldName").
+func ValidateWebhookEvent(e WebhookEvent) error {
+	_, ok := knownWebhookEventValues[e]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// SortWebhookEvents returns a sorted copy of events, without duplicates.
+// An empty list is returned as nil.
+func SortWebhookEvents(events []WebhookEvent) []WebhookEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	sorted := make([]WebhookEvent, 0, len(events))
+	seen := make(map[WebhookEvent]struct{}, len(events))
+	for _, e := range events {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// CommitFileAction is an enum specifying what a commit does with a CommitFile.
+type CommitFileAction string
+
+const (
+	// CommitFileActionAdd adds a file which doesn't exist yet.
+	CommitFileActionAdd = CommitFileAction("add")
+	// CommitFileActionUpdate changes the content or mode of an existing file.
+	CommitFileActionUpdate = CommitFileAction("update")
+	// CommitFileActionDelete deletes an existing file.
+	CommitFileActionDelete = CommitFileAction("delete")
+	// CommitFileActionMove moves an existing file from CommitFile.PreviousPath to CommitFile.Path,
+	// optionally changing its content.
+	CommitFileActionMove = CommitFileAction("move")
+)
+
+// knownCommitFileActionValues is a map of known CommitFileAction values, used for validation.
+//nolint:gochecknoglobals
+var knownCommitFileActionValues = map[CommitFileAction]struct{}{
+	CommitFileActionAdd:    {},
+	CommitFileActionUpdate: {},
+	CommitFileActionDelete: {},
+	CommitFileActionMove:   {},
+}
+
+// ValidateCommitFileAction validates a given CommitFileAction.
+// Use as errs.Append(ValidateCommitFileAction(action), action, "FieldName").
+func ValidateCommitFileAction(a CommitFileAction) error {
+	_, ok := knownCommitFileActionValues[a]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// CommitFileActionVar returns a pointer to a CommitFileAction.
+func CommitFileActionVar(a CommitFileAction) *CommitFileAction {
+	return &a
+}
+
+// CommitFileMode is an enum specifying the git file mode of a CommitFile.
+type CommitFileMode string
+
+const (
+	// CommitFileModeRegular is the mode of regular, non-executable files.
+	CommitFileModeRegular = CommitFileMode("100644")
+	// CommitFileModeExecutable is the mode of executable files.
+	CommitFileModeExecutable = CommitFileMode("100755")
+	// CommitFileModeSymlink is the mode of symbolic links, whose content is the link target.
+	CommitFileModeSymlink = CommitFileMode("120000")
+)
+
+// knownCommitFileModeValues is a map of known CommitFileMode values, used for validation.
+//nolint:gochecknoglobals
+var knownCommitFileModeValues = map[CommitFileMode]struct{}{
+	CommitFileModeRegular:    {},
+	CommitFileModeExecutable: {},
+	CommitFileModeSymlink:    {},
+}
+
+// ValidateCommitFileMode validates a given CommitFileMode.
+// Use as errs.Append(ValidateCommitFileMode(mode), mode, "FieldName").
+func ValidateCommitFileMode(m CommitFileMode) error {
+	_, ok := knownCommitFileModeValues[m]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// CommitFileModeVar returns a pointer to a CommitFileMode.
+func CommitFileModeVar(m CommitFileMode) *CommitFileMode {
+	return &m
+}
+
+// ContentEncoding is an enum specifying how the content of a CommitFile is encoded.
+type ContentEncoding string
+
+const (
+	// ContentEncodingRaw means the content is given as is, and may be binary.
+	ContentEncodingRaw = ContentEncoding("raw")
+	// ContentEncodingBase64 means the content is base64-encoded (standard encoding, with padding).
+	ContentEncodingBase64 = ContentEncoding("base64")
+)
+
+// knownContentEncodingValues is a map of known ContentEncoding values, used for validation.
+//nolint:gochecknoglobals
+var knownContentEncodingValues = map[ContentEncoding]struct{}{
+	ContentEncodingRaw:    {},
+	ContentEncodingBase64: {},
+}
+
+// ValidateContentEncoding validates a given ContentEncoding.
+// Use as errs.Append(ValidateContentEncoding(encoding), encoding, "FieldName").
+func ValidateContentEncoding(e ContentEncoding) error {
+	_, ok := knownContentEncodingValues[e]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// ContentEncodingVar returns a pointer to a ContentEncoding.
+func ContentEncodingVar(e ContentEncoding) *ContentEncoding {
+	return &e
+}
+
+// CommitStatusState is an enum specifying the state of a commit status.
+type CommitStatusState string
+
+const (
+	// CommitStatusStatePending means the check is queued or running.
+	CommitStatusStatePending = CommitStatusState("pending")
+	// CommitStatusStateSuccess means the check passed.
+	CommitStatusStateSuccess = CommitStatusState("success")
+	// CommitStatusStateFailure means the check failed.
+	CommitStatusStateFailure = CommitStatusState("failure")
+	// CommitStatusStateError means the check couldn't be completed, e.g. because it was canceled.
+	CommitStatusStateError = CommitStatusState("error")
+)
+
+// knownCommitStatusStateValues is a map of known CommitStatusState values, used for validation.
+//nolint:gochecknoglobals
+var knownCommitStatusStateValues = map[CommitStatusState]struct{}{
+	CommitStatusStatePending: {},
+	CommitStatusStateSuccess: {},
+	CommitStatusStateFailure: {},
+	CommitStatusStateError:   {},
+}
+
+// ValidateCommitStatusState validates a given CommitStatusState.
+// Use as errs.Append(ValidateCommitStatusState(state), state, "FieldName").
+func ValidateCommitStatusState(s CommitStatusState) error {
+	_, ok := knownCommitStatusStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// CommitStatusStateVar returns a pointer to a CommitStatusState.
+func CommitStatusStateVar(s CommitStatusState) *CommitStatusState {
+	return &s
+}
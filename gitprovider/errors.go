@@ -42,6 +42,9 @@ var (
 	ErrAlreadyExists = errors.New("resource already exists, cannot create object. Use Reconcile() to create it idempotently")
 	// ErrNotFound is returned by .Get() and .Update() calls if the given resource doesn't exist.
 	ErrNotFound = errors.New("the requested resource was not found")
+	// ErrConflict is returned by write requests with an expected SHA, e.g. FileClient.Put(), if the
+	// resource was changed in the meantime, i.e. the expected SHA is stale.
+	ErrConflict = errors.New("the resource was changed concurrently, the expected SHA is stale")
 	// ErrInvalidServerData is returned when the server returned invalid data, e.g. missing required fields in the response.
 	ErrInvalidServerData = errors.New("got invalid data from server, don't know how to handle")
 
@@ -68,6 +71,9 @@ var (
 	ErrInvalidPermissionLevel = errors.New("invalid permission level")
 	// ErrMissingHeader is returned when an expected header is missing from the HTTP response.
 	ErrMissingHeader = errors.New("header is missing")
+	// ErrTokenPermissionUnknown is returned by HasTokenPermission when the provider can't tell
+	// whether the token has the requested permission, e.g. as the token doesn't report its scopes.
+	ErrTokenPermissionUnknown = errors.New("the token permission can't be determined")
 	// ErrGroupNotFound is returned when the gitlab group does not exist
 	ErrGroupNotFound = errors.New("404 Group Not Found")
 )
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/provider"
+)
+
+const (
+	// ProviderID is the provider ID for the fake provider.
+	ProviderID = gitprovider.ProviderID("fake")
+	// DefaultDomain specifies the default domain used by the fake provider.
+	DefaultDomain = "fake.example.com"
+)
+
+// NewClient creates a new gitprovider.Client instance, backed by a new, empty Server.
+// The Server can be accessed through the Raw method of the client.
+//
+// Only the WithDomain and WithDestructiveAPICalls options have an effect, as no HTTP
+// requests are made. Any authentication options are accepted, but ignored.
+func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	return NewServer().NewClient(optFns...)
+}
+
+// NewClient creates a new gitprovider.Client instance for the state held by s.
+// Multiple clients, e.g. with different options, can share the same Server.
+//
+// Only the WithDomain and WithDestructiveAPICalls options have an effect, as no HTTP
+// requests are made. Any authentication options are accepted, but ignored.
+func (s *Server) NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := DefaultDomain
+	if opts.Domain != nil {
+		domain = *opts.Domain
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return provider.NewClient(s.storage, ProviderID, domain, destructiveActions, s), nil
+}
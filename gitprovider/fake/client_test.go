@@ -0,0 +1,1447 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func setup(t *testing.T, optFns ...gitprovider.ClientOption) (*Server, gitprovider.Client) {
+	t.Helper()
+	s := NewServer()
+	c, err := s.NewClient(optFns...)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if err := s.CreateOrganization(orgRef(), gitprovider.OrganizationInfo{
+		Description: gitprovider.StringVar("An organization"),
+	}); err != nil {
+		t.Fatalf("CreateOrganization returned error: %v", err)
+	}
+	return s, c
+}
+
+func orgRef() gitprovider.OrganizationRef {
+	return gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org"}
+}
+
+func repoRef() gitprovider.OrgRepositoryRef {
+	return gitprovider.OrgRepositoryRef{OrganizationRef: orgRef(), RepositoryName: "repo"}
+}
+
+func createRepo(t *testing.T, c gitprovider.Client) gitprovider.OrgRepository {
+	t.Helper()
+	repo, err := c.OrgRepositories().Create(context.Background(), repoRef(), gitprovider.RepositoryInfo{},
+		&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("OrgRepositories().Create returned error: %v", err)
+	}
+	return repo
+}
+
+func commit(t *testing.T, repo gitprovider.UserRepository, branch string, files map[string]*string) gitprovider.CommitInfo {
+	t.Helper()
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	commitFiles := make([]gitprovider.CommitFile, 0, len(files))
+	for _, path := range paths {
+		commitFiles = append(commitFiles, gitprovider.CommitFile{Path: gitprovider.StringVar(path), Content: files[path]})
+	}
+	c, err := repo.Commits().Create(context.Background(), branch, "commit", commitFiles)
+	if err != nil {
+		t.Fatalf("Commits().Create returned error: %v", err)
+	}
+	return c.Get()
+}
+
+func readFiles(t *testing.T, repo gitprovider.UserRepository, path, branch string, optFns ...gitprovider.FilesGetOption) map[string]string {
+	t.Helper()
+	files, err := repo.Files().Get(context.Background(), path, branch, optFns...)
+	if err != nil {
+		t.Fatalf("Files().Get returned error: %v", err)
+	}
+	contents := map[string]string{}
+	for _, file := range files {
+		contents[*file.Path] = *file.Content
+	}
+	return contents
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []gitprovider.ClientOption
+		wantDomain string
+	}{
+		{
+			name:       "default domain",
+			wantDomain: DefaultDomain,
+		},
+		{
+			name:       "custom domain and ignored token",
+			opts:       []gitprovider.ClientOption{gitprovider.WithDomain("git.example.org"), gitprovider.WithOAuth2Token("token")},
+			wantDomain: "git.example.org",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.opts...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			if got := c.SupportedDomain(); got != tt.wantDomain {
+				t.Errorf("SupportedDomain() = %q, want %q", got, tt.wantDomain)
+			}
+			if got := c.ProviderID(); got != ProviderID {
+				t.Errorf("ProviderID() = %q, want %q", got, ProviderID)
+			}
+			if _, ok := c.Raw().(*Server); !ok {
+				t.Errorf("Raw() = %T, want *Server", c.Raw())
+			}
+		})
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	subOrgRef := gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "org", SubOrganizations: []string{"sub"}}
+
+	if err := s.CreateOrganization(orgRef(), gitprovider.OrganizationInfo{}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("CreateOrganization() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	orphanRef := gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "missing", SubOrganizations: []string{"sub"}}
+	if err := s.CreateOrganization(orphanRef, gitprovider.OrganizationInfo{}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("CreateOrganization() without parent error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if err := s.CreateOrganization(subOrgRef, gitprovider.OrganizationInfo{}); err != nil {
+		t.Fatalf("CreateOrganization returned error: %v", err)
+	}
+	if err := s.CreateTeam(orgRef(), gitprovider.TeamInfo{Name: "team", Members: []string{"alice", "bob"}}); err != nil {
+		t.Fatalf("CreateTeam returned error: %v", err)
+	}
+
+	org, err := c.Organizations().Get(ctx, orgRef())
+	if err != nil {
+		t.Fatalf("Organizations().Get returned error: %v", err)
+	}
+	want := gitprovider.OrganizationInfo{Name: gitprovider.StringVar("org"), Description: gitprovider.StringVar("An organization")}
+	if diff := cmp.Diff(want, org.Get()); diff != "" {
+		t.Errorf("Organizations().Get() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := c.Organizations().Get(ctx, orphanRef); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Organizations().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if _, err := c.Organizations().Get(ctx, gitprovider.OrganizationRef{Domain: "other.com", Organization: "org"}); !errors.Is(err, gitprovider.ErrDomainUnsupported) {
+		t.Errorf("Organizations().Get() error = %v, want %v", err, gitprovider.ErrDomainUnsupported)
+	}
+
+	orgs, err := c.Organizations().List(ctx)
+	if err != nil || len(orgs) != 1 || orgs[0].Organization().String() != orgRef().String() {
+		t.Errorf("Organizations().List() = %v, %v, want only the top-level organization", orgs, err)
+	}
+	children, err := c.Organizations().Children(ctx, orgRef())
+	if err != nil || len(children) != 1 || children[0].Organization().String() != subOrgRef.String() {
+		t.Errorf("Organizations().Children() = %v, %v, want the sub-organization", children, err)
+	}
+
+	team, err := org.Teams().Get(ctx, "team")
+	if err != nil {
+		t.Fatalf("Teams().Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(gitprovider.TeamInfo{Name: "team", Members: []string{"alice", "bob"}}, team.Get()); diff != "" {
+		t.Errorf("Teams().Get() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := org.Teams().Get(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Teams().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if teams, err := org.Teams().List(ctx); err != nil || len(teams) != 1 {
+		t.Errorf("Teams().List() = %v, %v, want one team", teams, err)
+	}
+}
+
+func TestOrganizationWrites(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	newRef := gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "new"}
+	subRef := gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "new", SubOrganizations: []string{"sub"}}
+
+	if _, err := c.Organizations().Create(ctx, orgRef(), gitprovider.OrganizationInfo{}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	org, err := c.Organizations().Create(ctx, newRef, gitprovider.OrganizationInfo{Description: gitprovider.StringVar("new")})
+	if err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+	want := gitprovider.OrganizationInfo{Name: gitprovider.StringVar("new"), Description: gitprovider.StringVar("new")}
+	if diff := cmp.Diff(want, org.Get()); diff != "" {
+		t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+	}
+
+	req := gitprovider.OrganizationInfo{Name: gitprovider.StringVar("Sub"), Description: gitprovider.StringVar("A sub-organization")}
+	sub, actionTaken, err := c.Organizations().Reconcile(ctx, subRef, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want the sub-organization to be created", actionTaken, err)
+	}
+	if _, actionTaken, err := c.Organizations().Reconcile(ctx, subRef, gitprovider.OrganizationInfo{Name: req.Name}); err != nil || actionTaken {
+		t.Errorf("Reconcile() of unchanged state = %v, %v, want no action", actionTaken, err)
+	}
+	req.Description = gitprovider.StringVar("Updated")
+	if _, actionTaken, err := c.Organizations().Reconcile(ctx, subRef, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() of changed state = %v, %v, want an update", actionTaken, err)
+	}
+	if err := sub.Set(gitprovider.OrganizationInfo{Name: gitprovider.StringVar("")}); !errors.Is(err, validation.ErrFieldRequired) {
+		t.Errorf("Set() with an empty name error = %v, want %v", err, validation.ErrFieldRequired)
+	}
+	if actionTaken, err := sub.Reconcile(ctx); err != nil || !actionTaken {
+		t.Errorf("organization Reconcile() = %v, %v, want the description to be reverted", actionTaken, err)
+	}
+	if err := sub.Set(gitprovider.OrganizationInfo{Description: gitprovider.StringVar("Updated")}); err != nil {
+		t.Fatalf("Set() returned error: %v", err)
+	}
+	if err := sub.Update(ctx); err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+	sub, err = c.Organizations().Get(ctx, subRef)
+	if err != nil || *sub.Get().Description != "Updated" {
+		t.Errorf("Get() after Update() = %v, %v, want the updated description", sub, err)
+	}
+
+	repoRef := gitprovider.OrgRepositoryRef{OrganizationRef: subRef, RepositoryName: "repo"}
+	if _, err := c.OrgRepositories().Create(ctx, repoRef, gitprovider.RepositoryInfo{}); err != nil {
+		t.Fatalf("OrgRepositories().Create returned error: %v", err)
+	}
+	if err := org.Delete(ctx); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	destructive, err := s.NewClient(gitprovider.WithDestructiveAPICalls(true))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	org, err = destructive.Organizations().Get(ctx, newRef)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	if err := org.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := c.Organizations().Get(ctx, subRef); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() of sub-organization after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if _, err := c.OrgRepositories().Get(ctx, repoRef); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("OrgRepositories().Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestTeamWrites(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	org, err := c.Organizations().Get(ctx, orgRef())
+	if err != nil {
+		t.Fatalf("Organizations().Get returned error: %v", err)
+	}
+
+	if _, err := org.Teams().Create(ctx, gitprovider.TeamInfo{}); !errors.Is(err, validation.ErrFieldRequired) {
+		t.Errorf("Create() without name error = %v, want %v", err, validation.ErrFieldRequired)
+	}
+	team, err := org.Teams().Create(ctx, gitprovider.TeamInfo{Name: "team", Members: []string{"alice"}})
+	if err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+	if _, err := org.Teams().Create(ctx, gitprovider.TeamInfo{Name: "team"}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+
+	if err := team.AddMember(ctx, "bob"); err != nil {
+		t.Fatalf("AddMember() returned error: %v", err)
+	}
+	if err := team.AddMember(ctx, "bob"); err != nil {
+		t.Fatalf("AddMember() of an existing member returned error: %v", err)
+	}
+	if err := team.RemoveMember(ctx, "alice"); err != nil {
+		t.Fatalf("RemoveMember() returned error: %v", err)
+	}
+	if err := team.RemoveMember(ctx, "alice"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("RemoveMember() of a non-member error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if diff := cmp.Diff([]string{"bob"}, team.Get().Members); diff != "" {
+		t.Errorf("members after AddMember() and RemoveMember() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := team.Set(gitprovider.TeamInfo{Name: "renamed"}); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Set() with another name error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+	if _, actionTaken, err := org.Teams().Reconcile(ctx, gitprovider.TeamInfo{Name: "team", Members: []string{"carol", "bob"}}); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want the members to be updated", actionTaken, err)
+	}
+	team, actionTaken, err := org.Teams().Reconcile(ctx, gitprovider.TeamInfo{Name: "team", Members: []string{"bob", "carol"}})
+	if err != nil || actionTaken {
+		t.Errorf("Reconcile() with reordered members = %v, %v, want no action", actionTaken, err)
+	}
+	if _, actionTaken, err := org.Teams().Reconcile(ctx, gitprovider.TeamInfo{Name: "other"}); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want the team to be created", actionTaken, err)
+	}
+
+	if err := team.Delete(ctx); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	destructive, err := s.NewClient(gitprovider.WithDestructiveAPICalls(true))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	destructiveOrg, err := destructive.Organizations().Get(ctx, orgRef())
+	if err != nil {
+		t.Fatalf("Organizations().Get returned error: %v", err)
+	}
+	destructiveTeam, err := destructiveOrg.Teams().Get(ctx, "team")
+	if err != nil {
+		t.Fatalf("Teams().Get returned error: %v", err)
+	}
+	if err := destructiveTeam.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := org.Teams().Get(ctx, "team"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if actionTaken, err := team.Reconcile(ctx); err != nil || !actionTaken {
+		t.Errorf("team Reconcile() after Delete() = %v, %v, want the team to be recreated", actionTaken, err)
+	}
+	if diff := cmp.Diff([]string{"carol", "bob"}, team.Get().Members); diff != "" {
+		t.Errorf("members after Reconcile() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+
+	missingOrgRepo := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "missing"},
+		RepositoryName:  "repo",
+	}
+	if _, err := c.OrgRepositories().Create(ctx, missingOrgRepo, gitprovider.RepositoryInfo{}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Create() in missing organization error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	req := gitprovider.RepositoryInfo{Description: gitprovider.StringVar("desc")}
+	repo, actionTaken, err := c.OrgRepositories().Reconcile(ctx, repoRef(), req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want repository to be created", actionTaken, err)
+	}
+	want := gitprovider.RepositoryInfo{
+		Description:   gitprovider.StringVar("desc"),
+		DefaultBranch: gitprovider.StringVar("main"),
+		Visibility:    gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}
+	if diff := cmp.Diff(want, repo.Get()); diff != "" {
+		t.Errorf("Reconcile() mismatch (-want +got):\n%s", diff)
+	}
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, repoRef(), req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	if _, err := c.OrgRepositories().Create(ctx, repoRef(), req); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+
+	req.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPublic)
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, repoRef(), req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want repository to be updated", actionTaken, err)
+	}
+	repo, err = c.OrgRepositories().Get(ctx, repoRef())
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if got := *repo.Get().Visibility; got != gitprovider.RepositoryVisibilityPublic {
+		t.Errorf("Visibility = %q, want %q", got, gitprovider.RepositoryVisibilityPublic)
+	}
+
+	repos, err := c.OrgRepositories().List(ctx, orgRef())
+	if err != nil || len(repos) != 1 {
+		t.Errorf("OrgRepositories().List() = %v, %v, want one repository", repos, err)
+	}
+
+	// Organization repositories aren't user repositories
+	userRef := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "org"},
+		RepositoryName: "repo",
+	}
+	if _, err := c.UserRepositories().Get(ctx, userRef); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("UserRepositories().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	userRef.UserLogin = "user"
+	if _, err := c.UserRepositories().Create(ctx, userRef, gitprovider.RepositoryInfo{}); err != nil {
+		t.Errorf("UserRepositories().Create returned error: %v", err)
+	}
+	if repos, err := c.UserRepositories().List(ctx, userRef.UserRef); err != nil || len(repos) != 1 {
+		t.Errorf("UserRepositories().List() = %v, %v, want one repository", repos, err)
+	}
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	if err := repo.Delete(ctx); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+
+	destructive, err := s.NewClient(gitprovider.WithDestructiveAPICalls(true))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	repo, err = destructive.OrgRepositories().Get(ctx, repoRef())
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if err := repo.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := c.OrgRepositories().Get(ctx, repoRef()); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if err := repo.Delete(ctx); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("second Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestDeployKeys(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	req := gitprovider.DeployKeyInfo{Name: "key", Key: []byte("ssh-ed25519 AAAA")}
+	dk, actionTaken, err := repo.DeployKeys().Reconcile(ctx, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want deploy key to be created", actionTaken, err)
+	}
+	if !*dk.Get().ReadOnly {
+		t.Error("ReadOnly = false, want the default true")
+	}
+	if _, actionTaken, err := repo.DeployKeys().Reconcile(ctx, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	if _, err := repo.DeployKeys().Create(ctx, req); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	if _, err := repo.DeployKeys().Create(ctx, gitprovider.DeployKeyInfo{Name: "other", Key: req.Key}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() with same key error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+
+	req.ReadOnly = gitprovider.BoolVar(false)
+	if _, actionTaken, err := repo.DeployKeys().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want deploy key to be updated", actionTaken, err)
+	}
+	dk, err = repo.DeployKeys().Get(ctx, "key")
+	if err != nil {
+		t.Fatalf("DeployKeys().Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(req, dk.Get()); diff != "" {
+		t.Errorf("DeployKeys().Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := dk.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if keys, err := repo.DeployKeys().List(ctx); err != nil || len(keys) != 0 {
+		t.Errorf("DeployKeys().List() = %v, %v, want no keys", keys, err)
+	}
+}
+
+func TestBranchProtections(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	commit(t, repo, "main", map[string]*string{"README.md": gitprovider.StringVar("# repo\n")})
+
+	req := gitprovider.BranchProtectionInfo{Branch: "main", RequiredApprovals: gitprovider.IntVar(1)}
+	bp, actionTaken, err := repo.BranchProtections().Reconcile(ctx, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want branch protection to be created", actionTaken, err)
+	}
+	if !*bp.Get().EnforceAdmins {
+		t.Error("EnforceAdmins = false, want the default true")
+	}
+	if _, actionTaken, err := repo.BranchProtections().Reconcile(ctx, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	if _, err := repo.BranchProtections().Create(ctx, req); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	if branch, err := repo.Branches().Get(ctx, "main"); err != nil || !branch.Get().Protected {
+		t.Errorf("Branches().Get() = %v, %v, want protected branch", branch, err)
+	}
+
+	req.RequiredStatusChecks = []string{"ci/build"}
+	req.AllowForcePush = gitprovider.BoolVar(true)
+	req.EnforceAdmins = gitprovider.BoolVar(false)
+	if _, actionTaken, err := repo.BranchProtections().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want branch protection to be updated", actionTaken, err)
+	}
+	bp, err = repo.BranchProtections().Get(ctx, "main")
+	if err != nil {
+		t.Fatalf("BranchProtections().Get returned error: %v", err)
+	}
+	if diff := cmp.Diff(req, bp.Get()); diff != "" {
+		t.Errorf("BranchProtections().Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := bp.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := repo.BranchProtections().Get(ctx, "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("BranchProtections().Get() after Delete error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if branch, err := repo.Branches().Get(ctx, "main"); err != nil || branch.Get().Protected {
+		t.Errorf("Branches().Get() = %v, %v, want unprotected branch", branch, err)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	req := gitprovider.WebhookInfo{URL: "https://flux.example.com/hook", Secret: gitprovider.StringVar("s3cr3t")}
+	wh, actionTaken, err := repo.Webhooks().Reconcile(ctx, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want webhook to be created", actionTaken, err)
+	}
+	if diff := cmp.Diff([]gitprovider.WebhookEvent{gitprovider.WebhookEventPush}, wh.Get().Events); diff != "" {
+		t.Errorf("Events mismatch (-want +got):\n%s", diff)
+	}
+	if _, actionTaken, err := repo.Webhooks().Reconcile(ctx, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	if _, err := repo.Webhooks().Create(ctx, req); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+
+	req.Events = []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush}
+	req.Active = gitprovider.BoolVar(false)
+	if _, actionTaken, err := repo.Webhooks().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want webhook to be updated", actionTaken, err)
+	}
+	wh, err = repo.Webhooks().Get(ctx, req.URL)
+	if err != nil {
+		t.Fatalf("Webhooks().Get returned error: %v", err)
+	}
+	req.Default()
+	if !req.Equals(wh.Get()) {
+		t.Errorf("Webhooks().Get() = %+v, want %+v", wh.Get(), req)
+	}
+	// The secret is stored, but not reported
+	if secret := wh.APIObject().(*Webhook).Secret; secret != "s3cr3t" || wh.Get().Secret != nil {
+		t.Errorf("Secret = %q, %v, want it to be stored but not reported", secret, wh.Get().Secret)
+	}
+
+	if err := repo.Webhooks().Delete(ctx, req.URL); err != nil {
+		t.Fatalf("Webhooks().Delete returned error: %v", err)
+	}
+	if webhooks, err := repo.Webhooks().List(ctx); err != nil || len(webhooks) != 0 {
+		t.Errorf("Webhooks().List() = %v, %v, want no webhooks", webhooks, err)
+	}
+}
+
+func TestTeamAccess(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	req := gitprovider.TeamAccessInfo{Name: "team"}
+	if _, err := repo.TeamAccess().Create(ctx, req); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Create() for missing team error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if err := s.CreateTeam(orgRef(), gitprovider.TeamInfo{Name: "team"}); err != nil {
+		t.Fatalf("CreateTeam returned error: %v", err)
+	}
+
+	ta, actionTaken, err := repo.TeamAccess().Reconcile(ctx, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want team access to be created", actionTaken, err)
+	}
+	if got := *ta.Get().Permission; got != gitprovider.RepositoryPermissionPull {
+		t.Errorf("Permission = %q, want the default %q", got, gitprovider.RepositoryPermissionPull)
+	}
+	if _, actionTaken, err := repo.TeamAccess().Reconcile(ctx, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	req.Permission = gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionMaintain)
+	if _, actionTaken, err := repo.TeamAccess().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want team access to be updated", actionTaken, err)
+	}
+	list, err := repo.TeamAccess().List(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("TeamAccess().List() = %v, %v, want one entry", list, err)
+	}
+	if diff := cmp.Diff(req, list[0].Get()); diff != "" {
+		t.Errorf("TeamAccess().List() mismatch (-want +got):\n%s", diff)
+	}
+	if err := list[0].Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := repo.TeamAccess().Get(ctx, "team"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestUserAccess(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	req := gitprovider.UserAccessInfo{Login: "alice"}
+	ua, actionTaken, err := repo.UserAccess().Reconcile(ctx, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want user access to be created", actionTaken, err)
+	}
+	if got := *ua.Get().Permission; got != gitprovider.RepositoryPermissionPull {
+		t.Errorf("Permission = %q, want the default %q", got, gitprovider.RepositoryPermissionPull)
+	}
+	if ua.Invited() {
+		t.Error("Invited() = true, want false")
+	}
+	if _, err := repo.UserAccess().Create(ctx, req); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() for existing user error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	req.Permission = gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionAdmin)
+	if _, actionTaken, err := repo.UserAccess().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want user access to be updated", actionTaken, err)
+	}
+	list, err := repo.UserAccess().List(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("UserAccess().List() = %v, %v, want one entry", list, err)
+	}
+	if diff := cmp.Diff(req, list[0].Get()); diff != "" {
+		t.Errorf("UserAccess().List() mismatch (-want +got):\n%s", diff)
+	}
+	if err := list[0].Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := repo.UserAccess().Get(ctx, "alice"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestCommitsAndBranches(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	if got := readFiles(t, repo, "", "main"); got["README.md"] != "# repo\n" {
+		t.Errorf("files after AutoInit = %v, want README.md", got)
+	}
+
+	first := commit(t, repo, "main", map[string]*string{
+		"a.txt":     gitprovider.StringVar("a"),
+		"dir/b.txt": gitprovider.StringVar("b"),
+	})
+	second := commit(t, repo, "main", map[string]*string{
+		"/a.txt":    gitprovider.StringVar("changed"),
+		"README.md": nil,
+	})
+	want := map[string]string{"a.txt": "changed", "dir/b.txt": "b"}
+	if diff := cmp.Diff(want, readFiles(t, repo, "", "main", &gitprovider.FilesGetOptions{Recursive: true})); diff != "" {
+		t.Errorf("files mismatch (-want +got):\n%s", diff)
+	}
+
+	commits, err := repo.Commits().ListPage(ctx, "main", 2, 1)
+	if err != nil {
+		t.Fatalf("Commits().ListPage returned error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Get().Sha != second.Sha || commits[1].Get().Sha != first.Sha {
+		t.Errorf("Commits().ListPage() = %v, want the last two commits", commits)
+	}
+	if commits, err := repo.Commits().ListPage(ctx, "main", 2, 2); err != nil || len(commits) != 1 {
+		t.Errorf("Commits().ListPage() second page = %v, %v, want the initial commit", commits, err)
+	}
+	if commits[0].Get().Author != commitAuthor.Name {
+		t.Errorf("Author = %q, want %q", commits[0].Get().Author, commitAuthor.Name)
+	}
+
+	if _, err := repo.Commits().Create(ctx, "missing", "commit", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("c.txt"), Content: gitprovider.StringVar("c")},
+	}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Commits().Create() on missing branch error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if _, err := repo.Commits().Create(ctx, "main", "commit", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("missing.txt")},
+	}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Commits().Create() deleting missing file error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	if err := repo.Branches().Create(ctx, "feature", first.Sha); err != nil {
+		t.Fatalf("Branches().Create returned error: %v", err)
+	}
+	if err := repo.Branches().Create(ctx, "feature", first.Sha); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Branches().Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	if err := repo.Branches().Create(ctx, "other", "0123456789012345678901234567890123456789"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches().Create() with unknown commit error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if got := readFiles(t, repo, "a.txt", "feature"); got["a.txt"] != "a" {
+		t.Errorf("a.txt on feature = %q, want %q", got["a.txt"], "a")
+	}
+
+	// The commits are stored in the backing Git repository
+	gitRepo, err := s.GitRepository(repoRef())
+	if err != nil {
+		t.Fatalf("GitRepository returned error: %v", err)
+	}
+	head, err := gitRepo.Head()
+	if err != nil {
+		t.Fatalf("Head returned error: %v", err)
+	}
+	if head.Hash().String() != second.Sha {
+		t.Errorf("HEAD = %s, want %s", head.Hash(), second.Sha)
+	}
+}
+
+func TestBranches(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	info := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("a")})
+	if err := repo.Branches().Create(ctx, "feature", info.Sha); err != nil {
+		t.Fatalf("Branches().Create returned error: %v", err)
+	}
+
+	branches, err := repo.Branches().List(ctx)
+	if err != nil {
+		t.Fatalf("Branches().List returned error: %v", err)
+	}
+	got := []gitprovider.BranchInfo{}
+	for _, branch := range branches {
+		got = append(got, branch.Get())
+	}
+	want := []gitprovider.BranchInfo{{Name: "feature", Sha: info.Sha}, {Name: "main", Sha: info.Sha}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Branches().List() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := repo.Branches().Get(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	if err := repo.Branches().SetDefault(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches().SetDefault() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if err := repo.Branches().SetDefault(ctx, "feature"); err != nil {
+		t.Fatalf("Branches().SetDefault returned error: %v", err)
+	}
+	updated, err := c.OrgRepositories().Get(ctx, repoRef())
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if got := *updated.Get().DefaultBranch; got != "feature" {
+		t.Errorf("DefaultBranch = %q, want %q", got, "feature")
+	}
+
+	// Deleting branches is a destructive call, and the default branch can't be deleted
+	if err := repo.Branches().Delete(ctx, "main"); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Branches().Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	dc, err := s.NewClient(gitprovider.WithDestructiveAPICalls(true))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	drepo, err := dc.OrgRepositories().Get(ctx, repoRef())
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if err := drepo.Branches().Delete(ctx, "feature"); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Branches().Delete() of the default branch error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+	if err := drepo.Branches().Delete(ctx, "main"); err != nil {
+		t.Fatalf("Branches().Delete returned error: %v", err)
+	}
+	if err := drepo.Branches().Delete(ctx, "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches().Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestFilesAndTrees(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	info := commit(t, repo, "main", map[string]*string{
+		"dir/a.txt":     gitprovider.StringVar("a"),
+		"dir/sub/b.txt": gitprovider.StringVar("bb"),
+	})
+
+	if diff := cmp.Diff(map[string]string{"dir/a.txt": "a"}, readFiles(t, repo, "dir", "main")); diff != "" {
+		t.Errorf("Files().Get() mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]string{"dir/a.txt": "a", "dir/sub/b.txt": "bb"}
+	if diff := cmp.Diff(want, readFiles(t, repo, "dir/", "main", &gitprovider.FilesGetOptions{Recursive: true})); diff != "" {
+		t.Errorf("Files().Get() recursive mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := repo.Files().Get(ctx, "missing", "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Files().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	tree, err := repo.Trees().Get(ctx, info.TreeSha, false)
+	if err != nil {
+		t.Fatalf("Trees().Get returned error: %v", err)
+	}
+	var paths []string
+	for _, entry := range tree.Tree {
+		paths = append(paths, entry.Path+" "+entry.Type+" "+entry.Mode)
+	}
+	if diff := cmp.Diff([]string{"README.md blob 100644", "dir tree 040000"}, paths); diff != "" {
+		t.Errorf("Trees().Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Commit SHAs can be used as well
+	entries, err := repo.Trees().List(ctx, info.Sha, "dir/sub", true)
+	if err != nil {
+		t.Fatalf("Trees().List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "dir/sub/b.txt" || entries[0].Size != 2 {
+		t.Errorf("Trees().List() = %+v, want dir/sub/b.txt", entries)
+	}
+}
+
+func TestFilePutAndDelete(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+
+	if err := repo.Files().Put(ctx, "a.txt", "main", "a", "Add a.txt", ""); err != nil {
+		t.Fatalf("Files().Put() returned error: %v", err)
+	}
+	if err := repo.Files().Put(ctx, "a.txt", "main", "b", "Add a.txt again", ""); !errors.Is(err, gitprovider.ErrConflict) {
+		t.Errorf("Files().Put() of existing file error = %v, want %v", err, gitprovider.ErrConflict)
+	}
+	files, err := repo.Files().Get(ctx, "a.txt", "main")
+	if err != nil {
+		t.Fatalf("Files().Get() returned error: %v", err)
+	}
+	sha := *files[0].SHA
+	if want := "2e65efe2a145dda7ee51d1741299f848e5bf752e"; sha != want {
+		t.Errorf("SHA = %q, want the blob SHA %q", sha, want)
+	}
+
+	if err := repo.Files().Put(ctx, "a.txt", "main", "changed", "Change a.txt", sha); err != nil {
+		t.Fatalf("Files().Put() returned error: %v", err)
+	}
+	if got := readFiles(t, repo, "a.txt", "main"); got["a.txt"] != "changed" {
+		t.Errorf("files after Put = %v, want changed a.txt", got)
+	}
+	// The SHA is stale after the update
+	if err := repo.Files().Put(ctx, "a.txt", "main", "again", "Change a.txt", sha); !errors.Is(err, gitprovider.ErrConflict) {
+		t.Errorf("Files().Put() with stale SHA error = %v, want %v", err, gitprovider.ErrConflict)
+	}
+	if err := repo.Files().Delete(ctx, "a.txt", "main", "Delete a.txt", sha); !errors.Is(err, gitprovider.ErrConflict) {
+		t.Errorf("Files().Delete() with stale SHA error = %v, want %v", err, gitprovider.ErrConflict)
+	}
+
+	files, err = repo.Files().Get(ctx, "a.txt", "main")
+	if err != nil {
+		t.Fatalf("Files().Get() returned error: %v", err)
+	}
+	if err := repo.Files().Delete(ctx, "a.txt", "main", "Delete a.txt", *files[0].SHA); err != nil {
+		t.Fatalf("Files().Delete() returned error: %v", err)
+	}
+	if err := repo.Files().Delete(ctx, "a.txt", "main", "Delete a.txt", *files[0].SHA); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Files().Delete() of deleted file error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if _, err := repo.Files().Get(ctx, "a.txt", "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Files().Get() after Delete error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestCommitFileActions(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	commit(t, repo, "main", map[string]*string{
+		"a.sh":  gitprovider.StringVar("#!/bin/sh"),
+		"b.txt": gitprovider.StringVar("b"),
+	})
+
+	changed, err := repo.Commits().Create(ctx, "main", "change files", []gitprovider.CommitFile{
+		{
+			Path:         gitprovider.StringVar("bin/a.sh"),
+			Action:       gitprovider.CommitFileActionVar(gitprovider.CommitFileActionMove),
+			PreviousPath: gitprovider.StringVar("a.sh"),
+			Mode:         gitprovider.CommitFileModeVar(gitprovider.CommitFileModeExecutable),
+		},
+		{
+			Path:   gitprovider.StringVar("b.txt"),
+			Action: gitprovider.CommitFileActionVar(gitprovider.CommitFileActionDelete),
+		},
+		{
+			Path:     gitprovider.StringVar("c.bin"),
+			Content:  gitprovider.StringVar("AP8="),
+			Encoding: gitprovider.ContentEncodingVar(gitprovider.ContentEncodingBase64),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Commits().Create returned error: %v", err)
+	}
+
+	entries, err := repo.Trees().List(ctx, changed.Get().Sha, "", true)
+	if err != nil {
+		t.Fatalf("Trees().List returned error: %v", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path+" "+entry.Mode)
+	}
+	if diff := cmp.Diff([]string{"README.md 100644", "bin/a.sh 100755", "c.bin 100644"}, paths); diff != "" {
+		t.Errorf("Trees().List() mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]string{"bin/a.sh": "#!/bin/sh"}
+	if diff := cmp.Diff(want, readFiles(t, repo, "bin", "main")); diff != "" {
+		t.Errorf("Files().Get() mismatch (-want +got):\n%s", diff)
+	}
+	if got := readFiles(t, repo, "c.bin", "main"); got["c.bin"] != "\x00\xff" {
+		t.Errorf("Files().Get() = %q, want decoded binary content", got["c.bin"])
+	}
+
+	tests := []struct {
+		name    string
+		file    gitprovider.CommitFile
+		wantErr error
+	}{
+		{
+			name:    "add existing file",
+			file:    gitprovider.CommitFile{Path: gitprovider.StringVar("c.bin"), Content: gitprovider.StringVar("c"), Action: gitprovider.CommitFileActionVar(gitprovider.CommitFileActionAdd)},
+			wantErr: gitprovider.ErrAlreadyExists,
+		},
+		{
+			name:    "update missing file",
+			file:    gitprovider.CommitFile{Path: gitprovider.StringVar("d.txt"), Content: gitprovider.StringVar("d"), Action: gitprovider.CommitFileActionVar(gitprovider.CommitFileActionUpdate)},
+			wantErr: gitprovider.ErrNotFound,
+		},
+		{
+			name:    "move missing file",
+			file:    gitprovider.CommitFile{Path: gitprovider.StringVar("d.txt"), PreviousPath: gitprovider.StringVar("b.txt"), Action: gitprovider.CommitFileActionVar(gitprovider.CommitFileActionMove)},
+			wantErr: gitprovider.ErrNotFound,
+		},
+		{
+			name:    "invalid file",
+			file:    gitprovider.CommitFile{Path: gitprovider.StringVar("d.txt"), Content: gitprovider.StringVar("d"), Action: gitprovider.CommitFileActionVar(gitprovider.CommitFileActionDelete)},
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repo.Commits().Create(ctx, "main", "invalid", []gitprovider.CommitFile{tt.file}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Commits().Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommitStatuses(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	info := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("a")})
+
+	for _, req := range []gitprovider.CommitStatusInfo{
+		{State: gitprovider.CommitStatusStatePending, Context: "ci/build"},
+		{State: gitprovider.CommitStatusStateSuccess, Context: "cd/deploy", TargetURL: gitprovider.StringVar("https://cd.example.com/1")},
+		// Replaces the pending status of the same context
+		{State: gitprovider.CommitStatusStateFailure, Context: "ci/build", Description: gitprovider.StringVar("tests failed")},
+	} {
+		if _, err := repo.Statuses().Create(ctx, info.Sha, req); err != nil {
+			t.Fatalf("Statuses().Create returned error: %v", err)
+		}
+	}
+	statuses, err := repo.Statuses().List(ctx, info.Sha)
+	if err != nil {
+		t.Fatalf("Statuses().List returned error: %v", err)
+	}
+	got := []gitprovider.CommitStatusInfo{}
+	for _, status := range statuses {
+		got = append(got, status.Get())
+	}
+	want := []gitprovider.CommitStatusInfo{
+		{State: gitprovider.CommitStatusStateFailure, Context: "ci/build", Description: gitprovider.StringVar("tests failed")},
+		{State: gitprovider.CommitStatusStateSuccess, Context: "cd/deploy", TargetURL: gitprovider.StringVar("https://cd.example.com/1")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Statuses().List() mismatch (-want +got):\n%s", diff)
+	}
+	if state := gitprovider.CombinedCommitStatusState(got); state != gitprovider.CommitStatusStateFailure {
+		t.Errorf("CombinedCommitStatusState() = %q, want %q", state, gitprovider.CommitStatusStateFailure)
+	}
+
+	missing := "0123456789012345678901234567890123456789"
+	if _, err := repo.Statuses().Create(ctx, missing, want[0]); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Statuses().Create() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestTagsAndReleases(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	info := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("a")})
+
+	if _, err := repo.Tags().Create(ctx, gitprovider.TagInfo{Name: "v0.1.0", Sha: info.Sha}); err != nil {
+		t.Fatalf("Tags().Create returned error: %v", err)
+	}
+	if _, err := repo.Tags().Create(ctx, gitprovider.TagInfo{
+		Name:    "v0.2.0",
+		Sha:     info.Sha,
+		Message: gitprovider.StringVar("Release v0.2.0"),
+	}); err != nil {
+		t.Fatalf("Tags().Create returned error: %v", err)
+	}
+	if _, err := repo.Tags().Create(ctx, gitprovider.TagInfo{Name: "v0.1.0", Sha: info.Sha}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Tags().Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	tags, err := repo.Tags().List(ctx)
+	if err != nil {
+		t.Fatalf("Tags().List returned error: %v", err)
+	}
+	gotTags := []gitprovider.TagInfo{}
+	for _, tag := range tags {
+		gotTags = append(gotTags, tag.Get())
+	}
+	wantTags := []gitprovider.TagInfo{
+		{Name: "v0.1.0", Sha: info.Sha},
+		{Name: "v0.2.0", Sha: info.Sha, Message: gitprovider.StringVar("Release v0.2.0")},
+	}
+	if diff := cmp.Diff(wantTags, gotTags); diff != "" {
+		t.Errorf("Tags().List() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The tag of a release is created from the default branch if it doesn't exist
+	rel, err := repo.Releases().Create(ctx, gitprovider.ReleaseInfo{
+		TagName:    "v0.3.0",
+		Name:       gitprovider.StringVar("v0.3.0"),
+		Notes:      gitprovider.StringVar("Fixes"),
+		Prerelease: gitprovider.BoolVar(true),
+	})
+	if err != nil {
+		t.Fatalf("Releases().Create returned error: %v", err)
+	}
+	tag, err := repo.Tags().Get(ctx, "v0.3.0")
+	if err != nil {
+		t.Fatalf("Tags().Get returned error: %v", err)
+	}
+	if tag.Get().Sha != info.Sha {
+		t.Errorf("Tags().Get().Sha = %q, want %q", tag.Get().Sha, info.Sha)
+	}
+	if _, err := repo.Releases().Create(ctx, gitprovider.ReleaseInfo{TagName: "v0.3.0"}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Releases().Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+
+	asset, err := repo.Releases().UploadAsset(ctx, "v0.3.0", "manifests.yaml", []byte("kind: List\n"))
+	if err != nil {
+		t.Fatalf("Releases().UploadAsset returned error: %v", err)
+	}
+	if _, err := repo.Releases().Update(ctx, rel.Get().TagName, gitprovider.ReleaseInfo{Prerelease: gitprovider.BoolVar(false)}); err != nil {
+		t.Fatalf("Releases().Update returned error: %v", err)
+	}
+	updated, err := repo.Releases().Get(ctx, "v0.3.0")
+	if err != nil {
+		t.Fatalf("Releases().Get returned error: %v", err)
+	}
+	want := gitprovider.ReleaseInfo{
+		TagName:    "v0.3.0",
+		Name:       gitprovider.StringVar("v0.3.0"),
+		Notes:      gitprovider.StringVar("Fixes"),
+		Draft:      gitprovider.BoolVar(false),
+		Prerelease: gitprovider.BoolVar(false),
+		Assets:     []gitprovider.ReleaseAsset{*asset},
+	}
+	if diff := cmp.Diff(want, updated.Get()); diff != "" {
+		t.Errorf("Releases().Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := repo.Releases().Delete(ctx, "v0.3.0"); err != nil {
+		t.Fatalf("Releases().Delete returned error: %v", err)
+	}
+	if _, err := repo.Releases().Get(ctx, "v0.3.0"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Releases().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	// Deleting tags is a destructive call
+	if err := repo.Tags().Delete(ctx, "v0.3.0"); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Tags().Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	dc, err := s.NewClient(gitprovider.WithDestructiveAPICalls(true))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	drepo, err := dc.OrgRepositories().Get(ctx, repoRef())
+	if err != nil {
+		t.Fatalf("OrgRepositories().Get returned error: %v", err)
+	}
+	if err := drepo.Tags().Delete(ctx, "v0.3.0"); err != nil {
+		t.Fatalf("Tags().Delete returned error: %v", err)
+	}
+	if _, err := repo.Tags().Get(ctx, "v0.3.0"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Tags().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	tests := []struct {
+		name               string
+		mergeMethod        gitprovider.MergeMethod
+		deleteSourceBranch bool
+		feature            map[string]*string
+		main               map[string]*string
+		wantErr            bool
+		wantParents        int
+		wantFiles          map[string]string
+	}{
+		{
+			name:        "merge",
+			mergeMethod: gitprovider.MergeMethodMerge,
+			feature:     map[string]*string{"a.txt": gitprovider.StringVar("feature")},
+			main:        map[string]*string{"b.txt": gitprovider.StringVar("main")},
+			wantParents: 2,
+			wantFiles:   map[string]string{"README.md": "# repo\n", "a.txt": "feature", "b.txt": "main"},
+		},
+		{
+			name:        "squash with deleted file",
+			mergeMethod: gitprovider.MergeMethodSquash,
+			feature:     map[string]*string{"README.md": nil},
+			wantParents: 1,
+			wantFiles:   map[string]string{"a.txt": "base"},
+		},
+		{
+			name:               "rebase and delete source branch",
+			mergeMethod:        gitprovider.MergeMethodRebase,
+			deleteSourceBranch: true,
+			feature:            map[string]*string{"a.txt": gitprovider.StringVar("feature")},
+			main:               map[string]*string{"b.txt": gitprovider.StringVar("main")},
+			wantParents:        1,
+			wantFiles:          map[string]string{"README.md": "# repo\n", "a.txt": "feature", "b.txt": "main"},
+		},
+		{
+			name:        "fast-forward",
+			mergeMethod: gitprovider.MergeMethodFastForwardOnly,
+			feature:     map[string]*string{"a.txt": gitprovider.StringVar("feature")},
+			wantParents: 1,
+			wantFiles:   map[string]string{"README.md": "# repo\n", "a.txt": "feature"},
+		},
+		{
+			name:        "fast-forward of diverged branches",
+			mergeMethod: gitprovider.MergeMethodFastForwardOnly,
+			feature:     map[string]*string{"a.txt": gitprovider.StringVar("feature")},
+			main:        map[string]*string{"b.txt": gitprovider.StringVar("main")},
+			wantErr:     true,
+		},
+		{
+			name:        "conflict",
+			mergeMethod: gitprovider.MergeMethodMerge,
+			feature:     map[string]*string{"a.txt": gitprovider.StringVar("feature")},
+			main:        map[string]*string{"a.txt": gitprovider.StringVar("main")},
+			wantErr:     true,
+		},
+		{
+			name:        "rebase conflict",
+			mergeMethod: gitprovider.MergeMethodRebase,
+			feature:     map[string]*string{"a.txt": gitprovider.StringVar("feature")},
+			main:        map[string]*string{"a.txt": gitprovider.StringVar("main")},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := setup(t, gitprovider.WithDestructiveAPICalls(true))
+			ctx := context.Background()
+			repo := createRepo(t, c)
+			base := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("base")})
+			if err := repo.Branches().Create(ctx, "feature", base.Sha); err != nil {
+				t.Fatalf("Branches().Create returned error: %v", err)
+			}
+			head := commit(t, repo, "feature", tt.feature)
+			if tt.main != nil {
+				base = commit(t, repo, "main", tt.main)
+			}
+
+			if _, err := repo.PullRequests().Create(ctx, "title", "missing", "main", ""); !errors.Is(err, gitprovider.ErrNotFound) {
+				t.Errorf("PullRequests().Create() error = %v, want %v", err, gitprovider.ErrNotFound)
+			}
+			pr, err := repo.PullRequests().Create(ctx, "title", "feature", "main", "description")
+			if err != nil {
+				t.Fatalf("PullRequests().Create returned error: %v", err)
+			}
+			want := gitprovider.PullRequestInfo{
+				Number:       1,
+				WebURL:       repoRef().String() + "/pull/1",
+				Title:        "title",
+				Description:  "description",
+				State:        gitprovider.PullRequestStateOpen,
+				SourceBranch: "feature",
+				TargetBranch: "main",
+				HeadSHA:      head.Sha,
+				BaseSHA:      base.Sha,
+				Author:       commitAuthor.Name,
+			}
+			if diff := cmp.Diff(want, pr.Get(), cmpopts.IgnoreFields(gitprovider.PullRequestInfo{}, "CreatedAt", "UpdatedAt")); diff != "" {
+				t.Errorf("PullRequests().Create() mismatch (-want +got):\n%s", diff)
+			}
+
+			if tt.deleteSourceBranch {
+				// Deleting the source branch is destructive, hence the merge is refused by default
+				readOnly, err := s.NewClient()
+				if err != nil {
+					t.Fatalf("NewClient returned error: %v", err)
+				}
+				readOnlyRepo, err := readOnly.OrgRepositories().Get(ctx, repoRef())
+				if err != nil {
+					t.Fatalf("OrgRepositories().Get returned error: %v", err)
+				}
+				err = readOnlyRepo.PullRequests().Merge(ctx, 1, tt.mergeMethod, "", &gitprovider.PullRequestMergeOptions{
+					DeleteSourceBranch: gitprovider.BoolVar(true),
+				})
+				if !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+					t.Errorf("Merge() without destructive calls error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+				}
+			}
+
+			err = repo.PullRequests().Merge(ctx, 1, tt.mergeMethod, "", &gitprovider.PullRequestMergeOptions{
+				DeleteSourceBranch: gitprovider.BoolVar(tt.deleteSourceBranch),
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Merge() succeeded, want merge conflict")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Merge() returned error: %v", err)
+			}
+			pr, err = repo.PullRequests().Get(ctx, 1)
+			if err != nil || !pr.Get().Merged || pr.Get().State != gitprovider.PullRequestStateMerged {
+				t.Fatalf("PullRequests().Get() = %v, %v, want merged pull request", pr, err)
+			}
+			if err := repo.PullRequests().Merge(ctx, 1, tt.mergeMethod, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+				t.Errorf("second Merge() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+			}
+			if diff := cmp.Diff(tt.wantFiles, readFiles(t, repo, "", "main")); diff != "" {
+				t.Errorf("files after merge mismatch (-want +got):\n%s", diff)
+			}
+			if _, err := repo.Branches().Get(ctx, "feature"); errors.Is(err, gitprovider.ErrNotFound) != tt.deleteSourceBranch {
+				t.Errorf("Branches().Get() of source branch error = %v, want deleted = %v", err, tt.deleteSourceBranch)
+			}
+
+			gitRepo, err := s.GitRepository(repoRef())
+			if err != nil {
+				t.Fatalf("GitRepository returned error: %v", err)
+			}
+			mergeCommit, err := gitRepo.CommitObject(plumbing.NewHash(pr.APIObject().(*PullRequest).MergeCommitSHA))
+			if err != nil {
+				t.Fatalf("CommitObject returned error: %v", err)
+			}
+			if got := mergeCommit.NumParents(); got != tt.wantParents {
+				t.Errorf("merge commit has %d parents, want %d", got, tt.wantParents)
+			}
+		})
+	}
+}
+
+func TestPullRequestLifecycle(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	base := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("base")})
+	for _, branch := range []string{"feature", "other", "release"} {
+		if err := repo.Branches().Create(ctx, branch, base.Sha); err != nil {
+			t.Fatalf("Branches().Create returned error: %v", err)
+		}
+	}
+	for _, branch := range []string{"feature", "other"} {
+		if _, err := repo.PullRequests().Create(ctx, "title", branch, "main", ""); err != nil {
+			t.Fatalf("PullRequests().Create returned error: %v", err)
+		}
+	}
+
+	pr, err := repo.PullRequests().Update(ctx, 1, gitprovider.PullRequestUpdateInfo{
+		Title:        gitprovider.StringVar("new title"),
+		TargetBranch: gitprovider.StringVar("release"),
+	})
+	if err != nil {
+		t.Fatalf("PullRequests().Update returned error: %v", err)
+	}
+	if info := pr.Get(); info.Title != "new title" || info.TargetBranch != "release" {
+		t.Errorf("PullRequests().Update() = %+v, want new title and target branch", info)
+	}
+	if _, err := repo.PullRequests().Update(ctx, 1, gitprovider.PullRequestUpdateInfo{TargetBranch: gitprovider.StringVar("missing")}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("PullRequests().Update() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	if err := repo.PullRequests().Close(ctx, 2); err != nil {
+		t.Fatalf("PullRequests().Close returned error: %v", err)
+	}
+	if err := repo.PullRequests().Merge(ctx, 2, gitprovider.MergeMethodMerge, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("PullRequests().Merge() of closed pull request error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+
+	for _, tt := range []struct {
+		opts *gitprovider.PullRequestListOptions
+		want []int
+	}{
+		{opts: &gitprovider.PullRequestListOptions{}, want: []int{1, 2}},
+		{opts: &gitprovider.PullRequestListOptions{State: gitprovider.PullRequestStateVar(gitprovider.PullRequestStateOpen)}, want: []int{1}},
+		{opts: &gitprovider.PullRequestListOptions{State: gitprovider.PullRequestStateVar(gitprovider.PullRequestStateClosed)}, want: []int{2}},
+		{opts: &gitprovider.PullRequestListOptions{TargetBranch: gitprovider.StringVar("main")}, want: []int{2}},
+		{opts: &gitprovider.PullRequestListOptions{SourceBranch: gitprovider.StringVar("feature")}, want: []int{1}},
+	} {
+		prs, err := repo.PullRequests().List(ctx, tt.opts)
+		if err != nil {
+			t.Fatalf("PullRequests().List returned error: %v", err)
+		}
+		got := []int{}
+		for _, pr := range prs {
+			got = append(got, pr.Get().Number)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("PullRequests().List(%+v) mismatch (-want +got):\n%s", tt.opts, diff)
+		}
+	}
+}
+
+func TestPullRequestCreateOptions(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	base := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("base")})
+	if err := repo.Branches().Create(ctx, "feature", base.Sha); err != nil {
+		t.Fatalf("Branches().Create returned error: %v", err)
+	}
+
+	if _, err := repo.PullRequests().Create(ctx, "title", "feature", "main", "", &gitprovider.PullRequestCreateOptions{Labels: []string{""}}); !errors.Is(err, validation.ErrFieldRequired) {
+		t.Errorf("PullRequests().Create() error = %v, want %v", err, validation.ErrFieldRequired)
+	}
+	pr, err := repo.PullRequests().Create(ctx, "title", "feature", "main", "", &gitprovider.PullRequestCreateOptions{
+		Labels:              []string{"bug"},
+		Assignees:           []string{"alice"},
+		Reviewers:           []string{"bob"},
+		Draft:               gitprovider.BoolVar(true),
+		MaintainerCanModify: gitprovider.BoolVar(true),
+	})
+	if err != nil {
+		t.Fatalf("PullRequests().Create returned error: %v", err)
+	}
+	if !pr.Get().Draft {
+		t.Errorf("PullRequests().Create() Draft = false, want true")
+	}
+	apiObj := pr.APIObject().(*PullRequest)
+	want := &PullRequest{Labels: []string{"bug"}, Assignees: []string{"alice"}, Reviewers: []string{"bob"}, Draft: true, MaintainerCanModify: true}
+	if diff := cmp.Diff(want, apiObj, cmpopts.IgnoreFields(PullRequest{}, "Number", "Title", "SourceBranch", "TargetBranch", "HeadSHA", "BaseSHA", "Author", "WebURL", "CreatedAt", "UpdatedAt")); diff != "" {
+		t.Errorf("PullRequests().Create() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullRequestCommentsAndReviews(t *testing.T) {
+	s, c := setup(t)
+	ctx := context.Background()
+	repo := createRepo(t, c)
+	base := commit(t, repo, "main", map[string]*string{"a.txt": gitprovider.StringVar("base")})
+	if err := repo.Branches().Create(ctx, "feature", base.Sha); err != nil {
+		t.Fatalf("Branches().Create returned error: %v", err)
+	}
+	pr, err := repo.PullRequests().Create(ctx, "title", "feature", "main", "")
+	if err != nil {
+		t.Fatalf("PullRequests().Create returned error: %v", err)
+	}
+
+	first, err := pr.Comments().Create(ctx, "first")
+	if err != nil {
+		t.Fatalf("Comments().Create returned error: %v", err)
+	}
+	second, err := pr.Comments().Create(ctx, "second")
+	if err != nil {
+		t.Fatalf("Comments().Create returned error: %v", err)
+	}
+	if _, err := pr.Comments().Update(ctx, first.Get().ID, "edited"); err != nil {
+		t.Fatalf("Comments().Update returned error: %v", err)
+	}
+	if err := pr.Comments().Delete(ctx, second.Get().ID); err != nil {
+		t.Fatalf("Comments().Delete returned error: %v", err)
+	}
+	if err := pr.Comments().Delete(ctx, second.Get().ID); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Comments().Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	comments, err := pr.Comments().List(ctx)
+	if err != nil {
+		t.Fatalf("Comments().List returned error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Get().Body != "edited" {
+		t.Errorf("Comments().List() = %v, want the edited comment", comments)
+	}
+
+	for _, review := range []PullRequestReview{
+		{Reviewer: "alice", State: gitprovider.PullRequestReviewStateChangesRequested},
+		{Reviewer: "bob", State: gitprovider.PullRequestReviewStatePending},
+		{Reviewer: "alice", State: gitprovider.PullRequestReviewStateApproved},
+	} {
+		if err := s.SetPullRequestReview(repoRef(), 1, review); err != nil {
+			t.Fatalf("SetPullRequestReview returned error: %v", err)
+		}
+	}
+	if err := s.SetPullRequestReview(repoRef(), 2, PullRequestReview{Reviewer: "alice", State: gitprovider.PullRequestReviewStateApproved}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("SetPullRequestReview() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	reviews, err := pr.Reviews().List(ctx)
+	if err != nil {
+		t.Fatalf("Reviews().List returned error: %v", err)
+	}
+	got := map[string]gitprovider.PullRequestReviewState{}
+	for _, review := range reviews {
+		got[review.Get().Reviewer] = review.Get().State
+	}
+	want := map[string]gitprovider.PullRequestReviewState{
+		"alice": gitprovider.PullRequestReviewStateApproved,
+		"bob":   gitprovider.PullRequestReviewStatePending,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Reviews().List() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	s := NewServer()
+	if err := s.CreateOrganization(orgRef(), gitprovider.OrganizationInfo{}); err != nil {
+		t.Fatalf("CreateOrganization returned error: %v", err)
+	}
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := s.NewClient(opts...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: orgRef(),
+	})
+}
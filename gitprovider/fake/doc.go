@@ -0,0 +1,27 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake implements the gitprovider.Client interface in memory, for unit testing code
+// built on top of this library without talking to a real Git provider.
+//
+// All state is kept in a Server, which can be shared between multiple clients. As the
+// gitprovider.Client interface can't create organizations and teams, these are created
+// through the Server. Organizations can have sub-organizations.
+//
+// Every repository is backed by in-memory go-git storage, hence commits, branches, pull requests,
+// trees and files behave like on a real Git provider, and can be inspected through Server.GitRepository.
+// Repository creation honors AutoInit, but ignores LicenseTemplate.
+package fake
@@ -0,0 +1,1399 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/gitrepo"
+	"github.com/fluxcd/go-git-providers/internal/provider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// Server holds the state of the fake Git provider, i.e. all organizations, teams and repositories.
+// It is safe for concurrent use.
+type Server struct {
+	storage *storage
+}
+
+// storage implements the provider.Backend interface in memory.
+var _ provider.Backend = &storage{}
+
+// storage holds the organizations and repositories of a Server.
+type storage struct {
+	mu    sync.Mutex
+	orgs  map[string]*organizationData
+	repos map[string]*repositoryData
+	// lastID is used to hand out unique IDs, e.g. for deploy keys.
+	lastID int
+}
+
+type organizationData struct {
+	apiObj Organization
+	teams  map[string]*Team
+}
+
+type repositoryData struct {
+	apiObj       Repository
+	git          *git.Repository
+	deployKeys   map[string]*DeployKey
+	teamAccess   map[string]*TeamAccess
+	userAccess   map[string]*UserAccess
+	pullRequests []*PullRequest
+	// branchProtections maps branch names to their protection.
+	branchProtections map[string]*BranchProtection
+	// webhooks maps webhook URLs to their webhook.
+	webhooks map[string]*Webhook
+	// releases maps tag names to their release.
+	releases map[string]*Release
+	// commitStatuses maps commit SHAs to the latest status of each context.
+	commitStatuses map[string][]*CommitStatus
+	// pullRequestComments maps pull request numbers to their comments, oldest first.
+	pullRequestComments map[int][]*PullRequestComment
+	// pullRequestReviews maps pull request numbers to the current review of each reviewer.
+	pullRequestReviews map[int][]*PullRequestReview
+}
+
+// NewServer creates an empty Server.
+func NewServer() *Server {
+	return &Server{
+		storage: &storage{
+			orgs:  map[string]*organizationData{},
+			repos: map[string]*repositoryData{},
+		},
+	}
+}
+
+// CreateOrganization creates an organization, or a sub-organization if ref has SubOrganizations.
+// The parent organization of a sub-organization must exist. If info.Name is unset, the last
+// path element of ref is used.
+//
+// ErrAlreadyExists will be returned if the organization already exists.
+func (s *Server) CreateOrganization(ref gitprovider.OrganizationRef, info gitprovider.OrganizationInfo) error {
+	if err := validation.ValidateTargets("OrganizationRef", ref); err != nil {
+		return err
+	}
+
+	apiObj := &Organization{Ref: ref}
+	if info.Name != nil {
+		apiObj.Name = *info.Name
+	}
+	if info.Description != nil {
+		apiObj.Description = *info.Description
+	}
+	_, err := s.storage.CreateOrganization(apiObj)
+	return err
+}
+
+// CreateTeam creates a team in the given organization, which must exist.
+//
+// ErrAlreadyExists will be returned if the team already exists.
+func (s *Server) CreateTeam(ref gitprovider.OrganizationRef, info gitprovider.TeamInfo) error {
+	if info.Name == "" {
+		return fmt.Errorf("team name is required: %w", gitprovider.ErrInvalidArgument)
+	}
+
+	_, err := s.storage.CreateTeam(ref, &Team{Name: info.Name, Members: info.Members})
+	return err
+}
+
+// GitRepository returns the go-git repository backing the given repository, e.g. for
+// inspecting its contents in tests. It must not be modified concurrently with API calls.
+//
+// ErrNotFound is returned if the repository does not exist.
+func (s *Server) GitRepository(ref gitprovider.RepositoryRef) (*git.Repository, error) {
+	s.storage.mu.Lock()
+	defer s.storage.mu.Unlock()
+
+	r, err := s.storage.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return r.git, nil
+}
+
+// SetPullRequestReview records the review of a pull request, e.g. for testing code that waits
+// for approvals. It replaces the earlier review of the same reviewer, if any. If SubmittedAt
+// is unset, the current time is used.
+//
+// ErrNotFound is returned if the pull request does not exist.
+func (s *Server) SetPullRequestReview(ref gitprovider.RepositoryRef, number int, review PullRequestReview) error {
+	if review.Reviewer == "" {
+		return fmt.Errorf("reviewer is required: %w", gitprovider.ErrInvalidArgument)
+	}
+	if err := gitprovider.ValidatePullRequestReviewState(review.State); err != nil {
+		return fmt.Errorf("review state %q: %w", review.State, gitprovider.ErrInvalidArgument)
+	}
+	if review.SubmittedAt.IsZero() {
+		review.SubmittedAt = time.Now()
+	}
+	return s.storage.setPullRequestReview(ref, number, &review)
+}
+
+//
+// Organizations and teams
+//
+
+func (s *storage) GetOrganization(ref gitprovider.OrganizationRef) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObj := org.apiObj
+	return &apiObj, nil
+}
+
+// ListOrganizations returns the top-level organizations of the given domain, sorted by name.
+func (s *storage) ListOrganizations(domain string) ([]*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apiObjs := []*Organization{}
+	for _, org := range s.orgs {
+		if org.apiObj.Ref.Domain == domain && len(org.apiObj.Ref.SubOrganizations) == 0 {
+			apiObj := org.apiObj
+			apiObjs = append(apiObjs, &apiObj)
+		}
+	}
+	sortOrganizations(apiObjs)
+	return apiObjs, nil
+}
+
+// ListChildOrganizations returns the immediate sub-organizations of ref, sorted by name.
+func (s *storage) ListChildOrganizations(ref gitprovider.OrganizationRef) ([]*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.organization(ref); err != nil {
+		return nil, err
+	}
+	apiObjs := []*Organization{}
+	for _, org := range s.orgs {
+		childRef := org.apiObj.Ref
+		if len(childRef.SubOrganizations) > 0 && parentOrganization(childRef).String() == ref.String() {
+			apiObj := org.apiObj
+			apiObjs = append(apiObjs, &apiObj)
+		}
+	}
+	sortOrganizations(apiObjs)
+	return apiObjs, nil
+}
+
+func (s *storage) GetTeam(ref gitprovider.OrganizationRef, name string) (*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, err := s.team(ref, name)
+	if err != nil {
+		return nil, err
+	}
+	return copyTeam(team), nil
+}
+
+// ListTeams returns the teams of the given organization, sorted by name.
+func (s *storage) ListTeams(ref gitprovider.OrganizationRef) ([]*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Team, 0, len(org.teams))
+	for _, team := range org.teams {
+		apiObjs = append(apiObjs, copyTeam(team))
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+// CreateOrganization creates the organization described by req. The parent of a
+// sub-organization needs to exist. If req.Name is unset, the last path element of the
+// reference is used.
+func (s *storage) CreateOrganization(req *Organization) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := req.Ref.String()
+	if _, ok := s.orgs[key]; ok {
+		return nil, fmt.Errorf("organization %q: %w", key, gitprovider.ErrAlreadyExists)
+	}
+	if len(req.Ref.SubOrganizations) > 0 {
+		if _, err := s.organization(parentOrganization(req.Ref)); err != nil {
+			return nil, err
+		}
+	}
+
+	apiObj := *req
+	if apiObj.Name == "" {
+		parts := strings.Split(req.Ref.GetIdentity(), "/")
+		apiObj.Name = parts[len(parts)-1]
+	}
+	s.orgs[key] = &organizationData{
+		apiObj: apiObj,
+		teams:  map[string]*Team{},
+	}
+	return &apiObj, nil
+}
+
+// UpdateOrganization updates the name and description of the organization.
+func (s *storage) UpdateOrganization(req *Organization) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, err := s.organization(req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	org.apiObj.Name = req.Name
+	org.apiObj.Description = req.Description
+	apiObj := org.apiObj
+	return &apiObj, nil
+}
+
+// DeleteOrganization deletes the organization along with its sub-organizations and all
+// repositories below them.
+func (s *storage) DeleteOrganization(ref gitprovider.OrganizationRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.organization(ref); err != nil {
+		return err
+	}
+	key := ref.String()
+	isBelow := func(other string) bool {
+		return other == key || strings.HasPrefix(other, key+"/")
+	}
+	for orgKey := range s.orgs {
+		if isBelow(orgKey) {
+			delete(s.orgs, orgKey)
+		}
+	}
+	for repoKey, r := range s.repos {
+		if isOrgRepository(r.apiObj.Ref) && isBelow(repositoryOwner(r.apiObj.Ref).String()) {
+			delete(s.repos, repoKey)
+		}
+	}
+	return nil
+}
+
+// CreateTeam adds req to the teams of the organization.
+func (s *storage) CreateTeam(ref gitprovider.OrganizationRef, req *Team) (*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := org.teams[req.Name]; ok {
+		return nil, fmt.Errorf("team %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+	}
+	team := copyTeam(req)
+	org.teams[req.Name] = team
+	return copyTeam(team), nil
+}
+
+// UpdateTeam replaces the members of the team with the same name.
+func (s *storage) UpdateTeam(ref gitprovider.OrganizationRef, req *Team) (*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, err := s.team(ref, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	team.Members = append([]string{}, req.Members...)
+	return copyTeam(team), nil
+}
+
+// AddTeamMember adds login to the members of the team, unless it's a member already.
+func (s *storage) AddTeamMember(ref gitprovider.OrganizationRef, name, login string) (*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, err := s.team(ref, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range team.Members {
+		if member == login {
+			return copyTeam(team), nil
+		}
+	}
+	team.Members = append(team.Members, login)
+	return copyTeam(team), nil
+}
+
+func (s *storage) RemoveTeamMember(ref gitprovider.OrganizationRef, name, login string) (*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team, err := s.team(ref, name)
+	if err != nil {
+		return nil, err
+	}
+	for i, member := range team.Members {
+		if member == login {
+			team.Members = append(team.Members[:i], team.Members[i+1:]...)
+			return copyTeam(team), nil
+		}
+	}
+	return nil, fmt.Errorf("member %q of team %q: %w", login, name, gitprovider.ErrNotFound)
+}
+
+func (s *storage) DeleteTeam(ref gitprovider.OrganizationRef, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, err := s.organization(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := org.teams[name]; !ok {
+		return fmt.Errorf("team %q: %w", name, gitprovider.ErrNotFound)
+	}
+	delete(org.teams, name)
+	return nil
+}
+
+//
+// Repositories
+//
+
+func (s *storage) GetRepo(ref gitprovider.RepositoryRef) (*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObj := r.apiObj
+	return &apiObj, nil
+}
+
+// ListRepos returns the repositories owned by the given organization or user, sorted by name.
+func (s *storage) ListRepos(owner gitprovider.IdentityRef) ([]*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if orgRef, ok := owner.(gitprovider.OrganizationRef); ok {
+		if _, err := s.organization(orgRef); err != nil {
+			return nil, err
+		}
+	}
+	apiObjs := []*Repository{}
+	for _, r := range s.repos {
+		if repositoryOwner(r.apiObj.Ref).String() == owner.String() && isOrgRepository(r.apiObj.Ref) == isOrganization(owner) {
+			apiObj := r.apiObj
+			apiObjs = append(apiObjs, &apiObj)
+		}
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Ref.GetRepository() < apiObjs[j].Ref.GetRepository()
+	})
+	return apiObjs, nil
+}
+
+// CreateRepo creates the repository described by req. If autoInit is true, an initial commit
+// with a README.md file is added to the default branch.
+func (s *storage) CreateRepo(req *Repository, autoInit bool) (*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := req.Ref.String()
+	if _, ok := s.repos[key]; ok {
+		return nil, fmt.Errorf("repository %q: %w", key, gitprovider.ErrAlreadyExists)
+	}
+	// Organizations need to exist, users are created on demand
+	if orgRef, ok := req.Ref.(gitprovider.OrgRepositoryRef); ok {
+		if _, err := s.organization(orgRef.OrganizationRef); err != nil {
+			return nil, err
+		}
+	}
+
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	r := &repositoryData{
+		apiObj:              *req,
+		git:                 repo,
+		deployKeys:          map[string]*DeployKey{},
+		teamAccess:          map[string]*TeamAccess{},
+		userAccess:          map[string]*UserAccess{},
+		branchProtections:   map[string]*BranchProtection{},
+		webhooks:            map[string]*Webhook{},
+		releases:            map[string]*Release{},
+		commitStatuses:      map[string][]*CommitStatus{},
+		pullRequestComments: map[int][]*PullRequestComment{},
+		pullRequestReviews:  map[int][]*PullRequestReview{},
+	}
+	r.apiObj.CreatedAt = time.Now()
+	if err := gitrepo.SetHead(repo, r.apiObj.DefaultBranch); err != nil {
+		return nil, err
+	}
+	if autoInit {
+		content := fmt.Sprintf("# %s\n", req.Ref.GetRepository())
+		if _, err := gitrepo.CommitFiles(repo, r.apiObj.DefaultBranch, "Initial commit", []gitprovider.CommitFile{{
+			Path:    gitprovider.StringVar("README.md"),
+			Content: &content,
+		}}, commitAuthor); err != nil {
+			return nil, err
+		}
+	}
+
+	s.repos[key] = r
+	apiObj := r.apiObj
+	return &apiObj, nil
+}
+
+// UpdateRepo updates the description, default branch and visibility of the repository.
+func (s *storage) UpdateRepo(req *Repository) (*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := gitrepo.SetHead(r.git, req.DefaultBranch); err != nil {
+		return nil, err
+	}
+	r.apiObj.Description = req.Description
+	r.apiObj.DefaultBranch = req.DefaultBranch
+	r.apiObj.Visibility = req.Visibility
+	apiObj := r.apiObj
+	return &apiObj, nil
+}
+
+func (s *storage) DeleteRepo(ref gitprovider.RepositoryRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.repository(ref); err != nil {
+		return err
+	}
+	delete(s.repos, ref.String())
+	return nil
+}
+
+//
+// Deploy keys
+//
+
+func (s *storage) GetDeployKey(ref gitprovider.RepositoryRef, name string) (*DeployKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	dk, ok := r.deployKeys[name]
+	if !ok {
+		return nil, fmt.Errorf("deploy key %q: %w", name, gitprovider.ErrNotFound)
+	}
+	return copyDeployKey(dk), nil
+}
+
+// ListDeployKeys returns the deploy keys of the repository, sorted by name.
+func (s *storage) ListDeployKeys(ref gitprovider.RepositoryRef) ([]*DeployKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*DeployKey, 0, len(r.deployKeys))
+	for _, dk := range r.deployKeys {
+		apiObjs = append(apiObjs, copyDeployKey(dk))
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+// CreateDeployKey adds req to the repository. Both the name and the key need to be unique.
+func (s *storage) CreateDeployKey(ref gitprovider.RepositoryRef, req *DeployKey) (*DeployKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkDeployKey(req); err != nil {
+		return nil, err
+	}
+	s.lastID++
+	dk := copyDeployKey(req)
+	dk.ID = s.lastID
+	r.deployKeys[dk.Name] = dk
+	return copyDeployKey(dk), nil
+}
+
+// UpdateDeployKey replaces the deploy key with the same name.
+func (s *storage) UpdateDeployKey(ref gitprovider.RepositoryRef, req *DeployKey) (*DeployKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	actual, ok := r.deployKeys[req.Name]
+	if !ok {
+		return nil, fmt.Errorf("deploy key %q: %w", req.Name, gitprovider.ErrNotFound)
+	}
+	delete(r.deployKeys, req.Name)
+	if err := r.checkDeployKey(req); err != nil {
+		r.deployKeys[req.Name] = actual
+		return nil, err
+	}
+	dk := copyDeployKey(req)
+	dk.ID = actual.ID
+	r.deployKeys[dk.Name] = dk
+	return copyDeployKey(dk), nil
+}
+
+func (s *storage) DeleteDeployKey(ref gitprovider.RepositoryRef, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.deployKeys[name]; !ok {
+		return fmt.Errorf("deploy key %q: %w", name, gitprovider.ErrNotFound)
+	}
+	delete(r.deployKeys, name)
+	return nil
+}
+
+// checkDeployKey makes sure neither the name nor the key of dk is in use already.
+func (r *repositoryData) checkDeployKey(dk *DeployKey) error {
+	if _, ok := r.deployKeys[dk.Name]; ok {
+		return fmt.Errorf("deploy key %q: %w", dk.Name, gitprovider.ErrAlreadyExists)
+	}
+	for _, other := range r.deployKeys {
+		if string(other.Key) == string(dk.Key) {
+			return fmt.Errorf("key of deploy key %q is already in use by %q: %w", dk.Name, other.Name, gitprovider.ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+//
+// Branch protections
+//
+
+func (s *storage) GetBranchProtection(ref gitprovider.RepositoryRef, branch string) (*BranchProtection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	bp, ok := r.branchProtections[branch]
+	if !ok {
+		return nil, fmt.Errorf("protection of branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	return copyBranchProtection(bp), nil
+}
+
+// ListBranchProtections returns the branch protections of the repository, sorted by branch.
+func (s *storage) ListBranchProtections(ref gitprovider.RepositoryRef) ([]*BranchProtection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*BranchProtection, 0, len(r.branchProtections))
+	for _, bp := range r.branchProtections {
+		apiObjs = append(apiObjs, copyBranchProtection(bp))
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Branch < apiObjs[j].Branch
+	})
+	return apiObjs, nil
+}
+
+// SetBranchProtection protects a branch of the repository, the branch doesn't need to exist.
+// If create is true, ErrAlreadyExists is returned if the branch is protected already, otherwise
+// ErrNotFound is returned if it isn't.
+func (s *storage) SetBranchProtection(ref gitprovider.RepositoryRef, req *BranchProtection, create bool) (*BranchProtection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	_, exists := r.branchProtections[req.Branch]
+	switch {
+	case create && exists:
+		return nil, fmt.Errorf("protection of branch %q: %w", req.Branch, gitprovider.ErrAlreadyExists)
+	case !create && !exists:
+		return nil, fmt.Errorf("protection of branch %q: %w", req.Branch, gitprovider.ErrNotFound)
+	}
+	r.branchProtections[req.Branch] = copyBranchProtection(req)
+	return copyBranchProtection(req), nil
+}
+
+func (s *storage) DeleteBranchProtection(ref gitprovider.RepositoryRef, branch string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.branchProtections[branch]; !ok {
+		return fmt.Errorf("protection of branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	delete(r.branchProtections, branch)
+	return nil
+}
+
+//
+// Webhooks
+//
+
+func (s *storage) GetWebhook(ref gitprovider.RepositoryRef, url string) (*Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	wh, ok := r.webhooks[url]
+	if !ok {
+		return nil, fmt.Errorf("webhook %q: %w", url, gitprovider.ErrNotFound)
+	}
+	return copyWebhook(wh), nil
+}
+
+// ListWebhooks returns the webhooks of the repository, in the order they were created.
+func (s *storage) ListWebhooks(ref gitprovider.RepositoryRef) ([]*Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Webhook, 0, len(r.webhooks))
+	for _, wh := range r.webhooks {
+		apiObjs = append(apiObjs, copyWebhook(wh))
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].ID < apiObjs[j].ID
+	})
+	return apiObjs, nil
+}
+
+// CreateWebhook adds a webhook to the repository, the URL of which has to be unique.
+func (s *storage) CreateWebhook(ref gitprovider.RepositoryRef, req *Webhook) (*Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.webhooks[req.URL]; ok {
+		return nil, fmt.Errorf("webhook %q: %w", req.URL, gitprovider.ErrAlreadyExists)
+	}
+	s.lastID++
+	wh := copyWebhook(req)
+	wh.ID = s.lastID
+	r.webhooks[wh.URL] = wh
+	return copyWebhook(wh), nil
+}
+
+// UpdateWebhook replaces the webhook with the same URL.
+func (s *storage) UpdateWebhook(ref gitprovider.RepositoryRef, req *Webhook) (*Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	actual, ok := r.webhooks[req.URL]
+	if !ok {
+		return nil, fmt.Errorf("webhook %q: %w", req.URL, gitprovider.ErrNotFound)
+	}
+	wh := copyWebhook(req)
+	wh.ID = actual.ID
+	r.webhooks[wh.URL] = wh
+	return copyWebhook(wh), nil
+}
+
+func (s *storage) DeleteWebhook(ref gitprovider.RepositoryRef, url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.webhooks[url]; !ok {
+		return fmt.Errorf("webhook %q: %w", url, gitprovider.ErrNotFound)
+	}
+	delete(r.webhooks, url)
+	return nil
+}
+
+//
+// Releases
+//
+
+func (s *storage) GetRelease(ref gitprovider.RepositoryRef, tagName string) (*Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := r.release(tagName)
+	if err != nil {
+		return nil, err
+	}
+	return copyRelease(rel), nil
+}
+
+// ListReleases returns the releases of the repository, in the order they were created.
+func (s *storage) ListReleases(ref gitprovider.RepositoryRef) ([]*Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Release, 0, len(r.releases))
+	for _, rel := range r.releases {
+		apiObjs = append(apiObjs, copyRelease(rel))
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].ID < apiObjs[j].ID
+	})
+	return apiObjs, nil
+}
+
+// CreateRelease adds a release for req.TagName. If the tag doesn't exist, a lightweight tag
+// is created from target, which is a branch name or commit SHA and defaults to the default branch.
+func (s *storage) CreateRelease(ref gitprovider.RepositoryRef, req *Release, target string) (*Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.releases[req.TagName]; ok {
+		return nil, fmt.Errorf("release %q: %w", req.TagName, gitprovider.ErrAlreadyExists)
+	}
+	if _, err := gitrepo.GetTag(r.git, req.TagName); errors.Is(err, gitprovider.ErrNotFound) {
+		if target == "" {
+			target = r.apiObj.DefaultBranch
+		}
+		commit, err := gitrepo.ResolveCommit(r.git, target)
+		if err != nil {
+			return nil, err
+		}
+		if err := gitrepo.CreateTag(r.git, req.TagName, commit.Hash.String(), nil, commitAuthor); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	s.lastID++
+	rel := copyRelease(req)
+	rel.ID = s.lastID
+	rel.Assets = nil
+	r.releases[rel.TagName] = rel
+	return copyRelease(rel), nil
+}
+
+// UpdateRelease replaces the name, notes and flags of the release for the same tag.
+func (s *storage) UpdateRelease(ref gitprovider.RepositoryRef, req *Release) (*Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := r.release(req.TagName)
+	if err != nil {
+		return nil, err
+	}
+	rel.Name = req.Name
+	rel.Notes = req.Notes
+	rel.Draft = req.Draft
+	rel.Prerelease = req.Prerelease
+	return copyRelease(rel), nil
+}
+
+func (s *storage) DeleteRelease(ref gitprovider.RepositoryRef, tagName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, err := r.release(tagName); err != nil {
+		return err
+	}
+	delete(r.releases, tagName)
+	return nil
+}
+
+// UploadReleaseAsset attaches content to the release, the asset name has to be unique.
+func (s *storage) UploadReleaseAsset(ref gitprovider.RepositoryRef, tagName, name string, content []byte) (*ReleaseAsset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := r.release(tagName)
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range rel.Assets {
+		if asset.Name == name {
+			return nil, fmt.Errorf("release asset %q: %w", name, gitprovider.ErrAlreadyExists)
+		}
+	}
+	asset := ReleaseAsset{
+		Name: name,
+		URL:  fmt.Sprintf("%s/releases/download/%s/%s", ref.String(), tagName, name),
+		Size: len(content),
+	}
+	rel.Assets = append(rel.Assets, asset)
+	return &asset, nil
+}
+
+func (r *repositoryData) release(tagName string) (*Release, error) {
+	rel, ok := r.releases[tagName]
+	if !ok {
+		return nil, fmt.Errorf("release %q: %w", tagName, gitprovider.ErrNotFound)
+	}
+	return rel, nil
+}
+
+//
+// Commit statuses
+//
+
+// ListCommitStatuses returns the latest status of each context, in the order the contexts
+// were first reported.
+func (s *storage) ListCommitStatuses(ref gitprovider.RepositoryRef, sha string) ([]*CommitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gitrepo.CommitObject(r.git, sha); err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*CommitStatus, 0, len(r.commitStatuses[sha]))
+	for _, status := range r.commitStatuses[sha] {
+		apiObj := *status
+		apiObjs = append(apiObjs, &apiObj)
+	}
+	return apiObjs, nil
+}
+
+// CreateCommitStatus adds the status to the commit, replacing the status of the same context.
+func (s *storage) CreateCommitStatus(ref gitprovider.RepositoryRef, req *CommitStatus) (*CommitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gitrepo.CommitObject(r.git, req.SHA); err != nil {
+		return nil, err
+	}
+	status := *req
+	statuses := r.commitStatuses[req.SHA]
+	replaced := false
+	for i := range statuses {
+		if statuses[i].Context == status.Context {
+			statuses[i] = &status
+			replaced = true
+		}
+	}
+	if !replaced {
+		statuses = append(statuses, &status)
+	}
+	r.commitStatuses[req.SHA] = statuses
+
+	apiObj := status
+	return &apiObj, nil
+}
+
+//
+// Pull request comments and reviews
+//
+
+func (s *storage) ListPullRequestComments(ref gitprovider.RepositoryRef, number int) ([]*PullRequestComment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.pullRequest(number); err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*PullRequestComment, 0, len(r.pullRequestComments[number]))
+	for _, comment := range r.pullRequestComments[number] {
+		apiObj := *comment
+		apiObjs = append(apiObjs, &apiObj)
+	}
+	return apiObjs, nil
+}
+
+// CreatePullRequestComment adds a comment to the pull request, its ID and author are assigned
+// by the server.
+func (s *storage) CreatePullRequestComment(ref gitprovider.RepositoryRef, number int, body string) (*PullRequestComment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.pullRequest(number); err != nil {
+		return nil, err
+	}
+	s.lastID++
+	comment := &PullRequestComment{
+		ID:                int64(s.lastID),
+		PullRequestNumber: number,
+		Body:              body,
+		Author:            commitAuthor.Name,
+		CreatedAt:         time.Now(),
+	}
+	comment.UpdatedAt = comment.CreatedAt
+	r.pullRequestComments[number] = append(r.pullRequestComments[number], comment)
+
+	apiObj := *comment
+	return &apiObj, nil
+}
+
+func (s *storage) UpdatePullRequestComment(ref gitprovider.RepositoryRef, number int, id int64, body string) (*PullRequestComment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.pullRequestComment(number, id)
+	if err != nil {
+		return nil, err
+	}
+	comment := r.pullRequestComments[number][i]
+	comment.Body = body
+	comment.UpdatedAt = time.Now()
+
+	apiObj := *comment
+	return &apiObj, nil
+}
+
+func (s *storage) DeletePullRequestComment(ref gitprovider.RepositoryRef, number int, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	i, err := r.pullRequestComment(number, id)
+	if err != nil {
+		return err
+	}
+	comments := r.pullRequestComments[number]
+	r.pullRequestComments[number] = append(comments[:i], comments[i+1:]...)
+	return nil
+}
+
+// pullRequestComment returns the index of the comment with the given id of the pull request.
+func (r *repositoryData) pullRequestComment(number int, id int64) (int, error) {
+	if _, err := r.pullRequest(number); err != nil {
+		return 0, err
+	}
+	for i, comment := range r.pullRequestComments[number] {
+		if comment.ID == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("pull request %d comment %d: %w", number, id, gitprovider.ErrNotFound)
+}
+
+// setPullRequestReview replaces the review of the same reviewer, or adds review.
+func (s *storage) setPullRequestReview(ref gitprovider.RepositoryRef, number int, review *PullRequestReview) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, err := r.pullRequest(number); err != nil {
+		return err
+	}
+	reviews := r.pullRequestReviews[number]
+	for i := range reviews {
+		if reviews[i].Reviewer == review.Reviewer {
+			reviews[i] = review
+			return nil
+		}
+	}
+	r.pullRequestReviews[number] = append(reviews, review)
+	return nil
+}
+
+// ListPullRequestReviews returns the current review of each reviewer, in the order the
+// reviewers first reviewed.
+func (s *storage) ListPullRequestReviews(ref gitprovider.RepositoryRef, number int) ([]*PullRequestReview, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.pullRequest(number); err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*PullRequestReview, 0, len(r.pullRequestReviews[number]))
+	for _, review := range r.pullRequestReviews[number] {
+		apiObj := *review
+		apiObjs = append(apiObjs, &apiObj)
+	}
+	return apiObjs, nil
+}
+
+//
+// Team access
+//
+
+func (s *storage) GetTeamAccess(ref gitprovider.OrgRepositoryRef, name string) (*TeamAccess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	ta, ok := r.teamAccess[name]
+	if !ok {
+		return nil, fmt.Errorf("team access %q: %w", name, gitprovider.ErrNotFound)
+	}
+	apiObj := *ta
+	return &apiObj, nil
+}
+
+// ListTeamAccess returns the teams with access to the repository, sorted by name.
+func (s *storage) ListTeamAccess(ref gitprovider.OrgRepositoryRef) ([]*TeamAccess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*TeamAccess, 0, len(r.teamAccess))
+	for _, ta := range r.teamAccess {
+		apiObj := *ta
+		apiObjs = append(apiObjs, &apiObj)
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+// SetTeamAccess gives a team of the repository's organization access to the repository.
+// If create is true, ErrAlreadyExists is returned if the team has access already, otherwise
+// ErrNotFound is returned if it doesn't.
+func (s *storage) SetTeamAccess(ref gitprovider.OrgRepositoryRef, req *TeamAccess, create bool) (*TeamAccess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.organization(ref.OrganizationRef)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := org.teams[req.Name]; !ok {
+		return nil, fmt.Errorf("team %q: %w", req.Name, gitprovider.ErrNotFound)
+	}
+	_, exists := r.teamAccess[req.Name]
+	switch {
+	case create && exists:
+		return nil, fmt.Errorf("team access %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+	case !create && !exists:
+		return nil, fmt.Errorf("team access %q: %w", req.Name, gitprovider.ErrNotFound)
+	}
+	apiObj := *req
+	r.teamAccess[req.Name] = &apiObj
+	result := apiObj
+	return &result, nil
+}
+
+func (s *storage) DeleteTeamAccess(ref gitprovider.OrgRepositoryRef, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.teamAccess[name]; !ok {
+		return fmt.Errorf("team access %q: %w", name, gitprovider.ErrNotFound)
+	}
+	delete(r.teamAccess, name)
+	return nil
+}
+
+//
+// User access
+//
+
+func (s *storage) GetUserAccess(ref gitprovider.RepositoryRef, login string) (*UserAccess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	ua, ok := r.userAccess[login]
+	if !ok {
+		return nil, fmt.Errorf("user access %q: %w", login, gitprovider.ErrNotFound)
+	}
+	apiObj := *ua
+	return &apiObj, nil
+}
+
+// ListUserAccess returns the users with access to the repository, sorted by login.
+func (s *storage) ListUserAccess(ref gitprovider.RepositoryRef) ([]*UserAccess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*UserAccess, 0, len(r.userAccess))
+	for _, ua := range r.userAccess {
+		apiObj := *ua
+		apiObjs = append(apiObjs, &apiObj)
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Login < apiObjs[j].Login
+	})
+	return apiObjs, nil
+}
+
+// SetUserAccess gives a user access to the repository. Users are created on demand.
+// If create is true, ErrAlreadyExists is returned if the user has access already, otherwise
+// ErrNotFound is returned if they don't.
+func (s *storage) SetUserAccess(ref gitprovider.RepositoryRef, req *UserAccess, create bool) (*UserAccess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	_, exists := r.userAccess[req.Login]
+	switch {
+	case create && exists:
+		return nil, fmt.Errorf("user access %q: %w", req.Login, gitprovider.ErrAlreadyExists)
+	case !create && !exists:
+		return nil, fmt.Errorf("user access %q: %w", req.Login, gitprovider.ErrNotFound)
+	}
+	apiObj := *req
+	r.userAccess[req.Login] = &apiObj
+	result := apiObj
+	return &result, nil
+}
+
+func (s *storage) DeleteUserAccess(ref gitprovider.RepositoryRef, login string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.userAccess[login]; !ok {
+		return fmt.Errorf("user access %q: %w", login, gitprovider.ErrNotFound)
+	}
+	delete(r.userAccess, login)
+	return nil
+}
+
+//
+// Helpers, s.mu must be held by the caller
+//
+
+// organization returns the stored organization for ref.
+func (s *storage) organization(ref gitprovider.OrganizationRef) (*organizationData, error) {
+	org, ok := s.orgs[ref.String()]
+	if !ok {
+		return nil, fmt.Errorf("organization %q: %w", ref.String(), gitprovider.ErrNotFound)
+	}
+	return org, nil
+}
+
+// team returns the stored team with the given name in the organization.
+func (s *storage) team(ref gitprovider.OrganizationRef, name string) (*Team, error) {
+	org, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	team, ok := org.teams[name]
+	if !ok {
+		return nil, fmt.Errorf("team %q: %w", name, gitprovider.ErrNotFound)
+	}
+	return team, nil
+}
+
+// repository returns the stored repository for ref. Organization repositories aren't
+// found through user repository references, and vice versa.
+func (s *storage) repository(ref gitprovider.RepositoryRef) (*repositoryData, error) {
+	r, ok := s.repos[ref.String()]
+	if !ok || isOrgRepository(r.apiObj.Ref) != isOrgRepository(ref) {
+		return nil, fmt.Errorf("repository %q: %w", ref.String(), gitprovider.ErrNotFound)
+	}
+	return r, nil
+}
+
+// parentOrganization returns the parent of the given sub-organization.
+func parentOrganization(ref gitprovider.OrganizationRef) gitprovider.OrganizationRef {
+	return gitprovider.OrganizationRef{
+		Domain:           ref.Domain,
+		Organization:     ref.Organization,
+		SubOrganizations: ref.SubOrganizations[:len(ref.SubOrganizations)-1],
+	}
+}
+
+// repositoryOwner returns the organization or user owning the repository.
+func repositoryOwner(ref gitprovider.RepositoryRef) gitprovider.IdentityRef {
+	switch r := ref.(type) {
+	case gitprovider.OrgRepositoryRef:
+		return r.OrganizationRef
+	case gitprovider.UserRepositoryRef:
+		return r.UserRef
+	}
+	return ref
+}
+
+func isOrgRepository(ref gitprovider.RepositoryRef) bool {
+	_, ok := ref.(gitprovider.OrgRepositoryRef)
+	return ok
+}
+
+func isOrganization(ref gitprovider.IdentityRef) bool {
+	_, ok := ref.(gitprovider.OrganizationRef)
+	return ok
+}
+
+func sortOrganizations(orgs []*Organization) {
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Ref.String() < orgs[j].Ref.String()
+	})
+}
+
+func copyTeam(team *Team) *Team {
+	return &Team{
+		Name:    team.Name,
+		Members: append([]string{}, team.Members...),
+	}
+}
+
+func copyDeployKey(dk *DeployKey) *DeployKey {
+	apiObj := *dk
+	apiObj.Key = append([]byte{}, dk.Key...)
+	return &apiObj
+}
+
+func copyWebhook(wh *Webhook) *Webhook {
+	apiObj := *wh
+	if wh.Events != nil {
+		apiObj.Events = append([]gitprovider.WebhookEvent{}, wh.Events...)
+	}
+	return &apiObj
+}
+
+func copyRelease(rel *Release) *Release {
+	apiObj := *rel
+	apiObj.Assets = make([]ReleaseAsset, 0, len(rel.Assets))
+	apiObj.Assets = append(apiObj.Assets, rel.Assets...)
+	return &apiObj
+}
+
+func copyBranchProtection(bp *BranchProtection) *BranchProtection {
+	apiObj := *bp
+	if bp.RequiredStatusChecks != nil {
+		apiObj.RequiredStatusChecks = append([]string{}, bp.RequiredStatusChecks...)
+	}
+	return &apiObj
+}
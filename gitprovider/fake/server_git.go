@@ -0,0 +1,450 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/gitrepo"
+)
+
+// commitAuthor is the author and committer of all commits created by the fake provider.
+var commitAuthor = object.Signature{
+	Name:  "Fake Provider",
+	Email: "noreply@example.com",
+}
+
+//
+// Commits and branches
+//
+
+// ListCommitsPage returns the given page of the history of branch, newest commits first.
+// Pages start at 1, page 0 is treated as the first page.
+func (s *storage) ListCommitsPage(ref gitprovider.RepositoryRef, branch string, perPage, page int) ([]*object.Commit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return gitrepo.ListCommits(r.git, branch, perPage, page)
+}
+
+func (s *storage) CreateCommit(ref gitprovider.RepositoryRef, branch, message string, files []gitprovider.CommitFile) (*object.Commit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return gitrepo.CommitFiles(r.git, branch, message, files, commitAuthor)
+}
+
+// CreateBranch creates a branch pointing to the commit with the given SHA.
+//
+// ErrAlreadyExists is returned if the branch exists already.
+func (s *storage) CreateBranch(ref gitprovider.RepositoryRef, branch, sha string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return gitrepo.CreateBranch(r.git, branch, sha)
+}
+
+func (s *storage) ListBranches(ref gitprovider.RepositoryRef) ([]*Branch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := gitrepo.ListBranches(r.git)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Branch, 0, len(refs))
+	for _, ref := range refs {
+		name := ref.Name().Short()
+		_, protected := r.branchProtections[name]
+		apiObjs = append(apiObjs, &Branch{Name: name, SHA: ref.Hash().String(), Protected: protected})
+	}
+	return apiObjs, nil
+}
+
+func (s *storage) GetBranch(ref gitprovider.RepositoryRef, branch string) (*Branch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := gitrepo.BranchCommit(r.git, branch)
+	if err != nil {
+		return nil, err
+	}
+	_, protected := r.branchProtections[branch]
+	return &Branch{Name: branch, SHA: commit.Hash.String(), Protected: protected}, nil
+}
+
+// DeleteBranch deletes the branch, which can't be the default branch.
+func (s *storage) DeleteBranch(ref gitprovider.RepositoryRef, branch string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return gitrepo.DeleteBranch(r.git, branch)
+}
+
+// SetDefaultBranch makes the existing branch the default branch of the repository.
+func (s *storage) SetDefaultBranch(ref gitprovider.RepositoryRef, branch string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	if err := gitrepo.SetDefaultBranch(r.git, branch); err != nil {
+		return err
+	}
+	r.apiObj.DefaultBranch = branch
+	return nil
+}
+
+//
+// Tags
+//
+
+// ListTags returns the tags of the repository, sorted by name.
+func (s *storage) ListTags(ref gitprovider.RepositoryRef) ([]*Tag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := gitrepo.ListTags(r.git)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiObjs = append(apiObjs, &Tag{Name: tag.Name, SHA: tag.Sha, Message: tag.Message})
+	}
+	return apiObjs, nil
+}
+
+func (s *storage) GetTag(ref gitprovider.RepositoryRef, name string) (*Tag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := gitrepo.GetTag(r.git, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Tag{Name: tag.Name, SHA: tag.Sha, Message: tag.Message}, nil
+}
+
+// CreateTag creates a tag pointing to the commit with the given SHA. If message is not nil,
+// an annotated tag is created.
+func (s *storage) CreateTag(ref gitprovider.RepositoryRef, name, sha string, message *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return gitrepo.CreateTag(r.git, name, sha, message, commitAuthor)
+}
+
+func (s *storage) DeleteTag(ref gitprovider.RepositoryRef, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return gitrepo.DeleteTag(r.git, name)
+}
+
+//
+// Pull requests
+//
+
+func (s *storage) ListPullRequests(ref gitprovider.RepositoryRef) ([]*PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*PullRequest, 0, len(r.pullRequests))
+	for _, pr := range r.pullRequests {
+		apiObjs = append(apiObjs, r.pullRequestCopy(pr))
+	}
+	return apiObjs, nil
+}
+
+// CreatePullRequest opens req, its Number and WebURL are assigned by the server.
+// Both branches need to exist.
+func (s *storage) CreatePullRequest(ref gitprovider.RepositoryRef, req *PullRequest) (*PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range []string{req.SourceBranch, req.TargetBranch} {
+		if _, err := gitrepo.BranchCommit(r.git, branch); err != nil {
+			return nil, err
+		}
+	}
+	if req.SourceBranch == req.TargetBranch {
+		return nil, fmt.Errorf("source and target branch are both %q: %w", req.SourceBranch, gitprovider.ErrInvalidArgument)
+	}
+
+	pr := *req
+	pr.Number = len(r.pullRequests) + 1
+	pr.WebURL = fmt.Sprintf("%s/pull/%d", ref.String(), pr.Number)
+	pr.Author = commitAuthor.Name
+	pr.CreatedAt = time.Now()
+	pr.UpdatedAt = pr.CreatedAt
+	r.pullRequests = append(r.pullRequests, &pr)
+	return r.pullRequestCopy(&pr), nil
+}
+
+func (s *storage) GetPullRequest(ref gitprovider.RepositoryRef, number int) (*PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := r.pullRequest(number)
+	if err != nil {
+		return nil, err
+	}
+	return r.pullRequestCopy(pr), nil
+}
+
+// UpdatePullRequest applies the set fields of req to the pull request. The target branch of
+// merged pull requests can't be changed.
+func (s *storage) UpdatePullRequest(ref gitprovider.RepositoryRef, number int, req gitprovider.PullRequestUpdateInfo) (*PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := r.pullRequest(number)
+	if err != nil {
+		return nil, err
+	}
+	if req.TargetBranch != nil {
+		if pr.Merged {
+			return nil, fmt.Errorf("pull request %d is already merged: %w", number, gitprovider.ErrInvalidArgument)
+		}
+		if _, err := gitrepo.BranchCommit(r.git, *req.TargetBranch); err != nil {
+			return nil, err
+		}
+		if *req.TargetBranch == pr.SourceBranch {
+			return nil, fmt.Errorf("source and target branch are both %q: %w", pr.SourceBranch, gitprovider.ErrInvalidArgument)
+		}
+		pr.TargetBranch = *req.TargetBranch
+	}
+	if req.Title != nil {
+		pr.Title = *req.Title
+	}
+	if req.Description != nil {
+		pr.Description = *req.Description
+	}
+	pr.UpdatedAt = time.Now()
+	return r.pullRequestCopy(pr), nil
+}
+
+// ClosePullRequest closes the pull request without merging it. Closing a closed pull request
+// is a no-op.
+func (s *storage) ClosePullRequest(ref gitprovider.RepositoryRef, number int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	pr, err := r.pullRequest(number)
+	if err != nil {
+		return err
+	}
+	if pr.Merged {
+		return fmt.Errorf("pull request %d is already merged: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	if !pr.Closed {
+		// Freeze the commits the pull request was closed at
+		*pr = *r.pullRequestCopy(pr)
+		pr.Closed = true
+		pr.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// MergePullRequest merges the source branch of the pull request into its target branch, and
+// deletes the source branch afterwards if deleteSourceBranch is true.
+// If message is empty, a default commit message is used.
+func (s *storage) MergePullRequest(ref gitprovider.RepositoryRef, number int, mergeMethod gitprovider.MergeMethod, message string, deleteSourceBranch bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	pr, err := r.pullRequest(number)
+	if err != nil {
+		return err
+	}
+	if pr.Merged {
+		return fmt.Errorf("pull request %d is already merged: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	if pr.Closed {
+		return fmt.Errorf("pull request %d is closed: %w", number, gitprovider.ErrInvalidArgument)
+	}
+	// Freeze the commits the pull request was merged at
+	*pr = *r.pullRequestCopy(pr)
+	commit, err := gitrepo.MergeBranch(r.git, pr.Number, pr.Title, pr.TargetBranch, pr.SourceBranch, mergeMethod, message, commitAuthor)
+	if err != nil {
+		return err
+	}
+	pr.Merged = true
+	pr.MergeCommitSHA = commit.Hash.String()
+	pr.UpdatedAt = time.Now()
+	if deleteSourceBranch {
+		return gitrepo.DeleteBranch(r.git, pr.SourceBranch)
+	}
+	return nil
+}
+
+func (r *repositoryData) pullRequest(number int) (*PullRequest, error) {
+	if number < 1 || number > len(r.pullRequests) {
+		return nil, fmt.Errorf("pull request %d: %w", number, gitprovider.ErrNotFound)
+	}
+	return r.pullRequests[number-1], nil
+}
+
+// pullRequestCopy returns a copy of pr. The head and base commits of open pull requests are
+// set to the current commits of their branches, unless the branches have been deleted.
+func (r *repositoryData) pullRequestCopy(pr *PullRequest) *PullRequest {
+	apiObj := *pr
+	apiObj.Labels = append([]string(nil), pr.Labels...)
+	apiObj.Assignees = append([]string(nil), pr.Assignees...)
+	apiObj.Reviewers = append([]string(nil), pr.Reviewers...)
+	if apiObj.Merged || apiObj.Closed {
+		return &apiObj
+	}
+	if head, err := gitrepo.BranchCommit(r.git, apiObj.SourceBranch); err == nil {
+		apiObj.HeadSHA = head.Hash.String()
+	}
+	if base, err := gitrepo.BranchCommit(r.git, apiObj.TargetBranch); err == nil {
+		apiObj.BaseSHA = base.Hash.String()
+	}
+	return &apiObj
+}
+
+//
+// Files and trees
+//
+
+// GetFiles returns the file at path, or the files in the directory at path, on the given branch.
+// Files in sub-directories are only returned if recursive is true.
+func (s *storage) GetFiles(ref gitprovider.RepositoryRef, filePath, branch string, recursive bool) ([]*gitprovider.CommitFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return gitrepo.Files(r.git, filePath, branch, recursive)
+}
+
+// PutFile commits content to the file at filePath on the given branch.
+//
+// ErrConflict is returned if the blob SHA of the file isn't expectedSHA.
+func (s *storage) PutFile(ref gitprovider.RepositoryRef, filePath, branch, content, message, expectedSHA string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	_, err = gitrepo.PutFile(r.git, branch, filePath, content, message, expectedSHA, commitAuthor)
+	return err
+}
+
+// DeleteFile deletes the file at filePath on the given branch.
+//
+// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its blob SHA isn't expectedSHA.
+func (s *storage) DeleteFile(ref gitprovider.RepositoryRef, filePath, branch, message, expectedSHA string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	_, err = gitrepo.DeleteFile(r.git, branch, filePath, message, expectedSHA, commitAuthor)
+	return err
+}
+
+// GetTree returns the tree with the given SHA, or the tree of the commit with the given SHA.
+// If recursive is true, the entries of all sub-trees are included, with their full paths.
+func (s *storage) GetTree(ref gitprovider.RepositoryRef, sha string, recursive bool) (*gitprovider.TreeInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return gitrepo.Tree(r.git, ref, sha, recursive)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/fluxcd/go-git-providers/internal/provider"
+)
+
+// The API objects returned by the APIObject methods of the resources of this provider.
+type (
+	// Organization is the API object of an organization or sub-organization.
+	Organization = provider.Organization
+	// Team is the API object of a team in an organization.
+	Team = provider.Team
+	// Repository is the API object of a repository.
+	Repository = provider.Repository
+	// Branch is the API object of a branch of a repository.
+	Branch = provider.Branch
+	// BranchProtection is the API object of the protection of a branch.
+	BranchProtection = provider.BranchProtection
+	// Webhook is the API object of a webhook of a repository.
+	Webhook = provider.Webhook
+	// DeployKey is the API object of a deploy key of a repository.
+	DeployKey = provider.DeployKey
+	// TeamAccess is the API object of a team's access to a repository.
+	TeamAccess = provider.TeamAccess
+	// UserAccess is the API object of a user's access to a repository.
+	UserAccess = provider.UserAccess
+	// PullRequest is the API object of a pull request.
+	PullRequest = provider.PullRequest
+	// PullRequestReview is the API object of the current review of a reviewer of a pull request,
+	// see Server.SetPullRequestReview.
+	PullRequestReview = provider.PullRequestReview
+	// PullRequestComment is the API object of a general comment of a pull request.
+	PullRequestComment = provider.PullRequestComment
+	// Tag is the API object of a tag of a repository.
+	Tag = provider.Tag
+	// Release is the API object of a release of a repository.
+	Release = provider.Release
+	// CommitStatus is the API object of the status of a commit.
+	CommitStatus = provider.CommitStatus
+	// ReleaseAsset is the API object of a file attached to a release.
+	ReleaseAsset = provider.ReleaseAsset
+)
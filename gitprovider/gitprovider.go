@@ -99,6 +99,12 @@ type OrganizationBound interface {
 	Organization() OrganizationRef
 }
 
+// UserBound describes an object that is bound to a given user account.
+type UserBound interface {
+	// User returns the UserRef associated with this object.
+	User() UserRef
+}
+
 // RepositoryBound describes an object that is bound to a given repository, e.g. a deploy key.
 type RepositoryBound interface {
 	// Repository returns the RepositoryRef associated with this object.
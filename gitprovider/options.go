@@ -94,3 +94,205 @@ func (opts *FilesGetOptions) ApplyFilesGetOptions(target *FilesGetOptions) {
 	target.Recursive = opts.Recursive
 
 }
+
+// MakePullRequestCreateOptions returns a PullRequestCreateOptions based off the mutator functions
+// given to e.g. PullRequestClient.Create().
+func MakePullRequestCreateOptions(opts ...PullRequestCreateOption) (PullRequestCreateOptions, error) {
+	o := &PullRequestCreateOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestCreateOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// PullRequestCreateOption is an interface for applying options when creating pull requests.
+type PullRequestCreateOption interface {
+	// ApplyToPullRequestCreateOptions should apply relevant options to the target.
+	ApplyToPullRequestCreateOptions(target *PullRequestCreateOptions)
+}
+
+// PullRequestCreateOptions specifies optional options when creating a pull request. Providers
+// return ErrNoProviderSupport for options they can't apply.
+type PullRequestCreateOptions struct {
+	// Labels are the names of the labels to add to the pull request.
+	// Default: nil.
+	Labels []string
+
+	// Assignees are the logins of the users to assign the pull request to.
+	// Default: nil.
+	Assignees []string
+
+	// Reviewers are the logins of the users to request a review from.
+	// Default: nil.
+	Reviewers []string
+
+	// Draft can be set to true in order to open the pull request as a draft.
+	// Default: nil (which means "false, ready for review")
+	Draft *bool
+
+	// MaintainerCanModify can be set to true in order to allow maintainers of the target
+	// repository to push to the source branch of a pull request from a fork.
+	// Default: nil (which means the provider default)
+	MaintainerCanModify *bool
+}
+
+// ApplyToPullRequestCreateOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestCreateOptions) ApplyToPullRequestCreateOptions(target *PullRequestCreateOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.Labels != nil {
+		target.Labels = opts.Labels
+	}
+	if opts.Assignees != nil {
+		target.Assignees = opts.Assignees
+	}
+	if opts.Reviewers != nil {
+		target.Reviewers = opts.Reviewers
+	}
+	if opts.Draft != nil {
+		target.Draft = opts.Draft
+	}
+	if opts.MaintainerCanModify != nil {
+		target.MaintainerCanModify = opts.MaintainerCanModify
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *PullRequestCreateOptions) ValidateOptions() error {
+	errs := validation.New("PullRequestCreateOptions")
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{"Labels", opts.Labels},
+		{"Assignees", opts.Assignees},
+		{"Reviewers", opts.Reviewers},
+	} {
+		for _, value := range field.values {
+			if value == "" {
+				errs.Required(field.name)
+				break
+			}
+		}
+	}
+	return errs.Error()
+}
+
+// MakePullRequestMergeOptions returns a PullRequestMergeOptions based off the mutator functions
+// given to e.g. PullRequestClient.Merge().
+func MakePullRequestMergeOptions(opts ...PullRequestMergeOption) (PullRequestMergeOptions, error) {
+	o := &PullRequestMergeOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestMergeOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// PullRequestMergeOption is an interface for applying options when merging pull requests.
+type PullRequestMergeOption interface {
+	// ApplyToPullRequestMergeOptions should apply relevant options to the target.
+	ApplyToPullRequestMergeOptions(target *PullRequestMergeOptions)
+}
+
+// PullRequestMergeOptions specifies optional options when merging a pull request. Providers
+// return ErrNoProviderSupport for options they can't apply.
+type PullRequestMergeOptions struct {
+	// AutoMerge can be set to true in order to merge the pull request once its required checks,
+	// e.g. the pipeline, succeed, instead of merging it right away.
+	// Default: nil (which means "false, merge right away")
+	AutoMerge *bool
+
+	// DeleteSourceBranch can be set to true in order to delete the source branch once the pull
+	// request is merged.
+	// Default: nil (which means "false, keep the source branch")
+	DeleteSourceBranch *bool
+}
+
+// ApplyToPullRequestMergeOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestMergeOptions) ApplyToPullRequestMergeOptions(target *PullRequestMergeOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.AutoMerge != nil {
+		target.AutoMerge = opts.AutoMerge
+	}
+	if opts.DeleteSourceBranch != nil {
+		target.DeleteSourceBranch = opts.DeleteSourceBranch
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *PullRequestMergeOptions) ValidateOptions() error {
+	// All options are optional booleans, there is nothing to validate
+	return nil
+}
+
+// MakePullRequestListOptions returns a PullRequestListOptions based off the mutator functions
+// given to e.g. PullRequestClient.List().
+// validation.ErrFieldEnumInvalid is returned if the state doesn't match known values.
+func MakePullRequestListOptions(opts ...PullRequestListOption) (PullRequestListOptions, error) {
+	o := &PullRequestListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestListOptions(o)
+	}
+	return *o, o.ValidateOptions()
+}
+
+// PullRequestListOption is an interface for applying options when listing pull requests.
+type PullRequestListOption interface {
+	// ApplyToPullRequestListOptions should apply relevant options to the target.
+	ApplyToPullRequestListOptions(target *PullRequestListOptions)
+}
+
+// PullRequestListOptions specifies optional filters when listing pull requests.
+type PullRequestListOptions struct {
+	// State only lists pull requests in the given state.
+	// Default: nil (which means pull requests in all states)
+	State *PullRequestState
+
+	// SourceBranch only lists pull requests from the given branch.
+	// Default: nil.
+	SourceBranch *string
+
+	// TargetBranch only lists pull requests into the given branch.
+	// Default: nil.
+	TargetBranch *string
+}
+
+// ApplyToPullRequestListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestListOptions) ApplyToPullRequestListOptions(target *PullRequestListOptions) {
+	// Go through each field in opts, and apply it to target if set
+	if opts.State != nil {
+		target.State = opts.State
+	}
+	if opts.SourceBranch != nil {
+		target.SourceBranch = opts.SourceBranch
+	}
+	if opts.TargetBranch != nil {
+		target.TargetBranch = opts.TargetBranch
+	}
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *PullRequestListOptions) ValidateOptions() error {
+	errs := validation.New("PullRequestListOptions")
+	if opts.State != nil {
+		errs.Append(ValidatePullRequestState(*opts.State), *opts.State, "State")
+	}
+	return errs.Error()
+}
+
+// Matches returns true if the pull request passes all filters. It is used by providers which
+// can't filter by all fields on the server side.
+func (opts *PullRequestListOptions) Matches(info PullRequestInfo) bool {
+	if opts.State != nil && info.State != *opts.State {
+		return false
+	}
+	if opts.SourceBranch != nil && info.SourceBranch != *opts.SourceBranch {
+		return false
+	}
+	if opts.TargetBranch != nil && info.TargetBranch != *opts.TargetBranch {
+		return false
+	}
+	return true
+}
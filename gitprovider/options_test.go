@@ -93,3 +93,120 @@ func TestMakeRepositoryCreateOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestMakePullRequestListOptions(t *testing.T) {
+	unknownState := PullRequestState("draft")
+	tests := []struct {
+		name        string
+		opts        []PullRequestListOption
+		want        PullRequestListOptions
+		expectedErr error
+	}{
+		{
+			name: "default nil pointers",
+			want: PullRequestListOptions{},
+		},
+		{
+			name: "partial options can form an unit",
+			opts: []PullRequestListOption{
+				&PullRequestListOptions{State: PullRequestStateVar(PullRequestStateOpen)},
+				&PullRequestListOptions{SourceBranch: StringVar("feature")},
+			},
+			want: PullRequestListOptions{State: PullRequestStateVar(PullRequestStateOpen), SourceBranch: StringVar("feature")},
+		},
+		{
+			name:        "invalid state",
+			opts:        []PullRequestListOption{&PullRequestListOptions{State: &unknownState}},
+			want:        PullRequestListOptions{State: &unknownState},
+			expectedErr: validation.ErrFieldEnumInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakePullRequestListOptions(tt.opts...)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("MakePullRequestListOptions() error = %v, wanted %v", err, tt.expectedErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakePullRequestListOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestListOptions_Matches(t *testing.T) {
+	info := PullRequestInfo{State: PullRequestStateOpen, SourceBranch: "feature", TargetBranch: "main"}
+	tests := []struct {
+		name string
+		opts PullRequestListOptions
+		want bool
+	}{
+		{
+			name: "no filters",
+			want: true,
+		},
+		{
+			name: "all filters match",
+			opts: PullRequestListOptions{
+				State:        PullRequestStateVar(PullRequestStateOpen),
+				SourceBranch: StringVar("feature"),
+				TargetBranch: StringVar("main"),
+			},
+			want: true,
+		},
+		{
+			name: "state differs",
+			opts: PullRequestListOptions{State: PullRequestStateVar(PullRequestStateMerged)},
+		},
+		{
+			name: "source branch differs",
+			opts: PullRequestListOptions{SourceBranch: StringVar("main")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Matches(info); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakePullRequestCreateOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []PullRequestCreateOption
+		want        PullRequestCreateOptions
+		expectedErr error
+	}{
+		{
+			name: "default nil fields",
+			want: PullRequestCreateOptions{},
+		},
+		{
+			name: "latter overrides former",
+			opts: []PullRequestCreateOption{
+				&PullRequestCreateOptions{Labels: []string{"automerge"}, Draft: BoolVar(true)},
+				&PullRequestCreateOptions{Labels: []string{"dependencies"}, Reviewers: []string{"alice"}},
+			},
+			want: PullRequestCreateOptions{Labels: []string{"dependencies"}, Reviewers: []string{"alice"}, Draft: BoolVar(true)},
+		},
+		{
+			name:        "empty assignee",
+			opts:        []PullRequestCreateOption{&PullRequestCreateOptions{Assignees: []string{""}}},
+			want:        PullRequestCreateOptions{Assignees: []string{""}},
+			expectedErr: validation.ErrFieldRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakePullRequestCreateOptions(tt.opts...)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("MakePullRequestCreateOptions() error = %v, wanted %v", err, tt.expectedErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakePullRequestCreateOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
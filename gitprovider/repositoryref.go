@@ -19,6 +19,7 @@ package gitprovider
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/fluxcd/go-git-providers/validation"
@@ -306,7 +307,16 @@ func (r UserRepositoryRef) GetCloneURL(transport TransportType) string {
 
 // GetCloneURL returns the URL to clone a repository for a given transport type. If the given
 // TransportType isn't known an empty string is returned.
+// If the domain is a file URL, the path of the repository on the local filesystem is returned
+// for all known transport types.
 func GetCloneURL(rs RepositoryRef, transport TransportType) string {
+	if domainURL, err := url.Parse(rs.GetDomain()); err == nil && domainURL.Scheme == "file" {
+		switch transport {
+		case TransportTypeHTTPS, TransportTypeGit, TransportTypeSSH:
+			return ParseTypeFile(domainURL.Path, rs.GetIdentity(), rs.GetRepository())
+		}
+		return ""
+	}
 	switch transport {
 	case TransportTypeHTTPS:
 		return ParseTypeHTTPS(rs.String())
@@ -336,6 +346,11 @@ func ParseTypeSSH(domain, identity, repository string) string {
 	return fmt.Sprintf("ssh://git@%s/%s/%s", trimmedDomain, identity, repository)
 }
 
+// ParseTypeFile returns the path of a bare repository in the given root directory.
+func ParseTypeFile(root, identity, repository string) string {
+	return filepath.Join(filepath.FromSlash(root), filepath.FromSlash(identity), repository+".git")
+}
+
 // ParseOrganizationURL parses an URL to an organization into a OrganizationRef object.
 func ParseOrganizationURL(o string) (*OrganizationRef, error) {
 	u, parts, err := parseURL(o)
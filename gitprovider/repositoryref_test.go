@@ -543,6 +543,24 @@ func TestGetCloneURL(t *testing.T) {
 			transport: TransportType("random"),
 			want:      "",
 		},
+		{
+			name:      "org: file",
+			repoinfo:  newOrgRepoRef("file:///srv/git", "luxas", []string{"test-org"}, "foo-bar"),
+			transport: TransportTypeSSH,
+			want:      "/srv/git/luxas/test-org/foo-bar.git",
+		},
+		{
+			name:      "user: file",
+			repoinfo:  newUserRepoRef("file:///srv/git", "luxas", "foo-bar"),
+			transport: TransportTypeHTTPS,
+			want:      "/srv/git/luxas/foo-bar.git",
+		},
+		{
+			name:      "user: file, none",
+			repoinfo:  newUserRepoRef("file:///srv/git", "luxas", "foo-bar"),
+			transport: TransportType("random"),
+			want:      "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -717,6 +735,11 @@ func TestGetDomainURL(t *testing.T) {
 			domain: "http://my-gitlab.com",
 			want:   "http://my-gitlab.com",
 		},
+		{
+			name:   "local directory",
+			domain: "file:///srv/git",
+			want:   "file:///srv/git",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
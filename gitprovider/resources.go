@@ -16,33 +16,59 @@ limitations under the License.
 
 package gitprovider
 
+import "context"
+
 // Organization represents an organization in a Git provider.
-// For now, the organization is read-only, i.e. there aren't set/update methods.
 type Organization interface {
 	// Organization implements the Object interface,
 	// allowing access to the underlying object returned from the API.
 	Object
+	// The organization can be updated.
+	Updatable
+	// The organization can be reconciled.
+	Reconcilable
+	// The organization can be deleted.
+	Deletable
 	// OrganizationBound returns organization reference details.
 	OrganizationBound
 
 	// Get returns high-level information about the organization.
 	Get() OrganizationInfo
+	// Set sets high-level desired state for this organization. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(OrganizationInfo) error
 
 	// Teams gives access to the TeamsClient for this specific organization
 	Teams() TeamsClient
 }
 
 // Team represents a team in an organization in a Git provider.
-// For now, the team is read-only, i.e. there aren't set/update methods.
 type Team interface {
 	// Team implements the Object interface,
 	// allowing access to the underlying object returned from the API.
 	Object
+	// The team members can be updated.
+	Updatable
+	// The team can be reconciled.
+	Reconcilable
+	// The team can be deleted.
+	Deletable
 	// OrganizationBound returns organization reference details.
 	OrganizationBound
 
 	// Get returns high-level information about this team.
 	Get() TeamInfo
+	// Set sets high-level desired state for this team. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile(). The name of a team can't be changed.
+	Set(TeamInfo) error
+
+	// AddMember adds the user with the given login to the team.
+	// Adding an existing member is a no-op.
+	AddMember(ctx context.Context, login string) error
+	// RemoveMember removes the user with the given login from the team.
+	//
+	// ErrNotFound is returned if the user isn't a member of the team.
+	RemoveMember(ctx context.Context, login string) error
 }
 
 // UserRepository describes a repository owned by an user.
@@ -68,15 +94,33 @@ type UserRepository interface {
 	// DeployKeys gives access to manipulating deploy keys to access this specific repository.
 	DeployKeys() DeployKeyClient
 
+	// UserAccess gives access to manipulating the individual users' access to this specific repository.
+	UserAccess() UserAccessClient
+
 	// Commits gives access to this specific repository commits
 	Commits() CommitClient
 
 	// Branches gives access to this specific repository branches
 	Branches() BranchClient
 
+	// BranchProtections gives access to the protection rules of this specific repository's branches.
+	BranchProtections() BranchProtectionClient
+
+	// Webhooks gives access to manipulating the webhooks of this specific repository.
+	Webhooks() WebhookClient
+
 	// PullRequests gives access to this specific repository pull requests
 	PullRequests() PullRequestClient
 
+	// Tags gives access to this specific repository tags.
+	Tags() TagClient
+
+	// Releases gives access to this specific repository releases.
+	Releases() ReleaseClient
+
+	// Statuses gives access to the statuses of this specific repository commits.
+	Statuses() CommitStatusClient
+
 	// Files gives access to this specific repository files
 	Files() FileClient
 
@@ -140,6 +184,42 @@ type TeamAccess interface {
 	Set(TeamAccessInfo) error
 }
 
+// UserAccess describes a binding between a repository and an individual user.
+type UserAccess interface {
+	// UserAccess implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The user access can be updated.
+	Updatable
+	// The user access can be reconciled.
+	Reconcilable
+	// The user access can be deleted. Deleting a pending invitation revokes it.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this user access for the repository.
+	Get() UserAccessInfo
+	// Set sets high-level desired state for this user access object. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(UserAccessInfo) error
+	// Invited returns true if the user has been invited to the repository, but hasn't accepted
+	// the invitation yet. Providers without invitations always return false.
+	Invited() bool
+}
+
+// User represents a user account.
+type User interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// UserBound returns the UserRef of this user, which can be used to build UserRepositoryRefs.
+	UserBound
+
+	// Get returns high-level information about this user.
+	Get() UserInfo
+}
+
 // Commit represents a git commit.
 type Commit interface {
 	// Object implements the Object interface,
@@ -150,6 +230,88 @@ type Commit interface {
 	Get() CommitInfo
 }
 
+// BranchProtection represents the protection rules of a branch.
+type BranchProtection interface {
+	// BranchProtection implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The branch protection can be updated.
+	Updatable
+	// The branch protection can be reconciled.
+	Reconcilable
+	// The branch protection can be deleted.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this branch protection.
+	Get() BranchProtectionInfo
+	// Set sets high-level desired state for this branch protection. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(BranchProtectionInfo) error
+}
+
+// Webhook represents a webhook of a repository.
+type Webhook interface {
+	// Webhook implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The webhook can be updated.
+	Updatable
+	// The webhook can be reconciled.
+	Reconcilable
+	// The webhook can be deleted.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this webhook.
+	Get() WebhookInfo
+	// Set sets high-level desired state for this webhook. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(WebhookInfo) error
+}
+
+// Tag represents a git tag.
+type Tag interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this tag.
+	Get() TagInfo
+}
+
+// Release represents a release of a repository.
+type Release interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this release.
+	Get() ReleaseInfo
+}
+
+// CommitStatus represents the status of a commit, as reported by a CI or CD system.
+type CommitStatus interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this commit status.
+	Get() CommitStatusInfo
+}
+
+// Branch represents a git branch.
+type Branch interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this branch.
+	Get() BranchInfo
+}
+
 // PullRequest represents a pull request.
 type PullRequest interface {
 	// Object implements the Object interface,
@@ -158,6 +320,31 @@ type PullRequest interface {
 
 	// Get returns high-level information about this pull request.
 	Get() PullRequestInfo
+
+	// Comments gives access to the general comments of this pull request.
+	Comments() PullRequestCommentClient
+	// Reviews gives access to the reviews of this pull request.
+	Reviews() PullRequestReviewClient
+}
+
+// PullRequestComment represents a general comment on a pull request.
+type PullRequestComment interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this comment.
+	Get() PullRequestCommentInfo
+}
+
+// PullRequestReview represents the review of a pull request by a single reviewer.
+type PullRequestReview interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this review.
+	Get() PullRequestReviewInfo
 }
 
 // Tree represents a git tree which is the hierarchical structure of your git data.
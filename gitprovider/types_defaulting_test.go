@@ -117,6 +117,46 @@ func TestDefaulting(t *testing.T) {
 				Permission: RepositoryPermissionVar(RepositoryPermissionPush),
 			},
 		},
+		{
+			name:       "BranchProtection: sort status checks",
+			structName: "BranchProtection",
+			object: &BranchProtectionInfo{
+				RequiredStatusChecks: []string{"ci/test", "ci/build"},
+			},
+			expected: &BranchProtectionInfo{
+				RequiredApprovals:    IntVar(0),
+				RequiredStatusChecks: []string{"ci/build", "ci/test"},
+				AllowForcePush:       BoolVar(false),
+				EnforceAdmins:        BoolVar(true),
+			},
+		},
+		{
+			name:       "Webhook: empty",
+			structName: "Webhook",
+			object:     &WebhookInfo{},
+			expected: &WebhookInfo{
+				ContentType:           WebhookContentTypeVar(WebhookContentTypeJSON),
+				Events:                []WebhookEvent{WebhookEventPush},
+				InsecureSkipTLSVerify: BoolVar(false),
+				Active:                BoolVar(true),
+			},
+		},
+		{
+			name:       "Webhook: don't set if non-nil (non-default)",
+			structName: "Webhook",
+			object: &WebhookInfo{
+				ContentType:           WebhookContentTypeVar(WebhookContentTypeForm),
+				Events:                []WebhookEvent{WebhookEventRelease},
+				InsecureSkipTLSVerify: BoolVar(true),
+				Active:                BoolVar(false),
+			},
+			expected: &WebhookInfo{
+				ContentType:           WebhookContentTypeVar(WebhookContentTypeForm),
+				Events:                []WebhookEvent{WebhookEventRelease},
+				InsecureSkipTLSVerify: BoolVar(true),
+				Active:                BoolVar(false),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -16,6 +16,15 @@ limitations under the License.
 
 package gitprovider
 
+import (
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// OrganizationInfo implements InfoRequest.
+var _ InfoRequest = OrganizationInfo{}
+
 // OrganizationInfo represents an (top-level- or sub-) organization.
 type OrganizationInfo struct {
 	// Name is the human-friendly name of this organization, e.g. "Flux" or "Kubernetes SIGs".
@@ -25,6 +34,30 @@ type OrganizationInfo struct {
 	Description *string `json:"description"`
 }
 
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (o OrganizationInfo) ValidateInfo() error {
+	validator := validation.New("Organization")
+	// An empty name can't be applied, as it's the only identifying field for humans
+	if o.Name != nil && len(*o.Name) == 0 {
+		validator.Required("Name")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. Fields which aren't set in the desired state are ignored, as
+// providers fill them in with their own values.
+func (o OrganizationInfo) Equals(actual InfoRequest) bool {
+	a, ok := actual.(OrganizationInfo)
+	if !ok {
+		return false
+	}
+	return stringPtrMatches(o.Name, a.Name) && stringPtrMatches(o.Description, a.Description)
+}
+
+// TeamInfo implements InfoRequest.
+var _ InfoRequest = TeamInfo{}
+
 // TeamInfo is a representation for a team of users inside of an organization.
 type TeamInfo struct {
 	// Name describes the name of the team. The team name may contain slashes.
@@ -33,3 +66,84 @@ type TeamInfo struct {
 	// Members points to a set of user names (logins) of the members of this team.
 	Members []string `json:"members"`
 }
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (t TeamInfo) ValidateInfo() error {
+	validator := validation.New("Team")
+	// Make sure we've set the name of the team
+	if len(t.Name) == 0 {
+		validator.Required("Name")
+	}
+	for _, member := range t.Members {
+		if len(member) == 0 {
+			validator.Required("Members")
+			break
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The order of Members doesn't matter.
+func (t TeamInfo) Equals(actual InfoRequest) bool {
+	a, ok := actual.(TeamInfo)
+	if !ok || t.Name != a.Name {
+		return false
+	}
+	added, removed := t.MemberChanges(a)
+	return len(added) == 0 && len(removed) == 0
+}
+
+// MemberChanges returns the members that need to be added to and removed from actual in
+// order to reach the members of this (desired) TeamInfo. Both lists are sorted.
+func (t TeamInfo) MemberChanges(actual TeamInfo) (added, removed []string) {
+	desired := make(map[string]struct{}, len(t.Members))
+	for _, member := range t.Members {
+		desired[member] = struct{}{}
+	}
+	current := make(map[string]struct{}, len(actual.Members))
+	for _, member := range actual.Members {
+		current[member] = struct{}{}
+	}
+	for member := range desired {
+		if _, ok := current[member]; !ok {
+			added = append(added, member)
+		}
+	}
+	for member := range current {
+		if _, ok := desired[member]; !ok {
+			removed = append(removed, member)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// UserInfo is a representation of a user account. All fields but Login are optional, as not
+// every provider exposes them, e.g. the email address might be private.
+type UserInfo struct {
+	// Login is the user name used to refer to the user, e.g. in a UserRef.
+	// +required
+	Login string `json:"login"`
+
+	// Name is the display name of the user.
+	Name string `json:"name,omitempty"`
+
+	// Email is the public or primary email address of the user.
+	Email string `json:"email,omitempty"`
+
+	// AvatarURL is the link to the avatar image of the user.
+	AvatarURL string `json:"avatarURL,omitempty"`
+
+	// WebURL is the link to the profile page of the user.
+	WebURL string `json:"webURL,omitempty"`
+}
+
+// stringPtrMatches returns true if desired is unset, or points to the same value as actual.
+func stringPtrMatches(desired, actual *string) bool {
+	if desired == nil {
+		return true
+	}
+	return actual != nil && *desired == *actual
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package gitprovider
 
 import (
+	"encoding/base64"
+	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/fluxcd/go-git-providers/validation"
@@ -34,6 +37,18 @@ const (
 	defaultBranchName = "main"
 	// by default, deploy keys are read-only.
 	defaultDeployKeyReadOnly = true
+	// by default, protected branches don't require approvals.
+	defaultBranchProtectionRequiredApprovals = 0
+	// by default, force pushes to protected branches are rejected.
+	defaultBranchProtectionAllowForcePush = false
+	// by default, branch protection also applies to administrators.
+	defaultBranchProtectionEnforceAdmins = true
+	// by default, webhooks deliver JSON payloads.
+	defaultWebhookContentType = WebhookContentTypeJSON
+	// by default, webhooks verify the TLS certificate of the receiver.
+	defaultWebhookInsecureSkipTLSVerify = false
+	// by default, webhooks are active.
+	defaultWebhookActive = true
 )
 
 // RepositoryInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
@@ -130,6 +145,50 @@ func (ta TeamAccessInfo) Equals(actual InfoRequest) bool {
 	return reflect.DeepEqual(ta, actual)
 }
 
+// UserAccessInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = UserAccessInfo{}
+var _ DefaultedInfoRequest = &UserAccessInfo{}
+
+// UserAccessInfo contains high-level information about an individual user's access to a repository.
+type UserAccessInfo struct {
+	// Login is the login name of the user.
+	// +required
+	Login string `json:"login"`
+
+	// Permission describes the permission level for which the user is allowed to operate.
+	// Default: pull.
+	// Available options: See the RepositoryPermission enum.
+	// +optional
+	Permission *RepositoryPermission `json:"permission,omitempty"`
+}
+
+// Default defaults the UserAccess fields.
+func (ua *UserAccessInfo) Default() {
+	if ua.Permission == nil {
+		ua.Permission = RepositoryPermissionVar(defaultRepoPermission)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (ua UserAccessInfo) ValidateInfo() error {
+	validator := validation.New("UserAccess")
+	// Make sure we've set the login of the user
+	if len(ua.Login) == 0 {
+		validator.Required("Login")
+	}
+	// Validate the Permission enum
+	if ua.Permission != nil {
+		validator.Append(ValidateRepositoryPermission(*ua.Permission), *ua.Permission, "Permission")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (ua UserAccessInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(ua, actual)
+}
+
 // DeployKeyInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
 var _ InfoRequest = DeployKeyInfo{}
 var _ DefaultedInfoRequest = &DeployKeyInfo{}
@@ -179,6 +238,175 @@ func (dk DeployKeyInfo) Equals(actual InfoRequest) bool {
 	return reflect.DeepEqual(dk, actual)
 }
 
+// BranchProtectionInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = BranchProtectionInfo{}
+var _ DefaultedInfoRequest = &BranchProtectionInfo{}
+
+// BranchProtectionInfo contains high-level information about the protection of a branch.
+// Providers that can't express a setting report its default value, and return
+// ErrNoProviderSupport when asked for a different one.
+type BranchProtectionInfo struct {
+	// Branch is the name of the protected branch.
+	// +required
+	Branch string `json:"branch"`
+
+	// RequiredApprovals is the number of approving reviews a pull request needs before
+	// it can be merged into the branch.
+	// Default value at POST-time: 0.
+	// +optional
+	RequiredApprovals *int `json:"requiredApprovals,omitempty"`
+
+	// RequiredStatusChecks lists the contexts of the commit statuses that need to succeed
+	// before a pull request can be merged into the branch. The order doesn't matter, the
+	// list is sorted when defaulted.
+	// No default value at POST-time.
+	// +optional
+	RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty"`
+
+	// AllowForcePush specifies whether the history of the branch can be rewritten.
+	// Default value at POST-time: false.
+	// +optional
+	AllowForcePush *bool `json:"allowForcePush,omitempty"`
+
+	// EnforceAdmins specifies whether the protection also applies to administrators.
+	// Default value at POST-time: true.
+	// +optional
+	EnforceAdmins *bool `json:"enforceAdmins,omitempty"`
+}
+
+// Default defaults the BranchProtection fields.
+func (bp *BranchProtectionInfo) Default() {
+	if bp.RequiredApprovals == nil {
+		bp.RequiredApprovals = IntVar(defaultBranchProtectionRequiredApprovals)
+	}
+	// An empty list means no required status checks, just like nil
+	if len(bp.RequiredStatusChecks) == 0 {
+		bp.RequiredStatusChecks = nil
+	} else {
+		// Sort a copy, so that the order the caller or server lists them in doesn't cause a diff
+		bp.RequiredStatusChecks = append([]string{}, bp.RequiredStatusChecks...)
+		sort.Strings(bp.RequiredStatusChecks)
+	}
+	if bp.AllowForcePush == nil {
+		bp.AllowForcePush = BoolVar(defaultBranchProtectionAllowForcePush)
+	}
+	if bp.EnforceAdmins == nil {
+		bp.EnforceAdmins = BoolVar(defaultBranchProtectionEnforceAdmins)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (bp BranchProtectionInfo) ValidateInfo() error {
+	validator := validation.New("BranchProtection")
+	// Make sure we've set the name of the branch
+	if len(bp.Branch) == 0 {
+		validator.Required("Branch")
+	}
+	// A negative amount of approvals doesn't make sense
+	if bp.RequiredApprovals != nil && *bp.RequiredApprovals < 0 {
+		validator.Invalid(*bp.RequiredApprovals, "RequiredApprovals")
+	}
+	// Status check contexts can't be empty
+	for _, check := range bp.RequiredStatusChecks {
+		if len(check) == 0 {
+			validator.Required("RequiredStatusChecks")
+			break
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (bp BranchProtectionInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(bp, actual)
+}
+
+// WebhookInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = WebhookInfo{}
+var _ DefaultedInfoRequest = &WebhookInfo{}
+
+// WebhookInfo contains high-level information about a repository webhook.
+type WebhookInfo struct {
+	// URL is the address the webhook delivers its payloads to. It identifies the webhook
+	// within the repository.
+	// +required
+	URL string `json:"url"`
+
+	// Secret is used to sign the payloads, so that the receiver can verify them.
+	// Git providers don't return the secret, hence it is only applied at POST and PATCH-time,
+	// and isn't taken into account when checking whether the webhook is up to date.
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// ContentType specifies the encoding of the payloads.
+	// Default value at POST-time: WebhookContentTypeJSON.
+	// +optional
+	ContentType *WebhookContentType `json:"contentType,omitempty"`
+
+	// Events lists the events the webhook is triggered by. Events the Git provider
+	// can't express are rejected with ErrNoProviderSupport.
+	// Default value at POST-time: [WebhookEventPush].
+	// +optional
+	Events []WebhookEvent `json:"events,omitempty"`
+
+	// InsecureSkipTLSVerify disables verification of the receiver's TLS certificate.
+	// Default value at POST-time: false.
+	// +optional
+	InsecureSkipTLSVerify *bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// Active specifies whether payloads are delivered when the events occur.
+	// Default value at POST-time: true.
+	// +optional
+	Active *bool `json:"active,omitempty"`
+}
+
+// Default defaults the Webhook fields.
+func (wh *WebhookInfo) Default() {
+	if wh.ContentType == nil {
+		wh.ContentType = WebhookContentTypeVar(defaultWebhookContentType)
+	}
+	if len(wh.Events) == 0 {
+		wh.Events = []WebhookEvent{WebhookEventPush}
+	}
+	if wh.InsecureSkipTLSVerify == nil {
+		wh.InsecureSkipTLSVerify = BoolVar(defaultWebhookInsecureSkipTLSVerify)
+	}
+	if wh.Active == nil {
+		wh.Active = BoolVar(defaultWebhookActive)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (wh WebhookInfo) ValidateInfo() error {
+	validator := validation.New("Webhook")
+	// Make sure we've set the URL of the webhook
+	if len(wh.URL) == 0 {
+		validator.Required("URL")
+	}
+	// Validate the content type, if set
+	if wh.ContentType != nil {
+		validator.Append(ValidateWebhookContentType(*wh.ContentType), *wh.ContentType, "ContentType")
+	}
+	// Validate the events
+	for _, event := range wh.Events {
+		validator.Append(ValidateWebhookEvent(event), event, "Events")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The secret and the order of the events are ignored.
+func (wh WebhookInfo) Equals(actual InfoRequest) bool {
+	other, ok := actual.(WebhookInfo)
+	if !ok {
+		return false
+	}
+	wh.Secret, other.Secret = nil, nil
+	wh.Events, other.Events = SortWebhookEvents(wh.Events), SortWebhookEvents(other.Events)
+	return reflect.DeepEqual(wh, other)
+}
+
 // CommitInfo contains high-level information about a deploy key.
 type CommitInfo struct {
 	// Sha is the git sha for this commit.
@@ -208,9 +436,128 @@ type CommitFile struct {
 	// +required
 	Path *string `json:"path"`
 
-	// Content is the content of the file.
-	// +required
+	// Content is the content of the file, encoded as specified by Encoding.
+	// It's required when adding or updating a file, optional when moving one, and must be
+	// nil when deleting one. If Action is unset, a nil Content deletes the file.
 	Content *string `json:"content"`
+
+	// SHA is the git blob SHA of the file, as returned by FileClient.Get. It can be passed as the
+	// expected SHA to FileClient.Put and FileClient.Delete, and is ignored when creating commits.
+	SHA *string `json:"sha,omitempty"`
+
+	// Action specifies what the commit does with the file. If unset, the file is deleted if
+	// Content is nil, and added or updated (depending on whether it exists) otherwise.
+	// Not all providers fail adding a file which exists, or updating one which doesn't.
+	// +optional
+	Action *CommitFileAction `json:"action,omitempty"`
+
+	// PreviousPath is the path the file is moved from. It's required for CommitFileActionMove,
+	// and must be nil for other actions.
+	// +optional
+	PreviousPath *string `json:"previous_path,omitempty"`
+
+	// Mode is the git file mode of the file. When moving a file without Content, the mode
+	// is kept if unset.
+	// Default: CommitFileModeRegular.
+	// +optional
+	Mode *CommitFileMode `json:"mode,omitempty"`
+
+	// Encoding specifies how Content is encoded. Use ContentEncodingBase64 to pass binary
+	// content through e.g. JSON.
+	// Default: ContentEncodingRaw.
+	// +optional
+	Encoding *ContentEncoding `json:"encoding,omitempty"`
+}
+
+// Validate validates the CommitFile before a commit is created.
+func (f CommitFile) Validate() error {
+	validator := validation.New("CommitFile")
+	if f.Path == nil || len(*f.Path) == 0 {
+		validator.Required("Path")
+	}
+	if f.Action != nil {
+		validator.Append(ValidateCommitFileAction(*f.Action), *f.Action, "Action")
+	}
+	if f.Mode != nil {
+		validator.Append(ValidateCommitFileMode(*f.Mode), *f.Mode, "Mode")
+	}
+	if f.Encoding != nil {
+		validator.Append(ValidateContentEncoding(*f.Encoding), *f.Encoding, "Encoding")
+	}
+	switch f.GetAction() {
+	case CommitFileActionAdd, CommitFileActionUpdate:
+		if f.Content == nil {
+			validator.Required("Content")
+		}
+	case CommitFileActionDelete:
+		if f.Content != nil {
+			validator.Invalid(*f.Content, "Content")
+		}
+	case CommitFileActionMove:
+		if f.PreviousPath == nil || len(*f.PreviousPath) == 0 {
+			validator.Required("PreviousPath")
+		}
+	}
+	if f.PreviousPath != nil && f.GetAction() != CommitFileActionMove {
+		validator.Invalid(*f.PreviousPath, "PreviousPath")
+	}
+	if _, err := f.DecodedContent(); err != nil {
+		validator.Append(err, *f.Content, "Content")
+	}
+	return validator.Error()
+}
+
+// GetAction returns Action if set, otherwise CommitFileActionDelete if Content is nil.
+// An empty CommitFileAction is returned for a file with Content and an unset Action,
+// which is added or updated depending on whether it exists.
+func (f CommitFile) GetAction() CommitFileAction {
+	switch {
+	case f.Action != nil:
+		return *f.Action
+	case f.Content == nil:
+		return CommitFileActionDelete
+	default:
+		return ""
+	}
+}
+
+// GetMode returns Mode if set, otherwise CommitFileModeRegular.
+func (f CommitFile) GetMode() CommitFileMode {
+	if f.Mode != nil {
+		return *f.Mode
+	}
+	return CommitFileModeRegular
+}
+
+// DecodedContent returns the content of the file, decoded as specified by Encoding.
+// nil is returned if Content is nil.
+func (f CommitFile) DecodedContent() ([]byte, error) {
+	if f.Content == nil {
+		return nil, nil
+	}
+	if f.Encoding != nil && *f.Encoding == ContentEncodingBase64 {
+		content, err := base64.StdEncoding.DecodeString(*f.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 content: %v: %w", err, validation.ErrFieldInvalid)
+		}
+		return content, nil
+	}
+	return []byte(*f.Content), nil
+}
+
+// BranchInfo contains high-level information about a branch.
+type BranchInfo struct {
+	// Name is the name of the branch, e.g. "main".
+	// +required
+	Name string `json:"name"`
+
+	// Sha is the git sha of the commit the branch points to.
+	// +required
+	Sha string `json:"sha"`
+
+	// Protected specifies whether the branch is protected from force pushes and deletion.
+	// Providers that don't expose branch protection on the branch always report false.
+	Protected bool `json:"protected"`
 }
 
 // PullRequestInfo contains high-level information about a pull request.
@@ -224,6 +571,94 @@ type PullRequestInfo struct {
 	// WebURL is the URL of the pull request in the git provider web interface.
 	// +required
 	WebURL string `json:"web_url"`
+
+	// Title is the title of the pull request.
+	Title string `json:"title"`
+
+	// Description is the description of the pull request, in markdown.
+	Description string `json:"description"`
+
+	// State is the state of the pull request.
+	State PullRequestState `json:"state"`
+
+	// Draft specifies whether the pull request is a draft, which can't be merged yet.
+	// Draft pull requests are open.
+	Draft bool `json:"draft"`
+
+	// SourceBranch is the branch which is requested to be merged.
+	SourceBranch string `json:"sourceBranch"`
+
+	// TargetBranch is the branch the pull request is merged into.
+	TargetBranch string `json:"targetBranch"`
+
+	// HeadSHA is the latest commit of the source branch.
+	HeadSHA string `json:"headSHA,omitempty"`
+
+	// BaseSHA is the commit of the target branch the pull request is compared against,
+	// if known.
+	BaseSHA string `json:"baseSHA,omitempty"`
+
+	// Author is the login of the user who opened the pull request.
+	Author string `json:"author,omitempty"`
+
+	// CreatedAt is the time the pull request was opened.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is the time the pull request was last changed.
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Mergeable specifies whether the pull request can be merged without conflicts. It is nil
+	// if unknown, e.g. while the provider is still computing it.
+	Mergeable *bool `json:"mergeable,omitempty"`
+}
+
+// PullRequestUpdateInfo contains the fields of a pull request which can be changed.
+// Unset fields are left unchanged.
+type PullRequestUpdateInfo struct {
+	// Title is the title of the pull request.
+	// +optional
+	Title *string `json:"title,omitempty"`
+
+	// Description is the description of the pull request, in markdown.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// TargetBranch is the branch the pull request is merged into.
+	// +optional
+	TargetBranch *string `json:"targetBranch,omitempty"`
+}
+
+// PullRequestCommentInfo contains high-level information about a general comment on a pull
+// request, i.e. a comment which isn't attached to a line of the diff.
+type PullRequestCommentInfo struct {
+	// ID identifies the comment within the pull request.
+	ID int64 `json:"id"`
+
+	// Body is the text of the comment, in markdown.
+	Body string `json:"body"`
+
+	// Author is the login of the user who wrote the comment.
+	Author string `json:"author"`
+
+	// CreatedAt is the time the comment was written.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is the time the comment was last edited, or CreatedAt if it never was.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PullRequestReviewInfo contains high-level information about the review of a pull request by
+// a single reviewer.
+type PullRequestReviewInfo struct {
+	// Reviewer is the login of the reviewer.
+	Reviewer string `json:"reviewer"`
+
+	// State is the current state of the review.
+	State PullRequestReviewState `json:"state"`
+
+	// SubmittedAt is the time the review was submitted. It is the zero time for pending
+	// reviews, and if the provider doesn't record it.
+	SubmittedAt time.Time `json:"submittedAt"`
 }
 
 // TreeEntry contains info about each tree object's structure in TreeInfo whether it is a file or tree
@@ -257,3 +692,141 @@ type TreeInfo struct {
 	// If truncated is true in the response when fetching a tree, then the number of items in the tree array exceeded the maximum limit
 	Truncated bool `json:"truncated"`
 }
+
+// TagInfo contains high-level information about a git tag.
+type TagInfo struct {
+	// Name is the name of the tag, e.g. "v1.0.0".
+	// +required
+	Name string `json:"name"`
+
+	// Sha is the git sha of the commit the tag points to.
+	// +required
+	Sha string `json:"sha"`
+
+	// Message is the message of an annotated tag. Tags without a message are lightweight tags.
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+// ValidateInfo validates the object at POST-time.
+func (t TagInfo) ValidateInfo() error {
+	validator := validation.New("Tag")
+	if len(t.Name) == 0 {
+		validator.Required("Name")
+	}
+	if len(t.Sha) == 0 {
+		validator.Required("Sha")
+	}
+	return validator.Error()
+}
+
+// ReleaseInfo contains high-level information about a release.
+type ReleaseInfo struct {
+	// TagName is the name of the tag the release is for. It identifies the release within
+	// the repository.
+	// +required
+	TagName string `json:"tagName"`
+
+	// Target is the commit SHA or branch the tag is created from if it doesn't exist yet.
+	// Default value at POST-time: the default branch of the repository.
+	// +optional
+	Target *string `json:"target,omitempty"`
+
+	// Name is the title of the release.
+	// Default value at POST-time: TagName.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Notes are the release notes, in markdown.
+	// +optional
+	Notes *string `json:"notes,omitempty"`
+
+	// Draft specifies whether the release is unpublished.
+	// Default value at POST-time: false.
+	// +optional
+	Draft *bool `json:"draft,omitempty"`
+
+	// Prerelease specifies whether the release is marked as not ready for production.
+	// Default value at POST-time: false.
+	// +optional
+	Prerelease *bool `json:"prerelease,omitempty"`
+
+	// Assets are the files attached to the release. They are set by the server, and
+	// uploaded through ReleaseClient.UploadAsset.
+	Assets []ReleaseAsset `json:"assets,omitempty"`
+}
+
+// ValidateInfo validates the object at POST-time.
+func (r ReleaseInfo) ValidateInfo() error {
+	validator := validation.New("Release")
+	if len(r.TagName) == 0 {
+		validator.Required("TagName")
+	}
+	return validator.Error()
+}
+
+// ReleaseAsset is a file attached to a release.
+type ReleaseAsset struct {
+	// Name is the file name of the asset.
+	Name string `json:"name"`
+
+	// URL is the address the asset can be downloaded from.
+	URL string `json:"url"`
+
+	// Size is the size of the asset in bytes, if known.
+	Size int `json:"size"`
+}
+
+// CommitStatusInfo contains high-level information about the status of a commit, as reported
+// by a CI or CD system.
+type CommitStatusInfo struct {
+	// State is the state of the check.
+	// +required
+	State CommitStatusState `json:"state"`
+
+	// Context identifies the check, e.g. "ci/build". Creating a status for a context
+	// which already has one for the commit replaces it.
+	// +required
+	Context string `json:"context"`
+
+	// Description is a short, human-readable summary of the status.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// TargetURL links to the details of the check, e.g. the build log.
+	// +optional
+	TargetURL *string `json:"targetURL,omitempty"`
+}
+
+// ValidateInfo validates the object at POST-time.
+func (s CommitStatusInfo) ValidateInfo() error {
+	validator := validation.New("CommitStatus")
+	if len(s.State) == 0 {
+		validator.Required("State")
+	} else {
+		validator.Append(ValidateCommitStatusState(s.State), s.State, "State")
+	}
+	if len(s.Context) == 0 {
+		validator.Required("Context")
+	}
+	return validator.Error()
+}
+
+// CombinedCommitStatusState returns the overall state of a commit with the given statuses:
+// failure if any status is failure or error, pending if any status is pending or if there are
+// no statuses, and success otherwise.
+func CombinedCommitStatusState(statuses []CommitStatusInfo) CommitStatusState {
+	if len(statuses) == 0 {
+		return CommitStatusStatePending
+	}
+	state := CommitStatusStateSuccess
+	for _, s := range statuses {
+		switch s.State {
+		case CommitStatusStateFailure, CommitStatusStateError:
+			return CommitStatusStateFailure
+		case CommitStatusStatePending:
+			state = CommitStatusStatePending
+		}
+	}
+	return state
+}
@@ -154,3 +154,279 @@ func TestTeamAccess_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestUserAccess_Validate(t *testing.T) {
+	invalidPermission := RepositoryPermission("unknown")
+	tests := []struct {
+		name         string
+		ua           UserAccessInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid create, required field set",
+			ua: UserAccessInfo{
+				Login: "octocat",
+			},
+		},
+		{
+			name:         "invalid create, required login",
+			ua:           UserAccessInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "valid create, with valid enum",
+			ua: UserAccessInfo{
+				Login:      "octocat",
+				Permission: RepositoryPermissionVar(RepositoryPermissionMaintain),
+			},
+		},
+		{
+			name: "invalid create, invalid enum",
+			ua: UserAccessInfo{
+				Login:      "octocat",
+				Permission: &invalidPermission,
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "UserAccess", tt.ua.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	invalidContentType := WebhookContentType("xml")
+	tests := []struct {
+		name         string
+		wh           WebhookInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid create, required field set",
+			wh: WebhookInfo{
+				URL: "https://example.com/hook",
+			},
+		},
+		{
+			name:         "invalid create, required url",
+			wh:           WebhookInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "valid create, with valid enums",
+			wh: WebhookInfo{
+				URL:         "https://example.com/hook",
+				ContentType: WebhookContentTypeVar(WebhookContentTypeForm),
+				Events:      []WebhookEvent{WebhookEventPush, WebhookEventPullRequest},
+			},
+		},
+		{
+			name: "invalid create, invalid content type",
+			wh: WebhookInfo{
+				URL:         "https://example.com/hook",
+				ContentType: &invalidContentType,
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name: "invalid create, invalid event",
+			wh: WebhookInfo{
+				URL:    "https://example.com/hook",
+				Events: []WebhookEvent{WebhookEventPush, "deployment"},
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "Webhook", tt.wh.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestWebhook_Equals(t *testing.T) {
+	desired := WebhookInfo{
+		URL:    "https://example.com/hook",
+		Secret: StringVar("secret"),
+		Events: []WebhookEvent{WebhookEventPush, WebhookEventPullRequest},
+	}
+	actual := WebhookInfo{
+		URL:    "https://example.com/hook",
+		Events: []WebhookEvent{WebhookEventPullRequest, WebhookEventPush},
+	}
+	if !desired.Equals(actual) {
+		t.Error("Equals() = false, want the secret and event order to be ignored")
+	}
+	actual.Events = []WebhookEvent{WebhookEventPush}
+	if desired.Equals(actual) {
+		t.Error("Equals() = true, want differing events to be detected")
+	}
+}
+
+func TestOrganization_Equals(t *testing.T) {
+	desired := OrganizationInfo{Description: StringVar("desc")}
+	actual := OrganizationInfo{Name: StringVar("Org"), Description: StringVar("desc")}
+	if !desired.Equals(actual) {
+		t.Error("Equals() = false, want the unset name to be ignored")
+	}
+	desired.Name = StringVar("Other")
+	if desired.Equals(actual) {
+		t.Error("Equals() = true, want differing names to be detected")
+	}
+}
+
+func TestTeam_Equals(t *testing.T) {
+	desired := TeamInfo{Name: "team", Members: []string{"alice", "bob"}}
+	actual := TeamInfo{Name: "team", Members: []string{"carol", "alice"}}
+	if desired.Equals(actual) {
+		t.Error("Equals() = true, want differing members to be detected")
+	}
+	added, removed := desired.MemberChanges(actual)
+	if len(added) != 1 || added[0] != "bob" || len(removed) != 1 || removed[0] != "carol" {
+		t.Errorf("MemberChanges() = %v, %v, want [bob], [carol]", added, removed)
+	}
+	actual.Members = []string{"bob", "alice"}
+	if !desired.Equals(actual) {
+		t.Error("Equals() = false, want the member order to be ignored")
+	}
+}
+
+func TestCommitFile_Validate(t *testing.T) {
+	invalidMode := CommitFileMode("040000")
+	tests := []struct {
+		name         string
+		file         CommitFile
+		expectedErrs []error
+	}{
+		{
+			name: "valid add or update",
+			file: CommitFile{Path: StringVar("a.txt"), Content: StringVar("a")},
+		},
+		{
+			name: "valid delete",
+			file: CommitFile{Path: StringVar("a.txt")},
+		},
+		{
+			name: "valid move with mode",
+			file: CommitFile{
+				Path:         StringVar("b.sh"),
+				Action:       CommitFileActionVar(CommitFileActionMove),
+				PreviousPath: StringVar("a.sh"),
+				Mode:         CommitFileModeVar(CommitFileModeExecutable),
+			},
+		},
+		{
+			name: "valid base64 content",
+			file: CommitFile{Path: StringVar("a.bin"), Content: StringVar("AP8="), Encoding: ContentEncodingVar(ContentEncodingBase64)},
+		},
+		{
+			name:         "invalid, required path",
+			file:         CommitFile{Content: StringVar("a")},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, add without content",
+			file:         CommitFile{Path: StringVar("a.txt"), Action: CommitFileActionVar(CommitFileActionAdd)},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, delete with content",
+			file:         CommitFile{Path: StringVar("a.txt"), Content: StringVar("a"), Action: CommitFileActionVar(CommitFileActionDelete)},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name:         "invalid, move without previous path",
+			file:         CommitFile{Path: StringVar("a.txt"), Action: CommitFileActionVar(CommitFileActionMove)},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, previous path without move",
+			file:         CommitFile{Path: StringVar("a.txt"), Content: StringVar("a"), PreviousPath: StringVar("b.txt")},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name:         "invalid mode",
+			file:         CommitFile{Path: StringVar("a.txt"), Content: StringVar("a"), Mode: &invalidMode},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name:         "invalid base64 content",
+			file:         CommitFile{Path: StringVar("a.bin"), Content: StringVar("not base64!"), Encoding: ContentEncodingVar(ContentEncodingBase64)},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "CommitFile", tt.file.Validate, tt.expectedErrs)
+		})
+	}
+}
+
+func TestCommitStatus_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       CommitStatusInfo
+		expectedErrs []error
+	}{
+		{
+			name:   "valid create, required fields set",
+			status: CommitStatusInfo{State: CommitStatusStateSuccess, Context: "ci/build"},
+		},
+		{
+			name:         "invalid create, required state and context",
+			status:       CommitStatusInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid create, invalid state",
+			status:       CommitStatusInfo{State: "cancelled", Context: "ci/build"},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "CommitStatus", tt.status.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestCombinedCommitStatusState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []CommitStatusState
+		want   CommitStatusState
+	}{
+		{
+			name: "no statuses",
+			want: CommitStatusStatePending,
+		},
+		{
+			name:   "all successful",
+			states: []CommitStatusState{CommitStatusStateSuccess, CommitStatusStateSuccess},
+			want:   CommitStatusStateSuccess,
+		},
+		{
+			name:   "pending",
+			states: []CommitStatusState{CommitStatusStateSuccess, CommitStatusStatePending},
+			want:   CommitStatusStatePending,
+		},
+		{
+			name:   "error wins over pending",
+			states: []CommitStatusState{CommitStatusStatePending, CommitStatusStateError},
+			want:   CommitStatusStateFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := make([]CommitStatusInfo, 0, len(tt.states))
+			for _, s := range tt.states {
+				statuses = append(statuses, CommitStatusInfo{State: s, Context: "ci"})
+			}
+			if got := CombinedCommitStatusState(statuses); got != tt.want {
+				t.Errorf("CombinedCommitStatusState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -26,15 +26,21 @@ func BoolVar(b bool) *bool {
 	return &b
 }
 
+// IntVar returns a pointer to the given int.
+func IntVar(i int) *int {
+	return &i
+}
+
 // StringVar returns a pointer to the given string.
 func StringVar(s string) *string {
 	return &s
 }
 
 // GetDomainURL returns the domain URL prepended with https:// if a scheme is not set.
+// Domains with the file scheme, e.g. "file:///srv/git", point to a local directory.
 func GetDomainURL(d string) string {
 	parsedURL, _ := url.Parse(d)
-	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" {
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" && parsedURL.Scheme != "file" {
 		d = fmt.Sprintf("https://%s", d)
 	}
 	return d
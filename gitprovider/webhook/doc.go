@@ -0,0 +1,24 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook parses webhook deliveries sent by Git providers into provider-neutral events.
+//
+// Parse verifies the signature of a delivery against the secret the webhook was registered with
+// (see gitprovider.WebhookInfo), and decodes its payload. GitHub, GitLab and Bitbucket Server
+// (the stash provider) deliveries are supported. The raw payload is kept on every event, so
+// provider-specific fields remain available to the caller. ParseUnverified decodes deliveries of
+// webhooks registered without a secret, which anyone able to reach the receiver can forge.
+package webhook
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+)
+
+// githubRepository is the repository object of GitHub payloads.
+type githubRepository struct {
+	HTMLURL string `json:"html_url"`
+}
+
+// githubPushPayload is the payload of a GitHub "push" delivery.
+type githubPushPayload struct {
+	Ref        string           `json:"ref"`
+	Before     string           `json:"before"`
+	After      string           `json:"after"`
+	Deleted    bool             `json:"deleted"`
+	Repository githubRepository `json:"repository"`
+	Commits    []struct {
+		ID        string    `json:"id"`
+		TreeID    string    `json:"tree_id"`
+		Message   string    `json:"message"`
+		Timestamp time.Time `json:"timestamp"`
+		URL       string    `json:"url"`
+		Author    struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+}
+
+// githubPullRequestPayload is the payload of a GitHub "pull_request" delivery.
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		Title   string `json:"title"`
+		Merged  bool   `json:"merged"`
+		Head    struct {
+			Ref string `json:"ref"`
+			Sha string `json:"sha"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository githubRepository `json:"repository"`
+}
+
+// decodeGitHub decodes a GitHub delivery. GitHub signs deliveries with HMAC-SHA256 in the
+// X-Hub-Signature-256 header.
+func decodeGitHub(header http.Header, payload []byte) ([]Event, error) {
+	meta := eventMeta{provider: providerGitHub, payload: payload}
+	switch event := header.Get(githubEventHeader); event {
+	case "push":
+		p := githubPushPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, decodeError(providerGitHub, err)
+		}
+		return githubPushEvents(meta, &p)
+	case "pull_request":
+		p := githubPullRequestPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, decodeError(providerGitHub, err)
+		}
+		return githubPullRequestEvents(meta, &p)
+	default:
+		return nil, unsupportedEvent("GitHub event %q", event)
+	}
+}
+
+func githubPushEvents(meta eventMeta, p *githubPushPayload) ([]Event, error) {
+	if p.Deleted {
+		return nil, unsupportedEvent("GitHub deletion of %q", p.Ref)
+	}
+
+	switch {
+	case strings.HasPrefix(p.Ref, branchRefPrefix):
+		meta.eventType = EventTypePush
+		commits := make([]gitprovider.CommitInfo, 0, len(p.Commits))
+		for _, c := range p.Commits {
+			commits = append(commits, gitprovider.CommitInfo{
+				Sha:       c.ID,
+				TreeSha:   c.TreeID,
+				Author:    c.Author.Name,
+				Message:   c.Message,
+				CreatedAt: c.Timestamp,
+				URL:       c.URL,
+			})
+		}
+		return []Event{&PushEvent{
+			eventMeta:     meta,
+			RepositoryURL: p.Repository.HTMLURL,
+			Branch:        strings.TrimPrefix(p.Ref, branchRefPrefix),
+			Before:        beforeSha(p.Before),
+			After:         p.After,
+			Commits:       commits,
+		}}, nil
+	case strings.HasPrefix(p.Ref, tagRefPrefix) && beforeSha(p.Before) == "":
+		meta.eventType = EventTypeTagCreated
+		return []Event{&TagCreatedEvent{
+			eventMeta:     meta,
+			RepositoryURL: p.Repository.HTMLURL,
+			Tag:           strings.TrimPrefix(p.Ref, tagRefPrefix),
+			Sha:           p.After,
+		}}, nil
+	default:
+		return nil, unsupportedEvent("GitHub push to %q", p.Ref)
+	}
+}
+
+func githubPullRequestEvents(meta eventMeta, p *githubPullRequestPayload) ([]Event, error) {
+	switch {
+	case p.Action == "opened" || p.Action == "reopened":
+		meta.eventType = EventTypePullRequestOpened
+	case p.Action == "closed" && p.PullRequest.Merged:
+		meta.eventType = EventTypePullRequestMerged
+	case p.Action == "closed":
+		meta.eventType = EventTypePullRequestClosed
+	default:
+		return nil, unsupportedEvent("GitHub pull request action %q", p.Action)
+	}
+
+	return []Event{&PullRequestEvent{
+		eventMeta:     meta,
+		RepositoryURL: p.Repository.HTMLURL,
+		PullRequestInfo: gitprovider.PullRequestInfo{
+			Merged:       p.PullRequest.Merged,
+			Number:       p.PullRequest.Number,
+			WebURL:       p.PullRequest.HTMLURL,
+			Title:        p.PullRequest.Title,
+			State:        pullRequestState(meta.eventType),
+			SourceBranch: p.PullRequest.Head.Ref,
+			TargetBranch: p.PullRequest.Base.Ref,
+			HeadSHA:      p.PullRequest.Head.Sha,
+		},
+	}}, nil
+}
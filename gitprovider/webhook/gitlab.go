@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	gitlabEventHeader = "X-Gitlab-Event"
+	gitlabTokenHeader = "X-Gitlab-Token"
+)
+
+// gitlabProject is the project object of GitLab payloads.
+type gitlabProject struct {
+	WebURL string `json:"web_url"`
+}
+
+// gitlabPushPayload is the payload of a GitLab "Push Hook" or "Tag Push Hook" delivery.
+type gitlabPushPayload struct {
+	Ref     string        `json:"ref"`
+	Before  string        `json:"before"`
+	After   string        `json:"after"`
+	Project gitlabProject `json:"project"`
+	Commits []struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		Timestamp time.Time `json:"timestamp"`
+		URL       string    `json:"url"`
+		Author    struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+}
+
+// gitlabMergeRequestPayload is the payload of a GitLab "Merge Request Hook" delivery.
+type gitlabMergeRequestPayload struct {
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		URL          string `json:"url"`
+		Title        string `json:"title"`
+		Action       string `json:"action"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+}
+
+// verifyGitLabToken verifies a GitLab delivery. GitLab doesn't sign deliveries, but sends
+// the secret as is in the X-Gitlab-Token header.
+func verifyGitLabToken(header http.Header, secret []byte) error {
+	token := header.Get(gitlabTokenHeader)
+	if token == "" {
+		return fmt.Errorf("header %s not set: %w", gitlabTokenHeader, ErrInvalidSignature)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+		return fmt.Errorf("header %s doesn't match the secret: %w", gitlabTokenHeader, ErrInvalidSignature)
+	}
+	return nil
+}
+
+// decodeGitLab decodes a GitLab delivery.
+func decodeGitLab(header http.Header, payload []byte) ([]Event, error) {
+	meta := eventMeta{provider: providerGitLab, payload: payload}
+	switch event := header.Get(gitlabEventHeader); event {
+	case "Push Hook", "Tag Push Hook":
+		p := gitlabPushPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, decodeError(providerGitLab, err)
+		}
+		return gitlabPushEvents(meta, &p)
+	case "Merge Request Hook":
+		p := gitlabMergeRequestPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, decodeError(providerGitLab, err)
+		}
+		return gitlabMergeRequestEvents(meta, &p)
+	default:
+		return nil, unsupportedEvent("GitLab event %q", event)
+	}
+}
+
+func gitlabPushEvents(meta eventMeta, p *gitlabPushPayload) ([]Event, error) {
+	if p.After == zeroSha {
+		return nil, unsupportedEvent("GitLab deletion of %q", p.Ref)
+	}
+
+	switch {
+	case strings.HasPrefix(p.Ref, branchRefPrefix):
+		meta.eventType = EventTypePush
+		commits := make([]gitprovider.CommitInfo, 0, len(p.Commits))
+		for _, c := range p.Commits {
+			commits = append(commits, gitprovider.CommitInfo{
+				Sha:       c.ID,
+				Author:    c.Author.Name,
+				Message:   c.Message,
+				CreatedAt: c.Timestamp,
+				URL:       c.URL,
+			})
+		}
+		return []Event{&PushEvent{
+			eventMeta:     meta,
+			RepositoryURL: p.Project.WebURL,
+			Branch:        strings.TrimPrefix(p.Ref, branchRefPrefix),
+			Before:        beforeSha(p.Before),
+			After:         p.After,
+			Commits:       commits,
+		}}, nil
+	case strings.HasPrefix(p.Ref, tagRefPrefix) && beforeSha(p.Before) == "":
+		meta.eventType = EventTypeTagCreated
+		return []Event{&TagCreatedEvent{
+			eventMeta:     meta,
+			RepositoryURL: p.Project.WebURL,
+			Tag:           strings.TrimPrefix(p.Ref, tagRefPrefix),
+			Sha:           p.After,
+		}}, nil
+	default:
+		return nil, unsupportedEvent("GitLab push to %q", p.Ref)
+	}
+}
+
+func gitlabMergeRequestEvents(meta eventMeta, p *gitlabMergeRequestPayload) ([]Event, error) {
+	switch action := p.ObjectAttributes.Action; action {
+	case "open", "reopen":
+		meta.eventType = EventTypePullRequestOpened
+	case "merge":
+		meta.eventType = EventTypePullRequestMerged
+	case "close":
+		meta.eventType = EventTypePullRequestClosed
+	default:
+		return nil, unsupportedEvent("GitLab merge request action %q", action)
+	}
+
+	return []Event{&PullRequestEvent{
+		eventMeta:     meta,
+		RepositoryURL: p.Project.WebURL,
+		PullRequestInfo: gitprovider.PullRequestInfo{
+			Merged:       meta.eventType == EventTypePullRequestMerged,
+			Number:       p.ObjectAttributes.IID,
+			WebURL:       p.ObjectAttributes.URL,
+			Title:        p.ObjectAttributes.Title,
+			State:        pullRequestState(meta.eventType),
+			SourceBranch: p.ObjectAttributes.SourceBranch,
+			TargetBranch: p.ObjectAttributes.TargetBranch,
+			HeadSHA:      p.ObjectAttributes.LastCommit.ID,
+		},
+	}}, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	stashEventHeader     = "X-Event-Key"
+	stashSignatureHeader = "X-Hub-Signature"
+)
+
+// stashLinks is the links object of Bitbucket Server payloads.
+type stashLinks struct {
+	Self []struct {
+		Href string `json:"href"`
+	} `json:"self"`
+}
+
+// selfLink returns the first self link, if any.
+func (l stashLinks) selfLink() string {
+	if len(l.Self) == 0 {
+		return ""
+	}
+	return l.Self[0].Href
+}
+
+// stashRepository is the repository object of Bitbucket Server payloads.
+type stashRepository struct {
+	Links stashLinks `json:"links"`
+}
+
+// webURL returns the URL of the repository, without the trailing "/browse" of its self link.
+func (r stashRepository) webURL() string {
+	return strings.TrimSuffix(r.Links.selfLink(), "/browse")
+}
+
+// stashRefsChangedPayload is the payload of a Bitbucket Server "repo:refs_changed" delivery.
+type stashRefsChangedPayload struct {
+	Repository stashRepository `json:"repository"`
+	Changes    []struct {
+		Ref struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"ref"`
+		FromHash string `json:"fromHash"`
+		ToHash   string `json:"toHash"`
+		Type     string `json:"type"`
+	} `json:"changes"`
+}
+
+// stashPullRequestPayload is the payload of a Bitbucket Server "pr:*" delivery.
+type stashPullRequestPayload struct {
+	PullRequest struct {
+		ID      int    `json:"id"`
+		Title   string `json:"title"`
+		FromRef struct {
+			DisplayID    string `json:"displayId"`
+			LatestCommit string `json:"latestCommit"`
+		} `json:"fromRef"`
+		ToRef struct {
+			DisplayID  string          `json:"displayId"`
+			Repository stashRepository `json:"repository"`
+		} `json:"toRef"`
+		Links stashLinks `json:"links"`
+	} `json:"pullRequest"`
+}
+
+// decodeStash decodes a Bitbucket Server delivery. Bitbucket Server signs deliveries with
+// HMAC-SHA256 in the X-Hub-Signature header.
+func decodeStash(header http.Header, payload []byte) ([]Event, error) {
+	meta := eventMeta{provider: providerStash, payload: payload}
+	switch event := header.Get(stashEventHeader); event {
+	case "repo:refs_changed":
+		p := stashRefsChangedPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, decodeError(providerStash, err)
+		}
+		return stashRefsChangedEvents(meta, &p)
+	case "pr:opened":
+		meta.eventType = EventTypePullRequestOpened
+	case "pr:merged":
+		meta.eventType = EventTypePullRequestMerged
+	case "pr:declined", "pr:deleted":
+		meta.eventType = EventTypePullRequestClosed
+	default:
+		return nil, unsupportedEvent("Bitbucket Server event %q", event)
+	}
+
+	p := stashPullRequestPayload{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, decodeError(providerStash, err)
+	}
+	return []Event{&PullRequestEvent{
+		eventMeta:     meta,
+		RepositoryURL: p.PullRequest.ToRef.Repository.webURL(),
+		PullRequestInfo: gitprovider.PullRequestInfo{
+			Merged:       meta.eventType == EventTypePullRequestMerged,
+			Number:       p.PullRequest.ID,
+			WebURL:       p.PullRequest.Links.selfLink(),
+			Title:        p.PullRequest.Title,
+			State:        pullRequestState(meta.eventType),
+			SourceBranch: p.PullRequest.FromRef.DisplayID,
+			TargetBranch: p.PullRequest.ToRef.DisplayID,
+			HeadSHA:      p.PullRequest.FromRef.LatestCommit,
+		},
+	}}, nil
+}
+
+// stashRefsChangedEvents returns an event per pushed branch and created tag. Deleted refs,
+// and updated tags, are skipped.
+func stashRefsChangedEvents(meta eventMeta, p *stashRefsChangedPayload) ([]Event, error) {
+	repoURL := p.Repository.webURL()
+	events := make([]Event, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		switch {
+		case change.Type == "DELETE":
+			continue
+		case change.Ref.Type == "BRANCH":
+			m := meta
+			m.eventType = EventTypePush
+			events = append(events, &PushEvent{
+				eventMeta:     m,
+				RepositoryURL: repoURL,
+				Branch:        strings.TrimPrefix(change.Ref.ID, branchRefPrefix),
+				Before:        beforeSha(change.FromHash),
+				After:         change.ToHash,
+			})
+		case change.Ref.Type == "TAG" && change.Type == "ADD":
+			m := meta
+			m.eventType = EventTypeTagCreated
+			events = append(events, &TagCreatedEvent{
+				eventMeta:     m,
+				RepositoryURL: repoURL,
+				Tag:           strings.TrimPrefix(change.Ref.ID, tagRefPrefix),
+				Sha:           change.ToHash,
+			})
+		}
+	}
+	if len(events) == 0 {
+		return nil, unsupportedEvent("Bitbucket Server refs change without pushed branches or created tags")
+	}
+	return events, nil
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// providerGitHub, providerGitLab and providerStash are the IDs of the supported providers.
+	// They match the ProviderID constants of the respective provider packages.
+	providerGitHub = gitprovider.ProviderID("github")
+	providerGitLab = gitprovider.ProviderID("gitlab")
+	providerStash  = gitprovider.ProviderID("stash")
+
+	// maxPayloadSize is the maximum size of a payload read by Parse.
+	// GitHub caps payloads at 25 MB, other providers send smaller ones.
+	maxPayloadSize = 25 << 20
+
+	// zeroSha is the sha reported for a ref which didn't exist before, or doesn't exist
+	// anymore after a push.
+	zeroSha = "0000000000000000000000000000000000000000"
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature of a delivery is missing, or
+	// doesn't match the secret.
+	ErrInvalidSignature = errors.New("webhook signature is missing or invalid")
+	// ErrUnsupportedEvent is returned when a delivery has no provider-neutral representation,
+	// e.g. a ping, a branch deletion or a pull request being labeled. Receivers will usually
+	// want to acknowledge such deliveries, and ignore them.
+	ErrUnsupportedEvent = errors.New("unsupported webhook event")
+)
+
+// EventType is an enum specifying the type of a provider-neutral Event.
+type EventType string
+
+const (
+	// EventTypePush is the type of a PushEvent.
+	EventTypePush = EventType("push")
+	// EventTypeTagCreated is the type of a TagCreatedEvent.
+	EventTypeTagCreated = EventType("tag_created")
+	// EventTypePullRequestOpened is the type of a PullRequestEvent for an opened, or reopened,
+	// pull request.
+	EventTypePullRequestOpened = EventType("pull_request_opened")
+	// EventTypePullRequestMerged is the type of a PullRequestEvent for a merged pull request.
+	EventTypePullRequestMerged = EventType("pull_request_merged")
+	// EventTypePullRequestClosed is the type of a PullRequestEvent for a pull request which
+	// was closed (or declined) without being merged.
+	EventTypePullRequestClosed = EventType("pull_request_closed")
+)
+
+// Event is a provider-neutral webhook event. Use a type switch to access the
+// fields of *PushEvent, *TagCreatedEvent and *PullRequestEvent.
+type Event interface {
+	// Type returns the type of the event.
+	Type() EventType
+	// Provider returns the ID of the provider which sent the event.
+	Provider() gitprovider.ProviderID
+	// Payload returns the raw payload of the delivery the event was decoded from.
+	Payload() []byte
+}
+
+// eventMeta implements the Event interface, and is embedded in all events.
+type eventMeta struct {
+	eventType EventType
+	provider  gitprovider.ProviderID
+	payload   []byte
+}
+
+func (e eventMeta) Type() EventType                  { return e.eventType }
+func (e eventMeta) Provider() gitprovider.ProviderID { return e.provider }
+func (e eventMeta) Payload() []byte                  { return e.payload }
+
+// PushEvent is sent when commits are pushed to a branch.
+type PushEvent struct {
+	eventMeta
+
+	// RepositoryURL is the URL of the repository in the git provider web interface.
+	RepositoryURL string
+	// Branch is the name of the branch which was pushed to.
+	Branch string
+	// Before is the sha the branch pointed to before the push. It is empty if the branch
+	// was created by the push.
+	Before string
+	// After is the sha the branch points to after the push.
+	After string
+	// Commits lists the pushed commits, if the provider reports them.
+	// Bitbucket Server doesn't, hence only After is set.
+	Commits []gitprovider.CommitInfo
+}
+
+// TagCreatedEvent is sent when a tag is pushed.
+type TagCreatedEvent struct {
+	eventMeta
+
+	// RepositoryURL is the URL of the repository in the git provider web interface.
+	RepositoryURL string
+	// Tag is the name of the tag.
+	Tag string
+	// Sha is the sha the tag points to.
+	Sha string
+}
+
+// PullRequestEvent is sent when a pull request is opened, merged or closed. Use Type to
+// tell these apart.
+type PullRequestEvent struct {
+	eventMeta
+
+	// RepositoryURL is the URL of the target repository in the git provider web interface.
+	RepositoryURL string
+	// PullRequestInfo holds the number, web URL, title, state, branches and head commit of the
+	// pull request. Fields not included in the delivery are left unset.
+	gitprovider.PullRequestInfo
+}
+
+// pullRequestState returns the state of a pull request after an event of the given type.
+func pullRequestState(eventType EventType) gitprovider.PullRequestState {
+	switch eventType {
+	case EventTypePullRequestMerged:
+		return gitprovider.PullRequestStateMerged
+	case EventTypePullRequestClosed:
+		return gitprovider.PullRequestStateClosed
+	default:
+		return gitprovider.PullRequestStateOpen
+	}
+}
+
+// Parse reads the delivery in r, verifies its signature against secret, and decodes it
+// into provider-neutral events. provider is the ID of the provider which sent the
+// delivery, e.g. github.ProviderID. secret must not be empty, see ParseUnverified for
+// deliveries which can't be verified.
+//
+// A single delivery may decode into multiple events, as Bitbucket Server reports all
+// refs updated by a push in one delivery. ErrUnsupportedEvent is returned for deliveries
+// without any provider-neutral event, and ErrInvalidSignature for unsigned or forged ones.
+func Parse(provider gitprovider.ProviderID, r *http.Request, secret []byte) ([]Event, error) {
+	payload, err := readPayload(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePayload(provider, r.Header, payload, secret)
+}
+
+// ParsePayload is like Parse, for a delivery whose headers and payload have already been read.
+func ParsePayload(provider gitprovider.ProviderID, header http.Header, payload, secret []byte) ([]Event, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("cannot verify webhook delivery without a secret: %w", gitprovider.ErrInvalidArgument)
+	}
+
+	var err error
+	switch provider {
+	case providerGitHub:
+		err = verifyHMACSignature(header, githubSignatureHeader, payload, secret)
+	case providerGitLab:
+		err = verifyGitLabToken(header, secret)
+	case providerStash:
+		err = verifyHMACSignature(header, stashSignatureHeader, payload, secret)
+	default:
+		return nil, unsupportedProvider(provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParsePayloadUnverified(provider, header, payload)
+}
+
+// ParseUnverified is like Parse, but doesn't verify the signature of the delivery. Anyone
+// able to reach the receiver can forge deliveries, hence only use it for webhooks registered
+// without a secret, behind other means of authentication.
+func ParseUnverified(provider gitprovider.ProviderID, r *http.Request) ([]Event, error) {
+	payload, err := readPayload(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePayloadUnverified(provider, r.Header, payload)
+}
+
+// ParsePayloadUnverified is like ParseUnverified, for a delivery whose headers and payload have
+// already been read.
+func ParsePayloadUnverified(provider gitprovider.ProviderID, header http.Header, payload []byte) ([]Event, error) {
+	switch provider {
+	case providerGitHub:
+		return decodeGitHub(header, payload)
+	case providerGitLab:
+		return decodeGitLab(header, payload)
+	case providerStash:
+		return decodeStash(header, payload)
+	default:
+		return nil, unsupportedProvider(provider)
+	}
+}
+
+// readPayload reads the payload of the delivery in r.
+func readPayload(r *http.Request) ([]byte, error) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook payload: %w", err)
+	}
+	return payload, nil
+}
+
+// verifyHMACSignature verifies a "sha256=<hex>" HMAC signature of payload, as sent by
+// GitHub and Bitbucket Server in the given header.
+func verifyHMACSignature(header http.Header, key string, payload, secret []byte) error {
+	signature := header.Get(key)
+	if signature == "" {
+		return fmt.Errorf("header %s not set: %w", key, ErrInvalidSignature)
+	}
+	digest, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return fmt.Errorf("header %s isn't a sha256 signature: %w", key, ErrInvalidSignature)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return fmt.Errorf("header %s doesn't match the payload: %w", key, ErrInvalidSignature)
+	}
+	return nil
+}
+
+// unsupportedProvider returns ErrNoProviderSupport for webhooks of the given provider.
+func unsupportedProvider(provider gitprovider.ProviderID) error {
+	return fmt.Errorf("webhooks of provider %q: %w", provider, gitprovider.ErrNoProviderSupport)
+}
+
+// unsupportedEvent returns ErrUnsupportedEvent, wrapped with the given description.
+func unsupportedEvent(format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), ErrUnsupportedEvent)
+}
+
+// decodeError wraps a JSON decoding error of the payload of the given provider.
+func decodeError(provider gitprovider.ProviderID, err error) error {
+	return fmt.Errorf("failed to decode %s webhook payload: %v: %w", provider, err, gitprovider.ErrInvalidArgument)
+}
+
+// beforeSha returns sha, or an empty string if it's the zero sha.
+func beforeSha(sha string) string {
+	if sha == zeroSha {
+		return ""
+	}
+	return sha
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	secret = "s3cr3t"
+	sha1   = "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"
+	sha2   = "e4d1ee3c1a1a4e7e1b7b5e14f3b8c1c7e8a39d11"
+)
+
+var cmpEvents = cmp.AllowUnexported(eventMeta{}, PushEvent{}, TagCreatedEvent{}, PullRequestEvent{})
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParse(t *testing.T) {
+	githubPush := `{
+		"ref": "refs/heads/main", "before": "` + zeroSha + `", "after": "` + sha2 + `",
+		"repository": {"html_url": "https://github.com/org/repo"},
+		"commits": [{
+			"id": "` + sha2 + `", "tree_id": "` + sha1 + `", "message": "Add README",
+			"timestamp": "2022-08-01T10:00:00Z", "url": "https://github.com/org/repo/commit/` + sha2 + `",
+			"author": {"name": "Jane Doe"}
+		}]
+	}`
+	githubTag := `{"ref": "refs/tags/v1.0.0", "before": "` + zeroSha + `", "after": "` + sha1 + `",
+		"repository": {"html_url": "https://github.com/org/repo"}}`
+	githubBranchDeleted := `{"ref": "refs/heads/feature", "before": "` + sha1 + `", "after": "` + zeroSha + `", "deleted": true}`
+	githubMerged := `{
+		"action": "closed",
+		"pull_request": {
+			"number": 42, "html_url": "https://github.com/org/repo/pull/42", "title": "Add feature", "merged": true,
+			"head": {"ref": "feature", "sha": "` + sha2 + `"}, "base": {"ref": "main"}
+		},
+		"repository": {"html_url": "https://github.com/org/repo"}
+	}`
+	githubLabeled := `{"action": "labeled"}`
+	gitlabPush := `{
+		"object_kind": "push", "ref": "refs/heads/main", "before": "` + sha1 + `", "after": "` + sha2 + `",
+		"project": {"web_url": "https://gitlab.com/group/repo"},
+		"commits": [{
+			"id": "` + sha2 + `", "message": "Add README", "timestamp": "2022-08-01T10:00:00Z",
+			"url": "https://gitlab.com/group/repo/-/commit/` + sha2 + `", "author": {"name": "Jane Doe"}
+		}]
+	}`
+	gitlabOpened := `{
+		"object_kind": "merge_request", "project": {"web_url": "https://gitlab.com/group/repo"},
+		"object_attributes": {
+			"iid": 7, "url": "https://gitlab.com/group/repo/-/merge_requests/7", "title": "Add feature",
+			"action": "open", "source_branch": "feature", "target_branch": "main", "last_commit": {"id": "` + sha2 + `"}
+		}
+	}`
+	stashRefsChanged := `{
+		"eventKey": "repo:refs_changed",
+		"repository": {"slug": "repo", "links": {"self": [{"href": "https://stash.example.com/projects/PRJ/repos/repo/browse"}]}},
+		"changes": [
+			{"ref": {"id": "refs/heads/main", "displayId": "main", "type": "BRANCH"}, "fromHash": "` + sha1 + `", "toHash": "` + sha2 + `", "type": "UPDATE"},
+			{"ref": {"id": "refs/heads/old", "displayId": "old", "type": "BRANCH"}, "fromHash": "` + sha1 + `", "toHash": "` + zeroSha + `", "type": "DELETE"},
+			{"ref": {"id": "refs/tags/v1.0.0", "displayId": "v1.0.0", "type": "TAG"}, "fromHash": "` + zeroSha + `", "toHash": "` + sha2 + `", "type": "ADD"}
+		]
+	}`
+	stashDeclined := `{
+		"eventKey": "pr:declined",
+		"pullRequest": {
+			"id": 3, "title": "Add feature",
+			"fromRef": {"displayId": "feature", "latestCommit": "` + sha2 + `"},
+			"toRef": {"displayId": "main", "repository": {"links": {"self": [{"href": "https://stash.example.com/projects/PRJ/repos/repo/browse"}]}}},
+			"links": {"self": [{"href": "https://stash.example.com/projects/PRJ/repos/repo/pull-requests/3"}]}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		provider gitprovider.ProviderID
+		header   map[string]string
+		payload  string
+		want     func(payload []byte) []Event
+		wantErr  error
+	}{
+		{
+			name:     "github push",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "push", githubSignatureHeader: sign(githubPush)},
+			payload:  githubPush,
+			want: func(payload []byte) []Event {
+				return []Event{&PushEvent{
+					eventMeta:     eventMeta{eventType: EventTypePush, provider: providerGitHub, payload: payload},
+					RepositoryURL: "https://github.com/org/repo",
+					Branch:        "main",
+					After:         sha2,
+					Commits: []gitprovider.CommitInfo{{
+						Sha:       sha2,
+						TreeSha:   sha1,
+						Author:    "Jane Doe",
+						Message:   "Add README",
+						CreatedAt: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+						URL:       "https://github.com/org/repo/commit/" + sha2,
+					}},
+				}}
+			},
+		},
+		{
+			name:     "github tag created",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "push", githubSignatureHeader: sign(githubTag)},
+			payload:  githubTag,
+			want: func(payload []byte) []Event {
+				return []Event{&TagCreatedEvent{
+					eventMeta:     eventMeta{eventType: EventTypeTagCreated, provider: providerGitHub, payload: payload},
+					RepositoryURL: "https://github.com/org/repo",
+					Tag:           "v1.0.0",
+					Sha:           sha1,
+				}}
+			},
+		},
+		{
+			name:     "github branch deleted",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "push", githubSignatureHeader: sign(githubBranchDeleted)},
+			payload:  githubBranchDeleted,
+			wantErr:  ErrUnsupportedEvent,
+		},
+		{
+			name:     "github pull request merged",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "pull_request", githubSignatureHeader: sign(githubMerged)},
+			payload:  githubMerged,
+			want: func(payload []byte) []Event {
+				return []Event{&PullRequestEvent{
+					eventMeta:     eventMeta{eventType: EventTypePullRequestMerged, provider: providerGitHub, payload: payload},
+					RepositoryURL: "https://github.com/org/repo",
+					PullRequestInfo: gitprovider.PullRequestInfo{
+						Merged:       true,
+						Number:       42,
+						WebURL:       "https://github.com/org/repo/pull/42",
+						Title:        "Add feature",
+						State:        gitprovider.PullRequestStateMerged,
+						SourceBranch: "feature",
+						TargetBranch: "main",
+						HeadSHA:      sha2,
+					},
+				}}
+			},
+		},
+		{
+			name:     "github pull request labeled",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "pull_request", githubSignatureHeader: sign(githubLabeled)},
+			payload:  githubLabeled,
+			wantErr:  ErrUnsupportedEvent,
+		},
+		{
+			name:     "github ping",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "ping", githubSignatureHeader: sign(`{}`)},
+			payload:  `{}`,
+			wantErr:  ErrUnsupportedEvent,
+		},
+		{
+			name:     "github missing signature",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "push"},
+			payload:  githubPush,
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "github forged payload",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "push", githubSignatureHeader: sign(githubTag)},
+			payload:  githubPush,
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "github invalid payload",
+			provider: providerGitHub,
+			header:   map[string]string{githubEventHeader: "push", githubSignatureHeader: sign(`{`)},
+			payload:  `{`,
+			wantErr:  gitprovider.ErrInvalidArgument,
+		},
+		{
+			name:     "gitlab push",
+			provider: providerGitLab,
+			header:   map[string]string{gitlabEventHeader: "Push Hook", gitlabTokenHeader: secret},
+			payload:  gitlabPush,
+			want: func(payload []byte) []Event {
+				return []Event{&PushEvent{
+					eventMeta:     eventMeta{eventType: EventTypePush, provider: providerGitLab, payload: payload},
+					RepositoryURL: "https://gitlab.com/group/repo",
+					Branch:        "main",
+					Before:        sha1,
+					After:         sha2,
+					Commits: []gitprovider.CommitInfo{{
+						Sha:       sha2,
+						Author:    "Jane Doe",
+						Message:   "Add README",
+						CreatedAt: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+						URL:       "https://gitlab.com/group/repo/-/commit/" + sha2,
+					}},
+				}}
+			},
+		},
+		{
+			name:     "gitlab merge request opened",
+			provider: providerGitLab,
+			header:   map[string]string{gitlabEventHeader: "Merge Request Hook", gitlabTokenHeader: secret},
+			payload:  gitlabOpened,
+			want: func(payload []byte) []Event {
+				return []Event{&PullRequestEvent{
+					eventMeta:     eventMeta{eventType: EventTypePullRequestOpened, provider: providerGitLab, payload: payload},
+					RepositoryURL: "https://gitlab.com/group/repo",
+					PullRequestInfo: gitprovider.PullRequestInfo{
+						Number:       7,
+						WebURL:       "https://gitlab.com/group/repo/-/merge_requests/7",
+						Title:        "Add feature",
+						State:        gitprovider.PullRequestStateOpen,
+						SourceBranch: "feature",
+						TargetBranch: "main",
+						HeadSHA:      sha2,
+					},
+				}}
+			},
+		},
+		{
+			name:     "gitlab wrong token",
+			provider: providerGitLab,
+			header:   map[string]string{gitlabEventHeader: "Push Hook", gitlabTokenHeader: "wrong"},
+			payload:  gitlabPush,
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "stash refs changed",
+			provider: providerStash,
+			header:   map[string]string{stashEventHeader: "repo:refs_changed", stashSignatureHeader: sign(stashRefsChanged)},
+			payload:  stashRefsChanged,
+			want: func(payload []byte) []Event {
+				return []Event{
+					&PushEvent{
+						eventMeta:     eventMeta{eventType: EventTypePush, provider: providerStash, payload: payload},
+						RepositoryURL: "https://stash.example.com/projects/PRJ/repos/repo",
+						Branch:        "main",
+						Before:        sha1,
+						After:         sha2,
+					},
+					&TagCreatedEvent{
+						eventMeta:     eventMeta{eventType: EventTypeTagCreated, provider: providerStash, payload: payload},
+						RepositoryURL: "https://stash.example.com/projects/PRJ/repos/repo",
+						Tag:           "v1.0.0",
+						Sha:           sha2,
+					},
+				}
+			},
+		},
+		{
+			name:     "stash pull request declined",
+			provider: providerStash,
+			header:   map[string]string{stashEventHeader: "pr:declined", stashSignatureHeader: sign(stashDeclined)},
+			payload:  stashDeclined,
+			want: func(payload []byte) []Event {
+				return []Event{&PullRequestEvent{
+					eventMeta:     eventMeta{eventType: EventTypePullRequestClosed, provider: providerStash, payload: payload},
+					RepositoryURL: "https://stash.example.com/projects/PRJ/repos/repo",
+					PullRequestInfo: gitprovider.PullRequestInfo{
+						Number:       3,
+						WebURL:       "https://stash.example.com/projects/PRJ/repos/repo/pull-requests/3",
+						Title:        "Add feature",
+						State:        gitprovider.PullRequestStateClosed,
+						SourceBranch: "feature",
+						TargetBranch: "main",
+						HeadSHA:      sha2,
+					},
+				}}
+			},
+		},
+		{
+			name:     "stash signature of other secret",
+			provider: providerStash,
+			header:   map[string]string{stashEventHeader: "pr:declined", stashSignatureHeader: "sha256=" + hex.EncodeToString([]byte("nope"))},
+			payload:  stashDeclined,
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "unsupported provider",
+			provider: gitprovider.ProviderID("azuredevops"),
+			payload:  `{}`,
+			wantErr:  gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewBufferString(tt.payload))
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			got, err := Parse(tt.provider, r, []byte(secret))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want([]byte(tt.payload)), got, cmpEvents); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_noSecret(t *testing.T) {
+	payload := []byte(`{"ref": "refs/heads/main", "after": "` + sha2 + `"}`)
+	header := http.Header{}
+	header.Set(githubEventHeader, "push")
+	if _, err := ParsePayload(providerGitHub, header, payload, nil); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Fatalf("ParsePayload() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+
+	events, err := ParsePayloadUnverified(providerGitHub, header, payload)
+	if err != nil {
+		t.Fatalf("ParsePayloadUnverified() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Type() != EventTypePush || !bytes.Equal(events[0].Payload(), payload) {
+		t.Errorf("ParsePayloadUnverified() = %v, want a single push event with the raw payload", events)
+	}
+}
@@ -0,0 +1,520 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitrepo implements the Git operations of the providers which manage repositories
+// themselves through go-git, e.g. creating commits from files and merging branches.
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// fileEntry is a file in a flattened Git tree.
+type fileEntry struct {
+	hash plumbing.Hash
+	mode filemode.FileMode
+}
+
+// BranchCommit returns the commit the given branch points to.
+//
+// ErrNotFound is returned if the branch does not exist.
+func BranchCommit(repo *git.Repository, branch string) (*object.Commit, error) {
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, fmt.Errorf("branch %q: %w", branch, gitprovider.ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(ref.Hash())
+}
+
+// CommitObject returns the commit with the given SHA.
+//
+// ErrNotFound is returned if the commit does not exist.
+func CommitObject(repo *git.Repository, sha string) (*object.Commit, error) {
+	commit, err := repo.CommitObject(plumbing.NewHash(sha))
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, fmt.Errorf("commit %q: %w", sha, gitprovider.ErrNotFound)
+	}
+	return commit, err
+}
+
+// ResolveCommit returns the commit the given branch points to, or else the commit with the given SHA.
+//
+// ErrNotFound is returned if neither exists.
+func ResolveCommit(repo *git.Repository, branchOrSHA string) (*object.Commit, error) {
+	commit, err := BranchCommit(repo, branchOrSHA)
+	if !errors.Is(err, gitprovider.ErrNotFound) || !plumbing.IsHash(branchOrSHA) {
+		return commit, err
+	}
+	return CommitObject(repo, branchOrSHA)
+}
+
+// TreeObject returns the tree with the given SHA, or the tree of the commit with the given SHA.
+//
+// ErrNotFound is returned if neither exists.
+func TreeObject(repo *git.Repository, sha string) (*object.Tree, error) {
+	hash := plumbing.NewHash(sha)
+	tree, err := repo.TreeObject(hash)
+	if err == nil {
+		return tree, nil
+	}
+	if commit, err := repo.CommitObject(hash); err == nil {
+		return commit.Tree()
+	}
+	return nil, fmt.Errorf("tree %q: %w", sha, gitprovider.ErrNotFound)
+}
+
+// IsEmpty returns true if the repository has no branches yet.
+func IsEmpty(repo *git.Repository) (bool, error) {
+	branches, err := repo.Branches()
+	if err != nil {
+		return false, err
+	}
+	defer branches.Close()
+	_, err = branches.Next()
+	if errors.Is(err, io.EOF) {
+		return true, nil
+	}
+	return false, err
+}
+
+// CommitFiles creates a commit on top of the given branch, applying the action of each of the
+// given files, see gitprovider.CommitFile. If the repository is empty, the branch is created.
+// author is used as author and committer, with the current time.
+//
+// ErrNotFound is returned if the branch does not exist in a non-empty repository.
+func CommitFiles(repo *git.Repository, branch, message string, files []gitprovider.CommitFile, author object.Signature) (*object.Commit, error) {
+	parents := []plumbing.Hash{}
+	tree := map[string]fileEntry{}
+	parent, err := BranchCommit(repo, branch)
+	switch {
+	case err == nil:
+		parents = append(parents, parent.Hash)
+		if tree, err = flattenCommit(parent); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, gitprovider.ErrNotFound):
+		// Only the first branch can be created through a commit
+		empty, emptyErr := IsEmpty(repo)
+		if emptyErr != nil {
+			return nil, emptyErr
+		}
+		if !empty {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	for _, file := range files {
+		if err := applyCommitFile(repo.Storer, tree, file); err != nil {
+			return nil, err
+		}
+	}
+
+	treeHash, err := writeTree(repo.Storer, tree)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := writeCommit(repo, treeHash, parents, message, author)
+	if err != nil {
+		return nil, err
+	}
+	return commit, SetBranch(repo, branch, commit.Hash)
+}
+
+// applyCommitFile applies the action of file to the flattened tree, storing its content as a blob.
+//
+// ErrNotFound is returned when updating, deleting or moving a file which doesn't exist, and
+// ErrAlreadyExists when adding a file, or moving a file to a path, which exists.
+func applyCommitFile(s storer.EncodedObjectStorer, tree map[string]fileEntry, file gitprovider.CommitFile) error {
+	if err := file.Validate(); err != nil {
+		return validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+	}
+	filePath, err := cleanPath(*file.Path)
+	if err != nil {
+		return err
+	}
+	mode, err := filemode.New(string(file.GetMode()))
+	if err != nil {
+		return err
+	}
+	_, exists := tree[filePath]
+
+	switch file.GetAction() {
+	case gitprovider.CommitFileActionAdd:
+		if exists {
+			return fmt.Errorf("file %q: %w", filePath, gitprovider.ErrAlreadyExists)
+		}
+	case gitprovider.CommitFileActionUpdate, gitprovider.CommitFileActionDelete:
+		if !exists {
+			return fmt.Errorf("file %q: %w", filePath, gitprovider.ErrNotFound)
+		}
+	case gitprovider.CommitFileActionMove:
+		previousPath, err := cleanPath(*file.PreviousPath)
+		if err != nil {
+			return err
+		}
+		previous, ok := tree[previousPath]
+		if !ok {
+			return fmt.Errorf("file %q: %w", previousPath, gitprovider.ErrNotFound)
+		}
+		if exists && filePath != previousPath {
+			return fmt.Errorf("file %q: %w", filePath, gitprovider.ErrAlreadyExists)
+		}
+		delete(tree, previousPath)
+		if file.Mode == nil {
+			mode = previous.mode
+		}
+		if file.Content == nil {
+			tree[filePath] = fileEntry{hash: previous.hash, mode: mode}
+			return nil
+		}
+	}
+
+	if file.GetAction() == gitprovider.CommitFileActionDelete {
+		delete(tree, filePath)
+		return nil
+	}
+	content, err := file.DecodedContent()
+	if err != nil {
+		return err
+	}
+	hash, err := writeBlob(s, string(content))
+	if err != nil {
+		return err
+	}
+	tree[filePath] = fileEntry{hash: hash, mode: mode}
+	return nil
+}
+
+// PutFile creates a commit on top of the given branch, setting the file at filePath to content.
+// expectedSHA is the blob SHA the file is expected to have, or empty if it's expected not to exist.
+//
+// ErrConflict is returned if the file doesn't match expectedSHA.
+func PutFile(repo *git.Repository, branch, filePath, content, message, expectedSHA string, author object.Signature) (*object.Commit, error) {
+	actualSHA, err := fileSHA(repo, branch, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if actualSHA != expectedSHA {
+		return nil, fmt.Errorf("file %q has SHA %q, expected %q: %w", filePath, actualSHA, expectedSHA, gitprovider.ErrConflict)
+	}
+	return CommitFiles(repo, branch, message, []gitprovider.CommitFile{{Path: &filePath, Content: &content}}, author)
+}
+
+// DeleteFile creates a commit on top of the given branch, deleting the file at filePath.
+//
+// ErrNotFound is returned if the file doesn't exist, and ErrConflict if it doesn't match expectedSHA.
+func DeleteFile(repo *git.Repository, branch, filePath, message, expectedSHA string, author object.Signature) (*object.Commit, error) {
+	if expectedSHA == "" {
+		return nil, fmt.Errorf("expected SHA is required to delete file %q: %w", filePath, gitprovider.ErrInvalidArgument)
+	}
+	actualSHA, err := fileSHA(repo, branch, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if actualSHA == "" {
+		return nil, fmt.Errorf("file %q: %w", filePath, gitprovider.ErrNotFound)
+	}
+	if actualSHA != expectedSHA {
+		return nil, fmt.Errorf("file %q has SHA %q, expected %q: %w", filePath, actualSHA, expectedSHA, gitprovider.ErrConflict)
+	}
+	return CommitFiles(repo, branch, message, []gitprovider.CommitFile{{Path: &filePath}}, author)
+}
+
+// fileSHA returns the blob SHA of the file at filePath on the given branch, or an empty string
+// if there's no such file. An empty repository has no files.
+func fileSHA(repo *git.Repository, branch, filePath string) (string, error) {
+	cleaned, err := cleanPath(filePath)
+	if err != nil {
+		return "", err
+	}
+	commit, err := BranchCommit(repo, branch)
+	if errors.Is(err, gitprovider.ErrNotFound) {
+		if empty, emptyErr := IsEmpty(repo); emptyErr != nil || empty {
+			return "", emptyErr
+		}
+		return "", err
+	} else if err != nil {
+		return "", err
+	}
+	file, err := commit.File(cleaned)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return file.Hash.String(), nil
+}
+
+// mergeCommits creates a commit on top of target, containing the changes of source since their
+// merge base. If squash is false, source is recorded as the second parent.
+// Files changed differently in both commits are reported as conflicts.
+func mergeCommits(repo *git.Repository, target, source *object.Commit, message string, squash bool, author object.Signature) (*object.Commit, error) {
+	bases, err := target.MergeBase(source)
+	if err != nil {
+		return nil, err
+	}
+	var base *object.Commit
+	if len(bases) > 0 {
+		base = bases[0]
+	}
+	treeHash, err := mergeTrees(repo, base, target, source)
+	if err != nil {
+		return nil, err
+	}
+	parents := []plumbing.Hash{target.Hash}
+	if !squash {
+		parents = append(parents, source.Hash)
+	}
+	return writeCommit(repo, treeHash, parents, message, author)
+}
+
+// rebaseCommits replays the commits of source since its merge base with target on top of
+// target, keeping their messages, and returns the last replayed commit.
+// Merge commits can't be replayed, and files changed differently on both sides are reported
+// as conflicts.
+func rebaseCommits(repo *git.Repository, target, source *object.Commit, author object.Signature) (*object.Commit, error) {
+	bases, err := target.MergeBase(source)
+	if err != nil {
+		return nil, err
+	}
+	commits := []*object.Commit{}
+	for commit := source; len(bases) == 0 || commit.Hash != bases[0].Hash; {
+		if commit.NumParents() > 1 {
+			return nil, fmt.Errorf("can't rebase merge commit %s: %w", commit.Hash, gitprovider.ErrInvalidArgument)
+		}
+		commits = append(commits, commit)
+		if commit.NumParents() == 0 {
+			break
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return nil, err
+		}
+	}
+
+	head := target
+	for i := len(commits) - 1; i >= 0; i-- {
+		var parent *object.Commit
+		if commits[i].NumParents() > 0 {
+			if parent, err = commits[i].Parent(0); err != nil {
+				return nil, err
+			}
+		}
+		treeHash, err := mergeTrees(repo, parent, head, commits[i])
+		if err != nil {
+			return nil, err
+		}
+		if head, err = writeCommit(repo, treeHash, []plumbing.Hash{head.Hash}, commits[i].Message, author); err != nil {
+			return nil, err
+		}
+	}
+	return head, nil
+}
+
+// mergeTrees stores the tree containing the changes of both target and source since base, which
+// may be nil, and returns its hash. Files changed differently in both commits are reported as
+// conflicts.
+func mergeTrees(repo *git.Repository, baseCommit, target, source *object.Commit) (plumbing.Hash, error) {
+	base := map[string]fileEntry{}
+	var err error
+	if baseCommit != nil {
+		if base, err = flattenCommit(baseCommit); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	ours, err := flattenCommit(target)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	theirs, err := flattenCommit(source)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	merged := map[string]fileEntry{}
+	for p, entry := range ours {
+		merged[p] = entry
+	}
+	for p, their := range theirs {
+		our, inOurs := ours[p]
+		original, inBase := base[p]
+		switch {
+		case inOurs && our == their:
+		case !inOurs && !inBase, inOurs && inBase && our == original:
+			merged[p] = their
+		case inBase && their == original:
+			// Only changed on the target
+		default:
+			return plumbing.ZeroHash, fmt.Errorf("merge conflict in file %q", p)
+		}
+	}
+	// Files deleted on the source
+	for p, original := range base {
+		if _, inTheirs := theirs[p]; inTheirs {
+			continue
+		}
+		our, inOurs := ours[p]
+		if inOurs && our != original {
+			return plumbing.ZeroHash, fmt.Errorf("merge conflict in file %q", p)
+		}
+		delete(merged, p)
+	}
+
+	return writeTree(repo.Storer, merged)
+}
+
+// SetHead points HEAD of repo to the given branch, which doesn't need to exist yet.
+func SetHead(repo *git.Repository, branch string) error {
+	return repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch)))
+}
+
+// SetBranch points the given branch to hash.
+func SetBranch(repo *git.Repository, branch string, hash plumbing.Hash) error {
+	return repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), hash))
+}
+
+// flattenCommit returns all files in the tree of commit, by path.
+func flattenCommit(commit *object.Commit) (map[string]fileEntry, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]fileEntry{}
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if entry.Mode == filemode.Dir {
+			continue
+		}
+		files[name] = fileEntry{hash: entry.Hash, mode: entry.Mode}
+	}
+}
+
+// writeTree stores the (nested) trees for the given files, and returns the hash of the root tree.
+func writeTree(s storer.EncodedObjectStorer, files map[string]fileEntry) (plumbing.Hash, error) {
+	entries := []object.TreeEntry{}
+	dirs := map[string]map[string]fileEntry{}
+	for p, file := range files {
+		if i := strings.Index(p, "/"); i >= 0 {
+			dir := p[:i]
+			if dirs[dir] == nil {
+				dirs[dir] = map[string]fileEntry{}
+			}
+			dirs[dir][p[i+1:]] = file
+			continue
+		}
+		entries = append(entries, object.TreeEntry{Name: p, Mode: file.mode, Hash: file.hash})
+	}
+	for dir, dirFiles := range dirs {
+		if _, ok := files[dir]; ok {
+			return plumbing.ZeroHash, fmt.Errorf("path %q is both a file and a directory: %w", dir, gitprovider.ErrInvalidArgument)
+		}
+		hash, err := writeTree(s, dirFiles)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: dir, Mode: filemode.Dir, Hash: hash})
+	}
+	// Git sorts tree entries by name, with directory names suffixed by a slash
+	sort.Slice(entries, func(i, j int) bool {
+		return treeEntrySortKey(entries[i]) < treeEntrySortKey(entries[j])
+	})
+
+	obj := s.NewEncodedObject()
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+func treeEntrySortKey(entry object.TreeEntry) string {
+	if entry.Mode == filemode.Dir {
+		return entry.Name + "/"
+	}
+	return entry.Name
+}
+
+// writeBlob stores content as a blob, and returns its hash.
+func writeBlob(s storer.EncodedObjectStorer, content string) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(obj)
+}
+
+// writeCommit stores a commit of the given tree, and returns it.
+func writeCommit(repo *git.Repository, treeHash plumbing.Hash, parents []plumbing.Hash, message string, author object.Signature) (*object.Commit, error) {
+	signature := author
+	signature.When = time.Now()
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+	obj := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return nil, err
+	}
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(hash)
+}
+
+// cleanPath returns the canonical form of a path in the repository, without leading slash.
+func cleanPath(p string) (string, error) {
+	cleaned := path.Clean("/" + p)[1:]
+	if cleaned == "" {
+		return "", fmt.Errorf("invalid file path %q: %w", p, gitprovider.ErrInvalidArgument)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,410 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// Types of the entries returned by Tree.
+const (
+	TreeEntryTypeBlob = "blob"
+	TreeEntryTypeTree = "tree"
+)
+
+// ListCommits returns the given page of the history of branch, newest commits first.
+// Pages start at 1, page 0 is treated as the first page.
+func ListCommits(repo *git.Repository, branch string, perPage, page int) ([]*object.Commit, error) {
+	head, err := BranchCommit(repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	if page > 0 {
+		page--
+	}
+	skip := page * perPage
+
+	commits := []*object.Commit{}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for len(commits) < perPage {
+		commit, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// CreateBranch creates a branch pointing to the commit with the given SHA.
+//
+// ErrAlreadyExists is returned if the branch exists already.
+func CreateBranch(repo *git.Repository, branch, sha string) error {
+	if _, err := BranchCommit(repo, branch); err == nil {
+		return fmt.Errorf("branch %q: %w", branch, gitprovider.ErrAlreadyExists)
+	}
+	commit, err := CommitObject(repo, sha)
+	if err != nil {
+		return err
+	}
+	return SetBranch(repo, branch, commit.Hash)
+}
+
+// ListBranches returns the references of all branches, sorted by name.
+func ListBranches(repo *git.Repository) ([]*plumbing.Reference, error) {
+	iter, err := repo.Branches()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	refs := []*plumbing.Reference{}
+	if err := iter.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name() < refs[j].Name()
+	})
+	return refs, nil
+}
+
+// DeleteBranch deletes the given branch.
+//
+// ErrNotFound is returned if the branch does not exist, and ErrInvalidArgument if it's the
+// default branch of the repository.
+func DeleteBranch(repo *git.Repository, branch string) error {
+	if _, err := BranchCommit(repo, branch); err != nil {
+		return err
+	}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return err
+	}
+	if head.Target() == plumbing.NewBranchReferenceName(branch) {
+		return fmt.Errorf("branch %q is the default branch: %w", branch, gitprovider.ErrInvalidArgument)
+	}
+	return repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(branch))
+}
+
+// SetDefaultBranch makes the given branch the default branch of the repository.
+//
+// ErrNotFound is returned if the branch does not exist.
+func SetDefaultBranch(repo *git.Repository, branch string) error {
+	if _, err := BranchCommit(repo, branch); err != nil {
+		return err
+	}
+	return SetHead(repo, branch)
+}
+
+// CreateTag creates a tag pointing to the commit with the given SHA. If message is not nil, an
+// annotated tag is created, tagged by tagger.
+//
+// ErrAlreadyExists is returned if the tag exists already, and ErrNotFound if the commit doesn't.
+func CreateTag(repo *git.Repository, name, sha string, message *string, tagger object.Signature) error {
+	if _, err := repo.Tag(name); err == nil {
+		return fmt.Errorf("tag %q: %w", name, gitprovider.ErrAlreadyExists)
+	}
+	commit, err := CommitObject(repo, sha)
+	if err != nil {
+		return err
+	}
+	var opts *git.CreateTagOptions
+	if message != nil {
+		if strings.TrimSpace(*message) == "" {
+			return fmt.Errorf("annotated tag %q needs a message: %w", name, gitprovider.ErrInvalidArgument)
+		}
+		tagger.When = time.Now()
+		opts = &git.CreateTagOptions{Tagger: &tagger, Message: *message}
+	}
+	_, err = repo.CreateTag(name, commit.Hash, opts)
+	return err
+}
+
+// ListTags returns all tags, sorted by name.
+func ListTags(repo *git.Repository) ([]gitprovider.TagInfo, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	tags := []gitprovider.TagInfo{}
+	if err := iter.ForEach(func(ref *plumbing.Reference) error {
+		tag, err := tagInfo(repo, ref)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+// GetTag returns the tag with the given name.
+//
+// ErrNotFound is returned if the tag does not exist.
+func GetTag(repo *git.Repository, name string) (gitprovider.TagInfo, error) {
+	ref, err := repo.Tag(name)
+	if errors.Is(err, git.ErrTagNotFound) {
+		return gitprovider.TagInfo{}, fmt.Errorf("tag %q: %w", name, gitprovider.ErrNotFound)
+	} else if err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	return tagInfo(repo, ref)
+}
+
+// DeleteTag deletes the tag with the given name.
+//
+// ErrNotFound is returned if the tag does not exist.
+func DeleteTag(repo *git.Repository, name string) error {
+	err := repo.DeleteTag(name)
+	if errors.Is(err, git.ErrTagNotFound) {
+		return fmt.Errorf("tag %q: %w", name, gitprovider.ErrNotFound)
+	}
+	return err
+}
+
+// tagInfo returns the commit and, for annotated tags, the message of the tag reference.
+func tagInfo(repo *git.Repository, ref *plumbing.Reference) (gitprovider.TagInfo, error) {
+	tag := gitprovider.TagInfo{Name: ref.Name().Short(), Sha: ref.Hash().String()}
+	annotated, err := repo.TagObject(ref.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		// Lightweight tags point to the commit directly
+		return tag, nil
+	} else if err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	commit, err := annotated.Commit()
+	if err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	tag.Sha = commit.Hash.String()
+	// Git terminates tag messages with a newline
+	tag.Message = gitprovider.StringVar(strings.TrimSuffix(annotated.Message, "\n"))
+	return tag, nil
+}
+
+// MergeBranch merges the source branch into the target branch of the pull request with the given
+// number and title, and returns the resulting commit. If message is empty, a default commit
+// message is used, rebases and fast-forwards keep the messages of the source commits.
+// Files changed differently on both branches are reported as conflicts.
+func MergeBranch(repo *git.Repository, number int, title, targetBranch, sourceBranch string,
+	mergeMethod gitprovider.MergeMethod, message string, author object.Signature) (*object.Commit, error) {
+	target, err := BranchCommit(repo, targetBranch)
+	if err != nil {
+		return nil, err
+	}
+	source, err := BranchCommit(repo, sourceBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	var squash bool
+	switch mergeMethod {
+	case gitprovider.MergeMethodFastForwardOnly:
+		isAncestor, err := target.IsAncestor(source)
+		if err != nil {
+			return nil, err
+		}
+		if !isAncestor {
+			return nil, fmt.Errorf("branch %q can't be fast-forwarded to %q: %w", targetBranch, sourceBranch, gitprovider.ErrInvalidArgument)
+		}
+		return source, SetBranch(repo, targetBranch, source.Hash)
+	case gitprovider.MergeMethodRebase:
+		commit, err := rebaseCommits(repo, target, source, author)
+		if err != nil {
+			return nil, err
+		}
+		return commit, SetBranch(repo, targetBranch, commit.Hash)
+	case gitprovider.MergeMethodMerge:
+		if message == "" {
+			message = fmt.Sprintf("Merge pull request #%d from %s\n\n%s", number, sourceBranch, title)
+		}
+	case gitprovider.MergeMethodSquash:
+		squash = true
+		if message == "" {
+			message = fmt.Sprintf("%s (#%d)", title, number)
+		}
+	default:
+		return nil, fmt.Errorf("unknown merge method %q: %w", mergeMethod, gitprovider.ErrInvalidArgument)
+	}
+
+	commit, err := mergeCommits(repo, target, source, message, squash, author)
+	if err != nil {
+		return nil, err
+	}
+	return commit, SetBranch(repo, targetBranch, commit.Hash)
+}
+
+// Files returns the file at path, or the files in the directory at path, on the given branch.
+// Files in sub-directories are only returned if recursive is true.
+func Files(repo *git.Repository, filePath, branch string, recursive bool) ([]*gitprovider.CommitFile, error) {
+	commit, err := BranchCommit(repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	root, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := strings.Trim(filePath, "/")
+	tree := root
+	if dir != "" {
+		entry, err := root.FindEntry(dir)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", filePath, gitprovider.ErrNotFound)
+		}
+		if entry.Mode != filemode.Dir {
+			file, err := root.File(dir)
+			if err != nil {
+				return nil, err
+			}
+			return commitFilesFromTree([]*object.File{file}, "")
+		}
+		if tree, err = root.Tree(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	files := []*object.File{}
+	if recursive {
+		err = tree.Files().ForEach(func(file *object.File) error {
+			files = append(files, file)
+			return nil
+		})
+	} else {
+		for _, entry := range tree.Entries {
+			if entry.Mode == filemode.Dir {
+				continue
+			}
+			file, fileErr := tree.TreeEntryFile(&entry)
+			if fileErr != nil {
+				return nil, fileErr
+			}
+			files = append(files, file)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return commitFilesFromTree(files, dir)
+}
+
+// commitFilesFromTree returns the contents of files, with their paths prefixed by dir.
+func commitFilesFromTree(files []*object.File, dir string) ([]*gitprovider.CommitFile, error) {
+	commitFiles := make([]*gitprovider.CommitFile, 0, len(files))
+	for _, file := range files {
+		content, err := file.Contents()
+		if err != nil {
+			return nil, err
+		}
+		filePath := file.Name
+		if dir != "" {
+			filePath = dir + "/" + file.Name
+		}
+		commitFiles = append(commitFiles, &gitprovider.CommitFile{
+			Path:    &filePath,
+			Content: &content,
+			SHA:     gitprovider.StringVar(file.Hash.String()),
+		})
+	}
+	return commitFiles, nil
+}
+
+// Tree returns the tree with the given SHA, or the tree of the commit with the given SHA.
+// If recursive is true, the entries of all sub-trees are included, with their full paths.
+// The URLs of the entries are relative to the URL of ref.
+func Tree(repo *git.Repository, ref gitprovider.RepositoryRef, sha string, recursive bool) (*gitprovider.TreeInfo, error) {
+	tree, err := TreeObject(repo, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*gitprovider.TreeEntry{}
+	walker := object.NewTreeWalker(tree, recursive, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		treeEntry, err := treeEntryFromAPI(repo, ref, name, entry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, treeEntry)
+	}
+
+	return &gitprovider.TreeInfo{
+		SHA:  tree.Hash.String(),
+		Tree: entries,
+	}, nil
+}
+
+func treeEntryFromAPI(repo *git.Repository, ref gitprovider.RepositoryRef, name string, entry object.TreeEntry) (*gitprovider.TreeEntry, error) {
+	treeEntry := &gitprovider.TreeEntry{
+		Path: name,
+		Mode: fmt.Sprintf("%06o", uint32(entry.Mode)),
+		Type: TreeEntryTypeBlob,
+		SHA:  entry.Hash.String(),
+		URL:  fmt.Sprintf("%s/git/blobs/%s", ref.String(), entry.Hash),
+	}
+	if entry.Mode == filemode.Dir {
+		treeEntry.Type = TreeEntryTypeTree
+		treeEntry.URL = fmt.Sprintf("%s/git/trees/%s", ref.String(), entry.Hash)
+		return treeEntry, nil
+	}
+	size, err := repo.Storer.EncodedObjectSize(entry.Hash)
+	if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, err
+	}
+	treeEntry.Size = int(size)
+	return treeEntry, nil
+}
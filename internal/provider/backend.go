@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provider implements the gitprovider.Client interface on top of a Backend, which stores
+// the organizations and repositories. It is shared by the providers which manage the repositories
+// themselves, e.g. the fake provider keeping them in memory and the local provider keeping them
+// on the filesystem.
+package provider
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// Backend stores the organizations, teams and repositories a Client operates on.
+//
+// The Client validates all references and requests before calling the Backend. The Backend
+// returns copies of its API objects, wraps gitprovider.ErrNotFound if an object doesn't exist
+// and gitprovider.ErrAlreadyExists if an object to create exists already.
+// It has to be safe for concurrent use.
+type Backend interface {
+	// GetOrganization returns the organization for ref.
+	GetOrganization(ref gitprovider.OrganizationRef) (*Organization, error)
+	// ListOrganizations returns the top-level organizations of domain, sorted by name.
+	ListOrganizations(domain string) ([]*Organization, error)
+	// ListChildOrganizations returns the immediate sub-organizations of ref, sorted by name.
+	ListChildOrganizations(ref gitprovider.OrganizationRef) ([]*Organization, error)
+	// CreateOrganization creates the organization described by req. The parent of a
+	// sub-organization needs to exist. If req.Name is unset, it's derived from req.Ref.
+	CreateOrganization(req *Organization) (*Organization, error)
+	// UpdateOrganization updates the name and description of the organization.
+	UpdateOrganization(req *Organization) (*Organization, error)
+	// DeleteOrganization deletes the organization, including its sub-organizations and repositories.
+	DeleteOrganization(ref gitprovider.OrganizationRef) error
+
+	// GetTeam returns the team with the given name of the organization.
+	GetTeam(ref gitprovider.OrganizationRef, name string) (*Team, error)
+	// ListTeams returns the teams of the organization, sorted by name.
+	ListTeams(ref gitprovider.OrganizationRef) ([]*Team, error)
+	// CreateTeam adds req to the teams of the organization.
+	CreateTeam(ref gitprovider.OrganizationRef, req *Team) (*Team, error)
+	// UpdateTeam replaces the members of the team with the same name.
+	UpdateTeam(ref gitprovider.OrganizationRef, req *Team) (*Team, error)
+	// AddTeamMember adds login to the members of the team, unless it's a member already.
+	AddTeamMember(ref gitprovider.OrganizationRef, name, login string) (*Team, error)
+	// RemoveTeamMember removes login from the members of the team.
+	RemoveTeamMember(ref gitprovider.OrganizationRef, name, login string) (*Team, error)
+	// DeleteTeam deletes the team with the given name of the organization.
+	DeleteTeam(ref gitprovider.OrganizationRef, name string) error
+
+	// GetRepo returns the repository for ref.
+	GetRepo(ref gitprovider.RepositoryRef) (*Repository, error)
+	// ListRepos returns the repositories owned by the given organization or user, sorted by name.
+	ListRepos(owner gitprovider.IdentityRef) ([]*Repository, error)
+	// CreateRepo creates the repository described by req. If autoInit is true, an initial commit
+	// with a README.md file is added to the default branch.
+	CreateRepo(req *Repository, autoInit bool) (*Repository, error)
+	// UpdateRepo updates the description, default branch and visibility of the repository.
+	UpdateRepo(req *Repository) (*Repository, error)
+	// DeleteRepo deletes the repository.
+	DeleteRepo(ref gitprovider.RepositoryRef) error
+
+	// GetDeployKey returns the deploy key with the given name of the repository.
+	GetDeployKey(ref gitprovider.RepositoryRef, name string) (*DeployKey, error)
+	// ListDeployKeys returns the deploy keys of the repository, sorted by name.
+	ListDeployKeys(ref gitprovider.RepositoryRef) ([]*DeployKey, error)
+	// CreateDeployKey adds req to the repository. Both the name and the key need to be unique.
+	CreateDeployKey(ref gitprovider.RepositoryRef, req *DeployKey) (*DeployKey, error)
+	// UpdateDeployKey replaces the deploy key with the same name.
+	UpdateDeployKey(ref gitprovider.RepositoryRef, req *DeployKey) (*DeployKey, error)
+	// DeleteDeployKey deletes the deploy key with the given name of the repository.
+	DeleteDeployKey(ref gitprovider.RepositoryRef, name string) error
+
+	// GetBranchProtection returns the protection of the given branch of the repository.
+	GetBranchProtection(ref gitprovider.RepositoryRef, branch string) (*BranchProtection, error)
+	// ListBranchProtections returns the branch protections of the repository, sorted by branch.
+	ListBranchProtections(ref gitprovider.RepositoryRef) ([]*BranchProtection, error)
+	// SetBranchProtection protects a branch of the repository, the branch doesn't need to exist.
+	// If create is true, ErrAlreadyExists is returned if the branch is protected already, otherwise
+	// ErrNotFound is returned if it isn't.
+	SetBranchProtection(ref gitprovider.RepositoryRef, req *BranchProtection, create bool) (*BranchProtection, error)
+	// DeleteBranchProtection removes the protection of the given branch of the repository.
+	DeleteBranchProtection(ref gitprovider.RepositoryRef, branch string) error
+
+	// GetWebhook returns the webhook with the given URL of the repository.
+	GetWebhook(ref gitprovider.RepositoryRef, url string) (*Webhook, error)
+	// ListWebhooks returns the webhooks of the repository, in the order they were created.
+	ListWebhooks(ref gitprovider.RepositoryRef) ([]*Webhook, error)
+	// CreateWebhook adds req to the repository, the URL of which has to be unique.
+	CreateWebhook(ref gitprovider.RepositoryRef, req *Webhook) (*Webhook, error)
+	// UpdateWebhook replaces the webhook with the same URL.
+	UpdateWebhook(ref gitprovider.RepositoryRef, req *Webhook) (*Webhook, error)
+	// DeleteWebhook deletes the webhook with the given URL of the repository.
+	DeleteWebhook(ref gitprovider.RepositoryRef, url string) error
+
+	// GetRelease returns the release for the given tag of the repository.
+	GetRelease(ref gitprovider.RepositoryRef, tagName string) (*Release, error)
+	// ListReleases returns the releases of the repository, in the order they were created.
+	ListReleases(ref gitprovider.RepositoryRef) ([]*Release, error)
+	// CreateRelease adds a release for req.TagName. If the tag doesn't exist, a lightweight tag
+	// is created from target, which is a branch name or commit SHA and defaults to the default branch.
+	CreateRelease(ref gitprovider.RepositoryRef, req *Release, target string) (*Release, error)
+	// UpdateRelease replaces the name, notes and flags of the release for the same tag.
+	UpdateRelease(ref gitprovider.RepositoryRef, req *Release) (*Release, error)
+	// DeleteRelease deletes the release and its assets.
+	DeleteRelease(ref gitprovider.RepositoryRef, tagName string) error
+	// UploadReleaseAsset attaches content to the release. The asset name has to be unique
+	// within the release.
+	UploadReleaseAsset(ref gitprovider.RepositoryRef, tagName, name string, content []byte) (*ReleaseAsset, error)
+
+	// ListCommitStatuses returns the latest status of each context for the commit, in the order
+	// the contexts were first reported.
+	ListCommitStatuses(ref gitprovider.RepositoryRef, sha string) ([]*CommitStatus, error)
+	// CreateCommitStatus adds the status to the commit req.SHA, replacing the status of the
+	// same context.
+	CreateCommitStatus(ref gitprovider.RepositoryRef, req *CommitStatus) (*CommitStatus, error)
+
+	// ListPullRequestComments returns the comments of the pull request, oldest first.
+	ListPullRequestComments(ref gitprovider.RepositoryRef, number int) ([]*PullRequestComment, error)
+	// CreatePullRequestComment adds a comment to the pull request, its ID and author are
+	// assigned by the Backend.
+	CreatePullRequestComment(ref gitprovider.RepositoryRef, number int, body string) (*PullRequestComment, error)
+	// UpdatePullRequestComment replaces the body of the comment with the given ID.
+	UpdatePullRequestComment(ref gitprovider.RepositoryRef, number int, id int64, body string) (*PullRequestComment, error)
+	// DeletePullRequestComment deletes the comment with the given ID.
+	DeletePullRequestComment(ref gitprovider.RepositoryRef, number int, id int64) error
+	// ListPullRequestReviews returns the current review of each reviewer of the pull request.
+	ListPullRequestReviews(ref gitprovider.RepositoryRef, number int) ([]*PullRequestReview, error)
+
+	// GetTeamAccess returns the access of the team with the given name to the repository.
+	GetTeamAccess(ref gitprovider.OrgRepositoryRef, name string) (*TeamAccess, error)
+	// ListTeamAccess returns the teams with access to the repository, sorted by name.
+	ListTeamAccess(ref gitprovider.OrgRepositoryRef) ([]*TeamAccess, error)
+	// SetTeamAccess gives a team of the repository's organization access to the repository.
+	// If create is true, ErrAlreadyExists is returned if the team has access already, otherwise
+	// ErrNotFound is returned if it doesn't.
+	SetTeamAccess(ref gitprovider.OrgRepositoryRef, req *TeamAccess, create bool) (*TeamAccess, error)
+	// DeleteTeamAccess removes the access of the team with the given name to the repository.
+	DeleteTeamAccess(ref gitprovider.OrgRepositoryRef, name string) error
+
+	// GetUserAccess returns the access of the user with the given login to the repository.
+	GetUserAccess(ref gitprovider.RepositoryRef, login string) (*UserAccess, error)
+	// ListUserAccess returns the users with access to the repository, sorted by login.
+	ListUserAccess(ref gitprovider.RepositoryRef) ([]*UserAccess, error)
+	// SetUserAccess gives a user access to the repository.
+	// If create is true, ErrAlreadyExists is returned if the user has access already, otherwise
+	// ErrNotFound is returned if they don't.
+	SetUserAccess(ref gitprovider.RepositoryRef, req *UserAccess, create bool) (*UserAccess, error)
+	// DeleteUserAccess removes the access of the user with the given login to the repository.
+	DeleteUserAccess(ref gitprovider.RepositoryRef, login string) error
+
+	// ListCommitsPage returns the given page of the history of branch, newest commits first.
+	// Pages start at 1, page 0 is treated as the first page.
+	ListCommitsPage(ref gitprovider.RepositoryRef, branch string, perPage, page int) ([]*object.Commit, error)
+	// CreateCommit commits files on top of branch, which is only created if the repository is empty.
+	CreateCommit(ref gitprovider.RepositoryRef, branch, message string, files []gitprovider.CommitFile) (*object.Commit, error)
+
+	// ListBranches returns the branches of the repository, sorted by name.
+	ListBranches(ref gitprovider.RepositoryRef) ([]*Branch, error)
+	// GetBranch returns the given branch of the repository.
+	GetBranch(ref gitprovider.RepositoryRef, branch string) (*Branch, error)
+	// CreateBranch creates a branch pointing to the commit with the given SHA.
+	CreateBranch(ref gitprovider.RepositoryRef, branch, sha string) error
+	// DeleteBranch deletes the branch, which can't be the default branch.
+	DeleteBranch(ref gitprovider.RepositoryRef, branch string) error
+	// SetDefaultBranch makes the existing branch the default branch of the repository.
+	SetDefaultBranch(ref gitprovider.RepositoryRef, branch string) error
+
+	// ListTags returns the tags of the repository, sorted by name.
+	ListTags(ref gitprovider.RepositoryRef) ([]*Tag, error)
+	// GetTag returns the tag with the given name of the repository.
+	GetTag(ref gitprovider.RepositoryRef, name string) (*Tag, error)
+	// CreateTag creates a tag pointing to the commit with the given SHA. If message is not nil,
+	// an annotated tag is created.
+	CreateTag(ref gitprovider.RepositoryRef, name, sha string, message *string) error
+	// DeleteTag deletes the tag with the given name of the repository.
+	DeleteTag(ref gitprovider.RepositoryRef, name string) error
+
+	// ListPullRequests returns the pull requests of the repository, in the order they were created.
+	ListPullRequests(ref gitprovider.RepositoryRef) ([]*PullRequest, error)
+	// CreatePullRequest opens req, its Number and WebURL are assigned by the Backend.
+	// Both branches need to exist.
+	CreatePullRequest(ref gitprovider.RepositoryRef, req *PullRequest) (*PullRequest, error)
+	// GetPullRequest returns the pull request with the given number.
+	GetPullRequest(ref gitprovider.RepositoryRef, number int) (*PullRequest, error)
+	// UpdatePullRequest applies the set fields of req to the pull request. The target branch of
+	// merged pull requests can't be changed.
+	UpdatePullRequest(ref gitprovider.RepositoryRef, number int, req gitprovider.PullRequestUpdateInfo) (*PullRequest, error)
+	// ClosePullRequest closes the pull request without merging it. Closing a closed pull request
+	// is a no-op.
+	ClosePullRequest(ref gitprovider.RepositoryRef, number int) error
+	// MergePullRequest merges the source branch of the pull request into its target branch, and
+	// deletes the source branch afterwards if deleteSourceBranch is true.
+	// If message is empty, a default commit message is used.
+	MergePullRequest(ref gitprovider.RepositoryRef, number int, mergeMethod gitprovider.MergeMethod, message string, deleteSourceBranch bool) error
+
+	// GetFiles returns the file at path, or the files in the directory at path, on the given branch.
+	// Files in sub-directories are only returned if recursive is true.
+	GetFiles(ref gitprovider.RepositoryRef, filePath, branch string, recursive bool) ([]*gitprovider.CommitFile, error)
+	// PutFile commits content to the file at filePath on the given branch.
+	//
+	// ErrConflict is returned if the blob SHA of the file isn't expectedSHA.
+	PutFile(ref gitprovider.RepositoryRef, filePath, branch, content, message, expectedSHA string) error
+	// DeleteFile deletes the file at filePath on the given branch.
+	//
+	// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its blob SHA isn't expectedSHA.
+	DeleteFile(ref gitprovider.RepositoryRef, filePath, branch, message, expectedSHA string) error
+	// GetTree returns the tree with the given SHA, or the tree of the commit with the given SHA.
+	// If recursive is true, the entries of all sub-trees are included, with their full paths.
+	GetTree(ref gitprovider.RepositoryRef, sha string, recursive bool) (*gitprovider.TreeInfo, error)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// NewClient creates a new Client for the state held by s. The providerID and domain are
+// reported by the client, which only accepts references to that domain, and raw is returned
+// by Client.Raw.
+func NewClient(s Backend, providerID gitprovider.ProviderID, domain string, destructiveActions bool, raw interface{}) *Client {
+	ctx := &clientContext{s, domain, destructiveActions}
+	return &Client{
+		clientContext: ctx,
+		providerID:    providerID,
+		raw:           raw,
+		orgs: &OrganizationsClient{
+			clientContext: ctx,
+		},
+		orgRepos: &OrgRepositoriesClient{
+			clientContext: ctx,
+		},
+		userRepos: &UserRepositoriesClient{
+			clientContext: ctx,
+		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
+	}
+}
+
+type clientContext struct {
+	s                  Backend
+	domain             string
+	destructiveActions bool
+}
+
+// Client implements the gitprovider.Client interface.
+var _ gitprovider.Client = &Client{}
+
+// Client is an interface that allows talking to a Git provider.
+type Client struct {
+	*clientContext
+
+	providerID gitprovider.ProviderID
+	raw        interface{}
+
+	orgs      *OrganizationsClient
+	orgRepos  *OrgRepositoriesClient
+	userRepos *UserRepositoriesClient
+	users     *UsersClient
+}
+
+// SupportedDomain returns the domain endpoint for this client.
+// This allows a higher-level user to know what Client to use for what endpoints.
+// This field is set at client creation time, and can't be changed.
+func (c *Client) SupportedDomain() string {
+	return c.domain
+}
+
+// ProviderID returns the provider ID of the provider this client was created for.
+// This field is set at client creation time, and can't be changed.
+func (c *Client) ProviderID() gitprovider.ProviderID {
+	return c.providerID
+}
+
+// Raw returns the raw object passed to NewClient.
+func (c *Client) Raw() interface{} {
+	return c.raw
+}
+
+// Organizations returns the OrganizationsClient handling sets of organizations.
+func (c *Client) Organizations() gitprovider.OrganizationsClient {
+	return c.orgs
+}
+
+// OrgRepositories returns the OrgRepositoriesClient handling sets of repositories in an organization.
+func (c *Client) OrgRepositories() gitprovider.OrgRepositoriesClient {
+	return c.orgRepos
+}
+
+// UserRepositories returns the UserRepositoriesClient handling sets of repositories for a user.
+func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
+	return c.userRepos
+}
+
+// Users returns the UsersClient looking up user accounts.
+func (c *Client) Users() gitprovider.UsersClient {
+	return c.users
+}
+
+// HasTokenPermission returns true if the given token has the given permissions.
+//
+// The Backend isn't accessed with a token, hence all permissions are granted.
+func (c *Client) HasTokenPermission(_ context.Context, permission gitprovider.TokenPermission) (bool, error) {
+	if err := gitprovider.ValidateTokenPermission(permission); err != nil {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+	return true, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TeamsClient implements the gitprovider.TeamsClient interface.
+var _ gitprovider.TeamsClient = &TeamsClient{}
+
+// TeamsClient handles teams organization-wide.
+type TeamsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get a team within the specific organization.
+//
+// teamName must not be an empty string.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TeamsClient) Get(_ context.Context, teamName string) (gitprovider.Team, error) {
+	apiObj, err := c.s.GetTeam(c.ref, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return newTeam(c, apiObj), nil
+}
+
+// List all teams within the specific organization.
+// Teams of sub-organizations aren't included.
+func (c *TeamsClient) List(_ context.Context) ([]gitprovider.Team, error) {
+	apiObjs, err := c.s.ListTeams(c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]gitprovider.Team, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		teams = append(teams, newTeam(c, apiObj))
+	}
+	return teams, nil
+}
+
+// Create creates a team within the specific organization, with the given members.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamsClient) Create(_ context.Context, req gitprovider.TeamInfo) (gitprovider.Team, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := c.s.CreateTeam(c.ref, &Team{Name: req.Name, Members: req.Members})
+	if err != nil {
+		return nil, err
+	}
+	return newTeam(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamsClient) Reconcile(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(req); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+func newTeam(c *TeamsClient, apiObj *Team) *team {
+	return &team{
+		t: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Team = &team{}
+
+type team struct {
+	t Team
+	c *TeamsClient
+}
+
+func (t *team) Get() gitprovider.TeamInfo {
+	return gitprovider.TeamInfo{
+		Name:    t.t.Name,
+		Members: t.t.Members,
+	}
+}
+
+// Set sets the desired members of this team. The name of the team can't be changed.
+// User have to call Update() to apply the changes to the server.
+func (t *team) Set(info gitprovider.TeamInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Name != t.t.Name {
+		return fmt.Errorf("cannot rename team %q to %q: %w", t.t.Name, info.Name, gitprovider.ErrInvalidArgument)
+	}
+	t.t.Members = append([]string{}, info.Members...)
+	return nil
+}
+
+func (t *team) APIObject() interface{} {
+	return &t.t
+}
+
+func (t *team) Organization() gitprovider.OrganizationRef {
+	return t.c.ref
+}
+
+// Update will apply the desired members in this object to the server.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (t *team) Update(_ context.Context) error {
+	apiObj, err := t.c.s.UpdateTeam(t.c.ref, &t.t)
+	if err != nil {
+		return err
+	}
+	t.t = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (t *team) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := t.c.Get(ctx, t.t.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := t.c.Create(ctx, t.Get())
+			if err != nil {
+				return true, err
+			}
+			t.t = *resp.APIObject().(*Team)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if t.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, t.Update(ctx)
+}
+
+// Delete deletes the team from the organization.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (t *team) Delete(_ context.Context) error {
+	// Don't allow deleting teams if the user didn't explicitly allow dangerous API calls.
+	if !t.c.destructiveActions {
+		return fmt.Errorf("cannot delete team: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	return t.c.s.DeleteTeam(t.c.ref, t.t.Name)
+}
+
+// AddMember adds the user with the given login to the team.
+// Adding an existing member is a no-op.
+func (t *team) AddMember(_ context.Context, login string) error {
+	apiObj, err := t.c.s.AddTeamMember(t.c.ref, t.t.Name, login)
+	if err != nil {
+		return err
+	}
+	t.t = *apiObj
+	return nil
+}
+
+// RemoveMember removes the user with the given login from the team.
+//
+// ErrNotFound is returned if the user isn't a member of the team.
+func (t *team) RemoveMember(_ context.Context, login string) error {
+	apiObj, err := t.c.s.RemoveTeamMember(t.c.ref, t.t.Name, login)
+	if err != nil {
+		return err
+	}
+	t.t = *apiObj
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchClient implements the gitprovider.BranchClient interface.
+var _ gitprovider.BranchClient = &BranchClient{}
+
+// BranchClient operates on the branches for a specific repository.
+type BranchClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all branches in the repository, sorted by name.
+func (c *BranchClient) List(_ context.Context) ([]gitprovider.Branch, error) {
+	apiObjs, err := c.s.ListBranches(c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]gitprovider.Branch, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		branches = append(branches, newBranch(apiObj))
+	}
+	return branches, nil
+}
+
+// Get returns the branch with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Get(_ context.Context, branch string) (gitprovider.Branch, error) {
+	apiObj, err := c.s.GetBranch(c.ref, branch)
+	if err != nil {
+		return nil, err
+	}
+	return newBranch(apiObj), nil
+}
+
+// Create creates a branch pointing to the commit with the given SHA.
+//
+// ErrAlreadyExists is returned if the branch already exists, and ErrNotFound
+// if the commit does not exist.
+func (c *BranchClient) Create(_ context.Context, branch, sha string) error {
+	return c.s.CreateBranch(c.ref, branch, sha)
+}
+
+// Delete deletes the branch with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist, and ErrInvalidArgument if it's the
+// default branch of the repository.
+func (c *BranchClient) Delete(_ context.Context, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	return c.s.DeleteBranch(c.ref, branch)
+}
+
+// SetDefault makes the branch with the given name the default branch of the repository.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) SetDefault(_ context.Context, branch string) error {
+	return c.s.SetDefaultBranch(c.ref, branch)
+}
+
+func newBranch(apiObj *Branch) *branchType {
+	return &branchType{
+		b: *apiObj,
+	}
+}
+
+var _ gitprovider.Branch = &branchType{}
+
+type branchType struct {
+	b Branch
+}
+
+func (b *branchType) Get() gitprovider.BranchInfo {
+	return branchFromAPI(&b.b)
+}
+
+func (b *branchType) APIObject() interface{} {
+	return &b.b
+}
+
+func branchFromAPI(apiObj *Branch) gitprovider.BranchInfo {
+	return gitprovider.BranchInfo{
+		Name:      apiObj.Name,
+		Sha:       apiObj.SHA,
+		Protected: apiObj.Protected,
+	}
+}
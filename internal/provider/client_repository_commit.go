@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CommitClient implements the gitprovider.CommitClient interface.
+var _ gitprovider.CommitClient = &CommitClient{}
+
+// CommitClient operates on the commits for a specific repository.
+type CommitClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// ListPage lists repository commits of the given page and page size, newest commits first.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *CommitClient) ListPage(_ context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	apiObjs, err := c.s.ListCommitsPage(c.ref, branch, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our CommitType type
+	commits := make([]gitprovider.Commit, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		commits = append(commits, newCommit(c, apiObj))
+	}
+	return commits, nil
+}
+
+// Create creates a commit with the given specifications.
+// Files with a nil Content are deleted. The branch is created if the repository is empty.
+//
+// ErrNotFound is returned if the branch, or a file to delete, does not exist.
+func (c *CommitClient) Create(_ context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+
+	apiObj, err := c.s.CreateCommit(c.ref, branch, message, files)
+	if err != nil {
+		return nil, err
+	}
+	return newCommit(c, apiObj), nil
+}
+
+func newCommit(c *CommitClient, commit *object.Commit) *commitType {
+	return &commitType{
+		k: *commit,
+		c: c,
+	}
+}
+
+var _ gitprovider.Commit = &commitType{}
+
+type commitType struct {
+	k object.Commit
+	c *CommitClient
+}
+
+func (c *commitType) Get() gitprovider.CommitInfo {
+	return commitFromAPI(&c.k, c.c.ref)
+}
+
+func (c *commitType) APIObject() interface{} {
+	return &c.k
+}
+
+func commitFromAPI(apiObj *object.Commit, ref gitprovider.RepositoryRef) gitprovider.CommitInfo {
+	return gitprovider.CommitInfo{
+		Sha:       apiObj.Hash.String(),
+		TreeSha:   apiObj.TreeHash.String(),
+		Author:    apiObj.Author.Name,
+		Message:   apiObj.Message,
+		CreatedAt: apiObj.Author.When,
+		URL:       fmt.Sprintf("%s/commit/%s", ref.String(), apiObj.Hash),
+	}
+}
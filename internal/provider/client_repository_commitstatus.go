@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CommitStatusClient implements the gitprovider.CommitStatusClient interface.
+var _ gitprovider.CommitStatusClient = &CommitStatusClient{}
+
+// CommitStatusClient operates on the commit statuses of a specific repository.
+type CommitStatusClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List returns the latest status of each context for the commit with the given sha.
+//
+// ErrNotFound is returned if the commit does not exist.
+func (c *CommitStatusClient) List(_ context.Context, sha string) ([]gitprovider.CommitStatus, error) {
+	apiObjs, err := c.s.ListCommitStatuses(c.ref, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]gitprovider.CommitStatus, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		statuses = append(statuses, newCommitStatus(apiObj))
+	}
+	return statuses, nil
+}
+
+// Create reports a status with the given specifications for the commit with the given sha,
+// replacing the status of the same context, if any.
+//
+// ErrNotFound is returned if the commit does not exist.
+func (c *CommitStatusClient) Create(_ context.Context, sha string, req gitprovider.CommitStatusInfo) (gitprovider.CommitStatus, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	apiObj, err := c.s.CreateCommitStatus(c.ref, commitStatusInfoToAPIObj(sha, &req))
+	if err != nil {
+		return nil, err
+	}
+	return newCommitStatus(apiObj), nil
+}
+
+func newCommitStatus(apiObj *CommitStatus) *commitStatus {
+	return &commitStatus{
+		s: *apiObj,
+	}
+}
+
+var _ gitprovider.CommitStatus = &commitStatus{}
+
+type commitStatus struct {
+	s CommitStatus
+}
+
+func (s *commitStatus) Get() gitprovider.CommitStatusInfo {
+	return commitStatusFromAPI(&s.s)
+}
+
+func (s *commitStatus) APIObject() interface{} {
+	return &s.s
+}
+
+func commitStatusFromAPI(apiObj *CommitStatus) gitprovider.CommitStatusInfo {
+	info := gitprovider.CommitStatusInfo{
+		State:   gitprovider.CommitStatusState(apiObj.State),
+		Context: apiObj.Context,
+	}
+	if apiObj.Description != "" {
+		info.Description = gitprovider.StringVar(apiObj.Description)
+	}
+	if apiObj.TargetURL != "" {
+		info.TargetURL = gitprovider.StringVar(apiObj.TargetURL)
+	}
+	return info
+}
+
+func commitStatusInfoToAPIObj(sha string, info *gitprovider.CommitStatusInfo) *CommitStatus {
+	apiObj := &CommitStatus{
+		SHA:     sha,
+		State:   string(info.State),
+		Context: info.Context,
+	}
+	if info.Description != nil {
+		apiObj.Description = *info.Description
+	}
+	if info.TargetURL != nil {
+		apiObj.TargetURL = *info.TargetURL
+	}
+	return apiObj
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// FileClient implements the gitprovider.FileClient interface.
+var _ gitprovider.FileClient = &FileClient{}
+
+// FileClient operates on the files of a specific repository.
+type FileClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get fetches and returns the contents of a file or multiple files in a directory from a given branch and path with possible options of FilesGetOption
+// If a file path is given, the contents of the file are returned
+// If a directory path is given, the contents of the files in the path's root are returned
+func (c *FileClient) Get(_ context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	fileOpts := gitprovider.FilesGetOptions{}
+	for _, opt := range optFns {
+		opt.ApplyFilesGetOptions(&fileOpts)
+	}
+
+	files, err := c.s.GetFiles(c.ref, path, branch, fileOpts.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found on this path[%s]", path)
+	}
+
+	return files, nil
+}
+
+// Put creates or updates the file at path on the given branch, committing content with message.
+// ErrConflict is returned if the blob SHA of the file isn't expectedSHA, which is empty for new files.
+func (c *FileClient) Put(_ context.Context, path, branch, content, message, expectedSHA string) error {
+	return c.s.PutFile(c.ref, path, branch, content, message, expectedSHA)
+}
+
+// Delete deletes the file at path on the given branch, committing with message.
+// ErrNotFound is returned if the file doesn't exist, and ErrConflict if its blob SHA isn't expectedSHA.
+func (c *FileClient) Delete(_ context.Context, path, branch, message, expectedSHA string) error {
+	return c.s.DeleteFile(c.ref, path, branch, message, expectedSHA)
+}
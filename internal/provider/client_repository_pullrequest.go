@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestClient implements the gitprovider.PullRequestClient interface.
+var _ gitprovider.PullRequestClient = &PullRequestClient{}
+
+// PullRequestClient operates on the pull requests for a specific repository.
+type PullRequestClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all pull requests in the repository, in all states unless filtered
+// with PullRequestListOptions.
+func (c *PullRequestClient) List(_ context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	apiObjs, err := c.s.ListPullRequests(c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]gitprovider.PullRequest, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		pr := newPullRequest(c.clientContext, c.ref, apiObj)
+		if o.Matches(pr.Get()) {
+			requests = append(requests, pr)
+		}
+	}
+	return requests, nil
+}
+
+// Create creates a pull request with the given specifications.
+//
+// ErrNotFound is returned if either of the branches does not exist. All options are supported,
+// they are stored on the PullRequest API object.
+func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	apiObj, err := c.s.CreatePullRequest(c.ref, &PullRequest{
+		Title:               title,
+		Description:         description,
+		SourceBranch:        branch,
+		TargetBranch:        baseBranch,
+		Labels:              o.Labels,
+		Assignees:           o.Assignees,
+		Reviewers:           o.Reviewers,
+		Draft:               o.Draft != nil && *o.Draft,
+		MaintainerCanModify: o.MaintainerCanModify != nil && *o.MaintainerCanModify,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(c.clientContext, c.ref, apiObj), nil
+}
+
+// Get retrieves an existing pull request by number
+//
+// ErrNotFound is returned if the pull request does not exist.
+func (c *PullRequestClient) Get(_ context.Context, number int) (gitprovider.PullRequest, error) {
+	apiObj, err := c.s.GetPullRequest(c.ref, number)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(c.clientContext, c.ref, apiObj), nil
+}
+
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the pull request or the new target branch does not exist.
+func (c *PullRequestClient) Update(_ context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	apiObj, err := c.s.UpdatePullRequest(c.ref, number, req)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequest(c.clientContext, c.ref, apiObj), nil
+}
+
+// Close closes the pull request with the given number without merging it.
+//
+// ErrNotFound is returned if the pull request does not exist, and ErrInvalidArgument if it is
+// already merged.
+func (c *PullRequestClient) Close(_ context.Context, number int) error {
+	return c.s.ClosePullRequest(c.ref, number)
+}
+
+// Merge merges a pull request with the given specifications.
+// Changes to the same file on both branches are reported as merge conflicts. There are no
+// required checks, hence auto-merge merges the pull request right away.
+// Deleting the source branch after merging requires destructive API calls to be enabled, otherwise
+// ErrDestructiveCallDisallowed is returned without merging the pull request.
+func (c *PullRequestClient) Merge(_ context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	// Don't allow deleting the source branch if the user didn't explicitly allow dangerous API calls.
+	if o.DeleteSourceBranch != nil && *o.DeleteSourceBranch && !c.destructiveActions {
+		return fmt.Errorf("cannot delete source branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	return c.s.MergePullRequest(c.ref, number, mergeMethod, message, o.DeleteSourceBranch != nil && *o.DeleteSourceBranch)
+}
+
+func newPullRequest(ctx *clientContext, ref gitprovider.RepositoryRef, apiObj *PullRequest) *pullrequest {
+	return &pullrequest{
+		clientContext: ctx,
+		pr:            *apiObj,
+		ref:           ref,
+	}
+}
+
+var _ gitprovider.PullRequest = &pullrequest{}
+
+type pullrequest struct {
+	*clientContext
+
+	pr  PullRequest
+	ref gitprovider.RepositoryRef
+}
+
+func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
+	return pullRequestFromAPI(&pr.pr)
+}
+
+func (pr *pullrequest) APIObject() interface{} {
+	return &pr.pr
+}
+
+// Comments gives access to the general comments of this pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return &PullRequestCommentClient{clientContext: pr.clientContext, ref: pr.ref, number: pr.pr.Number}
+}
+
+// Reviews gives access to the reviews of this pull request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return &PullRequestReviewClient{clientContext: pr.clientContext, ref: pr.ref, number: pr.pr.Number}
+}
+
+func pullRequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
+	info := gitprovider.PullRequestInfo{
+		Merged:       apiObj.Merged,
+		Number:       apiObj.Number,
+		WebURL:       apiObj.WebURL,
+		Title:        apiObj.Title,
+		Description:  apiObj.Description,
+		State:        gitprovider.PullRequestStateOpen,
+		SourceBranch: apiObj.SourceBranch,
+		TargetBranch: apiObj.TargetBranch,
+		HeadSHA:      apiObj.HeadSHA,
+		BaseSHA:      apiObj.BaseSHA,
+		Author:       apiObj.Author,
+		Draft:        apiObj.Draft,
+		CreatedAt:    apiObj.CreatedAt,
+		UpdatedAt:    apiObj.UpdatedAt,
+	}
+	switch {
+	case apiObj.Merged:
+		info.State = gitprovider.PullRequestStateMerged
+	case apiObj.Closed:
+		info.State = gitprovider.PullRequestStateClosed
+	}
+	return info
+}
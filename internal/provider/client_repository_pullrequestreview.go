@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestReviewClient implements the gitprovider.PullRequestReviewClient interface.
+var _ gitprovider.PullRequestReviewClient = &PullRequestReviewClient{}
+
+// PullRequestReviewClient gives read access to the reviews of a specific pull request.
+// Reviews are recorded by the Backend.
+type PullRequestReviewClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+}
+
+// List returns the current review of each reviewer of the pull request.
+//
+// ErrNotFound is returned if the pull request does not exist.
+func (c *PullRequestReviewClient) List(_ context.Context) ([]gitprovider.PullRequestReview, error) {
+	apiObjs, err := c.s.ListPullRequestReviews(c.ref, c.number)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]gitprovider.PullRequestReview, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		reviews = append(reviews, newPullRequestReview(apiObj))
+	}
+	return reviews, nil
+}
+
+func newPullRequestReview(apiObj *PullRequestReview) *pullRequestReview {
+	return &pullRequestReview{
+		r: *apiObj,
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+type pullRequestReview struct {
+	r PullRequestReview
+}
+
+func (r *pullRequestReview) Get() gitprovider.PullRequestReviewInfo {
+	return gitprovider.PullRequestReviewInfo{
+		Reviewer:    r.r.Reviewer,
+		State:       r.r.State,
+		SubmittedAt: r.r.SubmittedAt,
+	}
+}
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return &r.r
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all releases in the repository, in the order they were created.
+func (c *ReleaseClient) List(_ context.Context) ([]gitprovider.Release, error) {
+	apiObjs, err := c.s.ListReleases(c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]gitprovider.Release, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		releases = append(releases, newRelease(apiObj))
+	}
+	return releases, nil
+}
+
+// Get returns the release for the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Get(_ context.Context, tagName string) (gitprovider.Release, error) {
+	apiObj, err := c.s.GetRelease(c.ref, tagName)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(apiObj), nil
+}
+
+// Create creates a release with the given specifications, creating its tag from req.Target
+// if it doesn't exist yet.
+//
+// ErrAlreadyExists will be returned if a release for the tag already exists.
+func (c *ReleaseClient) Create(_ context.Context, req gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	var target string
+	if req.Target != nil {
+		target = *req.Target
+	}
+	apiObj := &Release{TagName: req.TagName, Name: req.TagName}
+	releaseInfoToAPIObj(&req, apiObj)
+	apiObj, err := c.s.CreateRelease(c.ref, apiObj, target)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(apiObj), nil
+}
+
+// Update changes the release for the tag with the given name to req. Unset fields of req
+// are left unchanged, and the tag can't be changed.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Update(_ context.Context, tagName string, req gitprovider.ReleaseInfo) (gitprovider.Release, error) {
+	apiObj, err := c.s.GetRelease(c.ref, tagName)
+	if err != nil {
+		return nil, err
+	}
+	releaseInfoToAPIObj(&req, apiObj)
+	apiObj, err = c.s.UpdateRelease(c.ref, apiObj)
+	if err != nil {
+		return nil, err
+	}
+	return newRelease(apiObj), nil
+}
+
+// Delete deletes the release for the tag with the given name. The tag itself is kept.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ReleaseClient) Delete(_ context.Context, tagName string) error {
+	return c.s.DeleteRelease(c.ref, tagName)
+}
+
+// UploadAsset attaches a file with the given name and content to the release for the
+// tag with the given name.
+//
+// ErrNotFound is returned if the release does not exist.
+func (c *ReleaseClient) UploadAsset(_ context.Context, tagName, name string, content []byte) (*gitprovider.ReleaseAsset, error) {
+	apiObj, err := c.s.UploadReleaseAsset(c.ref, tagName, name, content)
+	if err != nil {
+		return nil, err
+	}
+	asset := releaseAssetFromAPI(apiObj)
+	return &asset, nil
+}
+
+func newRelease(apiObj *Release) *releaseType {
+	return &releaseType{
+		r: *apiObj,
+	}
+}
+
+var _ gitprovider.Release = &releaseType{}
+
+type releaseType struct {
+	r Release
+}
+
+func (r *releaseType) Get() gitprovider.ReleaseInfo {
+	return releaseFromAPI(&r.r)
+}
+
+func (r *releaseType) APIObject() interface{} {
+	return &r.r
+}
+
+func releaseFromAPI(apiObj *Release) gitprovider.ReleaseInfo {
+	info := gitprovider.ReleaseInfo{
+		TagName:    apiObj.TagName,
+		Name:       gitprovider.StringVar(apiObj.Name),
+		Notes:      gitprovider.StringVar(apiObj.Notes),
+		Draft:      gitprovider.BoolVar(apiObj.Draft),
+		Prerelease: gitprovider.BoolVar(apiObj.Prerelease),
+	}
+	for i := range apiObj.Assets {
+		info.Assets = append(info.Assets, releaseAssetFromAPI(&apiObj.Assets[i]))
+	}
+	return info
+}
+
+func releaseAssetFromAPI(apiObj *ReleaseAsset) gitprovider.ReleaseAsset {
+	return gitprovider.ReleaseAsset{
+		Name: apiObj.Name,
+		URL:  apiObj.URL,
+		Size: apiObj.Size,
+	}
+}
+
+func releaseInfoToAPIObj(info *gitprovider.ReleaseInfo, apiObj *Release) {
+	if info.Name != nil {
+		apiObj.Name = *info.Name
+	}
+	if info.Notes != nil {
+		apiObj.Notes = *info.Notes
+	}
+	if info.Draft != nil {
+		apiObj.Draft = *info.Draft
+	}
+	if info.Prerelease != nil {
+		apiObj.Prerelease = *info.Prerelease
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all tags in the repository, sorted by name.
+func (c *TagClient) List(_ context.Context) ([]gitprovider.Tag, error) {
+	apiObjs, err := c.s.ListTags(c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gitprovider.Tag, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		tags = append(tags, newTag(apiObj))
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Get(_ context.Context, name string) (gitprovider.Tag, error) {
+	apiObj, err := c.s.GetTag(c.ref, name)
+	if err != nil {
+		return nil, err
+	}
+	return newTag(apiObj), nil
+}
+
+// Create creates a tag with the given specifications. A tag with a Message is an
+// annotated tag, otherwise a lightweight tag.
+//
+// ErrAlreadyExists will be returned if the tag already exists.
+func (c *TagClient) Create(ctx context.Context, req gitprovider.TagInfo) (gitprovider.Tag, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	if err := c.s.CreateTag(c.ref, req.Name, req.Sha, req.Message); err != nil {
+		return nil, err
+	}
+	return c.Get(ctx, req.Name)
+}
+
+// Delete deletes the tag with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Delete(_ context.Context, name string) error {
+	// Don't allow deleting tags if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete tag: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	return c.s.DeleteTag(c.ref, name)
+}
+
+func newTag(apiObj *Tag) *tagType {
+	return &tagType{
+		t: *apiObj,
+	}
+}
+
+var _ gitprovider.Tag = &tagType{}
+
+type tagType struct {
+	t Tag
+}
+
+func (t *tagType) Get() gitprovider.TagInfo {
+	return tagFromAPI(&t.t)
+}
+
+func (t *tagType) APIObject() interface{} {
+	return &t.t
+}
+
+func tagFromAPI(apiObj *Tag) gitprovider.TagInfo {
+	return gitprovider.TagInfo{
+		Name:    apiObj.Name,
+		Sha:     apiObj.SHA,
+		Message: apiObj.Message,
+	}
+}
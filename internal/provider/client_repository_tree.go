@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/gitrepo"
+)
+
+// TreeClient implements the gitprovider.TreeClient interface.
+var _ gitprovider.TreeClient = &TreeClient{}
+
+// TreeClient operates on the trees in a specific repository.
+type TreeClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns a single tree using the SHA1 value for that tree, or the SHA1 value of a commit.
+//
+// ErrNotFound is returned if neither a tree nor a commit with that SHA1 value exists.
+func (c *TreeClient) Get(_ context.Context, sha string, recursive bool) (*gitprovider.TreeInfo, error) {
+	return c.s.GetTree(c.ref, sha, recursive)
+}
+
+// List files (blob) in a tree given the tree sha, only files under path are returned if set
+func (c *TreeClient) List(ctx context.Context, sha string, path string, recursive bool) ([]*gitprovider.TreeEntry, error) {
+	treeInfo, err := c.Get(ctx, sha, recursive)
+	if err != nil {
+		return nil, err
+	}
+	treeEntries := make([]*gitprovider.TreeEntry, 0)
+	for _, treeEntry := range treeInfo.Tree {
+		if treeEntry.Type == gitrepo.TreeEntryTypeBlob && strings.HasPrefix(treeEntry.Path, path) {
+			treeEntries = append(treeEntries, treeEntry)
+		}
+	}
+
+	return treeEntries, nil
+}
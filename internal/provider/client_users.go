@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UsersClient implements the gitprovider.UsersClient interface.
+var _ gitprovider.UsersClient = &UsersClient{}
+
+// UsersClient looks up user accounts.
+//
+// A Backend doesn't authenticate requests and users only exist as repository owners, hence
+// this client isn't supported.
+type UsersClient struct {
+	*clientContext
+}
+
+// GetAuthenticated returns the user the client is authenticated as.
+//
+// This is not supported by a Backend.
+func (c *UsersClient) GetAuthenticated(_ context.Context) (gitprovider.User, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Get returns the user for the given reference.
+//
+// This is not supported by a Backend.
+func (c *UsersClient) Get(_ context.Context, _ gitprovider.UserRef) (gitprovider.User, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranchProtection(c *BranchProtectionClient, apiObj *BranchProtection) *branchProtection {
+	return &branchProtection{
+		p: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	p BranchProtection
+	c *BranchProtectionClient
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	return branchProtectionFromAPI(&bp.p)
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	branchProtectionInfoToAPIObj(&info, &bp.p)
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return &bp.p
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(_ context.Context) error {
+	apiObj, err := bp.c.s.SetBranchProtection(bp.c.ref, &bp.p, false)
+	if err != nil {
+		return err
+	}
+	bp.p = *apiObj
+	return nil
+}
+
+// Delete removes the protection of the branch.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(_ context.Context) error {
+	return bp.c.s.DeleteBranchProtection(bp.c.ref, bp.p.Branch)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (bp *branchProtection) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := bp.c.s.GetBranchProtection(bp.c.ref, bp.p.Branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			apiObj, err := bp.c.s.SetBranchProtection(bp.c.ref, &bp.p, true)
+			if err != nil {
+				return true, err
+			}
+			bp.p = *apiObj
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if branchProtectionFromAPI(&bp.p).Equals(branchProtectionFromAPI(actual)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, bp.Update(ctx)
+}
+
+func branchProtectionFromAPI(apiObj *BranchProtection) gitprovider.BranchProtectionInfo {
+	info := gitprovider.BranchProtectionInfo{
+		Branch:            apiObj.Branch,
+		RequiredApprovals: gitprovider.IntVar(apiObj.RequiredApprovals),
+		AllowForcePush:    gitprovider.BoolVar(apiObj.AllowForcePush),
+		EnforceAdmins:     gitprovider.BoolVar(apiObj.EnforceAdmins),
+	}
+	if len(apiObj.RequiredStatusChecks) != 0 {
+		// Sort the status checks like BranchProtectionInfo.Default
+		info.RequiredStatusChecks = append([]string{}, apiObj.RequiredStatusChecks...)
+		sort.Strings(info.RequiredStatusChecks)
+	}
+	return info
+}
+
+func branchProtectionToAPI(info *gitprovider.BranchProtectionInfo) *BranchProtection {
+	p := &BranchProtection{}
+	branchProtectionInfoToAPIObj(info, p)
+	return p
+}
+
+func branchProtectionInfoToAPIObj(info *gitprovider.BranchProtectionInfo, apiObj *BranchProtection) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Branch = info.Branch
+	// RequiredStatusChecks is always applied, as an empty list means no required status checks
+	apiObj.RequiredStatusChecks = info.RequiredStatusChecks
+	// optional fields
+	if info.RequiredApprovals != nil {
+		apiObj.RequiredApprovals = *info.RequiredApprovals
+	}
+	if info.AllowForcePush != nil {
+		apiObj.AllowForcePush = *info.AllowForcePush
+	}
+	if info.EnforceAdmins != nil {
+		apiObj.EnforceAdmins = *info.EnforceAdmins
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTeamAccess(c *TeamAccessClient, apiObj *TeamAccess) *teamAccess {
+	return &teamAccess{
+		t: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.TeamAccess = &teamAccess{}
+
+type teamAccess struct {
+	t TeamAccess
+	c *TeamAccessClient
+}
+
+func (ta *teamAccess) Get() gitprovider.TeamAccessInfo {
+	return teamAccessFromAPI(&ta.t)
+}
+
+func (ta *teamAccess) Set(info gitprovider.TeamAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	teamAccessInfoToAPIObj(&info, &ta.t)
+	return nil
+}
+
+func (ta *teamAccess) APIObject() interface{} {
+	return &ta.t
+}
+
+func (ta *teamAccess) Repository() gitprovider.RepositoryRef {
+	return ta.c.ref
+}
+
+// Delete removes the given team from the repo's team access control list.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ta *teamAccess) Delete(_ context.Context) error {
+	return ta.c.s.DeleteTeamAccess(ta.c.ref, ta.t.Name)
+}
+
+// Update will apply the desired state in this object to the server.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ta *teamAccess) Update(_ context.Context) error {
+	apiObj, err := ta.c.s.SetTeamAccess(ta.c.ref, &ta.t, false)
+	if err != nil {
+		return err
+	}
+	ta.t = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ta *teamAccess) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := ta.c.s.GetTeamAccess(ta.c.ref, ta.t.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			apiObj, err := ta.c.s.SetTeamAccess(ta.c.ref, &ta.t, true)
+			if err != nil {
+				return true, err
+			}
+			ta.t = *apiObj
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if *actual == ta.t {
+		return false, nil
+	}
+	return true, ta.Update(ctx)
+}
+
+func teamAccessFromAPI(apiObj *TeamAccess) gitprovider.TeamAccessInfo {
+	return gitprovider.TeamAccessInfo{
+		Name:       apiObj.Name,
+		Permission: gitprovider.RepositoryPermissionVar(apiObj.Permission),
+	}
+}
+
+func teamAccessToAPI(info *gitprovider.TeamAccessInfo) *TeamAccess {
+	apiObj := &TeamAccess{}
+	teamAccessInfoToAPIObj(info, apiObj)
+	return apiObj
+}
+
+func teamAccessInfoToAPIObj(info *gitprovider.TeamAccessInfo, apiObj *TeamAccess) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Name = info.Name
+	// optional fields
+	if info.Permission != nil {
+		apiObj.Permission = *info.Permission
+	}
+}
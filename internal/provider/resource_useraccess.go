@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUserAccess(c *UserAccessClient, apiObj *UserAccess) *userAccess {
+	return &userAccess{
+		u: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	u UserAccess
+	c *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return userAccessFromAPI(&ua.u)
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	userAccessInfoToAPIObj(&info, &ua.u)
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return &ua.u
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited always returns false, access is granted directly.
+func (ua *userAccess) Invited() bool {
+	return false
+}
+
+// Delete removes the given user from the repo's user access control list.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(_ context.Context) error {
+	return ua.c.s.DeleteUserAccess(ua.c.ref, ua.u.Login)
+}
+
+// Update will apply the desired state in this object to the server.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Update(_ context.Context) error {
+	apiObj, err := ua.c.s.SetUserAccess(ua.c.ref, &ua.u, false)
+	if err != nil {
+		return err
+	}
+	ua.u = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := ua.c.s.GetUserAccess(ua.c.ref, ua.u.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			apiObj, err := ua.c.s.SetUserAccess(ua.c.ref, &ua.u, true)
+			if err != nil {
+				return true, err
+			}
+			ua.u = *apiObj
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if *actual == ua.u {
+		return false, nil
+	}
+	return true, ua.Update(ctx)
+}
+
+func userAccessFromAPI(apiObj *UserAccess) gitprovider.UserAccessInfo {
+	return gitprovider.UserAccessInfo{
+		Login:      apiObj.Login,
+		Permission: gitprovider.RepositoryPermissionVar(apiObj.Permission),
+	}
+}
+
+func userAccessToAPI(info *gitprovider.UserAccessInfo) *UserAccess {
+	apiObj := &UserAccess{}
+	userAccessInfoToAPIObj(info, apiObj)
+	return apiObj
+}
+
+func userAccessInfoToAPIObj(info *gitprovider.UserAccessInfo, apiObj *UserAccess) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Login = info.Login
+	// optional fields
+	if info.Permission != nil {
+		apiObj.Permission = *info.Permission
+	}
+}
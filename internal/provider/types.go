@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// Organization is the API object of an organization or sub-organization.
+type Organization struct {
+	Ref         gitprovider.OrganizationRef
+	Name        string
+	Description string
+}
+
+// Team is the API object of a team in an organization.
+type Team struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members,omitempty"`
+}
+
+// Repository is the API object of a repository.
+type Repository struct {
+	Ref           gitprovider.RepositoryRef
+	Description   string
+	DefaultBranch string
+	Visibility    gitprovider.RepositoryVisibility
+	CreatedAt     time.Time
+}
+
+// Branch is the API object of a branch of a repository.
+type Branch struct {
+	Name      string `json:"name"`
+	SHA       string `json:"sha"`
+	Protected bool   `json:"protected,omitempty"`
+}
+
+// BranchProtection is the API object of the protection of a branch.
+type BranchProtection struct {
+	Branch               string   `json:"branch"`
+	RequiredApprovals    int      `json:"requiredApprovals,omitempty"`
+	RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty"`
+	AllowForcePush       bool     `json:"allowForcePush,omitempty"`
+	EnforceAdmins        bool     `json:"enforceAdmins,omitempty"`
+}
+
+// Webhook is the API object of a webhook of a repository. Webhooks are only stored, no
+// payloads are delivered.
+type Webhook struct {
+	ID                    int                            `json:"id"`
+	URL                   string                         `json:"url"`
+	Secret                string                         `json:"secret,omitempty"`
+	ContentType           gitprovider.WebhookContentType `json:"contentType"`
+	Events                []gitprovider.WebhookEvent     `json:"events"`
+	InsecureSkipTLSVerify bool                           `json:"insecureSkipTLSVerify,omitempty"`
+	Active                bool                           `json:"active"`
+}
+
+// DeployKey is the API object of a deploy key of a repository.
+type DeployKey struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Key      []byte `json:"key"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+// TeamAccess is the API object of a team's access to a repository.
+type TeamAccess struct {
+	Name       string                           `json:"name"`
+	Permission gitprovider.RepositoryPermission `json:"permission"`
+}
+
+// UserAccess is the API object of a user's access to a repository.
+type UserAccess struct {
+	Login      string                           `json:"login"`
+	Permission gitprovider.RepositoryPermission `json:"permission"`
+}
+
+// PullRequest is the API object of a pull request. HeadSHA and BaseSHA are the commits of the
+// source and target branch, they follow the branches while the pull request is open.
+type PullRequest struct {
+	Number         int       `json:"number"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description,omitempty"`
+	SourceBranch   string    `json:"sourceBranch"`
+	TargetBranch   string    `json:"targetBranch"`
+	HeadSHA        string    `json:"headSHA,omitempty"`
+	BaseSHA        string    `json:"baseSHA,omitempty"`
+	Author         string    `json:"author,omitempty"`
+	Merged         bool      `json:"merged,omitempty"`
+	Closed         bool      `json:"closed,omitempty"`
+	MergeCommitSHA string    `json:"mergeCommitSHA,omitempty"`
+	WebURL         string    `json:"webURL"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+
+	Labels              []string `json:"labels,omitempty"`
+	Assignees           []string `json:"assignees,omitempty"`
+	Reviewers           []string `json:"reviewers,omitempty"`
+	Draft               bool     `json:"draft,omitempty"`
+	MaintainerCanModify bool     `json:"maintainerCanModify,omitempty"`
+}
+
+// PullRequestReview is the API object of the current review of a reviewer of a pull request.
+type PullRequestReview struct {
+	Reviewer    string                             `json:"reviewer"`
+	State       gitprovider.PullRequestReviewState `json:"state"`
+	SubmittedAt time.Time                          `json:"submittedAt"`
+}
+
+// PullRequestComment is the API object of a general comment of a pull request.
+type PullRequestComment struct {
+	ID                int64     `json:"id"`
+	PullRequestNumber int       `json:"pullRequestNumber"`
+	Body              string    `json:"body"`
+	Author            string    `json:"author,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// Tag is the API object of a tag of a repository.
+type Tag struct {
+	Name string `json:"name"`
+	SHA  string `json:"sha"`
+	// Message is only set for annotated tags.
+	Message *string `json:"message,omitempty"`
+}
+
+// Release is the API object of a release of a repository.
+type Release struct {
+	ID         int            `json:"id"`
+	TagName    string         `json:"tagName"`
+	Name       string         `json:"name,omitempty"`
+	Notes      string         `json:"notes,omitempty"`
+	Draft      bool           `json:"draft,omitempty"`
+	Prerelease bool           `json:"prerelease,omitempty"`
+	Assets     []ReleaseAsset `json:"assets,omitempty"`
+}
+
+// CommitStatus is the API object of the status of a commit.
+type CommitStatus struct {
+	SHA         string `json:"sha"`
+	State       string `json:"state"`
+	Context     string `json:"context"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"targetURL,omitempty"`
+}
+
+// ReleaseAsset is the API object of a file attached to a release.
+type ReleaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Size int    `json:"size"`
+}
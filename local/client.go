@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/provider"
+)
+
+const (
+	// ProviderID is the provider ID for the local filesystem provider.
+	ProviderID = gitprovider.ProviderID("local")
+)
+
+// NewClient creates a new gitprovider.Client instance for the directory of bare repositories
+// given by the domain, which is a file URL of an existing directory, e.g. "file:///srv/git".
+// There is no default domain, hence the WithDomain option is required. The Raw method of the
+// client returns the path of the directory holding the repositories, as a string.
+//
+// Only the WithDomain and WithDestructiveAPICalls options have an effect, as no HTTP
+// requests are made. Any authentication options are accepted, but ignored.
+func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
+	// Complete the options struct
+	opts, err := gitprovider.MakeClientOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Domain == nil {
+		return nil, fmt.Errorf("option Domain is required for the local provider: %w", gitprovider.ErrInvalidClientOptions)
+	}
+	domain := *opts.Domain
+	root, err := rootDirectory(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// By default, turn destructive actions off. But allow overrides.
+	destructiveActions := false
+	if opts.EnableDestructiveAPICalls != nil {
+		destructiveActions = *opts.EnableDestructiveAPICalls
+	}
+
+	return provider.NewClient(newStorage(root, domain), ProviderID, domain, destructiveActions, root), nil
+}
+
+// rootDirectory returns the directory the given file URL points to, which must exist.
+func rootDirectory(domain string) (string, error) {
+	u, err := url.Parse(domain)
+	if err != nil || u.Scheme != "file" || u.Host != "" || !filepath.IsAbs(filepath.FromSlash(u.Path)) {
+		return "", fmt.Errorf("domain %q is not a file URL with an absolute path: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	root := filepath.Clean(filepath.FromSlash(u.Path))
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", fmt.Errorf("domain %q: %v: %w", domain, err, gitprovider.ErrInvalidClientOptions)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("domain %q is not a directory: %w", domain, gitprovider.ErrInvalidClientOptions)
+	}
+	return root, nil
+}
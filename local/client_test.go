@@ -0,0 +1,709 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+func setup(t *testing.T, optFns ...gitprovider.ClientOption) (string, gitprovider.Client) {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "org", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeOrganizationMetadata(t, filepath.Join(root, "org"), `{"description": "An organization", "teams": [{"name": "team", "members": ["alice"]}]}`)
+	c, err := NewClient(append([]gitprovider.ClientOption{gitprovider.WithDomain(domain(root))}, optFns...)...)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return root, c
+}
+
+func domain(root string) string {
+	return "file://" + filepath.ToSlash(root)
+}
+
+func writeOrganizationMetadata(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, organizationMetadataFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func orgRepoRef(c gitprovider.Client, name string) gitprovider.OrgRepositoryRef {
+	return gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "org"},
+		RepositoryName:  name,
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		opts    []gitprovider.ClientOption
+		wantErr bool
+	}{
+		{
+			name: "directory",
+			opts: []gitprovider.ClientOption{gitprovider.WithDomain(domain(root))},
+		},
+		{
+			name:    "no domain",
+			wantErr: true,
+		},
+		{
+			name:    "https domain",
+			opts:    []gitprovider.ClientOption{gitprovider.WithDomain("https://example.com")},
+			wantErr: true,
+		},
+		{
+			name:    "relative path",
+			opts:    []gitprovider.ClientOption{gitprovider.WithDomain("file://git")},
+			wantErr: true,
+		},
+		{
+			name:    "missing directory",
+			opts:    []gitprovider.ClientOption{gitprovider.WithDomain(domain(filepath.Join(root, "missing")))},
+			wantErr: true,
+		},
+		{
+			name:    "file",
+			opts:    []gitprovider.ClientOption{gitprovider.WithDomain(domain(file))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.opts...)
+			if tt.wantErr {
+				if !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+					t.Errorf("NewClient() error = %v, want %v", err, gitprovider.ErrInvalidClientOptions)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			if got := c.ProviderID(); got != ProviderID {
+				t.Errorf("ProviderID() = %q, want %q", got, ProviderID)
+			}
+			if got := c.Raw(); got != root {
+				t.Errorf("Raw() = %v, want %q", got, root)
+			}
+		})
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	orgRef := gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "org"}
+
+	if _, err := c.OrgRepositories().Create(ctx, orgRepoRef(c, "repo"), gitprovider.RepositoryInfo{}); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	orgs, err := c.Organizations().List(ctx)
+	if err != nil || len(orgs) != 1 {
+		t.Fatalf("Organizations().List() = %v, %v, want one organization", orgs, err)
+	}
+	want := gitprovider.OrganizationInfo{Name: gitprovider.StringVar("org"), Description: gitprovider.StringVar("An organization")}
+	if diff := cmp.Diff(want, orgs[0].Get()); diff != "" {
+		t.Errorf("Organizations().List() mismatch (-want +got):\n%s", diff)
+	}
+
+	children, err := c.Organizations().Children(ctx, orgRef)
+	if err != nil || len(children) != 1 {
+		t.Fatalf("Organizations().Children() = %v, %v, want the sub-organization", children, err)
+	}
+	wantRef := gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "org", SubOrganizations: []string{"sub"}}
+	if diff := cmp.Diff(wantRef, children[0].Organization(), cmp.AllowUnexported(gitprovider.OrganizationRef{})); diff != "" {
+		t.Errorf("Organizations().Children() mismatch (-want +got):\n%s", diff)
+	}
+
+	orgRef.Organization = "missing"
+	if _, err := c.Organizations().Get(ctx, orgRef); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Organizations().Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	orgRef.Organization = ".."
+	if _, err := c.Organizations().Get(ctx, orgRef); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Organizations().Get() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+
+	teams, err := orgs[0].Teams().List(ctx)
+	if err != nil || len(teams) != 1 {
+		t.Fatalf("Teams().List() = %v, %v, want one team", teams, err)
+	}
+	if diff := cmp.Diff(gitprovider.TeamInfo{Name: "team", Members: []string{"alice"}}, teams[0].Get()); diff != "" {
+		t.Errorf("Teams().List() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrganizationAndTeamWrites(t *testing.T) {
+	root, c := setup(t, gitprovider.WithDestructiveAPICalls(true))
+	ctx := context.Background()
+	newRef := gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "new"}
+
+	if _, err := c.Organizations().Create(ctx, gitprovider.OrganizationRef{Domain: c.SupportedDomain(), Organization: "org"}, gitprovider.OrganizationInfo{}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	req := gitprovider.OrganizationInfo{Name: gitprovider.StringVar("New"), Description: gitprovider.StringVar("desc")}
+	org, actionTaken, err := c.Organizations().Reconcile(ctx, newRef, req)
+	if err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want the organization to be created", actionTaken, err)
+	}
+	if diff := cmp.Diff(req, org.Get()); diff != "" {
+		t.Errorf("Reconcile() mismatch (-want +got):\n%s", diff)
+	}
+	if _, actionTaken, err := c.Organizations().Reconcile(ctx, newRef, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	subRef := newRef
+	subRef.SubOrganizations = []string{"sub"}
+	if _, err := c.Organizations().Create(ctx, subRef, gitprovider.OrganizationInfo{}); err != nil {
+		t.Fatalf("Create() of sub-organization returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "new", "sub")); err != nil {
+		t.Errorf("expected sub-organization directory: %v", err)
+	}
+
+	team, err := org.Teams().Create(ctx, gitprovider.TeamInfo{Name: "team", Members: []string{"alice"}})
+	if err != nil {
+		t.Fatalf("Teams().Create() returned error: %v", err)
+	}
+	if err := team.AddMember(ctx, "bob"); err != nil {
+		t.Fatalf("AddMember() returned error: %v", err)
+	}
+	if err := team.RemoveMember(ctx, "alice"); err != nil {
+		t.Fatalf("RemoveMember() returned error: %v", err)
+	}
+	if _, actionTaken, err := org.Teams().Reconcile(ctx, gitprovider.TeamInfo{Name: "team", Members: []string{"bob"}}); err != nil || actionTaken {
+		t.Errorf("Teams().Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	// Teams are stored in the metadata file, next to the organization name and description
+	data, err := os.ReadFile(filepath.Join(root, "new", organizationMetadataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{
+  "name": "New",
+  "description": "desc",
+  "teams": [
+    {
+      "name": "team",
+      "members": [
+        "bob"
+      ]
+    }
+  ]
+}
+`; string(data) != want {
+		t.Errorf("metadata = %s, want %s", data, want)
+	}
+	if err := team.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := org.Teams().Get(ctx, "team"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Teams().Get() after Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	if err := org.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "new")); !os.IsNotExist(err) {
+		t.Errorf("expected organization directory to be removed, got %v", err)
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	root, c := setup(t)
+	ctx := context.Background()
+	ref := orgRepoRef(c, "repo")
+
+	missing := ref
+	missing.Organization = "missing"
+	if _, err := c.OrgRepositories().Create(ctx, missing, gitprovider.RepositoryInfo{}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Create() in missing organization error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	req := gitprovider.RepositoryInfo{Description: gitprovider.StringVar("desc")}
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req); err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want repository to be created", actionTaken, err)
+	}
+	if _, err := git.PlainOpen(filepath.Join(root, "org", "repo.git")); err != nil {
+		t.Errorf("failed to open bare repository: %v", err)
+	}
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	req.DefaultBranch = gitprovider.StringVar("develop")
+	if _, actionTaken, err := c.OrgRepositories().Reconcile(ctx, ref, req); err != nil || !actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want repository to be updated", actionTaken, err)
+	}
+
+	// The state is read from disk by new clients
+	other, err := NewClient(gitprovider.WithDomain(c.SupportedDomain()))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	repos, err := other.OrgRepositories().List(ctx, ref.OrganizationRef)
+	if err != nil || len(repos) != 1 {
+		t.Fatalf("OrgRepositories().List() = %v, %v, want one repository", repos, err)
+	}
+	want := gitprovider.RepositoryInfo{
+		Description:   gitprovider.StringVar("desc"),
+		DefaultBranch: gitprovider.StringVar("develop"),
+		Visibility:    gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}
+	if diff := cmp.Diff(want, repos[0].Get()); diff != "" {
+		t.Errorf("OrgRepositories().List() mismatch (-want +got):\n%s", diff)
+	}
+
+	userRef := gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: c.SupportedDomain(), UserLogin: "user"},
+		RepositoryName: "repo",
+	}
+	if repos, err := c.UserRepositories().List(ctx, userRef.UserRef); err != nil || len(repos) != 0 {
+		t.Errorf("UserRepositories().List() = %v, %v, want no repositories", repos, err)
+	}
+	if _, err := c.UserRepositories().Create(ctx, userRef, gitprovider.RepositoryInfo{}); err != nil {
+		t.Fatalf("UserRepositories().Create returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "user", "repo.git")); err != nil {
+		t.Errorf("user repository not created: %v", err)
+	}
+
+	repo, err := c.UserRepositories().Get(ctx, userRef)
+	if err != nil {
+		t.Fatalf("UserRepositories().Get returned error: %v", err)
+	}
+	if err := repo.Delete(ctx); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+}
+
+func TestRepositoryMetadata(t *testing.T) {
+	root, c := setup(t, gitprovider.WithDestructiveAPICalls(true))
+	ctx := context.Background()
+	repo, err := c.OrgRepositories().Create(ctx, orgRepoRef(c, "repo"), gitprovider.RepositoryInfo{})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if _, err := repo.DeployKeys().Create(ctx, gitprovider.DeployKeyInfo{Name: "key", Key: []byte("ssh-ed25519 AAAA")}); err != nil {
+		t.Fatalf("DeployKeys().Create returned error: %v", err)
+	}
+	if _, err := repo.DeployKeys().Create(ctx, gitprovider.DeployKeyInfo{Name: "key", Key: []byte("ssh-ed25519 BBBB")}); !errors.Is(err, gitprovider.ErrAlreadyExists) {
+		t.Errorf("DeployKeys().Create() error = %v, want %v", err, gitprovider.ErrAlreadyExists)
+	}
+	if _, err := repo.TeamAccess().Create(ctx, gitprovider.TeamAccessInfo{Name: "missing"}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("TeamAccess().Create() for missing team error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if _, actionTaken, err := repo.TeamAccess().Reconcile(ctx, gitprovider.TeamAccessInfo{Name: "team"}); err != nil || !actionTaken {
+		t.Fatalf("TeamAccess().Reconcile() = %v, %v, want team access to be created", actionTaken, err)
+	}
+	push := gitprovider.RepositoryPermissionVar(gitprovider.RepositoryPermissionPush)
+	if _, actionTaken, err := repo.UserAccess().Reconcile(ctx, gitprovider.UserAccessInfo{Login: "alice", Permission: push}); err != nil || !actionTaken {
+		t.Fatalf("UserAccess().Reconcile() = %v, %v, want user access to be created", actionTaken, err)
+	}
+	if _, actionTaken, err := repo.UserAccess().Reconcile(ctx, gitprovider.UserAccessInfo{Login: "alice", Permission: push}); err != nil || actionTaken {
+		t.Errorf("UserAccess().Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "org", "repo.git", repositoryMetadataFile))
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	meta := repositoryMetadata{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("failed to decode metadata: %v", err)
+	}
+	wantKeys := []DeployKey{{ID: 1, Name: "key", Key: []byte("ssh-ed25519 AAAA"), ReadOnly: true}}
+	if diff := cmp.Diff(wantKeys, meta.DeployKeys); diff != "" {
+		t.Errorf("deploy keys mismatch (-want +got):\n%s", diff)
+	}
+	wantAccess := []TeamAccess{{Name: "team", Permission: gitprovider.RepositoryPermissionPull}}
+	if diff := cmp.Diff(wantAccess, meta.TeamAccess); diff != "" {
+		t.Errorf("team access mismatch (-want +got):\n%s", diff)
+	}
+	wantUsers := []UserAccess{{Login: "alice", Permission: gitprovider.RepositoryPermissionPush}}
+	if diff := cmp.Diff(wantUsers, meta.UserAccess); diff != "" {
+		t.Errorf("user access mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := repo.Delete(ctx); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "org", "repo.git")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("repository directory still exists: %v", err)
+	}
+}
+
+func TestCommitsAndPullRequests(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	ref := orgRepoRef(c, "repo")
+	repo, err := c.OrgRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{},
+		&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	commits, err := repo.Commits().ListPage(ctx, "main", 1, 1)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("Commits().ListPage() = %v, %v, want the initial commit", commits, err)
+	}
+	if err := repo.Branches().Create(ctx, "feature", commits[0].Get().Sha); err != nil {
+		t.Fatalf("Branches().Create returned error: %v", err)
+	}
+	if _, err := repo.Commits().Create(ctx, "feature", "Add manifests", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("deploy/app.yaml"), Content: gitprovider.StringVar("kind: Deployment\n")},
+	}); err != nil {
+		t.Fatalf("Commits().Create returned error: %v", err)
+	}
+
+	pr, err := repo.PullRequests().Create(ctx, "Add manifests", "feature", "main", "")
+	if err != nil {
+		t.Fatalf("PullRequests().Create returned error: %v", err)
+	}
+	if info := pr.Get(); info.State != gitprovider.PullRequestStateOpen || info.HeadSHA == "" || info.BaseSHA != commits[0].Get().Sha {
+		t.Errorf("PullRequests().Create() = %+v, want open pull request with head and base commits", info)
+	}
+	if pr, err = repo.PullRequests().Update(ctx, pr.Get().Number, gitprovider.PullRequestUpdateInfo{
+		Description: gitprovider.StringVar("Deploy the app"),
+	}); err != nil || pr.Get().Description != "Deploy the app" || pr.Get().Title != "Add manifests" {
+		t.Errorf("PullRequests().Update() = %v, %v, want updated description", pr, err)
+	}
+	comment, err := pr.Comments().Create(ctx, "LGTM")
+	if err != nil {
+		t.Fatalf("Comments().Create returned error: %v", err)
+	}
+	if _, err := pr.Comments().Update(ctx, comment.Get().ID, "LGTM!"); err != nil {
+		t.Fatalf("Comments().Update returned error: %v", err)
+	}
+	if comments, err := pr.Comments().List(ctx); err != nil || len(comments) != 1 || comments[0].Get().Body != "LGTM!" {
+		t.Errorf("Comments().List() = %v, %v, want the updated comment", comments, err)
+	}
+	if err := pr.Comments().Delete(ctx, comment.Get().ID); err != nil {
+		t.Fatalf("Comments().Delete returned error: %v", err)
+	}
+	if err := pr.Comments().Delete(ctx, comment.Get().ID); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Comments().Delete() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+	if reviews, err := pr.Reviews().List(ctx); err != nil || len(reviews) != 0 {
+		t.Errorf("Reviews().List() = %v, %v, want no reviews", reviews, err)
+	}
+	if err := repo.PullRequests().Merge(ctx, pr.Get().Number, gitprovider.MergeMethodSquash, ""); err != nil {
+		t.Fatalf("PullRequests().Merge returned error: %v", err)
+	}
+	if pr, err = repo.PullRequests().Get(ctx, pr.Get().Number); err != nil || !pr.Get().Merged {
+		t.Errorf("PullRequests().Get() = %v, %v, want merged pull request", pr, err)
+	}
+	if err := repo.PullRequests().Close(ctx, pr.Get().Number); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("PullRequests().Close() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+	}
+	prs, err := repo.PullRequests().List(ctx, &gitprovider.PullRequestListOptions{State: gitprovider.PullRequestStateVar(gitprovider.PullRequestStateOpen)})
+	if err != nil || len(prs) != 0 {
+		t.Errorf("PullRequests().List() = %v, %v, want no open pull requests", prs, err)
+	}
+
+	files, err := repo.Files().Get(ctx, "deploy", "main")
+	if err != nil || len(files) != 1 || *files[0].Content != "kind: Deployment\n" {
+		t.Errorf("Files().Get() = %v, %v, want deploy/app.yaml", files, err)
+	}
+
+	// The clone URL is the path of the bare repository
+	clone, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{URL: ref.GetCloneURL(gitprovider.TransportTypeHTTPS)})
+	if err != nil {
+		t.Fatalf("failed to clone repository: %v", err)
+	}
+	head, err := clone.Head()
+	if err != nil {
+		t.Fatalf("Head returned error: %v", err)
+	}
+	commit, err := clone.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject returned error: %v", err)
+	}
+	if commit.Message != "Add manifests (#1)" {
+		t.Errorf("HEAD commit message = %q, want %q", commit.Message, "Add manifests (#1)")
+	}
+}
+
+func TestBranches(t *testing.T) {
+	_, c := setup(t, gitprovider.WithDestructiveAPICalls(true))
+	ctx := context.Background()
+	ref := orgRepoRef(c, "repo")
+	repo, err := c.OrgRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{},
+		&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	main, err := repo.Branches().Get(ctx, "main")
+	if err != nil {
+		t.Fatalf("Branches().Get returned error: %v", err)
+	}
+	if err := repo.Branches().Create(ctx, "feature", main.Get().Sha); err != nil {
+		t.Fatalf("Branches().Create returned error: %v", err)
+	}
+	if branches, err := repo.Branches().List(ctx); err != nil || len(branches) != 2 {
+		t.Errorf("Branches().List() = %v, %v, want two branches", branches, err)
+	}
+
+	// The default branch is HEAD of the bare repository
+	if err := repo.Branches().SetDefault(ctx, "feature"); err != nil {
+		t.Fatalf("Branches().SetDefault returned error: %v", err)
+	}
+	updated, err := c.OrgRepositories().Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got := *updated.Get().DefaultBranch; got != "feature" {
+		t.Errorf("DefaultBranch = %q, want %q", got, "feature")
+	}
+
+	if err := repo.Branches().Delete(ctx, "main"); err != nil {
+		t.Fatalf("Branches().Delete returned error: %v", err)
+	}
+	if _, err := repo.Branches().Get(ctx, "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Branches().Get() after Delete error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestCommitStatuses(t *testing.T) {
+	_, c := setup(t)
+	ctx := context.Background()
+	ref := orgRepoRef(c, "repo")
+	repo, err := c.OrgRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{},
+		&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	main, err := repo.Branches().Get(ctx, "main")
+	if err != nil {
+		t.Fatalf("Branches().Get returned error: %v", err)
+	}
+	sha := main.Get().Sha
+
+	// The status of a context is replaced by the next one
+	for _, state := range []gitprovider.CommitStatusState{gitprovider.CommitStatusStatePending, gitprovider.CommitStatusStateSuccess} {
+		if _, err := repo.Statuses().Create(ctx, sha, gitprovider.CommitStatusInfo{State: state, Context: "ci/build"}); err != nil {
+			t.Fatalf("Statuses().Create returned error: %v", err)
+		}
+	}
+	statuses, err := repo.Statuses().List(ctx, sha)
+	if err != nil {
+		t.Fatalf("Statuses().List returned error: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("Statuses().List() returned %d statuses, want 1", len(statuses))
+	}
+	want := gitprovider.CommitStatusInfo{State: gitprovider.CommitStatusStateSuccess, Context: "ci/build"}
+	if diff := cmp.Diff(want, statuses[0].Get()); diff != "" {
+		t.Errorf("Statuses().List() mismatch (-want +got):\n%s", diff)
+	}
+
+	missing := "0123456789012345678901234567890123456789"
+	if _, err := repo.Statuses().List(ctx, missing); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Statuses().List() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestTagsAndReleases(t *testing.T) {
+	_, c := setup(t, gitprovider.WithDestructiveAPICalls(true))
+	ctx := context.Background()
+	ref := orgRepoRef(c, "repo")
+	repo, err := c.OrgRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{},
+		&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	main, err := repo.Branches().Get(ctx, "main")
+	if err != nil {
+		t.Fatalf("Branches().Get returned error: %v", err)
+	}
+
+	if _, err := repo.Tags().Create(ctx, gitprovider.TagInfo{
+		Name:    "v0.1.0",
+		Sha:     main.Get().Sha,
+		Message: gitprovider.StringVar("Release v0.1.0"),
+	}); err != nil {
+		t.Fatalf("Tags().Create returned error: %v", err)
+	}
+	tag, err := repo.Tags().Get(ctx, "v0.1.0")
+	if err != nil {
+		t.Fatalf("Tags().Get returned error: %v", err)
+	}
+	want := gitprovider.TagInfo{Name: "v0.1.0", Sha: main.Get().Sha, Message: gitprovider.StringVar("Release v0.1.0")}
+	if diff := cmp.Diff(want, tag.Get()); diff != "" {
+		t.Errorf("Tags().Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Release assets are stored next to the bare repository
+	if _, err := repo.Releases().Create(ctx, gitprovider.ReleaseInfo{TagName: "v0.1.0", Notes: gitprovider.StringVar("Fixes")}); err != nil {
+		t.Fatalf("Releases().Create returned error: %v", err)
+	}
+	asset, err := repo.Releases().UploadAsset(ctx, "v0.1.0", "manifests.yaml", []byte("kind: List\n"))
+	if err != nil {
+		t.Fatalf("Releases().UploadAsset returned error: %v", err)
+	}
+	rel, err := repo.Releases().Get(ctx, "v0.1.0")
+	if err != nil {
+		t.Fatalf("Releases().Get returned error: %v", err)
+	}
+	if diff := cmp.Diff([]gitprovider.ReleaseAsset{*asset}, rel.Get().Assets); diff != "" {
+		t.Errorf("Releases().Get().Assets mismatch (-want +got):\n%s", diff)
+	}
+	if asset.Size != len("kind: List\n") {
+		t.Errorf("ReleaseAsset.Size = %d, want %d", asset.Size, len("kind: List\n"))
+	}
+
+	if err := repo.Releases().Delete(ctx, "v0.1.0"); err != nil {
+		t.Fatalf("Releases().Delete returned error: %v", err)
+	}
+	if err := repo.Tags().Delete(ctx, "v0.1.0"); err != nil {
+		t.Fatalf("Tags().Delete returned error: %v", err)
+	}
+	if tags, err := repo.Tags().List(ctx); err != nil || len(tags) != 0 {
+		t.Errorf("Tags().List() = %v, %v, want no tags", tags, err)
+	}
+}
+
+func TestBranchProtections(t *testing.T) {
+	root, c := setup(t)
+	ctx := context.Background()
+	repo, err := c.OrgRepositories().Create(ctx, orgRepoRef(c, "repo"), gitprovider.RepositoryInfo{},
+		&gitprovider.RepositoryCreateOptions{AutoInit: gitprovider.BoolVar(true)})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	req := gitprovider.BranchProtectionInfo{Branch: "main", RequiredStatusChecks: []string{"ci/build"}}
+	if _, actionTaken, err := repo.BranchProtections().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want branch protection to be created", actionTaken, err)
+	}
+	if _, actionTaken, err := repo.BranchProtections().Reconcile(ctx, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+	if branches, err := repo.Branches().List(ctx); err != nil || len(branches) != 1 || !branches[0].Get().Protected {
+		t.Errorf("Branches().List() = %v, %v, want protected main branch", branches, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "org", "repo.git", repositoryMetadataFile))
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	meta := repositoryMetadata{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("failed to decode metadata: %v", err)
+	}
+	want := []BranchProtection{{Branch: "main", RequiredStatusChecks: []string{"ci/build"}, EnforceAdmins: true}}
+	if diff := cmp.Diff(want, meta.BranchProtections); diff != "" {
+		t.Errorf("branch protections mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := repo.BranchProtections().Delete(ctx, "main"); err != nil {
+		t.Fatalf("BranchProtections().Delete returned error: %v", err)
+	}
+	if protections, err := repo.BranchProtections().List(ctx); err != nil || len(protections) != 0 {
+		t.Errorf("BranchProtections().List() = %v, %v, want no protections", protections, err)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	root, c := setup(t)
+	ctx := context.Background()
+	repo, err := c.OrgRepositories().Create(ctx, orgRepoRef(c, "repo"), gitprovider.RepositoryInfo{})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	req := gitprovider.WebhookInfo{URL: "https://flux.example.com/hook", Secret: gitprovider.StringVar("s3cr3t")}
+	if _, actionTaken, err := repo.Webhooks().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want webhook to be created", actionTaken, err)
+	}
+	req.Events = []gitprovider.WebhookEvent{gitprovider.WebhookEventRelease}
+	if _, actionTaken, err := repo.Webhooks().Reconcile(ctx, req); err != nil || !actionTaken {
+		t.Fatalf("Reconcile() = %v, %v, want webhook to be updated", actionTaken, err)
+	}
+	if _, actionTaken, err := repo.Webhooks().Reconcile(ctx, req); err != nil || actionTaken {
+		t.Errorf("Reconcile() = %v, %v, want no action", actionTaken, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "org", "repo.git", repositoryMetadataFile))
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	meta := repositoryMetadata{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("failed to decode metadata: %v", err)
+	}
+	want := []Webhook{{
+		ID:          1,
+		URL:         "https://flux.example.com/hook",
+		Secret:      "s3cr3t",
+		ContentType: gitprovider.WebhookContentTypeJSON,
+		Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventRelease},
+		Active:      true,
+	}}
+	if diff := cmp.Diff(want, meta.Webhooks); diff != "" {
+		t.Errorf("webhooks mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := repo.Webhooks().Delete(ctx, req.URL); err != nil {
+		t.Fatalf("Webhooks().Delete returned error: %v", err)
+	}
+	if _, err := repo.Webhooks().Get(ctx, req.URL); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Webhooks().Get() after Delete error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "org"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewClient(append([]gitprovider.ClientOption{gitprovider.WithDomain(domain(root))}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: gitprovider.OrganizationRef{Domain: domain(root), Organization: "org"},
+	})
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package local implements the gitprovider.Client interface for a directory of bare repositories,
+// e.g. for air-gapped CI and offline demos.
+//
+// The directory is addressed through a file URL domain, e.g. "file:///srv/git". Organizations,
+// sub-organizations and users are directories, which share the same namespace, and repositories
+// are bare repositories named "<org>/[<sub-orgs...>/]<repo>.git". Organization directories are
+// created through the OrganizationsClient or outside of this package, while user directories are
+// created on demand.
+//
+// State which Git can't store lives in JSON metadata files: the name, description and teams of an
+// organization in ".gitprovider.json" in its directory, and the description, visibility, deploy
+// keys, team access, pull requests and pull request comments of a repository in
+// "gitprovider.json" in the bare repository.
+// Teams are managed through the TeamsClient, or by editing the organization metadata file, e.g.
+//
+//	{"description": "Platform team", "teams": [{"name": "admins", "members": ["alice"]}]}
+//
+// Clone URLs of repositories are their paths on the local filesystem. Access to the directory is
+// serialized within a client, but not across processes.
+package local
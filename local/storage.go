@@ -0,0 +1,1485 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/gitrepo"
+	"github.com/fluxcd/go-git-providers/internal/provider"
+)
+
+const (
+	// organizationMetadataFile is the name of the metadata file in an organization directory.
+	organizationMetadataFile = ".gitprovider.json"
+	// repositoryMetadataFile is the name of the metadata file in a bare repository.
+	repositoryMetadataFile = "gitprovider.json"
+	// releaseAssetsDir is the directory in a bare repository holding the release assets.
+	releaseAssetsDir = "releases"
+	// repositorySuffix is the suffix of the directories of bare repositories.
+	repositorySuffix = ".git"
+)
+
+// commitAuthor is the author and committer of all commits created by the local provider.
+var commitAuthor = object.Signature{
+	Name:  "Local Provider",
+	Email: "noreply@localhost",
+}
+
+// storage implements the provider.Backend interface.
+var _ provider.Backend = &storage{}
+
+// storage reads and writes the organizations and repositories below root. Access from a
+// single process is serialized, concurrent access from multiple processes is not.
+type storage struct {
+	mu     sync.Mutex
+	root   string
+	domain string
+}
+
+func newStorage(root, domain string) *storage {
+	return &storage{
+		root:   root,
+		domain: domain,
+	}
+}
+
+//
+// Organizations and teams
+//
+
+func (s *storage) GetOrganization(ref gitprovider.OrganizationRef) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	return organizationFromDisk(ref, dir)
+}
+
+// ListOrganizations returns the top-level organizations, sorted by name. All directories below
+// root belong to the domain of the storage, hence the given domain isn't used.
+func (s *storage) ListOrganizations(_ string) ([]*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.organizationsIn(s.root, func(name string) gitprovider.OrganizationRef {
+		return gitprovider.OrganizationRef{Domain: s.domain, Organization: name}
+	})
+}
+
+// ListChildOrganizations returns the immediate sub-organizations of ref, sorted by name.
+func (s *storage) ListChildOrganizations(ref gitprovider.OrganizationRef) ([]*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.organizationsIn(dir, func(name string) gitprovider.OrganizationRef {
+		return gitprovider.OrganizationRef{
+			Domain:           ref.Domain,
+			Organization:     ref.Organization,
+			SubOrganizations: append(append([]string{}, ref.SubOrganizations...), name),
+		}
+	})
+}
+
+// CreateOrganization creates the directory of the organization described by req. The parent
+// of a sub-organization needs to exist.
+func (s *storage) CreateOrganization(req *Organization) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.identityDir(req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("organization %q: %w", req.Ref.String(), gitprovider.ErrAlreadyExists)
+	}
+	if len(req.Ref.SubOrganizations) > 0 {
+		if _, err := s.organization(parentOrganization(req.Ref)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := updateOrganizationMetadata(dir, func(meta *organizationMetadata) error {
+		setOrganizationMetadata(meta, req, dir)
+		return nil
+	}); err != nil {
+		// Don't leave a half-initialized organization behind
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return organizationFromDisk(req.Ref, dir)
+}
+
+// UpdateOrganization updates the name and description of the organization.
+func (s *storage) UpdateOrganization(req *Organization) (*Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.organization(req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateOrganizationMetadata(dir, func(meta *organizationMetadata) error {
+		setOrganizationMetadata(meta, req, dir)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return organizationFromDisk(req.Ref, dir)
+}
+
+// DeleteOrganization removes the organization directory, including its sub-organizations
+// and repositories.
+func (s *storage) DeleteOrganization(ref gitprovider.OrganizationRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.organization(ref)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// setOrganizationMetadata copies the name and description of apiObj to meta. The name is
+// only stored if it differs from the directory name.
+func setOrganizationMetadata(meta *organizationMetadata, apiObj *Organization, dir string) {
+	meta.Name = apiObj.Name
+	if meta.Name == filepath.Base(dir) {
+		meta.Name = ""
+	}
+	meta.Description = apiObj.Description
+}
+
+func (s *storage) GetTeam(ref gitprovider.OrganizationRef, name string) (*Team, error) {
+	teams, err := s.ListTeams(ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		if team.Name == name {
+			return team, nil
+		}
+	}
+	return nil, fmt.Errorf("team %q: %w", name, gitprovider.ErrNotFound)
+}
+
+// ListTeams returns the teams of the given organization, sorted by name.
+func (s *storage) ListTeams(ref gitprovider.OrganizationRef) ([]*Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.organization(ref)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := readOrganizationMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Team, 0, len(meta.Teams))
+	for i := range meta.Teams {
+		apiObjs = append(apiObjs, &meta.Teams[i])
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+// CreateTeam adds req to the teams of the organization.
+func (s *storage) CreateTeam(ref gitprovider.OrganizationRef, req *Team) (*Team, error) {
+	var apiObj Team
+	err := s.updateOrganizationMetadata(ref, func(meta *organizationMetadata) error {
+		if findTeam(meta, req.Name) >= 0 {
+			return fmt.Errorf("team %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+		}
+		apiObj = Team{Name: req.Name, Members: append([]string{}, req.Members...)}
+		meta.Teams = append(meta.Teams, apiObj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiObj, nil
+}
+
+// UpdateTeam replaces the members of the team with the same name.
+func (s *storage) UpdateTeam(ref gitprovider.OrganizationRef, req *Team) (*Team, error) {
+	return s.updateTeamMembers(ref, req.Name, func(members []string) ([]string, error) {
+		return append([]string{}, req.Members...), nil
+	})
+}
+
+// AddTeamMember adds login to the members of the team, unless it's a member already.
+func (s *storage) AddTeamMember(ref gitprovider.OrganizationRef, name, login string) (*Team, error) {
+	return s.updateTeamMembers(ref, name, func(members []string) ([]string, error) {
+		for _, member := range members {
+			if member == login {
+				return members, nil
+			}
+		}
+		return append(members, login), nil
+	})
+}
+
+func (s *storage) RemoveTeamMember(ref gitprovider.OrganizationRef, name, login string) (*Team, error) {
+	return s.updateTeamMembers(ref, name, func(members []string) ([]string, error) {
+		for i, member := range members {
+			if member == login {
+				return append(members[:i], members[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("member %q of team %q: %w", login, name, gitprovider.ErrNotFound)
+	})
+}
+
+func (s *storage) DeleteTeam(ref gitprovider.OrganizationRef, name string) error {
+	return s.updateOrganizationMetadata(ref, func(meta *organizationMetadata) error {
+		i := findTeam(meta, name)
+		if i < 0 {
+			return fmt.Errorf("team %q: %w", name, gitprovider.ErrNotFound)
+		}
+		meta.Teams = append(meta.Teams[:i], meta.Teams[i+1:]...)
+		return nil
+	})
+}
+
+// updateTeamMembers replaces the members of the given team with the result of fn.
+func (s *storage) updateTeamMembers(ref gitprovider.OrganizationRef, name string, fn func(members []string) ([]string, error)) (*Team, error) {
+	var apiObj Team
+	err := s.updateOrganizationMetadata(ref, func(meta *organizationMetadata) error {
+		i := findTeam(meta, name)
+		if i < 0 {
+			return fmt.Errorf("team %q: %w", name, gitprovider.ErrNotFound)
+		}
+		members, err := fn(meta.Teams[i].Members)
+		if err != nil {
+			return err
+		}
+		meta.Teams[i].Members = members
+		apiObj = Team{Name: name, Members: append([]string{}, members...)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiObj, nil
+}
+
+func findTeam(meta *organizationMetadata, name string) int {
+	for i := range meta.Teams {
+		if meta.Teams[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+//
+// Repositories
+//
+
+func (s *storage) GetRepo(ref gitprovider.RepositoryRef) (*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, repo, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return repositoryFromDisk(ref, dir, repo)
+}
+
+// ListRepos returns the repositories owned by the given organization or user, sorted by name.
+// Organizations need to exist, users without repositories don't have a directory.
+func (s *storage) ListRepos(owner gitprovider.IdentityRef) ([]*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.identityDir(owner)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) && !isOrganization(owner) {
+		return []*Repository{}, nil
+	} else if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("organization %q: %w", owner.String(), gitprovider.ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+
+	apiObjs := []*Repository{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), repositorySuffix) {
+			continue
+		}
+		ref := repositoryRef(owner, strings.TrimSuffix(entry.Name(), repositorySuffix))
+		repoDir := filepath.Join(dir, entry.Name())
+		repo, err := git.PlainOpen(repoDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open repository %q: %w", repoDir, err)
+		}
+		apiObj, err := repositoryFromDisk(ref, repoDir, repo)
+		if err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, apiObj)
+	}
+	return apiObjs, nil
+}
+
+// CreateRepo initializes a bare repository for req. If autoInit is true, an initial commit
+// with a README.md file is added to the default branch.
+func (s *storage) CreateRepo(req *Repository, autoInit bool) (*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.repositoryDir(req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("repository %q: %w", req.Ref.String(), gitprovider.ErrAlreadyExists)
+	}
+	// Organizations need to exist, user directories are created on demand
+	if orgRef, ok := req.Ref.(gitprovider.OrgRepositoryRef); ok {
+		if _, err := s.organization(orgRef.OrganizationRef); err != nil {
+			return nil, err
+		}
+	}
+
+	repo, err := git.PlainInit(dir, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := initRepo(repo, dir, req, autoInit); err != nil {
+		// Don't leave a half-initialized repository behind
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return repositoryFromDisk(req.Ref, dir, repo)
+}
+
+func initRepo(repo *git.Repository, dir string, req *Repository, autoInit bool) error {
+	if err := gitrepo.SetHead(repo, req.DefaultBranch); err != nil {
+		return err
+	}
+	if autoInit {
+		content := fmt.Sprintf("# %s\n", req.Ref.GetRepository())
+		if _, err := gitrepo.CommitFiles(repo, req.DefaultBranch, "Initial commit", []gitprovider.CommitFile{{
+			Path:    gitprovider.StringVar("README.md"),
+			Content: &content,
+		}}, commitAuthor); err != nil {
+			return err
+		}
+	}
+	return writeJSON(filepath.Join(dir, repositoryMetadataFile), &repositoryMetadata{
+		Description: req.Description,
+		Visibility:  req.Visibility,
+		CreatedAt:   time.Now().UTC(),
+	})
+}
+
+// UpdateRepo updates the description, default branch and visibility of the repository.
+func (s *storage) UpdateRepo(req *Repository) (*Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, repo, err := s.repository(req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := gitrepo.SetHead(repo, req.DefaultBranch); err != nil {
+		return nil, err
+	}
+	if err := updateRepositoryMetadata(dir, func(meta *repositoryMetadata) error {
+		meta.Description = req.Description
+		meta.Visibility = req.Visibility
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return repositoryFromDisk(req.Ref, dir, repo)
+}
+
+func (s *storage) DeleteRepo(ref gitprovider.RepositoryRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, _, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+//
+// Deploy keys
+//
+
+func (s *storage) GetDeployKey(ref gitprovider.RepositoryRef, name string) (*DeployKey, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	i := findDeployKey(meta, name)
+	if i < 0 {
+		return nil, fmt.Errorf("deploy key %q: %w", name, gitprovider.ErrNotFound)
+	}
+	return &meta.DeployKeys[i], nil
+}
+
+// ListDeployKeys returns the deploy keys of the repository, sorted by name.
+func (s *storage) ListDeployKeys(ref gitprovider.RepositoryRef) ([]*DeployKey, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*DeployKey, 0, len(meta.DeployKeys))
+	for i := range meta.DeployKeys {
+		apiObjs = append(apiObjs, &meta.DeployKeys[i])
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+// CreateDeployKey adds req to the repository. Both the name and the key need to be unique.
+func (s *storage) CreateDeployKey(ref gitprovider.RepositoryRef, req *DeployKey) (*DeployKey, error) {
+	dk := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		if err := checkDeployKey(meta, req); err != nil {
+			return err
+		}
+		meta.LastDeployKeyID++
+		dk.ID = meta.LastDeployKeyID
+		meta.DeployKeys = append(meta.DeployKeys, dk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dk, nil
+}
+
+// UpdateDeployKey replaces the deploy key with the same name.
+func (s *storage) UpdateDeployKey(ref gitprovider.RepositoryRef, req *DeployKey) (*DeployKey, error) {
+	dk := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findDeployKey(meta, req.Name)
+		if i < 0 {
+			return fmt.Errorf("deploy key %q: %w", req.Name, gitprovider.ErrNotFound)
+		}
+		dk.ID = meta.DeployKeys[i].ID
+		meta.DeployKeys = append(meta.DeployKeys[:i], meta.DeployKeys[i+1:]...)
+		if err := checkDeployKey(meta, req); err != nil {
+			return err
+		}
+		meta.DeployKeys = append(meta.DeployKeys, dk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dk, nil
+}
+
+func (s *storage) DeleteDeployKey(ref gitprovider.RepositoryRef, name string) error {
+	return s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findDeployKey(meta, name)
+		if i < 0 {
+			return fmt.Errorf("deploy key %q: %w", name, gitprovider.ErrNotFound)
+		}
+		meta.DeployKeys = append(meta.DeployKeys[:i], meta.DeployKeys[i+1:]...)
+		return nil
+	})
+}
+
+// findDeployKey returns the index of the deploy key with the given name, or -1.
+func findDeployKey(meta *repositoryMetadata, name string) int {
+	for i := range meta.DeployKeys {
+		if meta.DeployKeys[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkDeployKey makes sure neither the name nor the key of dk is in use already.
+func checkDeployKey(meta *repositoryMetadata, dk *DeployKey) error {
+	for _, other := range meta.DeployKeys {
+		if other.Name == dk.Name {
+			return fmt.Errorf("deploy key %q: %w", dk.Name, gitprovider.ErrAlreadyExists)
+		}
+		if string(other.Key) == string(dk.Key) {
+			return fmt.Errorf("key of deploy key %q is already in use by %q: %w", dk.Name, other.Name, gitprovider.ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+//
+// Branch protections
+//
+
+func (s *storage) GetBranchProtection(ref gitprovider.RepositoryRef, branch string) (*BranchProtection, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	i := findBranchProtection(meta, branch)
+	if i < 0 {
+		return nil, fmt.Errorf("protection of branch %q: %w", branch, gitprovider.ErrNotFound)
+	}
+	return &meta.BranchProtections[i], nil
+}
+
+// ListBranchProtections returns the branch protections of the repository, sorted by branch.
+func (s *storage) ListBranchProtections(ref gitprovider.RepositoryRef) ([]*BranchProtection, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*BranchProtection, 0, len(meta.BranchProtections))
+	for i := range meta.BranchProtections {
+		apiObjs = append(apiObjs, &meta.BranchProtections[i])
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Branch < apiObjs[j].Branch
+	})
+	return apiObjs, nil
+}
+
+// SetBranchProtection protects a branch of the repository, the branch doesn't need to exist.
+// If create is true, ErrAlreadyExists is returned if the branch is protected already, otherwise
+// ErrNotFound is returned if it isn't.
+func (s *storage) SetBranchProtection(ref gitprovider.RepositoryRef, req *BranchProtection, create bool) (*BranchProtection, error) {
+	bp := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findBranchProtection(meta, req.Branch)
+		switch {
+		case create && i >= 0:
+			return fmt.Errorf("protection of branch %q: %w", req.Branch, gitprovider.ErrAlreadyExists)
+		case !create && i < 0:
+			return fmt.Errorf("protection of branch %q: %w", req.Branch, gitprovider.ErrNotFound)
+		case create:
+			meta.BranchProtections = append(meta.BranchProtections, bp)
+		default:
+			meta.BranchProtections[i] = bp
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &bp, nil
+}
+
+func (s *storage) DeleteBranchProtection(ref gitprovider.RepositoryRef, branch string) error {
+	return s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findBranchProtection(meta, branch)
+		if i < 0 {
+			return fmt.Errorf("protection of branch %q: %w", branch, gitprovider.ErrNotFound)
+		}
+		meta.BranchProtections = append(meta.BranchProtections[:i], meta.BranchProtections[i+1:]...)
+		return nil
+	})
+}
+
+// findBranchProtection returns the index of the protection of the given branch, or -1.
+func findBranchProtection(meta *repositoryMetadata, branch string) int {
+	for i := range meta.BranchProtections {
+		if meta.BranchProtections[i].Branch == branch {
+			return i
+		}
+	}
+	return -1
+}
+
+//
+// Webhooks
+//
+
+func (s *storage) GetWebhook(ref gitprovider.RepositoryRef, url string) (*Webhook, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	i := findWebhook(meta, url)
+	if i < 0 {
+		return nil, fmt.Errorf("webhook %q: %w", url, gitprovider.ErrNotFound)
+	}
+	return &meta.Webhooks[i], nil
+}
+
+// ListWebhooks returns the webhooks of the repository, in the order they were created.
+func (s *storage) ListWebhooks(ref gitprovider.RepositoryRef) ([]*Webhook, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Webhook, 0, len(meta.Webhooks))
+	for i := range meta.Webhooks {
+		apiObjs = append(apiObjs, &meta.Webhooks[i])
+	}
+	return apiObjs, nil
+}
+
+// CreateWebhook adds req to the repository, the URL of which has to be unique.
+func (s *storage) CreateWebhook(ref gitprovider.RepositoryRef, req *Webhook) (*Webhook, error) {
+	wh := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		if findWebhook(meta, req.URL) >= 0 {
+			return fmt.Errorf("webhook %q: %w", req.URL, gitprovider.ErrAlreadyExists)
+		}
+		meta.LastWebhookID++
+		wh.ID = meta.LastWebhookID
+		meta.Webhooks = append(meta.Webhooks, wh)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+// UpdateWebhook replaces the webhook with the same URL.
+func (s *storage) UpdateWebhook(ref gitprovider.RepositoryRef, req *Webhook) (*Webhook, error) {
+	wh := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findWebhook(meta, req.URL)
+		if i < 0 {
+			return fmt.Errorf("webhook %q: %w", req.URL, gitprovider.ErrNotFound)
+		}
+		wh.ID = meta.Webhooks[i].ID
+		meta.Webhooks[i] = wh
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+func (s *storage) DeleteWebhook(ref gitprovider.RepositoryRef, url string) error {
+	return s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findWebhook(meta, url)
+		if i < 0 {
+			return fmt.Errorf("webhook %q: %w", url, gitprovider.ErrNotFound)
+		}
+		meta.Webhooks = append(meta.Webhooks[:i], meta.Webhooks[i+1:]...)
+		return nil
+	})
+}
+
+// findWebhook returns the index of the webhook delivering to the given URL, or -1.
+func findWebhook(meta *repositoryMetadata, url string) int {
+	for i := range meta.Webhooks {
+		if meta.Webhooks[i].URL == url {
+			return i
+		}
+	}
+	return -1
+}
+
+//
+// Releases
+//
+
+func (s *storage) GetRelease(ref gitprovider.RepositoryRef, tagName string) (*Release, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	i := findRelease(meta, tagName)
+	if i < 0 {
+		return nil, fmt.Errorf("release %q: %w", tagName, gitprovider.ErrNotFound)
+	}
+	return &meta.Releases[i], nil
+}
+
+// ListReleases returns the releases of the repository, in the order they were created.
+func (s *storage) ListReleases(ref gitprovider.RepositoryRef) ([]*Release, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*Release, 0, len(meta.Releases))
+	for i := range meta.Releases {
+		apiObjs = append(apiObjs, &meta.Releases[i])
+	}
+	return apiObjs, nil
+}
+
+// CreateRelease adds a release for req.TagName. If the tag doesn't exist, a lightweight tag
+// is created from target, which is a branch name or commit SHA and defaults to the default branch.
+func (s *storage) CreateRelease(ref gitprovider.RepositoryRef, req *Release, target string) (*Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, repo, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	rel := *req
+	rel.Assets = nil
+	err = updateRepositoryMetadata(dir, func(meta *repositoryMetadata) error {
+		if findRelease(meta, req.TagName) >= 0 {
+			return fmt.Errorf("release %q: %w", req.TagName, gitprovider.ErrAlreadyExists)
+		}
+		if _, err := gitrepo.GetTag(repo, req.TagName); errors.Is(err, gitprovider.ErrNotFound) {
+			if err := createReleaseTag(repo, req.TagName, target); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		meta.LastReleaseID++
+		rel.ID = meta.LastReleaseID
+		meta.Releases = append(meta.Releases, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+func createReleaseTag(repo *git.Repository, tagName, target string) error {
+	if target == "" {
+		head, err := repo.Storer.Reference(plumbing.HEAD)
+		if err != nil {
+			return err
+		}
+		target = head.Target().Short()
+	}
+	commit, err := gitrepo.ResolveCommit(repo, target)
+	if err != nil {
+		return err
+	}
+	return gitrepo.CreateTag(repo, tagName, commit.Hash.String(), nil, commitAuthor)
+}
+
+// UpdateRelease replaces the name, notes and flags of the release for the same tag.
+func (s *storage) UpdateRelease(ref gitprovider.RepositoryRef, req *Release) (*Release, error) {
+	var rel Release
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findRelease(meta, req.TagName)
+		if i < 0 {
+			return fmt.Errorf("release %q: %w", req.TagName, gitprovider.ErrNotFound)
+		}
+		meta.Releases[i].Name = req.Name
+		meta.Releases[i].Notes = req.Notes
+		meta.Releases[i].Draft = req.Draft
+		meta.Releases[i].Prerelease = req.Prerelease
+		rel = meta.Releases[i]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// DeleteRelease deletes the release and its assets.
+func (s *storage) DeleteRelease(ref gitprovider.RepositoryRef, tagName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, _, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return updateRepositoryMetadata(dir, func(meta *repositoryMetadata) error {
+		i := findRelease(meta, tagName)
+		if i < 0 {
+			return fmt.Errorf("release %q: %w", tagName, gitprovider.ErrNotFound)
+		}
+		meta.Releases = append(meta.Releases[:i], meta.Releases[i+1:]...)
+		return os.RemoveAll(filepath.Join(dir, releaseAssetsDir, tagName))
+	})
+}
+
+// UploadReleaseAsset writes content to a file next to the repository and attaches it to the
+// release. The asset name has to be unique within the release.
+func (s *storage) UploadReleaseAsset(ref gitprovider.RepositoryRef, tagName, name string, content []byte) (*ReleaseAsset, error) {
+	if err := validateDirectoryName(name); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, _, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	var asset ReleaseAsset
+	err = updateRepositoryMetadata(dir, func(meta *repositoryMetadata) error {
+		i := findRelease(meta, tagName)
+		if i < 0 {
+			return fmt.Errorf("release %q: %w", tagName, gitprovider.ErrNotFound)
+		}
+		for _, a := range meta.Releases[i].Assets {
+			if a.Name == name {
+				return fmt.Errorf("release asset %q: %w", name, gitprovider.ErrAlreadyExists)
+			}
+		}
+		assetsDir := filepath.Join(dir, releaseAssetsDir, tagName)
+		if err := os.MkdirAll(assetsDir, 0o755); err != nil {
+			return err
+		}
+		path := filepath.Join(assetsDir, name)
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return err
+		}
+		asset = ReleaseAsset{
+			Name: name,
+			URL:  (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String(),
+			Size: len(content),
+		}
+		meta.Releases[i].Assets = append(meta.Releases[i].Assets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// findRelease returns the index of the release for the tag with the given name, or -1.
+func findRelease(meta *repositoryMetadata, tagName string) int {
+	for i := range meta.Releases {
+		if meta.Releases[i].TagName == tagName {
+			return i
+		}
+	}
+	return -1
+}
+
+//
+// Commit statuses
+//
+
+// ListCommitStatuses returns the latest status of each context for the commit, in the order the
+// contexts were first reported.
+func (s *storage) ListCommitStatuses(ref gitprovider.RepositoryRef, sha string) ([]*CommitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, repo, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gitrepo.CommitObject(repo, sha); err != nil {
+		return nil, err
+	}
+	meta, err := readRepositoryMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*CommitStatus{}
+	for i := range meta.CommitStatuses {
+		if meta.CommitStatuses[i].SHA == sha {
+			apiObjs = append(apiObjs, &meta.CommitStatuses[i])
+		}
+	}
+	return apiObjs, nil
+}
+
+// CreateCommitStatus adds the status to the commit, replacing the status of the same context.
+func (s *storage) CreateCommitStatus(ref gitprovider.RepositoryRef, req *CommitStatus) (*CommitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, repo, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gitrepo.CommitObject(repo, req.SHA); err != nil {
+		return nil, err
+	}
+	status := *req
+	err = updateRepositoryMetadata(dir, func(meta *repositoryMetadata) error {
+		for i := range meta.CommitStatuses {
+			if meta.CommitStatuses[i].SHA == status.SHA && meta.CommitStatuses[i].Context == status.Context {
+				meta.CommitStatuses[i] = status
+				return nil
+			}
+		}
+		meta.CommitStatuses = append(meta.CommitStatuses, status)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+//
+// Pull request comments and reviews
+//
+
+func (s *storage) ListPullRequestComments(ref gitprovider.RepositoryRef, number int) ([]*PullRequestComment, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pullRequest(meta, number); err != nil {
+		return nil, err
+	}
+	apiObjs := []*PullRequestComment{}
+	for i := range meta.PullRequestComments {
+		if meta.PullRequestComments[i].PullRequestNumber == number {
+			apiObjs = append(apiObjs, &meta.PullRequestComments[i])
+		}
+	}
+	return apiObjs, nil
+}
+
+// CreatePullRequestComment adds a comment to the pull request, its ID and author are assigned
+// by the provider.
+func (s *storage) CreatePullRequestComment(ref gitprovider.RepositoryRef, number int, body string) (*PullRequestComment, error) {
+	var comment PullRequestComment
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		if _, err := pullRequest(meta, number); err != nil {
+			return err
+		}
+		meta.LastCommentID++
+		comment = PullRequestComment{
+			ID:                meta.LastCommentID,
+			PullRequestNumber: number,
+			Body:              body,
+			Author:            commitAuthor.Name,
+			CreatedAt:         time.Now().UTC(),
+		}
+		comment.UpdatedAt = comment.CreatedAt
+		meta.PullRequestComments = append(meta.PullRequestComments, comment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (s *storage) UpdatePullRequestComment(ref gitprovider.RepositoryRef, number int, id int64, body string) (*PullRequestComment, error) {
+	var comment PullRequestComment
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i, err := findPullRequestComment(meta, number, id)
+		if err != nil {
+			return err
+		}
+		meta.PullRequestComments[i].Body = body
+		meta.PullRequestComments[i].UpdatedAt = time.Now().UTC()
+		comment = meta.PullRequestComments[i]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (s *storage) DeletePullRequestComment(ref gitprovider.RepositoryRef, number int, id int64) error {
+	return s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i, err := findPullRequestComment(meta, number, id)
+		if err != nil {
+			return err
+		}
+		meta.PullRequestComments = append(meta.PullRequestComments[:i], meta.PullRequestComments[i+1:]...)
+		return nil
+	})
+}
+
+func findPullRequestComment(meta *repositoryMetadata, number int, id int64) (int, error) {
+	if _, err := pullRequest(meta, number); err != nil {
+		return -1, err
+	}
+	for i := range meta.PullRequestComments {
+		if meta.PullRequestComments[i].PullRequestNumber == number && meta.PullRequestComments[i].ID == id {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("pull request %d comment %d: %w", number, id, gitprovider.ErrNotFound)
+}
+
+// ListPullRequestReviews returns no reviews for existing pull requests, as local repositories
+// have no reviewers.
+func (s *storage) ListPullRequestReviews(ref gitprovider.RepositoryRef, number int) ([]*PullRequestReview, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pullRequest(meta, number); err != nil {
+		return nil, err
+	}
+	return []*PullRequestReview{}, nil
+}
+
+//
+// Team access
+//
+
+func (s *storage) GetTeamAccess(ref gitprovider.OrgRepositoryRef, name string) (*TeamAccess, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	i := findTeamAccess(meta, name)
+	if i < 0 {
+		return nil, fmt.Errorf("team access %q: %w", name, gitprovider.ErrNotFound)
+	}
+	return &meta.TeamAccess[i], nil
+}
+
+// ListTeamAccess returns the teams with access to the repository, sorted by name.
+func (s *storage) ListTeamAccess(ref gitprovider.OrgRepositoryRef) ([]*TeamAccess, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*TeamAccess, 0, len(meta.TeamAccess))
+	for i := range meta.TeamAccess {
+		apiObjs = append(apiObjs, &meta.TeamAccess[i])
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Name < apiObjs[j].Name
+	})
+	return apiObjs, nil
+}
+
+// SetTeamAccess gives a team of the repository's organization access to the repository.
+// If create is true, ErrAlreadyExists is returned if the team has access already, otherwise
+// ErrNotFound is returned if it doesn't.
+func (s *storage) SetTeamAccess(ref gitprovider.OrgRepositoryRef, req *TeamAccess, create bool) (*TeamAccess, error) {
+	if _, err := s.GetTeam(ref.OrganizationRef, req.Name); err != nil {
+		return nil, err
+	}
+	ta := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findTeamAccess(meta, req.Name)
+		switch {
+		case create && i >= 0:
+			return fmt.Errorf("team access %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+		case !create && i < 0:
+			return fmt.Errorf("team access %q: %w", req.Name, gitprovider.ErrNotFound)
+		case create:
+			meta.TeamAccess = append(meta.TeamAccess, ta)
+		default:
+			meta.TeamAccess[i] = ta
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ta, nil
+}
+
+func (s *storage) DeleteTeamAccess(ref gitprovider.OrgRepositoryRef, name string) error {
+	return s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findTeamAccess(meta, name)
+		if i < 0 {
+			return fmt.Errorf("team access %q: %w", name, gitprovider.ErrNotFound)
+		}
+		meta.TeamAccess = append(meta.TeamAccess[:i], meta.TeamAccess[i+1:]...)
+		return nil
+	})
+}
+
+// findTeamAccess returns the index of the team access with the given name, or -1.
+func findTeamAccess(meta *repositoryMetadata, name string) int {
+	for i := range meta.TeamAccess {
+		if meta.TeamAccess[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+//
+// User access
+//
+
+func (s *storage) GetUserAccess(ref gitprovider.RepositoryRef, login string) (*UserAccess, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	i := findUserAccess(meta, login)
+	if i < 0 {
+		return nil, fmt.Errorf("user access %q: %w", login, gitprovider.ErrNotFound)
+	}
+	return &meta.UserAccess[i], nil
+}
+
+// ListUserAccess returns the users with access to the repository, sorted by login.
+func (s *storage) ListUserAccess(ref gitprovider.RepositoryRef) ([]*UserAccess, error) {
+	meta, err := s.repositoryMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := make([]*UserAccess, 0, len(meta.UserAccess))
+	for i := range meta.UserAccess {
+		apiObjs = append(apiObjs, &meta.UserAccess[i])
+	}
+	sort.Slice(apiObjs, func(i, j int) bool {
+		return apiObjs[i].Login < apiObjs[j].Login
+	})
+	return apiObjs, nil
+}
+
+// SetUserAccess gives a user access to the repository.
+// If create is true, ErrAlreadyExists is returned if the user has access already, otherwise
+// ErrNotFound is returned if they don't.
+func (s *storage) SetUserAccess(ref gitprovider.RepositoryRef, req *UserAccess, create bool) (*UserAccess, error) {
+	ua := *req
+	err := s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findUserAccess(meta, req.Login)
+		switch {
+		case create && i >= 0:
+			return fmt.Errorf("user access %q: %w", req.Login, gitprovider.ErrAlreadyExists)
+		case !create && i < 0:
+			return fmt.Errorf("user access %q: %w", req.Login, gitprovider.ErrNotFound)
+		case create:
+			meta.UserAccess = append(meta.UserAccess, ua)
+		default:
+			meta.UserAccess[i] = ua
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ua, nil
+}
+
+func (s *storage) DeleteUserAccess(ref gitprovider.RepositoryRef, login string) error {
+	return s.updateRepositoryMetadata(ref, func(meta *repositoryMetadata) error {
+		i := findUserAccess(meta, login)
+		if i < 0 {
+			return fmt.Errorf("user access %q: %w", login, gitprovider.ErrNotFound)
+		}
+		meta.UserAccess = append(meta.UserAccess[:i], meta.UserAccess[i+1:]...)
+		return nil
+	})
+}
+
+// findUserAccess returns the index of the user access with the given login, or -1.
+func findUserAccess(meta *repositoryMetadata, login string) int {
+	for i := range meta.UserAccess {
+		if meta.UserAccess[i].Login == login {
+			return i
+		}
+	}
+	return -1
+}
+
+//
+// Metadata
+//
+
+// repositoryMetadata returns the metadata of the given repository.
+func (s *storage) repositoryMetadata(ref gitprovider.RepositoryRef) (*repositoryMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, _, err := s.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+	return readRepositoryMetadata(dir)
+}
+
+// updateRepositoryMetadata applies fn to the metadata of the given repository, and writes it
+// back unless fn returns an error.
+func (s *storage) updateRepositoryMetadata(ref gitprovider.RepositoryRef, fn func(meta *repositoryMetadata) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, _, err := s.repository(ref)
+	if err != nil {
+		return err
+	}
+	return updateRepositoryMetadata(dir, fn)
+}
+
+func updateRepositoryMetadata(dir string, fn func(meta *repositoryMetadata) error) error {
+	meta, err := readRepositoryMetadata(dir)
+	if err != nil {
+		return err
+	}
+	if err := fn(meta); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dir, repositoryMetadataFile), meta)
+}
+
+// updateOrganizationMetadata applies fn to the metadata of the organization and writes it back,
+// unless fn returns an error.
+func (s *storage) updateOrganizationMetadata(ref gitprovider.OrganizationRef, fn func(meta *organizationMetadata) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.organization(ref)
+	if err != nil {
+		return err
+	}
+	return updateOrganizationMetadata(dir, fn)
+}
+
+func updateOrganizationMetadata(dir string, fn func(meta *organizationMetadata) error) error {
+	meta, err := readOrganizationMetadata(dir)
+	if err != nil {
+		return err
+	}
+	if err := fn(meta); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dir, organizationMetadataFile), meta)
+}
+
+func readOrganizationMetadata(dir string) (*organizationMetadata, error) {
+	meta := &organizationMetadata{}
+	return meta, readJSON(filepath.Join(dir, organizationMetadataFile), meta)
+}
+
+func readRepositoryMetadata(dir string) (*repositoryMetadata, error) {
+	meta := &repositoryMetadata{}
+	return meta, readJSON(filepath.Join(dir, repositoryMetadataFile), meta)
+}
+
+// readJSON decodes the given file into v. A missing file leaves v unchanged.
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %q: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON atomically replaces the given file with the encoding of v.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+//
+// Helpers, s.mu must be held by the caller
+//
+
+// identityDir returns the directory of the given organization or user, which might not exist.
+func (s *storage) identityDir(ref gitprovider.IdentityRef) (string, error) {
+	elems := []string{s.root}
+	for _, name := range strings.Split(ref.GetIdentity(), "/") {
+		if err := validateDirectoryName(name); err != nil {
+			return "", err
+		}
+		if strings.HasSuffix(name, repositorySuffix) {
+			return "", fmt.Errorf("organization and user names can't end with %q: %w", repositorySuffix, gitprovider.ErrInvalidArgument)
+		}
+		elems = append(elems, name)
+	}
+	return filepath.Join(elems...), nil
+}
+
+// repositoryDir returns the directory of the given repository, which might not exist.
+func (s *storage) repositoryDir(ref gitprovider.RepositoryRef) (string, error) {
+	dir, err := s.identityDir(repositoryOwner(ref))
+	if err != nil {
+		return "", err
+	}
+	if err := validateDirectoryName(ref.GetRepository()); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ref.GetRepository()+repositorySuffix), nil
+}
+
+// organization returns the directory of the given organization, which must exist.
+func (s *storage) organization(ref gitprovider.OrganizationRef) (string, error) {
+	dir, err := s.identityDir(ref)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("organization %q: %w", ref.String(), gitprovider.ErrNotFound)
+	}
+	return dir, nil
+}
+
+// repository opens the bare repository for ref.
+func (s *storage) repository(ref gitprovider.RepositoryRef) (string, *git.Repository, error) {
+	dir, err := s.repositoryDir(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", nil, fmt.Errorf("repository %q: %w", ref.String(), gitprovider.ErrNotFound)
+	}
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open repository %q: %w", dir, err)
+	}
+	return dir, repo, nil
+}
+
+// organizationsIn returns the organizations for the directories in dir, sorted by name.
+func (s *storage) organizationsIn(dir string, refFn func(name string) gitprovider.OrganizationRef) ([]*Organization, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs := []*Organization{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasSuffix(name, repositorySuffix) {
+			continue
+		}
+		apiObj, err := organizationFromDisk(refFn(name), filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		apiObjs = append(apiObjs, apiObj)
+	}
+	return apiObjs, nil
+}
+
+// organizationFromDisk assembles the API object of the organization in dir.
+func organizationFromDisk(ref gitprovider.OrganizationRef, dir string) (*Organization, error) {
+	meta, err := readOrganizationMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+	name := meta.Name
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	return &Organization{
+		Ref:         ref,
+		Name:        name,
+		Description: meta.Description,
+	}, nil
+}
+
+// repositoryFromDisk assembles the API object of the repository in dir.
+func repositoryFromDisk(ref gitprovider.RepositoryRef, dir string, repo *git.Repository) (*Repository, error) {
+	meta, err := readRepositoryMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{
+		Ref:           ref,
+		Description:   meta.Description,
+		DefaultBranch: head.Target().Short(),
+		Visibility:    meta.Visibility,
+		CreatedAt:     meta.CreatedAt,
+	}, nil
+}
+
+// validateDirectoryName makes sure name is a single path element.
+func validateDirectoryName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid name %q: %w", name, gitprovider.ErrInvalidArgument)
+	}
+	return nil
+}
+
+func parentOrganization(ref gitprovider.OrganizationRef) gitprovider.OrganizationRef {
+	return gitprovider.OrganizationRef{
+		Domain:           ref.Domain,
+		Organization:     ref.Organization,
+		SubOrganizations: ref.SubOrganizations[:len(ref.SubOrganizations)-1],
+	}
+}
+
+// repositoryOwner returns the organization or user owning the repository.
+func repositoryOwner(ref gitprovider.RepositoryRef) gitprovider.IdentityRef {
+	switch r := ref.(type) {
+	case gitprovider.OrgRepositoryRef:
+		return r.OrganizationRef
+	case gitprovider.UserRepositoryRef:
+		return r.UserRef
+	}
+	return ref
+}
+
+// repositoryRef returns the reference of the repository with the given name, owned by owner.
+func repositoryRef(owner gitprovider.IdentityRef, name string) gitprovider.RepositoryRef {
+	if orgRef, ok := owner.(gitprovider.OrganizationRef); ok {
+		return gitprovider.OrgRepositoryRef{OrganizationRef: orgRef, RepositoryName: name}
+	}
+	return gitprovider.UserRepositoryRef{UserRef: owner.(gitprovider.UserRef), RepositoryName: name}
+}
+
+func isOrganization(ref gitprovider.IdentityRef) bool {
+	_, ok := ref.(gitprovider.OrganizationRef)
+	return ok
+}
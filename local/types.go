@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/internal/provider"
+)
+
+// The API objects returned by the APIObject methods of the resources of this provider.
+type (
+	// Organization is the API object of an organization or sub-organization directory.
+	Organization = provider.Organization
+	// Team is the API object of a team in an organization.
+	Team = provider.Team
+	// Repository is the API object of a bare repository.
+	Repository = provider.Repository
+	// Branch is the API object of a branch of a repository.
+	Branch = provider.Branch
+	// BranchProtection is the API object of the protection of a branch.
+	BranchProtection = provider.BranchProtection
+	// Webhook is the API object of a webhook of a repository. Webhooks are only stored, no
+	// payloads are delivered.
+	Webhook = provider.Webhook
+	// DeployKey is the API object of a deploy key of a repository.
+	DeployKey = provider.DeployKey
+	// TeamAccess is the API object of a team's access to a repository.
+	TeamAccess = provider.TeamAccess
+	// UserAccess is the API object of a user's access to a repository.
+	UserAccess = provider.UserAccess
+	// PullRequest is the API object of a pull request.
+	PullRequest = provider.PullRequest
+	// PullRequestReview is the API object of a review of a pull request. Local repositories
+	// have no reviewers, hence there are no reviews.
+	PullRequestReview = provider.PullRequestReview
+	// PullRequestComment is the API object of a general comment of a pull request.
+	PullRequestComment = provider.PullRequestComment
+	// Tag is the API object of a tag of a repository.
+	Tag = provider.Tag
+	// Release is the API object of a release of a repository.
+	Release = provider.Release
+	// CommitStatus is the API object of the status of a commit.
+	CommitStatus = provider.CommitStatus
+	// ReleaseAsset is the API object of a file attached to a release. The file is stored next to
+	// the bare repository, and URL is its file URL.
+	ReleaseAsset = provider.ReleaseAsset
+)
+
+// organizationMetadata is the content of the metadata file of an organization directory.
+type organizationMetadata struct {
+	// Name is the human-friendly name of the organization, defaulting to the directory name.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Teams       []Team `json:"teams,omitempty"`
+}
+
+// repositoryMetadata is the content of the metadata file of a repository, holding the state
+// which can't be stored in the bare repository itself.
+type repositoryMetadata struct {
+	Description         string                           `json:"description,omitempty"`
+	Visibility          gitprovider.RepositoryVisibility `json:"visibility"`
+	CreatedAt           time.Time                        `json:"createdAt"`
+	DeployKeys          []DeployKey                      `json:"deployKeys,omitempty"`
+	BranchProtections   []BranchProtection               `json:"branchProtections,omitempty"`
+	Webhooks            []Webhook                        `json:"webhooks,omitempty"`
+	TeamAccess          []TeamAccess                     `json:"teamAccess,omitempty"`
+	UserAccess          []UserAccess                     `json:"userAccess,omitempty"`
+	PullRequests        []PullRequest                    `json:"pullRequests,omitempty"`
+	PullRequestComments []PullRequestComment             `json:"pullRequestComments,omitempty"`
+	Releases            []Release                        `json:"releases,omitempty"`
+	CommitStatuses      []CommitStatus                   `json:"commitStatuses,omitempty"`
+	// LastDeployKeyID is used to hand out unique deploy key IDs.
+	LastDeployKeyID int `json:"lastDeployKeyID,omitempty"`
+	// LastWebhookID is used to hand out unique webhook IDs.
+	LastWebhookID int `json:"lastWebhookID,omitempty"`
+	// LastReleaseID is used to hand out unique release IDs.
+	LastReleaseID int `json:"lastReleaseID,omitempty"`
+	// LastCommentID is used to hand out unique pull request comment IDs.
+	LastCommentID int64 `json:"lastCommentID,omitempty"`
+}
@@ -18,12 +18,71 @@ package stash
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/go-logr/logr"
 )
 
+// ProviderOption is a gitprovider.ClientOption which also sets options only Stash clients support.
+// It doesn't change the gitprovider.ClientOptions, NewStashClient applies it to the ProviderOptions.
+type ProviderOption interface {
+	gitprovider.ClientOption
+	// ApplyToProviderOptions applies set fields of this object into target.
+	ApplyToProviderOptions(target *ProviderOptions) error
+}
+
+// ProviderOptions tracks the options only Stash clients support.
+type ProviderOptions struct {
+	// gitClone will be set if commits and branches should be created in a clone of the repository.
+	gitClone *bool
+	// signKey is the key signing the commits created in a clone of the repository, if set.
+	signKey *openpgp.Entity
+}
+
+// ApplyToClientOptions implements gitprovider.ClientOption. None of the generic options are set.
+func (opts *ProviderOptions) ApplyToClientOptions(*gitprovider.ClientOptions) error {
+	return nil
+}
+
+// ApplyToProviderOptions implements ProviderOption, and applies the set fields of opts
+// into target. If both opts and target has the same specific field set, ErrInvalidClientOptions is returned.
+func (opts *ProviderOptions) ApplyToProviderOptions(target *ProviderOptions) error {
+	if opts.gitClone != nil {
+		// Make sure the user didn't specify gitClone twice
+		if target.gitClone != nil {
+			return fmt.Errorf("option GitClone already configured: %w", gitprovider.ErrInvalidClientOptions)
+		}
+		target.gitClone = opts.gitClone
+		target.signKey = opts.signKey
+	}
+	return nil
+}
+
+// makeProviderOptions applies the Stash specific options among optFns.
+func makeProviderOptions(optFns ...gitprovider.ClientOption) (*ProviderOptions, error) {
+	opts := &ProviderOptions{}
+	for _, optFn := range optFns {
+		if stashOptFn, ok := optFn.(ProviderOption); ok {
+			if err := stashOptFn.ApplyToProviderOptions(opts); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return opts, nil
+}
+
+// WithGitClone makes the client always create commits and branches by cloning the repository,
+// committing and pushing, instead of through the REST API. This is slower and uses disk space, but
+// commits are signed with signKey if it's not nil. Commits the REST API can't create are created
+// in a clone of the repository anyway.
+// The private key must be present and already decrypted.
+func WithGitClone(signKey *openpgp.Entity) ProviderOption {
+	return &ProviderOptions{gitClone: gitprovider.BoolVar(true), signKey: signKey}
+}
+
 // NewStashClient creates a new Client instance for Stash API endpoints.
 // The client accepts a username+token as an argument, which is used to authenticate.
 // The host name is used to construct the base URL for the Stash API.
@@ -35,6 +94,10 @@ func NewStashClient(username, token string, optFns ...gitprovider.ClientOption)
 	if err != nil {
 		return nil, err
 	}
+	stashOpts, err := makeProviderOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a *http.Client using the transport chain
 	client, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
@@ -75,5 +138,10 @@ func NewStashClient(username, token string, optFns ...gitprovider.ClientOption)
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(stashClient, host, token, destructiveActions, logger), nil
+	p := newClient(stashClient, host, token, destructiveActions, logger)
+	if stashOpts.gitClone != nil && *stashOpts.gitClone {
+		p.gitClone = true
+		p.signKey = stashOpts.signKey
+	}
+	return p, nil
 }
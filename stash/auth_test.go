@@ -17,6 +17,7 @@ limitations under the License.
 package stash
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -60,3 +61,26 @@ func Test_DomainVariations(t *testing.T) {
 		})
 	}
 }
+
+func Test_WithGitClone(t *testing.T) {
+	c, err := NewStashClient("user1", "token", gitprovider.WithDomain("stash.testserver.link"))
+	if err != nil {
+		t.Fatalf("NewStashClient returned error: %v", err)
+	}
+	if c.gitClone {
+		t.Errorf("commits and branches are created by cloning by default")
+	}
+
+	c, err = NewStashClient("user1", "token", gitprovider.WithDomain("stash.testserver.link"), WithGitClone(nil))
+	if err != nil {
+		t.Fatalf("NewStashClient returned error: %v", err)
+	}
+	if !c.gitClone {
+		t.Errorf("WithGitClone() didn't make commits and branches be created by cloning")
+	}
+
+	_, err = NewStashClient("user1", "token", gitprovider.WithDomain("stash.testserver.link"), WithGitClone(nil), WithGitClone(nil))
+	if !errors.Is(err, gitprovider.ErrInvalidClientOptions) {
+		t.Errorf("NewStashClient() with WithGitClone() twice error = %v, want %v", err, gitprovider.ErrInvalidClientOptions)
+	}
+}
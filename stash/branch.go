@@ -22,21 +22,25 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
-	branchesURI      = "branches"
-	defaultBranchURI = "default"
+	branchesURI        = "branches"
+	defaultBranchURI   = "default"
+	stashURIbranchUtil = "/rest/branch-utils/1.0"
 )
 
 // Branches interface defines the methods that can be used to
 // retrieve branches of a repository.
 type Branches interface {
 	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*BranchList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*Branch, error)
 	Get(ctx context.Context, projectKey, repositorySlug, branchID string) (*Branch, error)
 	Create(ctx context.Context, projectKey, repositorySlug, branchID, startPoint string) (*Branch, error)
 	Default(ctx context.Context, projectKey, repositorySlug string) (*Branch, error)
 	SetDefault(ctx context.Context, projectKey, repositorySlug, branchID string) error
+	Delete(ctx context.Context, projectKey, repositorySlug, branchID string) error
 }
 
 // BranchesService is a client for communicating with stash branches endpoint
@@ -107,36 +111,74 @@ func (s *BranchesService) List(ctx context.Context, projectKey, repositorySlug s
 	return b, nil
 }
 
-// Get retrieves a stash branch given it's ID i.e a git reference.
+// All retrieves all branches of a repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *BranchesService) All(ctx context.Context, projectKey, repositorySlug string) ([]*Branch, error) {
+	b := []*Branch{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, list.GetBranches()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Get retrieves a stash branch given its name or ID i.e a git reference.
+// Stash only filters branches by a substring of their name, hence the filtered branches are paged
+// through until the branch with exactly the given name is found. ErrNotFound is returned otherwise.
 // Get uses the endpoint
 // "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/branches?base&details&filterText&orderBy".
 // https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
 func (s *BranchesService) Get(ctx context.Context, projectKey, repositorySlug, branchID string) (*Branch, error) {
-	query := url.Values{
-		"filterText": []string{branchID},
-	}
-
-	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, branchesURI), WithQuery(query))
-	if err != nil {
-		return nil, fmt.Errorf("get branch request creation failed: %w", err)
-	}
-	res, resp, err := s.Client.Do(req)
+	name := strings.TrimPrefix(branchID, "refs/heads/")
+
+	var branch *Branch
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		query := addPaging(url.Values{"filterText": []string{name}}, opts)
+		req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, branchesURI), WithQuery(query))
+		if err != nil {
+			return nil, fmt.Errorf("get branch request creation failed: %w", err)
+		}
+		res, resp, err := s.Client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("get branch failed: %w", err)
+		}
+
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+
+		list := &BranchList{}
+		if err := json.Unmarshal(res, list); err != nil {
+			return nil, fmt.Errorf("get branch for repository failed, unable to unmarshall repository json: %w", err)
+		}
+
+		for _, b := range list.GetBranches() {
+			if b.DisplayID == name {
+				b.Session.set(resp)
+				branch = b
+				// Stop paging
+				return &Paging{IsLastPage: true}, nil
+			}
+		}
+		return &list.Paging, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get branch failed: %w", err)
+		return nil, err
 	}
-
-	if resp != nil && resp.StatusCode == http.StatusNotFound {
+	if branch == nil {
 		return nil, ErrNotFound
 	}
-
-	b := &Branch{}
-	if err := json.Unmarshal(res, b); err != nil {
-		return nil, fmt.Errorf("get branch for repository failed, unable to unmarshall repository json: %w", err)
-	}
-
-	b.Session.set(resp)
-	return b, nil
-
+	return branch, nil
 }
 
 // Default retrieves the default branch of a repository.
@@ -236,3 +278,41 @@ func (s *BranchesService) Create(ctx context.Context, projectKey, repositorySlug
 	b.Session.set(resp)
 	return b, nil
 }
+
+// Delete deletes a branch of a repository given it's ID i.e a git reference.
+// Delete uses the endpoint "DELETE /rest/branch-utils/1.0/projects/{projectKey}/repos/{repositorySlug}/branches".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-branch-rest.html
+func (s *BranchesService) Delete(ctx context.Context, projectKey, repositorySlug, branchID string) error {
+	branch := struct {
+		Name   string `json:"name"`
+		DryRun bool   `json:"dryRun"`
+	}{
+		Name:   branchID,
+		DryRun: false,
+	}
+	body, err := marshallBody(branch)
+	header := http.Header{"Content-Type": []string{"application/json"}}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshall branch: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newBranchUtilURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, branchesURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return fmt.Errorf("delete branch request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete branch failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// newBranchUtilURI builds stash branch utils URI
+func newBranchUtilURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIbranchUtil}, elements...), "/")
+}
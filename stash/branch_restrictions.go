@@ -0,0 +1,315 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	restrictionsURI            = "restrictions"
+	stashURIbranchPermissions  = "/rest/branch-permissions/2.0"
+	branchRestrictionMatcherID = "BRANCH"
+	patternMatcherID           = "PATTERN"
+)
+
+var (
+	// ErrPermissionDenied is returned if the token is not allowed to access the branch restrictions,
+	// which requires admin permissions on the repository.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Branch restriction types.
+const (
+	// BranchRestrictionReadOnly prevents all changes to the matching branches.
+	BranchRestrictionReadOnly = "read-only"
+	// BranchRestrictionNoDeletes prevents the deletion of the matching branches.
+	BranchRestrictionNoDeletes = "no-deletes"
+	// BranchRestrictionFastForwardOnly prevents rewriting the history of the matching branches.
+	BranchRestrictionFastForwardOnly = "fast-forward-only"
+	// BranchRestrictionPullRequestOnly prevents pushing changes to the matching branches without a pull request.
+	BranchRestrictionPullRequestOnly = "pull-request-only"
+)
+
+// BranchRestrictions interface defines the methods that can be used to
+// manage the branch restrictions of a repository.
+type BranchRestrictions interface {
+	List(ctx context.Context, projectKey, repositorySlug, branchID string, opts *PagingOptions) (*BranchRestrictionList, error)
+	All(ctx context.Context, projectKey, repositorySlug, branchID string) ([]*BranchRestriction, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, restriction *BranchRestriction) (*BranchRestriction, error)
+	Delete(ctx context.Context, projectKey, repositorySlug string, restrictionID int) error
+}
+
+// BranchRestrictionsService is a client for communicating with stash branch permissions endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ref-restriction-rest.html
+type BranchRestrictionsService service
+
+// BranchRestriction restricts the changes to the refs matched by its matcher.
+type BranchRestriction struct {
+	// Session is the session object for the branch restriction.
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the id of the restriction.
+	ID int `json:"id,omitempty"`
+	// Type is the type of the restriction, e.g. no-deletes.
+	Type string `json:"type,omitempty"`
+	// Matcher selects the refs the restriction applies to.
+	Matcher RefMatcher `json:"matcher,omitempty"`
+	// Users are the names of the users exempt from the restriction.
+	Users []string `json:"users,omitempty"`
+	// Groups are the names of the groups exempt from the restriction.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// UnmarshalJSON decodes a branch restriction, the server returns the exempt users as objects.
+func (r *BranchRestriction) UnmarshalJSON(data []byte) error {
+	type restriction BranchRestriction
+	aux := struct {
+		*restriction
+		Users []User `json:"users,omitempty"`
+	}{
+		restriction: (*restriction)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Users = nil
+	for _, user := range aux.Users {
+		r.Users = append(r.Users, user.Name)
+	}
+	return nil
+}
+
+// RefMatcher selects refs, e.g. a branch.
+type RefMatcher struct {
+	// ID is the ref the matcher selects, e.g. refs/heads/main.
+	ID string `json:"id,omitempty"`
+	// DisplayID is the name of the matched ref, e.g. main.
+	DisplayID string `json:"displayId,omitempty"`
+	// Type is the type of the matcher.
+	Type RefMatcherType `json:"type,omitempty"`
+	// Active is true if the matcher is active.
+	Active bool `json:"active,omitempty"`
+}
+
+// RefMatcherType is the type of a ref matcher, e.g. BRANCH or PATTERN.
+type RefMatcherType struct {
+	// ID is the id of the matcher type.
+	ID string `json:"id,omitempty"`
+	// Name is the human-readable name of the matcher type.
+	Name string `json:"name,omitempty"`
+}
+
+// BranchRestrictionList is a list of branch restrictions.
+type BranchRestrictionList struct {
+	// Paging is the paging information.
+	Paging
+	// BranchRestrictions is the list of branch restrictions.
+	BranchRestrictions []*BranchRestriction `json:"values,omitempty"`
+}
+
+// GetBranchRestrictions returns the list of branch restrictions.
+func (b *BranchRestrictionList) GetBranchRestrictions() []*BranchRestriction {
+	return b.BranchRestrictions
+}
+
+// List returns the list of restrictions of the repository.
+// If branchID, e.g. refs/heads/main, is set, only the restrictions matching that branch are returned.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a BranchRestrictionList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/branch-permissions/2.0/projects/{projectKey}/repos/{repositorySlug}/restrictions".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ref-restriction-rest.html
+func (s *BranchRestrictionsService) List(ctx context.Context, projectKey, repositorySlug, branchID string, opts *PagingOptions) (*BranchRestrictionList, error) {
+	query := addPaging(url.Values{}, opts)
+	if branchID != "" {
+		query.Add("matcherType", branchRestrictionMatcherID)
+		query.Add("matcherId", branchID)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newBranchPermissionsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, restrictionsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list branch restrictions request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return nil, fmt.Errorf("list branch restrictions failed with %s: %w", resp.Status, ErrPermissionDenied)
+		}
+		return nil, fmt.Errorf("list branch restrictions failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	r := &BranchRestrictionList{}
+	if err := json.Unmarshal(res, r); err != nil {
+		return nil, fmt.Errorf("list branch restrictions failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, restriction := range r.GetBranchRestrictions() {
+		restriction.Session.set(resp)
+	}
+
+	return r, nil
+}
+
+// All retrieves all restrictions of a repository, or of the given branch if branchID is set.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *BranchRestrictionsService) All(ctx context.Context, projectKey, repositorySlug, branchID string) ([]*BranchRestriction, error) {
+	r := []*BranchRestriction{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, branchID, opts)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, list.GetBranchRestrictions()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Create creates a branch restriction.
+// Create uses the endpoint "POST /rest/branch-permissions/2.0/projects/{projectKey}/repos/{repositorySlug}/restrictions".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ref-restriction-rest.html
+func (s *BranchRestrictionsService) Create(ctx context.Context, projectKey, repositorySlug string, restriction *BranchRestriction) (*BranchRestriction, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(restriction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall branch restriction: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newBranchPermissionsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, restrictionsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create branch restriction request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create branch restriction failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("create branch restriction failed: %s", resp.Status)
+	}
+
+	r := &BranchRestriction{}
+	if err := json.Unmarshal(res, r); err != nil {
+		return nil, fmt.Errorf("create branch restriction failed, unable to unmarshall json: %w", err)
+	}
+
+	r.Session.set(resp)
+
+	return r, nil
+}
+
+// Delete deletes the branch restriction with the given ID.
+// Delete uses the endpoint "DELETE /rest/branch-permissions/2.0/projects/{projectKey}/repos/{repositorySlug}/restrictions/{id}".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ref-restriction-rest.html
+func (s *BranchRestrictionsService) Delete(ctx context.Context, projectKey, repositorySlug string, restrictionID int) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newBranchPermissionsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, restrictionsURI, strconv.Itoa(restrictionID)))
+	if err != nil {
+		return fmt.Errorf("delete branch restriction request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete branch restriction failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// newBranchPermissionsURI builds stash branch permissions URI
+func newBranchPermissionsURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIbranchPermissions}, elements...), "/")
+}
+
+// BranchProtection groups the restrictions matching a single branch, which together make up
+// the protection of that branch.
+type BranchProtection struct {
+	// Branch is the name of the protected branch, e.g. main.
+	Branch string
+	// Restrictions are the restrictions matching the branch.
+	Restrictions []*BranchRestriction
+}
+
+// branchMatcher returns the matcher selecting the given branch.
+func branchMatcher(branch string) RefMatcher {
+	return RefMatcher{
+		ID:   fmt.Sprintf("refs/heads/%s", branch),
+		Type: RefMatcherType{ID: branchRestrictionMatcherID},
+	}
+}
+
+// matchesBranch returns true if the matcher selects the branch. Branch matchers select a single branch,
+// pattern matchers select the branches matching their ant-style pattern, where * and ? don't match slashes
+// and ** matches any number of path segments. Patterns not starting with refs/ may match at any level of the ref.
+func matchesBranch(m RefMatcher, branch string) bool {
+	ref := branchMatcher(branch).ID
+	switch m.Type.ID {
+	case branchRestrictionMatcherID:
+		return m.ID == ref
+	case patternMatcherID:
+		pattern := m.ID
+		if !strings.HasPrefix(pattern, "refs/") {
+			pattern = "**/" + pattern
+		}
+		return antPattern(pattern).MatchString(ref)
+	}
+	return false
+}
+
+// antPattern compiles an ant-style pattern to a regular expression matching the whole string.
+func antPattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListBranchRestrictions(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIbranchPermissions, projectsURI, RepositoriesURI, restrictionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		if got := r.URL.Query().Get("matcherId"); got != "refs/heads/main" {
+			t.Errorf("matcherId = %q, want %q", got, "refs/heads/main")
+		}
+		if got := r.URL.Query().Get("matcherType"); got != branchRestrictionMatcherID {
+			t.Errorf("matcherType = %q, want %q", got, branchRestrictionMatcherID)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"isLastPage": true, "values": [{
+			"id": 1,
+			"type": "no-deletes",
+			"matcher": {"id": "refs/heads/main", "displayId": "main", "type": {"id": "BRANCH", "name": "Branch"}, "active": true},
+			"users": [{"name": "admin", "displayName": "Administrator"}],
+			"groups": ["release-managers"]
+		}]}`)
+	})
+
+	restrictions, err := client.BranchRestrictions.All(context.Background(), "prj1", "repo1", "refs/heads/main")
+	if err != nil {
+		t.Fatalf("BranchRestrictions.All returned error: %v", err)
+	}
+	want := []*BranchRestriction{{
+		ID:   1,
+		Type: BranchRestrictionNoDeletes,
+		Matcher: RefMatcher{
+			ID:        "refs/heads/main",
+			DisplayID: "main",
+			Type:      RefMatcherType{ID: "BRANCH", Name: "Branch"},
+			Active:    true,
+		},
+		Users:  []string{"admin"},
+		Groups: []string{"release-managers"},
+	}}
+	if diff := cmp.Diff(want, restrictions, cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Session"
+	}, cmp.Ignore())); diff != "" {
+		t.Errorf("BranchRestrictions.All mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateBranchRestriction(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIbranchPermissions, projectsURI, RepositoriesURI, restrictionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		req := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		matcher := req["matcher"].(map[string]interface{})
+		if req["type"] != BranchRestrictionFastForwardOnly || matcher["id"] != "refs/heads/main" {
+			http.Error(w, "invalid restriction", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"id": 2, "type": "fast-forward-only", "matcher": {"id": "refs/heads/main", "displayId": "main", "type": {"id": "BRANCH"}}}`)
+	})
+
+	ctx := context.Background()
+	restriction, err := client.BranchRestrictions.Create(ctx, "prj1", "repo1", &BranchRestriction{
+		Type:    BranchRestrictionFastForwardOnly,
+		Matcher: branchMatcher("main"),
+	})
+	if err != nil {
+		t.Fatalf("BranchRestrictions.Create returned error: %v", err)
+	}
+	if restriction.ID != 2 {
+		t.Errorf("ID = %d, want 2", restriction.ID)
+	}
+
+	_, err = client.BranchRestrictions.Create(ctx, "prj1", "repo1", &BranchRestriction{
+		Type:    BranchRestrictionReadOnly,
+		Matcher: branchMatcher("main"),
+	})
+	if err == nil {
+		t.Error("BranchRestrictions.Create of an invalid restriction returned no error")
+	}
+}
+
+func TestDeleteBranchRestriction(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/3", stashURIbranchPermissions, projectsURI, RepositoriesURI, restrictionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.BranchRestrictions.Delete(ctx, "prj1", "repo1", 3); err != nil {
+		t.Fatalf("BranchRestrictions.Delete returned error: %v", err)
+	}
+	if err := client.BranchRestrictions.Delete(ctx, "prj1", "repo1", 4); err != ErrNotFound {
+		t.Errorf("BranchRestrictions.Delete returned error %v, want %v", err, ErrNotFound)
+	}
+}
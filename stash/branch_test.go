@@ -19,17 +19,23 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 func TestGetBranch(t *testing.T) {
 	tests := []struct {
 		name     string
 		branchID string
+		want     string
 	}{
 		{
 			name:     "test branch does not exist",
@@ -37,47 +43,65 @@ func TestGetBranch(t *testing.T) {
 		},
 		{
 			name:     "test main branch",
+			branchID: "main",
+			want:     "refs/heads/main",
+		},
+		{
+			name:     "test main branch reference",
 			branchID: "refs/heads/main",
+			want:     "refs/heads/main",
+		},
+		{
+			name:     "test branch on second page",
+			branchID: "feature",
+			want:     "refs/heads/feature",
 		},
 	}
 
-	validBranchID := []string{"refs/heads/main"}
+	branches := []*Branch{
+		{ID: "refs/heads/main-old", DisplayID: "main-old"},
+		{ID: "refs/heads/main", DisplayID: "main"},
+		{ID: "refs/heads/feature-1", DisplayID: "feature-1"},
+		{ID: "refs/heads/feature", DisplayID: "feature"},
+	}
 
 	mux, client := setup(t)
 
 	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, branchesURI)
 	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
-		for _, substr := range validBranchID {
-			if r.URL.Query().Get("filterText") == substr {
-				w.WriteHeader(http.StatusOK)
-				u := &Branch{
-					ID:        "refs/heads/main",
-					DisplayID: "main",
-				}
-				json.NewEncoder(w).Encode(u)
-				return
+		// Stash filters by substring, return one branch per page
+		filtered := []*Branch{}
+		for _, b := range branches {
+			if strings.Contains(b.DisplayID, r.URL.Query().Get("filterText")) {
+				filtered = append(filtered, b)
 			}
 		}
-
-		http.Error(w, "The specified branch does not exist", http.StatusNotFound)
-
-		return
-
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		list := &BranchList{
+			Paging: Paging{IsLastPage: start >= len(filtered)-1, NextPageStart: int64(start + 1)},
+		}
+		if start < len(filtered) {
+			list.Branches = filtered[start : start+1]
+		}
+		json.NewEncoder(w).Encode(list)
 	})
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 			b, err := client.Branches.Get(ctx, "prj1", "repo1", tt.branchID)
-			if err != nil {
-				if err != ErrNotFound {
-					t.Fatalf("Branches.Get returned error: %v", err)
+			if tt.want == "" {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("Branches.Get returned error %v, want %v", err, ErrNotFound)
 				}
 				return
 			}
+			if err != nil {
+				t.Fatalf("Branches.Get returned error: %v", err)
+			}
 
-			if b.ID != tt.branchID {
-				t.Errorf("Branches.Get returned branch:\n%s, want:\n%s", b.ID, tt.branchID)
+			if b.ID != tt.want {
+				t.Errorf("Branches.Get returned branch:\n%s, want:\n%s", b.ID, tt.want)
 			}
 
 		})
@@ -153,3 +177,167 @@ func TestDefaultBranch(t *testing.T) {
 		t.Errorf("Branches.Default returned branch:\n%s, want:\n %s", b.ID, d.ID)
 	}
 }
+
+func TestDeleteBranch(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIbranchUtil, projectsURI, RepositoriesURI, branchesURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		b := struct {
+			Name   string `json:"name"`
+			DryRun bool   `json:"dryRun"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if b.Name != "refs/heads/feature" || b.DryRun {
+			http.Error(w, "The specified branch does not exist", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.Branches.Delete(ctx, "prj1", "repo1", "refs/heads/feature"); err != nil {
+		t.Fatalf("Branches.Delete returned error: %v", err)
+	}
+	if err := client.Branches.Delete(ctx, "prj1", "repo1", "refs/heads/missing"); err != ErrNotFound {
+		t.Errorf("Branches.Delete returned error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestCreateBranchWithAPI(t *testing.T) {
+	mux, client := setup(t)
+
+	repoPath := fmt.Sprintf("%s/%s/prj1/%s/repo1", stashURIprefix, projectsURI, RepositoriesURI)
+	mux.HandleFunc(fmt.Sprintf("%s/%s/%s", repoPath, branchesURI, defaultBranchURI), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "refs/heads/main", "displayId": "main", "latestCommit": "c0"}`)
+	})
+	var startPoints []string
+	mux.HandleFunc(fmt.Sprintf("%s/%s", repoPath, branchesURI), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		req := struct {
+			Name       string `json:"name"`
+			StartPoint string `json:"startPoint"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Name != "feature" {
+			t.Errorf("branch name = %q, want %q", req.Name, "feature")
+		}
+		startPoints = append(startPoints, req.StartPoint)
+		fmt.Fprintf(w, `{"id": "refs/heads/feature", "displayId": "feature", "latestCommit": %q}`, req.StartPoint)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj1"}, RepositoryName: "repo1"}
+	ref.SetKey("prj1")
+	ref.SetSlug("repo1")
+	branchClient := &BranchClient{clientContext: &clientContext{client: client}, ref: ref}
+
+	if err := branchClient.Create(context.Background(), "feature", "c1"); err != nil {
+		t.Fatalf("BranchClient.Create returned error: %v", err)
+	}
+	// Without a commit, the branch starts at the head of the default branch
+	if err := branchClient.Create(context.Background(), "feature", ""); err != nil {
+		t.Fatalf("BranchClient.Create returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"c1", "c0"}, startPoints); diff != "" {
+		t.Errorf("start points mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBranchClientProtected(t *testing.T) {
+	mux, client := setup(t)
+
+	repoPath := fmt.Sprintf("%s/%s/prj1/%s/repo1", stashURIprefix, projectsURI, RepositoriesURI)
+	mux.HandleFunc(fmt.Sprintf("%s/%s", repoPath, branchesURI), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"isLastPage": true, "values": [
+			{"id": "refs/heads/main", "displayId": "main", "latestCommit": "c0"},
+			{"id": "refs/heads/feature", "displayId": "feature", "latestCommit": "c1"},
+			{"id": "refs/heads/release/1.0", "displayId": "release/1.0", "latestCommit": "c2"},
+			{"id": "refs/heads/team/release/2.0", "displayId": "team/release/2.0", "latestCommit": "c3"},
+			{"id": "refs/heads/release/1.0/fix", "displayId": "release/1.0/fix", "latestCommit": "c4"}
+		]}`)
+	})
+	forbidden := false
+	mux.HandleFunc(fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIbranchPermissions, projectsURI, RepositoriesURI, restrictionsURI), func(w http.ResponseWriter, r *http.Request) {
+		if forbidden {
+			http.Error(w, `{"errors": [{"message": "You are not permitted to access this resource"}]}`, http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"isLastPage": true, "values": [
+			{"id": 1, "type": "no-deletes", "matcher": {"id": "refs/heads/main", "type": {"id": "BRANCH"}}},
+			{"id": 2, "type": "fast-forward-only", "matcher": {"id": "release/*", "type": {"id": "PATTERN"}}}
+		]}`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj1"}, RepositoryName: "repo1"}
+	ref.SetKey("prj1")
+	ref.SetSlug("repo1")
+	branchClient := &BranchClient{clientContext: &clientContext{client: client}, ref: ref}
+
+	tests := []struct {
+		name      string
+		forbidden bool
+		want      []gitprovider.BranchInfo
+	}{
+		{
+			name: "branch and pattern matchers",
+			want: []gitprovider.BranchInfo{
+				{Name: "main", Sha: "c0", Protected: true},
+				{Name: "feature", Sha: "c1"},
+				{Name: "release/1.0", Sha: "c2", Protected: true},
+				{Name: "team/release/2.0", Sha: "c3", Protected: true},
+				{Name: "release/1.0/fix", Sha: "c4"},
+			},
+		},
+		{
+			name:      "restrictions not accessible",
+			forbidden: true,
+			want: []gitprovider.BranchInfo{
+				{Name: "main", Sha: "c0"},
+				{Name: "feature", Sha: "c1"},
+				{Name: "release/1.0", Sha: "c2"},
+				{Name: "team/release/2.0", Sha: "c3"},
+				{Name: "release/1.0/fix", Sha: "c4"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forbidden = tt.forbidden
+
+			ctx := context.Background()
+			branches, err := branchClient.List(ctx)
+			if err != nil {
+				t.Fatalf("BranchClient.List returned error: %v", err)
+			}
+			got := []gitprovider.BranchInfo{}
+			for _, b := range branches {
+				got = append(got, b.Get())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("BranchClient.List mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, info := range tt.want {
+				b, err := branchClient.Get(ctx, info.Name)
+				if err != nil {
+					t.Fatalf("BranchClient.Get returned error: %v", err)
+				}
+				if diff := cmp.Diff(info, b.Get()); diff != "" {
+					t.Errorf("BranchClient.Get mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if _, err := branchClient.Get(ctx, "missing"); !errors.Is(err, gitprovider.ErrNotFound) {
+				t.Errorf("BranchClient.Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+			}
+		})
+	}
+}
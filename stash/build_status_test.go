@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListBuildStatuses(t *testing.T) {
+	statuses := []*BuildStatus{
+		{State: BuildStatusStateSuccessful, Key: "ci/build", URL: "https://ci.example.com/1"},
+		{State: BuildStatusStateInProgress, Key: "cd/deploy", URL: "https://cd.example.com/1", Description: "deploying"},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/abc", stashURIbuildStatus, buildStatusCommitsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		l := struct {
+			BuildStatuses []*BuildStatus `json:"values"`
+		}{statuses}
+		json.NewEncoder(w).Encode(l)
+	})
+
+	ctx := context.Background()
+	list, err := client.BuildStatuses.List(ctx, "abc", nil)
+	if err != nil {
+		t.Fatalf("BuildStatuses.List returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(statuses, list.BuildStatuses); diff != "" {
+		t.Errorf("BuildStatuses.List returned diff (want -> got):\n%s", diff)
+	}
+}
+
+func TestCreateBuildStatus(t *testing.T) {
+	status := &BuildStatus{State: BuildStatusStateFailed, Key: "ci/build", Name: "ci/build", URL: "https://ci.example.com/1"}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/abc", stashURIbuildStatus, buildStatusCommitsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("BuildStatuses.Create used method %s, want %s", r.Method, http.MethodPost)
+		}
+		req := &BuildStatus{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if diff := cmp.Diff(status, req); diff != "" {
+			t.Errorf("BuildStatuses.Create sent diff (want -> got):\n%s", diff)
+		}
+		// Bitbucket Server responds without a body
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.BuildStatuses.Create(ctx, "abc", status); err != nil {
+		t.Fatalf("BuildStatuses.Create returned error: %v", err)
+	}
+}
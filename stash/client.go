@@ -104,15 +104,21 @@ type Client struct {
 	caBundle []byte
 
 	// Services are used to communicate with the different stash endpoints.
-	Users        Users
-	Groups       Groups
-	Projects     Projects
-	Git          Git
-	Repositories Repositories
-	Branches     Branches
-	Commits      Commits
-	PullRequests PullRequests
-	DeployKeys   DeployKeys
+	Users               Users
+	Groups              Groups
+	Projects            Projects
+	Git                 Git
+	Repositories        Repositories
+	Branches            Branches
+	BranchRestrictions  BranchRestrictions
+	Commits             Commits
+	PullRequests        PullRequests
+	PullRequestComments PullRequestComments
+	DeployKeys          DeployKeys
+	Webhooks            Webhooks
+	Files               Files
+	Tags                Tags
+	BuildStatuses       BuildStatuses
 }
 
 // RateLimiter is the interface that wraps the basic Wait method.
@@ -226,9 +232,15 @@ func NewClient(httpClient *http.Client, host string, header *http.Header, logger
 	c.Git = &GitService{Client: c}
 	c.Repositories = &RepositoriesService{Client: c}
 	c.Branches = &BranchesService{Client: c}
+	c.BranchRestrictions = &BranchRestrictionsService{Client: c}
 	c.Commits = &CommitsService{Client: c}
 	c.PullRequests = &PullRequestsService{Client: c}
+	c.PullRequestComments = &PullRequestCommentsService{Client: c}
 	c.DeployKeys = &DeployKeysService{Client: c}
+	c.Webhooks = &WebhooksService{Client: c}
+	c.Files = &FilesService{Client: c}
+	c.Tags = &TagsService{Client: c}
+	c.BuildStatuses = &BuildStatusService{Client: c}
 
 	return c, nil
 }
@@ -501,7 +513,7 @@ func (c *Client) Do(request *http.Request) ([]byte, *http.Response, error) {
 	}
 
 	if resp.StatusCode == http.StatusOK || (resp.StatusCode == http.StatusCreated && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodDelete) ||
-		(resp.StatusCode == http.StatusAccepted && request.Method == http.MethodDelete) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodPut) || resp.StatusCode == http.StatusBadRequest {
+		(resp.StatusCode == http.StatusAccepted && request.Method == http.MethodDelete) || (resp.StatusCode == http.StatusNoContent && (request.Method == http.MethodPut || request.Method == http.MethodPost)) || resp.StatusCode == http.StatusBadRequest {
 		return resBytes, resp, nil
 	}
 
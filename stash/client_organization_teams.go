@@ -18,6 +18,7 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -49,6 +50,9 @@ func getGroupMemberSlugs(users []*User) []string {
 func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Team, error) {
 	users, err := c.client.Groups.AllGroupMembers(ctx, teamName)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
 		return nil, err
 	}
 
@@ -67,6 +71,7 @@ func (c *TeamsClient) Get(ctx context.Context, teamName string) (gitprovider.Tea
 	team := &Team{
 		ref:   c.ref,
 		users: users,
+		c:     c,
 	}
 
 	team.info = gitprovider.TeamInfo{
@@ -119,6 +124,70 @@ func (c *TeamsClient) List(ctx context.Context) ([]gitprovider.Team, error) {
 	return teams, nil
 }
 
+// Create creates a stash group with the given members, and grants it read access to the project,
+// such that it's listed as one of the project's teams.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *TeamsClient) Create(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.client.Groups.Create(ctx, req.Name); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return nil, gitprovider.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create group %s: %w", req.Name, err)
+	}
+
+	permission := &ProjectGroupPermission{Permission: "PROJECT_READ"}
+	permission.Group.Name = req.Name
+	if err := c.client.Projects.UpdateProjectGroupPermission(ctx, c.ref.Key(), permission); err != nil {
+		return nil, fmt.Errorf("failed to grant group %s access to project %s: %w", req.Name, c.ref.Key(), err)
+	}
+
+	if len(req.Members) > 0 {
+		if err := c.client.Groups.AddUsers(ctx, req.Name, req.Members...); err != nil {
+			return nil, fmt.Errorf("failed to add members to group %s: %w", req.Name, err)
+		}
+	}
+
+	return c.Get(ctx, req.Name)
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *TeamsClient) Reconcile(ctx context.Context, req gitprovider.TeamInfo) (gitprovider.Team, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(req); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
 func validateProjectGroupPermissionAPI(apiObj *ProjectGroupPermission) error {
 	return validateAPIObject("Stash.ProjectGroupPermission", func(validator validation.Validator) {
 		if apiObj.Group.Name == "" {
@@ -18,7 +18,9 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -42,6 +44,9 @@ func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.Organizat
 	}
 	apiObj, err := c.client.Projects.Get(ctx, ref.Organization)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to get organization %q: %w", ref.Organization, err)
 	}
 
@@ -97,6 +102,100 @@ func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.Organiza
 	return nil, gitprovider.ErrNoProviderSupport
 }
 
+// Create creates a project, named after the organization in the OrganizationRef.
+// The project key is taken from the OrganizationRef if set, and otherwise derived from the name.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *OrganizationsClient) Create(ctx context.Context, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (gitprovider.Organization, error) {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.host); err != nil {
+		return nil, err
+	}
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	key := ref.Key()
+	if key == "" {
+		key = projectKey(ref.Organization)
+	}
+	if key == "" {
+		return nil, fmt.Errorf("cannot derive a project key from %q: %w", ref.Organization, gitprovider.ErrInvalidArgument)
+	}
+
+	data := &Project{
+		Key:  key,
+		Name: ref.Organization,
+	}
+	organizationInfoToAPIObj(&req, data)
+
+	apiObj, err := c.client.Projects.Create(ctx, data)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return nil, gitprovider.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create organization %q: %w", ref.Organization, err)
+	}
+
+	// Validate the API objects
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, err
+	}
+
+	ref.Organization = apiObj.Name
+	ref.SetKey(apiObj.Key)
+
+	return newOrganization(c.clientContext, apiObj, ref), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrganizationsClient) Reconcile(ctx context.Context, ref gitprovider.OrganizationRef, req gitprovider.OrganizationInfo) (gitprovider.Organization, bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, ref, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+	// Populate the desired state to the current-actual object, and apply it
+	if err := actual.Set(req); err != nil {
+		return nil, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// projectKey derives a project key from the given project name. Project keys may only
+// contain upper case letters, digits and underscores, and must start with a letter.
+func projectKey(name string) string {
+	key := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return -1
+	}, name)
+	return strings.TrimLeft(key, "0123456789_")
+}
+
 // validateOrganizationRef makes sure the OrganizationRef is valid for stash usage.
 func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
 	// Make sure the OrganizationRef fields are valid
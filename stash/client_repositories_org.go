@@ -143,6 +143,12 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context,
 // If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 // If req is already the actual state, this is a no-op (actionTaken == false).
 func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
@@ -183,8 +189,12 @@ func update(ctx context.Context, c *Client, orgKey, repoSlug string, repository
 	return apiObj, nil
 }
 
-func deleteRepository(ctx context.Context, c *Client, orgKey, repoSlug string) error {
-	if err := c.Repositories.Delete(ctx, orgKey, repoSlug); err != nil {
+func deleteRepository(ctx context.Context, c *clientContext, orgKey, repoSlug string) error {
+	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete repository: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if err := c.client.Repositories.Delete(ctx, orgKey, repoSlug); err != nil {
 		return fmt.Errorf("failed to delete repository: %w", err)
 	}
 
@@ -256,7 +266,9 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 			return nil, fmt.Errorf("failed to create initial commit: %w", err)
 		}
 
-		err = initRepo(ctx, c, initCommit, repo)
+		if err := initRepo(ctx, c, initCommit, repo); err != nil {
+			return nil, err
+		}
 
 		if data.DefaultBranch != "" && data.DefaultBranch != legacyBranch {
 			//create default branch
@@ -282,7 +294,9 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 			return nil, fmt.Errorf("failed to create initial commit: %w", err)
 		}
 
-		err = initRepo(ctx, c, initCommit, repo)
+		if err := initRepo(ctx, c, initCommit, repo); err != nil {
+			return nil, err
+		}
 		br, err := setDefaultBranch(ctx, c, orgKey, data.DefaultBranch, repo)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create default branch: %w", err)
@@ -408,6 +422,16 @@ func getStashRefs(ref gitprovider.RepositoryRef) (string, string) {
 	return projectKey, repoSlug
 }
 
+// getRepositoryRefs is like getStashRefs, but returns the project key of the personal
+// project of the user for user repositories.
+func getRepositoryRefs(ref gitprovider.RepositoryRef) (string, string) {
+	projectKey, repoSlug := getStashRefs(ref)
+	if r, ok := ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+	return projectKey, repoSlug
+}
+
 // validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
 // valid for our use.
 func validateRepositoryAPI(apiObj *Repository) error {
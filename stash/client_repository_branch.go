@@ -18,6 +18,7 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -32,16 +33,117 @@ type BranchClient struct {
 	ref gitprovider.RepositoryRef
 }
 
+// List lists all branches in the repository.
+//
+// List returns all available branches, using multiple paginated requests if needed.
+func (c *BranchClient) List(ctx context.Context) ([]gitprovider.Branch, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObjs, err := c.client.Branches.All(ctx, projectKey, repoSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	matchers, err := c.branchMatchers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]gitprovider.Branch, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		branches = append(branches, newBranch(apiObj, isProtected(matchers, apiObj.DisplayID)))
+	}
+	return branches, nil
+}
+
+// Get returns the branch with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Get(ctx context.Context, branch string) (gitprovider.Branch, error) {
+	apiObj, err := c.get(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	matchers, err := c.branchMatchers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newBranch(apiObj, isProtected(matchers, apiObj.DisplayID)), nil
+}
+
+func (c *BranchClient) get(ctx context.Context, branch string) (*Branch, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObj, err := c.client.Branches.Get(ctx, projectKey, repoSlug, branch)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("branch %s: %w", branch, gitprovider.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get branch %s: %w", branch, err)
+	}
+	return apiObj, nil
+}
+
+// branchMatchers returns the matchers of the branch restrictions of the repository, see BranchProtectionClient.
+// Listing the restrictions requires admin permissions on the repository. If the token isn't allowed to list
+// them, the protection of the branches is unknown and no matchers are returned.
+func (c *BranchClient) branchMatchers(ctx context.Context) ([]RefMatcher, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObjs, err := c.client.BranchRestrictions.All(ctx, projectKey, repoSlug, "")
+	if err != nil {
+		if errors.Is(err, ErrPermissionDenied) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list branch restrictions: %w", err)
+	}
+
+	matchers := make([]RefMatcher, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		matchers = append(matchers, apiObj.Matcher)
+	}
+	return matchers, nil
+}
+
+// isProtected returns true if any of the matchers selects the branch.
+func isProtected(matchers []RefMatcher, branch string) bool {
+	for _, m := range matchers {
+		if matchesBranch(m, branch) {
+			return true
+		}
+	}
+	return false
+}
+
 // Create creates a branch with the given specifications.
+// The branch starts at the commit sha, or at the head of the default branch if sha is empty.
 func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
-	projectKey, repoSlug := getStashRefs(c.ref)
+	if c.gitClone {
+		return c.createWithGit(ctx, branch, sha)
+	}
 
-	// check if it is a user repository
-	// if yes, we need to add a tilde to the user login and use it as the project key
-	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
-		projectKey = addTilde(r.UserLogin)
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+	startPoint := sha
+	if startPoint == "" {
+		defaultBranch, err := c.client.Branches.Default(ctx, projectKey, repoSlug)
+		if err != nil {
+			return fmt.Errorf("failed to get default branch: %w", err)
+		}
+		startPoint = defaultBranch.LatestCommit
 	}
 
+	if _, err := c.client.Branches.Create(ctx, projectKey, repoSlug, branch, startPoint); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("repository %s/%s: %w", projectKey, repoSlug, gitprovider.ErrNotFound)
+		}
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// createWithGit creates the branch in a clone of the repository, and pushes it.
+func (c *BranchClient) createWithGit(ctx context.Context, branch, sha string) error {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
 	repo, err := c.client.Repositories.Get(ctx, projectKey, repoSlug)
 	if err != nil {
 		return fmt.Errorf("failed to get repository %s/%s: %w", projectKey, repoSlug, err)
@@ -71,6 +173,14 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 		}),
 		WithMessage("Create branch"),
 		WithURL(url))
+	if err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+	if c.signKey != nil {
+		if err := WithSignature(c.signKey)(commit); err != nil {
+			return err
+		}
+	}
 
 	_, err = c.client.Git.CreateCommit(dir, r, "", commit)
 	if err != nil {
@@ -90,14 +200,46 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 	return nil
 }
 
-func (c *BranchClient) getDefault(ctx context.Context) (string, error) {
-	projectKey, repoSlug := getStashRefs(c.ref)
+// Delete deletes the branch with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *BranchClient) Delete(ctx context.Context, branch string) error {
+	// Don't allow deleting branches if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
 
-	// check if it is a user repository
-	// if yes, we need to add a tilde to the user login and use it as the project key
-	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
-		projectKey = addTilde(r.UserLogin)
+	apiObj, err := c.get(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+	if err := c.client.Branches.Delete(ctx, projectKey, repoSlug, apiObj.ID); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// SetDefault makes the branch with the given name the default branch of the repository.
+//
+// ErrNotFound is returned if the branch does not exist.
+func (c *BranchClient) SetDefault(ctx context.Context, branch string) error {
+	apiObj, err := c.get(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+	if err := c.client.Branches.SetDefault(ctx, projectKey, repoSlug, apiObj.ID); err != nil {
+		return fmt.Errorf("failed to set default branch: %w", err)
 	}
+	return nil
+}
+
+func (c *BranchClient) getDefault(ctx context.Context) (string, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
 
 	b, err := c.client.Branches.Default(ctx, projectKey, repoSlug)
 	if err != nil {
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// BranchProtectionClient implements the gitprovider.BranchProtectionClient interface.
+var _ gitprovider.BranchProtectionClient = &BranchProtectionClient{}
+
+// BranchProtectionClient operates on the branch restrictions of a specific repository.
+//
+// A branch is protected if it has at least one branch restriction. Protected branches
+// can't be deleted, and unless force pushes are allowed, their history can't be rewritten.
+// Branch restrictions apply to administrators too, and stash doesn't support required
+// approvals or status checks per branch.
+type BranchProtectionClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the protection of the branch with the given name.
+//
+// ErrNotFound is returned if the branch isn't protected.
+func (c *BranchProtectionClient) Get(ctx context.Context, branch string) (gitprovider.BranchProtection, error) {
+	apiObj, err := c.get(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	return newBranchProtection(c, apiObj), nil
+}
+
+func (c *BranchProtectionClient) get(ctx context.Context, branch string) (*BranchProtection, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	matcher := branchMatcher(branch)
+	apiObjs, err := c.client.BranchRestrictions.All(ctx, projectKey, repoSlug, matcher.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get protection of branch %s: %w", branch, err)
+	}
+
+	protections := groupBranchRestrictions(apiObjs)
+	if len(protections) == 0 {
+		return nil, fmt.Errorf("protection of branch %s: %w", branch, gitprovider.ErrNotFound)
+	}
+	return protections[0], nil
+}
+
+// List lists the protections of all protected branches in the repository.
+//
+// List returns all available branch protections, using multiple paginated requests if needed.
+func (c *BranchProtectionClient) List(ctx context.Context) ([]gitprovider.BranchProtection, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObjs, err := c.client.BranchRestrictions.All(ctx, projectKey, repoSlug, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch restrictions: %w", err)
+	}
+
+	protections := []gitprovider.BranchProtection{}
+	for _, apiObj := range groupBranchRestrictions(apiObjs) {
+		protections = append(protections, newBranchProtection(c, apiObj))
+	}
+	return protections, nil
+}
+
+// Create protects a branch with the given specifications.
+//
+// ErrAlreadyExists will be returned if the branch is protected already.
+func (c *BranchProtectionClient) Create(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	if err := validateBranchProtectionInfo(req); err != nil {
+		return nil, err
+	}
+
+	// Restrictions are created one by one, hence make sure the branch isn't protected yet
+	_, err := c.get(ctx, req.Branch)
+	if err == nil {
+		return nil, fmt.Errorf("protection of branch %s: %w", req.Branch, gitprovider.ErrAlreadyExists)
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	bp := newBranchProtection(c, branchProtectionToAPI(&req))
+	if err := bp.update(ctx, &BranchProtection{Branch: req.Branch}); err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *BranchProtectionClient) Reconcile(ctx context.Context, req gitprovider.BranchProtectionInfo) (gitprovider.BranchProtection, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	// Get the protection of the desired branch
+	actual, err := c.Get(ctx, req.Branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// Delete removes the protection of the branch with the given name.
+//
+// ErrNotFound is returned if the branch isn't protected.
+func (c *BranchProtectionClient) Delete(ctx context.Context, branch string) error {
+	apiObj, err := c.get(ctx, branch)
+	if err != nil {
+		return err
+	}
+	return newBranchProtection(c, apiObj).Delete(ctx)
+}
+
+// groupBranchRestrictions groups the restrictions matching single branches by branch,
+// in the order the branches first occur. Restrictions matching e.g. patterns are ignored.
+func groupBranchRestrictions(apiObjs []*BranchRestriction) []*BranchProtection {
+	protections := []*BranchProtection{}
+	byID := map[string]*BranchProtection{}
+	for _, apiObj := range apiObjs {
+		if apiObj.Matcher.Type.ID != branchRestrictionMatcherID {
+			continue
+		}
+		bp, ok := byID[apiObj.Matcher.ID]
+		if !ok {
+			bp = &BranchProtection{Branch: strings.TrimPrefix(apiObj.Matcher.ID, "refs/heads/")}
+			byID[apiObj.Matcher.ID] = bp
+			protections = append(protections, bp)
+		}
+		bp.Restrictions = append(bp.Restrictions, apiObj)
+	}
+	return protections
+}
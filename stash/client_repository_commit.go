@@ -18,9 +18,11 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
 )
 
 // CommitClient implements the gitprovider.CommitClient interface.
@@ -47,13 +49,7 @@ func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, pag
 }
 
 func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
-	projectKey, repoSlug := getStashRefs(c.ref)
-
-	// check if it is a user repository
-	// if yes, we need to add a tilde to the user login and use it as the project key
-	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
-		projectKey = addTilde(r.UserLogin)
-	}
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
 
 	apiObjs, err := c.client.Commits.ListPage(ctx, projectKey, repoSlug, branch, perPage, page)
 	if err != nil {
@@ -69,13 +65,116 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 }
 
 // Create creates a commit with the given specifications.
+//
+// The REST API commits a single file per request, hence commits adding or updating a single regular
+// file are created through it. Other commits, i.e. with several files, or deleting, moving or changing
+// the mode of files, are created in a clone of the repository, which commits all files at once.
+// Creating the client with WithGitClone always uses a clone, e.g. for signing commits.
 func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
-	projectKey, repoSlug := getStashRefs(c.ref)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added")
+	}
+	for _, file := range files {
+		if err := file.Validate(); err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+	}
+	if c.gitClone || !editable(files) {
+		return c.createWithGit(ctx, branch, message, files)
+	}
+
+	file := files[0]
+	// Validated above
+	content, _ := file.DecodedContent()
+
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+	head, sourceBranch, err := c.head(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Browsing a single line is enough to know whether the file exists
+	_, err = c.client.Files.Browse(ctx, projectKey, repoSlug, *file.Path, head, &PagingOptions{Limit: 1})
+	exists := err == nil
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to get file %s: %w", *file.Path, err)
+	}
+	switch {
+	case exists && file.GetAction() == gitprovider.CommitFileActionAdd:
+		return nil, fmt.Errorf("cannot add %q: %w", *file.Path, gitprovider.ErrAlreadyExists)
+	case !exists && file.GetAction() == gitprovider.CommitFileActionUpdate:
+		return nil, fmt.Errorf("cannot update %q: %w", *file.Path, gitprovider.ErrNotFound)
+	}
+
+	edit := &FileEdit{
+		Branch:       branch,
+		Content:      string(content),
+		Message:      message,
+		SourceBranch: sourceBranch,
+	}
+	// New files can't have a source commit
+	if exists {
+		edit.SourceCommitID = head
+	}
+	commit, err := c.client.Files.Edit(ctx, projectKey, repoSlug, *file.Path, edit)
+	if err != nil {
+		if errors.Is(err, ErrFileConflict) {
+			return nil, fmt.Errorf("file %q: %w", *file.Path, gitprovider.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to commit file %s: %w", *file.Path, err)
+	}
+
+	return newCommit(commit), nil
+}
 
-	// check if it is a user repository
-	// if yes, we need to add a tilde to the user login and use it as the project key
-	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
-		projectKey = addTilde(r.UserLogin)
+// editable returns true if the commit adds or updates the content of a single file, without
+// setting its mode, which the file edit endpoint can commit.
+func editable(files []gitprovider.CommitFile) bool {
+	if len(files) != 1 {
+		return false
+	}
+	action := files[0].GetAction()
+	return action != gitprovider.CommitFileActionDelete && action != gitprovider.CommitFileActionMove && files[0].Mode == nil
+}
+
+// head returns the latest commit of the branch. If the branch doesn't exist, the latest commit of
+// the default branch is returned, along with the name of the default branch to create it from.
+func (c *CommitClient) head(ctx context.Context, branch string) (string, string, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	commits, err := c.client.Commits.ListPage(ctx, projectKey, repoSlug, branch, 1, 0)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", "", fmt.Errorf("failed to get head of branch %s: %w", branch, err)
+	}
+	if len(commits) != 0 {
+		return commits[0].ID, "", nil
+	}
+
+	defaultBranch, err := c.client.Branches.Default(ctx, projectKey, repoSlug)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+	return defaultBranch.LatestCommit, defaultBranch.DisplayID, nil
+}
+
+// createWithGit creates the commit in a clone of the repository, and pushes it.
+func (c *CommitClient) createWithGit(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	f := make([]CommitFile, 0, len(files))
+	for _, file := range files {
+		if err := file.Validate(); err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+		content, err := file.DecodedContent()
+		if err != nil {
+			return nil, validation.NewMultiError(err, gitprovider.ErrInvalidArgument)
+		}
+		commitFile := CommitFile{Path: file.Path, PreviousPath: file.PreviousPath, Mode: file.Mode}
+		if content != nil {
+			commitFile.Content = gitprovider.StringVar(string(content))
+		}
+		f = append(f, commitFile)
 	}
 
 	repo, err := c.client.Repositories.Get(ctx, projectKey, repoSlug)
@@ -94,10 +193,6 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 		return nil, fmt.Errorf("failed to clone repository %s: %w", url, err)
 	}
 
-	f := make([]CommitFile, 0, len(files))
-	for _, file := range files {
-		f = append(f, CommitFile{Path: file.Path, Content: file.Content})
-	}
 	commit, err := NewCommit(
 		WithAuthor(&CommitAuthor{
 			Name:  user.Name,
@@ -106,6 +201,14 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 		WithMessage(message),
 		WithURL(url),
 		WithFiles(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+	if c.signKey != nil {
+		if err := WithSignature(c.signKey)(commit); err != nil {
+			return nil, err
+		}
+	}
 
 	result, err := c.client.Git.CreateCommit(dir, r, branch, commit)
 	if err != nil {
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// CommitStatusClient implements the gitprovider.CommitStatusClient interface.
+var _ gitprovider.CommitStatusClient = &CommitStatusClient{}
+
+// CommitStatusClient operates on the commit statuses of a specific repository, using the
+// build statuses of Bitbucket Server. The Context of a status is the key of the build, and
+// a TargetURL is required when creating a status.
+type CommitStatusClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List returns the latest status of each context for the commit with the given sha.
+//
+// List returns all available statuses, using multiple paginated requests if needed.
+func (c *CommitStatusClient) List(ctx context.Context, sha string) ([]gitprovider.CommitStatus, error) {
+	apiObjs, err := c.client.BuildStatuses.All(ctx, sha)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list build statuses: %w", err)
+	}
+
+	statuses := make([]gitprovider.CommitStatus, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		statuses = append(statuses, newCommitStatus(apiObj))
+	}
+	return statuses, nil
+}
+
+// Create reports a status with the given specifications for the commit with the given sha,
+// replacing the status of the same context, if any.
+func (c *CommitStatusClient) Create(ctx context.Context, sha string, req gitprovider.CommitStatusInfo) (gitprovider.CommitStatus, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	if req.TargetURL == nil {
+		validator := validation.New("CommitStatus")
+		validator.Required("TargetURL")
+		return nil, validator.Error()
+	}
+
+	apiObj := commitStatusToAPI(req)
+	if err := c.client.BuildStatuses.Create(ctx, sha, apiObj); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("commit %s: %w", sha, gitprovider.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to create build status: %w", err)
+	}
+	// Bitbucket Server doesn't return the created build status
+	return newCommitStatus(apiObj), nil
+}
@@ -121,6 +121,15 @@ func createDeployKey(ctx context.Context, c *DeployKeyClient, req gitprovider.De
 		return nil, err
 	}
 
+	// Stash adds keys which exist already again, hence check for the name first
+	_, err := c.get(ctx, req.Name)
+	if err == nil {
+		return nil, fmt.Errorf("deploy key %q: %w", req.Name, gitprovider.ErrAlreadyExists)
+	}
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -209,9 +218,13 @@ func (c *DeployKeyClient) delete(ctx context.Context, req gitprovider.DeployKeyI
 		projectKey = addTilde(r.UserLogin)
 	}
 
-	key := deployKeyToAPI(projectKey, repoSlug, &req)
+	// The ID of the key isn't part of req, hence look it up by name
+	key, err := c.get(ctx, req.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get deploy key %q: %w", req.Name, err)
+	}
 	// Delete the old key
-	if err := c.client.DeployKeys.Delete(ctx, key.Project.Key, key.Repository.Slug, key.Key.ID); err != nil {
+	if err := c.client.DeployKeys.Delete(ctx, projectKey, repoSlug, key.Key.ID); err != nil {
 		return fmt.Errorf("failed to delete deploy key %q: %w", req.Name, err)
 	}
 
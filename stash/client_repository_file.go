@@ -18,7 +18,11 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -32,7 +36,129 @@ type FileClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// Get fetches and returns the contents of a file from a given branch and path
-func (c *FileClient) Get(_ context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
-	return nil, fmt.Errorf("error getting file %s@%s. not implemented in stash yet", path, branch)
+// Get fetches and returns the contents of a file or multiple files in a directory from a given branch and path with possible options of FilesGetOption
+// If a file path is given, the contents of the file are returned
+// If a directory path is given, the contents of the files in the path's root are returned,
+// or the contents of all files in the directory and its subdirectories if FilesGetOptions.Recursive is set
+func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...gitprovider.FilesGetOption) ([]*gitprovider.CommitFile, error) {
+	fileOpts := gitprovider.FilesGetOptions{}
+	for _, opt := range optFns {
+		opt.ApplyFilesGetOptions(&fileOpts)
+	}
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	// Browsing a file returns its first lines, a single one is enough to tell it apart from a directory
+	b, err := c.client.Files.Browse(ctx, projectKey, repoSlug, path, branch, &PagingOptions{Limit: 1})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("path %s@%s: %w", path, branch, gitprovider.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to browse %s@%s: %w", path, branch, err)
+	}
+
+	var paths []string
+	switch {
+	case !b.IsDirectory():
+		paths = []string{path}
+	case fileOpts.Recursive:
+		files, err := c.client.Files.All(ctx, projectKey, repoSlug, path, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in %s@%s: %w", path, branch, err)
+		}
+		for _, file := range files {
+			paths = append(paths, joinFilePath(path, file))
+		}
+	default:
+		children, err := c.client.Files.AllChildren(ctx, projectKey, repoSlug, path, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in %s@%s: %w", path, branch, err)
+		}
+		for _, child := range children {
+			if child.Type == entryTypeFile {
+				paths = append(paths, joinFilePath(path, child.Path.ToString))
+			}
+		}
+	}
+
+	files := make([]*gitprovider.CommitFile, 0, len(paths))
+	for _, filePath := range paths {
+		raw, err := c.client.Files.Raw(ctx, projectKey, repoSlug, filePath, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file %s@%s: %w", filePath, branch, err)
+		}
+		files = append(files, &gitprovider.CommitFile{
+			Path:    gitprovider.StringVar(filePath),
+			Content: gitprovider.StringVar(string(raw)),
+			SHA:     gitprovider.StringVar(blobSHA(raw)),
+		})
+	}
+	return files, nil
+}
+
+// Put creates or updates the file at path on the given branch, committing content with message.
+// ErrConflict is returned if the blob SHA of the file isn't expectedSHA, which is empty for new files.
+//
+// Stash doesn't report blob SHAs, hence the SHA of the file is computed from its content at the
+// head of the branch. The edit is based on that commit, so that concurrent changes are rejected.
+func (c *FileClient) Put(ctx context.Context, path, branch, content, message, expectedSHA string) error {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	commits, err := c.client.Commits.ListPage(ctx, projectKey, repoSlug, branch, 1, 0)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("branch %s: %w", branch, gitprovider.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get head of branch %s: %w", branch, err)
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("branch %s: %w", branch, gitprovider.ErrNotFound)
+	}
+	head := commits[0].ID
+
+	actualSHA := ""
+	raw, err := c.client.Files.Raw(ctx, projectKey, repoSlug, path, head)
+	if err == nil {
+		actualSHA = blobSHA(raw)
+	} else if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to get file %s@%s: %w", path, branch, err)
+	}
+	if actualSHA != expectedSHA {
+		return fmt.Errorf("file %q has SHA %q, expected %q: %w", path, actualSHA, expectedSHA, gitprovider.ErrConflict)
+	}
+
+	edit := &FileEdit{
+		Branch:  branch,
+		Content: content,
+		Message: message,
+	}
+	// New files can't have a source commit
+	if actualSHA != "" {
+		edit.SourceCommitID = head
+	}
+	if _, err := c.client.Files.Edit(ctx, projectKey, repoSlug, path, edit); err != nil {
+		if errors.Is(err, ErrFileConflict) {
+			return fmt.Errorf("file %q: %w", path, gitprovider.ErrConflict)
+		}
+		return fmt.Errorf("failed to put file %s@%s: %w", path, branch, err)
+	}
+	return nil
+}
+
+// Delete deletes the file at path on the given branch.
+// This is not supported in Stash, whose REST API can only create and edit files.
+func (c *FileClient) Delete(_ context.Context, path, branch, _, _ string) error {
+	return fmt.Errorf("error deleting file %s@%s: %w", path, branch, gitprovider.ErrNoProviderSupport)
+}
+
+// blobSHA returns the git blob SHA of content, which Stash doesn't report.
+func blobSHA(content []byte) string {
+	return plumbing.ComputeHash(plumbing.BlobObject, content).String()
+}
+
+// joinFilePath returns the path of name in the directory dir.
+func joinFilePath(dir, name string) string {
+	if dir = strings.Trim(dir, "/"); dir == "" {
+		return name
+	}
+	return dir + "/" + name
 }
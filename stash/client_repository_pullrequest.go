@@ -27,6 +27,14 @@ import (
 // PullRequestClient implements the gitprovider.PullRequestClient interface.
 var _ gitprovider.PullRequestClient = &PullRequestClient{}
 
+// mergeStrategies maps merge methods to the IDs of Bitbucket Server merge strategies.
+var mergeStrategies = map[gitprovider.MergeMethod]string{
+	gitprovider.MergeMethodMerge:           "no-ff",
+	gitprovider.MergeMethodSquash:          "squash",
+	gitprovider.MergeMethodRebase:          "rebase-ff-only",
+	gitprovider.MergeMethodFastForwardOnly: "ff-only",
+}
+
 // PullRequestClient operates on the pull requests for a specific repository.
 type PullRequestClient struct {
 	*clientContext
@@ -47,12 +55,18 @@ func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.Pu
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
-	return newPullRequest(pr), nil
+	return newPullRequest(c.clientContext, c.ref, pr), nil
 
 }
 
-// List returns all pull requests for the given repository.
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
+// List returns all pull requests for the given repository, in all states unless filtered
+// with PullRequestListOptions.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -61,24 +75,127 @@ func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest
 		projectKey = addTilde(r.UserLogin)
 	}
 
-	apiObjs, err := c.client.PullRequests.All(ctx, projectKey, repoSlug)
+	// Stash can only filter on one ref at a time, the other one is matched client side
+	filter := &PullRequestFilter{State: PullRequestStateAll}
+	if o.State != nil {
+		filter.State = pullRequestStates[*o.State]
+	}
+	switch {
+	case o.TargetBranch != nil:
+		filter.At = fmt.Sprintf("refs/heads/%s", *o.TargetBranch)
+		filter.Direction = PullRequestDirectionIncoming
+	case o.SourceBranch != nil:
+		filter.At = fmt.Sprintf("refs/heads/%s", *o.SourceBranch)
+		filter.Direction = PullRequestDirectionOutgoing
+	}
+
+	apiObjs, err := c.client.PullRequests.AllFiltered(ctx, projectKey, repoSlug, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests: %w", err)
 	}
 
-	// Traverse the list, and return a list of OrgRepository objects
+	// Traverse the list, and return a list of PullRequest objects
 	prs := make([]gitprovider.PullRequest, 0, len(apiObjs))
 	for _, apiObj := range apiObjs {
-		prs = append(prs, newPullRequest(apiObj))
+		pr := newPullRequest(c.clientContext, c.ref, apiObj)
+		if o.Matches(pr.Get()) {
+			prs = append(prs, pr)
+		}
 	}
 
 	return prs, nil
+}
+
+// Update changes the pull request with the given number to req. Unset fields of req are left
+// unchanged.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Update(ctx context.Context, number int, req gitprovider.PullRequestUpdateInfo) (gitprovider.PullRequest, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
 
+	// Get the pull request first, the update must carry its current version
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	if req.Title != nil {
+		pr.Title = *req.Title
+	}
+	if req.Description != nil {
+		pr.Description = *req.Description
+	}
+	if req.TargetBranch != nil {
+		pr.ToRef = Ref{
+			ID:         fmt.Sprintf("refs/heads/%s", *req.TargetBranch),
+			Repository: pr.ToRef.Repository,
+		}
+	}
+
+	updated, err := c.client.PullRequests.Update(ctx, projectKey, repoSlug, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+	return newPullRequest(c.clientContext, c.ref, updated), nil
 }
 
-// Merge merges the pull request.
-// Stash does not support message and merge strategy options for pull requests automatic merges.
-func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider.MergeMethod, _ string) error {
+// Close declines the pull request with the given number.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestClient) Close(ctx context.Context, number int) error {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	// Get the pull request first, declining requires its current version
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	_, err = c.client.PullRequests.Decline(ctx, projectKey, repoSlug, pr.ID, pr.Version)
+	if err != nil {
+		return fmt.Errorf("failed to decline pull request: %w", err)
+	}
+
+	return nil
+}
+
+// Merge merges the pull request with the merge strategy matching mergeMethod, which needs to be
+// enabled for the repository, otherwise the error of the server is returned.
+// Auto-merge needs Bitbucket Server 8.15 or later, and can't be combined with deleting the source branch.
+//
+// ErrDestructiveCallDisallowed is returned if the source branch should be deleted, but destructive
+// API calls aren't enabled for the client. The pull request isn't merged in that case.
+func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod gitprovider.MergeMethod, message string, opts ...gitprovider.PullRequestMergeOption) error {
+	o, err := gitprovider.MakePullRequestMergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	strategyID, ok := mergeStrategies[mergeMethod]
+	if !ok {
+		return fmt.Errorf("merge method %q is not supported: %w", mergeMethod, gitprovider.ErrNoProviderSupport)
+	}
+	autoMerge := o.AutoMerge != nil && *o.AutoMerge
+	deleteSourceBranch := o.DeleteSourceBranch != nil && *o.DeleteSourceBranch
+	if autoMerge && deleteSourceBranch {
+		return fmt.Errorf("bitbucket server can't delete the source branch of auto-merged pull requests: %w", gitprovider.ErrNoProviderSupport)
+	}
+	// Don't allow deleting the source branch if the user didn't explicitly allow dangerous API calls.
+	if deleteSourceBranch && !c.destructiveActions {
+		return fmt.Errorf("cannot delete source branch: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -94,17 +211,37 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider
 	}
 
 	// Merge the pull request
-	_, err = c.client.PullRequests.Merge(ctx, projectKey, repoSlug, pr.ID, pr.Version)
+	_, err = c.client.PullRequests.Merge(ctx, projectKey, repoSlug, pr.ID, pr.Version, &MergePullRequest{
+		Message:    message,
+		StrategyID: strategyID,
+		AutoMerge:  autoMerge,
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to merge pull request with strategy %q: %w", strategyID, err)
 	}
 
-	return nil
+	if deleteSourceBranch {
+		// The source branch may belong to a fork
+		from := pr.FromRef.Repository
+		if err := c.client.Branches.Delete(ctx, from.Project.Key, from.Slug, pr.FromRef.ID); err != nil {
+			return fmt.Errorf("failed to delete source branch: %w", err)
+		}
+	}
 
+	return nil
 }
 
-// Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Create creates a pull request with the given specifications. Bitbucket Server only supports
+// reviewers, other options return gitprovider.ErrNoProviderSupport.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o, err := gitprovider.MakePullRequestCreateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestCreateOptions(o); err != nil {
+		return nil, err
+	}
+
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -135,12 +272,31 @@ func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranc
 			},
 		},
 	}
+	for _, reviewer := range o.Reviewers {
+		pr.Reviewers = append(pr.Reviewers, User{Name: reviewer})
+	}
 
 	created, err := c.client.PullRequests.Create(ctx, projectKey, repoSlug, pr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull request: %w", err)
 	}
-	return newPullRequest(created), nil
+	return newPullRequest(c.clientContext, c.ref, created), nil
+}
+
+// validatePullRequestCreateOptions returns gitprovider.ErrNoProviderSupport for the options
+// Bitbucket Server pull requests don't have.
+func validatePullRequestCreateOptions(o gitprovider.PullRequestCreateOptions) error {
+	switch {
+	case len(o.Labels) > 0:
+		return fmt.Errorf("stash pull requests don't support labels: %w", gitprovider.ErrNoProviderSupport)
+	case len(o.Assignees) > 0:
+		return fmt.Errorf("stash pull requests don't support assignees: %w", gitprovider.ErrNoProviderSupport)
+	case o.Draft != nil && *o.Draft:
+		return fmt.Errorf("stash pull requests don't support drafts: %w", gitprovider.ErrNoProviderSupport)
+	case o.MaintainerCanModify != nil && *o.MaintainerCanModify:
+		return fmt.Errorf("stash pull requests don't support maintainer edits: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
 }
 
 func validatePullRequestsAPI(apiObj *PullRequest) error {
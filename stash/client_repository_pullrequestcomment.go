@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestCommentClient implements the gitprovider.PullRequestCommentClient interface.
+var _ gitprovider.PullRequestCommentClient = &PullRequestCommentClient{}
+
+// PullRequestCommentClient operates on the general comments of a specific pull request.
+type PullRequestCommentClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+}
+
+// List lists all general comments of the pull request, oldest first. Comments on the diff
+// and replies are left out.
+//
+// List returns all available comments, using multiple paginated requests if needed.
+func (c *PullRequestCommentClient) List(ctx context.Context) ([]gitprovider.PullRequestComment, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	// Bitbucket Server only lists comments as part of the pull request activities
+	activities, err := c.client.PullRequestComments.AllActivities(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return nil, wrapNotFound("failed to list pull request activities", err)
+	}
+
+	comments := []gitprovider.PullRequestComment{}
+	for i := len(activities) - 1; i >= 0; i-- {
+		activity := activities[i]
+		if activity.Action != ActivityActionCommented || activity.CommentAction != CommentActionAdded ||
+			activity.Comment == nil || activity.CommentAnchor != nil {
+			continue
+		}
+		comments = append(comments, newPullRequestComment(activity.Comment))
+	}
+	return comments, nil
+}
+
+// Create adds a general comment with the given body to the pull request.
+func (c *PullRequestCommentClient) Create(ctx context.Context, body string) (gitprovider.PullRequestComment, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObj, err := c.client.PullRequestComments.Create(ctx, projectKey, repoSlug, c.number, body)
+	if err != nil {
+		return nil, wrapNotFound("failed to create pull request comment", err)
+	}
+	return newPullRequestComment(apiObj), nil
+}
+
+// Update replaces the body of the comment with the given id.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestCommentClient) Update(ctx context.Context, id int64, body string) (gitprovider.PullRequestComment, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	// Get the comment first, the update must carry its current version
+	apiObj, err := c.client.PullRequestComments.Get(ctx, projectKey, repoSlug, c.number, id)
+	if err != nil {
+		return nil, wrapNotFound("failed to get pull request comment", err)
+	}
+
+	apiObj.Text = body
+	updated, err := c.client.PullRequestComments.Update(ctx, projectKey, repoSlug, c.number, apiObj)
+	if err != nil {
+		return nil, wrapNotFound("failed to update pull request comment", err)
+	}
+	return newPullRequestComment(updated), nil
+}
+
+// Delete removes the comment with the given id. Comments with replies can't be deleted.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PullRequestCommentClient) Delete(ctx context.Context, id int64) error {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	// Get the comment first, the deletion must carry its current version
+	apiObj, err := c.client.PullRequestComments.Get(ctx, projectKey, repoSlug, c.number, id)
+	if err != nil {
+		return wrapNotFound("failed to get pull request comment", err)
+	}
+
+	if err := c.client.PullRequestComments.Delete(ctx, projectKey, repoSlug, c.number, id, apiObj.Version); err != nil {
+		return wrapNotFound("failed to delete pull request comment", err)
+	}
+	return nil
+}
+
+// wrapNotFound wraps err with msg, translating ErrNotFound to gitprovider.ErrNotFound.
+func wrapNotFound(msg string, err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%s: %w", msg, gitprovider.ErrNotFound)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PullRequestReviewClient implements the gitprovider.PullRequestReviewClient interface.
+var _ gitprovider.PullRequestReviewClient = &PullRequestReviewClient{}
+
+// PullRequestReviewClient gives read access to the reviews of a specific pull request,
+// based on the status of its reviewers and participants.
+type PullRequestReviewClient struct {
+	*clientContext
+	ref    gitprovider.RepositoryRef
+	number int
+}
+
+// List returns the current review of each reviewer of the pull request. Reviewers that haven't
+// reviewed yet are pending, and participants are only listed once they approved or asked for
+// changes.
+func (c *PullRequestReviewClient) List(ctx context.Context) ([]gitprovider.PullRequestReview, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return nil, wrapNotFound("failed to get pull request", err)
+	}
+	activities, err := c.client.PullRequestComments.AllActivities(ctx, projectKey, repoSlug, c.number)
+	if err != nil {
+		return nil, wrapNotFound("failed to list pull request activities", err)
+	}
+
+	// Activities are listed newest first, hence the first review activity of a user is the latest
+	submitted := map[string]int64{}
+	for _, activity := range activities {
+		if activity.Action != ActivityActionApproved && activity.Action != ActivityActionReviewed {
+			continue
+		}
+		if _, ok := submitted[activity.User.Name]; !ok {
+			submitted[activity.User.Name] = activity.CreatedDate
+		}
+	}
+
+	reviews := []gitprovider.PullRequestReview{}
+	for _, reviewer := range pr.Reviewers {
+		if reviewer.Status == participantStatusUnapproved {
+			reviews = append(reviews, newPullRequestReview(reviewer, 0))
+			continue
+		}
+		reviews = append(reviews, newPullRequestReview(reviewer, submitted[reviewer.User.Name]))
+	}
+	for _, participant := range pr.Participants {
+		if participant.Status == participantStatusUnapproved {
+			continue
+		}
+		reviews = append(reviews, newPullRequestReview(participant, submitted[participant.User.Name]))
+	}
+	return reviews, nil
+}
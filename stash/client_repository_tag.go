@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+// Bitbucket Server doesn't return the message of annotated tags, hence the
+// Message of the returned tags is always nil.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all tags in the repository.
+//
+// List returns all available tags, using multiple paginated requests if needed.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.Tag, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObjs, err := c.client.Tags.All(ctx, projectKey, repoSlug)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make([]gitprovider.Tag, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		tags = append(tags, newTag(apiObj))
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Get(ctx context.Context, name string) (gitprovider.Tag, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	apiObj, err := c.client.Tags.Get(ctx, projectKey, repoSlug, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("tag %s: %w", name, gitprovider.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get tag %s: %w", name, err)
+	}
+	return newTag(apiObj), nil
+}
+
+// Create creates a tag with the given specifications. A tag with a Message is an
+// annotated tag, otherwise a lightweight tag.
+//
+// ErrAlreadyExists will be returned if the tag already exists.
+func (c *TagClient) Create(ctx context.Context, req gitprovider.TagInfo) (gitprovider.Tag, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	tag := &CreateTag{
+		Name:       req.Name,
+		StartPoint: req.Sha,
+		Type:       tagTypeLightweight,
+	}
+	if req.Message != nil {
+		tag.Message = *req.Message
+		tag.Type = tagTypeAnnotated
+	}
+
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+	apiObj, err := c.client.Tags.Create(ctx, projectKey, repoSlug, tag)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return nil, fmt.Errorf("tag %s: %w", req.Name, gitprovider.ErrAlreadyExists)
+		}
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("repository %s/%s: %w", projectKey, repoSlug, gitprovider.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to create tag %s: %w", req.Name, err)
+	}
+	return newTag(apiObj), nil
+}
+
+// Delete deletes the tag with the given name.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource does not exist.
+func (c *TagClient) Delete(ctx context.Context, name string) error {
+	// Don't allow deleting tags if the user didn't explicitly allow dangerous API calls.
+	if !c.destructiveActions {
+		return fmt.Errorf("cannot delete tag: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+	if err := c.client.Tags.Delete(ctx, projectKey, repoSlug, name); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("tag %s: %w", name, gitprovider.ErrNotFound)
+		}
+		return fmt.Errorf("failed to delete tag %s: %w", name, err)
+	}
+	return nil
+}
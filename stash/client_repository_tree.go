@@ -18,11 +18,18 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
+const (
+	// maxTreeEntries is the number of entries after which recursively listed trees are truncated, like on GitHub.
+	maxTreeEntries = 100000
+)
+
 // TreeClient implements the gitprovider.TreeClient interface.
 var _ gitprovider.TreeClient = &TreeClient{}
 
@@ -32,13 +39,85 @@ type TreeClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// Get returns a tree
+// Get returns the root tree of the given commit or branch.
+// Stash can't browse trees by their own SHA.
+// Recursive trees with more than maxTreeEntries entries are truncated.
 func (c *TreeClient) Get(ctx context.Context, sha string, recursive bool) (*gitprovider.TreeInfo, error) {
-	return nil, fmt.Errorf("error getting tree %s. not implemented in stash yet", sha)
+	entries, truncated, err := c.listTree(ctx, sha, "", recursive)
+	if err != nil {
+		return nil, err
+	}
 
+	return &gitprovider.TreeInfo{
+		SHA:       sha,
+		Tree:      entries,
+		Truncated: truncated,
+	}, nil
 }
 
-// List files (blob) in a tree
+// List files (blob) in the directory at path, at the given commit or branch.
 func (c *TreeClient) List(ctx context.Context, sha string, path string, recursive bool) ([]*gitprovider.TreeEntry, error) {
-	return nil, fmt.Errorf("error listing tree items %s. not implemented in stash yet", sha)
+	entries, _, err := c.listTree(ctx, sha, path, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	treeEntries := make([]*gitprovider.TreeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "blob" {
+			treeEntries = append(treeEntries, entry)
+		}
+	}
+	return treeEntries, nil
+}
+
+// listTree lists the entries of the directory dir at the given commit or branch, walking the
+// subdirectories if recursive is set. The bool result reports whether the entries were truncated.
+func (c *TreeClient) listTree(ctx context.Context, at, dir string, recursive bool) ([]*gitprovider.TreeEntry, bool, error) {
+	projectKey, repoSlug := getRepositoryRefs(c.ref)
+
+	entries := []*gitprovider.TreeEntry{}
+	dirs := []string{strings.Trim(dir, "/")}
+	for len(dirs) > 0 {
+		current := dirs[0]
+		dirs = dirs[1:]
+
+		children, err := c.client.Files.AllChildren(ctx, projectKey, repoSlug, current, at)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil, false, fmt.Errorf("tree %s@%s: %w", current, at, gitprovider.ErrNotFound)
+			}
+			return nil, false, fmt.Errorf("failed to list tree %s@%s: %w", current, at, err)
+		}
+		for _, child := range children {
+			entry := newTreeEntry(current, child)
+			entries = append(entries, entry)
+			if recursive && entry.Type == "tree" {
+				dirs = append(dirs, entry.Path)
+			}
+		}
+		if len(entries) > maxTreeEntries {
+			return entries[:maxTreeEntries], true, nil
+		}
+	}
+	return entries, false, nil
+}
+
+// newTreeEntry maps an entry of the directory dir to a git tree entry.
+// Stash doesn't report file modes, so files are assumed to be regular, non-executable files.
+func newTreeEntry(dir string, child *ChildEntry) *gitprovider.TreeEntry {
+	entry := &gitprovider.TreeEntry{
+		Path: joinFilePath(dir, child.Path.ToString),
+		SHA:  child.ContentID,
+	}
+	switch child.Type {
+	case entryTypeDirectory:
+		entry.Mode, entry.Type = "040000", "tree"
+	case entryTypeSubmodule:
+		entry.Mode, entry.Type = "160000", "commit"
+	default:
+		entry.Mode, entry.Type = "100644", "blob"
+		entry.Size = int(child.Size)
+	}
+	return entry
 }
@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UserAccessClient implements the gitprovider.UserAccessClient interface.
+var _ gitprovider.UserAccessClient = &UserAccessClient{}
+
+// UserAccessClient operates on the users permissions of a specific repository.
+type UserAccessClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get a user's access permission for a given repository.
+// login is the user name in Stash.
+// Only the permissions granted at the repository level are taken into account.
+// ErrNotFound is returned if the resource does not exist.
+func (c *UserAccessClient) Get(ctx context.Context, login string) (gitprovider.UserAccess, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObj, err := c.client.Repositories.GetRepositoryUserPermission(ctx, projectKey, repoSlug, login)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get repository user access: %w", err)
+	}
+	return userAccessFromAPI(c, apiObj)
+}
+
+// List lists the users access control list for this repository.
+// List returns all available user access lists, using multiple paginated requests if needed.
+func (c *UserAccessClient) List(ctx context.Context) ([]gitprovider.UserAccess, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObjs, err := c.client.Repositories.AllUsersPermission(ctx, projectKey, repoSlug)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list repository users access: %w", err)
+	}
+
+	usersAccess := make([]gitprovider.UserAccess, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		ua, err := userAccessFromAPI(c, apiObj)
+		if err != nil {
+			return nil, err
+		}
+		usersAccess = append(usersAccess, ua)
+	}
+
+	return usersAccess, nil
+}
+
+// Create grants a given user access to the repository.
+// The user shall exist in Stash.
+// Only the pull, push and admin permissions can be represented in Stash.
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *UserAccessClient) Create(ctx context.Context, req gitprovider.UserAccessInfo) (gitprovider.UserAccess, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	permission, err := getStashPermission(*req.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	projectKey, repoSlug := c.stashRefs()
+	apiObj := &RepositoryUserPermission{
+		User: User{
+			Name: req.Login,
+		},
+		Permission: permission,
+	}
+	err = c.client.Repositories.UpdateRepositoryUserPermission(ctx, projectKey, repoSlug, apiObj)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update repository user access: %w", err)
+	}
+
+	return newUserAccess(c, req, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *UserAccessClient) Reconcile(ctx context.Context,
+	req gitprovider.UserAccessInfo,
+) (gitprovider.UserAccess, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.Login)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.Update(ctx)
+}
+
+// stashRefs returns the project key and repository slug of the repository,
+// taking user repositories into account.
+func (c *UserAccessClient) stashRefs() (string, string) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+	// check if it is a user repository
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+	return projectKey, repoSlug
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// UsersClient implements the gitprovider.UsersClient interface.
+var _ gitprovider.UsersClient = &UsersClient{}
+
+// UsersClient looks up user accounts.
+type UsersClient struct {
+	*clientContext
+}
+
+// GetAuthenticated returns the user the client is authenticated as.
+// ErrNotFound is returned if the client is not authenticated.
+func (c *UsersClient) GetAuthenticated(ctx context.Context) (gitprovider.User, error) {
+	apiObj, err := c.client.Users.GetAuthenticated(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return newUser(c.clientContext, apiObj), nil
+}
+
+// Get returns the user for the given reference.
+// The user login is the user slug in Stash.
+// ErrNotFound is returned if the resource does not exist.
+func (c *UsersClient) Get(ctx context.Context, ref gitprovider.UserRef) (gitprovider.User, error) {
+	// Make sure the UserRef is valid
+	if err := validateUserRef(ref, c.host); err != nil {
+		return nil, err
+	}
+
+	apiObj, err := c.client.Users.Get(ctx, ref.UserLogin)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := validateUserAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return newUser(c.clientContext, apiObj), nil
+}
@@ -21,10 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 func TestGetCommit(t *testing.T) {
@@ -121,3 +125,77 @@ func TestListCommits(t *testing.T) {
 	}
 
 }
+
+func TestCreateCommitWithAPI(t *testing.T) {
+	mux, client := setup(t)
+
+	repoPath := fmt.Sprintf("%s/%s/prj1/%s/repo1/", stashURIprefix, projectsURI, RepositoriesURI)
+	var edits []url.Values
+	cloned := false
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		switch p := strings.TrimPrefix(r.URL.Path, repoPath); {
+		case p == "":
+			// Getting the repository is the first step of cloning it
+			cloned = true
+			w.WriteHeader(http.StatusNotFound)
+		case p == commitsURI:
+			// The branch doesn't exist yet
+			w.WriteHeader(http.StatusNotFound)
+		case p == branchesURI+"/"+defaultBranchURI:
+			fmt.Fprint(w, `{"id": "refs/heads/main", "displayId": "main", "latestCommit": "c0"}`)
+		case p == browseURI+"/a.txt" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"lines": [{"text": "a"}], "isLastPage": true}`)
+		case strings.HasPrefix(p, browseURI+"/") && r.Method == http.MethodPut:
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("failed to parse form: %v", err)
+			}
+			edits = append(edits, r.MultipartForm.Value)
+			fmt.Fprintf(w, `{"id": "c%d", "message": "commit"}`, len(edits))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj1"}, RepositoryName: "repo1"}
+	ref.SetKey("prj1")
+	ref.SetSlug("repo1")
+	commitClient := &CommitClient{clientContext: &clientContext{client: client}, ref: ref}
+
+	commit, err := commitClient.Create(context.Background(), "feature", "commit", []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("a.txt"), Content: gitprovider.StringVar("changed")},
+	})
+	if err != nil {
+		t.Fatalf("CommitClient.Create returned error: %v", err)
+	}
+	if commit.Get().Sha != "c1" {
+		t.Errorf("commit SHA = %q, want %q", commit.Get().Sha, "c1")
+	}
+
+	// The existing file is edited on top of the default branch, creating the branch
+	want := []url.Values{
+		{"branch": {"feature"}, "content": {"changed"}, "message": {"commit"}, "sourceCommitId": {"c0"}, "sourceBranch": {"main"}},
+	}
+	if diff := cmp.Diff(want, edits); diff != "" {
+		t.Errorf("file edits mismatch (-want +got):\n%s", diff)
+	}
+
+	for name, files := range map[string][]gitprovider.CommitFile{
+		"several files": {
+			{Path: gitprovider.StringVar("a.txt"), Content: gitprovider.StringVar("changed")},
+			{Path: gitprovider.StringVar("b.txt"), Content: gitprovider.StringVar("new")},
+		},
+		"deleting a file": {
+			{Path: gitprovider.StringVar("a.txt")},
+		},
+	} {
+		cloned = false
+		// The repository to clone isn't served, hence the commit fails after falling back to cloning
+		if _, err := commitClient.Create(context.Background(), "feature", "commit", files); err == nil || !cloned {
+			t.Errorf("CommitClient.Create() committing %s didn't fall back to cloning, error = %v", name, err)
+		}
+	}
+	// Commits in a clone must not write anything through the file edit endpoint
+	if len(edits) != 1 {
+		t.Errorf("got %d file edits, want 1", len(edits))
+	}
+}
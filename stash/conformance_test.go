@@ -0,0 +1,337 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/conformance"
+)
+
+// conformancePageSize is the page size of the stand-in, small enough for List to need multiple pages.
+const conformancePageSize = 2
+
+// stashStandIn emulates the repository, branch, deploy key and user endpoints of a Bitbucket Server
+// with a single project "ORG". Repositories are backed by in-memory git repositories, which can be
+// pushed to over HTTP, as repository creation pushes an initial commit.
+type stashStandIn struct {
+	t      *testing.T
+	mu     sync.Mutex
+	url    string
+	repos  []*Repository
+	git    map[string]*memory.Storage
+	keys   map[string][]*DeployKey
+	lastID int
+}
+
+func TestConformance(t *testing.T) {
+	s := &stashStandIn{t: t, git: map[string]*memory.Storage{}, keys: map[string][]*DeployKey{}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	s.url = server.URL
+
+	org := gitprovider.OrganizationRef{Domain: server.URL, Organization: "org"}
+	org.SetKey("ORG")
+	conformance.Run(t, conformance.Options{
+		NewClient: func(t *testing.T, opts ...gitprovider.ClientOption) gitprovider.Client {
+			c, err := NewStashClient("admin", "token", append([]gitprovider.ClientOption{
+				gitprovider.WithDomain(server.URL),
+			}, opts...)...)
+			if err != nil {
+				t.Fatalf("NewStashClient returned error: %v", err)
+			}
+			return c
+		},
+		Organization: org,
+	})
+}
+
+func (s *stashStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("X-AUSERNAME", "admin")
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/scm/ORG/"):
+		s.serveGit(w, r, strings.TrimPrefix(r.URL.Path, "/scm/ORG/"))
+	case strings.HasPrefix(r.URL.Path, stashURIkeys+"/projects/ORG/repos/"):
+		path := strings.Split(strings.TrimPrefix(r.URL.Path, stashURIkeys+"/projects/ORG/repos/"), "/")
+		s.serveKeys(w, r, path[0], path[1:])
+	case r.URL.Path == stashURIprefix+"/users/admin" && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, &User{Name: "admin", EmailAddress: "admin@example.com"})
+	case r.URL.Path == stashURIprefix+"/projects/ORG/repos" && r.Method == http.MethodGet:
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		end := start + conformancePageSize
+		if end > len(s.repos) {
+			end = len(s.repos)
+		}
+		writeJSON(s.t, w, http.StatusOK, &RepositoryList{
+			Paging: Paging{
+				IsLastPage:    end == len(s.repos),
+				Start:         int64(start),
+				Size:          int64(end - start),
+				Limit:         conformancePageSize,
+				NextPageStart: int64(end),
+			},
+			Repositories: s.repos[start:end],
+		})
+	case r.URL.Path == stashURIprefix+"/projects/ORG/repos" && r.Method == http.MethodPost:
+		req := &Repository{}
+		s.decode(r, req)
+		slug := strings.ToLower(req.Name)
+		if s.repo(slug) != nil {
+			writeJSON(s.t, w, http.StatusConflict, map[string]interface{}{
+				"errors": []map[string]string{{"message": "This repository URL is already taken."}},
+			})
+			return
+		}
+		storage := memory.NewStorage()
+		if _, err := git.Init(storage, nil); err != nil {
+			s.t.Fatalf("failed to init repository: %v", err)
+		}
+		s.git[slug] = storage
+		repo := &Repository{
+			Name:        req.Name,
+			Slug:        slug,
+			Description: req.Description,
+			Public:      req.Public,
+			ScmID:       "git",
+			Project:     Project{Key: "ORG", Name: "org"},
+			Links: Links{
+				Clone: []Clone{{Name: "http", Href: s.url + "/scm/ORG/" + slug + ".git"}},
+			},
+		}
+		s.repos = append(s.repos, repo)
+		writeJSON(s.t, w, http.StatusCreated, repo)
+	case strings.HasPrefix(r.URL.Path, stashURIprefix+"/projects/ORG/repos/"):
+		path := strings.Split(strings.TrimPrefix(r.URL.Path, stashURIprefix+"/projects/ORG/repos/"), "/")
+		s.serveRepo(w, r, path[0], path[1:])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *stashStandIn) serveRepo(w http.ResponseWriter, r *http.Request, slug string, path []string) {
+	repo := s.repo(slug)
+	if repo == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	storage := s.git[slug]
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodPut:
+		req := &Repository{}
+		s.decode(r, req)
+		repo.Description = req.Description
+		repo.Public = req.Public
+		writeJSON(s.t, w, http.StatusOK, repo)
+	case len(path) == 0 && r.Method == http.MethodDelete:
+		for i := range s.repos {
+			if s.repos[i] == repo {
+				s.repos = append(s.repos[:i], s.repos[i+1:]...)
+				break
+			}
+		}
+		delete(s.git, slug)
+		w.WriteHeader(http.StatusAccepted)
+	case len(path) == 1 && path[0] == branchesURI && r.Method == http.MethodPost:
+		req := struct {
+			Name       string `json:"name"`
+			StartPoint string `json:"startPoint"`
+		}{}
+		s.decode(r, &req)
+		start, err := storage.Reference(plumbing.ReferenceName(req.StartPoint))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		ref := plumbing.NewHashReference(plumbing.ReferenceName(req.Name), start.Hash())
+		if err := storage.SetReference(ref); err != nil {
+			s.t.Fatalf("failed to create branch: %v", err)
+		}
+		writeJSON(s.t, w, http.StatusOK, branchFromRef(ref))
+	case len(path) == 2 && path[0] == branchesURI && path[1] == defaultBranchURI && r.Method == http.MethodGet:
+		head, err := storage.Reference(plumbing.HEAD)
+		if err != nil {
+			s.t.Fatalf("failed to get HEAD: %v", err)
+		}
+		ref, err := storage.Reference(head.Target())
+		if err != nil {
+			// Empty repositories don't have a default branch yet
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(s.t, w, http.StatusOK, branchFromRef(ref))
+	case len(path) == 2 && path[0] == branchesURI && path[1] == defaultBranchURI && r.Method == http.MethodPut:
+		req := struct {
+			ID string `json:"id"`
+		}{}
+		s.decode(r, &req)
+		head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.ReferenceName(req.ID))
+		if err := storage.SetReference(head); err != nil {
+			s.t.Fatalf("failed to set HEAD: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *stashStandIn) serveKeys(w http.ResponseWriter, r *http.Request, slug string, path []string) {
+	if s.repo(slug) == nil || len(path) == 0 || path[0] != deployKeysURI {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	keys := s.keys[slug]
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		end := start + conformancePageSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		writeJSON(s.t, w, http.StatusOK, &DeployKeyList{
+			Paging: Paging{
+				IsLastPage:    end == len(keys),
+				Start:         int64(start),
+				Size:          int64(end - start),
+				Limit:         conformancePageSize,
+				NextPageStart: int64(end),
+			},
+			DeployKeys: keys[start:end],
+		})
+	case len(path) == 1 && r.Method == http.MethodPost:
+		req := &DeployKey{}
+		s.decode(r, req)
+		s.lastID++
+		// Like Bitbucket Server, the label is taken from the comment of the key
+		fields := strings.Fields(req.Key.Text)
+		key := &DeployKey{
+			Key:        Key{ID: s.lastID, Label: fields[len(fields)-1], Text: req.Key.Text},
+			Permission: req.Permission,
+		}
+		s.keys[slug] = append(keys, key)
+		writeJSON(s.t, w, http.StatusCreated, key)
+	case len(path) == 2 && r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(path[1])
+		for i := range keys {
+			if keys[i].Key.ID == id {
+				s.keys[slug] = append(keys[:i], keys[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// serveGit implements the receive-pack service of the smart HTTP protocol for the repository at path,
+// e.g. "repo.git/info/refs".
+func (s *stashStandIn) serveGit(w http.ResponseWriter, r *http.Request, path string) {
+	slug, service, _ := strings.Cut(path, ".git/")
+	storage, ok := s.git[slug]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	ep, err := transport.NewEndpoint("/" + slug)
+	if err != nil {
+		s.t.Fatalf("failed to create endpoint: %v", err)
+	}
+	session, err := server.NewServer(server.MapLoader{ep.String(): storage}).NewReceivePackSession(ep, nil)
+	if err != nil {
+		s.t.Fatalf("failed to create receive-pack session: %v", err)
+	}
+
+	switch {
+	case service == "info/refs" && r.URL.Query().Get("service") == transport.ReceivePackServiceName:
+		ar, err := session.AdvertisedReferencesContext(r.Context())
+		if err != nil {
+			s.t.Fatalf("failed to advertise references: %v", err)
+		}
+		ar.Prefix = [][]byte{[]byte("# service=" + transport.ReceivePackServiceName), pktline.Flush}
+		w.Header().Set("Content-Type", "application/x-git-receive-pack-advertisement")
+		if err := ar.Encode(w); err != nil {
+			s.t.Errorf("failed to encode references: %v", err)
+		}
+	case service == transport.ReceivePackServiceName && r.Method == http.MethodPost:
+		req := packp.NewReferenceUpdateRequest()
+		if err := req.Decode(r.Body); err != nil {
+			s.t.Fatalf("failed to decode reference update request: %v", err)
+		}
+		status, err := session.ReceivePack(r.Context(), req)
+		if err != nil {
+			s.t.Errorf("failed to receive pack: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/x-git-receive-pack-result")
+		if err := status.Encode(w); err != nil {
+			s.t.Errorf("failed to encode report status: %v", err)
+		}
+	default:
+		w.WriteHeader(http.StatusForbidden)
+	}
+}
+
+func (s *stashStandIn) repo(slug string) *Repository {
+	for _, repo := range s.repos {
+		if repo.Slug == slug {
+			return repo
+		}
+	}
+	return nil
+}
+
+func (s *stashStandIn) decode(r *http.Request, obj interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		s.t.Errorf("failed to decode request: %v", err)
+	}
+}
+
+func branchFromRef(ref *plumbing.Reference) *Branch {
+	return &Branch{
+		ID:           ref.Name().String(),
+		DisplayID:    ref.Name().Short(),
+		LatestCommit: ref.Hash().String(),
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
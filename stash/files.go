@@ -0,0 +1,356 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	rawURI    = "raw"
+	browseURI = "browse"
+	filesURI  = "files"
+
+	// Types of the entries of a directory
+	entryTypeFile      = "FILE"
+	entryTypeDirectory = "DIRECTORY"
+	entryTypeSubmodule = "SUBMODULE"
+)
+
+var (
+	// ErrFileConflict is returned when editing a file which was changed after the given source commit,
+	// or which exists although no source commit was given.
+	ErrFileConflict = errors.New("the file was changed since the source commit")
+)
+
+// Files interface defines the methods that can be used to
+// read and edit the files of a repository.
+type Files interface {
+	Raw(ctx context.Context, projectKey, repositorySlug, path, at string) ([]byte, error)
+	Edit(ctx context.Context, projectKey, repositorySlug, path string, edit *FileEdit) (*CommitObject, error)
+	Browse(ctx context.Context, projectKey, repositorySlug, path, at string, opts *PagingOptions) (*Browse, error)
+	AllChildren(ctx context.Context, projectKey, repositorySlug, path, at string) ([]*ChildEntry, error)
+	List(ctx context.Context, projectKey, repositorySlug, path, at string, opts *PagingOptions) (*FileList, error)
+	All(ctx context.Context, projectKey, repositorySlug, path, at string) ([]string, error)
+}
+
+// FilesService is a client for communicating with stash repository browse and raw endpoints
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-rest.html
+type FilesService service
+
+// FileEdit describes a commit of a single file through the browse endpoint.
+type FileEdit struct {
+	// Branch is the branch to commit to.
+	Branch string
+	// Content is the new content of the file.
+	Content string
+	// Message is the commit message.
+	Message string
+	// SourceCommitID is the commit the edit is based on. It's required when editing an
+	// existing file, and must be empty when creating one.
+	SourceCommitID string
+	// SourceBranch is the branch to create Branch from, if it doesn't exist yet.
+	SourceBranch string
+}
+
+// FilePath is the path of a file or directory.
+type FilePath struct {
+	// Components are the segments of the path.
+	Components []string `json:"components,omitempty"`
+	// Name is the last segment of the path.
+	Name string `json:"name,omitempty"`
+	// ToString is the path joined with slashes.
+	ToString string `json:"toString,omitempty"`
+}
+
+// ChildEntry is a file, directory or submodule in a directory.
+type ChildEntry struct {
+	// Path is the path of the entry, relative to the directory.
+	Path FilePath `json:"path"`
+	// ContentID is the SHA1 of the git object of the entry.
+	ContentID string `json:"contentId,omitempty"`
+	// Type is the type of the entry, i.e FILE, DIRECTORY or SUBMODULE.
+	Type string `json:"type,omitempty"`
+	// Size is the size of a file.
+	Size int64 `json:"size,omitempty"`
+}
+
+// ChildList is a page of the entries of a directory.
+type ChildList struct {
+	// Paging is the paging information.
+	Paging
+	// Children is the list of entries.
+	Children []*ChildEntry `json:"values,omitempty"`
+}
+
+// GetChildren returns the list of entries.
+func (c *ChildList) GetChildren() []*ChildEntry {
+	return c.Children
+}
+
+// Browse is the result of browsing a path.
+type Browse struct {
+	// Path is the browsed path.
+	Path FilePath `json:"path"`
+	// Revision is the commit or ref the path was browsed at.
+	Revision string `json:"revision,omitempty"`
+	// Children are the entries of the browsed directory. It's nil if a file was browsed.
+	Children *ChildList `json:"children,omitempty"`
+}
+
+// IsDirectory returns true if a directory was browsed.
+func (b *Browse) IsDirectory() bool {
+	return b.Children != nil
+}
+
+// FileList is a page of file paths.
+type FileList struct {
+	// Paging is the paging information.
+	Paging
+	// Files is the list of file paths.
+	Files []string `json:"values,omitempty"`
+}
+
+// GetFiles returns the list of file paths.
+func (f *FileList) GetFiles() []string {
+	return f.Files
+}
+
+// Raw retrieves the raw content of the file at path, at the given commit or ref.
+// Raw uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/raw/{path}?at".
+func (s *FilesService) Raw(ctx context.Context, projectKey, repositorySlug, path, at string) ([]byte, error) {
+	query := url.Values{}
+	if at != "" {
+		query.Add("at", at)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, filePathURI(projectKey, repositorySlug, rawURI, path), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("get raw file request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get raw file failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("get raw file failed: %s", resp.Status)
+	}
+
+	return res, nil
+}
+
+// Edit commits the content of a single file, creating it if it doesn't exist.
+// ErrFileConflict is returned if the file was changed after edit.SourceCommitID.
+// Edit uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/browse/{path}".
+func (s *FilesService) Edit(ctx context.Context, projectKey, repositorySlug, path string, edit *FileEdit) (*CommitObject, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fields := map[string]string{
+		"branch":  edit.Branch,
+		"content": edit.Content,
+		"message": edit.Message,
+	}
+	if edit.SourceCommitID != "" {
+		fields["sourceCommitId"] = edit.SourceCommitID
+	}
+	if edit.SourceBranch != "" {
+		fields["sourceBranch"] = edit.SourceBranch
+	}
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, fmt.Errorf("failed to write file edit: %w", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write file edit: %w", err)
+	}
+
+	header := http.Header{"Content-Type": []string{w.FormDataContentType()}}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, filePathURI(projectKey, repositorySlug, browseURI, path), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("edit file request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil, ErrFileConflict
+		}
+		return nil, fmt.Errorf("edit file failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("edit file failed: %s", resp.Status)
+	}
+
+	c := &CommitObject{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("edit file failed, unable to unmarshall json: %w", err)
+	}
+
+	c.Session.set(resp)
+
+	return c, nil
+}
+
+// Browse retrieves the entries of the directory at path, at the given commit or ref.
+// Children is nil if path is a file.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// Browse uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/browse/{path}?at&start&limit".
+func (s *FilesService) Browse(ctx context.Context, projectKey, repositorySlug, path, at string, opts *PagingOptions) (*Browse, error) {
+	query := url.Values{}
+	if at != "" {
+		query.Add("at", at)
+	}
+	query = addPaging(query, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, filePathURI(projectKey, repositorySlug, browseURI, path), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("browse request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("browse failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("browse failed: %s", resp.Status)
+	}
+
+	b := &Browse{}
+	if err := json.Unmarshal(res, b); err != nil {
+		return nil, fmt.Errorf("browse failed, unable to unmarshall json: %w", err)
+	}
+
+	return b, nil
+}
+
+// AllChildren retrieves all entries of the directory at path, at the given commit or ref.
+// ErrNotFound is returned if path isn't a directory.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *FilesService) AllChildren(ctx context.Context, projectKey, repositorySlug, path, at string) ([]*ChildEntry, error) {
+	c := []*ChildEntry{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		b, err := s.Browse(ctx, projectKey, repositorySlug, path, at, opts)
+		if err != nil {
+			return nil, err
+		}
+		if !b.IsDirectory() {
+			return nil, fmt.Errorf("%s is not a directory: %w", path, ErrNotFound)
+		}
+		c = append(c, b.Children.GetChildren()...)
+		return &b.Children.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// List retrieves the paths of the files in the directory at path and its subdirectories,
+// at the given commit or ref. The paths are relative to the directory.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/files/{path}?at&start&limit".
+func (s *FilesService) List(ctx context.Context, projectKey, repositorySlug, path, at string, opts *PagingOptions) (*FileList, error) {
+	query := url.Values{}
+	if at != "" {
+		query.Add("at", at)
+	}
+	query = addPaging(query, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, filePathURI(projectKey, repositorySlug, filesURI, path), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list files request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list files failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("list files failed: %s", resp.Status)
+	}
+
+	f := &FileList{}
+	if err := json.Unmarshal(res, f); err != nil {
+		return nil, fmt.Errorf("list files failed, unable to unmarshall json: %w", err)
+	}
+
+	return f, nil
+}
+
+// All retrieves the paths of all files in the directory at path and its subdirectories,
+// at the given commit or ref. The paths are relative to the directory.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *FilesService) All(ctx context.Context, projectKey, repositorySlug, path, at string) ([]string, error) {
+	f := []string{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, path, at, opts)
+		if err != nil {
+			return nil, err
+		}
+		f = append(f, list.GetFiles()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// filePathURI builds the URI of path for the given endpoint. An empty path is the repository root.
+func filePathURI(projectKey, repositorySlug, endpoint, path string) string {
+	uri := newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, endpoint)
+	if escaped := escapeFilePath(path); escaped != "" {
+		uri += "/" + escaped
+	}
+	return uri
+}
+
+// escapeFilePath escapes the segments of a file path, keeping the slashes between them.
+func escapeFilePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
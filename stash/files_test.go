@@ -0,0 +1,264 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestRawFile(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/dir/a b.txt", stashURIprefix, projectsURI, RepositoriesURI, rawURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); !strings.HasSuffix(got, "/dir/a%20b.txt") {
+			t.Errorf("path = %q, want the file name to be escaped", got)
+		}
+		if got := r.URL.Query().Get("at"); got != "abc" {
+			t.Errorf("at = %q, want %q", got, "abc")
+		}
+		fmt.Fprint(w, "hello")
+	})
+
+	content, err := client.Files.Raw(context.Background(), "prj1", "repo1", "dir/a b.txt", "abc")
+	if err != nil {
+		t.Fatalf("Files.Raw returned error: %v", err)
+	}
+	if string(content) != "hello" {
+		t.Errorf("Files.Raw() = %q, want %q", content, "hello")
+	}
+
+	if _, err := client.Files.Raw(context.Background(), "prj1", "repo1", "missing.txt", "abc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Files.Raw() of missing file error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestEditFile(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/a.txt", stashURIprefix, projectsURI, RepositoriesURI, browseURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.FormValue("sourceCommitId"); got != "abc" {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"errors": [{"message": "The file has been changed since the source commit"}]}`)
+			return
+		}
+		if r.FormValue("branch") != "main" || r.FormValue("content") != "hello" || r.FormValue("message") != "Change a.txt" {
+			t.Errorf("unexpected form %v", r.MultipartForm.Value)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"id": "def", "message": "Change a.txt"}`)
+	})
+
+	commit, err := client.Files.Edit(context.Background(), "prj1", "repo1", "a.txt", &FileEdit{
+		Branch:         "main",
+		Content:        "hello",
+		Message:        "Change a.txt",
+		SourceCommitID: "abc",
+	})
+	if err != nil {
+		t.Fatalf("Files.Edit returned error: %v", err)
+	}
+	if commit.ID != "def" {
+		t.Errorf("commit ID = %q, want %q", commit.ID, "def")
+	}
+
+	_, err = client.Files.Edit(context.Background(), "prj1", "repo1", "a.txt", &FileEdit{
+		Branch:  "main",
+		Content: "hello",
+		Message: "Change a.txt",
+	})
+	if !errors.Is(err, ErrFileConflict) {
+		t.Errorf("Files.Edit() without source commit error = %v, want %v", err, ErrFileConflict)
+	}
+}
+
+func TestBrowse(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/", stashURIprefix, projectsURI, RepositoriesURI, browseURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("at"); got != "main" {
+			t.Errorf("at = %q, want %q", got, "main")
+		}
+		switch strings.TrimPrefix(r.URL.Path, path) {
+		case "dir":
+			// Serve a page per child
+			if r.URL.Query().Get("start") == "" {
+				fmt.Fprint(w, `{"path": {"toString": "dir"}, "children": {"isLastPage": false, "nextPageStart": 1, "values": [{"path": {"toString": "a.txt"}, "contentId": "abc", "type": "FILE", "size": 1}]}}`)
+				return
+			}
+			fmt.Fprint(w, `{"path": {"toString": "dir"}, "children": {"isLastPage": true, "values": [{"path": {"toString": "sub"}, "contentId": "def", "type": "DIRECTORY"}]}}`)
+		case "dir/a.txt":
+			fmt.Fprint(w, `{"lines": [{"text": "a"}], "isLastPage": true}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	children, err := client.Files.AllChildren(context.Background(), "prj1", "repo1", "dir", "main")
+	if err != nil {
+		t.Fatalf("Files.AllChildren returned error: %v", err)
+	}
+	if len(children) != 2 || children[0].Path.ToString != "a.txt" || children[1].Type != entryTypeDirectory {
+		t.Errorf("Files.AllChildren() = %+v, want a.txt and sub", children)
+	}
+
+	b, err := client.Files.Browse(context.Background(), "prj1", "repo1", "dir/a.txt", "main", nil)
+	if err != nil {
+		t.Fatalf("Files.Browse returned error: %v", err)
+	}
+	if b.IsDirectory() {
+		t.Errorf("Files.Browse() of a file returned a directory")
+	}
+	if _, err := client.Files.AllChildren(context.Background(), "prj1", "repo1", "dir/a.txt", "main"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Files.AllChildren() of a file error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := client.Files.Browse(context.Background(), "prj1", "repo1", "missing", "main", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Files.Browse() of missing path error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/dir", stashURIprefix, projectsURI, RepositoriesURI, filesURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start") == "" {
+			fmt.Fprint(w, `{"isLastPage": false, "nextPageStart": 1, "values": ["a.txt"]}`)
+			return
+		}
+		fmt.Fprint(w, `{"isLastPage": true, "values": ["sub/b.txt"]}`)
+	})
+
+	files, err := client.Files.All(context.Background(), "prj1", "repo1", "dir", "main")
+	if err != nil {
+		t.Fatalf("Files.All returned error: %v", err)
+	}
+	if strings.Join(files, ",") != "a.txt,sub/b.txt" {
+		t.Errorf("Files.All() = %v, want [a.txt sub/b.txt]", files)
+	}
+}
+
+func TestFileAndTreeClients(t *testing.T) {
+	mux, client := setup(t)
+
+	// The repository contains a.txt, dir/b.txt and dir/sub/c.txt
+	dirs := map[string]string{
+		"":        `[{"path": {"toString": "a.txt"}, "contentId": "a1", "type": "FILE", "size": 1}, {"path": {"toString": "dir"}, "contentId": "d1", "type": "DIRECTORY"}]`,
+		"dir":     `[{"path": {"toString": "b.txt"}, "contentId": "b1", "type": "FILE", "size": 1}, {"path": {"toString": "sub"}, "contentId": "s1", "type": "DIRECTORY"}]`,
+		"dir/sub": `[{"path": {"toString": "c.txt"}, "contentId": "c1", "type": "FILE", "size": 1}]`,
+	}
+	files := map[string]string{"a.txt": "a", "dir/b.txt": "b", "dir/sub/c.txt": "c"}
+	repoPath := fmt.Sprintf("%s/%s/prj1/%s/repo1/", stashURIprefix, projectsURI, RepositoriesURI)
+	mux.HandleFunc(repoPath, func(w http.ResponseWriter, r *http.Request) {
+		endpoint, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, repoPath), "/")
+		children, isDir := dirs[path]
+		content, isFile := files[path]
+		switch {
+		case endpoint == browseURI && isDir:
+			fmt.Fprintf(w, `{"children": {"isLastPage": true, "values": %s}}`, children)
+		case endpoint == browseURI && isFile:
+			fmt.Fprintf(w, `{"lines": [{"text": %q}], "isLastPage": true}`, content)
+		case endpoint == filesURI && path == "dir":
+			fmt.Fprint(w, `{"isLastPage": true, "values": ["b.txt", "sub/c.txt"]}`)
+		case endpoint == rawURI && isFile:
+			fmt.Fprint(w, content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := context.Background()
+	c := &clientContext{client: client}
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj1"}, RepositoryName: "repo1"}
+	ref.SetKey("prj1")
+	ref.SetSlug("repo1")
+	fileClient := &FileClient{clientContext: c, ref: ref}
+	treeClient := &TreeClient{clientContext: c, ref: ref}
+
+	readFiles := func(path string, optFns ...gitprovider.FilesGetOption) map[string]string {
+		t.Helper()
+		commitFiles, err := fileClient.Get(ctx, path, "main", optFns...)
+		if err != nil {
+			t.Fatalf("FileClient.Get returned error: %v", err)
+		}
+		contents := map[string]string{}
+		for _, file := range commitFiles {
+			contents[*file.Path] = *file.Content
+		}
+		return contents
+	}
+	if diff := cmp.Diff(map[string]string{"a.txt": "a"}, readFiles("a.txt")); diff != "" {
+		t.Errorf("FileClient.Get() of a file mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"dir/b.txt": "b"}, readFiles("dir")); diff != "" {
+		t.Errorf("FileClient.Get() mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]string{"dir/b.txt": "b", "dir/sub/c.txt": "c"}
+	if diff := cmp.Diff(want, readFiles("dir", &gitprovider.FilesGetOptions{Recursive: true})); diff != "" {
+		t.Errorf("FileClient.Get() recursive mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := fileClient.Get(ctx, "missing", "main"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("FileClient.Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	tree, err := treeClient.Get(ctx, "main", true)
+	if err != nil {
+		t.Fatalf("TreeClient.Get returned error: %v", err)
+	}
+	var paths []string
+	for _, entry := range tree.Tree {
+		paths = append(paths, entry.Path+" "+entry.Type+" "+entry.Mode+" "+entry.SHA)
+	}
+	wantPaths := []string{
+		"a.txt blob 100644 a1",
+		"dir tree 040000 d1",
+		"dir/b.txt blob 100644 b1",
+		"dir/sub tree 040000 s1",
+		"dir/sub/c.txt blob 100644 c1",
+	}
+	if diff := cmp.Diff(wantPaths, paths); diff != "" {
+		t.Errorf("TreeClient.Get() mismatch (-want +got):\n%s", diff)
+	}
+	if tree.Truncated {
+		t.Errorf("TreeClient.Get() returned a truncated tree")
+	}
+
+	entries, err := treeClient.List(ctx, "main", "dir", false)
+	if err != nil {
+		t.Fatalf("TreeClient.List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "dir/b.txt" || entries[0].Size != 1 {
+		t.Errorf("TreeClient.List() = %+v, want dir/b.txt", entries)
+	}
+}
@@ -145,8 +145,13 @@ type CreateCommit struct {
 type CommitFile struct {
 	// The path of the file relative to the repository root.
 	Path *string `json:"path"`
-	// The contents of the file.
+	// The contents of the file. If nil, the file is deleted, unless PreviousPath is set.
 	Content *string `json:"content"`
+	// The path the file is moved from, if any.
+	PreviousPath *string `json:"previous_path,omitempty"`
+	// The git file mode of the file. Defaults to a regular file, or to the mode
+	// of the previous file when moving one without content.
+	Mode *gitprovider.CommitFileMode `json:"mode,omitempty"`
 }
 
 // GitCommitOptionsFunc is a function that returns an error if the commit options are invalid
@@ -340,6 +345,21 @@ func (s *GitService) CloneRepository(ctx context.Context, URL string) (r *git.Re
 
 func (s *GitService) addCommitFiles(w *git.Worktree, dir string, files []CommitFile) error {
 	for _, file := range files {
+		if file.PreviousPath != nil {
+			// Moves the file and stages both paths, new content is written below.
+			if _, err := w.Move(*file.PreviousPath, *file.Path); err != nil {
+				return err
+			}
+			if file.Content == nil && file.Mode == nil {
+				continue
+			}
+		} else if file.Content == nil {
+			// Removes the file from the working tree and the staging area.
+			if _, err := w.Remove(*file.Path); err != nil {
+				return err
+			}
+			continue
+		}
 		err := writeCommitFile(file, dir)
 		if err != nil {
 			return err
@@ -363,12 +383,48 @@ func writeCommitFile(file CommitFile, dir string) error {
 			return err
 		}
 	}
-	err := os.WriteFile(filename, []byte(*file.Content), 0644)
-	if err != nil {
+
+	var content []byte
+	if file.Content != nil {
+		content = []byte(*file.Content)
+	} else {
+		// A moved file whose mode changes keeps its content.
+		var err error
+		if content, err = readCommitFile(filename); err != nil {
+			return err
+		}
+	}
+
+	// Replace the file rather than writing through it, it may be a symlink.
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
 		return err
 	}
+	mode := gitprovider.CommitFileModeRegular
+	if file.Mode != nil {
+		mode = *file.Mode
+	}
+	switch mode {
+	case gitprovider.CommitFileModeSymlink:
+		return os.Symlink(string(content), filename)
+	case gitprovider.CommitFileModeExecutable:
+		return os.WriteFile(filename, content, 0755)
+	default:
+		return os.WriteFile(filename, content, 0644)
+	}
+}
 
-	return nil
+// readCommitFile returns the content of the file at filename as it would be committed,
+// which is the link target for symlinks.
+func readCommitFile(filename string) ([]byte, error) {
+	info, err := os.Lstat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(filename)
+		return []byte(target), err
+	}
+	return os.ReadFile(filename)
 }
 
 // Cleanup removes the temporary directory created for the repository.
@@ -21,7 +21,12 @@ import (
 	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 func TestNewCommit(t *testing.T) {
@@ -218,3 +223,83 @@ func TestCreateCommit(t *testing.T) {
 		t.Errorf("Message mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestAddCommitFiles(t *testing.T) {
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("unexpected error while init repo: %v", err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &GitService{}
+
+	if err := s.addCommitFiles(w, dir, []CommitFile{
+		{Path: gitprovider.StringVar("old.txt"), Content: gitprovider.StringVar("old")},
+		{Path: gitprovider.StringVar("gone.txt"), Content: gitprovider.StringVar("gone")},
+	}); err != nil {
+		t.Fatalf("unexpected error while adding files: %v", err)
+	}
+	if _, err := w.Commit("init", &git.CommitOptions{Author: &object.Signature{Name: "user1", Email: "user1@users.com"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	executable := gitprovider.CommitFileModeExecutable
+	symlink := gitprovider.CommitFileModeSymlink
+	if err := s.addCommitFiles(w, dir, []CommitFile{
+		{Path: gitprovider.StringVar("gone.txt")},
+		{Path: gitprovider.StringVar("dir/new.txt"), PreviousPath: gitprovider.StringVar("old.txt")},
+		{Path: gitprovider.StringVar("run.sh"), Content: gitprovider.StringVar("#!/bin/sh"), Mode: &executable},
+		{Path: gitprovider.StringVar("link"), Content: gitprovider.StringVar("dir/new.txt"), Mode: &symlink},
+		{Path: gitprovider.StringVar("binary"), Content: gitprovider.StringVar("\x00\xff")},
+	}); err != nil {
+		t.Fatalf("unexpected error while adding files: %v", err)
+	}
+	hash, err := w.Commit("change", &git.CommitOptions{Author: &object.Signature{Name: "user1", Email: "user1@users.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct {
+		mode    filemode.FileMode
+		content string
+	}{
+		"dir/new.txt": {filemode.Regular, "old"},
+		"run.sh":      {filemode.Executable, "#!/bin/sh"},
+		"link":        {filemode.Symlink, "dir/new.txt"},
+		"binary":      {filemode.Regular, "\x00\xff"},
+	}
+	got := 0
+	err = tree.Files().ForEach(func(f *object.File) error {
+		got++
+		wantFile, ok := want[f.Name]
+		if !ok {
+			t.Errorf("unexpected file %q", f.Name)
+			return nil
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		if f.Mode != wantFile.mode || content != wantFile.content {
+			t.Errorf("file %q = (%v, %q), want (%v, %q)", f.Name, f.Mode, content, wantFile.mode, wantFile.content)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != len(want) {
+		t.Errorf("got %d files, want %d", got, len(want))
+	}
+}
@@ -25,8 +25,10 @@ import (
 )
 
 const (
-	groupsURI       = "admin/groups"
-	groupMembersURI = "admin/groups/more-members"
+	groupsURI            = "admin/groups"
+	groupMembersURI      = "admin/groups/more-members"
+	groupAddUsersURI     = "admin/groups/add-users"
+	groupRemoveMemberURI = "admin/groups/remove-user"
 )
 
 // Groups interface defines the methods that can be used to
@@ -36,6 +38,10 @@ type Groups interface {
 	Get(ctx context.Context, groupName string) (*Group, error)
 	ListGroupMembers(ctx context.Context, groupName string, opts *PagingOptions) (*GroupMembers, error)
 	AllGroupMembers(ctx context.Context, groupName string) ([]*User, error)
+	Create(ctx context.Context, groupName string) (*Group, error)
+	Delete(ctx context.Context, groupName string) error
+	AddUsers(ctx context.Context, groupName string, userNames ...string) error
+	RemoveUser(ctx context.Context, groupName, userName string) error
 }
 
 // GroupsService is a client for communicating with stash groups endpoint
@@ -208,3 +214,138 @@ func (s *GroupsService) AllGroupMembers(ctx context.Context, groupName string) (
 
 	return p, nil
 }
+
+// Create creates a stash group with the given name.
+// Create uses the endpoint "POST /rest/api/1.0/admin/groups?name".
+// The authenticated user must have the ADMIN permission to call this resource.
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *GroupsService) Create(ctx context.Context, groupName string) (*Group, error) {
+	query := url.Values{
+		"name": []string{groupName},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(groupsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("create group request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("create group failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("create group failed: %s", resp.Status)
+	}
+
+	g := &Group{}
+	if err := json.Unmarshal(res, g); err != nil {
+		return nil, fmt.Errorf("create group failed, unable to unmarshal group json: %w", err)
+	}
+
+	g.Session.set(resp)
+	return g, nil
+}
+
+// Delete deletes the stash group with the given name.
+// Delete uses the endpoint "DELETE /rest/api/1.0/admin/groups?name".
+// The authenticated user must have the ADMIN permission to call this resource.
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *GroupsService) Delete(ctx context.Context, groupName string) error {
+	query := url.Values{
+		"name": []string{groupName},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newURI(groupsURI), WithQuery(query))
+	if err != nil {
+		return fmt.Errorf("delete group request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete group failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// groupUsers is the request body used to add users to a group.
+type groupUsers struct {
+	Group string   `json:"group"`
+	Users []string `json:"users"`
+}
+
+// AddUsers adds the users with the given names to the stash group.
+// AddUsers uses the endpoint "POST /rest/api/1.0/admin/groups/add-users".
+// The authenticated user must have the ADMIN permission to call this resource.
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *GroupsService) AddUsers(ctx context.Context, groupName string, userNames ...string) error {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&groupUsers{
+		Group: groupName,
+		Users: userNames,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshall group users: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(groupAddUsersURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return fmt.Errorf("add group users request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("add group users failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("add group users failed: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// groupMember is the request body used to remove a user from a group.
+type groupMember struct {
+	Context  string `json:"context"`
+	ItemName string `json:"itemName"`
+}
+
+// RemoveUser removes the user with the given name from the stash group.
+// RemoveUser uses the endpoint "POST /rest/api/1.0/admin/groups/remove-user".
+// The authenticated user must have the ADMIN permission to call this resource.
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *GroupsService) RemoveUser(ctx context.Context, groupName, userName string) error {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&groupMember{
+		Context:  groupName,
+		ItemName: userName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshall group member: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(groupRemoveMemberURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return fmt.Errorf("remove group user request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remove group user failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("remove group user failed: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -153,3 +153,105 @@ func TestListGroupMembers(t *testing.T) {
 		t.Errorf("Groups.ListGroupMembers returned diff (want -> got):\n%s", diff)
 	}
 }
+
+func TestCreateAndDeleteGroup(t *testing.T) {
+	mux, client := setup(t)
+
+	groups := map[string]bool{}
+	path := fmt.Sprintf("%s/%s", stashURIprefix, groupsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		switch r.Method {
+		case http.MethodPost:
+			if groups[name] {
+				http.Error(w, "The group already exists", http.StatusConflict)
+				return
+			}
+			groups[name] = true
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(&Group{Name: name, Deleteable: true})
+		case http.MethodDelete:
+			if !groups[name] {
+				http.Error(w, "The specified group does not exist", http.StatusNotFound)
+				return
+			}
+			delete(groups, name)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(&Group{Name: name, Deleteable: true})
+		}
+	})
+
+	ctx := context.Background()
+	group, err := client.Groups.Create(ctx, "avengers")
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	if group.Name != "avengers" {
+		t.Errorf("Groups.Create returned group %s, want %s", group.Name, "avengers")
+	}
+
+	if _, err := client.Groups.Create(ctx, "avengers"); err != ErrAlreadyExists {
+		t.Errorf("Groups.Create returned error %v, want %v", err, ErrAlreadyExists)
+	}
+
+	if err := client.Groups.Delete(ctx, "avengers"); err != nil {
+		t.Fatalf("Groups.Delete returned error: %v", err)
+	}
+
+	if err := client.Groups.Delete(ctx, "avengers"); err != ErrNotFound {
+		t.Errorf("Groups.Delete returned error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestAddAndRemoveGroupUsers(t *testing.T) {
+	mux, client := setup(t)
+
+	members := []string{}
+	mux.HandleFunc(fmt.Sprintf("%s/%s", stashURIprefix, groupAddUsersURI), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Groups.AddUsers used method %s, want %s", r.Method, http.MethodPost)
+		}
+		req := &groupUsers{}
+		json.NewDecoder(r.Body).Decode(req)
+		if req.Group != "avengers" {
+			http.Error(w, "The specified group does not exist", http.StatusNotFound)
+			return
+		}
+		members = append(members, req.Users...)
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(fmt.Sprintf("%s/%s", stashURIprefix, groupRemoveMemberURI), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Groups.RemoveUser used method %s, want %s", r.Method, http.MethodPost)
+		}
+		req := &groupMember{}
+		json.NewDecoder(r.Body).Decode(req)
+		for i, member := range members {
+			if req.Context == "avengers" && member == req.ItemName {
+				members = append(members[:i], members[i+1:]...)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		http.Error(w, "The specified user does not exist", http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	if err := client.Groups.AddUsers(ctx, "avengers", "tstark", "rwilliams"); err != nil {
+		t.Fatalf("Groups.AddUsers returned error: %v", err)
+	}
+	if err := client.Groups.AddUsers(ctx, "x-men", "jgrey"); err != ErrNotFound {
+		t.Errorf("Groups.AddUsers returned error %v, want %v", err, ErrNotFound)
+	}
+
+	if err := client.Groups.RemoveUser(ctx, "avengers", "tstark"); err != nil {
+		t.Fatalf("Groups.RemoveUser returned error: %v", err)
+	}
+	if err := client.Groups.RemoveUser(ctx, "avengers", "tstark"); err != ErrNotFound {
+		t.Errorf("Groups.RemoveUser returned error %v, want %v", err, ErrNotFound)
+	}
+
+	if diff := cmp.Diff([]string{"rwilliams"}, members); diff != "" {
+		t.Errorf("Groups members diff (want -> got):\n%s", diff)
+	}
+}
@@ -410,6 +410,10 @@ var _ = Describe("Stash Provider", func() {
 		err = orgRepo.Branches().Create(ctx, branchName, latestCommit.Get().Sha)
 		Expect(err).ToNot(HaveOccurred())
 
+		branch, err := orgRepo.Branches().Get(ctx, branchName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(branch.Get().Sha).To(Equal(latestCommit.Get().Sha))
+
 		err = orgRepo.Branches().Create(ctx, branchName2, "wrong-sha")
 		Expect(err).To(HaveOccurred())
 
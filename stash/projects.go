@@ -34,9 +34,14 @@ const (
 // retrieve projects and related permissions.
 type Projects interface {
 	List(ctx context.Context, opts *PagingOptions) (*ProjectsList, error)
+	ListByPermission(ctx context.Context, permission string, opts *PagingOptions) (*ProjectsList, error)
 	Get(ctx context.Context, projectName string) (*Project, error)
 	All(ctx context.Context) ([]*Project, error)
+	Create(ctx context.Context, project *Project) (*Project, error)
+	Update(ctx context.Context, projectKey string, project *Project) (*Project, error)
+	Delete(ctx context.Context, projectKey string) error
 	GetProjectGroupPermission(ctx context.Context, projectKey, groupName string) (*ProjectGroupPermission, error)
+	UpdateProjectGroupPermission(ctx context.Context, projectKey string, permission *ProjectGroupPermission) error
 	ListProjectGroupsPermission(ctx context.Context, projectKey string, opts *PagingOptions) (*ProjectGroups, error)
 	AllGroupsPermission(ctx context.Context, projectKey string) ([]*ProjectGroupPermission, error)
 	ListProjectUsersPermission(ctx context.Context, projectKey string, opts *PagingOptions) (*ProjectUsers, error)
@@ -120,6 +125,40 @@ func (s *ProjectsService) List(ctx context.Context, opts *PagingOptions) (*Proje
 	return p, nil
 }
 
+// ListByPermission lists the projects the requester has at least the given permission on,
+// i.e. PROJECT_VIEW, PROJECT_READ, PROJECT_WRITE or PROJECT_ADMIN. When using an access token,
+// the permissions of the token apply.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// ListByPermission uses the endpoint "GET /rest/api/1.0/projects?permission={permission}".
+func (s *ProjectsService) ListByPermission(ctx context.Context, permission string, opts *PagingOptions) (*ProjectsList, error) {
+	query := addPaging(url.Values{"permission": []string{permission}}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list projects by permission request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list projects by permission failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	p := &ProjectsList{
+		Projects: []*Project{},
+	}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("list projects by permission failed, unable to unmarshal project list json: %w", err)
+	}
+
+	for _, r := range p.GetProjects() {
+		r.Session.set(resp)
+	}
+
+	return p, nil
+}
+
 // All retrieves all projects.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *ProjectsService) All(ctx context.Context) ([]*Project, error) {
@@ -185,6 +224,101 @@ func (s *ProjectsService) Get(ctx context.Context, projectName string) (*Project
 
 }
 
+// Create creates a project.
+// Create uses the endpoint "POST /rest/api/1.0/projects".
+// The authenticated user must have PROJECT_CREATE permission to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) Create(ctx context.Context, project *Project) (*Project, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall project: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create project request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("create project failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("create project failed: %s", resp.Status)
+	}
+
+	p := &Project{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("create project failed, unable to unmarshall project json: %w", err)
+	}
+
+	p.Session.set(resp)
+
+	return p, nil
+}
+
+// Update updates the project with the given key.
+// Update uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}".
+// The authenticated user must have PROJECT_ADMIN permission for the specified project to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) Update(ctx context.Context, projectKey string, project *Project) (*Project, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall project: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update project request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update project failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("update project failed: %s", resp.Status)
+	}
+
+	p := &Project{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("update project failed, unable to unmarshall project json: %w", err)
+	}
+
+	p.Session.set(resp)
+
+	return p, nil
+}
+
+// Delete deletes the project with the given key.
+// The project must not contain any repositories.
+// Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}".
+// The authenticated user must have PROJECT_ADMIN permission for the specified project to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) Delete(ctx context.Context, projectKey string) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newURI(projectsURI, projectKey))
+	if err != nil {
+		return fmt.Errorf("delete project request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete project failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 // ProjectGroupPermission is a permission for a given group.
 // The permission is tied to a project.
 // The permission can be either read, write, or admin.
@@ -202,6 +336,36 @@ type ProjectGroupPermission struct {
 	Permission string `json:"permission,omitempty"`
 }
 
+// UpdateProjectGroupPermission grants the given permission on the project to the group.
+// UpdateProjectGroupPermission uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/permissions/groups?name&permission".
+// The authenticated user must have PROJECT_ADMIN permission for the specified project
+// or a higher global permission to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) UpdateProjectGroupPermission(ctx context.Context, projectKey string, permission *ProjectGroupPermission) error {
+	query := url.Values{
+		"name":       []string{permission.Group.Name},
+		"permission": []string{permission.Permission},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, groupPermisionsURI), WithQuery(query))
+	if err != nil {
+		return fmt.Errorf("update group permissions request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("update group permissions to project failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("update group permissions to project failed: %s", resp.Status)
+	}
+
+	return nil
+}
+
 // ProjectGroups represents a list of groups for a given project.
 type ProjectGroups struct {
 	// Paging is the paging information.
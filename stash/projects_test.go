@@ -227,3 +227,100 @@ func TestListProjectUsersPermission(t *testing.T) {
 	}
 
 }
+
+func TestCreateUpdateAndDeleteProject(t *testing.T) {
+	mux, client := setup(t)
+
+	projects := map[string]*Project{}
+	mux.HandleFunc(fmt.Sprintf("%s/%s", stashURIprefix, projectsURI), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Projects.Create used method %s, want %s", r.Method, http.MethodPost)
+		}
+		p := &Project{}
+		json.NewDecoder(r.Body).Decode(p)
+		if _, ok := projects[p.Key]; ok {
+			http.Error(w, "The project key is already in use", http.StatusConflict)
+			return
+		}
+		p.ID = int64(len(projects) + 1)
+		projects[p.Key] = p
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(p)
+	})
+	mux.HandleFunc(fmt.Sprintf("%s/%s/", stashURIprefix, projectsURI), func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("%s/%s/", stashURIprefix, projectsURI))
+		p, ok := projects[key]
+		if !ok {
+			http.Error(w, "The specified project does not exist", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			update := &Project{}
+			json.NewDecoder(r.Body).Decode(update)
+			p.Name = update.Name
+			p.Description = update.Description
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(p)
+		case http.MethodDelete:
+			delete(projects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	p, err := client.Projects.Create(ctx, &Project{Key: "PRJ", Name: "project1"})
+	if err != nil {
+		t.Fatalf("Projects.Create returned error: %v", err)
+	}
+	p.Session = Session{}
+	if diff := cmp.Diff(&Project{ID: 1, Key: "PRJ", Name: "project1"}, p); diff != "" {
+		t.Errorf("Projects.Create returned diff (want -> got):\n%s", diff)
+	}
+
+	if _, err := client.Projects.Create(ctx, &Project{Key: "PRJ", Name: "project2"}); err != ErrAlreadyExists {
+		t.Errorf("Projects.Create returned error %v, want %v", err, ErrAlreadyExists)
+	}
+
+	p, err = client.Projects.Update(ctx, "PRJ", &Project{Key: "PRJ", Name: "project1", Description: "the first project"})
+	if err != nil {
+		t.Fatalf("Projects.Update returned error: %v", err)
+	}
+	if p.Description != "the first project" {
+		t.Errorf("Projects.Update returned description %q, want %q", p.Description, "the first project")
+	}
+
+	if err := client.Projects.Delete(ctx, "PRJ"); err != nil {
+		t.Fatalf("Projects.Delete returned error: %v", err)
+	}
+	if err := client.Projects.Delete(ctx, "PRJ"); err != ErrNotFound {
+		t.Errorf("Projects.Delete returned error %v, want %v", err, ErrNotFound)
+	}
+	if _, err := client.Projects.Update(ctx, "PRJ", &Project{Key: "PRJ"}); err != ErrNotFound {
+		t.Errorf("Projects.Update returned error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestUpdateProjectGroupPermission(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/PRJ/%s", stashURIprefix, projectsURI, groupPermisionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Projects.UpdateProjectGroupPermission used method %s, want %s", r.Method, http.MethodPut)
+		}
+		if got := r.URL.Query().Get("name"); got != "avengers" {
+			t.Errorf("Projects.UpdateProjectGroupPermission used group %s, want %s", got, "avengers")
+		}
+		if got := r.URL.Query().Get("permission"); got != "PROJECT_READ" {
+			t.Errorf("Projects.UpdateProjectGroupPermission used permission %s, want %s", got, "PROJECT_READ")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	permission := &ProjectGroupPermission{Permission: "PROJECT_READ"}
+	permission.Group.Name = "avengers"
+	if err := client.Projects.UpdateProjectGroupPermission(context.Background(), "PRJ", permission); err != nil {
+		t.Fatalf("Projects.UpdateProjectGroupPermission returned error: %v", err)
+	}
+}
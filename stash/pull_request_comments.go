@@ -0,0 +1,273 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	commentsURI   = "comments"
+	activitiesURI = "activities"
+)
+
+const (
+	// ActivityActionCommented, ActivityActionApproved and ActivityActionReviewed are the actions of
+	// the pull request activities recording comments, approvals and requests for changes.
+	ActivityActionCommented = "COMMENTED"
+	ActivityActionApproved  = "APPROVED"
+	ActivityActionReviewed  = "REVIEWED"
+
+	// CommentActionAdded is the comment action of the activity recording a new comment.
+	CommentActionAdded = "ADDED"
+)
+
+// PullRequestComments interface defines the methods that can be used to
+// manage the comments of a pull request, and to retrieve its activities.
+type PullRequestComments interface {
+	Get(ctx context.Context, projectKey, repositorySlug string, prID int, commentID int64) (*Comment, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, prID int, text string) (*Comment, error)
+	Update(ctx context.Context, projectKey, repositorySlug string, prID int, comment *Comment) (*Comment, error)
+	Delete(ctx context.Context, projectKey, repositorySlug string, prID int, commentID int64, version int) error
+	ListActivities(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ActivityList, error)
+	AllActivities(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Activity, error)
+}
+
+// PullRequestCommentsService is a client for communicating with stash pull request comments and activities endpoints
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+type PullRequestCommentsService service
+
+// Comment is a comment of a pull request
+type Comment struct {
+	// Session is the session of the comment
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the id of the comment
+	ID int64 `json:"id,omitempty"`
+	// Version is the version of the comment, it is required to update or delete the comment
+	Version int `json:"version"`
+	// Text is the markdown text of the comment
+	Text string `json:"text,omitempty"`
+	// Author is the author of the comment
+	Author User `json:"author,omitempty"`
+	// CreatedDate is the creation date of the comment
+	CreatedDate int64 `json:"createdDate,omitempty"`
+	// UpdatedDate is the update date of the comment
+	UpdatedDate int64 `json:"updatedDate,omitempty"`
+	// Comments are the replies to the comment
+	Comments []*Comment `json:"comments,omitempty"`
+}
+
+// CommentAnchor is the position of a comment on the diff of a pull request
+type CommentAnchor struct {
+	// Path is the path of the commented file
+	Path string `json:"path,omitempty"`
+	// Line is the commented line, if any
+	Line int `json:"line,omitempty"`
+}
+
+// Activity is an event in the history of a pull request
+type Activity struct {
+	// ID is the id of the activity
+	ID int64 `json:"id,omitempty"`
+	// CreatedDate is the date of the activity
+	CreatedDate int64 `json:"createdDate,omitempty"`
+	// User is the user that caused the activity
+	User User `json:"user,omitempty"`
+	// Action is the kind of activity, e.g. COMMENTED, APPROVED or MERGED
+	Action string `json:"action,omitempty"`
+	// CommentAction tells what happened to the comment of a COMMENTED activity, e.g. ADDED
+	CommentAction string `json:"commentAction,omitempty"`
+	// Comment is the comment of a COMMENTED activity
+	Comment *Comment `json:"comment,omitempty"`
+	// CommentAnchor is set for comments on the diff of the pull request
+	CommentAnchor *CommentAnchor `json:"commentAnchor,omitempty"`
+}
+
+// ActivityList is a list of pull request activities
+type ActivityList struct {
+	// Paging is the paging information
+	Paging
+	// Activities are the activities
+	Activities []*Activity `json:"values,omitempty"`
+}
+
+// GetActivities returns a list of activities
+func (a *ActivityList) GetActivities() []*Activity {
+	return a.Activities
+}
+
+// ListActivities returns the activities of the pull request with the given ID, newest first.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to an ActivityList struct is returned to retrieve the next page of results.
+// ListActivities uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/activities".
+func (s *PullRequestCommentsService) ListActivities(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ActivityList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), activitiesURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list pull request activities request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request activities failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	a := &ActivityList{}
+	if err := json.Unmarshal(res, a); err != nil {
+		return nil, fmt.Errorf("list pull request activities failed, unable to unmarshal activity list json: %w", err)
+	}
+
+	return a, nil
+}
+
+// AllActivities retrieves all activities of the pull request with the given ID, newest first.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *PullRequestCommentsService) AllActivities(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Activity, error) {
+	a := []*Activity{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.ListActivities(ctx, projectKey, repositorySlug, prID, opts)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, list.GetActivities()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Get retrieves a pull request comment given its ID.
+// Get uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments/{commentId}".
+func (s *PullRequestCommentsService) Get(ctx context.Context, projectKey, repositorySlug string, prID int, commentID int64) (*Comment, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI, strconv.FormatInt(commentID, 10)))
+	if err != nil {
+		return nil, fmt.Errorf("get pull request comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	c := &Comment{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("get pull request comment failed, unable to unmarshal comment json: %w", err)
+	}
+
+	c.Session.set(resp)
+
+	return c, nil
+}
+
+// Create adds a general comment with the given text to the pull request with the given ID.
+// Create uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments".
+func (s *PullRequestCommentsService) Create(ctx context.Context, projectKey, repositorySlug string, prID int, text string) (*Comment, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&Comment{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall pull request comment: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create pull request comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create pull request comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	c := &Comment{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("create pull request comment failed, unable to unmarshal comment json: %w", err)
+	}
+
+	c.Session.set(resp)
+
+	return c, nil
+}
+
+// Update replaces the text of the given comment. The version of the comment must be the current one.
+// Update uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments/{commentId}".
+func (s *PullRequestCommentsService) Update(ctx context.Context, projectKey, repositorySlug string, prID int, comment *Comment) (*Comment, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&Comment{Text: comment.Text, Version: comment.Version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall pull request comment: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI, strconv.FormatInt(comment.ID, 10)), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update pull request comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update pull request comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	c := &Comment{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("update pull request comment failed, unable to unmarshal comment json: %w", err)
+	}
+
+	c.Session.set(resp)
+
+	return c, nil
+}
+
+// Delete deletes the comment with the given ID and version.
+// Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments/{commentId}?version".
+func (s *PullRequestCommentsService) Delete(ctx context.Context, projectKey, repositorySlug string, prID int, commentID int64, version int) error {
+	query := url.Values{
+		"version": []string{strconv.Itoa(version)},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI, strconv.FormatInt(commentID, 10)), WithQuery(query))
+	if err != nil {
+		return fmt.Errorf("delete pull request comment request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete pull request comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestListPRComments(t *testing.T) {
+	mux, client := setup(t)
+
+	p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, activitiesURI)
+	mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		// Activities are listed newest first
+		fmt.Fprint(w, `{"isLastPage": true, "values": [
+			{"id": 5, "action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 12, "text": "second", "author": {"name": "bob"}}},
+			{"id": 4, "action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 11, "text": "on a line"}, "commentAnchor": {"path": "README.md", "line": 1}},
+			{"id": 3, "action": "APPROVED", "user": {"name": "bob"}},
+			{"id": 2, "action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 10, "text": "first", "author": {"name": "alice"}}},
+			{"id": 1, "action": "OPENED"}
+		]}`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj"}, RepositoryName: "my-repo"}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	commentClient := &PullRequestCommentClient{clientContext: &clientContext{client: client}, ref: ref, number: 1}
+
+	comments, err := commentClient.List(context.Background())
+	if err != nil {
+		t.Fatalf("PullRequestCommentClient.List returned error: %v", err)
+	}
+	got := []gitprovider.PullRequestCommentInfo{}
+	for _, comment := range comments {
+		got = append(got, comment.Get())
+	}
+	want := []gitprovider.PullRequestCommentInfo{
+		{ID: 10, Body: "first", Author: "alice"},
+		{ID: 12, Body: "second", Author: "bob"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PullRequestCommentClient.List returned diff (want -> got):\n%s", diff)
+	}
+}
+
+func TestUpdatePRComment(t *testing.T) {
+	mux, client := setup(t)
+
+	p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1/%s/10", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, commentsURI)
+	mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(&Comment{ID: 10, Version: 2, Text: "first"})
+		case http.MethodPut:
+			req := &Comment{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.Version != 2 {
+				t.Errorf("unexpected version: %d", req.Version)
+			}
+			json.NewEncoder(w).Encode(&Comment{ID: 10, Version: 3, Text: req.Text})
+		case http.MethodDelete:
+			if r.URL.Query().Get("version") != "2" {
+				t.Errorf("unexpected version: %s", r.URL.Query().Get("version"))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj"}, RepositoryName: "my-repo"}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	commentClient := &PullRequestCommentClient{clientContext: &clientContext{client: client}, ref: ref, number: 1}
+
+	ctx := context.Background()
+	comment, err := commentClient.Update(ctx, 10, "edited")
+	if err != nil {
+		t.Fatalf("PullRequestCommentClient.Update returned error: %v", err)
+	}
+	if comment.Get().Body != "edited" {
+		t.Errorf("PullRequestCommentClient.Update returned %v", comment.Get())
+	}
+	if err := commentClient.Delete(ctx, 10); err != nil {
+		t.Fatalf("PullRequestCommentClient.Delete returned error: %v", err)
+	}
+}
+
+func TestListPRReviews(t *testing.T) {
+	mux, client := setup(t)
+
+	p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&PullRequest{
+			IDVersion: IDVersion{ID: 1},
+			Reviewers: []Participant{
+				{Status: participantStatusApproved, User: User{Name: "alice"}},
+				{Status: participantStatusUnapproved, User: User{Name: "bob"}},
+			},
+			Participants: []Participant{
+				{Status: participantStatusNeedsWork, User: User{Name: "carol"}},
+				{Status: participantStatusUnapproved, User: User{Name: "dave"}},
+			},
+		})
+	})
+	mux.HandleFunc(p+"/"+activitiesURI, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"isLastPage": true, "values": [
+			{"id": 3, "action": "REVIEWED", "createdDate": 3000, "user": {"name": "carol"}},
+			{"id": 2, "action": "APPROVED", "createdDate": 2000, "user": {"name": "alice"}},
+			{"id": 1, "action": "APPROVED", "createdDate": 1000, "user": {"name": "alice"}}
+		]}`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj"}, RepositoryName: "my-repo"}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	reviewClient := &PullRequestReviewClient{clientContext: &clientContext{client: client}, ref: ref, number: 1}
+
+	reviews, err := reviewClient.List(context.Background())
+	if err != nil {
+		t.Fatalf("PullRequestReviewClient.List returned error: %v", err)
+	}
+	got := []gitprovider.PullRequestReviewInfo{}
+	for _, review := range reviews {
+		got = append(got, review.Get())
+	}
+	want := []gitprovider.PullRequestReviewInfo{
+		{Reviewer: "alice", State: gitprovider.PullRequestReviewStateApproved, SubmittedAt: fromUnixMilli(2000)},
+		{Reviewer: "bob", State: gitprovider.PullRequestReviewStatePending},
+		{Reviewer: "carol", State: gitprovider.PullRequestReviewStateChangesRequested, SubmittedAt: fromUnixMilli(3000)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PullRequestReviewClient.List returned diff (want -> got):\n%s", diff)
+	}
+}
@@ -28,6 +28,21 @@ import (
 const (
 	pullRequestsURI = "pull-requests"
 	mergeURI        = "merge"
+	declineURI      = "decline"
+)
+
+const (
+	// PullRequestStateOpen, PullRequestStateDeclined and PullRequestStateMerged are the states of a pull request.
+	PullRequestStateOpen     = "OPEN"
+	PullRequestStateDeclined = "DECLINED"
+	PullRequestStateMerged   = "MERGED"
+	// PullRequestStateAll is used to list pull requests in any state.
+	PullRequestStateAll = "ALL"
+
+	// PullRequestDirectionIncoming and PullRequestDirectionOutgoing tell whether the "at" ref of
+	// a PullRequestFilter is the target or the source of the listed pull requests.
+	PullRequestDirectionIncoming = "INCOMING"
+	PullRequestDirectionOutgoing = "OUTGOING"
 )
 
 // PullRequests interface defines the methods that can be used to
@@ -35,10 +50,13 @@ const (
 type PullRequests interface {
 	Get(ctx context.Context, projectKey, repositorySlug string, prID int) (*PullRequest, error)
 	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*PullRequestList, error)
+	ListFiltered(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter, opts *PagingOptions) (*PullRequestList, error)
 	All(ctx context.Context, projectKey, repositorySlug string) ([]*PullRequest, error)
+	AllFiltered(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter) ([]*PullRequest, error)
 	Create(ctx context.Context, projectKey, repositorySlug string, pr *CreatePullRequest) (*PullRequest, error)
 	Update(ctx context.Context, projectKey, repositorySlug string, pr *PullRequest) (*PullRequest, error)
-	Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)
+	Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int, opts *MergePullRequest) (*PullRequest, error)
+	Decline(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)
 	Delete(ctx context.Context, projectKey, repositorySlug string, IDVersion IDVersion) error
 }
 
@@ -91,8 +109,22 @@ type CreatePullRequest struct {
 	Title string `json:"title,omitempty"`
 	// ToRef is the target branch
 	ToRef Ref `json:"toRef,omitempty"`
-	// Reviewers is the list of reviewers
-	Reviewers []User `json:"reviewers,omitempty"`
+	// Reviewers is the list of reviewers, only their user names are required.
+	// They are sent as participants along with ReviewerParticipants.
+	Reviewers []User `json:"-"`
+	// ReviewerParticipants is the list of reviewers, only the user names of the participants are required
+	ReviewerParticipants []Participant `json:"reviewers,omitempty"`
+}
+
+// MarshalJSON sends Reviewers as participants, which is what the API expects.
+func (pr CreatePullRequest) MarshalJSON() ([]byte, error) {
+	type createPullRequest CreatePullRequest
+	p := createPullRequest(pr)
+	p.ReviewerParticipants = append([]Participant{}, pr.ReviewerParticipants...)
+	for _, reviewer := range pr.Reviewers {
+		p.ReviewerParticipants = append(p.ReviewerParticipants, Participant{User: reviewer})
+	}
+	return json.Marshal(p)
 }
 
 // IDVersion is a pull request id and version
@@ -103,6 +135,17 @@ type IDVersion struct {
 	Version int `json:"version"`
 }
 
+// MergePullRequest specifies how a pull request is merged.
+type MergePullRequest struct {
+	// Message is the merge commit message, the server generates one if empty
+	Message string `json:"message,omitempty"`
+	// StrategyID is the merge strategy, e.g. "no-ff", "ff-only", "rebase-ff-only" or "squash".
+	// The strategy needs to be enabled for the repository, the default strategy is used if empty
+	StrategyID string `json:"strategyId,omitempty"`
+	// AutoMerge merges the pull request once its merge checks pass, it needs Bitbucket Server 8.15+
+	AutoMerge bool `json:"autoMerge,omitempty"`
+}
+
 // PullRequest is a pull request
 type PullRequest struct {
 	// Session is the session of the pull request
@@ -166,18 +209,56 @@ type PullRequestList struct {
 	PullRequests []*PullRequest `json:"values,omitempty"`
 }
 
+// PullRequestFilter narrows down the pull requests returned by ListFiltered and AllFiltered.
+type PullRequestFilter struct {
+	// State is one of OPEN, DECLINED, MERGED or ALL. The server defaults to OPEN.
+	State string
+	// At is a fully qualified ref, e.g. refs/heads/main, the pull requests must target or
+	// originate from, depending on Direction.
+	At string
+	// Direction is either INCOMING (the default) or OUTGOING.
+	Direction string
+}
+
+func addPullRequestFilter(query url.Values, filter *PullRequestFilter) url.Values {
+	if filter == nil {
+		return query
+	}
+
+	if filter.State != "" {
+		query.Add("state", filter.State)
+	}
+
+	if filter.At != "" {
+		query.Add("at", filter.At)
+	}
+
+	if filter.Direction != "" {
+		query.Add("direction", filter.Direction)
+	}
+
+	return query
+}
+
 // GetPullRequests returns a list of pull requests
 func (p *PullRequestList) GetPullRequests() []*PullRequest {
 	return p.PullRequests
 }
 
-// List returns the list of pull requests.
+// List returns the list of open pull requests.
 // Paging is optional and is enabled by providing a PagingOptions struct.
 // A pointer to a PullRequestsList struct is returned to retrieve the next page of results.
 // List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests".
 // https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
 func (s *PullRequestsService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*PullRequestList, error) {
-	query := addPaging(url.Values{}, opts)
+	return s.ListFiltered(ctx, projectKey, repositorySlug, nil, opts)
+}
+
+// ListFiltered returns the list of pull requests matching the given filter.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// ListFiltered uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests?state&at&direction".
+func (s *PullRequestsService) ListFiltered(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter, opts *PagingOptions) (*PullRequestList, error) {
+	query := addPaging(addPullRequestFilter(url.Values{}, filter), opts)
 	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI), WithQuery(query))
 	if err != nil {
 		return nil, fmt.Errorf("list pull requests request creation failed: %w", err)
@@ -203,13 +284,19 @@ func (s *PullRequestsService) List(ctx context.Context, projectKey, repositorySl
 	return p, nil
 }
 
-// All retrieves all pull requests for a given repository.
+// All retrieves all open pull requests for a given repository.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *PullRequestsService) All(ctx context.Context, projectKey, repositorySlug string) ([]*PullRequest, error) {
+	return s.AllFiltered(ctx, projectKey, repositorySlug, nil)
+}
+
+// AllFiltered retrieves all pull requests matching the given filter for a given repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *PullRequestsService) AllFiltered(ctx context.Context, projectKey, repositorySlug string, filter *PullRequestFilter) ([]*PullRequest, error) {
 	pr := []*PullRequest{}
 	opts := &PagingOptions{Limit: perPageLimit}
 	err := allPages(opts, func() (*Paging, error) {
-		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		list, err := s.ListFiltered(ctx, projectKey, repositorySlug, filter, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -308,16 +395,27 @@ func (s *PullRequestsService) Update(ctx context.Context, projectKey, repository
 	return p, nil
 }
 
-// Merge the pull request with the given ID and version.
+// Merge the pull request with the given ID and version, opts may be nil to merge with the defaults
+// of the repository.
 // Merge uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/merge?version".
-func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error) {
+func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int, opts *MergePullRequest) (*PullRequest, error) {
 	query := url.Values{
 		"version": []string{strconv.Itoa(version)},
 	}
 
 	header := http.Header{"X-Atlassian-Token": []string{"no-check"}}
+	reqOpts := []RequestOptionFunc{WithQuery(query)}
+	if opts != nil {
+		body, err := marshallBody(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshall merge options: %v", err)
+		}
+		header.Set("Content-Type", "application/json")
+		reqOpts = append(reqOpts, WithBody(body))
+	}
+	reqOpts = append(reqOpts, WithHeader(header))
 
-	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), mergeURI), WithQuery(query), WithHeader(header))
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), mergeURI), reqOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("merge pull request request creation failed: %w", err)
 	}
@@ -331,7 +429,7 @@ func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositoryS
 	}
 
 	if resp != nil && resp.StatusCode == http.StatusBadRequest {
-		return nil, fmt.Errorf("list commits failed: %s", resp.Status)
+		return nil, fmt.Errorf("merge pull request failed: %s", resp.Status)
 	}
 
 	p := &PullRequest{}
@@ -344,6 +442,42 @@ func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositoryS
 	return p, nil
 }
 
+// Decline declines the pull request with the given ID and version, closing it without merging.
+// Decline uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/decline?version".
+func (s *PullRequestsService) Decline(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error) {
+	query := url.Values{
+		"version": []string{strconv.Itoa(version)},
+	}
+
+	header := http.Header{"X-Atlassian-Token": []string{"no-check"}}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), declineURI), WithQuery(query), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("decline pull request request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("decline pull request failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("decline pull request failed: %s", resp.Status)
+	}
+
+	p := &PullRequest{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("decline pull request failed, unable to unmarshal pull request json: %w", err)
+	}
+
+	p.Session.set(resp)
+
+	return p, nil
+}
+
 // Delete deletes the pull request with the given ID
 // Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}".
 // To call this resource, users must:
@@ -19,6 +19,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -27,6 +28,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -145,6 +147,42 @@ func TestListPRs(t *testing.T) {
 
 }
 
+func TestListFilteredPRs(t *testing.T) {
+	prs := []*PullRequest{
+		{IDVersion: IDVersion{ID: 101}, State: PullRequestStateDeclined},
+		{IDVersion: IDVersion{ID: 102}, State: PullRequestStateDeclined},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != PullRequestStateDeclined || q.Get("at") != "refs/heads/main" || q.Get("direction") != PullRequestDirectionIncoming {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		b := struct {
+			PRs        []*PullRequest `json:"values"`
+			IsLastPage bool           `json:"isLastPage"`
+		}{prs, true}
+		json.NewEncoder(w).Encode(b)
+	})
+	ctx := context.Background()
+	list, err := client.PullRequests.AllFiltered(ctx, "prj1", "repo1", &PullRequestFilter{
+		State:     PullRequestStateDeclined,
+		At:        "refs/heads/main",
+		Direction: PullRequestDirectionIncoming,
+	})
+	if err != nil {
+		t.Fatalf("PullRequests.AllFiltered returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(prs, list); diff != "" {
+		t.Errorf("PullRequests.AllFiltered returned diff (want -> got):\n%s", diff)
+	}
+}
+
 func TestCreatePR(t *testing.T) {
 	tests := []struct {
 		name string
@@ -285,7 +323,7 @@ func TestCreatePR(t *testing.T) {
 					},
 					Reviewers: []Participant{
 						{
-							User:     req.Reviewers[0],
+							User:     req.ReviewerParticipants[0].User,
 							Role:     "REVIEWER",
 							Approved: false,
 							Status:   "UNAPPROVED",
@@ -490,3 +528,151 @@ func TestDeletePR(t *testing.T) {
 		})
 	}
 }
+
+func TestDeclinePR(t *testing.T) {
+	mux, client := setup(t)
+
+	p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, declineURI)
+	mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Query().Get("version") != "3" {
+			t.Errorf("unexpected version: %s", r.URL.Query().Get("version"))
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&PullRequest{IDVersion: IDVersion{ID: 1, Version: 4}, State: PullRequestStateDeclined})
+	})
+
+	ctx := context.Background()
+	pr, err := client.PullRequests.Decline(ctx, "prj", "my-repo", 1, 3)
+	if err != nil {
+		t.Fatalf("PullRequests.Decline returned error: %v", err)
+	}
+	if pr.State != PullRequestStateDeclined || pr.Version != 4 {
+		t.Errorf("PullRequests.Decline returned %v", pr)
+	}
+
+	info := pullrequestFromAPI(pr)
+	if info.State != gitprovider.PullRequestStateClosed || info.Merged || info.Number != 1 {
+		t.Errorf("pullrequestFromAPI returned %v", info)
+	}
+}
+
+func TestCreatePRWithOptions(t *testing.T) {
+	mux, client := setup(t)
+
+	p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+		req := &CreatePullRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(req.ReviewerParticipants) != 1 || req.ReviewerParticipants[0].User.Name != "charlie" {
+			t.Errorf("unexpected reviewers: %v", req.ReviewerParticipants)
+		}
+		json.NewEncoder(w).Encode(&PullRequest{IDVersion: IDVersion{ID: 1, Version: 0}, Title: req.Title, Reviewers: req.ReviewerParticipants})
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj"}, RepositoryName: "my-repo"}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	prClient := &PullRequestClient{clientContext: &clientContext{client: client}, ref: ref}
+
+	ctx := context.Background()
+	if _, err := prClient.Create(ctx, "title", "feature", "main", "", &gitprovider.PullRequestCreateOptions{
+		Reviewers: []string{"charlie"},
+	}); err != nil {
+		t.Fatalf("PullRequestClient.Create returned error: %v", err)
+	}
+	if _, err := prClient.Create(ctx, "title", "feature", "main", "", &gitprovider.PullRequestCreateOptions{
+		Labels: []string{"automerge"},
+	}); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("PullRequestClient.Create() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+func TestMergePRWithOptions(t *testing.T) {
+	mux, client := setup(t)
+
+	p := fmt.Sprintf("%s/%s/prj/%s/my-repo/%s/1", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI)
+	mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&PullRequest{
+			IDVersion: IDVersion{ID: 1, Version: 3},
+			FromRef: Ref{
+				ID:         "refs/heads/feature",
+				Repository: Repository{Slug: "my-repo", Project: Project{Key: "prj"}},
+			},
+		})
+	})
+	var merges []MergePullRequest
+	mux.HandleFunc(p+"/"+mergeURI, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("version"); got != "3" {
+			t.Errorf("unexpected version: %s", got)
+		}
+		req := &MergePullRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		merges = append(merges, *req)
+		json.NewEncoder(w).Encode(&PullRequest{IDVersion: IDVersion{ID: 1, Version: 4}, State: "MERGED"})
+	})
+	var deleted string
+	mux.HandleFunc(fmt.Sprintf("%s/%s/prj/%s/my-repo/%s", stashURIbranchUtil, projectsURI, RepositoriesURI, branchesURI), func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		deleted = req.Name
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{OrganizationRef: gitprovider.OrganizationRef{Organization: "prj"}, RepositoryName: "my-repo"}
+	ref.SetKey("prj")
+	ref.SetSlug("my-repo")
+	prClient := &PullRequestClient{clientContext: &clientContext{client: client}, ref: ref}
+
+	ctx := context.Background()
+	// Deleting the source branch is checked before merging
+	if err := prClient.Merge(ctx, 1, gitprovider.MergeMethodRebase, "message", &gitprovider.PullRequestMergeOptions{
+		DeleteSourceBranch: gitprovider.BoolVar(true),
+	}); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Errorf("PullRequestClient.Merge() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if len(merges) != 0 {
+		t.Errorf("pull request was merged although the source branch can't be deleted")
+	}
+
+	prClient.clientContext.destructiveActions = true
+	if err := prClient.Merge(ctx, 1, gitprovider.MergeMethodRebase, "message", &gitprovider.PullRequestMergeOptions{
+		DeleteSourceBranch: gitprovider.BoolVar(true),
+	}); err != nil {
+		t.Fatalf("PullRequestClient.Merge returned error: %v", err)
+	}
+	if deleted != "refs/heads/feature" {
+		t.Errorf("deleted branch = %q, want %q", deleted, "refs/heads/feature")
+	}
+	// Merge commits are requested explicitly, instead of using the default strategy of the repository
+	if err := prClient.Merge(ctx, 1, gitprovider.MergeMethodMerge, ""); err != nil {
+		t.Fatalf("PullRequestClient.Merge returned error: %v", err)
+	}
+	want := []MergePullRequest{
+		{StrategyID: "rebase-ff-only", Message: "message"},
+		{StrategyID: "no-ff"},
+	}
+	if diff := cmp.Diff(want, merges); diff != "" {
+		t.Errorf("merge requests mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := prClient.Merge(ctx, 1, gitprovider.MergeMethodMerge, "", &gitprovider.PullRequestMergeOptions{
+		AutoMerge:          gitprovider.BoolVar(true),
+		DeleteSourceBranch: gitprovider.BoolVar(true),
+	}); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("PullRequestClient.Merge() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+	if err := prClient.Merge(ctx, 1, gitprovider.MergeMethod("octopus"), ""); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("PullRequestClient.Merge() with unknown method error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
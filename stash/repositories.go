@@ -48,6 +48,7 @@ type Repositories interface {
 // RepositoryManager interface defines the CRUD operations for repositories.
 type RepositoryManager interface {
 	List(ctx context.Context, projectKey string, opts *PagingOptions) (*RepositoryList, error)
+	ListByPermission(ctx context.Context, permission string, opts *PagingOptions) (*RepositoryList, error)
 	All(ctx context.Context, projectKey string) ([]*Repository, error)
 	Get(ctx context.Context, projectKey, repoSlug string) (*Repository, error)
 	Create(ctx context.Context, projectKey string, repository *Repository) (*Repository, error)
@@ -62,6 +63,10 @@ type RepositoryPermissionManager interface {
 	AllGroupsPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryGroupPermission, error)
 	UpdateRepositoryGroupPermission(ctx context.Context, projectKey, repositorySlug string, permission *RepositoryGroupPermission) error
 	ListRepositoryUsersPermission(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*RepositoryUsers, error)
+	GetRepositoryUserPermission(ctx context.Context, projectKey, repositorySlug, userName string) (*RepositoryUserPermission, error)
+	AllUsersPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryUserPermission, error)
+	UpdateRepositoryUserPermission(ctx context.Context, projectKey, repositorySlug string, permission *RepositoryUserPermission) error
+	DeleteRepositoryUserPermission(ctx context.Context, projectKey, repositorySlug, userName string) error
 }
 
 // RepositoriesService is a client for communicating with stash repositories endpoints
@@ -150,6 +155,40 @@ func (s *RepositoriesService) List(ctx context.Context, projectKey string, opts
 	return repos, nil
 }
 
+// ListByPermission lists the repositories of all projects the requester has at least the given permission on,
+// i.e. REPO_READ, REPO_WRITE or REPO_ADMIN. When using an access token, the permissions of the token apply.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// ListByPermission uses the endpoint "GET /rest/api/1.0/repos?permission={permission}".
+func (s *RepositoriesService) ListByPermission(ctx context.Context, permission string, opts *PagingOptions) (*RepositoryList, error) {
+	query := addPaging(url.Values{"permission": []string{permission}}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(RepositoriesURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list respositories by permission request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list respositories by permission failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	repos := &RepositoryList{
+		Repositories: []*Repository{},
+	}
+
+	if err := json.Unmarshal(res, repos); err != nil {
+		return nil, fmt.Errorf("list repositories by permission failed, unable to unmarshal repository list json: %w", err)
+	}
+
+	for _, r := range repos.GetRepositories() {
+		r.Session.set(resp)
+	}
+
+	return repos, nil
+}
+
 // All retrieves all repositories for a given project.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *RepositoriesService) All(ctx context.Context, projectKey string) ([]*Repository, error) {
@@ -495,3 +534,108 @@ func (s *RepositoriesService) ListRepositoryUsersPermission(ctx context.Context,
 
 	return users, nil
 }
+
+// GetRepositoryUserPermission retrieve a user that has been granted at least one permission for the specified repository.
+// GetRepositoryUserPermission uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/permissions/users?filter".
+// The authenticated user must have REPO_ADMIN permission for the specified repository to call this resource.
+func (s *RepositoriesService) GetRepositoryUserPermission(ctx context.Context, projectKey, repositorySlug, userName string) (*RepositoryUserPermission, error) {
+	query := url.Values{
+		filterKey: []string{userName},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, userPermisionsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("get user permissions request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get user permissions to repository failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	permissions := &RepositoryUsers{}
+	if err := json.Unmarshal(res, permissions); err != nil {
+		return nil, fmt.Errorf("get user permissions for repository failed, unable to unmarshall json: %w", err)
+	}
+
+	// The filter matches substrings of the user name, look for an exact match
+	for _, userPerm := range permissions.GetUsers() {
+		if userPerm.User.Name == userName {
+			userPerm.Session.set(resp)
+			return userPerm, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// AllUsersPermission retrieves all repository users permission.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *RepositoriesService) AllUsersPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryUserPermission, error) {
+	p := []*RepositoryUserPermission{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.ListRepositoryUsersPermission(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, list.GetUsers()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// UpdateRepositoryUserPermission Promote or demote a user's permission level for the specified repository.
+// UpdateRepositoryUserPermission uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/permissions/users?permission&name".
+func (s *RepositoriesService) UpdateRepositoryUserPermission(ctx context.Context, projectKey, repositorySlug string, permission *RepositoryUserPermission) error {
+	query := url.Values{
+		"name":       []string{permission.User.Name},
+		"permission": []string{permission.Permission},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, userPermisionsURI), WithQuery(query))
+	if err != nil {
+		return fmt.Errorf("add user permissions request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("add user permissions to repository failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("add user permissions to repository failed: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// DeleteRepositoryUserPermission revokes all permissions of a user for the specified repository.
+// DeleteRepositoryUserPermission uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/permissions/users?name".
+func (s *RepositoriesService) DeleteRepositoryUserPermission(ctx context.Context, projectKey, repositorySlug, userName string) error {
+	query := url.Values{
+		"name": []string{userName},
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, userPermisionsURI), WithQuery(query))
+	if err != nil {
+		return fmt.Errorf("revoke user permissions request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("revoke user permissions to repository failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -660,3 +661,86 @@ func TestListRepositoryUsersPermission(t *testing.T) {
 	}
 
 }
+
+func TestGetRepositoryUserPermission(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/testProject/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, userPermisionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// The filter matches substrings, so both users are returned for "jcook"
+		u := struct {
+			Users []*RepositoryUserPermission `json:"values"`
+		}{
+			[]*RepositoryUserPermission{
+				{User: User{Name: "jcook2"}, Permission: "REPO_ADMIN"},
+				{User: User{Name: "jcook"}, Permission: "REPO_WRITE"},
+			},
+		}
+		if r.URL.Query().Get("filter") != "jcook" {
+			u.Users = nil
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(u)
+	})
+
+	ctx := context.Background()
+	perm, err := client.Repositories.GetRepositoryUserPermission(ctx, "testProject", "repo1", "jcook")
+	if err != nil {
+		t.Fatalf("Repositories.GetRepositoryUserPermission returned error: %v", err)
+	}
+	if perm.User.Name != "jcook" || perm.Permission != "REPO_WRITE" {
+		t.Errorf("Repositories.GetRepositoryUserPermission returned %s with %s, want jcook with REPO_WRITE", perm.User.Name, perm.Permission)
+	}
+
+	_, err = client.Repositories.GetRepositoryUserPermission(ctx, "testProject", "repo1", "jsparrow")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Repositories.GetRepositoryUserPermission returned error: %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestUpdateAndDeleteRepositoryUserPermission(t *testing.T) {
+	mux, client := setup(t)
+
+	permissions := map[string]string{}
+	path := fmt.Sprintf("%s/%s/testProject/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, userPermisionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		switch r.Method {
+		case http.MethodPut:
+			permissions[name] = r.URL.Query().Get("permission")
+		case http.MethodDelete:
+			if _, ok := permissions[name]; !ok {
+				http.Error(w, "The specified user does not exist", http.StatusNotFound)
+				return
+			}
+			delete(permissions, name)
+		default:
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	err := client.Repositories.UpdateRepositoryUserPermission(ctx, "testProject", "repo1", &RepositoryUserPermission{
+		User:       User{Name: "jcook"},
+		Permission: "REPO_WRITE",
+	})
+	if err != nil {
+		t.Fatalf("Repositories.UpdateRepositoryUserPermission returned error: %v", err)
+	}
+	if permissions["jcook"] != "REPO_WRITE" {
+		t.Errorf("permission = %q, want REPO_WRITE", permissions["jcook"])
+	}
+
+	if err := client.Repositories.DeleteRepositoryUserPermission(ctx, "testProject", "repo1", "jcook"); err != nil {
+		t.Fatalf("Repositories.DeleteRepositoryUserPermission returned error: %v", err)
+	}
+	if len(permissions) != 0 {
+		t.Errorf("permissions = %v, want none", permissions)
+	}
+	err = client.Repositories.DeleteRepositoryUserPermission(ctx, "testProject", "repo1", "jcook")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Repositories.DeleteRepositoryUserPermission returned error: %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranch(branch *Branch, protected bool) *branchType {
+	return &branchType{
+		b:         *branch,
+		protected: protected,
+	}
+}
+
+var _ gitprovider.Branch = &branchType{}
+
+type branchType struct {
+	b Branch
+	// protected is true if the branch has branch restrictions, which aren't part of the stash branch.
+	protected bool
+}
+
+func (b *branchType) Get() gitprovider.BranchInfo {
+	return branchFromAPI(b.b, b.protected)
+}
+
+func (b *branchType) APIObject() interface{} {
+	return &b.b
+}
+
+// branchFromAPI converts a stash branch to a BranchInfo.
+// Branch restrictions aren't part of the stash branch, hence whether it's protected is passed separately.
+func branchFromAPI(branch Branch, protected bool) gitprovider.BranchInfo {
+	return gitprovider.BranchInfo{
+		Name:      branch.DisplayID,
+		Sha:       branch.LatestCommit,
+		Protected: protected,
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newBranchProtection(c *BranchProtectionClient, apiObj *BranchProtection) *branchProtection {
+	return &branchProtection{
+		p: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.BranchProtection = &branchProtection{}
+
+type branchProtection struct {
+	p BranchProtection
+	c *BranchProtectionClient
+}
+
+func (bp *branchProtection) Get() gitprovider.BranchProtectionInfo {
+	return branchProtectionFromAPI(&bp.p)
+}
+
+func (bp *branchProtection) Set(info gitprovider.BranchProtectionInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateBranchProtectionInfo(info); err != nil {
+		return err
+	}
+	branchProtectionInfoToAPIObj(&info, &bp.p)
+	return nil
+}
+
+func (bp *branchProtection) APIObject() interface{} {
+	return &bp.p
+}
+
+func (bp *branchProtection) Repository() gitprovider.RepositoryRef {
+	return bp.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (bp *branchProtection) Update(ctx context.Context) error {
+	actual, err := bp.c.get(ctx, bp.p.Branch)
+	if err != nil {
+		return err
+	}
+	return bp.update(ctx, actual)
+}
+
+// update deletes the restrictions of actual that aren't part of the desired state anymore,
+// and creates the new restrictions, i.e. those without an ID.
+func (bp *branchProtection) update(ctx context.Context, actual *BranchProtection) error {
+	projectKey, repoSlug := getRepositoryRefs(bp.c.ref)
+
+	desiredIDs := map[int]bool{}
+	for _, r := range bp.p.Restrictions {
+		desiredIDs[r.ID] = true
+	}
+	for _, r := range actual.Restrictions {
+		if desiredIDs[r.ID] {
+			continue
+		}
+		if err := bp.c.client.BranchRestrictions.Delete(ctx, projectKey, repoSlug, r.ID); err != nil {
+			return fmt.Errorf("failed to delete %s restriction of branch %s: %w", r.Type, bp.p.Branch, err)
+		}
+	}
+
+	restrictions := make([]*BranchRestriction, 0, len(bp.p.Restrictions))
+	for _, r := range bp.p.Restrictions {
+		if r.ID == 0 {
+			apiObj, err := bp.c.client.BranchRestrictions.Create(ctx, projectKey, repoSlug, r)
+			if err != nil {
+				return fmt.Errorf("failed to create %s restriction of branch %s: %w", r.Type, bp.p.Branch, err)
+			}
+			r = apiObj
+		}
+		restrictions = append(restrictions, r)
+	}
+	bp.p.Restrictions = restrictions
+	return nil
+}
+
+// Delete removes the protection of the branch, i.e. all its restrictions.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (bp *branchProtection) Delete(ctx context.Context) error {
+	projectKey, repoSlug := getRepositoryRefs(bp.c.ref)
+
+	for _, r := range bp.p.Restrictions {
+		if err := bp.c.client.BranchRestrictions.Delete(ctx, projectKey, repoSlug, r.ID); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return gitprovider.ErrNotFound
+			}
+			return fmt.Errorf("failed to delete %s restriction of branch %s: %w", r.Type, bp.p.Branch, err)
+		}
+	}
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (bp *branchProtection) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := bp.c.get(ctx, bp.p.Branch)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// The restrictions don't exist anymore, hence recreate all of them
+			for _, r := range bp.p.Restrictions {
+				r.ID = 0
+			}
+			return true, bp.update(ctx, &BranchProtection{Branch: bp.p.Branch})
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if branchProtectionFromAPI(&bp.p).Equals(branchProtectionFromAPI(actual)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, bp.update(ctx, actual)
+}
+
+// validateBranchProtectionInfo makes sure info only uses settings stash branch restrictions support.
+// info is expected to be defaulted.
+func validateBranchProtectionInfo(info gitprovider.BranchProtectionInfo) error {
+	if info.RequiredApprovals != nil && *info.RequiredApprovals != 0 {
+		return fmt.Errorf("stash branch restrictions don't support required approvals: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if len(info.RequiredStatusChecks) != 0 {
+		return fmt.Errorf("stash branch restrictions don't support required status checks: %w", gitprovider.ErrNoProviderSupport)
+	}
+	if info.EnforceAdmins != nil && !*info.EnforceAdmins {
+		return fmt.Errorf("stash branch restrictions always apply to administrators: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+func branchProtectionFromAPI(apiObj *BranchProtection) gitprovider.BranchProtectionInfo {
+	return gitprovider.BranchProtectionInfo{
+		Branch:            apiObj.Branch,
+		RequiredApprovals: gitprovider.IntVar(0),
+		AllowForcePush:    gitprovider.BoolVar(!preventsForcePush(apiObj)),
+		EnforceAdmins:     gitprovider.BoolVar(true),
+	}
+}
+
+func branchProtectionToAPI(info *gitprovider.BranchProtectionInfo) *BranchProtection {
+	p := &BranchProtection{
+		Restrictions: []*BranchRestriction{
+			{Type: BranchRestrictionNoDeletes, Matcher: branchMatcher(info.Branch)},
+		},
+	}
+	branchProtectionInfoToAPIObj(info, p)
+	return p
+}
+
+func branchProtectionInfoToAPIObj(info *gitprovider.BranchProtectionInfo, apiObj *BranchProtection) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Branch = info.Branch
+	// optional fields
+	if info.AllowForcePush != nil {
+		if *info.AllowForcePush {
+			restrictions := []*BranchRestriction{}
+			for _, r := range apiObj.Restrictions {
+				if r.Type != BranchRestrictionFastForwardOnly && r.Type != BranchRestrictionReadOnly {
+					restrictions = append(restrictions, r)
+				}
+			}
+			apiObj.Restrictions = restrictions
+		} else if !preventsForcePush(apiObj) {
+			apiObj.Restrictions = append(apiObj.Restrictions, &BranchRestriction{
+				Type:    BranchRestrictionFastForwardOnly,
+				Matcher: branchMatcher(info.Branch),
+			})
+		}
+	}
+	// Without any restriction, the branch wouldn't be protected anymore
+	if len(apiObj.Restrictions) == 0 {
+		apiObj.Restrictions = append(apiObj.Restrictions, &BranchRestriction{
+			Type:    BranchRestrictionNoDeletes,
+			Matcher: branchMatcher(info.Branch),
+		})
+	}
+}
+
+// preventsForcePush returns true if any restriction of the protection prevents rewriting history.
+func preventsForcePush(apiObj *BranchProtection) bool {
+	for _, r := range apiObj.Restrictions {
+		if r.Type == BranchRestrictionFastForwardOnly || r.Type == BranchRestrictionReadOnly {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchProtectionInfoToAPIObj(t *testing.T) {
+	noDeletes := &BranchRestriction{ID: 1, Type: BranchRestrictionNoDeletes, Matcher: branchMatcher("main")}
+	fastForwardOnly := &BranchRestriction{ID: 2, Type: BranchRestrictionFastForwardOnly, Matcher: branchMatcher("main")}
+	tests := []struct {
+		name   string
+		actual []*BranchRestriction
+		info   gitprovider.BranchProtectionInfo
+		want   []*BranchRestriction
+	}{
+		{
+			name: "create",
+			info: gitprovider.BranchProtectionInfo{Branch: "main"},
+			want: []*BranchRestriction{
+				{Type: BranchRestrictionNoDeletes, Matcher: branchMatcher("main")},
+				{Type: BranchRestrictionFastForwardOnly, Matcher: branchMatcher("main")},
+			},
+		},
+		{
+			name:   "allow force push",
+			actual: []*BranchRestriction{noDeletes, fastForwardOnly},
+			info:   gitprovider.BranchProtectionInfo{Branch: "main", AllowForcePush: gitprovider.BoolVar(true)},
+			want:   []*BranchRestriction{noDeletes},
+		},
+		{
+			name:   "keep the branch protected",
+			actual: []*BranchRestriction{fastForwardOnly},
+			info:   gitprovider.BranchProtectionInfo{Branch: "main", AllowForcePush: gitprovider.BoolVar(true)},
+			want: []*BranchRestriction{
+				{Type: BranchRestrictionNoDeletes, Matcher: branchMatcher("main")},
+			},
+		},
+		{
+			name:   "no-op",
+			actual: []*BranchRestriction{noDeletes, fastForwardOnly},
+			info:   gitprovider.BranchProtectionInfo{Branch: "main"},
+			want:   []*BranchRestriction{noDeletes, fastForwardOnly},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.Default()
+			var apiObj *BranchProtection
+			if tt.actual == nil {
+				apiObj = branchProtectionToAPI(&tt.info)
+			} else {
+				apiObj = &BranchProtection{Branch: "main", Restrictions: tt.actual}
+				branchProtectionInfoToAPIObj(&tt.info, apiObj)
+			}
+			if diff := cmp.Diff(tt.want, apiObj.Restrictions); diff != "" {
+				t.Errorf("restrictions mismatch (-want +got):\n%s", diff)
+			}
+			if got := branchProtectionFromAPI(apiObj); !got.Equals(tt.info) {
+				t.Errorf("branchProtectionFromAPI() = %+v, want %+v", got, tt.info)
+			}
+		})
+	}
+}
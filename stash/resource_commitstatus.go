@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newCommitStatus(status *BuildStatus) *commitStatus {
+	return &commitStatus{
+		s: *status,
+	}
+}
+
+var _ gitprovider.CommitStatus = &commitStatus{}
+
+type commitStatus struct {
+	s BuildStatus
+}
+
+func (s *commitStatus) Get() gitprovider.CommitStatusInfo {
+	return commitStatusFromAPI(s.s)
+}
+
+func (s *commitStatus) APIObject() interface{} {
+	return &s.s
+}
+
+// commitStatusFromAPI converts a stash build status to a CommitStatusInfo.
+// The Context of the status is the key of the build.
+func commitStatusFromAPI(status BuildStatus) gitprovider.CommitStatusInfo {
+	info := gitprovider.CommitStatusInfo{
+		State:   commitStatusStateFromAPI(status.State),
+		Context: status.Key,
+	}
+	if status.Description != "" {
+		info.Description = &status.Description
+	}
+	if status.URL != "" {
+		info.TargetURL = &status.URL
+	}
+	return info
+}
+
+func commitStatusToAPI(info gitprovider.CommitStatusInfo) *BuildStatus {
+	status := &BuildStatus{
+		State: commitStatusStateToAPI(info.State),
+		Key:   info.Context,
+		Name:  info.Context,
+	}
+	if info.Description != nil {
+		status.Description = *info.Description
+	}
+	if info.TargetURL != nil {
+		status.URL = *info.TargetURL
+	}
+	return status
+}
+
+// commitStatusStateFromAPI maps the build states, Bitbucket Server has no separate error state.
+func commitStatusStateFromAPI(state string) gitprovider.CommitStatusState {
+	switch state {
+	case BuildStatusStateSuccessful:
+		return gitprovider.CommitStatusStateSuccess
+	case BuildStatusStateFailed:
+		return gitprovider.CommitStatusStateFailure
+	default:
+		return gitprovider.CommitStatusStatePending
+	}
+}
+
+// commitStatusStateToAPI maps both failure and error to FAILED.
+func commitStatusStateToAPI(state gitprovider.CommitStatusState) string {
+	switch state {
+	case gitprovider.CommitStatusStateSuccess:
+		return BuildStatusStateSuccessful
+	case gitprovider.CommitStatusStateFailure, gitprovider.CommitStatusStateError:
+		return BuildStatusStateFailed
+	default:
+		return BuildStatusStateInProgress
+	}
+}
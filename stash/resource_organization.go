@@ -17,6 +17,10 @@ limitations under the License.
 package stash
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
@@ -25,6 +29,7 @@ var _ gitprovider.Organization = &Organization{}
 
 // Organization represents a project in the Stash provider.
 type Organization struct {
+	*clientContext
 	p     Project
 	ref   gitprovider.OrganizationRef
 	teams *TeamsClient
@@ -50,6 +55,100 @@ func (o *Organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+// Set sets the desired name and description of the project.
+// User have to call Update() to apply the changes to the server.
+func (o *Organization) Set(info gitprovider.OrganizationInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	organizationInfoToAPIObj(&info, &o.p)
+	return nil
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (o *Organization) Update(ctx context.Context) error {
+	apiObj, err := o.client.Projects.Update(ctx, o.ref.Key(), &Project{
+		Key:         o.p.Key,
+		Name:        o.p.Name,
+		Description: o.p.Description,
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to update organization %q: %w", o.ref.Organization, err)
+	}
+	if err := validateProjectAPI(apiObj); err != nil {
+		return err
+	}
+
+	// The project name is used to look up the project, so keep the reference in sync
+	o.ref.Organization = apiObj.Name
+	o.teams.ref = o.ref
+	o.p = *apiObj
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (o *Organization) Reconcile(ctx context.Context) (bool, error) {
+	c := &OrganizationsClient{clientContext: o.clientContext}
+	actual, err := c.Get(ctx, o.ref)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, o.ref, o.Get())
+			if err != nil {
+				return true, err
+			}
+			*o = *resp.(*Organization)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if o.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, o.Update(ctx)
+}
+
+// Delete deletes the project. The project must not contain any repositories.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (o *Organization) Delete(ctx context.Context) error {
+	// Don't allow deleting projects if the user didn't explicitly allow dangerous API calls.
+	if !o.destructiveActions {
+		return fmt.Errorf("cannot delete organization: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if err := o.client.Projects.Delete(ctx, o.ref.Key()); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete organization %q: %w", o.ref.Organization, err)
+	}
+	return nil
+}
+
 func organizationFromAPI(apiObj *Project) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        &apiObj.Name,
@@ -59,11 +158,21 @@ func organizationFromAPI(apiObj *Project) gitprovider.OrganizationInfo {
 
 func newOrganization(ctx *clientContext, apiObj *Project, ref gitprovider.OrganizationRef) *Organization {
 	return &Organization{
-		p:   *apiObj,
-		ref: ref,
+		clientContext: ctx,
+		p:             *apiObj,
+		ref:           ref,
 		teams: &TeamsClient{
 			clientContext: ctx,
 			ref:           ref,
 		},
 	}
 }
+
+func organizationInfoToAPIObj(info *gitprovider.OrganizationInfo, apiObj *Project) {
+	if info.Name != nil {
+		apiObj.Name = *info.Name
+	}
+	if info.Description != nil {
+		apiObj.Description = *info.Description
+	}
+}
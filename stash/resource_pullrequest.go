@@ -17,12 +17,39 @@ limitations under the License.
 package stash
 
 import (
+	"time"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
-func newPullRequest(apiObj *PullRequest) *pullrequest {
+const (
+	// mergeOutcomeClean and mergeOutcomeConflicted are the outcomes of a pull request dry-run merge.
+	mergeOutcomeClean      = "CLEAN"
+	mergeOutcomeConflicted = "CONFLICTED"
+)
+
+// pullRequestStates maps pull request states to the states of stash pull requests.
+//
+//nolint:gochecknoglobals
+var pullRequestStates = map[gitprovider.PullRequestState]string{
+	gitprovider.PullRequestStateOpen:   PullRequestStateOpen,
+	gitprovider.PullRequestStateClosed: PullRequestStateDeclined,
+	gitprovider.PullRequestStateMerged: PullRequestStateMerged,
+}
+
+func newPullRequest(ctx *clientContext, ref gitprovider.RepositoryRef, apiObj *PullRequest) *pullrequest {
 	return &pullrequest{
 		pr: *apiObj,
+		comments: &PullRequestCommentClient{
+			clientContext: ctx,
+			ref:           ref,
+			number:        apiObj.ID,
+		},
+		reviews: &PullRequestReviewClient{
+			clientContext: ctx,
+			ref:           ref,
+			number:        apiObj.ID,
+		},
 	}
 }
 
@@ -30,6 +57,9 @@ var _ gitprovider.PullRequest = &pullrequest{}
 
 type pullrequest struct {
 	pr PullRequest
+
+	comments *PullRequestCommentClient
+	reviews  *PullRequestReviewClient
 }
 
 func (pr *pullrequest) Get() gitprovider.PullRequestInfo {
@@ -40,10 +70,54 @@ func (pr *pullrequest) APIObject() interface{} {
 	return &pr.pr
 }
 
+// Comments gives access to the general comments of this pull request.
+func (pr *pullrequest) Comments() gitprovider.PullRequestCommentClient {
+	return pr.comments
+}
+
+// Reviews gives access to the reviews of this pull request.
+func (pr *pullrequest) Reviews() gitprovider.PullRequestReviewClient {
+	return pr.reviews
+}
+
 func pullrequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
-	return gitprovider.PullRequestInfo{
-		WebURL: getSelfref(apiObj.Self),
+	info := gitprovider.PullRequestInfo{
+		Merged:       apiObj.State == PullRequestStateMerged,
+		Number:       apiObj.ID,
+		WebURL:       getSelfref(apiObj.Self),
+		Title:        apiObj.Title,
+		Description:  apiObj.Description,
+		State:        gitprovider.PullRequestStateOpen,
+		SourceBranch: apiObj.FromRef.DisplayID,
+		TargetBranch: apiObj.ToRef.DisplayID,
+		HeadSHA:      apiObj.FromRef.LatestCommit,
+		BaseSHA:      apiObj.ToRef.LatestCommit,
+		Author:       apiObj.Author.User.Name,
+		CreatedAt:    fromUnixMilli(apiObj.CreatedDate),
+		UpdatedAt:    fromUnixMilli(apiObj.UpdatedDate),
+	}
+	switch apiObj.State {
+	case PullRequestStateMerged:
+		info.State = gitprovider.PullRequestStateMerged
+	case PullRequestStateDeclined:
+		info.State = gitprovider.PullRequestStateClosed
+	}
+	switch apiObj.Properties.MergeResult.Outcome {
+	case mergeOutcomeClean:
+		info.Mergeable = gitprovider.BoolVar(true)
+	case mergeOutcomeConflicted:
+		info.Mergeable = gitprovider.BoolVar(false)
+	}
+	return info
+}
+
+// fromUnixMilli converts a stash timestamp in milliseconds to a time.Time, leaving unset
+// timestamps as the zero time.
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
 	}
+	return time.UnixMilli(ms)
 }
 
 func getSelfref(selves []Self) string {
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// participantStatusApproved, participantStatusNeedsWork and participantStatusUnapproved are
+	// the review statuses of the participants of a pull request.
+	participantStatusApproved   = "APPROVED"
+	participantStatusNeedsWork  = "NEEDS_WORK"
+	participantStatusUnapproved = "UNAPPROVED"
+)
+
+func newPullRequestReview(apiObj Participant, submittedDate int64) *pullRequestReview {
+	return &pullRequestReview{
+		p:           apiObj,
+		submittedAt: fromUnixMilli(submittedDate),
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+type pullRequestReview struct {
+	p           Participant
+	submittedAt time.Time
+}
+
+func (r *pullRequestReview) Get() gitprovider.PullRequestReviewInfo {
+	return gitprovider.PullRequestReviewInfo{
+		Reviewer:    r.p.User.Name,
+		State:       pullRequestReviewStateFromAPI(r.p.Status),
+		SubmittedAt: r.submittedAt,
+	}
+}
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return &r.p
+}
+
+// pullRequestReviewStateFromAPI maps NEEDS_WORK to changes_requested, and reviewers that haven't
+// approved to pending.
+func pullRequestReviewStateFromAPI(status string) gitprovider.PullRequestReviewState {
+	switch status {
+	case participantStatusApproved:
+		return gitprovider.PullRequestReviewStateApproved
+	case participantStatusNeedsWork:
+		return gitprovider.PullRequestReviewStateChangesRequested
+	default:
+		return gitprovider.PullRequestReviewStatePending
+	}
+}
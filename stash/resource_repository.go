@@ -36,6 +36,10 @@ func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.R
 			clientContext: ctx,
 			ref:           ref,
 		},
+		userAccess: &UserAccessClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -44,10 +48,30 @@ func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.R
 			clientContext: ctx,
 			ref:           ref,
 		},
+		branchProtections: &BranchProtectionClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &WebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		statuses: &CommitStatusClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		files: &FileClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -62,21 +86,35 @@ func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.R
 var _ gitprovider.UserRepository = &userRepository{}
 
 type userRepository struct {
-	repository   Repository
-	ref          gitprovider.RepositoryRef
-	c            *UserRepositoriesClient
-	deployKeys   *DeployKeyClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	commits      *CommitClient
-	files        *FileClient
-	trees        *TreeClient
+	repository        Repository
+	ref               gitprovider.RepositoryRef
+	c                 *UserRepositoriesClient
+	deployKeys        *DeployKeyClient
+	userAccess        *UserAccessClient
+	branches          *BranchClient
+	branchProtections *BranchProtectionClient
+	webhooks          *WebhookClient
+	pullRequests      *PullRequestClient
+	commits           *CommitClient
+	tags              *TagClient
+	releases          *ReleaseClient
+	statuses          *CommitStatusClient
+	files             *FileClient
+	trees             *TreeClient
 }
 
 func (r *userRepository) Branches() gitprovider.BranchClient {
 	return r.branches
 }
 
+func (r *userRepository) BranchProtections() gitprovider.BranchProtectionClient {
+	return r.branchProtections
+}
+
+func (r *userRepository) Webhooks() gitprovider.WebhookClient {
+	return r.webhooks
+}
+
 func (r *userRepository) Commits() gitprovider.CommitClient {
 	return r.commits
 }
@@ -85,6 +123,18 @@ func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
 	return r.pullRequests
 }
 
+func (r *userRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
+func (r *userRepository) Releases() gitprovider.ReleaseClient {
+	return r.releases
+}
+
+func (r *userRepository) Statuses() gitprovider.CommitStatusClient {
+	return r.statuses
+}
+
 func (r *userRepository) Files() gitprovider.FileClient {
 	return r.files
 }
@@ -117,6 +167,10 @@ func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
 	return r.deployKeys
 }
 
+func (r *userRepository) UserAccess() gitprovider.UserAccessClient {
+	return r.userAccess
+}
+
 // The internal API object will be overridden with the received server data.
 func (r *userRepository) Update(ctx context.Context) error {
 	// update by calling client
@@ -162,7 +216,7 @@ func (r *userRepository) Reconcile(ctx context.Context) (bool, error) {
 // ErrNotFound is returned if the resource doesn't exist anymore.
 func (r *userRepository) Delete(ctx context.Context) error {
 	ref := r.ref.(gitprovider.UserRepositoryRef)
-	return deleteRepository(ctx, r.c.client, addTilde(ref.UserLogin), ref.Slug())
+	return deleteRepository(ctx, r.c.clientContext, addTilde(ref.UserLogin), ref.Slug())
 }
 
 // GetCloneURL returns a formatted string that can be used for cloning
@@ -256,7 +310,7 @@ func (r *orgRepository) Update(ctx context.Context) error {
 // ErrNotFound is returned if the resource doesn't exist anymore.
 func (r *orgRepository) Delete(ctx context.Context) error {
 	ref := r.ref.(gitprovider.OrgRepositoryRef)
-	return deleteRepository(ctx, r.c.client, ref.Key(), ref.Slug())
+	return deleteRepository(ctx, r.c.clientContext, ref.Key(), ref.Slug())
 }
 
 func repositoryFromAPI(apiObj *Repository) gitprovider.RepositoryInfo {
@@ -285,7 +339,7 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository
 		apiObj.Description = *repo.Description
 	}
 	if repo.Visibility != nil {
-		apiObj.Public = *gitprovider.StringVar(string(*repo.Visibility)) == "true"
+		apiObj.Public = *repo.Visibility == gitprovider.RepositoryVisibilityPublic
 	}
 
 	if repo.DefaultBranch != nil {
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTag(tag *Tag) *tagType {
+	return &tagType{
+		t: *tag,
+	}
+}
+
+var _ gitprovider.Tag = &tagType{}
+
+type tagType struct {
+	t Tag
+}
+
+func (t *tagType) Get() gitprovider.TagInfo {
+	return tagFromAPI(t.t)
+}
+
+func (t *tagType) APIObject() interface{} {
+	return &t.t
+}
+
+// tagFromAPI converts a stash tag to a TagInfo.
+// The stash tag doesn't contain the message of annotated tags, hence Message is always nil.
+func tagFromAPI(tag Tag) gitprovider.TagInfo {
+	sha := tag.LatestCommit
+	if sha == "" {
+		sha = tag.LatestChangeset
+	}
+	return gitprovider.TagInfo{
+		Name: tag.DisplayID,
+		Sha:  sha,
+	}
+}
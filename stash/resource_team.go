@@ -17,6 +17,10 @@ limitations under the License.
 package stash
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
@@ -28,6 +32,7 @@ type Team struct {
 	users []*User
 	info  gitprovider.TeamInfo
 	ref   gitprovider.OrganizationRef
+	c     *TeamsClient
 }
 
 // Get returns the team's information, Name and members.
@@ -44,3 +49,136 @@ func (t *Team) APIObject() interface{} {
 func (t *Team) Organization() gitprovider.OrganizationRef {
 	return t.ref
 }
+
+// Set sets the desired members of this team. The name of the team can't be changed.
+// User have to call Update() to apply the changes to the server.
+func (t *Team) Set(info gitprovider.TeamInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if info.Name != t.info.Name {
+		return fmt.Errorf("cannot rename team %q to %q: %w", t.info.Name, info.Name, gitprovider.ErrInvalidArgument)
+	}
+	t.info = info
+	return nil
+}
+
+// Update adds and removes members of the group, such that they match the desired state in this object.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (t *Team) Update(ctx context.Context) error {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		return err
+	}
+	added, removed := t.info.MemberChanges(actual.Get())
+	if len(added) > 0 {
+		if err := t.c.client.Groups.AddUsers(ctx, t.info.Name, added...); err != nil {
+			return fmt.Errorf("failed to add members to group %s: %w", t.info.Name, err)
+		}
+	}
+	for _, login := range removed {
+		if err := t.removeUser(ctx, login); err != nil {
+			return err
+		}
+	}
+	return t.refresh(ctx)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the members will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (t *Team) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := t.c.Create(ctx, t.info)
+			if err != nil {
+				return true, err
+			}
+			*t = *resp.(*Team)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if t.info.Equals(actual.Get()) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update
+	return true, t.Update(ctx)
+}
+
+// Delete deletes the group. Groups are server-wide, so this removes it from all projects and repositories.
+//
+// ErrDestructiveCallDisallowed is returned if destructive API calls aren't enabled for the client.
+// ErrNotFound is returned if the resource doesn't exist anymore.
+func (t *Team) Delete(ctx context.Context) error {
+	// Don't allow deleting groups if the user didn't explicitly allow dangerous API calls.
+	if !t.c.destructiveActions {
+		return fmt.Errorf("cannot delete group: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if err := t.c.client.Groups.Delete(ctx, t.info.Name); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete group %s: %w", t.info.Name, err)
+	}
+	return nil
+}
+
+// AddMember adds the user with the given login to the group.
+// Adding an existing member is a no-op.
+func (t *Team) AddMember(ctx context.Context, login string) error {
+	if err := t.c.client.Groups.AddUsers(ctx, t.info.Name, login); err != nil {
+		return fmt.Errorf("failed to add member %s to group %s: %w", login, t.info.Name, err)
+	}
+	return t.refresh(ctx)
+}
+
+// RemoveMember removes the user with the given login from the group.
+//
+// ErrNotFound is returned if the user isn't a member of the team.
+func (t *Team) RemoveMember(ctx context.Context, login string) error {
+	isMember := false
+	for _, member := range getGroupMemberSlugs(t.users) {
+		if member == login {
+			isMember = true
+		}
+	}
+	if !isMember {
+		return fmt.Errorf("member %q of team %q: %w", login, t.info.Name, gitprovider.ErrNotFound)
+	}
+	if err := t.removeUser(ctx, login); err != nil {
+		return err
+	}
+	return t.refresh(ctx)
+}
+
+func (t *Team) removeUser(ctx context.Context, login string) error {
+	if err := t.c.client.Groups.RemoveUser(ctx, t.info.Name, login); err != nil {
+		return fmt.Errorf("failed to remove member %s from group %s: %w", login, t.info.Name, err)
+	}
+	return nil
+}
+
+// refresh overrides the internal API object with the current members of the group.
+func (t *Team) refresh(ctx context.Context) error {
+	actual, err := t.c.Get(ctx, t.info.Name)
+	if err != nil {
+		return err
+	}
+	*t = *actual.(*Team)
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUser(ctx *clientContext, apiObj *User) *user {
+	return &user{
+		clientContext: ctx,
+		u:             *apiObj,
+	}
+}
+
+var _ gitprovider.User = &user{}
+
+type user struct {
+	*clientContext
+
+	u User
+}
+
+func (u *user) Get() gitprovider.UserInfo {
+	return userFromAPI(&u.u)
+}
+
+func (u *user) APIObject() interface{} {
+	return &u.u
+}
+
+func (u *user) User() gitprovider.UserRef {
+	return gitprovider.UserRef{
+		Domain:    u.host,
+		UserLogin: u.u.Name,
+	}
+}
+
+func userFromAPI(apiObj *User) gitprovider.UserInfo {
+	info := gitprovider.UserInfo{
+		Login: apiObj.Name,
+		Name:  apiObj.DisplayName,
+		Email: apiObj.EmailAddress,
+	}
+	if len(apiObj.Links.Self) > 0 {
+		info.WebURL = apiObj.Links.Self[0].Href
+	}
+	return info
+}
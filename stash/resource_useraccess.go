@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newUserAccess(c *UserAccessClient, ua gitprovider.UserAccessInfo, apiObj *RepositoryUserPermission) *userAccess {
+	return &userAccess{
+		ua:     ua,
+		apiObj: apiObj,
+		c:      c,
+	}
+}
+
+func userAccessFromAPI(c *UserAccessClient, apiObj *RepositoryUserPermission) (*userAccess, error) {
+	permission, err := getGitProviderPermission(stashPriority[apiObj.Permission])
+	if err != nil {
+		return nil, err
+	}
+	return newUserAccess(c, gitprovider.UserAccessInfo{
+		Login:      apiObj.User.Name,
+		Permission: permission,
+	}, apiObj), nil
+}
+
+var _ gitprovider.UserAccess = &userAccess{}
+
+type userAccess struct {
+	ua     gitprovider.UserAccessInfo
+	apiObj *RepositoryUserPermission
+	c      *UserAccessClient
+}
+
+func (ua *userAccess) Get() gitprovider.UserAccessInfo {
+	return ua.ua
+}
+
+func (ua *userAccess) Set(info gitprovider.UserAccessInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	ua.ua = info
+	return nil
+}
+
+func (ua *userAccess) APIObject() interface{} {
+	return ua.apiObj
+}
+
+func (ua *userAccess) Repository() gitprovider.RepositoryRef {
+	return ua.c.ref
+}
+
+// Invited always returns false, as Stash grants permissions to existing users directly.
+func (ua *userAccess) Invited() bool {
+	return false
+}
+
+// Delete revokes the repository permissions of the user.
+// ErrNotFound is returned if the resource does not exist.
+func (ua *userAccess) Delete(ctx context.Context) error {
+	projectKey, repoSlug := ua.c.stashRefs()
+	err := ua.c.client.Repositories.DeleteRepositoryUserPermission(ctx, projectKey, repoSlug, ua.ua.Login)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete repository user access: %w", err)
+	}
+	return nil
+}
+
+func (ua *userAccess) Update(ctx context.Context) error {
+	// Update the actual state to be the desired state
+	// by issuing a Create, which uses a PUT underneath.
+	resp, err := ua.c.Create(ctx, ua.Get())
+	if err != nil {
+		// Log the error and return it
+		ua.c.log.V(1).Error(err, "Error updating user access",
+			"org", ua.Repository().GetIdentity(),
+			"repo", ua.Repository().GetRepository())
+		return err
+	}
+	return ua.Set(resp.Get())
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (ua *userAccess) Reconcile(ctx context.Context) (bool, error) {
+	_, actionTaken, err := ua.c.Reconcile(ctx, ua.ua)
+
+	if err != nil {
+		// Log the error and return it
+		ua.c.log.V(1).Error(err, "Error reconciling user access",
+			"org", ua.Repository().GetIdentity(),
+			"repo", ua.Repository().GetRepository(),
+			"actionTaken", actionTaken)
+		return actionTaken, err
+	}
+
+	return actionTaken, nil
+}
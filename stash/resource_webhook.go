@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// webhookSecretKey is the configuration key holding the secret of a webhook.
+const webhookSecretKey = "secret"
+
+// stashPullRequestEvents are the events making up gitprovider.WebhookEventPullRequest.
+//nolint:gochecknoglobals
+var stashPullRequestEvents = []string{
+	WebhookEventPROpened,
+	WebhookEventPRFromRefUpdated,
+	WebhookEventPRModified,
+	WebhookEventPRMerged,
+	WebhookEventPRDeclined,
+	WebhookEventPRDeleted,
+}
+
+func newWebhook(c *WebhookClient, apiObj *Webhook) *webhook {
+	return &webhook{
+		w: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Webhook = &webhook{}
+
+type webhook struct {
+	w Webhook
+	c *WebhookClient
+}
+
+func (wh *webhook) Get() gitprovider.WebhookInfo {
+	return webhookFromAPI(&wh.w)
+}
+
+func (wh *webhook) Set(info gitprovider.WebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := validateWebhookInfo(info); err != nil {
+		return err
+	}
+	webhookInfoToAPIObj(&info, &wh.w)
+	return nil
+}
+
+func (wh *webhook) APIObject() interface{} {
+	return &wh.w
+}
+
+func (wh *webhook) Repository() gitprovider.RepositoryRef {
+	return wh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (wh *webhook) Update(ctx context.Context) error {
+	projectKey, repoSlug := getRepositoryRefs(wh.c.ref)
+	apiObj, err := wh.c.client.Webhooks.Update(ctx, projectKey, repoSlug, &wh.w)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to update webhook %s: %w", wh.w.URL, err)
+	}
+	wh.w = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the repository.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (wh *webhook) Delete(ctx context.Context) error {
+	projectKey, repoSlug := getRepositoryRefs(wh.c.ref)
+	if err := wh.c.client.Webhooks.Delete(ctx, projectKey, repoSlug, wh.w.ID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete webhook %s: %w", wh.w.URL, err)
+	}
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (wh *webhook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := wh.c.get(ctx, wh.w.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, wh.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if webhookFromAPI(&wh.w).Equals(webhookFromAPI(actual)) {
+		return false, nil
+	}
+	// If desired and actual state mis-match, update the actual webhook
+	wh.w.ID = actual.ID
+	return true, wh.Update(ctx)
+}
+
+func (wh *webhook) createIntoSelf(ctx context.Context) error {
+	projectKey, repoSlug := getRepositoryRefs(wh.c.ref)
+	apiObj, err := wh.c.client.Webhooks.Create(ctx, projectKey, repoSlug, &wh.w)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return fmt.Errorf("failed to create webhook %s: %w", wh.w.URL, err)
+	}
+	wh.w = *apiObj
+	return nil
+}
+
+func validateWebhookAPI(apiObj *Webhook) error {
+	return validateAPIObject("Stash.Webhook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// validateWebhookInfo returns ErrNoProviderSupport for the settings stash webhooks can't express.
+func validateWebhookInfo(info gitprovider.WebhookInfo) error {
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("stash webhooks always deliver json payloads: %w", gitprovider.ErrNoProviderSupport)
+	}
+	for _, event := range info.Events {
+		if event != gitprovider.WebhookEventPush && event != gitprovider.WebhookEventPullRequest {
+			return fmt.Errorf("stash doesn't support the %q webhook event: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	return nil
+}
+
+func webhookFromAPI(apiObj *Webhook) gitprovider.WebhookInfo {
+	var events []gitprovider.WebhookEvent
+	if containsString(apiObj.Events, WebhookEventRefsChanged) {
+		events = append(events, gitprovider.WebhookEventPush)
+	}
+	for _, event := range stashPullRequestEvents {
+		if containsString(apiObj.Events, event) {
+			events = append(events, gitprovider.WebhookEventPullRequest)
+			break
+		}
+	}
+	return gitprovider.WebhookInfo{
+		URL:                   apiObj.URL,
+		ContentType:           gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Events:                gitprovider.SortWebhookEvents(events),
+		InsecureSkipTLSVerify: gitprovider.BoolVar(!apiObj.SSLVerificationRequired),
+		Active:                gitprovider.BoolVar(apiObj.Active),
+	}
+}
+
+func webhookToAPI(info *gitprovider.WebhookInfo) *Webhook {
+	w := &Webhook{
+		// The name is required, use the URL as it identifies the webhook
+		Name: info.URL,
+	}
+	webhookInfoToAPIObj(info, w)
+	return w
+}
+
+func webhookInfoToAPIObj(info *gitprovider.WebhookInfo, apiObj *Webhook) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.URL = info.URL
+	// optional fields
+	if info.Secret != nil {
+		config := map[string]string{}
+		for k, v := range apiObj.Configuration {
+			config[k] = v
+		}
+		config[webhookSecretKey] = *info.Secret
+		apiObj.Configuration = config
+	}
+	if len(info.Events) != 0 {
+		apiObj.Events = nil
+		for _, event := range gitprovider.SortWebhookEvents(info.Events) {
+			switch event {
+			case gitprovider.WebhookEventPush:
+				apiObj.Events = append(apiObj.Events, WebhookEventRefsChanged)
+			case gitprovider.WebhookEventPullRequest:
+				apiObj.Events = append(apiObj.Events, stashPullRequestEvents...)
+			}
+		}
+	}
+	if info.InsecureSkipTLSVerify != nil {
+		apiObj.SSLVerificationRequired = !*info.InsecureSkipTLSVerify
+	}
+	if info.Active != nil {
+		apiObj.Active = *info.Active
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -18,7 +18,9 @@ package stash
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
 	"github.com/go-logr/logr"
@@ -50,6 +52,9 @@ func newClient(c *Client, host, token string, destructiveActions bool, logger lo
 		userRepos: &UserRepositoriesClient{
 			clientContext: ctx,
 		},
+		users: &UsersClient{
+			clientContext: ctx,
+		},
 	}
 }
 
@@ -59,6 +64,9 @@ type clientContext struct {
 	token              string
 	destructiveActions bool
 	log                logr.Logger
+	// gitClone is set if commits and branches are created by cloning the repository, see WithGitClone.
+	gitClone bool
+	signKey  *openpgp.Entity
 }
 
 // Client implements the gitprovider.Client interface.
@@ -71,6 +79,7 @@ type ProviderClient struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+	users     *UsersClient
 }
 
 // SupportedDomain returns the host endpoint for this client, e.g. "mystash.com:7990"
@@ -107,9 +116,73 @@ func (p *ProviderClient) UserRepositories() gitprovider.UserRepositoriesClient {
 	return p.userRepos
 }
 
+// Users returns the UsersClient looking up user accounts.
+func (p *ProviderClient) Users() gitprovider.UsersClient {
+	return p.users
+}
+
+// permissionProbe describes how a token permission is probed.
+type permissionProbe struct {
+	// projects is true if the permission is probed on projects instead of repositories.
+	projects bool
+	// required is the Stash permission granting the token permission.
+	required string
+	// baseline is the weakest Stash permission, used to tell if there is anything to probe on.
+	baseline string
+}
+
+//nolint:gochecknoglobals
+var permissionProbes = map[gitprovider.TokenPermission]permissionProbe{
+	gitprovider.TokenPermissionRWRepository:      {required: "REPO_WRITE", baseline: "REPO_READ"},
+	gitprovider.TokenPermissionAdminRepoHook:     {required: "REPO_ADMIN", baseline: "REPO_READ"},
+	gitprovider.TokenPermissionDeleteRepository:  {required: "REPO_ADMIN", baseline: "REPO_READ"},
+	gitprovider.TokenPermissionAdminOrganization: {projects: true, required: "PROJECT_ADMIN", baseline: "PROJECT_VIEW"},
+}
+
 // HasTokenPermission returns a boolean indicating whether the supplied token has the requested permission.
-func (p *ProviderClient) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
-	return false, gitprovider.ErrNoProviderSupport
+//
+// Stash doesn't expose the permissions of tokens, hence they are probed by listing the repositories
+// or projects the token has the required permission on. The token is considered to have the
+// permission if it has it on any of them. ErrTokenPermissionUnknown is returned if the token
+// can't access any repository or project to probe on.
+func (p *ProviderClient) HasTokenPermission(ctx context.Context, permission gitprovider.TokenPermission) (bool, error) {
+	probe, ok := permissionProbes[permission]
+	if !ok {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+
+	granted, err := p.probePermission(ctx, probe.projects, probe.required)
+	if err != nil || granted {
+		return granted, err
+	}
+
+	// Without anything visible to the token, a missing permission can't be told apart from nothing to probe on
+	visible, err := p.probePermission(ctx, probe.projects, probe.baseline)
+	if err != nil {
+		return false, err
+	}
+	if !visible {
+		return false, gitprovider.ErrTokenPermissionUnknown
+	}
+	return false, nil
+}
+
+// probePermission returns true if the token has the given permission on any project or repository.
+func (p *ProviderClient) probePermission(ctx context.Context, projects bool, permission string) (bool, error) {
+	opts := &PagingOptions{Limit: 1}
+	if projects {
+		list, err := p.client.Projects.ListByPermission(ctx, permission, opts)
+		if err != nil {
+			return false, fmt.Errorf("failed to list projects with permission %s: %w", permission, err)
+		}
+		return len(list.GetProjects()) > 0, nil
+	}
+
+	list, err := p.client.Repositories.ListByPermission(ctx, permission, opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to list repositories with permission %s: %w", permission, err)
+	}
+	return len(list.GetRepositories()) > 0, nil
 }
 
 // validateAPIObject creates a Validatior with the specified name, gives it to fn, and
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestHasTokenPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		repos      map[string]int
+		projects   map[string]int
+		permission gitprovider.TokenPermission
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "write access to a repository",
+			repos:      map[string]int{"REPO_READ": 2, "REPO_WRITE": 1},
+			permission: gitprovider.TokenPermissionRWRepository,
+			want:       true,
+		},
+		{
+			name:       "read-only access to repositories",
+			repos:      map[string]int{"REPO_READ": 2},
+			permission: gitprovider.TokenPermissionDeleteRepository,
+			want:       false,
+		},
+		{
+			name:       "no repositories to probe on",
+			permission: gitprovider.TokenPermissionAdminRepoHook,
+			wantErr:    gitprovider.ErrTokenPermissionUnknown,
+		},
+		{
+			name:       "project admin",
+			projects:   map[string]int{"PROJECT_VIEW": 1, "PROJECT_ADMIN": 1},
+			permission: gitprovider.TokenPermissionAdminOrganization,
+			want:       true,
+		},
+		{
+			name:       "packages",
+			permission: gitprovider.TokenPermissionWritePackages,
+			wantErr:    gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			listHandler := func(counts map[string]int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if limit := r.URL.Query().Get("limit"); limit != "1" {
+						t.Errorf("limit = %q, want 1", limit)
+					}
+					values := []map[string]string{}
+					for i := 0; i < counts[r.URL.Query().Get("permission")]; i++ {
+						values = append(values, map[string]string{"slug": fmt.Sprintf("item-%d", i)})
+					}
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(map[string]interface{}{"values": values})
+				}
+			}
+			mux.HandleFunc(stashURIprefix+"/repos", listHandler(tt.repos))
+			mux.HandleFunc(stashURIprefix+"/projects", listHandler(tt.projects))
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			c, err := NewStashClient("admin", "token", gitprovider.WithDomain(server.URL))
+			if err != nil {
+				t.Fatalf("NewStashClient returned error: %v", err)
+			}
+
+			got, err := c.HasTokenPermission(context.Background(), tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HasTokenPermission() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HasTokenPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	tagsURI     = "tags"
+	stashURIgit = "/rest/git/1.0"
+
+	// tagTypeAnnotated and tagTypeLightweight are the types of tags created through the git REST API.
+	tagTypeAnnotated   = "ANNOTATED"
+	tagTypeLightweight = "LIGHTWEIGHT"
+)
+
+// Tags interface defines the methods that can be used to
+// manage the tags of a repository.
+type Tags interface {
+	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*TagList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*Tag, error)
+	Get(ctx context.Context, projectKey, repositorySlug, name string) (*Tag, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, tag *CreateTag) (*Tag, error)
+	Delete(ctx context.Context, projectKey, repositorySlug, name string) error
+}
+
+// TagsService is a client for communicating with stash tags endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+type TagsService service
+
+// Tag represents a tag of a repository.
+type Tag struct {
+	// Session is the session object for the tag.
+	Session `json:"sessionInfo,omitempty"`
+	// DisplayID is the tag name e.g. v1.0.0.
+	DisplayID string `json:"displayId,omitempty"`
+	// ID is the tag reference e.g. refs/tags/v1.0.0.
+	ID string `json:"id,omitempty"`
+	// LatestChangeset is the commit the tag points to.
+	LatestChangeset string `json:"latestChangeset,omitempty"`
+	// LatestCommit is the commit the tag points to.
+	LatestCommit string `json:"latestCommit,omitempty"`
+	// Hash is the SHA of the tag object of annotated tags, and empty for lightweight tags.
+	Hash string `json:"hash,omitempty"`
+	// Type is the type of the reference, i.e. TAG.
+	Type string `json:"type,omitempty"`
+}
+
+// TagList is a list of tags.
+type TagList struct {
+	// Paging is the paging information.
+	Paging
+	// Tags is the list of tags.
+	Tags []*Tag `json:"values,omitempty"`
+}
+
+// GetTags returns the list of tags.
+func (t *TagList) GetTags() []*Tag {
+	return t.Tags
+}
+
+// CreateTag is the request body for creating a tag.
+type CreateTag struct {
+	// Name is the name of the tag e.g. v1.0.0.
+	Name string `json:"name"`
+	// StartPoint is the commit the tag points to.
+	StartPoint string `json:"startPoint"`
+	// Message is the message of an annotated tag.
+	Message string `json:"message,omitempty"`
+	// Type is either ANNOTATED or LIGHTWEIGHT.
+	Type string `json:"type"`
+}
+
+// List returns the list of tags.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a TagList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/tags".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *TagsService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*TagList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, tagsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list tags request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list tags failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	t := &TagList{}
+	if err := json.Unmarshal(res, t); err != nil {
+		return nil, fmt.Errorf("list tags for repository failed, unable to unmarshall tag json: %w", err)
+	}
+
+	for _, tag := range t.GetTags() {
+		tag.Session.set(resp)
+	}
+
+	return t, nil
+}
+
+// All retrieves all tags of a repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *TagsService) All(ctx context.Context, projectKey, repositorySlug string) ([]*Tag, error) {
+	t := []*Tag{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		t = append(t, list.GetTags()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Get retrieves the tag with the given name.
+// Get uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/tags/{name}".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *TagsService) Get(ctx context.Context, projectKey, repositorySlug, name string) (*Tag, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, tagsURI, url.PathEscape(name)))
+	if err != nil {
+		return nil, fmt.Errorf("get tag request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get tag failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	t := &Tag{}
+	if err := json.Unmarshal(res, t); err != nil {
+		return nil, fmt.Errorf("get tag for repository failed, unable to unmarshall tag json: %w", err)
+	}
+
+	t.Session.set(resp)
+	return t, nil
+}
+
+// Create creates a tag in a repository.
+// ErrAlreadyExists is returned if the tag exists already.
+// Create uses the endpoint "POST /rest/git/1.0/projects/{projectKey}/repos/{repositorySlug}/tags".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-git-rest.html
+func (s *TagsService) Create(ctx context.Context, projectKey, repositorySlug string, tag *CreateTag) (*Tag, error) {
+	body, err := marshallBody(tag)
+	header := http.Header{"Content-Type": []string{"application/json"}}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall tag: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newGitURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, tagsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create tag request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("create tag failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("create tag failed: %s", resp.Status)
+	}
+
+	t := &Tag{}
+	if err := json.Unmarshal(res, t); err != nil {
+		return nil, fmt.Errorf("create tag for repository failed, unable to unmarshall tag json: %w", err)
+	}
+
+	t.Session.set(resp)
+	return t, nil
+}
+
+// Delete deletes the tag with the given name.
+// Delete uses the endpoint "DELETE /rest/git/1.0/projects/{projectKey}/repos/{repositorySlug}/tags/{name}".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-git-rest.html
+func (s *TagsService) Delete(ctx context.Context, projectKey, repositorySlug, name string) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newGitURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, tagsURI, url.PathEscape(name)))
+	if err != nil {
+		return fmt.Errorf("delete tag request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete tag failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// newGitURI builds stash git URI
+func newGitURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIgit}, elements...), "/")
+}
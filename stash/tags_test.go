@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListTags(t *testing.T) {
+	tags := []*Tag{
+		{ID: "refs/tags/v1.0.0", DisplayID: "v1.0.0", LatestCommit: "abc"},
+		{ID: "refs/tags/v1.1.0", DisplayID: "v1.1.0", LatestCommit: "def", Hash: "123"},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, tagsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		l := struct {
+			Tags []*Tag `json:"values"`
+		}{tags}
+		json.NewEncoder(w).Encode(l)
+	})
+
+	ctx := context.Background()
+	list, err := client.Tags.List(ctx, "prj1", "repo1", nil)
+	if err != nil {
+		t.Fatalf("Tags.List returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(tags, list.Tags); diff != "" {
+		t.Errorf("Tags.List returned diff (want -> got):\n%s", diff)
+	}
+}
+
+func TestGetTag(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/v1.0.0", stashURIprefix, projectsURI, RepositoriesURI, tagsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&Tag{ID: "refs/tags/v1.0.0", DisplayID: "v1.0.0", LatestCommit: "abc"})
+	})
+
+	ctx := context.Background()
+	tag, err := client.Tags.Get(ctx, "prj1", "repo1", "v1.0.0")
+	if err != nil {
+		t.Fatalf("Tags.Get returned error: %v", err)
+	}
+	if tag.LatestCommit != "abc" {
+		t.Errorf("Tags.Get returned commit %s, want abc", tag.LatestCommit)
+	}
+
+	if _, err := client.Tags.Get(ctx, "prj1", "repo1", "v2.0.0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Tags.Get returned error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestCreateTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     *CreateTag
+		wantErr error
+	}{
+		{
+			name: "annotated tag",
+			tag:  &CreateTag{Name: "v1.0.0", StartPoint: "abc", Message: "release", Type: tagTypeAnnotated},
+		},
+		{
+			name:    "existing tag",
+			tag:     &CreateTag{Name: "v0.1.0", StartPoint: "abc", Type: tagTypeLightweight},
+			wantErr: ErrAlreadyExists,
+		},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIgit, projectsURI, RepositoriesURI, tagsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("Tags.Create used method %s, want %s", r.Method, http.MethodPost)
+		}
+		req := &CreateTag{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if req.Name == "v0.1.0" {
+			http.Error(w, "Tag v0.1.0 already exists", http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&Tag{ID: "refs/tags/" + req.Name, DisplayID: req.Name, LatestCommit: req.StartPoint})
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tag, err := client.Tags.Create(ctx, "prj1", "repo1", tt.tag)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Tags.Create returned error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Tags.Create returned error: %v", err)
+			}
+			if tag.DisplayID != tt.tag.Name {
+				t.Errorf("Tags.Create returned tag %s, want %s", tag.DisplayID, tt.tag.Name)
+			}
+		})
+	}
+}
@@ -40,6 +40,7 @@ var (
 type Users interface {
 	List(ctx context.Context, opts *PagingOptions) (*UserList, error)
 	Get(ctx context.Context, userName string) (*User, error)
+	GetAuthenticated(ctx context.Context) (*User, error)
 }
 
 // UsersService is a client for communicating with stash users endpoint
@@ -152,6 +153,32 @@ func (s *UsersService) Get(ctx context.Context, userSlug string) (*User, error)
 
 }
 
+// GetAuthenticated retrieves the user the client is authenticated as.
+// Stash has no endpoint for the current user, so the user name is read from the
+// X-AUSERNAME header of a minimal users list request before the user is retrieved.
+// ErrNotFound is returned if the request is anonymous.
+// GetAuthenticated uses the endpoints "GET /rest/api/1.0/users?limit=1" and "GET /rest/api/1.0/users/{userSlug}".
+func (s *UsersService) GetAuthenticated(ctx context.Context) (*User, error) {
+	query := addPaging(url.Values{}, &PagingOptions{Limit: 1})
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(usersURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("get authenticated user request creation failed, %w", err)
+	}
+
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get authenticated user failed, %w", err)
+	}
+
+	var session Session
+	session.set(resp)
+	if session.UserName == "" {
+		return nil, ErrNotFound
+	}
+
+	return s.Get(ctx, session.UserName)
+}
+
 // addPaging adds paging elements to URI query
 func addPaging(query url.Values, opts *PagingOptions) url.Values {
 	if query == nil {
@@ -146,3 +146,45 @@ func TestUserList(t *testing.T) {
 		t.Errorf("Users.List returned diff (want -> got):\n%s", diff)
 	}
 }
+
+func TestGetAuthenticatedUser(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc(fmt.Sprintf("%s/users", stashURIprefix), func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "1" {
+			t.Errorf("unexpected limit query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("X-Ausername", "jcitizen")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(struct {
+			Users []*User `json:"values"`
+		}{[]*User{}})
+	})
+	mux.HandleFunc(fmt.Sprintf("%s/users/jcitizen", stashURIprefix), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&User{Name: "jcitizen", Slug: "jcitizen", DisplayName: "John Citizen"})
+	})
+
+	user, err := client.Users.GetAuthenticated(context.Background())
+	if err != nil {
+		t.Fatalf("Users.GetAuthenticated returned error: %v", err)
+	}
+	if user.Slug != "jcitizen" || user.DisplayName != "John Citizen" {
+		t.Errorf("Users.GetAuthenticated returned user %+v", user)
+	}
+}
+
+func TestGetAuthenticatedUserAnonymous(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc(fmt.Sprintf("%s/users", stashURIprefix), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(struct {
+			Users []*User `json:"values"`
+		}{[]*User{}})
+	})
+
+	if _, err := client.Users.GetAuthenticated(context.Background()); err != ErrNotFound {
+		t.Errorf("Users.GetAuthenticated returned error %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	webhooksURI = "webhooks"
+)
+
+// Webhook events.
+const (
+	// WebhookEventRefsChanged is triggered by pushes to branches and tags.
+	WebhookEventRefsChanged = "repo:refs_changed"
+	// WebhookEventPROpened is triggered when a pull request is opened.
+	WebhookEventPROpened = "pr:opened"
+	// WebhookEventPRFromRefUpdated is triggered when the source branch of a pull request is updated.
+	WebhookEventPRFromRefUpdated = "pr:from_ref_updated"
+	// WebhookEventPRModified is triggered when the title, description or target of a pull request is changed.
+	WebhookEventPRModified = "pr:modified"
+	// WebhookEventPRMerged is triggered when a pull request is merged.
+	WebhookEventPRMerged = "pr:merged"
+	// WebhookEventPRDeclined is triggered when a pull request is declined.
+	WebhookEventPRDeclined = "pr:declined"
+	// WebhookEventPRDeleted is triggered when a pull request is deleted.
+	WebhookEventPRDeleted = "pr:deleted"
+)
+
+// Webhooks interface defines the methods that can be used to
+// manage the webhooks of a repository.
+type Webhooks interface {
+	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*WebhookList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*Webhook, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error)
+	Update(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error)
+	Delete(ctx context.Context, projectKey, repositorySlug string, webhookID int) error
+}
+
+// WebhooksService is a client for communicating with stash repository webhooks endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.21.0/bitbucket-rest.html#idp384
+type WebhooksService service
+
+// Webhook delivers a JSON payload to its URL when one of its events occurs.
+type Webhook struct {
+	// Session is the session object for the webhook.
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the id of the webhook.
+	ID int `json:"id,omitempty"`
+	// Name is the name of the webhook.
+	Name string `json:"name,omitempty"`
+	// URL is the address the payloads are delivered to.
+	URL string `json:"url,omitempty"`
+	// Events are the events triggering the webhook, e.g. repo:refs_changed.
+	Events []string `json:"events,omitempty"`
+	// Active is true if payloads are delivered.
+	Active bool `json:"active"`
+	// SSLVerificationRequired is true if the TLS certificate of the receiver is verified.
+	SSLVerificationRequired bool `json:"sslVerificationRequired"`
+	// Configuration holds the settings of the webhook, e.g. the secret used to sign the payloads.
+	Configuration map[string]string `json:"configuration,omitempty"`
+}
+
+// WebhookList is a list of webhooks.
+type WebhookList struct {
+	// Paging is the paging information.
+	Paging
+	// Webhooks is the list of webhooks.
+	Webhooks []*Webhook `json:"values,omitempty"`
+}
+
+// GetWebhooks returns the list of webhooks.
+func (w *WebhookList) GetWebhooks() []*Webhook {
+	return w.Webhooks
+}
+
+// List returns the list of webhooks of the repository.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a WebhookList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks".
+func (s *WebhooksService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*WebhookList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	w := &WebhookList{}
+	if err := json.Unmarshal(res, w); err != nil {
+		return nil, fmt.Errorf("list webhooks failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, webhook := range w.GetWebhooks() {
+		webhook.Session.set(resp)
+	}
+
+	return w, nil
+}
+
+// All retrieves all webhooks of a repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *WebhooksService) All(ctx context.Context, projectKey, repositorySlug string) ([]*Webhook, error) {
+	w := []*Webhook{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(opts, func() (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		w = append(w, list.GetWebhooks()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Create creates a webhook.
+// Create uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks".
+func (s *WebhooksService) Create(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall webhook: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("create webhook failed: %s", resp.Status)
+	}
+
+	w := &Webhook{}
+	if err := json.Unmarshal(res, w); err != nil {
+		return nil, fmt.Errorf("create webhook failed, unable to unmarshall json: %w", err)
+	}
+
+	w.Session.set(resp)
+
+	return w, nil
+}
+
+// Update updates the webhook with the given ID.
+// Update uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Update(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall webhook: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhook.ID)), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("update webhook failed: %s", resp.Status)
+	}
+
+	w := &Webhook{}
+	if err := json.Unmarshal(res, w); err != nil {
+		return nil, fmt.Errorf("update webhook failed, unable to unmarshall json: %w", err)
+	}
+
+	w.Session.set(resp)
+
+	return w, nil
+}
+
+// Delete deletes the webhook with the given ID.
+// Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Delete(ctx context.Context, projectKey, repositorySlug string, webhookID int) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhookID)))
+	if err != nil {
+		return fmt.Errorf("delete webhook request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
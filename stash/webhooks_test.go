@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestListWebhooks(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, webhooksURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"isLastPage": true, "values": [{
+			"id": 1,
+			"name": "flux",
+			"url": "https://flux.example.com/hook",
+			"events": ["repo:refs_changed", "pr:merged"],
+			"active": true,
+			"sslVerificationRequired": true
+		}]}`)
+	})
+
+	webhooks, err := client.Webhooks.All(context.Background(), "prj1", "repo1")
+	if err != nil {
+		t.Fatalf("Webhooks.All returned error: %v", err)
+	}
+	want := []*Webhook{{
+		ID:                      1,
+		Name:                    "flux",
+		URL:                     "https://flux.example.com/hook",
+		Events:                  []string{WebhookEventRefsChanged, WebhookEventPRMerged},
+		Active:                  true,
+		SSLVerificationRequired: true,
+	}}
+	if diff := cmp.Diff(want, webhooks, cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Session"
+	}, cmp.Ignore())); diff != "" {
+		t.Errorf("Webhooks.All mismatch (-want +got):\n%s", diff)
+	}
+
+	wantInfo := gitprovider.WebhookInfo{
+		URL:                   "https://flux.example.com/hook",
+		ContentType:           gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Events:                []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+		InsecureSkipTLSVerify: gitprovider.BoolVar(false),
+		Active:                gitprovider.BoolVar(true),
+	}
+	if diff := cmp.Diff(wantInfo, webhookFromAPI(webhooks[0])); diff != "" {
+		t.Errorf("webhookFromAPI mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateAndUpdateWebhook(t *testing.T) {
+	mux, client := setup(t)
+
+	handler := func(method string, id int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != method {
+				t.Fatalf("unexpected method %s", r.Method)
+			}
+			req := &Webhook{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if req.Configuration[webhookSecretKey] != "s3cr3t" {
+				http.Error(w, "missing secret", http.StatusBadRequest)
+				return
+			}
+			req.ID = id
+			req.Configuration = nil
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(req)
+		}
+	}
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, webhooksURI)
+	mux.HandleFunc(path, handler(http.MethodPost, 2))
+	mux.HandleFunc(path+"/2", handler(http.MethodPut, 2))
+
+	ctx := context.Background()
+	info := gitprovider.WebhookInfo{URL: "https://flux.example.com/hook", Secret: gitprovider.StringVar("s3cr3t")}
+	info.Default()
+	webhook, err := client.Webhooks.Create(ctx, "prj1", "repo1", webhookToAPI(&info))
+	if err != nil {
+		t.Fatalf("Webhooks.Create returned error: %v", err)
+	}
+	if webhook.ID != 2 || webhook.Name != info.URL || !webhook.Active {
+		t.Errorf("Webhooks.Create = %+v, want an active webhook named after its URL", webhook)
+	}
+	if diff := cmp.Diff([]string{WebhookEventRefsChanged}, webhook.Events); diff != "" {
+		t.Errorf("Events mismatch (-want +got):\n%s", diff)
+	}
+
+	webhook.Active = false
+	if _, err := client.Webhooks.Update(ctx, "prj1", "repo1", webhook); err == nil {
+		t.Error("Webhooks.Update without secret returned no error")
+	}
+	webhookInfoToAPIObj(&gitprovider.WebhookInfo{URL: info.URL, Secret: info.Secret}, webhook)
+	if webhook, err = client.Webhooks.Update(ctx, "prj1", "repo1", webhook); err != nil {
+		t.Fatalf("Webhooks.Update returned error: %v", err)
+	}
+	if webhook.Active {
+		t.Error("Webhooks.Update Active = true, want false")
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/3", stashURIprefix, projectsURI, RepositoriesURI, webhooksURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.Webhooks.Delete(ctx, "prj1", "repo1", 3); err != nil {
+		t.Fatalf("Webhooks.Delete returned error: %v", err)
+	}
+	if err := client.Webhooks.Delete(ctx, "prj1", "repo1", 4); err != ErrNotFound {
+		t.Errorf("Webhooks.Delete returned error %v, want %v", err, ErrNotFound)
+	}
+}